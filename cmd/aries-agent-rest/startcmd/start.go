@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/pkcs11crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
@@ -37,6 +38,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/pkcs11kms"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -77,6 +79,32 @@ const (
 	databaseTimeoutEnvKey  = "ARIESD_DATABASE_TIMEOUT"
 	databaseTimeoutDefault = "30"
 
+	// kms type flag.
+	kmsTypeFlagName  = "kms-type"
+	kmsTypeEnvKey    = "ARIESD_KMS_TYPE"
+	kmsTypeFlagUsage = "The type of KMS and crypto to use for keys. Supported options: local, pkcs11. Default: local." +
+		" The pkcs11 KMS supports the ecdsap256der, ecdsap256ieee1363, ecdsap384der and ecdsap384ieee1363 key types" +
+		" and the p256kw and p384kw key agreement types, which must then be set as well." +
+		" Alternatively, this can be set with the following environment variable: " + kmsTypeEnvKey
+
+	// pkcs11 library flag.
+	pkcs11LibFlagName  = "pkcs11-lib"
+	pkcs11LibEnvKey    = "ARIESD_PKCS11_LIB"
+	pkcs11LibFlagUsage = "Path of the PKCS#11 library of the token, required for the pkcs11 KMS type." +
+		" Alternatively, this can be set with the following environment variable: " + pkcs11LibEnvKey
+
+	// pkcs11 token label flag.
+	pkcs11TokenLabelFlagName  = "pkcs11-token-label"
+	pkcs11TokenLabelEnvKey    = "ARIESD_PKCS11_TOKEN_LABEL"
+	pkcs11TokenLabelFlagUsage = "Label of the PKCS#11 token, required for the pkcs11 KMS type." +
+		" Alternatively, this can be set with the following environment variable: " + pkcs11TokenLabelEnvKey
+
+	// pkcs11 pin flag.
+	pkcs11PINFlagName  = "pkcs11-pin"
+	pkcs11PINEnvKey    = "ARIESD_PKCS11_PIN"
+	pkcs11PINFlagUsage = "User PIN of the PKCS#11 token, required for the pkcs11 KMS type." +
+		" Alternatively, this can be set with the following environment variable: " + pkcs11PINEnvKey
+
 	// webhook url flag.
 	agentWebhookFlagName      = "webhook-url"
 	agentWebhookEnvKey        = "ARIESD_WEBHOOK_URL"
@@ -216,6 +244,9 @@ const (
 	databaseTypeMongoDBOption    = "mongodb"
 	databaseTypeMySQLOption      = "mysql"
 	databaseTypePostgreSQLOption = "postgresql"
+
+	kmsTypeLocalOption  = "local"
+	kmsTypePKCS11Option = "pkcs11"
 )
 
 var (
@@ -255,6 +286,7 @@ type AgentParameters struct {
 	autoAccept                                     bool
 	msgHandler                                     command.MessageHandler
 	dbParam                                        *dbParam
+	kmsParam                                       *kmsParam
 	autoExecuteRFC0593                             bool
 }
 
@@ -264,6 +296,13 @@ type dbParam struct {
 	timeout uint64
 }
 
+type kmsParam struct {
+	kmsType    string
+	lib        string
+	tokenLabel string
+	pin        string
+}
+
 // nolint:gochecknoglobals
 var supportedStorageProviders = map[string]func(prefix string) (storage.Provider, error){
 	databaseTypeMemOption: func(_ string) (storage.Provider, error) { // nolint:unparam
@@ -422,6 +461,11 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		return nil, err
 	}
 
+	kmsParam, err := getKMSParam(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	parameters := &AgentParameters{
 		server:               server,
 		host:                 host,
@@ -430,6 +474,7 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		inboundHostExternals: inboundHostExternals,
 		websocketReadLimit:   websocketReadLimit,
 		dbParam:              dbParam,
+		kmsParam:             kmsParam,
 		defaultLabel:         defaultLabel,
 		webhookURLs:          webhookURLs,
 		httpResolvers:        httpResolvers,
@@ -497,6 +542,38 @@ func getDBParam(cmd *cobra.Command) (*dbParam, error) {
 	return dbParam, nil
 }
 
+func getKMSParam(cmd *cobra.Command) (*kmsParam, error) {
+	kmsParam := &kmsParam{}
+
+	var err error
+
+	kmsParam.kmsType, err = getUserSetVar(cmd, kmsTypeFlagName, kmsTypeEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if kmsParam.kmsType != kmsTypePKCS11Option {
+		return kmsParam, nil
+	}
+
+	kmsParam.lib, err = getUserSetVar(cmd, pkcs11LibFlagName, pkcs11LibEnvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	kmsParam.tokenLabel, err = getUserSetVar(cmd, pkcs11TokenLabelFlagName, pkcs11TokenLabelEnvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	kmsParam.pin, err = getUserSetVar(cmd, pkcs11PINFlagName, pkcs11PINEnvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return kmsParam, nil
+}
+
 func getAutoAcceptValue(cmd *cobra.Command) (bool, error) {
 	v, err := getUserSetVar(cmd, agentAutoAcceptFlagName, agentAutoAcceptEnvKey, true)
 	if err != nil {
@@ -613,6 +690,14 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(agentKeyAgreementTypeFlagName, "", "", agentKeyAgreementTypeUsage)
 
 	startCmd.Flags().StringSliceP(agentMediaTypeProfilesFlagName, "", []string{}, agentMediaTypeProfilesUsage)
+
+	// kms type
+	startCmd.Flags().StringP(kmsTypeFlagName, "", "", kmsTypeFlagUsage)
+
+	// pkcs11 token
+	startCmd.Flags().StringP(pkcs11LibFlagName, "", "", pkcs11LibFlagUsage)
+	startCmd.Flags().StringP(pkcs11TokenLabelFlagName, "", "", pkcs11TokenLabelFlagUsage)
+	startCmd.Flags().StringP(pkcs11PINFlagName, "", "", pkcs11PINFlagUsage)
 }
 
 func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
@@ -878,6 +963,14 @@ func createAriesAgent(parameters *AgentParameters) (*context.Provider, error) {
 
 	opts = append(opts, aries.WithStoreProvider(storePro))
 
+	kmsOpts, err := getKMSOpts(parameters.kmsParam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to kms opts : %w",
+			parameters.host, err)
+	}
+
+	opts = append(opts, kmsOpts...)
+
 	if parameters.transportReturnRoute != "" {
 		opts = append(opts, aries.WithTransportReturnRoute(parameters.transportReturnRoute))
 	}
@@ -940,6 +1033,34 @@ func createAriesAgent(parameters *AgentParameters) (*context.Provider, error) {
 	return ctx, nil
 }
 
+func getKMSOpts(kmsParam *kmsParam) ([]aries.Option, error) {
+	if kmsParam == nil || kmsParam.kmsType == "" || kmsParam.kmsType == kmsTypeLocalOption {
+		return nil, nil
+	}
+
+	if kmsParam.kmsType != kmsTypePKCS11Option {
+		return nil, fmt.Errorf("kms type '%s' not supported, run start --help to see the available options",
+			kmsParam.kmsType)
+	}
+
+	km, err := pkcs11kms.New(kmsParam.lib, kmsParam.tokenLabel, kmsParam.pin)
+	if err != nil {
+		return nil, err
+	}
+
+	cr, err := pkcs11crypto.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return []aries.Option{
+		aries.WithKMS(func(kms.Provider) (kms.KeyManager, error) {
+			return km, nil
+		}),
+		aries.WithCrypto(cr),
+	}, nil
+}
+
 func createStoreProviders(parameters *AgentParameters) (storage.Provider, error) {
 	provider, supported := supportedStorageProviders[parameters.dbParam.dbType]
 	if !supported {
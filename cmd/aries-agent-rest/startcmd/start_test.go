@@ -899,6 +899,57 @@ func TestStoreProvider(t *testing.T) {
 	})
 }
 
+func TestKMSType(t *testing.T) {
+	t.Run("test local kms type", func(t *testing.T) {
+		_, err := createAriesAgent(&AgentParameters{
+			dbParam:  &dbParam{dbType: databaseTypeMemOption},
+			kmsParam: &kmsParam{kmsType: kmsTypeLocalOption},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("test invalid kms type", func(t *testing.T) {
+		_, err := createAriesAgent(&AgentParameters{
+			dbParam:  &dbParam{dbType: databaseTypeMemOption},
+			kmsParam: &kmsParam{kmsType: "hsm"},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kms type 'hsm' not supported")
+	})
+
+	t.Run("test pkcs11 kms type with invalid library", func(t *testing.T) {
+		_, err := createAriesAgent(&AgentParameters{
+			dbParam: &dbParam{dbType: databaseTypeMemOption},
+			kmsParam: &kmsParam{
+				kmsType:    kmsTypePKCS11Option,
+				lib:        "/does/not/exist.so",
+				tokenLabel: "aries",
+				pin:        "1234",
+			},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to load PKCS#11 library '/does/not/exist.so'")
+	})
+
+	t.Run("test pkcs11 kms type without token label", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + agentInboundHostFlagName, httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + agentWebhookFlagName, "",
+			"--" + kmsTypeFlagName, kmsTypePKCS11Option,
+			"--" + pkcs11LibFlagName, "/does/not/exist.so",
+		})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Neither pkcs11-token-label (command line flag) nor ARIESD_PKCS11_TOKEN_LABEL")
+	})
+}
+
 func TestStartCmdInvalidAutoExecuteRFC0593Value(t *testing.T) {
 	startCmd, err := Cmd(&mockServer{})
 	require.NoError(t, err)
@@ -994,6 +1045,18 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	os.Setenv(agentMediaTypeProfilesEnvKey, "agentMediaTypeProfiles")
 	defer os.Unsetenv(agentMediaTypeProfilesEnvKey)
 
+	os.Setenv(kmsTypeEnvKey, kmsTypePKCS11Option)
+	defer os.Unsetenv(kmsTypeEnvKey)
+
+	os.Setenv(pkcs11LibEnvKey, "pkcs11Lib")
+	defer os.Unsetenv(pkcs11LibEnvKey)
+
+	os.Setenv(pkcs11TokenLabelEnvKey, "pkcs11TokenLabel")
+	defer os.Unsetenv(pkcs11TokenLabelEnvKey)
+
+	os.Setenv(pkcs11PINEnvKey, "pkcs11PIN")
+	defer os.Unsetenv(pkcs11PINEnvKey)
+
 	parameters, err := NewAgentParameters(&mockServer{}, nil)
 
 	require.Nil(t, err)
@@ -1018,6 +1081,10 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	require.Equal(t, "agentKeyType", parameters.keyType)
 	require.Equal(t, "agentKeyAgreementType", parameters.keyAgreementType)
 	require.Equal(t, "agentMediaTypeProfiles", parameters.mediaTypeProfiles[0])
+	require.Equal(t, kmsTypePKCS11Option, parameters.kmsParam.kmsType)
+	require.Equal(t, "pkcs11Lib", parameters.kmsParam.lib)
+	require.Equal(t, "pkcs11TokenLabel", parameters.kmsParam.tokenLabel)
+	require.Equal(t, "pkcs11PIN", parameters.kmsParam.pin)
 }
 
 func waitForServerToStart(t *testing.T, host, inboundHost string) {
@@ -130,6 +130,10 @@ const pkgs = {
             path: "/mediator/reconnect-all",
             method: "GET",
         },
+        Keylist: {
+            path: "/mediator/keylist",
+            method: "POST"
+        },
     },
     verifiable: {
         ValidateCredential: {
@@ -541,6 +545,14 @@ const pkgs = {
             path: "/vcwallet/resolve-credential-manifest",
             method: "POST",
         },
+        Export: {
+            path: "/vcwallet/export",
+            method: "POST",
+        },
+        Import: {
+            path: "/vcwallet/import",
+            method: "POST",
+        },
     },
     ld: {
         AddContexts: {
@@ -1037,6 +1037,16 @@ const Aries = function (opts) {
             reconnectAll: async function () {
                 return invoke(aw, pending, this.pkgname, "ReconnectAll", {}, "timeout while reconnecting to mediator")
             },
+
+            /**
+             * keylist returns the keys the router routes for given connection.
+             *
+             * @param req - json document containing connection ID and optional limit and offset
+             * @returns {Promise<Object>}
+             */
+            keylist: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Keylist", req, "timeout while querying keylist from router")
+            },
         },
 
         /**
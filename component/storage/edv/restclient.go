@@ -8,6 +8,7 @@ package edv
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -39,12 +40,12 @@ type restClient struct {
 	headersFunc  addHeaders
 }
 
-func (c *restClient) createDocument(vaultID string, docBytes []byte) (string, error) {
+func (c *restClient) createDocument(ctx context.Context, vaultID string, docBytes []byte) (string, error) {
 	logger.Debugf(`Sending request to vault with ID "%s" to create the following document: %s`, docBytes)
 
 	endpoint := fmt.Sprintf("%s/%s/documents", c.edvServerURL, vaultID)
 
-	statusCode, hdr, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, docBytes, c.headersFunc)
+	statusCode, hdr, respBytes, err := c.sendHTTPRequest(ctx, http.MethodPost, endpoint, docBytes, c.headersFunc)
 	if err != nil {
 		return "", fmt.Errorf(failSendPOSTRequest, err)
 	}
@@ -56,13 +57,13 @@ func (c *restClient) createDocument(vaultID string, docBytes []byte) (string, er
 	return "", fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
 }
 
-func (c *restClient) updateDocument(vaultID, docID string, docBytes []byte) error {
+func (c *restClient) updateDocument(ctx context.Context, vaultID, docID string, docBytes []byte) error {
 	endpoint := fmt.Sprintf("%s/%s/documents/%s", c.edvServerURL, url.PathEscape(vaultID), url.PathEscape(docID))
 
 	logger.Debugf(`Sending request to vault with ID "%s" to update a document with ID "%s". `+
 		`Document contents: %s`, vaultID, docID, docBytes)
 
-	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, docBytes, c.headersFunc)
+	statusCode, _, respBytes, err := c.sendHTTPRequest(ctx, http.MethodPost, endpoint, docBytes, c.headersFunc)
 	if err != nil {
 		return fmt.Errorf(failSendPOSTRequest, err)
 	}
@@ -75,10 +76,10 @@ func (c *restClient) updateDocument(vaultID, docID string, docBytes []byte) erro
 	return fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
 }
 
-func (c *restClient) readDocument(vaultID, docID string) ([]byte, error) {
+func (c *restClient) readDocument(ctx context.Context, vaultID, docID string) ([]byte, error) {
 	endpoint := fmt.Sprintf("%s/%s/documents/%s", c.edvServerURL, url.PathEscape(vaultID), url.PathEscape(docID))
 
-	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodGet, endpoint, nil, c.headersFunc)
+	statusCode, _, respBytes, err := c.sendHTTPRequest(ctx, http.MethodGet, endpoint, nil, c.headersFunc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send GET request: %w", err)
 	}
@@ -98,7 +99,7 @@ func (c *restClient) readDocument(vaultID, docID string) ([]byte, error) {
 // then it acts as a wildcard, where any tag value for the associated tag name will match.
 // If query.ReturnFullDocuments is false, then only the document locations will be returned via the first return value.
 // If query.ReturnFullDocuments is true, then the full documents will be returned via the second return value.
-func (c *restClient) query(vaultID string, edvQuery query) ([]string, []encryptedDocument, error) {
+func (c *restClient) query(ctx context.Context, vaultID string, edvQuery query) ([]string, []encryptedDocument, error) {
 	jsonToSend, err := json.Marshal(edvQuery)
 	if err != nil {
 		return nil, nil, err
@@ -106,7 +107,7 @@ func (c *restClient) query(vaultID string, edvQuery query) ([]string, []encrypte
 
 	endpoint := fmt.Sprintf("%s/%s/query", c.edvServerURL, url.PathEscape(vaultID))
 
-	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, jsonToSend, c.headersFunc)
+	statusCode, _, respBytes, err := c.sendHTTPRequest(ctx, http.MethodPost, endpoint, jsonToSend, c.headersFunc)
 	if err != nil {
 		return nil, nil, fmt.Errorf(failSendPOSTRequest, err)
 	}
@@ -136,7 +137,7 @@ func (c *restClient) query(vaultID string, edvQuery query) ([]string, []encrypte
 	return nil, nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
 }
 
-func (c *restClient) batch(vaultID string, vaultOperations []vaultOperation) error {
+func (c *restClient) batch(ctx context.Context, vaultID string, vaultOperations []vaultOperation) error {
 	jsonToSend, err := json.Marshal(vaultOperations)
 	if err != nil {
 		return fmt.Errorf("failed to marshal vault operations: %w", err)
@@ -144,7 +145,7 @@ func (c *restClient) batch(vaultID string, vaultOperations []vaultOperation) err
 
 	endpoint := fmt.Sprintf("%s/%s/batch", c.edvServerURL, url.PathEscape(vaultID))
 
-	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, jsonToSend, c.headersFunc)
+	statusCode, _, respBytes, err := c.sendHTTPRequest(ctx, http.MethodPost, endpoint, jsonToSend, c.headersFunc)
 	if err != nil {
 		return fmt.Errorf(failSendPOSTRequest, err)
 	}
@@ -156,10 +157,10 @@ func (c *restClient) batch(vaultID string, vaultOperations []vaultOperation) err
 	return fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
 }
 
-func (c *restClient) deleteDocument(vaultID, docID string) error {
+func (c *restClient) deleteDocument(ctx context.Context, vaultID, docID string) error {
 	endpoint := fmt.Sprintf("%s/%s/documents/%s", c.edvServerURL, url.PathEscape(vaultID), url.PathEscape(docID))
 
-	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodDelete, endpoint, nil, c.headersFunc)
+	statusCode, _, respBytes, err := c.sendHTTPRequest(ctx, http.MethodDelete, endpoint, nil, c.headersFunc)
 	if err != nil {
 		return err
 	}
@@ -174,19 +175,19 @@ func (c *restClient) deleteDocument(vaultID, docID string) error {
 	return fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
 }
 
-func (c *restClient) sendHTTPRequest(method, endpoint string, body []byte,
+func (c *restClient) sendHTTPRequest(ctx context.Context, method, endpoint string, body []byte,
 	addHeadersFunc addHeaders) (int, http.Header, []byte, error) {
 	var req *http.Request
 
 	var err error
 
 	if len(body) == 0 {
-		req, err = http.NewRequest(method, endpoint, nil)
+		req, err = http.NewRequestWithContext(ctx, method, endpoint, nil)
 		if err != nil {
 			return -1, nil, nil, fmt.Errorf(failCreateRequest, err)
 		}
 	} else {
-		req, err = http.NewRequest(method, endpoint, bytes.NewBuffer(body))
+		req, err = http.NewRequestWithContext(ctx, method, endpoint, bytes.NewBuffer(body))
 		if err != nil {
 			return -1, nil, nil, fmt.Errorf(failCreateRequest, err)
 		}
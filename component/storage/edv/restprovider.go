@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package edv
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -222,18 +223,23 @@ type restStore struct {
 
 // Put stores data into an EDV server.
 func (r *restStore) Put(key string, value []byte, tags ...spi.Tag) error {
+	return r.PutContext(context.Background(), key, value, tags...)
+}
+
+// PutContext is Put honouring ctx cancellation and deadline for the EDV server requests.
+func (r *restStore) PutContext(ctx context.Context, key string, value []byte, tags ...spi.Tag) error {
 	errInputValidation := validatePutInput(key, value, tags)
 	if errInputValidation != nil {
 		return errInputValidation
 	}
 
 	if r.formatter.UsesDeterministicKeyFormatting() {
-		err := r.putUsingDeterministicDocumentID(key, value, tags)
+		err := r.putUsingDeterministicDocumentID(ctx, key, value, tags)
 		if err != nil {
 			return fmt.Errorf("failed to store data using a deterministic document ID: %w", err)
 		}
 	} else {
-		err := r.appendKeyTagThenLockAndPutUsingRandomDocumentID(key, value, tags)
+		err := r.appendKeyTagThenLockAndPutUsingRandomDocumentID(ctx, key, value, tags)
 		if err != nil {
 			return fmt.Errorf("failed to store data using a random document ID: %w", err)
 		}
@@ -243,6 +249,10 @@ func (r *restStore) Put(key string, value []byte, tags ...spi.Tag) error {
 }
 
 func (r *restStore) Get(key string) ([]byte, error) {
+	return r.GetContext(context.Background(), key)
+}
+
+func (r *restStore) GetContext(ctx context.Context, key string) ([]byte, error) {
 	if key == "" {
 		return nil, errEmptyKey
 	}
@@ -252,13 +262,13 @@ func (r *restStore) Get(key string) ([]byte, error) {
 	var err error
 
 	if r.formatter.UsesDeterministicKeyFormatting() {
-		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderDeterministicID(key)
+		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderDeterministicID(ctx, key)
 		if err != nil {
 			return nil,
 				fmt.Errorf("failed to get encrypted document stored under a deterministic document ID: %w", err)
 		}
 	} else {
-		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderRandomID(key)
+		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderRandomID(ctx, key)
 		if err != nil {
 			return nil,
 				fmt.Errorf("failed to get encrypted document stored under a randomly-generated ID: %w", err)
@@ -274,6 +284,10 @@ func (r *restStore) Get(key string) ([]byte, error) {
 }
 
 func (r *restStore) GetTags(key string) ([]spi.Tag, error) {
+	return r.GetTagsContext(context.Background(), key)
+}
+
+func (r *restStore) GetTagsContext(ctx context.Context, key string) ([]spi.Tag, error) {
 	if key == "" {
 		return nil, errEmptyKey
 	}
@@ -283,13 +297,13 @@ func (r *restStore) GetTags(key string) ([]spi.Tag, error) {
 	var err error
 
 	if r.formatter.UsesDeterministicKeyFormatting() {
-		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderDeterministicID(key)
+		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderDeterministicID(ctx, key)
 		if err != nil {
 			return nil,
 				fmt.Errorf("failed to get encrypted document stored under a deterministic document ID: %w", err)
 		}
 	} else {
-		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderRandomID(key)
+		encryptedDocumentBytes, err = r.getEncryptedDocumentStoredUnderRandomID(ctx, key)
 		if err != nil {
 			return nil,
 				fmt.Errorf("failed to get encrypted document stored under a randomly-generated ID: %w", err)
@@ -312,6 +326,10 @@ func (r *restStore) GetTags(key string) ([]spi.Tag, error) {
 // key. A more efficient way to get documents in bulk is to use tags and querying with the "return full documents
 // on query" extension enabled, which is non-standard (as of writing).
 func (r *restStore) GetBulk(keys ...string) ([][]byte, error) {
+	return r.GetBulkContext(context.Background(), keys...)
+}
+
+func (r *restStore) GetBulkContext(ctx context.Context, keys ...string) ([][]byte, error) {
 	if len(keys) == 0 {
 		return nil, errors.New("keys slice must contain at least one key")
 	}
@@ -324,7 +342,7 @@ func (r *restStore) GetBulk(keys ...string) ([][]byte, error) {
 	for i, key := range keys {
 		var err error
 
-		values[i], err = r.Get(key)
+		values[i], err = r.GetContext(ctx, key)
 		if err != nil && !errors.Is(err, spi.ErrDataNotFound) {
 			return nil, fmt.Errorf(`unexpected failure while getting value for key "%s": %w`, key, err)
 		}
@@ -346,6 +364,13 @@ func (r *restStore) GetBulk(keys ...string) ([][]byte, error) {
 // spi.WithInitialPageNum and spi.WithSortOrder will result in an error being returned since those options do
 // affect the results that the Iterator returns.
 func (r *restStore) Query(expression string, options ...spi.QueryOption) (spi.Iterator, error) {
+	return r.QueryContext(context.Background(), expression, options...)
+}
+
+// QueryContext is Query honouring ctx cancellation and deadline. The returned Iterator keeps using ctx for the
+// documents it fetches.
+func (r *restStore) QueryContext(ctx context.Context, expression string,
+	options ...spi.QueryOption) (spi.Iterator, error) {
 	err := checkForUnsupportedQueryOptions(options)
 	if err != nil {
 		return nil, err
@@ -356,21 +381,25 @@ func (r *restStore) Query(expression string, options ...spi.QueryOption) (spi.It
 		return nil, err
 	}
 
-	return r.query(edvQuery)
+	return r.query(ctx, edvQuery)
 }
 
 func (r *restStore) Delete(key string) error {
+	return r.DeleteContext(context.Background(), key)
+}
+
+func (r *restStore) DeleteContext(ctx context.Context, key string) error {
 	if key == "" {
 		return errEmptyKey
 	}
 
 	if r.formatter.UsesDeterministicKeyFormatting() {
-		err := r.deleteDocumentUsingDeterministicID(key)
+		err := r.deleteDocumentUsingDeterministicID(ctx, key)
 		if err != nil {
 			return fmt.Errorf("failed to delete document using deterministic ID: %w", err)
 		}
 	} else {
-		err := r.lockAndDeleteDocumentStoredUnderRandomID(key)
+		err := r.lockAndDeleteDocumentStoredUnderRandomID(ctx, key)
 		if err != nil {
 			return fmt.Errorf("failed to delete document using random ID: %w", err)
 		}
@@ -381,6 +410,10 @@ func (r *restStore) Delete(key string) error {
 
 // TODO (#2494): Return a spi.MultiError from here in the case of a failure.
 func (r *restStore) Batch(operations []spi.Operation) error {
+	return r.BatchContext(context.Background(), operations)
+}
+
+func (r *restStore) BatchContext(ctx context.Context, operations []spi.Operation) error {
 	if len(operations) == 0 {
 		return errors.New("batch requires at least one operation")
 	}
@@ -392,14 +425,14 @@ func (r *restStore) Batch(operations []spi.Operation) error {
 	}
 
 	if r.batchEndpointExtensionEnabled {
-		err := r.fastBatchUsingBatchExtension(operations)
+		err := r.fastBatchUsingBatchExtension(ctx, operations)
 		if err != nil {
 			return fmt.Errorf("failed to batch using batch extension: %w", err)
 		}
 	} else {
 		// If the batch extension hasn't been enabled, we will have to emulate the behaviour using the
 		// standard endpoints, which will be slower.
-		err := r.slowBatchUsingStandardEndpoints(operations)
+		err := r.slowBatchUsingStandardEndpoints(ctx, operations)
 		if err != nil {
 			return fmt.Errorf("failed to batch using standard endpoints: %w", err)
 		}
@@ -419,17 +452,18 @@ func (r *restStore) Flush() error {
 	return nil
 }
 
-func (r *restStore) putUsingDeterministicDocumentID(key string, value []byte, tags []spi.Tag) error {
+func (r *restStore) putUsingDeterministicDocumentID(ctx context.Context,
+	key string, value []byte, tags []spi.Tag) error {
 	// If the batch endpoint extension is enabled, we can avoid the need to read the document first since the
 	// batch endpoint does upserts instead of explicit creates and updates.
 	if r.batchEndpointExtensionEnabled {
-		err := r.storeUsingDeterministicDocumentIDAndBatchEndpoint(key, value, tags)
+		err := r.storeUsingDeterministicDocumentIDAndBatchEndpoint(ctx, key, value, tags)
 		if err != nil {
 			return fmt.Errorf("failed to store document using "+
 				"deterministic ID and batch endpoint: %w", err)
 		}
 	} else {
-		err := r.storeUsingDeterministicDocumentIDAndStandardEndpoints(key, value, tags)
+		err := r.storeUsingDeterministicDocumentIDAndStandardEndpoints(ctx, key, value, tags)
 		if err != nil {
 			return fmt.Errorf("failed to store document using random document ID and "+
 				"standard endpoints: %w", err)
@@ -439,16 +473,18 @@ func (r *restStore) putUsingDeterministicDocumentID(key string, value []byte, ta
 	return nil
 }
 
-func (r *restStore) appendKeyTagThenLockAndPutUsingRandomDocumentID(key string, value []byte, tags []spi.Tag) error {
+func (r *restStore) appendKeyTagThenLockAndPutUsingRandomDocumentID(ctx context.Context,
+	key string, value []byte, tags []spi.Tag) error {
 	tags = append(tags, spi.Tag{Value: key})
 
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	return r.putUsingRandomDocumentID(key, value, tags)
+	return r.putUsingRandomDocumentID(ctx, key, value, tags)
 }
 
-func (r *restStore) storeUsingDeterministicDocumentIDAndBatchEndpoint(key string, value []byte, tags []spi.Tag) error {
+func (r *restStore) storeUsingDeterministicDocumentIDAndBatchEndpoint(ctx context.Context,
+	key string, value []byte, tags []spi.Tag) error {
 	encryptedDocumentID, encryptedDocumentBytes, _, err :=
 		r.formatter.format(r.name, key, value, tags...)
 	if err != nil {
@@ -456,7 +492,7 @@ func (r *restStore) storeUsingDeterministicDocumentIDAndBatchEndpoint(key string
 			"encrypted document bytes: %w", err)
 	}
 
-	err = r.restClient.batch(r.vaultID, []vaultOperation{{
+	err = r.restClient.batch(ctx, r.vaultID, []vaultOperation{{
 		Operation:         upsertDocumentVaultOperation,
 		DocumentID:        encryptedDocumentID,
 		EncryptedDocument: encryptedDocumentBytes,
@@ -469,14 +505,14 @@ func (r *restStore) storeUsingDeterministicDocumentIDAndBatchEndpoint(key string
 	return nil
 }
 
-func (r *restStore) storeUsingDeterministicDocumentIDAndStandardEndpoints(
+func (r *restStore) storeUsingDeterministicDocumentIDAndStandardEndpoints(ctx context.Context,
 	key string, value []byte, tags []spi.Tag) error {
 	documentID, err := r.formatter.generateDeterministicDocumentID(r.name, key)
 	if err != nil {
 		return fmt.Errorf("failed to generate the encrypted document ID: %w", err)
 	}
 
-	err = r.createOrUpdateDocumentBasedOnDeterministicDocumentID(key, documentID, value, tags)
+	err = r.createOrUpdateDocumentBasedOnDeterministicDocumentID(ctx, key, documentID, value, tags)
 	if err != nil {
 		return fmt.Errorf("failed to create or update document based on document ID: %w", err)
 	}
@@ -484,12 +520,12 @@ func (r *restStore) storeUsingDeterministicDocumentIDAndStandardEndpoints(
 	return nil
 }
 
-func (r *restStore) createOrUpdateDocumentBasedOnDeterministicDocumentID(
+func (r *restStore) createOrUpdateDocumentBasedOnDeterministicDocumentID(ctx context.Context,
 	key, documentID string, value []byte, tags []spi.Tag) error {
-	_, err := r.restClient.readDocument(r.vaultID, documentID)
+	_, err := r.restClient.readDocument(ctx, r.vaultID, documentID)
 	if err != nil {
 		if errors.Is(err, spi.ErrDataNotFound) {
-			err = r.formatTagsThenCreateDocument(key, documentID, value, tags)
+			err = r.formatTagsThenCreateDocument(ctx, key, documentID, value, tags)
 			if err != nil {
 				return fmt.Errorf("failed to format tags then create document: %w", err)
 			}
@@ -499,7 +535,7 @@ func (r *restStore) createOrUpdateDocumentBasedOnDeterministicDocumentID(
 		}
 	}
 
-	err = r.updateDocument(key, documentID, value, tags)
+	err = r.updateDocument(ctx, key, documentID, value, tags)
 	if err != nil {
 		return fmt.Errorf("failed to update document: %w", err)
 	}
@@ -507,7 +543,7 @@ func (r *restStore) createOrUpdateDocumentBasedOnDeterministicDocumentID(
 	return nil
 }
 
-func (r *restStore) updateDocument(key, documentID string, value []byte, tags []spi.Tag) error {
+func (r *restStore) updateDocument(ctx context.Context, key, documentID string, value []byte, tags []spi.Tag) error {
 	formattedTags, err := r.formatter.formatTags(r.name, tags)
 	if err != nil {
 		return fmt.Errorf("failed to format tags: %w", err)
@@ -518,7 +554,7 @@ func (r *restStore) updateDocument(key, documentID string, value []byte, tags []
 		return fmt.Errorf("failed to format value: %w", err)
 	}
 
-	err = r.restClient.updateDocument(r.vaultID, documentID, encryptedDocumentBytes)
+	err = r.restClient.updateDocument(ctx, r.vaultID, documentID, encryptedDocumentBytes)
 	if err != nil {
 		return fmt.Errorf("failed to update existing document in EDV server: %w", err)
 	}
@@ -526,13 +562,13 @@ func (r *restStore) updateDocument(key, documentID string, value []byte, tags []
 	return nil
 }
 
-func (r *restStore) getEncryptedDocumentStoredUnderDeterministicID(key string) ([]byte, error) {
+func (r *restStore) getEncryptedDocumentStoredUnderDeterministicID(ctx context.Context, key string) ([]byte, error) {
 	encryptedDocumentID, _, _, err := r.formatter.format(r.name, key, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate the encrypted document ID: %w", err)
 	}
 
-	encryptedDocumentBytes, err := r.restClient.readDocument(r.vaultID, encryptedDocumentID)
+	encryptedDocumentBytes, err := r.restClient.readDocument(ctx, r.vaultID, encryptedDocumentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve document from EDV server: %w", err)
 	}
@@ -540,9 +576,9 @@ func (r *restStore) getEncryptedDocumentStoredUnderDeterministicID(key string) (
 	return encryptedDocumentBytes, nil
 }
 
-func (r *restStore) getEncryptedDocumentStoredUnderRandomID(key string) ([]byte, error) {
+func (r *restStore) getEncryptedDocumentStoredUnderRandomID(ctx context.Context, key string) ([]byte, error) {
 	if r.returnFullDocumentsOnQuery {
-		encryptedDocumentBytes, err := r.getFullDocumentViaKeyTagQuery(key)
+		encryptedDocumentBytes, err := r.getFullDocumentViaKeyTagQuery(ctx, key)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get full document via query: %w", err)
 		}
@@ -550,12 +586,12 @@ func (r *restStore) getEncryptedDocumentStoredUnderRandomID(key string) ([]byte,
 		return encryptedDocumentBytes, nil
 	}
 
-	documentID, err := r.getDocumentIDViaKeyTagQuery(key)
+	documentID, err := r.getDocumentIDViaKeyTagQuery(ctx, key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get document ID via key tag query: %w", err)
 	}
 
-	encryptedDocumentBytes, err := r.restClient.readDocument(r.vaultID, documentID)
+	encryptedDocumentBytes, err := r.restClient.readDocument(ctx, r.vaultID, documentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve document from EDV server: %w", err)
 	}
@@ -563,13 +599,13 @@ func (r *restStore) getEncryptedDocumentStoredUnderRandomID(key string) ([]byte,
 	return encryptedDocumentBytes, nil
 }
 
-func (r *restStore) deleteDocumentUsingDeterministicID(key string) error {
+func (r *restStore) deleteDocumentUsingDeterministicID(ctx context.Context, key string) error {
 	edvDocumentID, err := r.formatter.generateDeterministicDocumentID(r.name, key)
 	if err != nil {
 		return fmt.Errorf("failed to generate the encrypted document ID: %w", err)
 	}
 
-	err = r.restClient.deleteDocument(r.vaultID, edvDocumentID)
+	err = r.restClient.deleteDocument(ctx, r.vaultID, edvDocumentID)
 	if err != nil && !errors.Is(err, spi.ErrDataNotFound) {
 		return fmt.Errorf("unexpected failure while deleting document in EDV server: %w", err)
 	}
@@ -577,14 +613,14 @@ func (r *restStore) deleteDocumentUsingDeterministicID(key string) error {
 	return nil
 }
 
-func (r *restStore) lockAndDeleteDocumentStoredUnderRandomID(key string) error {
+func (r *restStore) lockAndDeleteDocumentStoredUnderRandomID(ctx context.Context, key string) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	return r.deleteDocumentStoredUnderRandomID(key)
+	return r.deleteDocumentStoredUnderRandomID(ctx, key)
 }
 
-func (r *restStore) getFullDocumentViaKeyTagQuery(key string) ([]byte, error) {
+func (r *restStore) getFullDocumentViaKeyTagQuery(ctx context.Context, key string) ([]byte, error) {
 	formattedKeyTag, err := r.formatter.formatTag(r.name, spi.Tag{Value: key})
 	if err != nil {
 		return nil, fmt.Errorf("failed to format key tag: %w", err)
@@ -595,7 +631,7 @@ func (r *restStore) getFullDocumentViaKeyTagQuery(key string) ([]byte, error) {
 		ReturnFullDocuments: true,
 	}
 
-	_, matchingDocuments, err := r.restClient.query(r.vaultID, edvQuery)
+	_, matchingDocuments, err := r.restClient.query(ctx, r.vaultID, edvQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failure while querying vault: %w", err)
 	}
@@ -615,8 +651,8 @@ func (r *restStore) getFullDocumentViaKeyTagQuery(key string) ([]byte, error) {
 	return encryptedDocumentBytes, nil
 }
 
-func (r *restStore) query(edvQuery query) (spi.Iterator, error) {
-	documentURLs, documents, err := r.restClient.query(r.vaultID, edvQuery)
+func (r *restStore) query(ctx context.Context, edvQuery query) (spi.Iterator, error) {
+	documentURLs, documents, err := r.restClient.query(ctx, r.vaultID, edvQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failure while querying vault: %w", err)
 	}
@@ -629,7 +665,7 @@ func (r *restStore) query(edvQuery query) (spi.Iterator, error) {
 		}
 
 		return &restIterator{
-			vaultID: r.vaultID, restClient: r.restClient, formatter: r.formatter,
+			ctx: ctx, vaultID: r.vaultID, restClient: r.restClient, formatter: r.formatter,
 			documentIDs: documentIDs,
 		}, nil
 	}
@@ -646,12 +682,12 @@ func (r *restStore) query(edvQuery query) (spi.Iterator, error) {
 	}
 
 	return &restIterator{
-		vaultID: r.vaultID, restClient: r.restClient, formatter: r.formatter,
+		ctx: ctx, vaultID: r.vaultID, restClient: r.restClient, formatter: r.formatter,
 		documents: allDocumentsBytes,
 	}, nil
 }
 
-func (r *restStore) fastBatchUsingBatchExtension(operations []spi.Operation) error {
+func (r *restStore) fastBatchUsingBatchExtension(ctx context.Context, operations []spi.Operation) error {
 	var vaultOperations []vaultOperation
 
 	var err error
@@ -665,13 +701,13 @@ func (r *restStore) fastBatchUsingBatchExtension(operations []spi.Operation) err
 		r.lock.Lock()
 		defer r.lock.Unlock()
 
-		vaultOperations, err = r.createVaultOperationsUsingNonDeterministicIDs(operations)
+		vaultOperations, err = r.createVaultOperationsUsingNonDeterministicIDs(ctx, operations)
 		if err != nil {
 			return fmt.Errorf("failed to create vault operations using random document IDs: %w", err)
 		}
 	}
 
-	err = r.restClient.batch(r.vaultID, vaultOperations)
+	err = r.restClient.batch(ctx, r.vaultID, vaultOperations)
 	if err != nil {
 		return fmt.Errorf("failure while executing batch operation in EDV server: %w", err)
 	}
@@ -679,12 +715,12 @@ func (r *restStore) fastBatchUsingBatchExtension(operations []spi.Operation) err
 	return nil
 }
 
-func (r *restStore) slowBatchUsingStandardEndpoints(operations []spi.Operation) error {
+func (r *restStore) slowBatchUsingStandardEndpoints(ctx context.Context, operations []spi.Operation) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
 	for _, operation := range operations {
-		err := r.executeOperationUsingStandardEndpoints(operation)
+		err := r.executeOperationUsingStandardEndpoints(ctx, operation)
 		if err != nil {
 			return fmt.Errorf("failed to execute operation using standard endpoints: %w", err)
 		}
@@ -693,14 +729,14 @@ func (r *restStore) slowBatchUsingStandardEndpoints(operations []spi.Operation)
 	return nil
 }
 
-func (r *restStore) executeOperationUsingStandardEndpoints(operation spi.Operation) error {
+func (r *restStore) executeOperationUsingStandardEndpoints(ctx context.Context, operation spi.Operation) error {
 	if operation.Value == nil {
-		err := r.executeDeleteOperationUsingStandardEndpoints(operation)
+		err := r.executeDeleteOperationUsingStandardEndpoints(ctx, operation)
 		if err != nil {
 			return fmt.Errorf("failed to execute delete operation using standard endpoints: %w", err)
 		}
 	} else {
-		err := r.executePutOperationUsingStandardEndpoints(operation)
+		err := r.executePutOperationUsingStandardEndpoints(ctx, operation)
 		if err != nil {
 			return fmt.Errorf("failed to execute put operation using standard endpoints: %w", err)
 		}
@@ -709,14 +745,14 @@ func (r *restStore) executeOperationUsingStandardEndpoints(operation spi.Operati
 	return nil
 }
 
-func (r *restStore) executeDeleteOperationUsingStandardEndpoints(operation spi.Operation) error {
+func (r *restStore) executeDeleteOperationUsingStandardEndpoints(ctx context.Context, operation spi.Operation) error {
 	if r.formatter.UsesDeterministicKeyFormatting() {
-		err := r.deleteDocumentUsingDeterministicID(operation.Key)
+		err := r.deleteDocumentUsingDeterministicID(ctx, operation.Key)
 		if err != nil {
 			return fmt.Errorf("failed to delete document using deterministic ID: %w", err)
 		}
 	} else {
-		err := r.deleteDocumentStoredUnderRandomID(operation.Key)
+		err := r.deleteDocumentStoredUnderRandomID(ctx, operation.Key)
 		if err != nil {
 			return fmt.Errorf("failed to delete document using random ID: %w", err)
 		}
@@ -725,14 +761,14 @@ func (r *restStore) executeDeleteOperationUsingStandardEndpoints(operation spi.O
 	return nil
 }
 
-func (r *restStore) executePutOperationUsingStandardEndpoints(operation spi.Operation) error {
+func (r *restStore) executePutOperationUsingStandardEndpoints(ctx context.Context, operation spi.Operation) error {
 	if r.formatter.UsesDeterministicKeyFormatting() {
-		err := r.putUsingDeterministicDocumentID(operation.Key, operation.Value, operation.Tags)
+		err := r.putUsingDeterministicDocumentID(ctx, operation.Key, operation.Value, operation.Tags)
 		if err != nil {
 			return fmt.Errorf("failed to store data using a deterministic document ID: %w", err)
 		}
 	} else {
-		err := r.appendKeyTagAndPutUsingRandomDocumentID(operation.Key, operation.Value, operation.Tags)
+		err := r.appendKeyTagAndPutUsingRandomDocumentID(ctx, operation.Key, operation.Value, operation.Tags)
 		if err != nil {
 			return fmt.Errorf("failed to store data using a random document ID: %w", err)
 		}
@@ -741,16 +777,17 @@ func (r *restStore) executePutOperationUsingStandardEndpoints(operation spi.Oper
 	return nil
 }
 
-func (r *restStore) appendKeyTagAndPutUsingRandomDocumentID(key string, value []byte, tags []spi.Tag) error {
+func (r *restStore) appendKeyTagAndPutUsingRandomDocumentID(ctx context.Context,
+	key string, value []byte, tags []spi.Tag) error {
 	tags = append(tags, spi.Tag{Value: key})
 
-	return r.putUsingRandomDocumentID(key, value, tags)
+	return r.putUsingRandomDocumentID(ctx, key, value, tags)
 }
 
-func (r *restStore) putUsingRandomDocumentID(key string, value []byte, tags []spi.Tag) error {
+func (r *restStore) putUsingRandomDocumentID(ctx context.Context, key string, value []byte, tags []spi.Tag) error {
 	var existingDocumentID string
 
-	existingDocumentBytes, err := r.getEncryptedDocumentStoredUnderRandomID(key)
+	existingDocumentBytes, err := r.getEncryptedDocumentStoredUnderRandomID(ctx, key)
 	if err == nil {
 		var existingDocument encryptedDocument
 
@@ -774,12 +811,12 @@ func (r *restStore) putUsingRandomDocumentID(key string, value []byte, tags []sp
 			return fmt.Errorf("failed to generate a random document ID: %w", err)
 		}
 
-		err = r.formatTagsThenCreateDocument(key, documentID, value, tags)
+		err = r.formatTagsThenCreateDocument(ctx, key, documentID, value, tags)
 		if err != nil {
 			return fmt.Errorf("failed to format tags then create document: %w", err)
 		}
 	} else {
-		err := r.updateDocument(key, existingDocumentID, value, tags)
+		err := r.updateDocument(ctx, key, existingDocumentID, value, tags)
 		if err != nil {
 			return fmt.Errorf("failed to update document: %w", err)
 		}
@@ -788,13 +825,14 @@ func (r *restStore) putUsingRandomDocumentID(key string, value []byte, tags []sp
 	return nil
 }
 
-func (r *restStore) formatTagsThenCreateDocument(key, documentID string, value []byte, tags []spi.Tag) error {
+func (r *restStore) formatTagsThenCreateDocument(ctx context.Context,
+	key, documentID string, value []byte, tags []spi.Tag) error {
 	formattedTags, err := r.formatter.formatTags(r.name, tags)
 	if err != nil {
 		return fmt.Errorf("failed to format tags: %w", err)
 	}
 
-	err = r.createDocument(key, documentID, value, tags, formattedTags)
+	err = r.createDocument(ctx, key, documentID, value, tags, formattedTags)
 	if err != nil {
 		return fmt.Errorf("failed to create document: %w", err)
 	}
@@ -802,14 +840,15 @@ func (r *restStore) formatTagsThenCreateDocument(key, documentID string, value [
 	return nil
 }
 
-func (r *restStore) createDocument(key, documentID string, value []byte, tags, formattedTags []spi.Tag) error {
+func (r *restStore) createDocument(ctx context.Context,
+	key, documentID string, value []byte, tags, formattedTags []spi.Tag) error {
 	encryptedDocumentBytes, err :=
 		r.formatter.formatValue(key, documentID, value, tags, formattedTags)
 	if err != nil {
 		return fmt.Errorf("failed to generate the encrypted document: %w", err)
 	}
 
-	_, err = r.restClient.createDocument(r.vaultID, encryptedDocumentBytes)
+	_, err = r.restClient.createDocument(ctx, r.vaultID, encryptedDocumentBytes)
 	if err != nil {
 		return fmt.Errorf("failed to create document in EDV server: %w", err)
 	}
@@ -817,8 +856,8 @@ func (r *restStore) createDocument(key, documentID string, value []byte, tags, f
 	return nil
 }
 
-func (r *restStore) deleteDocumentStoredUnderRandomID(key string) error {
-	encryptedDocumentID, err := r.determineRandomDocumentIDViaVaultQuery(key)
+func (r *restStore) deleteDocumentStoredUnderRandomID(ctx context.Context, key string) error {
+	encryptedDocumentID, err := r.determineRandomDocumentIDViaVaultQuery(ctx, key)
 	if err != nil {
 		// It's not considered an error to attempt deleting a value that doesn't exist.
 		if errors.Is(err, spi.ErrDataNotFound) {
@@ -828,7 +867,7 @@ func (r *restStore) deleteDocumentStoredUnderRandomID(key string) error {
 		return fmt.Errorf("failed to determine previously generated random document ID: %w", err)
 	}
 
-	err = r.restClient.deleteDocument(r.vaultID, encryptedDocumentID)
+	err = r.restClient.deleteDocument(ctx, r.vaultID, encryptedDocumentID)
 	if err != nil {
 		return fmt.Errorf("unexpected failure while deleting document in EDV server: %w", err)
 	}
@@ -865,7 +904,7 @@ func (r *restStore) generateVaultOperationsUsingDeterministicIDs(operations []sp
 	return vaultOperations, nil
 }
 
-func (r *restStore) createVaultOperationsUsingNonDeterministicIDs(
+func (r *restStore) createVaultOperationsUsingNonDeterministicIDs(ctx context.Context,
 	operations []spi.Operation) ([]vaultOperation, error) {
 	var vaultOperations []vaultOperation
 
@@ -873,7 +912,7 @@ func (r *restStore) createVaultOperationsUsingNonDeterministicIDs(
 
 	for _, operation := range operations {
 		if operation.Value == nil {
-			deleteOperation, err := r.createVaultDeleteOperation(resolvedIDs, operation)
+			deleteOperation, err := r.createVaultDeleteOperation(ctx, resolvedIDs, operation)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create vault delete operation: %w", err)
 			}
@@ -883,7 +922,7 @@ func (r *restStore) createVaultOperationsUsingNonDeterministicIDs(
 				vaultOperations = append(vaultOperations, deleteOperation)
 			}
 		} else {
-			putOperation, err := r.createVaultUpsertOperation(resolvedIDs, operation)
+			putOperation, err := r.createVaultUpsertOperation(ctx, resolvedIDs, operation)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create vault upsert operation: %w", err)
 			}
@@ -895,9 +934,9 @@ func (r *restStore) createVaultOperationsUsingNonDeterministicIDs(
 	return vaultOperations, nil
 }
 
-func (r *restStore) createVaultDeleteOperation(resolvedIDs map[string]string,
+func (r *restStore) createVaultDeleteOperation(ctx context.Context, resolvedIDs map[string]string,
 	operation spi.Operation) (vaultOperation, error) {
-	documentID, err := r.determineDocumentIDToUseForOperation(resolvedIDs, operation.Key)
+	documentID, err := r.determineDocumentIDToUseForOperation(ctx, resolvedIDs, operation.Key)
 	if err != nil {
 		return vaultOperation{}, fmt.Errorf("unexpected failure while determining document ID to use: %w", err)
 	}
@@ -919,9 +958,9 @@ func (r *restStore) createVaultDeleteOperation(resolvedIDs map[string]string,
 	return vaultOperation{}, nil
 }
 
-func (r *restStore) createVaultUpsertOperation(resolvedIDs map[string]string,
+func (r *restStore) createVaultUpsertOperation(ctx context.Context, resolvedIDs map[string]string,
 	operation spi.Operation) (vaultOperation, error) {
-	documentID, err := r.determineDocumentIDToUseForOperation(resolvedIDs, operation.Key)
+	documentID, err := r.determineDocumentIDToUseForOperation(ctx, resolvedIDs, operation.Key)
 	if err != nil {
 		return vaultOperation{}, fmt.Errorf("unexpected failure while determining document ID to use: %w", err)
 	}
@@ -979,7 +1018,7 @@ func (r *restStore) createVaultUpsertOperationUsingExistingDocumentID(resolvedID
 	return vaultOperation{Operation: upsertDocumentVaultOperation, EncryptedDocument: encryptedDocumentBytes}, nil
 }
 
-func (r *restStore) determineDocumentIDToUseForOperation(resolvedIDs map[string]string,
+func (r *restStore) determineDocumentIDToUseForOperation(ctx context.Context, resolvedIDs map[string]string,
 	currentOperationKey string) (string, error) {
 	// There are several cases to consider:
 	// 1. First, check the resolvedIDs slice. It contains the document IDs used in previous put operations within
@@ -1002,7 +1041,7 @@ func (r *restStore) determineDocumentIDToUseForOperation(resolvedIDs map[string]
 	if documentIDToUse == "" && !isMarkedForDeletion {
 		var err error
 
-		documentIDToUse, err = r.determineRandomDocumentIDViaVaultQuery(currentOperationKey)
+		documentIDToUse, err = r.determineRandomDocumentIDViaVaultQuery(ctx, currentOperationKey)
 		if err != nil && !errors.Is(err, spi.ErrDataNotFound) {
 			return "", fmt.Errorf("unexpected failure while attempting to "+
 				"determine document ID via vault query: %w", err)
@@ -1012,8 +1051,8 @@ func (r *restStore) determineDocumentIDToUseForOperation(resolvedIDs map[string]
 	return documentIDToUse, nil
 }
 
-func (r *restStore) determineRandomDocumentIDViaVaultQuery(key string) (string, error) {
-	documentID, err := r.getDocumentIDViaKeyTagQuery(key)
+func (r *restStore) determineRandomDocumentIDViaVaultQuery(ctx context.Context, key string) (string, error) {
+	documentID, err := r.getDocumentIDViaKeyTagQuery(ctx, key)
 	if err != nil {
 		return "", fmt.Errorf("failed to get document ID via key tag query: %w", err)
 	}
@@ -1021,7 +1060,7 @@ func (r *restStore) determineRandomDocumentIDViaVaultQuery(key string) (string,
 	return documentID, nil
 }
 
-func (r *restStore) getDocumentIDViaKeyTagQuery(key string) (string, error) {
+func (r *restStore) getDocumentIDViaKeyTagQuery(ctx context.Context, key string) (string, error) {
 	formattedKeyTag, err := r.formatter.formatTag(r.name, spi.Tag{Value: key})
 	if err != nil {
 		return "", fmt.Errorf("failed to format key tag: %w", err)
@@ -1032,7 +1071,7 @@ func (r *restStore) getDocumentIDViaKeyTagQuery(key string) (string, error) {
 		ReturnFullDocuments: false,
 	}
 
-	matchingDocumentsURLs, _, err := r.restClient.query(r.vaultID, edvQuery)
+	matchingDocumentsURLs, _, err := r.restClient.query(ctx, r.vaultID, edvQuery)
 	if err != nil {
 		return "", fmt.Errorf("failure while querying EDV server: %w", err)
 	}
@@ -1121,6 +1160,7 @@ func (r *restStore) generateEDVQuerySubfilter(expression string) (map[string]str
 }
 
 type restIterator struct {
+	ctx          context.Context
 	vaultID      string
 	restClient   *restClient
 	formatter    *EncryptedFormatter
@@ -1152,7 +1192,7 @@ func (r *restIterator) Next() (bool, error) {
 		return true, nil
 	}
 
-	encryptedDocumentBytes, err := r.restClient.readDocument(r.vaultID, r.documentIDs[r.currentIndex])
+	encryptedDocumentBytes, err := r.restClient.readDocument(r.ctx, r.vaultID, r.documentIDs[r.currentIndex])
 	if err != nil {
 		return false, fmt.Errorf("failed to retrieve document from EDV server: %w", err)
 	}
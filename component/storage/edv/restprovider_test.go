@@ -8,10 +8,12 @@ package edv_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -347,6 +349,36 @@ func TestRESTStore_Query(t *testing.T) {
 	})
 }
 
+func TestRESTStore_CancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Fail(t, "request must not be sent with a cancelled context")
+	}))
+	defer server.Close()
+
+	edvRESTProvider := edv.NewRESTProvider(server.URL, "VaultID", createValidEncryptedFormatter(t))
+
+	store, err := edvRESTProvider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	contextStore, ok := store.(spi.ContextStore)
+	require.True(t, ok)
+	require.Equal(t, contextStore, spi.NewContextStore(store))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = contextStore.PutContext(ctx, "Key", []byte("Value"))
+	require.True(t, errors.Is(err, context.Canceled))
+
+	value, err := contextStore.GetContext(ctx, "Key")
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Nil(t, value)
+
+	iterator, err := contextStore.QueryContext(ctx, "TagName:TagValue")
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Nil(t, iterator)
+}
+
 func TestRESTStore_Delete(t *testing.T) {
 	t.Run("Fail to generate encrypted document ID", func(t *testing.T) {
 		crypto, err := tinkcrypto.New()
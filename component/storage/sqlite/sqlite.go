@@ -0,0 +1,783 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	// Pure-Go SQLite driver, registered as "sqlite".
+	_ "modernc.org/sqlite"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	driverName = "sqlite"
+
+	defaultPageSize = 25
+
+	invalidTagName                  = `"%s" is an invalid tag name since it contains one or more ':' characters`
+	invalidTagValue                 = `"%s" is an invalid tag value since it contains one or more ':' characters`
+	duplicateTagName                = `tag name "%s" is used more than once`
+	expressionTagNameOnlyLength     = 1
+	expressionTagNameAndValueLength = 2
+	invalidQueryExpressionFormat    = `"%s" is not in a valid expression format. ` +
+		"it must be in the following format: TagName:TagValue"
+
+	andOperator = "&&"
+	orOperator  = "||"
+)
+
+// The data of all the stores is kept in the same database file. Tags are stored in their own table, indexed by
+// store, tag name and tag value, so that queries don't have to scan all the records of a store. The numerical value
+// of a tag (if any) is stored alongside it for sorting.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS stores (
+		name TEXT NOT NULL PRIMARY KEY,
+		config TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS entries (
+		store TEXT NOT NULL,
+		key TEXT NOT NULL,
+		value BLOB NOT NULL,
+		tags TEXT,
+		PRIMARY KEY (store, key)
+	)`,
+	`CREATE TABLE IF NOT EXISTS tags (
+		store TEXT NOT NULL,
+		key TEXT NOT NULL,
+		name TEXT NOT NULL,
+		value TEXT NOT NULL,
+		number REAL,
+		PRIMARY KEY (store, key, name)
+	)`,
+	`CREATE INDEX IF NOT EXISTS tags_name_value ON tags (store, name, value)`,
+}
+
+// Provider is a SQLite implementation of the spi.Provider interface. All the stores are kept in a single
+// database file.
+type Provider struct {
+	db   *sql.DB
+	dbs  map[string]*store
+	lock sync.RWMutex
+}
+
+type closer func(storeName string)
+
+// NewProvider instantiates Provider, opening (or creating) the SQLite database file at dbPath.
+func NewProvider(dbPath string) (*Provider, error) {
+	db, err := sql.Open(driverName, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	// SQLite allows a single writer at a time: sharing one connection avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range schema {
+		_, err = db.Exec(statement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create database schema: %w", err)
+		}
+	}
+
+	return &Provider{db: db, dbs: make(map[string]*store)}, nil
+}
+
+// OpenStore opens and returns a store for given name space. If the store has never been opened before,
+// then it is created.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	if name == "" {
+		return nil, errors.New("store name cannot be blank")
+	}
+
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	openStore, ok := p.dbs[name]
+	if ok {
+		return openStore, nil
+	}
+
+	_, err := p.db.Exec("INSERT OR IGNORE INTO stores (name) VALUES (?)", name)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create store "%s": %w`, name, err)
+	}
+
+	openStore = &store{db: p.db, name: name, close: p.removeStore}
+	p.dbs[name] = openStore
+
+	return openStore, nil
+}
+
+// SetStoreConfig saves the store configuration. Tag queries are indexed regardless of the configured tag names,
+// so the configuration is only saved for later retrieval.
+// If the store cannot be found, then an error wrapping spi.ErrStoreNotFound will be returned.
+func (p *Provider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	for _, tagName := range config.TagNames {
+		if strings.Contains(tagName, ":") {
+			return fmt.Errorf(invalidTagName, tagName)
+		}
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal store configuration: %w", err)
+	}
+
+	result, err := p.db.Exec("UPDATE stores SET config = ? WHERE name = ?", string(configBytes), strings.ToLower(name))
+	if err != nil {
+		return fmt.Errorf("failed to put store configuration: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to put store configuration: %w", err)
+	}
+
+	if updated == 0 {
+		return storage.ErrStoreNotFound
+	}
+
+	return nil
+}
+
+// GetStoreConfig returns the current store configuration. The store is looked up in the underlying database,
+// so it doesn't need to be open.
+// If the store cannot be found, then an error wrapping spi.ErrStoreNotFound will be returned.
+func (p *Provider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	name = strings.ToLower(name)
+
+	var configJSON sql.NullString
+
+	err := p.db.QueryRow("SELECT config FROM stores WHERE name = ?", name).Scan(&configJSON)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.StoreConfiguration{}, storage.ErrStoreNotFound
+		}
+
+		return storage.StoreConfiguration{},
+			fmt.Errorf(`failed to get store configuration for "%s": %w`, name, err)
+	}
+
+	var storeConfig storage.StoreConfiguration
+
+	if !configJSON.Valid {
+		return storeConfig, nil
+	}
+
+	err = json.Unmarshal([]byte(configJSON.String), &storeConfig)
+	if err != nil {
+		return storage.StoreConfiguration{}, fmt.Errorf("failed to unmarshal store configuration: %w", err)
+	}
+
+	return storeConfig, nil
+}
+
+// GetOpenStores returns all Stores currently open in the Provider.
+func (p *Provider) GetOpenStores() []storage.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	openStores := make([]storage.Store, len(p.dbs))
+
+	var counter int
+
+	for _, db := range p.dbs {
+		openStores[counter] = db
+		counter++
+	}
+
+	return openStores
+}
+
+// Close closes all stores created under this store provider, and the underlying database.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	p.dbs = make(map[string]*store)
+	p.lock.Unlock()
+
+	err := p.db.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close SQLite database: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Provider) removeStore(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.dbs, name)
+}
+
+type store struct {
+	db    *sql.DB
+	name  string
+	close closer
+}
+
+// Put stores the key and the record, replacing any existing record and tags.
+func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
+	err := validatePut(key, value, tags)
+	if err != nil {
+		return err
+	}
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		return s.put(tx, key, value, tags)
+	})
+}
+
+// Get fetches the record based on key.
+func (s *store) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, errors.New("key cannot be blank")
+	}
+
+	var value []byte
+
+	err := s.db.QueryRow("SELECT value FROM entries WHERE store = ? AND key = ?", s.name, key).Scan(&value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrDataNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get value: %w", err)
+	}
+
+	return value, nil
+}
+
+// GetTags fetches all tags associated with the given key.
+func (s *store) GetTags(key string) ([]storage.Tag, error) {
+	if key == "" {
+		return nil, errors.New("key cannot be blank")
+	}
+
+	var tagsJSON sql.NullString
+
+	err := s.db.QueryRow("SELECT tags FROM entries WHERE store = ? AND key = ?", s.name, key).Scan(&tagsJSON)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrDataNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return unmarshalTags(tagsJSON)
+}
+
+// GetBulk fetches the values associated with the given keys in a single query.
+// If no data exists under a given key, then a nil []byte is returned for that value.
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys slice must contain at least one key")
+	}
+
+	args := []interface{}{s.name}
+
+	for _, key := range keys {
+		if key == "" {
+			return nil, errors.New("key cannot be blank")
+		}
+
+		args = append(args, key)
+	}
+
+	rows, err := s.db.Query("SELECT key, value FROM entries WHERE store = ? AND key IN (?"+
+		strings.Repeat(", ?", len(keys)-1)+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values: %w", err)
+	}
+
+	defer rows.Close() // nolint: errcheck // read-only rows
+
+	retrieved := make(map[string][]byte)
+
+	for rows.Next() {
+		var (
+			key   string
+			value []byte
+		)
+
+		err = rows.Scan(&key, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read value: %w", err)
+		}
+
+		retrieved[key] = value
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get values: %w", err)
+	}
+
+	values := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		values[i] = retrieved[key]
+	}
+
+	return values, nil
+}
+
+// Query returns all data that satisfies the expression. Both the basic (TagName:TagValue) and the advanced
+// (criteria joined by "&&" and "||" operators) expression formats are supported.
+// Results are sorted by key unless spi.WithSortOrder is used, in which case they are sorted by the values of the
+// given tag (numerically if they are numbers). spi.WithPageSize sets the number of records fetched at once by the
+// iterator, and spi.WithInitialPageNum the page the iterator starts from.
+func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	where, args, err := parseQueryExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	queryOptions := getQueryOptions(options)
+
+	if queryOptions.SortOptions != nil && queryOptions.SortOptions.TagName == "" {
+		return nil, errors.New("sort tag name cannot be blank")
+	}
+
+	pageSize := queryOptions.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	return &iterator{
+		store:    s,
+		where:    where,
+		args:     args,
+		sort:     queryOptions.SortOptions,
+		pageSize: pageSize,
+		offset:   queryOptions.InitialPageNum * pageSize,
+	}, nil
+}
+
+// Delete will delete record with k key, along with its tags.
+func (s *store) Delete(key string) error {
+	if key == "" {
+		return errors.New("key cannot be blank")
+	}
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		return s.delete(tx, key)
+	})
+}
+
+// Batch performs multiple Put and/or Delete operations in order, in a single transaction: either all of them
+// succeed or none of them is applied.
+// If an operation uses the PutOptions.IsNewKey option and its key already exists, then an error wrapping
+// spi.ErrDuplicateKey is returned.
+func (s *store) Batch(operations []storage.Operation) error {
+	if len(operations) == 0 {
+		return errors.New("batch requires at least one operation")
+	}
+
+	for _, operation := range operations {
+		if operation.Key == "" {
+			return errors.New("key cannot be blank")
+		}
+
+		if operation.Value != nil {
+			err := validatePut(operation.Key, operation.Value, operation.Tags)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		for _, operation := range operations {
+			if operation.Value == nil {
+				err := s.delete(tx, operation.Key)
+				if err != nil {
+					return fmt.Errorf("failed to delete value: %w", err)
+				}
+
+				continue
+			}
+
+			if operation.PutOptions != nil && operation.PutOptions.IsNewKey {
+				err := s.checkNewKey(tx, operation.Key)
+				if err != nil {
+					return err
+				}
+			}
+
+			err := s.put(tx, operation.Key, operation.Value, operation.Tags)
+			if err != nil {
+				return fmt.Errorf("failed to put value: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// This store doesn't queue values, so there's never anything to flush.
+func (s *store) Flush() error {
+	return nil
+}
+
+// Close removes the store from the open stores of the provider. The underlying database is closed by the provider.
+func (s *store) Close() error {
+	s.close(s.name)
+
+	return nil
+}
+
+func (s *store) inTransaction(operation func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	err = operation(tx)
+	if err != nil {
+		errRollback := tx.Rollback()
+		if errRollback != nil {
+			return fmt.Errorf("%w (failed to roll back transaction: %s)", err, errRollback.Error())
+		}
+
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) put(tx *sql.Tx, key string, value []byte, tags []storage.Tag) error {
+	var tagsJSON sql.NullString
+
+	if len(tags) > 0 {
+		tagsBytes, err := json.Marshal(tags)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tags: %w", err)
+		}
+
+		tagsJSON = sql.NullString{String: string(tagsBytes), Valid: true}
+	}
+
+	_, err := tx.Exec("INSERT OR REPLACE INTO entries (store, key, value, tags) VALUES (?, ?, ?, ?)",
+		s.name, key, value, tagsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to store value: %w", err)
+	}
+
+	_, err = tx.Exec("DELETE FROM tags WHERE store = ? AND key = ?", s.name, key)
+	if err != nil {
+		return fmt.Errorf("failed to remove previous tags: %w", err)
+	}
+
+	for _, tag := range tags {
+		_, err = tx.Exec("INSERT INTO tags (store, key, name, value, number) VALUES (?, ?, ?, ?, ?)",
+			s.name, key, tag.Name, tag.Value, tagNumber(tag.Value))
+		if err != nil {
+			return fmt.Errorf("failed to store tag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *store) delete(tx *sql.Tx, key string) error {
+	_, err := tx.Exec("DELETE FROM entries WHERE store = ? AND key = ?", s.name, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete value: %w", err)
+	}
+
+	_, err = tx.Exec("DELETE FROM tags WHERE store = ? AND key = ?", s.name, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) checkNewKey(tx *sql.Tx, key string) error {
+	var exists bool
+
+	err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM entries WHERE store = ? AND key = ?)", s.name, key).
+		Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check key: %w", err)
+	}
+
+	if exists {
+		return fmt.Errorf(`key "%s": %w`, key, storage.ErrDuplicateKey)
+	}
+
+	return nil
+}
+
+type iterator struct {
+	store    *store
+	where    string
+	args     []interface{}
+	sort     *storage.SortOptions
+	pageSize int
+	offset   int
+
+	page      []iteratorEntry
+	pageIndex int
+	exhausted bool
+	current   *iteratorEntry
+}
+
+type iteratorEntry struct {
+	key   string
+	value []byte
+	tags  sql.NullString
+}
+
+// Next moves the pointer to the next entry in the iterator, fetching the next page of records when needed.
+func (i *iterator) Next() (bool, error) {
+	if i.pageIndex == len(i.page) {
+		if i.exhausted {
+			i.current = nil
+
+			return false, nil
+		}
+
+		err := i.fetchPage()
+		if err != nil {
+			return false, err
+		}
+
+		if len(i.page) == 0 {
+			i.current = nil
+
+			return false, nil
+		}
+	}
+
+	i.current = &i.page[i.pageIndex]
+	i.pageIndex++
+
+	return true, nil
+}
+
+func (i *iterator) Key() (string, error) {
+	if i.current == nil {
+		return "", errors.New("iterator is exhausted")
+	}
+
+	return i.current.key, nil
+}
+
+func (i *iterator) Value() ([]byte, error) {
+	if i.current == nil {
+		return nil, errors.New("iterator is exhausted")
+	}
+
+	return i.current.value, nil
+}
+
+func (i *iterator) Tags() ([]storage.Tag, error) {
+	if i.current == nil {
+		return nil, errors.New("iterator is exhausted")
+	}
+
+	return unmarshalTags(i.current.tags)
+}
+
+// TotalItems returns the number of records matching the query, regardless of the page settings.
+func (i *iterator) TotalItems() (int, error) {
+	var count int
+
+	err := i.store.db.QueryRow("SELECT COUNT(*) FROM entries e WHERE "+i.where,
+		append([]interface{}{i.store.name}, i.args...)...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count query results: %w", err)
+	}
+
+	return count, nil
+}
+
+func (i *iterator) Close() error {
+	return nil
+}
+
+func (i *iterator) fetchPage() error {
+	query := "SELECT e.key, e.value, e.tags FROM entries e"
+	args := make([]interface{}, 0, len(i.args)+4) // nolint: gomnd // store name, sort tag name, limit and offset
+
+	orderBy := "e.key"
+
+	if i.sort != nil {
+		query += " LEFT JOIN tags s ON s.store = e.store AND s.key = e.key AND s.name = ?"
+
+		args = append(args, i.sort.TagName)
+
+		direction := "ASC"
+		if i.sort.Order == storage.SortDescending {
+			direction = "DESC"
+		}
+
+		orderBy = fmt.Sprintf("s.number %[1]s, s.value %[1]s, e.key", direction)
+	}
+
+	query += " WHERE " + i.where + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?"
+
+	args = append(args, i.store.name)
+	args = append(args, i.args...)
+	args = append(args, i.pageSize, i.offset)
+
+	rows, err := i.store.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query records: %w", err)
+	}
+
+	defer rows.Close() // nolint: errcheck // read-only rows
+
+	i.page = i.page[:0]
+	i.pageIndex = 0
+
+	for rows.Next() {
+		var entry iteratorEntry
+
+		err = rows.Scan(&entry.key, &entry.value, &entry.tags)
+		if err != nil {
+			return fmt.Errorf("failed to read record: %w", err)
+		}
+
+		i.page = append(i.page, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to query records: %w", err)
+	}
+
+	i.offset += len(i.page)
+	i.exhausted = len(i.page) < i.pageSize
+
+	return nil
+}
+
+// parseQueryExpression converts the query expression into a SQL condition on the entries table (aliased "e"),
+// with the store name as its first argument. ANDs are evaluated before ORs.
+func parseQueryExpression(expression string) (string, []interface{}, error) {
+	if expression == "" {
+		return "", nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	}
+
+	var (
+		orConditions []string
+		args         []interface{}
+	)
+
+	for _, orCriteria := range strings.Split(expression, orOperator) {
+		var andConditions []string
+
+		for _, criterion := range strings.Split(orCriteria, andOperator) {
+			expressionSplit := strings.Split(criterion, ":")
+
+			condition := "EXISTS (SELECT 1 FROM tags t WHERE t.store = e.store AND t.key = e.key AND t.name = ?"
+
+			switch len(expressionSplit) {
+			case expressionTagNameOnlyLength:
+				args = append(args, expressionSplit[0])
+			case expressionTagNameAndValueLength:
+				condition += " AND t.value = ?"
+
+				args = append(args, expressionSplit[0], expressionSplit[1])
+			default:
+				return "", nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+			}
+
+			if expressionSplit[0] == "" {
+				return "", nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+			}
+
+			andConditions = append(andConditions, condition+")")
+		}
+
+		orConditions = append(orConditions, "("+strings.Join(andConditions, " AND ")+")")
+	}
+
+	return "e.store = ? AND (" + strings.Join(orConditions, " OR ") + ")", args, nil
+}
+
+func validatePut(key string, value []byte, tags []storage.Tag) error {
+	if key == "" {
+		return errors.New("key cannot be blank")
+	}
+
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	tagNames := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		if strings.Contains(tag.Name, ":") {
+			return fmt.Errorf(invalidTagName, tag.Name)
+		}
+
+		if strings.Contains(tag.Value, ":") {
+			return fmt.Errorf(invalidTagValue, tag.Value)
+		}
+
+		if _, ok := tagNames[tag.Name]; ok {
+			return fmt.Errorf(duplicateTagName, tag.Name)
+		}
+
+		tagNames[tag.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// tagNumber returns the numerical value of a tag, so that tags holding numbers are sorted numerically.
+func tagNumber(value string) sql.NullFloat64 {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: number, Valid: true}
+}
+
+func unmarshalTags(tagsJSON sql.NullString) ([]storage.Tag, error) {
+	if !tagsJSON.Valid {
+		return nil, nil
+	}
+
+	var tags []storage.Tag
+
+	err := json.Unmarshal([]byte(tagsJSON.String), &tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func getQueryOptions(options []storage.QueryOption) storage.QueryOptions {
+	var queryOptions storage.QueryOptions
+
+	for _, option := range options {
+		option(&queryOptions)
+	}
+
+	return queryOptions
+}
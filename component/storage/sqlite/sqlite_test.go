@@ -0,0 +1,278 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlite_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/sqlite"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	commontest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func setupSQLite(t testing.TB) string {
+	return filepath.Join(t.TempDir(), "aries.db")
+}
+
+func newProvider(t testing.TB, dbPath string) *sqlite.Provider {
+	provider, err := sqlite.NewProvider(dbPath)
+	require.NoError(t, err)
+
+	return provider
+}
+
+func TestCommon(t *testing.T) {
+	provider := newProvider(t, setupSQLite(t))
+
+	commontest.TestAll(t, provider)
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("Fail to create database schema", func(t *testing.T) {
+		provider, err := sqlite.NewProvider(t.TempDir())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create database schema")
+		require.Nil(t, provider)
+	})
+}
+
+func TestProvider_Persistence(t *testing.T) {
+	path := setupSQLite(t)
+
+	provider := newProvider(t, path)
+
+	testStore, err := provider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	err = provider.SetStoreConfig("TestStore", storage.StoreConfiguration{TagNames: []string{"TagName1"}})
+	require.NoError(t, err)
+
+	err = testStore.Put("key", []byte("value"), storage.Tag{Name: "TagName1", Value: "TagValue1"})
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Close())
+
+	provider = newProvider(t, path)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	// The store config is found in the database file even though the store wasn't opened by this provider.
+	config, err := provider.GetStoreConfig("TestStore")
+	require.NoError(t, err)
+	require.Equal(t, []string{"TagName1"}, config.TagNames)
+
+	testStore, err = provider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	value, err := testStore.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, "value", string(value))
+
+	itr, err := testStore.Query("TagName1:TagValue1")
+	require.NoError(t, err)
+
+	ok, err := itr.Next()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	key, err := itr.Key()
+	require.NoError(t, err)
+	require.Equal(t, "key", key)
+}
+
+func TestStore_Put(t *testing.T) {
+	provider := newProvider(t, setupSQLite(t))
+
+	testStore, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	t.Run("Replace tags", func(t *testing.T) {
+		err = testStore.Put("key", []byte("value"), storage.Tag{Name: "TagName1"})
+		require.NoError(t, err)
+
+		err = testStore.Put("key", []byte("value"))
+		require.NoError(t, err)
+
+		tags, errGetTags := testStore.GetTags("key")
+		require.NoError(t, errGetTags)
+		require.Empty(t, tags)
+
+		itr, errQuery := testStore.Query("TagName1")
+		require.NoError(t, errQuery)
+
+		count, errCount := itr.TotalItems()
+		require.NoError(t, errCount)
+		require.Zero(t, count)
+	})
+	t.Run("Fail to put tags sharing the same name", func(t *testing.T) {
+		err = testStore.Put("key", []byte("value"),
+			storage.Tag{Name: "TagName1", Value: "TagValue1"}, storage.Tag{Name: "TagName1", Value: "TagValue2"})
+		require.EqualError(t, err, `tag name "TagName1" is used more than once`)
+	})
+	t.Run("Fail to put value since the provider was closed", func(t *testing.T) {
+		closedProvider := newProvider(t, setupSQLite(t))
+
+		closedStore, errOpen := closedProvider.OpenStore(randomStoreName())
+		require.NoError(t, errOpen)
+
+		require.NoError(t, closedProvider.Close())
+
+		err = closedStore.Put("key", []byte("value"))
+		require.EqualError(t, err, "failed to begin transaction: sql: database is closed")
+	})
+}
+
+func TestStore_Query(t *testing.T) {
+	provider := newProvider(t, setupSQLite(t))
+
+	testStore, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	err = testStore.Batch([]storage.Operation{
+		{Key: "key1", Value: []byte("value1"), Tags: []storage.Tag{{Name: "A", Value: "1"}, {Name: "B", Value: "x"}}},
+		{Key: "key2", Value: []byte("value2"), Tags: []storage.Tag{{Name: "A", Value: "2"}}},
+		{Key: "key3", Value: []byte("value3"), Tags: []storage.Tag{{Name: "B", Value: "y"}, {Name: "C"}}},
+	})
+	require.NoError(t, err)
+
+	t.Run("Advanced expressions", func(t *testing.T) {
+		for expression, expectedKeys := range map[string][]string{
+			"A&&B":          {"key1"},
+			"A||B":          {"key1", "key2", "key3"},
+			"A:2||B:y":      {"key2", "key3"},
+			"A&&B:x||C":     {"key1", "key3"},
+			"C||A:1&&B:y":   {"key3"},
+			"A:1&&B:x||A:2": {"key1", "key2"},
+		} {
+			itr, errQuery := testStore.Query(expression)
+			require.NoError(t, errQuery)
+
+			require.Equal(t, expectedKeys, iteratorKeys(t, itr), expression)
+		}
+	})
+	t.Run("Numerical sort descending", func(t *testing.T) {
+		itr, errQuery := testStore.Query("A", storage.WithSortOrder(&storage.SortOptions{
+			Order:   storage.SortDescending,
+			TagName: "A",
+		}), storage.WithPageSize(1))
+		require.NoError(t, errQuery)
+
+		require.Equal(t, []string{"key2", "key1"}, iteratorKeys(t, itr))
+	})
+	t.Run("Invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{"", "A:1:2", "A&&:1", "A||"} {
+			itr, errQuery := testStore.Query(expression)
+			require.EqualError(t, errQuery, `"`+expression+`" is not in a valid expression format. `+
+				"it must be in the following format: TagName:TagValue")
+			require.Nil(t, itr)
+		}
+	})
+	t.Run("Blank sort tag name", func(t *testing.T) {
+		itr, errQuery := testStore.Query("A", storage.WithSortOrder(&storage.SortOptions{}))
+		require.EqualError(t, errQuery, "sort tag name cannot be blank")
+		require.Nil(t, itr)
+	})
+}
+
+func TestStore_Batch(t *testing.T) {
+	provider := newProvider(t, setupSQLite(t))
+
+	testStore, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	err = testStore.Put("existing", []byte("value"))
+	require.NoError(t, err)
+
+	t.Run("Duplicate key rolls back the whole batch", func(t *testing.T) {
+		err = testStore.Batch([]storage.Operation{
+			{Key: "new", Value: []byte("value"), PutOptions: &storage.PutOptions{IsNewKey: true}},
+			{Key: "existing", Value: []byte("value"), PutOptions: &storage.PutOptions{IsNewKey: true}},
+		})
+		require.True(t, errors.Is(err, storage.ErrDuplicateKey), "unexpected error or no error")
+
+		_, err = testStore.Get("new")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound), "unexpected error or no error")
+	})
+	t.Run("Invalid operations", func(t *testing.T) {
+		err = testStore.Batch([]storage.Operation{{Key: "key", Value: []byte("value")}, {Value: []byte("value")}})
+		require.EqualError(t, err, "key cannot be blank")
+
+		err = testStore.Batch([]storage.Operation{{Key: "key", Value: []byte("value"), Tags: []storage.Tag{
+			{Name: "Tag:Name"},
+		}}})
+		require.EqualError(t, err, `"Tag:Name" is an invalid tag name since it contains one or more ':' characters`)
+	})
+}
+
+func TestIterator(t *testing.T) {
+	provider := newProvider(t, setupSQLite(t))
+
+	testStore, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	itr, err := testStore.Query("expression")
+	require.NoError(t, err)
+
+	ok, err := itr.Next()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = itr.Key()
+	require.EqualError(t, err, "iterator is exhausted")
+
+	_, err = itr.Value()
+	require.EqualError(t, err, "iterator is exhausted")
+
+	_, err = itr.Tags()
+	require.EqualError(t, err, "iterator is exhausted")
+
+	unreadItr, err := testStore.Query("expression")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Close())
+
+	_, err = itr.TotalItems()
+	require.EqualError(t, err, "failed to count query results: sql: database is closed")
+
+	_, err = unreadItr.Next()
+	require.EqualError(t, err, "failed to query records: sql: database is closed")
+}
+
+func iteratorKeys(t *testing.T, itr storage.Iterator) []string {
+	t.Helper()
+
+	var keys []string
+
+	for {
+		ok, err := itr.Next()
+		require.NoError(t, err)
+
+		if !ok {
+			break
+		}
+
+		key, err := itr.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+
+	require.NoError(t, itr.Close())
+
+	return keys
+}
+
+func randomStoreName() string {
+	return "store-" + uuid.New().String()
+}
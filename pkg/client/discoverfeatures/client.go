@@ -0,0 +1,163 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// DefaultTimeout is the default time to wait for the disclosure of the other party.
+const DefaultTimeout = 10 * time.Second
+
+// ErrNoSupportedProtocol is returned when the other party of a connection supports none of the candidate protocols.
+var ErrNoSupportedProtocol = errors.New("none of the protocols is supported by the other party")
+
+type (
+	// Disclosure is a feature disclosed by the other party of a connection.
+	Disclosure = discoverfeatures.Disclosure
+	// FeatureQuery queries the features of a type whose ID matches a pattern.
+	FeatureQuery = discoverfeatures.FeatureQuery
+	// DisclosurePolicy decides whether a feature is disclosed to the DID of the other party.
+	DisclosurePolicy = discoverfeatures.DisclosurePolicy
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	Query(connectionID string, options ...discoverfeatures.QueryOption) ([]discoverfeatures.Disclosure, error)
+	Disclosures(connectionID string) ([]discoverfeatures.Disclosure, error)
+	SetDisclosurePolicy(policy discoverfeatures.DisclosurePolicy)
+	AddGoalCodes(goalCodes ...string)
+}
+
+// Client enable access to discover features api.
+type Client struct {
+	discoverfeaturesSvc protocolService
+}
+
+// QueryOption configures a features query sent by the client.
+type QueryOption func(opts *queryOpts)
+
+type queryOpts struct {
+	queries []FeatureQuery
+	timeout time.Duration
+}
+
+// WithQueries sets the feature queries, all features are queried by default. DIDComm V1 connections only support
+// a single protocol query.
+func WithQueries(queries ...FeatureQuery) QueryOption {
+	return func(opts *queryOpts) {
+		opts.queries = queries
+	}
+}
+
+// WithTimeout sets how long to wait for the disclosure, defaults to DefaultTimeout.
+func WithTimeout(timeout time.Duration) QueryOption {
+	return func(opts *queryOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// New return new instance of discover features client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discover features service: %w", err)
+	}
+
+	discoverfeaturesSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{
+		discoverfeaturesSvc: discoverfeaturesSvc,
+	}, nil
+}
+
+// Query queries the features of the other party of the connection and waits for their disclosure. The disclosed
+// features are saved with the connection, replacing the ones matching the queries.
+func (c *Client) Query(connectionID string, options ...QueryOption) ([]Disclosure, error) {
+	if connectionID == "" {
+		return nil, errors.New("discover features client - query: connection ID is required")
+	}
+
+	opts := &queryOpts{timeout: DefaultTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	disclosures, err := c.discoverfeaturesSvc.Query(connectionID,
+		discoverfeatures.WithQueries(opts.queries...), discoverfeatures.WithTimeout(opts.timeout))
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - query: %w", err)
+	}
+
+	return disclosures, nil
+}
+
+// Disclosures returns the features disclosed by the other party of the connection. The features are queried when
+// they were never disclosed, later calls return the saved disclosures.
+func (c *Client) Disclosures(connectionID string) ([]Disclosure, error) {
+	disclosures, err := c.discoverfeaturesSvc.Disclosures(connectionID)
+	if errors.Is(err, discoverfeatures.ErrNoDisclosures) {
+		return c.Query(connectionID)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - disclosures: %w", err)
+	}
+
+	return disclosures, nil
+}
+
+// SelectProtocol returns the first of the candidate protocol identifier URIs supported by the other party of the
+// connection, e.g. to pick the version of present proof to use:
+//
+//	pid, err := client.SelectProtocol(connID, presentproof.SpecV3, presentproof.SpecV2)
+//
+// Trailing slashes of the protocol identifiers are ignored. Returns ErrNoSupportedProtocol when none is supported.
+func (c *Client) SelectProtocol(connectionID string, candidates ...string) (string, error) {
+	disclosures, err := c.Disclosures(connectionID)
+	if err != nil {
+		return "", err
+	}
+
+	supported := make(map[string]bool)
+
+	for _, disclosure := range disclosures {
+		if disclosure.FeatureType == discoverfeatures.FeatureTypeProtocol {
+			supported[strings.TrimSuffix(disclosure.ID, "/")] = true
+		}
+	}
+
+	for _, candidate := range candidates {
+		if supported[strings.TrimSuffix(candidate, "/")] {
+			return candidate, nil
+		}
+	}
+
+	return "", ErrNoSupportedProtocol
+}
+
+// SetDisclosurePolicy sets the policy filtering the features disclosed to other agents, nil discloses all features.
+func (c *Client) SetDisclosurePolicy(policy DisclosurePolicy) {
+	c.discoverfeaturesSvc.SetDisclosurePolicy(policy)
+}
+
+// AddGoalCodes adds goal codes to the features disclosed to other agents.
+func (c *Client) AddGoalCodes(goalCodes ...string) {
+	c.discoverfeaturesSvc.AddGoalCodes(goalCodes...)
+}
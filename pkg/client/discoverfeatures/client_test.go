@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to discover features service failed")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	t.Run("query - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryFunc: func(connectionID string, options ...discoverfeatures.QueryOption) ([]Disclosure, error) {
+					require.Equal(t, "connID", connectionID)
+					require.Len(t, options, 2)
+
+					return []Disclosure{{FeatureType: discoverfeatures.FeatureTypeGoalCode, ID: "issue-vc"}}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		disclosures, err := client.Query("connID", WithQueries(FeatureQuery{
+			FeatureType: discoverfeatures.FeatureTypeGoalCode,
+			Match:       "*",
+		}), WithTimeout(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, []Disclosure{{FeatureType: discoverfeatures.FeatureTypeGoalCode, ID: "issue-vc"}}, disclosures)
+	})
+
+	t.Run("query - connection ID is required", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		_, err = client.Query("")
+		require.EqualError(t, err, "discover features client - query: connection ID is required")
+	})
+
+	t.Run("query - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{QueryErr: discoverfeatures.ErrTimeout},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Query("connID")
+		require.ErrorIs(t, err, discoverfeatures.ErrTimeout)
+	})
+}
+
+func TestDisclosures(t *testing.T) {
+	t.Run("saved disclosures", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				DisclosuresFunc: func(string) ([]Disclosure, error) {
+					return []Disclosure{{FeatureType: discoverfeatures.FeatureTypeGoalCode, ID: "issue-vc"}}, nil
+				},
+				QueryErr: errors.New("must not query"),
+			},
+		})
+		require.NoError(t, err)
+
+		disclosures, err := client.Disclosures("connID")
+		require.NoError(t, err)
+		require.Len(t, disclosures, 1)
+	})
+
+	t.Run("queries when never disclosed", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		disclosures, err := client.Disclosures("connID")
+		require.NoError(t, err)
+		require.Equal(t, "https://didcomm.org/trust-ping/2.0", disclosures[0].ID)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{DisclosuresErr: errors.New("store error")},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Disclosures("connID")
+		require.EqualError(t, err, "discover features client - disclosures: store error")
+	})
+}
+
+func TestSelectProtocol(t *testing.T) {
+	svc := &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+		DisclosuresFunc: func(string) ([]Disclosure, error) {
+			return []Disclosure{
+				{FeatureType: discoverfeatures.FeatureTypeGoalCode, ID: "https://didcomm.org/present-proof/3.0"},
+				{FeatureType: discoverfeatures.FeatureTypeProtocol, ID: "https://didcomm.org/present-proof/2.0"},
+			}, nil
+		},
+	}
+
+	client, err := New(&mockprovider.Provider{ServiceValue: svc})
+	require.NoError(t, err)
+
+	pid, err := client.SelectProtocol("connID", presentproof.SpecV3, presentproof.SpecV2)
+	require.NoError(t, err)
+	require.Equal(t, presentproof.SpecV2, pid)
+
+	_, err = client.SelectProtocol("connID", presentproof.SpecV3)
+	require.ErrorIs(t, err, ErrNoSupportedProtocol)
+
+	svc.DisclosuresErr = errors.New("store error")
+
+	_, err = client.SelectProtocol("connID", presentproof.SpecV3)
+	require.EqualError(t, err, "discover features client - disclosures: store error")
+}
+
+func TestDisclosurePolicy(t *testing.T) {
+	svc := &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}
+
+	client, err := New(&mockprovider.Provider{ServiceValue: svc})
+	require.NoError(t, err)
+
+	client.SetDisclosurePolicy(func(*Disclosure, string) bool { return false })
+	require.NotNil(t, svc.Policy)
+
+	client.AddGoalCodes("issue-vc")
+	require.Equal(t, []string{"issue-vc"}, svc.GoalCodes)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/invitationurl"
+)
+
+// HTTPClient fetches shortened invitation URLs.
+type HTTPClient = invitationurl.HTTPClient
+
+// URLOption configures the decoding of invitation URLs.
+type URLOption = invitationurl.URLOption
+
+// WithHTTPClient sets the HTTP client fetching shortened invitation URLs. By default, redirects to the full
+// invitation URL are followed up to 5 times.
+func WithHTTPClient(client HTTPClient) URLOption {
+	return invitationurl.WithHTTPClient(client)
+}
+
+// InvitationURL returns the invitation URL of the invitation, its JSON is base64url encoded in the `c_i` query
+// parameter of the base URL:
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0160-connection-protocol#standard-invitation-encoding
+func InvitationURL(baseURL string, i *Invitation) (string, error) {
+	invitationURL, err := invitationurl.Encode(baseURL, invitationurl.ConnectionParam, i)
+	if err != nil {
+		return "", fmt.Errorf("connection invitation URL: %w", err)
+	}
+
+	return invitationURL, nil
+}
+
+// ParseInvitationURL decodes the invitation of an invitation URL with the `c_i` query parameter. Shortened URLs are
+// fetched, the response either redirects to the full invitation URL or holds the invitation JSON.
+func ParseInvitationURL(invitationURL string, opts ...URLOption) (*Invitation, error) {
+	i := &legacyconnection.Invitation{}
+
+	err := invitationurl.Decode(invitationURL, invitationurl.ConnectionParam, i, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse connection invitation URL: %w", err)
+	}
+
+	return &Invitation{Invitation: i}, nil
+}
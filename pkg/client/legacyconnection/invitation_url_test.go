@@ -0,0 +1,46 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package legacyconnection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
+)
+
+func TestInvitationURL(t *testing.T) {
+	t.Run("encode and parse", func(t *testing.T) {
+		inv := &Invitation{&legacyconnection.Invitation{
+			ID:              "inv-id",
+			Type:            legacyconnection.InvitationMsgType,
+			Label:           "label",
+			RecipientKeys:   []string{"8HH5gYEeNc3z7PYXmd54d4x6qAfCNrqQqEB3nS7Zfu7K"},
+			ServiceEndpoint: "https://example.com/endpoint",
+		}}
+
+		invitationURL, err := InvitationURL("https://example.com/invite", inv)
+		require.NoError(t, err)
+		require.Contains(t, invitationURL, "https://example.com/invite?c_i=")
+
+		parsed, err := ParseInvitationURL(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, inv, parsed)
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		_, err := InvitationURL(":", &Invitation{&legacyconnection.Invitation{}})
+		require.ErrorContains(t, err, "connection invitation URL")
+	})
+
+	t.Run("not a connection invitation URL", func(t *testing.T) {
+		_, err := ParseInvitationURL("didcomm://invite?oob=e30")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse connection invitation URL")
+	})
+}
@@ -43,6 +43,9 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config(connID string) (*mediator.Config, error)
+
+	// KeylistQuery queries the keys routed by the router.
+	KeylistQuery(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error)
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -52,6 +55,14 @@ func WithTimeout(t time.Duration) mediator.ClientOption {
 	}
 }
 
+// WithCoordinationV2 option is for registering with the router using the coordinate mediation 2.0 protocol.
+// The connection with the router must be a DIDComm V2 connection.
+func WithCoordinationV2() mediator.ClientOption {
+	return func(opts *mediator.ClientOptions) {
+		opts.CoordinationV2 = true
+	}
+}
+
 // New return new instance of route client.
 func New(ctx provider, options ...mediator.ClientOption) (*Client, error) {
 	svc, err := ctx.Service(mediator.Coordination)
@@ -72,9 +83,11 @@ func New(ctx provider, options ...mediator.ClientOption) (*Client, error) {
 }
 
 // Register the agent with the router(passed in connectionID). This function asks router's
-// permission to publish it's endpoint and routing keys.
-func (c *Client) Register(connectionID string) error {
-	if err := c.routeSvc.Register(connectionID, c.options...); err != nil {
+// permission to publish it's endpoint and routing keys. The options override the options the client was created with.
+func (c *Client) Register(connectionID string, options ...mediator.ClientOption) error {
+	opts := append(append([]mediator.ClientOption{}, c.options...), options...)
+
+	if err := c.routeSvc.Register(connectionID, opts...); err != nil {
 		return fmt.Errorf("router registration : %w", err)
 	}
 
@@ -109,3 +122,14 @@ func (c *Client) GetConfig(connID string) (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// GetKeylist queries the keys the router routes for the agent (passed in connectionID). The whole key list
+// is returned when paginate is nil.
+func (c *Client) GetKeylist(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+	keylist, err := c.routeSvc.KeylistQuery(connID, paginate)
+	if err != nil {
+		return nil, fmt.Errorf("router keylist query : %w", err)
+	}
+
+	return keylist, nil
+}
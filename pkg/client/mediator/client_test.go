@@ -54,6 +54,13 @@ func TestNew(t *testing.T) {
 
 		require.Equal(t, timeout, opts.Timeout)
 	})
+
+	t.Run("test coordination v2 is applied to options", func(t *testing.T) {
+		opts := &mediator.ClientOptions{}
+		WithCoordinationV2()(opts)
+
+		require.True(t, opts.CoordinationV2)
+	})
 }
 
 func TestRegister(t *testing.T) {
@@ -69,6 +76,26 @@ func TestRegister(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test register - with options", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{RegisterFunc: func(connectionID string, options ...mediator.ClientOption) error { // nolint: lll
+				opts := &mediator.ClientOptions{}
+				for _, option := range options {
+					option(opts)
+				}
+
+				require.Equal(t, time.Second, opts.Timeout)
+				require.True(t, opts.CoordinationV2)
+
+				return nil
+			}},
+		}, WithTimeout(time.Second))
+		require.NoError(t, err)
+
+		err = c.Register("conn", WithCoordinationV2())
+		require.NoError(t, err)
+	})
+
 	t.Run("test register - error", func(t *testing.T) {
 		c, err := New(&mockprovider.Provider{
 			ServiceValue: &mockroute.MockMediatorSvc{RegisterFunc: func(connectionID string, options ...mediator.ClientOption) error { // nolint: lll
@@ -170,3 +197,38 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestGetKeylist(t *testing.T) {
+	t.Run("test get keylist - success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryFunc: func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+					require.Equal(t, "conn", connID)
+					require.Equal(t, 10, paginate.Limit)
+
+					return &mediator.Keylist{Keys: []mediator.KeylistKey{{RecipientKey: "key"}}}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		keylist, err := c.GetKeylist("conn", &mediator.Paginate{Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, "key", keylist.Keys[0].RecipientKey)
+	})
+
+	t.Run("test get keylist - error", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryFunc: func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+					return nil, errors.New("query error")
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = c.GetKeylist("conn", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "router keylist query")
+	})
+}
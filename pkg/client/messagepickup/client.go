@@ -33,6 +33,12 @@ type protocolService interface {
 	BatchPickup(connectionID string, size int) (int, error)
 
 	Noop(connectionID string) error
+
+	StatusRequestV3(connectionID string) (*messagepickup.StatusV3, error)
+
+	DeliveryRequest(connectionID string, limit int) (int, error)
+
+	LiveDeliveryChange(connectionID string, enabled bool) error
 }
 
 // New return new instance of messagepickup client.
@@ -78,3 +84,35 @@ func (r *Client) BatchPickup(connectionID string, size int) (int, error) {
 func (r *Client) Noop(connectionID string) error {
 	return r.messagepickupSvc.Noop(connectionID)
 }
+
+// StatusRequestV3 request the status of the Pickup 3.0 message queue.
+func (r *Client) StatusRequestV3(connectionID string) (*messagepickup.StatusV3, error) {
+	sts, err := r.messagepickupSvc.StatusRequestV3(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("message pickup client - status request v3: %w", err)
+	}
+
+	return sts, nil
+}
+
+// DeliveryRequest request up to limit queued messages using Pickup 3.0. Delivered messages are acknowledged,
+// so that the mediator removes them from the queue.
+func (r *Client) DeliveryRequest(connectionID string, limit int) (int, error) {
+	count, err := r.messagepickupSvc.DeliveryRequest(connectionID, limit)
+	if err != nil {
+		return -1, fmt.Errorf("message pickup client - delivery request: %w", err)
+	}
+
+	return count, nil
+}
+
+// LiveDeliveryChange enable or disable Pickup 3.0 live delivery, where the mediator pushes messages over the
+// current session as soon as they arrive.
+func (r *Client) LiveDeliveryChange(connectionID string, enabled bool) error {
+	err := r.messagepickupSvc.LiveDeliveryChange(connectionID, enabled)
+	if err != nil {
+		return fmt.Errorf("message pickup client - live delivery change: %w", err)
+	}
+
+	return nil
+}
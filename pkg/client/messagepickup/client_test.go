@@ -113,3 +113,77 @@ func TestNoop(t *testing.T) {
 		require.Contains(t, err.Error(), "service error")
 	})
 }
+
+func TestStatusRequestV3(t *testing.T) {
+	t.Run("status request v3 - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{},
+		})
+		require.NoError(t, err)
+
+		_, err = client.StatusRequestV3("connID")
+		require.NoError(t, err)
+	})
+
+	t.Run("status request v3 - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				StatusRequestV3Err: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.StatusRequestV3("connID")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+}
+
+func TestDeliveryRequest(t *testing.T) {
+	t.Run("delivery request - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{},
+		})
+		require.NoError(t, err)
+
+		_, err = client.DeliveryRequest("connID", 10)
+		require.NoError(t, err)
+	})
+
+	t.Run("delivery request - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				DeliveryRequestErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.DeliveryRequest("connID", 10)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+}
+
+func TestLiveDeliveryChange(t *testing.T) {
+	t.Run("live delivery change - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, client.LiveDeliveryChange("connID", true))
+	})
+
+	t.Run("live delivery change - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				LiveDeliveryChangeErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		err = client.LiveDeliveryChange("connID", true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofband
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitationURL(t *testing.T) {
+	t.Run("encode and parse", func(t *testing.T) {
+		inv := &Invitation{
+			ID:       "inv-id",
+			Type:     InvitationMsgType,
+			Label:    "label",
+			Services: []interface{}{"did:example:123"},
+		}
+
+		invitationURL, err := InvitationURL("https://example.com/invite", inv)
+		require.NoError(t, err)
+		require.Contains(t, invitationURL, "https://example.com/invite?oob=")
+
+		parsed, err := ParseInvitationURL(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, inv, parsed)
+	})
+
+	t.Run("parse shortened URL", func(t *testing.T) {
+		fullURL, err := InvitationURL("https://example.com/invite", &Invitation{ID: "inv-id"})
+		require.NoError(t, err)
+
+		server := httptest.NewServer(http.RedirectHandler(fullURL, http.StatusFound))
+		defer server.Close()
+
+		parsed, err := ParseInvitationURL(server.URL, WithHTTPClient(&http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, "inv-id", parsed.ID)
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		_, err := InvitationURL(":", &Invitation{})
+		require.ErrorContains(t, err, "out-of-band invitation URL")
+	})
+
+	t.Run("not an out-of-band invitation URL", func(t *testing.T) {
+		_, err := ParseInvitationURL("didcomm://invite?c_i=e30")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse out-of-band invitation URL")
+	})
+}
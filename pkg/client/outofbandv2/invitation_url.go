@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import (
+	"fmt"
+
+	oobv2 "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/invitationurl"
+)
+
+// HTTPClient fetches shortened invitation URLs.
+type HTTPClient = invitationurl.HTTPClient
+
+// URLOption configures the decoding of invitation URLs.
+type URLOption = invitationurl.URLOption
+
+// WithHTTPClient sets the HTTP client fetching shortened invitation URLs. By default, redirects to the full
+// invitation URL are followed up to 5 times.
+func WithHTTPClient(client HTTPClient) URLOption {
+	return invitationurl.WithHTTPClient(client)
+}
+
+// InvitationURL returns the invitation URL of the invitation, its JSON is base64url encoded in the `_oob` query
+// parameter of the base URL:
+// https://identity.foundation/didcomm-messaging/spec/#standard-message-encoding
+func InvitationURL(baseURL string, i *oobv2.Invitation) (string, error) {
+	invitationURL, err := invitationurl.Encode(baseURL, invitationurl.OOBV2Param, i)
+	if err != nil {
+		return "", fmt.Errorf("out-of-band/2.0 invitation URL: %w", err)
+	}
+
+	return invitationURL, nil
+}
+
+// ParseInvitationURL decodes the invitation of an invitation URL with the `_oob` query parameter. Shortened URLs are
+// fetched, the response either redirects to the full invitation URL or holds the invitation JSON.
+func ParseInvitationURL(invitationURL string, opts ...URLOption) (*oobv2.Invitation, error) {
+	i := &oobv2.Invitation{}
+
+	err := invitationurl.Decode(invitationURL, invitationurl.OOBV2Param, i, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse out-of-band/2.0 invitation URL: %w", err)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	oobv2 "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+)
+
+func TestInvitationURL(t *testing.T) {
+	inv := &oobv2.Invitation{
+		ID:   "inv-id",
+		Type: InvitationMsgType,
+		From: "did:example:123",
+		Body: &oobv2.InvitationBody{GoalCode: "issue-vc", Accept: []string{"didcomm/v2"}},
+	}
+
+	t.Run("encode and parse", func(t *testing.T) {
+		invitationURL, err := InvitationURL("https://example.com/invite", inv)
+		require.NoError(t, err)
+		require.Contains(t, invitationURL, "https://example.com/invite?_oob=")
+
+		parsed, err := ParseInvitationURL(invitationURL)
+		require.NoError(t, err)
+		require.Equal(t, inv, parsed)
+	})
+
+	t.Run("parse shortened URL serving the invitation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			_, _ = rw.Write([]byte(`{"id":"inv-id","from":"did:example:123"}`)) // nolint: errcheck
+		}))
+		defer server.Close()
+
+		parsed, err := ParseInvitationURL(server.URL, WithHTTPClient(http.DefaultClient))
+		require.NoError(t, err)
+		require.Equal(t, "inv-id", parsed.ID)
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		_, err := InvitationURL(":", inv)
+		require.ErrorContains(t, err, "out-of-band/2.0 invitation URL")
+	})
+
+	t.Run("not an out-of-band/2.0 invitation URL", func(t *testing.T) {
+		_, err := ParseInvitationURL("didcomm://invite?oob=e30")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse out-of-band/2.0 invitation URL")
+	})
+}
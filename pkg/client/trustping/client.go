@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// DefaultTimeout is the default time to wait for a ping response.
+const DefaultTimeout = 10 * time.Second
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	Ping(connectionID string, options ...trustping.PingOption) (*trustping.PingResult, error)
+}
+
+// Client enable access to trust ping api.
+type Client struct {
+	trustpingSvc protocolService
+}
+
+// PingOption configures a ping sent by the client.
+type PingOption func(opts *pingOpts)
+
+type pingOpts struct {
+	comment string
+	timeout time.Duration
+}
+
+// WithComment sets the comment of the ping, only DIDComm V1 pings have a comment.
+func WithComment(comment string) PingOption {
+	return func(opts *pingOpts) {
+		opts.comment = comment
+	}
+}
+
+// WithTimeout sets how long to wait for the ping response, defaults to DefaultTimeout.
+func WithTimeout(timeout time.Duration) PingOption {
+	return func(opts *pingOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// New return new instance of trust ping client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(trustping.TrustPing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trust ping service: %w", err)
+	}
+
+	trustpingSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to trust ping service failed")
+	}
+
+	return &Client{
+		trustpingSvc: trustpingSvc,
+	}, nil
+}
+
+// Ping sends a trust ping requesting a response over the connection, and waits for the response. The result holds
+// the round-trip time of the ping. The DIDComm version of the ping is the one of the connection.
+func (c *Client) Ping(connectionID string, options ...PingOption) (*trustping.PingResult, error) {
+	if connectionID == "" {
+		return nil, errors.New("trust ping client - ping: connection ID is required")
+	}
+
+	opts := &pingOpts{timeout: DefaultTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	result, err := c.trustpingSvc.Ping(connectionID,
+		trustping.WithComment(opts.comment), trustping.WithTimeout(opts.timeout))
+	if err != nil {
+		return nil, fmt.Errorf("trust ping client - ping: %w", err)
+	}
+
+	return result, nil
+}
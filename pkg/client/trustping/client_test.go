@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to trust ping service failed")
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("ping - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(connectionID string, options ...trustping.PingOption) (*trustping.PingResult, error) {
+					require.Equal(t, "connID", connectionID)
+					require.Len(t, options, 2)
+
+					return &trustping.PingResult{PingID: "ping-id", RoundTrip: time.Millisecond}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := client.Ping("connID", WithComment("hello"), WithTimeout(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, "ping-id", result.PingID)
+		require.Equal(t, time.Millisecond, result.RoundTrip)
+	})
+
+	t.Run("ping - missing connection ID", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Ping("")
+		require.EqualError(t, err, "trust ping client - ping: connection ID is required")
+	})
+
+	t.Run("ping - timeout", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingErr: trustping.ErrTimeout,
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Ping("connID")
+		require.True(t, errors.Is(err, trustping.ErrTimeout))
+	})
+}
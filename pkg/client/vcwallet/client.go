@@ -9,7 +9,6 @@ package vcwallet
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/piprate/json-gold/ld"
 
@@ -152,10 +151,11 @@ func (c *Client) Close() bool {
 }
 
 // Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// All wallet contents and private keys of local wallet key manager are exported into
+// Universal Wallet EncryptedWallet locked by given passphrase.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- passphrase: passphrase to be used to lock the wallet before exporting.
 //
 //	Returns exported locked wallet.
 //
@@ -165,18 +165,24 @@ func (c *Client) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Client) Export(passphrase string) (json.RawMessage, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.Export(auth, passphrase)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- passphrase: passphrase used while exporting the wallet.
+//		- contents: exported wallet to be imported.
+//		- options: options for importing, like resolution of conflicts with existing contents.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -186,9 +192,13 @@ func (c *Client) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Client) Import(passphrase string, contents json.RawMessage, options ...wallet.ImportOptions) error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	return c.wallet.Import(auth, passphrase, contents, options...)
 }
 
 // Add adds given data model to wallet contents store.
@@ -319,15 +329,29 @@ func (c *Client) Prove(opts *wallet.ProofOptions, creds ...wallet.ProveOptions)
 //
 //	Args:
 //		- verification option for sending different models (stored credential ID, raw credential, raw presentation).
+//		- optional status check and verification policy options.
 //
 // Returns: a boolean verified, and an error if verified is false.
-func (c *Client) Verify(option wallet.VerificationOption) (bool, error) {
+func (c *Client) Verify(options ...wallet.VerificationOption) (bool, error) {
 	auth, err := c.auth()
 	if err != nil {
 		return false, err
 	}
 
-	return c.wallet.Verify(auth, option)
+	return c.wallet.Verify(auth, options...)
+}
+
+// VerifyWithReport takes a Verifiable Credential or Verifiable Presentation as input and verifies it like Verify.
+//
+// Returns: the report of the checks of the verification policy, and an error if the proof or data model
+// verification fails.
+func (c *Client) VerifyWithReport(options ...wallet.VerificationOption) (*verifiable.VerificationReport, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.VerifyWithReport(auth, options...)
 }
 
 // Derive derives a credential and returns response credential.
@@ -350,14 +374,15 @@ func (c *Client) Derive(credential wallet.CredentialToDerive, options *wallet.De
 //	Args:
 //		- authToken: authorization for performing create key pair operation.
 //		- keyType: type of the key to be created.
+//		- opts: options for the key to be created (ex: purpose, labels or expiry).
 //
-func (c *Client) CreateKeyPair(keyType kms.KeyType) (*wallet.KeyPair, error) {
+func (c *Client) CreateKeyPair(keyType kms.KeyType, opts ...kms.KeyOpts) (*wallet.KeyPair, error) {
 	auth, err := c.auth()
 	if err != nil {
 		return nil, err
 	}
 
-	return c.wallet.CreateKeyPair(auth, keyType)
+	return c.wallet.CreateKeyPair(auth, keyType, opts...)
 }
 
 // Connect accepts out-of-band invitations and performs DID exchange.
@@ -60,7 +60,6 @@ const (
 	sampleRemoteKMSAuth     = "sample-auth-token"
 	sampleKeyServerURL      = "sample/keyserver/test"
 	sampleUserID            = "sample-user01"
-	toBeImplementedErr      = "to be implemented"
 	sampleClientErr         = "sample client err"
 	sampleDIDKey            = "did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5"
 	sampleDIDKey2           = "did:key:z6MkwFKUCsf8wvn6eSSu1WFAKatN1yexiDM7bf7pZLSFjdz6"
@@ -454,33 +453,53 @@ func TestClient_OpenClose(t *testing.T) {
 	})
 }
 
-func TestClient_Export(t *testing.T) {
+func TestClient_ExportImport(t *testing.T) {
+	const exportPassphrase = "export-passphrase"
+
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
-	require.NoError(t, err)
+	user := uuid.New().String()
+	require.NoError(t, CreateProfile(user, mockctx, wallet.WithPassphrase(samplePassPhrase)))
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
+	vcWalletClient, err := New(user, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
 	require.NotEmpty(t, vcWalletClient)
 	require.NoError(t, err)
 
-	result, err := vcWalletClient.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+	require.NoError(t, vcWalletClient.Add(wallet.Metadata, testdata.SampleWalletContentMetadata))
 
-func TestClient_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	exported, err := vcWalletClient.Export(exportPassphrase)
 	require.NoError(t, err)
+	require.NotEmpty(t, exported)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, vcWalletClient)
+	importCtx := newMockProvider(t)
+	importUser := uuid.New().String()
+	require.NoError(t, CreateProfile(importUser, importCtx, wallet.WithPassphrase(samplePassPhrase)))
+
+	importClient, err := New(importUser, importCtx, wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NotEmpty(t, importClient)
 	require.NoError(t, err)
 
-	err = vcWalletClient.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+	require.NoError(t, importClient.Import(exportPassphrase, exported))
+
+	content, err := importClient.Get(wallet.Metadata, "did:example:123456789abcdefghi")
+	require.NoError(t, err)
+	require.JSONEq(t, string(testdata.SampleWalletContentMetadata), string(content))
+
+	err = importClient.Import(exportPassphrase, exported)
+	require.True(t, errors.Is(err, wallet.ErrImportConflict))
+
+	require.NoError(t, importClient.Import(exportPassphrase, exported,
+		wallet.WithImportConflict(wallet.ImportConflictSkip)))
+
+	// try locked wallet
+	require.True(t, vcWalletClient.Close())
+	require.True(t, importClient.Close())
+
+	exported, err = vcWalletClient.Export(exportPassphrase)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, exported)
+
+	err = importClient.Import(exportPassphrase, content)
+	require.True(t, errors.Is(err, ErrWalletLocked))
 }
 
 func TestClient_Add(t *testing.T) {
@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid discover features controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.DiscoverFeatures)
+	// QueryErrorCode is for failures in query command.
+	QueryErrorCode
+	// DisclosuresErrorCode is for failures in disclosures command.
+	DisclosuresErrorCode
+)
+
+// constants for discover features.
+const (
+	// command name.
+	CommandName = "discoverfeatures"
+	Query       = "Query"
+	Disclosures = "Disclosures"
+
+	// error messages.
+	errEmptyConnID = "empty connection ID"
+
+	// log constants.
+	connectionIDString = "connectionID"
+	successString      = "success"
+)
+
+var logger = log.New("aries-framework/controller/discoverfeatures")
+
+// Provider contains dependencies for the discover features command and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command is controller command for discover features.
+type Command struct {
+	client *discoverfeatures.Client
+}
+
+// New returns new discover features controller command instance.
+func New(ctx Provider) (*Command, error) {
+	client, err := discoverfeatures.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, Query, c.Query),
+		cmdutil.NewCommandHandler(CommandName, Disclosures, c.Disclosures),
+	}
+}
+
+// Query queries the features of the other party of a connection and waits for their disclosure.
+func (c *Command) Query(rw io.Writer, req io.Reader) command.Error {
+	var args QueryArgs
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Query, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, Query, errEmptyConnID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnID))
+	}
+
+	opts := []discoverfeatures.QueryOption{discoverfeatures.WithQueries(args.Queries...)}
+
+	if args.Timeout > 0 {
+		opts = append(opts, discoverfeatures.WithTimeout(args.Timeout))
+	}
+
+	disclosures, err := c.client.Query(args.ConnectionID, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, Query, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+		return command.NewExecuteError(QueryErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &QueryResponse{Disclosures: disclosures}, logger)
+
+	logutil.LogDebug(logger, CommandName, Query, successString,
+		logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+
+	return nil
+}
+
+// Disclosures returns the features disclosed by the other party of a connection, they are queried when they were
+// never disclosed.
+func (c *Command) Disclosures(rw io.Writer, req io.Reader) command.Error {
+	var args DisclosuresArgs
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Disclosures, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, Disclosures, errEmptyConnID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnID))
+	}
+
+	disclosures, err := c.client.Disclosures(args.ConnectionID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, Disclosures, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+		return command.NewExecuteError(DisclosuresErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &DisclosuresResponse{Disclosures: disclosures}, logger)
+
+	logutil.LogDebug(logger, CommandName, Disclosures, successString,
+		logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+
+	return nil
+}
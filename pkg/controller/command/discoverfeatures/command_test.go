@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 2)
+	})
+
+	t.Run("Create client (error)", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.EqualError(t, err, "cannot create a client: failed to create discover features service: service error")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Query(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			QueryFunc: func(connectionID string,
+				options ...discoverfeatures.QueryOption) ([]mockdiscoverfeatures.Disclosure, error) {
+				require.Equal(t, "conn-id", connectionID)
+				require.Len(t, options, 2)
+
+				return []discoverfeatures.Disclosure{{FeatureType: "goal-code", ID: "issue-vc"}}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString(`{"connection_id":"conn-id",`+
+			`"queries":[{"feature-type":"goal-code","match":"*"}],"timeout":1000000}`))
+		require.NoError(t, cmdErr)
+
+		res := QueryResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, []discoverfeatures.Disclosure{{FeatureType: "goal-code", ID: "issue-vc"}}, res.Disclosures)
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString("}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty connection ID", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString("{}"))
+		require.EqualError(t, cmdErr, errEmptyConnID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			QueryErr: errors.New("query error"),
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString(`{"connection_id":"conn-id"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "query error")
+		require.Equal(t, QueryErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
+func TestCommand_Disclosures(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			DisclosuresFunc: func(connectionID string) ([]discoverfeatures.Disclosure, error) {
+				require.Equal(t, "conn-id", connectionID)
+
+				return []discoverfeatures.Disclosure{{FeatureType: "goal-code", ID: "issue-vc"}}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Disclosures(&b, bytes.NewBufferString(`{"connection_id":"conn-id"}`))
+		require.NoError(t, cmdErr)
+
+		res := DisclosuresResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.Disclosures, 1)
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Disclosures(&b, bytes.NewBufferString("}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Empty connection ID", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Disclosures(&b, bytes.NewBufferString("{}"))
+		require.EqualError(t, cmdErr, errEmptyConnID)
+	})
+
+	t.Run("Disclosures error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			DisclosuresErr: errors.New("store error"),
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Disclosures(&b, bytes.NewBufferString(`{"connection_id":"conn-id"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, DisclosuresErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/discoverfeatures"
+)
+
+// QueryArgs model
+//
+// This is used for querying the features of the other party of a connection.
+type QueryArgs struct {
+	// ConnectionID of the connection to query.
+	ConnectionID string `json:"connection_id"`
+
+	// Queries of the features, all features are queried by default.
+	// DIDComm V1 connections only support a single protocol query.
+	Queries []discoverfeatures.FeatureQuery `json:"queries,omitempty"`
+
+	// Timeout waiting for the disclosure, defaults to 10 seconds.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// QueryResponse model
+//
+// Represents the features disclosed in response to a query.
+type QueryResponse struct {
+	Disclosures []discoverfeatures.Disclosure `json:"disclosures"`
+}
+
+// DisclosuresArgs model
+//
+// This is used for getting the features disclosed by the other party of a connection.
+type DisclosuresArgs struct {
+	// ConnectionID of the connection.
+	ConnectionID string `json:"connection_id"`
+}
+
+// DisclosuresResponse model
+//
+// Represents the features disclosed by the other party of a connection.
+type DisclosuresResponse struct {
+	Disclosures []discoverfeatures.Disclosure `json:"disclosures"`
+}
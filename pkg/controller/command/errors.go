@@ -83,6 +83,12 @@ const (
 
 	// LegacyConnection error group for legacyconnection command errors.
 	LegacyConnection = 16000
+
+	// TrustPing error group for trust ping command errors.
+	TrustPing = 17000
+
+	// DiscoverFeatures error group for discover features command errors.
+	DiscoverFeatures = 18000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
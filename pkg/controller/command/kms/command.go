@@ -31,6 +31,14 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// ListKeysError is for failures while listing keys.
+	ListKeysError
+	// DeleteKeyError is for failures while deleting key.
+	DeleteKeyError
+	// GetKeyMetadataError is for failures while getting key metadata.
+	GetKeyMetadataError
+	// UpdateKeyMetadataError is for failures while updating key metadata.
+	UpdateKeyMetadataError
 )
 
 // constants for KMS commands.
@@ -39,12 +47,17 @@ const (
 	CommandName = "kms"
 
 	// command methods.
-	CreateKeySetCommandMethod = "CreateKeySet"
-	ImportKeyCommandMethod    = "ImportKey"
+	CreateKeySetCommandMethod      = "CreateKeySet"
+	ImportKeyCommandMethod         = "ImportKey"
+	ListKeysCommandMethod          = "ListKeys"
+	DeleteKeyCommandMethod         = "DeleteKey"
+	GetKeyMetadataCommandMethod    = "GetKeyMetadata"
+	UpdateKeyMetadataCommandMethod = "UpdateKeyMetadata"
 
 	// error messages.
-	errEmptyKeyType = "key type is mandatory"
-	errEmptyKeyID   = "key id is mandatory"
+	errEmptyKeyType          = "key type is mandatory"
+	errEmptyKeyID            = "key id is mandatory"
+	errLifecycleNotSupported = "key manager does not support key lifecycle management"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -75,6 +88,10 @@ func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateKeySetCommandMethod, o.CreateKeySet),
 		cmdutil.NewCommandHandler(CommandName, ImportKeyCommandMethod, o.ImportKey),
+		cmdutil.NewCommandHandler(CommandName, ListKeysCommandMethod, o.ListKeys),
+		cmdutil.NewCommandHandler(CommandName, DeleteKeyCommandMethod, o.DeleteKey),
+		cmdutil.NewCommandHandler(CommandName, GetKeyMetadataCommandMethod, o.GetKeyMetadata),
+		cmdutil.NewCommandHandler(CommandName, UpdateKeyMetadataCommandMethod, o.UpdateKeyMetadata),
 	}
 }
 
@@ -93,7 +110,8 @@ func (o *Command) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyType))
 	}
 
-	keyID, pubKeyBytes, err := o.ctx.KMS().CreateAndExportPubKeyBytes(kms.KeyType(request.KeyType))
+	keyID, pubKeyBytes, err := o.ctx.KMS().CreateAndExportPubKeyBytes(kms.KeyType(request.KeyType),
+		createKeyOpts(&request)...)
 	if err != nil {
 		logutil.LogError(logger, CommandName, CreateKeySetCommandMethod, err.Error())
 		return command.NewExecuteError(CreateKeySetError, err)
@@ -160,3 +178,177 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// ListKeys lists the metadata of keys matching the given purpose and labels.
+func (o *Command) ListKeys(rw io.Writer, req io.Reader) command.Error {
+	var request ListKeysRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	km, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewExecuteError(ListKeysError, err)
+	}
+
+	var opts []kms.ListOpts
+
+	if request.Purpose != "" {
+		opts = append(opts, kms.WithPurposeFilter(request.Purpose))
+	}
+
+	for name, value := range request.Labels {
+		opts = append(opts, kms.WithLabelFilter(name, value))
+	}
+
+	keys, err := km.List(opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewExecuteError(ListKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, &ListKeysResponse{Keys: keys}, logger)
+
+	logutil.LogDebug(logger, CommandName, ListKeysCommandMethod, "success")
+
+	return nil
+}
+
+// DeleteKey deletes a key along with its metadata.
+func (o *Command) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	var request KeyIDRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeleteKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, DeleteKeyCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	km, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeleteKeyCommandMethod, err.Error())
+		return command.NewExecuteError(DeleteKeyError, err)
+	}
+
+	err = km.Delete(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeleteKeyCommandMethod, err.Error())
+		return command.NewExecuteError(DeleteKeyError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, DeleteKeyCommandMethod, "success")
+
+	return nil
+}
+
+// GetKeyMetadata returns the metadata of a key.
+func (o *Command) GetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	var request KeyIDRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetKeyMetadataCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, GetKeyMetadataCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	km, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(GetKeyMetadataError, err)
+	}
+
+	metadata, err := km.GetMetadata(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(GetKeyMetadataError, err)
+	}
+
+	command.WriteNillableResponse(rw, &GetKeyMetadataResponse{Metadata: metadata}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetKeyMetadataCommandMethod, "success")
+
+	return nil
+}
+
+// UpdateKeyMetadata replaces the purpose, labels, expiry and disabled state of a key.
+func (o *Command) UpdateKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	var request UpdateKeyMetadataRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateKeyMetadataCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, UpdateKeyMetadataCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	km, err := o.lifecycleManager()
+	if err != nil {
+		logutil.LogError(logger, CommandName, UpdateKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(UpdateKeyMetadataError, err)
+	}
+
+	err = km.UpdateMetadata(request.KeyID, &kms.KeyMetadata{
+		KeyID:     request.KeyID,
+		Purpose:   request.Purpose,
+		Labels:    request.Labels,
+		ExpiresAt: request.ExpiresAt,
+		Disabled:  request.Disabled,
+	})
+	if err != nil {
+		logutil.LogError(logger, CommandName, UpdateKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(UpdateKeyMetadataError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, UpdateKeyMetadataCommandMethod, "success")
+
+	return nil
+}
+
+func (o *Command) lifecycleManager() (kms.KeyLifecycleManager, error) {
+	km, ok := o.ctx.KMS().(kms.KeyLifecycleManager)
+	if !ok {
+		return nil, fmt.Errorf(errLifecycleNotSupported)
+	}
+
+	return km, nil
+}
+
+func createKeyOpts(request *CreateKeySetRequest) []kms.KeyOpts {
+	var opts []kms.KeyOpts
+
+	if request.Purpose != "" {
+		opts = append(opts, kms.WithPurpose(request.Purpose))
+	}
+
+	if len(request.Labels) > 0 {
+		opts = append(opts, kms.WithLabels(request.Labels))
+	}
+
+	if request.ExpiresAt != nil {
+		opts = append(opts, kms.WithExpiry(*request.ExpiresAt))
+	}
+
+	return opts
+}
@@ -34,7 +34,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 2, len(handlers))
+		require.Equal(t, 6, len(handlers))
 	})
 
 	t.Run("test new command - error from import key", func(t *testing.T) {
@@ -275,3 +275,92 @@ func TestImportKey(t *testing.T) {
 		require.Contains(t, err.Error(), "failed request decode")
 	})
 }
+
+// keyManager hides the lifecycle methods of the wrapped KeyManager.
+type keyManager struct {
+	kms.KeyManager
+}
+
+func TestKeyLifecycle(t *testing.T) {
+	metadata := &kms.KeyMetadata{KeyID: "keyID", KeyType: kms.ED25519Type, Purpose: "signing"}
+
+	t.Run("test list keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListValue: []*kms.KeyMetadata{metadata}},
+		})
+
+		reqBytes, err := json.Marshal(ListKeysRequest{Purpose: "signing", Labels: map[string]string{"env": "test"}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.ListKeys(&b, bytes.NewBuffer(reqBytes)))
+
+		var response ListKeysResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, []*kms.KeyMetadata{metadata}, response.Keys)
+	})
+
+	t.Run("test get key metadata - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetMetadataValue: metadata},
+		})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.GetKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"keyID"}`)))
+
+		var response GetKeyMetadataResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, metadata, response.Metadata)
+	})
+
+	t.Run("test delete and update key - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.DeleteKey(&b, bytes.NewBufferString(`{"keyID":"keyID"}`)))
+		require.NoError(t, cmd.UpdateKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"keyID","disabled":true}`)))
+	})
+
+	t.Run("test key lifecycle - errors", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{
+				ListErr:           fmt.Errorf("list error"),
+				DeleteErr:         fmt.Errorf("delete error"),
+				GetMetadataErr:    fmt.Errorf("get metadata error"),
+				UpdateMetadataErr: fmt.Errorf("update metadata error"),
+			},
+		})
+
+		var b bytes.Buffer
+
+		require.EqualError(t, cmd.ListKeys(&b, bytes.NewBufferString(`{}`)), "list error")
+		require.EqualError(t, cmd.DeleteKey(&b, bytes.NewBufferString(`{"keyID":"k"}`)), "delete error")
+		require.EqualError(t, cmd.GetKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"k"}`)), "get metadata error")
+		require.EqualError(t, cmd.UpdateKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"k"}`)),
+			"update metadata error")
+
+		require.Contains(t, cmd.ListKeys(&b, bytes.NewBuffer(nil)).Error(), "failed request decode")
+		require.Contains(t, cmd.DeleteKey(&b, bytes.NewBuffer(nil)).Error(), "failed request decode")
+		require.Contains(t, cmd.GetKeyMetadata(&b, bytes.NewBuffer(nil)).Error(), "failed request decode")
+		require.Contains(t, cmd.UpdateKeyMetadata(&b, bytes.NewBuffer(nil)).Error(), "failed request decode")
+
+		require.EqualError(t, cmd.DeleteKey(&b, bytes.NewBufferString(`{}`)), errEmptyKeyID)
+		require.EqualError(t, cmd.GetKeyMetadata(&b, bytes.NewBufferString(`{}`)), errEmptyKeyID)
+		require.EqualError(t, cmd.UpdateKeyMetadata(&b, bytes.NewBufferString(`{}`)), errEmptyKeyID)
+	})
+
+	t.Run("test key lifecycle - not supported by key manager", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &keyManager{KeyManager: &mockkms.KeyManager{}},
+		})
+
+		var b bytes.Buffer
+
+		require.EqualError(t, cmd.ListKeys(&b, bytes.NewBufferString(`{}`)), errLifecycleNotSupported)
+		require.EqualError(t, cmd.DeleteKey(&b, bytes.NewBufferString(`{"keyID":"k"}`)), errLifecycleNotSupported)
+		require.EqualError(t, cmd.GetKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"k"}`)),
+			errLifecycleNotSupported)
+		require.EqualError(t, cmd.UpdateKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"k"}`)),
+			errLifecycleNotSupported)
+	})
+}
@@ -6,9 +6,21 @@ SPDX-License-Identifier: Apache-2.0
 
 package kms
 
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
 // CreateKeySetRequest is model for createKeySey request.
 type CreateKeySetRequest struct {
 	KeyType string `json:"keyType,omitempty"`
+	// optional purpose of the key (ex: signing).
+	Purpose string `json:"purpose,omitempty"`
+	// optional labels of the key.
+	Labels map[string]string `json:"labels,omitempty"`
+	// optional expiry of the key, an expired key can't be used anymore.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // CreateKeySetResponse for returning key pair.
@@ -30,3 +42,36 @@ type JSONWebKey struct {
 	Y   string `json:"y,omitempty"`
 	D   string `json:"d,omitempty"`
 }
+
+// ListKeysRequest is model for listing keys.
+type ListKeysRequest struct {
+	// optional purpose the listed keys must have.
+	Purpose string `json:"purpose,omitempty"`
+	// optional labels the listed keys must have.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ListKeysResponse is model for list keys response.
+type ListKeysResponse struct {
+	Keys []*kms.KeyMetadata `json:"keys"`
+}
+
+// KeyIDRequest is model for requests referencing a key by its ID.
+type KeyIDRequest struct {
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// GetKeyMetadataResponse is model for get key metadata response.
+type GetKeyMetadataResponse struct {
+	Metadata *kms.KeyMetadata `json:"metadata"`
+}
+
+// UpdateKeyMetadataRequest is model for updating key metadata, the purpose, labels, expiry and disabled state of
+// the key are replaced.
+type UpdateKeyMetadataRequest struct {
+	KeyID     string            `json:"keyID,omitempty"`
+	Purpose   string            `json:"purpose,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	ExpiresAt *time.Time        `json:"expiresAt,omitempty"`
+	Disabled  bool              `json:"disabled,omitempty"`
+}
@@ -63,6 +63,12 @@ const (
 
 	// ReconnectAllError is typically a code for mediator reconnectAll errors.
 	ReconnectAllError
+
+	// KeylistMissingConnIDCode for connection ID validation error.
+	KeylistMissingConnIDCode
+
+	// KeylistRequestErrorCode for keylist query error.
+	KeylistRequestErrorCode
 )
 
 // constant for the mediator controller.
@@ -78,6 +84,7 @@ const (
 	StatusCommandMethod         = "Status"
 	BatchPickupCommandMethod    = "BatchPickup"
 	ReconnectAllCommandMethod   = "ReconnectAll"
+	KeylistCommandMethod        = "Keylist"
 
 	// log constants.
 	connectionID  = "connectionID"
@@ -151,6 +158,7 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, ReconnectAllCommandMethod, o.ReconnectAll),
 		cmdutil.NewCommandHandler(CommandName, StatusCommandMethod, o.Status),
 		cmdutil.NewCommandHandler(CommandName, BatchPickupCommandMethod, o.BatchPickup),
+		cmdutil.NewCommandHandler(CommandName, KeylistCommandMethod, o.Keylist),
 	}
 }
 
@@ -170,7 +178,13 @@ func (o *Command) Register(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(RegisterMissingConnIDCode, errors.New("connectionID is mandatory"))
 	}
 
-	err = o.routeClient.Register(request.ConnectionID)
+	var opts []mediatorSvc.ClientOption
+
+	if request.CoordinationV2 {
+		opts = append(opts, mediator.WithCoordinationV2())
+	}
+
+	err = o.routeClient.Register(request.ConnectionID, opts...)
 	if err != nil {
 		logutil.LogError(logger, CommandName, RegisterCommandMethod, err.Error(),
 			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
@@ -382,3 +396,46 @@ func (o *Command) BatchPickup(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// Keylist returns the keys the router routes for given connection.
+func (o *Command) Keylist(rw io.Writer, req io.Reader) command.Error {
+	var request KeylistRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, KeylistCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, KeylistCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(KeylistMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	var paginate *mediatorSvc.Paginate
+
+	if request.Limit > 0 || request.Offset > 0 {
+		paginate = &mediatorSvc.Paginate{Limit: request.Limit, Offset: request.Offset}
+	}
+
+	keylist, err := o.routeClient.GetKeylist(request.ConnectionID, paginate)
+	if err != nil {
+		logutil.LogError(logger, CommandName, KeylistCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(KeylistRequestErrorCode, err)
+	}
+
+	response := &KeylistResponse{Keys: []string{}, Pagination: keylist.Pagination}
+
+	for _, k := range keylist.Keys {
+		response.Keys = append(response.Keys, k.RecipientKey)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, KeylistCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
@@ -39,7 +39,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 7, len(handlers))
+		require.Equal(t, 8, len(handlers))
 	})
 
 	t.Run("test new command - client creation fail", func(t *testing.T) {
@@ -76,6 +76,36 @@ func TestCommand_Register(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test register - coordinate mediation 2.0", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						RegisterFunc: func(connectionID string, options ...mediator.ClientOption) error {
+							opts := &mediator.ClientOptions{}
+							for _, option := range options {
+								option(opts)
+							}
+
+							require.True(t, opts.CoordinationV2)
+
+							return nil
+						},
+					},
+					oobsvc.Name: &mockoob.MockOobService{},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err = cmd.Register(&b, bytes.NewBufferString(`{"connectionID":"123-abc","coordination_v2":true}`))
+		require.NoError(t, err)
+	})
+
 	t.Run("test register - empty connectionID", func(t *testing.T) {
 		cmd, err := New(newMockProvider(nil), false)
 		require.NoError(t, err)
@@ -455,6 +485,90 @@ func TestCommand_Status(t *testing.T) {
 	})
 }
 
+func TestCommand_Keylist(t *testing.T) {
+	t.Run("test keylist - success", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						KeylistQueryFunc: func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+							require.Equal(t, &mediator.Paginate{Limit: 1, Offset: 1}, paginate)
+
+							return &mediator.Keylist{
+								Keys:       []mediator.KeylistKey{{RecipientKey: "key-b"}},
+								Pagination: &mediator.Pagination{Count: 1, Offset: 1, Remaining: 1},
+							}, nil
+						},
+					},
+					oobsvc.Name: &mockoob.MockOobService{},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err = cmd.Keylist(&b, bytes.NewBufferString(`{"connectionID":"123-abc","limit":1,"offset":1}`))
+		require.NoError(t, err)
+
+		response := KeylistResponse{}
+		err = json.NewDecoder(&b).Decode(&response)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key-b"}, response.Keys)
+		require.Equal(t, 1, response.Pagination.Remaining)
+	})
+
+	t.Run("test keylist - empty connectionID", func(t *testing.T) {
+		cmd, err := New(newMockProvider(nil), false)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err = cmd.Keylist(&b, bytes.NewBufferString(sampleEmptyConnectionRequest))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "connectionID is mandatory")
+	})
+
+	t.Run("test keylist - invalid request", func(t *testing.T) {
+		cmd, err := New(newMockProvider(nil), false)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err = cmd.Keylist(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "request decode")
+	})
+
+	t.Run("test keylist - failure", func(t *testing.T) {
+		cmd, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+					mediator.Coordination: &mockroute.MockMediatorSvc{
+						KeylistQueryFunc: func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+							require.Nil(t, paginate)
+
+							return nil, errors.New("keylist error")
+						},
+					},
+					oobsvc.Name: &mockoob.MockOobService{},
+				},
+			},
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err = cmd.Keylist(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "keylist error")
+	})
+}
+
 func TestCommand_BatchPickup(t *testing.T) {
 	t.Run("test batch pickup - success", func(t *testing.T) {
 		const count = 64
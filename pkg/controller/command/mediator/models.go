@@ -8,12 +8,15 @@ package mediator
 
 import (
 	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 )
 
 // RegisterRoute contains parameters for registering/reconnecting router.
 type RegisterRoute struct {
 	ConnectionID string `json:"connectionID"`
+	// CoordinationV2 registers using the coordinate mediation 2.0 protocol, requires a DIDComm V2 connection.
+	CoordinationV2 bool `json:"coordination_v2,omitempty"`
 }
 
 // ConnectionsRequest contains parameters for filtering when requesting router connections.
@@ -51,6 +54,24 @@ type BatchPickupResponse struct {
 	MessageCount int `json:"message_count"`
 }
 
+// KeylistRequest is request for querying the keys routed by the router.
+type KeylistRequest struct {
+	// ConnectionID of the router connection.
+	ConnectionID string `json:"connectionID"`
+	// Limit of keys to be returned, all keys are returned if not set.
+	Limit int `json:"limit,omitempty"`
+	// Offset of the first key to be returned.
+	Offset int `json:"offset,omitempty"`
+}
+
+// KeylistResponse is response containing the keys routed by the router.
+type KeylistResponse struct {
+	// Keys routed by the router.
+	Keys []string `json:"keys"`
+	// Pagination of the returned keys.
+	Pagination *mediator.Pagination `json:"pagination,omitempty"`
+}
+
 // CreateInvitationRequest model
 //
 // This is used for creating an invitation using mediator.
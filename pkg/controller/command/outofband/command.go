@@ -37,22 +37,27 @@ const (
 	ActionsErrorCode
 	// ActionContinueErrorCode is for failures in action continue command.
 	ActionContinueErrorCode
+	// ParseInvitationURLErrorCode is for failures in parsing invitation URLs.
+	ParseInvitationURLErrorCode
 )
 
 // constants for out-of-band.
 const (
 	// command name.
-	CommandName      = "outofband"
-	CreateInvitation = "CreateInvitation"
-	AcceptInvitation = "AcceptInvitation"
-	ActionStop       = "ActionStop"
-	Actions          = "Actions"
-	ActionContinue   = "ActionContinue"
+	CommandName         = "outofband"
+	CreateInvitation    = "CreateInvitation"
+	AcceptInvitation    = "AcceptInvitation"
+	ActionStop          = "ActionStop"
+	Actions             = "Actions"
+	ActionContinue      = "ActionContinue"
+	AcceptInvitationURL = "AcceptInvitationURL"
+	ParseInvitationURL  = "ParseInvitationURL"
 
 	// error messages.
 	errEmptyRequest = "request was not provided"
 	errEmptyMyLabel = "my_label was not provided"
 	errEmptyPIID    = "piid was not provided"
+	errEmptyURL     = "invitation_url was not provided"
 	// log constants.
 	successString = "success"
 
@@ -100,6 +105,8 @@ func (c *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateInvitation, c.CreateInvitation),
 		cmdutil.NewCommandHandler(CommandName, AcceptInvitation, c.AcceptInvitation),
+		cmdutil.NewCommandHandler(CommandName, AcceptInvitationURL, c.AcceptInvitationURL),
+		cmdutil.NewCommandHandler(CommandName, ParseInvitationURL, c.ParseInvitationURL),
 		cmdutil.NewCommandHandler(CommandName, Actions, c.Actions),
 		cmdutil.NewCommandHandler(CommandName, ActionContinue, c.ActionContinue),
 		cmdutil.NewCommandHandler(CommandName, ActionStop, c.ActionStop),
@@ -129,8 +136,19 @@ func (c *Command) CreateInvitation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewExecuteError(CreateInvitationErrorCode, err)
 	}
 
+	var invitationURL string
+
+	if args.BaseURL != "" {
+		invitationURL, err = outofband.InvitationURL(args.BaseURL, invitation)
+		if err != nil {
+			logutil.LogError(logger, CommandName, CreateInvitation, err.Error())
+			return command.NewExecuteError(CreateInvitationErrorCode, err)
+		}
+	}
+
 	command.WriteNillableResponse(rw, &CreateInvitationResponse{
-		Invitation: invitation,
+		Invitation:    invitation,
+		InvitationURL: invitationURL,
 	}, logger)
 
 	logutil.LogDebug(logger, CommandName, CreateInvitation, successString)
@@ -146,13 +164,17 @@ func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
+	return c.acceptInvitation(rw, &args, AcceptInvitation)
+}
+
+func (c *Command) acceptInvitation(rw io.Writer, args *AcceptInvitationArgs, commandMethod string) command.Error {
 	if args.Invitation == nil {
-		logutil.LogDebug(logger, CommandName, AcceptInvitation, errEmptyRequest)
+		logutil.LogDebug(logger, CommandName, commandMethod, errEmptyRequest)
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyRequest))
 	}
 
 	if args.MyLabel == "" {
-		logutil.LogDebug(logger, CommandName, AcceptInvitation, errEmptyMyLabel)
+		logutil.LogDebug(logger, CommandName, commandMethod, errEmptyMyLabel)
 		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyLabel))
 	}
 
@@ -167,7 +189,7 @@ func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error {
 
 	connID, err := c.client.AcceptInvitation(args.Invitation, args.MyLabel, options...)
 	if err != nil {
-		logutil.LogError(logger, CommandName, AcceptInvitation, err.Error())
+		logutil.LogError(logger, CommandName, commandMethod, err.Error())
 		return command.NewExecuteError(AcceptInvitationErrorCode, err)
 	}
 
@@ -175,7 +197,65 @@ func (c *Command) AcceptInvitation(rw io.Writer, req io.Reader) command.Error {
 		ConnectionID: connID,
 	}, logger)
 
-	logutil.LogDebug(logger, CommandName, AcceptInvitation, successString)
+	logutil.LogDebug(logger, CommandName, commandMethod, successString)
+
+	return nil
+}
+
+// AcceptInvitationURL accepts the invitation of an invitation URL from another agent and return the ID of the new
+// connection records. Shortened invitation URLs are fetched to get the invitation.
+func (c *Command) AcceptInvitationURL(rw io.Writer, req io.Reader) command.Error {
+	var args AcceptInvitationURLArgs
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptInvitationURL, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.InvitationURL == "" {
+		logutil.LogDebug(logger, CommandName, AcceptInvitationURL, errEmptyURL)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyURL))
+	}
+
+	invitation, err := outofband.ParseInvitationURL(args.InvitationURL)
+	if err != nil {
+		logutil.LogError(logger, CommandName, AcceptInvitationURL, err.Error())
+		return command.NewExecuteError(ParseInvitationURLErrorCode, err)
+	}
+
+	return c.acceptInvitation(rw, &AcceptInvitationArgs{
+		Invitation:         invitation,
+		MyLabel:            args.MyLabel,
+		RouterConnections:  args.RouterConnections,
+		ReuseConnection:    args.ReuseConnection,
+		ReuseAnyConnection: args.ReuseAnyConnection,
+	}, AcceptInvitationURL)
+}
+
+// ParseInvitationURL returns the invitation of an invitation URL. Shortened invitation URLs are fetched to get
+// the invitation.
+func (c *Command) ParseInvitationURL(rw io.Writer, req io.Reader) command.Error {
+	var args ParseInvitationURLArgs
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, ParseInvitationURL, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.InvitationURL == "" {
+		logutil.LogDebug(logger, CommandName, ParseInvitationURL, errEmptyURL)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyURL))
+	}
+
+	invitation, err := outofband.ParseInvitationURL(args.InvitationURL)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ParseInvitationURL, err.Error())
+		return command.NewExecuteError(ParseInvitationURLErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ParseInvitationURLResponse{
+		Invitation: invitation,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, ParseInvitationURL, successString)
 
 	return nil
 }
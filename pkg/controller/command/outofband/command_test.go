@@ -155,6 +155,7 @@ func TestCommand_CreateInvitation(t *testing.T) {
 			GoalCode:  "goal_code",
 			Service:   []interface{}{"did:example:123"},
 			Protocols: []string{"s1"},
+			BaseURL:   "https://example.com/invite",
 		}
 		args, err := json.Marshal(expected)
 		require.NoError(t, err)
@@ -167,6 +168,10 @@ func TestCommand_CreateInvitation(t *testing.T) {
 		require.Equal(t, expected.GoalCode, res.Invitation.GoalCode)
 		require.Equal(t, expected.Service, res.Invitation.Services)
 		require.Equal(t, expected.Protocols, res.Invitation.Protocols)
+
+		invitation, err := outofband.ParseInvitationURL(res.InvitationURL)
+		require.NoError(t, err)
+		require.Equal(t, res.Invitation, invitation)
 	})
 }
 
@@ -270,6 +275,134 @@ func TestCommand_AcceptInvitation(t *testing.T) {
 	})
 }
 
+func TestCommand_AcceptInvitationURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	invitationURL, err := outofband.InvitationURL("https://example.com/invite", &outofband.Invitation{ID: "inv-id"})
+	require.NoError(t, err)
+
+	service := mocks.NewMockOobService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+	provider.EXPECT().MediaTypeProfiles().AnyTimes()
+
+	cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	t.Run("Decode error", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptInvitationURL(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("No invitation URL", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptInvitationURL(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyURL)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Invalid invitation URL", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptInvitationURL(&b, bytes.NewBufferString(`{"invitation_url":"didcomm://invite"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "parse out-of-band invitation URL")
+		require.Equal(t, ParseInvitationURLErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("No label", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptInvitationURL(&b, bytes.NewBufferString(`{"invitation_url":"`+invitationURL+`"}`))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyMyLabel)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		const connID = "conn-id"
+
+		service.EXPECT().AcceptInvitation(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(i *protocol.Invitation, _ protocol.Options) (string, error) {
+				require.Equal(t, "inv-id", i.ID)
+
+				return connID, nil
+			})
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.AcceptInvitationURL(&b, bytes.NewBufferString(
+			`{"invitation_url":"`+invitationURL+`","my_label":"label"}`)))
+		res := AcceptInvitationResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, connID, res.ConnectionID)
+	})
+}
+
+func TestCommand_ParseInvitationURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockOobService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil).AnyTimes()
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil).AnyTimes()
+	provider.EXPECT().MediaTypeProfiles().AnyTimes()
+
+	cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	t.Run("Decode error", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.ParseInvitationURL(&b, bytes.NewBufferString("}"))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("No invitation URL", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.ParseInvitationURL(&b, bytes.NewBufferString("{}"))
+
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), errEmptyURL)
+	})
+
+	t.Run("Invalid invitation URL", func(t *testing.T) {
+		var b bytes.Buffer
+		cmdErr := cmd.ParseInvitationURL(&b, bytes.NewBufferString(`{"invitation_url":"didcomm://invite"}`))
+
+		require.Error(t, cmdErr)
+		require.Equal(t, ParseInvitationURLErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		invitationURL, err := outofband.InvitationURL("https://example.com/invite",
+			&outofband.Invitation{ID: "inv-id", Label: "label"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.ParseInvitationURL(&b, bytes.NewBufferString(`{"invitation_url":"`+invitationURL+`"}`)))
+		res := ParseInvitationURLResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, "inv-id", res.Invitation.ID)
+		require.Equal(t, "label", res.Invitation.Label)
+	})
+}
+
 func TestCommand_Actions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -497,7 +630,7 @@ func TestCommand_GetHandlers(t *testing.T) {
 	provider.EXPECT().MediaTypeProfiles().AnyTimes()
 	cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
 	require.NoError(t, err)
-	require.Equal(t, 5, len(cmd.GetHandlers()))
+	require.Equal(t, 7, len(cmd.GetHandlers()))
 }
 
 func toProtocolActions(actions []outofband.Action) []protocol.Action {
@@ -25,6 +25,8 @@ type CreateInvitationArgs struct {
 	RouterConnectionID string        `json:"router_connection_id"`
 	// Attachments is intended to provide the possibility to include files, links or even JSON payload to the message.
 	Attachments []*decorator.Attachment `json:"attachments"`
+	// BaseURL of the invitation URL, the response holds no invitation URL when it is empty.
+	BaseURL string `json:"base_url,omitempty"`
 }
 
 // CreateInvitationResponse model
@@ -33,6 +35,8 @@ type CreateInvitationArgs struct {
 //
 type CreateInvitationResponse struct {
 	Invitation *outofband.Invitation `json:"invitation"`
+	// InvitationURL is the invitation encoded in the `oob` query parameter of the base URL.
+	InvitationURL string `json:"invitation_url,omitempty"`
 }
 
 // AcceptInvitationArgs model
@@ -47,6 +51,36 @@ type AcceptInvitationArgs struct {
 	ReuseAnyConnection bool                  `json:"reuse_any_connection"`
 }
 
+// AcceptInvitationURLArgs model
+//
+// This is used for accepting the invitation of an invitation URL.
+//
+type AcceptInvitationURLArgs struct {
+	// InvitationURL with the `oob` query parameter, or its shortened URL.
+	InvitationURL      string `json:"invitation_url"`
+	MyLabel            string `json:"my_label"`
+	RouterConnections  string `json:"router_connections"`
+	ReuseConnection    string `json:"reuse_connection"`
+	ReuseAnyConnection bool   `json:"reuse_any_connection"`
+}
+
+// ParseInvitationURLArgs model
+//
+// This is used for parsing an invitation URL.
+//
+type ParseInvitationURLArgs struct {
+	// InvitationURL with the `oob` query parameter, or its shortened URL.
+	InvitationURL string `json:"invitation_url"`
+}
+
+// ParseInvitationURLResponse model
+//
+// Represents a ParseInvitationURL response message.
+//
+type ParseInvitationURLResponse struct {
+	Invitation *outofband.Invitation `json:"invitation"`
+}
+
 // AcceptInvitationResponse model
 //
 // Represents a AcceptInvitation response message.
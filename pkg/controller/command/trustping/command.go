@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid trust ping controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.TrustPing)
+	// PingErrorCode is for failures in ping command.
+	PingErrorCode
+)
+
+// constants for trust ping.
+const (
+	// command name.
+	CommandName = "trustping"
+	Ping        = "Ping"
+
+	// error messages.
+	errEmptyConnID = "empty connection ID"
+
+	// log constants.
+	connectionIDString = "connectionID"
+	successString      = "success"
+)
+
+var logger = log.New("aries-framework/controller/trustping")
+
+// Provider contains dependencies for the trust ping command and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command is controller command for trust ping.
+type Command struct {
+	client *trustping.Client
+}
+
+// New returns new trust ping controller command instance.
+func New(ctx Provider) (*Command, error) {
+	client, err := trustping.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, Ping, c.Ping),
+	}
+}
+
+// Ping sends a trust ping over a connection and waits for the response, the response holds the round-trip time.
+func (c *Command) Ping(rw io.Writer, req io.Reader) command.Error {
+	var args PingArgs
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, Ping, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, Ping, errEmptyConnID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyConnID))
+	}
+
+	opts := []trustping.PingOption{trustping.WithComment(args.Comment)}
+
+	if args.Timeout > 0 {
+		opts = append(opts, trustping.WithTimeout(args.Timeout))
+	}
+
+	result, err := c.client.Ping(args.ConnectionID, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, Ping, err.Error(),
+			logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+		return command.NewExecuteError(PingErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &PingResponse{
+		PingID:    result.PingID,
+		Comment:   result.Comment,
+		RoundTrip: result.RoundTrip,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, Ping, successString,
+		logutil.CreateKeyValueString(connectionIDString, args.ConnectionID))
+
+	return nil
+}
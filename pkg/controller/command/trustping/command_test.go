@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("Create client (error)", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.EqualError(t, err, "cannot create a client: failed to create trust ping service: service error")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Ping(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingFunc: func(connectionID string, options ...trustping.PingOption) (*trustping.PingResult, error) {
+				require.Equal(t, "conn-id", connectionID)
+
+				return &trustping.PingResult{PingID: "ping-id", Comment: "hi", RoundTrip: time.Millisecond}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connection_id":"conn-id","comment":"hello","timeout":1000000}`))
+		require.NoError(t, cmdErr)
+
+		res := PingResponse{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, "ping-id", res.PingID)
+		require.Equal(t, "hi", res.Comment)
+		require.Equal(t, time.Millisecond, res.RoundTrip)
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString("}"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Empty connection ID", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString("{}"))
+		require.EqualError(t, cmdErr, errEmptyConnID)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("Ping error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingErr: trustping.ErrTimeout,
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connection_id":"conn-id"}`))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), trustping.ErrTimeout.Error())
+		require.Equal(t, PingErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
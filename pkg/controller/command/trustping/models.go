@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+)
+
+// PingArgs model
+//
+// This is used for sending a trust ping over a connection.
+type PingArgs struct {
+	// ConnectionID of the connection to ping.
+	ConnectionID string `json:"connection_id"`
+
+	// Comment of the ping, only DIDComm V1 pings have a comment.
+	Comment string `json:"comment,omitempty"`
+
+	// Timeout waiting for the ping response, defaults to 10 seconds.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// PingResponse model
+//
+// Represents a Ping response message.
+type PingResponse struct {
+	// PingID is the ID of the ping message.
+	PingID string `json:"ping_id"`
+
+	// Comment of the ping response, DIDComm V1 only.
+	Comment string `json:"comment,omitempty"`
+
+	// RoundTrip is the time elapsed between sending the ping and receiving its response.
+	RoundTrip time.Duration `json:"round_trip"`
+}
@@ -22,8 +22,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
 	"github.com/hyperledger/aries-framework-go/pkg/wallet"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -89,6 +91,12 @@ const (
 
 	// VerifyJWTErrorCode for errors while verifying a JWT using wallet.
 	VerifyJWTErrorCode
+
+	// ExportWalletErrorCode for errors while exporting wallet.
+	ExportWalletErrorCode
+
+	// ImportWalletErrorCode for errors while importing wallet.
+	ImportWalletErrorCode
 )
 
 // All command operations.
@@ -114,6 +122,8 @@ const (
 	DeriveMethod                    = "Derive"
 	CreateKeyPairMethod             = "CreateKeyPair"
 	ResolveCredentialManifestMethod = "ResolveCredentialManifest"
+	ExportMethod                    = "Export"
+	ImportMethod                    = "Import"
 )
 
 // miscellaneous constants for the vc wallet command controller.
@@ -220,6 +230,8 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, DeriveMethod, o.Derive),
 		cmdutil.NewCommandHandler(CommandName, CreateKeyPairMethod, o.CreateKeyPair),
 		cmdutil.NewCommandHandler(CommandName, ResolveCredentialManifestMethod, o.ResolveCredentialManifest),
+		cmdutil.NewCommandHandler(CommandName, ExportMethod, o.Export),
+		cmdutil.NewCommandHandler(CommandName, ImportMethod, o.Import),
 	}
 }
 
@@ -375,6 +387,75 @@ func (o *Command) Close(rw io.Writer, req io.Reader) command.Error {
 	return nil
 }
 
+// Export exports all wallet contents and keys as Universal Wallet EncryptedWallet locked by given passphrase.
+func (o *Command) Export(rw io.Writer, req io.Reader) command.Error {
+	request := &ExportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	encryptedWallet, err := vcWallet.Export(request.Auth, request.Passphrase)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportResponse{EncryptedWallet: encryptedWallet}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Import imports contents and keys of exported wallet into wallet.
+func (o *Command) Import(rw io.Writer, req io.Reader) command.Error {
+	request := &ImportRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	var importOpts []wallet.ImportOptions
+	if request.ConflictResolution != "" {
+		importOpts = append(importOpts, wallet.WithImportConflict(request.ConflictResolution))
+	}
+
+	err = vcWallet.Import(request.Auth, request.Passphrase, request.EncryptedWallet, importOpts...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, ImportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
 // Add adds given data model to wallet content store.
 func (o *Command) Add(rw io.Writer, req io.Reader) command.Error {
 	request := &AddContentRequest{}
@@ -703,12 +784,33 @@ func (o *Command) Verify(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(InvalidRequestErrorCode, err)
 	}
 
-	verified, err := vcWallet.Verify(request.Auth, option)
+	options := []wallet.VerificationOption{option}
 
-	response := &VerifyResponse{Verified: verified}
+	if request.Policy != nil {
+		options = append(options, wallet.WithVerificationPolicyToVerify(&verifiable.VerificationPolicy{
+			ClockSkew:                 time.Duration(request.Policy.ClockSkew) * time.Second,
+			TrustedIssuers:            request.Policy.TrustedIssuers,
+			CredentialProofPurposes:   request.Policy.CredentialProofPurposes,
+			PresentationProofPurposes: request.Policy.PresentationProofPurposes,
+		}))
+	}
 
-	if err != nil {
+	report, err := vcWallet.VerifyWithReport(request.Auth, options...)
+
+	response := &VerifyResponse{}
+
+	switch {
+	case err != nil:
 		response.Error = err.Error()
+	case !report.Passed():
+		response.Error = (&verifiable.VerificationError{Report: report}).Error()
+		response.Report = report
+	default:
+		response.Verified = true
+
+		if request.Policy != nil {
+			response.Report = report
+		}
 	}
 
 	command.WriteNillableResponse(rw, response, logger)
@@ -770,7 +872,7 @@ func (o *Command) CreateKeyPair(rw io.Writer, req io.Reader) command.Error {
 		return command.NewExecuteError(CreateKeyPairFromWalletErrorCode, err)
 	}
 
-	response, err := vcWallet.CreateKeyPair(request.Auth, request.KeyType)
+	response, err := vcWallet.CreateKeyPair(request.Auth, request.KeyType, prepareKeyPairOptions(request)...)
 	if err != nil {
 		logutil.LogInfo(logger, CommandName, CreateKeyPairMethod, err.Error())
 
@@ -1009,3 +1111,21 @@ func prepareResolveManifestOption(rqst *ResolveCredentialManifestRequest) wallet
 
 	return nil
 }
+
+func prepareKeyPairOptions(rqst *CreateKeyPairRequest) []kms.KeyOpts {
+	var options []kms.KeyOpts
+
+	if rqst.Purpose != "" {
+		options = append(options, kms.WithPurpose(rqst.Purpose))
+	}
+
+	if len(rqst.Labels) > 0 {
+		options = append(options, kms.WithLabels(rqst.Labels))
+	}
+
+	if rqst.ExpiresAt != nil {
+		options = append(options, kms.WithExpiry(*rqst.ExpiresAt))
+	}
+
+	return options
+}
@@ -69,7 +69,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetHandlers(), 18)
+		require.Len(t, cmd.GetHandlers(), 20)
 	})
 }
 
@@ -1477,6 +1477,29 @@ func TestCommand_IssueProveVerify(t *testing.T) {
 		require.Empty(t, response.Error)
 	})
 
+	t.Run("verify a raw credential with verification policy", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.Verify(&b, getReader(t, &VerifyRequest{
+			WalletAuth:    WalletAuth{UserID: sampleUser1, Auth: token},
+			RawCredential: rawCredentialToVerify,
+			Policy: &VerificationPolicy{
+				CredentialProofPurposes: []string{"assertionMethod"},
+			},
+		}))
+		require.NoError(t, cmdErr)
+
+		var response VerifyResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.False(t, response.Verified)
+		require.Contains(t, response.Error, "validityPeriod check of http://example.edu/credentials/1872 failed")
+		require.NotNil(t, response.Report)
+		require.Len(t, response.Report.Failed(), 1)
+		require.Equal(t, verifiable.CheckPassed, response.Report.Checks[4].Result)
+	})
+
 	t.Run("verify a invalid credential", func(t *testing.T) {
 		// tamper a credential
 		invalidVC := string(rawCredentialToVerify)
@@ -1864,6 +1887,28 @@ func TestCommand_CreateKeyPair(t *testing.T) {
 		require.NotEmpty(t, response.PublicKey)
 	})
 
+	t.Run("successfully create key pair with metadata (local kms)", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		expiry := time.Now().Add(time.Hour)
+
+		request := &CreateKeyPairRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
+			KeyType:    kms.ED25519,
+			Purpose:    "signing",
+			Labels:     map[string]string{"env": "test"},
+			ExpiresAt:  &expiry,
+		}
+
+		var b bytes.Buffer
+		cmdErr := cmd.CreateKeyPair(&b, getReader(t, &request))
+		require.NoError(t, cmdErr)
+
+		var response CreateKeyPairResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.KeyID)
+	})
+
 	t.Run("create key pair using invalid auth (local kms)", func(t *testing.T) {
 		cmd := New(mockctx, &Config{})
 
@@ -1911,6 +1956,142 @@ func TestCommand_CreateKeyPair(t *testing.T) {
 	})
 }
 
+func TestCommand_ExportImport(t *testing.T) {
+	const (
+		sampleUser1      = "sample-user-01"
+		sampleUser2      = "sample-user-02"
+		exportPassphrase = "export-passphrase"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	addContent(t, mockctx, &AddContentRequest{
+		WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token},
+		ContentType: wallet.Metadata,
+		Content:     testdata.SampleWalletContentMetadata,
+	})
+
+	var encryptedWallet json.RawMessage
+
+	t.Run("successfully export wallet", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
+			Passphrase: exportPassphrase,
+		}))
+		require.NoError(t, cmdErr)
+
+		var response ExportResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.EncryptedWallet)
+
+		encryptedWallet = response.EncryptedWallet
+	})
+
+	t.Run("successfully import wallet", func(t *testing.T) {
+		importCtx := newMockProvider(t)
+
+		createSampleUserProfile(t, importCtx, &CreateOrUpdateProfileRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		importToken, importLock := unlockWallet(t, importCtx, &UnlockWalletRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		defer importLock()
+
+		cmd := New(importCtx, &Config{})
+
+		request := &ImportRequest{
+			WalletAuth:      WalletAuth{UserID: sampleUser2, Auth: importToken},
+			Passphrase:      exportPassphrase,
+			EncryptedWallet: encryptedWallet,
+		}
+
+		var b bytes.Buffer
+		require.NoError(t, cmd.Import(&b, getReader(t, request)))
+
+		cmdErr := cmd.Import(&b, getReader(t, request))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "already exists")
+
+		request.ConflictResolution = wallet.ImportConflictReplace
+		require.NoError(t, cmd.Import(&b, getReader(t, request)))
+
+		cmdErr = cmd.Get(&b, getReader(t, &GetContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser2, Auth: importToken},
+			ContentType: wallet.Metadata,
+			ContentID:   "did:example:123456789abcdefghi",
+		}))
+		require.NoError(t, cmdErr)
+
+		var response GetContentResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.JSONEq(t, string(testdata.SampleWalletContentMetadata), string(response.Content))
+	})
+
+	t.Run("export or import using invalid auth", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase: exportPassphrase,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "invalid auth token")
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth:      WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase:      exportPassphrase,
+			EncryptedWallet: encryptedWallet,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "invalid auth token")
+	})
+
+	t.Run("export or import using invalid request", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+
+		cmdErr = cmd.Import(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+	})
+
+	t.Run("export or import using invalid profile", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: sampleFakeTkn},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "failed to get VC wallet profile")
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: sampleFakeTkn},
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "failed to get VC wallet profile")
+	})
+}
+
 func TestCommand_ResolveCredentialManifest(t *testing.T) {
 	const sampleUser1 = "sample-user-r01"
 
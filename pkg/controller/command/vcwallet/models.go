@@ -300,6 +300,25 @@ type VerifyRequest struct {
 	// Presentation to be proved.
 	// optional, will be used only if other options are not provided.
 	Presentation json.RawMessage `json:"presentation"`
+
+	// Policy to check the verified credential or presentation with.
+	// optional, if not provided then only proofs and data model are verified.
+	Policy *VerificationPolicy `json:"policy,omitempty"`
+}
+
+// VerificationPolicy is the verification policy model for wallet verify operation.
+type VerificationPolicy struct {
+	// Allowed clock skew in seconds for the validity period check of credentials.
+	ClockSkew int64 `json:"clockSkew,omitempty"`
+
+	// IDs of trusted issuers of credentials, any issuer is trusted if empty.
+	TrustedIssuers []string `json:"trustedIssuers,omitempty"`
+
+	// Allowed proof purposes of credentials, proof purposes are not checked if empty.
+	CredentialProofPurposes []string `json:"credentialProofPurposes,omitempty"`
+
+	// Allowed proof purposes of presentations, proof purposes are not checked if empty.
+	PresentationProofPurposes []string `json:"presentationProofPurposes,omitempty"`
 }
 
 // VerifyResponse is response model for wallet verify operation.
@@ -309,6 +328,9 @@ type VerifyResponse struct {
 
 	// error details if verified is false.
 	Error string `json:"error,omitempty"`
+
+	// report of the checks of the verification policy, if the policy is provided.
+	Report *verifiable.VerificationReport `json:"report,omitempty"`
 }
 
 // DeriveRequest is request model for deriving a credential from wallet.
@@ -339,6 +361,15 @@ type CreateKeyPairRequest struct {
 
 	// type of the key to be created.
 	KeyType kms.KeyType `json:"keyType,omitempty"`
+
+	// optional purpose of the key to be created (ex: signing).
+	Purpose string `json:"purpose,omitempty"`
+
+	// optional labels of the key to be created.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// optional expiry of the key to be created, an expired key can't be used anymore.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // CreateKeyPairResponse is response model for creating key pair from wallet.
@@ -372,3 +403,32 @@ type ResolveCredentialManifestResponse struct {
 	// List of Resolved Descriptor results.
 	Resolved []*cm.ResolvedDescriptor `json:"resolved,omitempty"`
 }
+
+// ExportRequest is request model for exporting wallet.
+type ExportRequest struct {
+	WalletAuth
+
+	// Passphrase to be used to lock exported wallet.
+	Passphrase string `json:"passphrase"`
+}
+
+// ExportResponse is response model from wallet export operation.
+type ExportResponse struct {
+	// Universal Wallet EncryptedWallet containing all wallet contents locked by passphrase.
+	EncryptedWallet json.RawMessage `json:"encryptedWallet"`
+}
+
+// ImportRequest is request model for importing exported wallet.
+type ImportRequest struct {
+	WalletAuth
+
+	// Passphrase used while exporting the wallet.
+	Passphrase string `json:"passphrase"`
+
+	// Universal Wallet EncryptedWallet to be imported.
+	EncryptedWallet json.RawMessage `json:"encryptedWallet"`
+
+	// Resolution of conflicts with existing wallet contents.
+	// supported values: fail, skip, replace. Default is fail.
+	ConflictResolution wallet.ImportConflict `json:"conflictResolution,omitempty"`
+}
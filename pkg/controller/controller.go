@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/connection"
 	didcommwalletcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didcommwallet"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	discoverfeaturescmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
@@ -25,11 +26,13 @@ import (
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	outofbandv2cmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofbandv2"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	trustpingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	connectionrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/connection"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	discoverfeaturesrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/discoverfeatures"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
 	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
@@ -41,6 +44,7 @@ import (
 	outofbandv2rest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofbandv2"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/rfc0593"
+	trustpingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/trustping"
 	vcwalletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
@@ -241,6 +245,16 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create connection rest command : %w", err)
 	}
 
+	trustPingOp, err := trustpingrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trustping rest command : %w", err)
+	}
+
+	discoverFeaturesOp, err := discoverfeaturesrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discoverfeatures rest command : %w", err)
+	}
+
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
 	allHandlers = append(allHandlers, exchangeOp.GetRESTHandlers()...)
@@ -259,6 +273,8 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, wallet.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, ldOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, connOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, trustPingOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, discoverFeaturesOp.GetRESTHandlers()...)
 
 	nhp, ok := notifier.(handlerProvider)
 	if ok {
@@ -366,6 +382,18 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create connection command : %w", err)
 	}
 
+	// trust ping command operation
+	trustping, err := trustpingcmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trustping command : %w", err)
+	}
+
+	// discover features command operation
+	discoverfeatures, err := discoverfeaturescmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discoverfeatures command : %w", err)
+	}
+
 	// vc wallet command controller
 	wallet := didcommwalletcmd.New(ctx, cmdOpts.walletConf)
 
@@ -386,6 +414,8 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
 	allHandlers = append(allHandlers, outofbandv2.GetHandlers()...)
 	allHandlers = append(allHandlers, conncmd.GetHandlers()...)
+	allHandlers = append(allHandlers, trustping.GetHandlers()...)
+	allHandlers = append(allHandlers, discoverfeatures.GetHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetHandlers()...)
 	allHandlers = append(allHandlers, ldCmd.GetHandlers()...)
 
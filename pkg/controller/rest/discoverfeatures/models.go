@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+)
+
+// discoverFeaturesQueryRequest model
+//
+// This is used for operation to query the features of the other party of a connection.
+//
+// swagger:parameters discoverFeaturesQuery
+type discoverFeaturesQueryRequest struct { // nolint: unused,deadcode
+	// in: body
+	Params discoverfeatures.QueryArgs
+}
+
+// discoverFeaturesQueryResponse model
+//
+// Represents the features disclosed in response to a query.
+//
+// swagger:response discoverFeaturesQueryResponse
+type discoverFeaturesQueryResponse struct { // nolint: unused,deadcode
+	// in: body
+	Response discoverfeatures.QueryResponse
+}
+
+// discoverFeaturesDisclosuresRequest model
+//
+// This is used for operation to get the features disclosed by the other party of a connection.
+//
+// swagger:parameters discoverFeaturesDisclosures
+type discoverFeaturesDisclosuresRequest struct { // nolint: unused,deadcode
+	// The ID of the connection
+	//
+	// in: path
+	// required: true
+	ConnectionID string `json:"connection_id"`
+}
+
+// discoverFeaturesDisclosuresResponse model
+//
+// Represents the features disclosed by the other party of a connection.
+//
+// swagger:response discoverFeaturesDisclosuresResponse
+type discoverFeaturesDisclosuresResponse struct { // nolint: unused,deadcode
+	// in: body
+	Response discoverfeatures.DisclosuresResponse
+}
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the DiscoverFeatures protocol operations.
+const (
+	OperationID = "/discoverfeatures"
+	Query       = OperationID + "/query"
+	Disclosures = OperationID + "/{connection_id}/disclosures"
+)
+
+// Operation is controller REST service controller for discover features.
+type Operation struct {
+	command  *discoverfeatures.Command
+	handlers []rest.Handler
+}
+
+// New returns new discover features rest client protocol instance.
+func New(ctx discoverfeatures.Provider) (*Operation, error) {
+	cmd, err := discoverfeatures.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("discoverfeatures command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	// Add more protocol endpoints here to expose them as controller API endpoints
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(Query, http.MethodPost, c.Query),
+		cmdutil.NewHTTPHandler(Disclosures, http.MethodGet, c.Disclosures),
+	}
+}
+
+// Query swagger:route POST /discoverfeatures/query discover-features discoverFeaturesQuery
+//
+// Queries the features of the other party of a connection and waits for their disclosure.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: discoverFeaturesQueryResponse
+func (c *Operation) Query(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Query, rw, req.Body)
+}
+
+// Disclosures swagger:route GET /discoverfeatures/{connection_id}/disclosures discover-features discoverFeaturesDisclosures
+//
+// Returns the features disclosed by the other party of a connection.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: discoverFeaturesDisclosuresResponse
+func (c *Operation) Disclosures(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Disclosures, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"connection_id":%q
+	}`, mux.Vars(req)["connection_id"])))
+}
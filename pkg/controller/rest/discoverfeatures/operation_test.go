@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(&mockprovider.Provider{ServiceErr: errors.New("error")})
+	require.EqualError(t, err, "discoverfeatures command : cannot create a client: failed to create discover "+
+		"features service: error")
+}
+
+func TestOperation_Query(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		operation, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		b, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Query),
+			bytes.NewBufferString(`{"connection_id":"conn-id"}`),
+			Query,
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.Contains(t, b.String(), "https://didcomm.org/trust-ping/2.0")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		operation, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			QueryErr: errors.New("query error"),
+		}})
+		require.NoError(t, err)
+
+		b, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Query),
+			bytes.NewBufferString(`{"connection_id":"conn-id"}`),
+			Query,
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, b.String(), "query error")
+	})
+}
+
+func TestOperation_Disclosures(t *testing.T) {
+	operation, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+		DisclosuresFunc: func(connectionID string) ([]discoverfeatures.Disclosure, error) {
+			require.Equal(t, "conn-id", connectionID)
+
+			return []discoverfeatures.Disclosure{{FeatureType: "goal-code", ID: "issue-vc"}}, nil
+		},
+	}})
+	require.NoError(t, err)
+
+	b, code, err := sendRequestToHandler(
+		handlerLookup(t, operation, Disclosures),
+		nil,
+		"/discoverfeatures/conn-id/disclosures",
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+
+	res := struct {
+		Disclosures []discoverfeatures.Disclosure `json:"disclosures"`
+	}{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+	require.Equal(t, []discoverfeatures.Disclosure{{FeatureType: "goal-code", ID: "issue-vc"}}, res.Disclosures)
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
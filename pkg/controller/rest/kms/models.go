@@ -43,3 +43,84 @@ type importKeyReq struct {
 	// in: body
 	kms.JSONWebKey
 }
+
+// listKeysReq model
+//
+// This is used for listing keys
+//
+// swagger:parameters listKeysReq
+type listKeysReq struct { // nolint: unused,deadcode
+	// Purpose the listed keys must have
+	//
+	// in: query
+	Purpose string `json:"purpose"`
+
+	// Labels the listed keys must have, as 'name:value'
+	//
+	// in: query
+	Label []string `json:"label"`
+}
+
+// listKeysRes model
+//
+// This is used for returning the list keys response
+//
+// swagger:response listKeysRes
+type listKeysRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ListKeysResponse
+}
+
+// deleteKeyReq model
+//
+// This is used for deleting a key
+//
+// swagger:parameters deleteKeyReq
+type deleteKeyReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+}
+
+// getKeyMetadataReq model
+//
+// This is used for getting metadata of a key
+//
+// swagger:parameters getKeyMetadataReq
+type getKeyMetadataReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+}
+
+// getKeyMetadataRes model
+//
+// This is used for returning the get key metadata response
+//
+// swagger:response getKeyMetadataRes
+type getKeyMetadataRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.GetKeyMetadataResponse
+}
+
+// updateKeyMetadataReq model
+//
+// This is used for updating metadata of a key
+//
+// swagger:parameters updateKeyMetadataReq
+type updateKeyMetadataReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+
+	// in: body
+	Params kms.UpdateKeyMetadataRequest
+}
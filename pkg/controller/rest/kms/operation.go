@@ -7,8 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package kms
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
@@ -22,6 +28,9 @@ const (
 	KmsOperationID   = "/kms"
 	CreateKeySetPath = KmsOperationID + "/keyset"
 	ImportKeyPath    = KmsOperationID + "/import"
+	KeysPath         = KmsOperationID + "/keys"
+	KeyPath          = KeysPath + "/{keyID}"
+	KeyMetadataPath  = KeyPath + "/metadata"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -32,6 +41,10 @@ type provider interface {
 type kmsCommand interface {
 	CreateKeySet(rw io.Writer, req io.Reader) command.Error
 	ImportKey(rw io.Writer, req io.Reader) command.Error
+	ListKeys(rw io.Writer, req io.Reader) command.Error
+	DeleteKey(rw io.Writer, req io.Reader) command.Error
+	GetKeyMetadata(rw io.Writer, req io.Reader) command.Error
+	UpdateKeyMetadata(rw io.Writer, req io.Reader) command.Error
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -60,6 +73,10 @@ func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
+		cmdutil.NewHTTPHandler(KeysPath, http.MethodGet, o.ListKeys),
+		cmdutil.NewHTTPHandler(KeyPath, http.MethodDelete, o.DeleteKey),
+		cmdutil.NewHTTPHandler(KeyMetadataPath, http.MethodGet, o.GetKeyMetadata),
+		cmdutil.NewHTTPHandler(KeyMetadataPath, http.MethodPut, o.UpdateKeyMetadata),
 	}
 }
 
@@ -83,3 +100,93 @@ func (o *Operation) CreateKeySet(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ImportKey(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ImportKey, rw, req.Body)
 }
+
+// ListKeys swagger:route GET /kms/keys kms listKeysReq
+//
+// Lists metadata of keys, optionally filtered by purpose and by labels given as 'name:value'.
+//
+// Responses:
+//    default: genericError
+//        200: listKeysRes
+func (o *Operation) ListKeys(rw http.ResponseWriter, req *http.Request) {
+	request := cmdkms.ListKeysRequest{Purpose: req.URL.Query().Get("purpose")}
+
+	for _, label := range req.URL.Query()["label"] {
+		name, value, ok := strings.Cut(label, ":")
+		if !ok {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdkms.InvalidRequestErrorCode,
+				fmt.Errorf("invalid label '%s', expected 'name:value'", label))
+
+			return
+		}
+
+		if request.Labels == nil {
+			request.Labels = map[string]string{}
+		}
+
+		request.Labels[name] = value
+	}
+
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, cmdkms.ListKeysError, err)
+
+		return
+	}
+
+	rest.Execute(o.command.ListKeys, rw, bytes.NewBuffer(requestBytes))
+}
+
+// DeleteKey swagger:route DELETE /kms/keys/{keyID} kms deleteKeyReq
+//
+// Deletes a key along with its metadata.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DeleteKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeleteKey, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"keyID":%q
+	}`, mux.Vars(req)["keyID"])))
+}
+
+// GetKeyMetadata swagger:route GET /kms/keys/{keyID}/metadata kms getKeyMetadataReq
+//
+// Gets metadata of a key.
+//
+// Responses:
+//    default: genericError
+//        200: getKeyMetadataRes
+func (o *Operation) GetKeyMetadata(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetKeyMetadata, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"keyID":%q
+	}`, mux.Vars(req)["keyID"])))
+}
+
+// UpdateKeyMetadata swagger:route PUT /kms/keys/{keyID}/metadata kms updateKeyMetadataReq
+//
+// Replaces purpose, labels, expiry and disabled state of a key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) UpdateKeyMetadata(rw http.ResponseWriter, req *http.Request) {
+	var request cmdkms.UpdateKeyMetadataRequest
+
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdkms.InvalidRequestErrorCode,
+			fmt.Errorf("failed request decode : %w", err))
+
+		return
+	}
+
+	request.KeyID = mux.Vars(req)["keyID"]
+
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, cmdkms.UpdateKeyMetadataError, err)
+
+		return
+	}
+
+	rest.Execute(o.command.UpdateKeyMetadata, rw, bytes.NewBuffer(requestBytes))
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	kmsservice "github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 )
@@ -31,7 +32,7 @@ func TestNew(t *testing.T) {
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
-		require.Equal(t, 2, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 6, len(cmd.GetRESTHandlers()))
 	})
 }
 
@@ -102,14 +103,96 @@ func TestImportKey(t *testing.T) {
 	})
 }
 
+func TestKeyLifecycle(t *testing.T) {
+	metadata := &kmsservice.KeyMetadata{KeyID: "keyID", KeyType: kmsservice.ED25519Type, Purpose: "signing"}
+
+	t.Run("test list keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListValue: []*kmsservice.KeyMetadata{metadata}},
+		})
+
+		handler := lookupHandlerWithMethod(t, cmd, KeysPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"?purpose=signing&label=env:test")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		var response kms.ListKeysResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, []*kmsservice.KeyMetadata{metadata}, response.Keys)
+	})
+
+	t.Run("test list keys - invalid label", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		handler := lookupHandlerWithMethod(t, cmd, KeysPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"?label=env")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.InvalidRequestErrorCode, "invalid label 'env'", buf.Bytes())
+	})
+
+	t.Run("test get key metadata - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetMetadataValue: metadata},
+		})
+
+		handler := lookupHandlerWithMethod(t, cmd, KeyMetadataPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"/keyID/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		var response kms.GetKeyMetadataResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, metadata, response.Metadata)
+	})
+
+	t.Run("test update key metadata", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		handler := lookupHandlerWithMethod(t, cmd, KeyMetadataPath, http.MethodPut)
+
+		_, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"disabled":true}`),
+			KeysPath+"/keyID/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`[`), KeysPath+"/keyID/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.InvalidRequestErrorCode, "failed request decode", buf.Bytes())
+	})
+
+	t.Run("test delete key - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{DeleteErr: fmt.Errorf("error delete key")},
+		})
+
+		handler := lookupHandlerWithMethod(t, cmd, KeyPath, http.MethodDelete)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"/keyID")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.DeleteKeyError, "error delete key", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	t.Helper()
 
+	return lookupHandlerWithMethod(t, op, path, http.MethodPost)
+}
+
+func lookupHandlerWithMethod(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
 
 	for _, h := range handlers {
-		if h.Path() == path && h.Method() == http.MethodPost {
+		if h.Path() == path && h.Method() == method {
 			return h
 		}
 	}
@@ -185,3 +268,19 @@ func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error
 func (m *mockKMSCommand) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	return m.importKeyError
 }
+
+func (m *mockKMSCommand) ListKeys(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) GetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) UpdateKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
@@ -117,3 +117,27 @@ type batchPickupResponse struct {
 	// in: body
 	Params mediator.BatchPickupResponse
 }
+
+// keylistRequest model
+//
+// For querying the keys routed by the router for given connection.
+//
+// swagger:parameters keylistRequest
+type keylistRequest struct { // nolint: unused,deadcode
+	// Params for querying the keys routed for given connection.
+	//
+	// in: body
+	Params mediator.KeylistRequest
+}
+
+// keylistResponse model
+//
+// Response from router containing the keys routed for given connection.
+//
+// swagger:response keylistResponse
+type keylistResponse struct {
+	// Keys routed for given connection.
+	//
+	// in: body
+	Params mediator.KeylistResponse
+}
@@ -26,6 +26,7 @@ const (
 	StatusPath         = RouteOperationID + "/status"
 	BatchPickupPath    = RouteOperationID + "/batchpickup"
 	ReconnectAllPath   = RouteOperationID + "/reconnect-all"
+	KeylistPath        = RouteOperationID + "/keylist"
 )
 
 // provider contains dependencies for the route protocol and is typically created by using aries.Context().
@@ -74,6 +75,7 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(StatusPath, http.MethodPost, o.Status),
 		cmdutil.NewHTTPHandler(BatchPickupPath, http.MethodPost, o.BatchPickup),
 		cmdutil.NewHTTPHandler(ReconnectAllPath, http.MethodGet, o.ReconnectAll),
+		cmdutil.NewHTTPHandler(KeylistPath, http.MethodPost, o.Keylist),
 	}
 }
 
@@ -151,3 +153,14 @@ func (o *Operation) BatchPickup(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ReconnectAll(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ReconnectAll, rw, req.Body)
 }
+
+// Keylist swagger:route POST /mediator/keylist mediator keylistRequest
+//
+// Retrieves the keys the router routes for given connection.
+//
+// Responses:
+//    default: genericError
+//    200: keylistResponse
+func (o *Operation) Keylist(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Keylist, rw, req.Body)
+}
@@ -61,7 +61,7 @@ func TestOperation_GetRESTHandlers(t *testing.T) {
 	require.NotNil(t, svc)
 
 	handlers := svc.GetRESTHandlers()
-	require.Equal(t, len(handlers), 7)
+	require.Equal(t, len(handlers), 8)
 }
 
 func TestOperation_Register(t *testing.T) {
@@ -317,6 +317,48 @@ func TestOperation_BatchPickup(t *testing.T) {
 	})
 }
 
+func TestOperation_Keylist(t *testing.T) {
+	t.Run("test keylist - success", func(t *testing.T) {
+		svc, err := New(
+			newMockProvider(map[string]interface{}{
+				messagepickupSvc.MessagePickup: &messagepickup.MockMessagePickupSvc{},
+				mediatorSvc.Coordination: &mockroute.MockMediatorSvc{
+					KeylistQueryFunc: func(connID string, paginate *mediatorSvc.Paginate) (*mediatorSvc.Keylist, error) {
+						return &mediatorSvc.Keylist{Keys: []mediatorSvc.KeylistKey{{RecipientKey: "key"}}}, nil
+					},
+				},
+				oobsvc.Name: &mockoob.MockOobService{},
+			}),
+			false,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, KeylistPath)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer([]byte(connIDRequest)), handler.Path())
+		require.NoError(t, err)
+
+		response := keylistResponse{}
+		err = json.Unmarshal(buf.Bytes(), &response.Params)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key"}, response.Params.Keys)
+	})
+
+	t.Run("test keylist - missing connectionID", func(t *testing.T) {
+		svc, err := New(newMockProvider(nil), false)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, KeylistPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer([]byte(`{}`)), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, mediator.KeylistMissingConnIDCode, "connectionID is mandatory", buf.Bytes())
+	})
+}
+
 func newMockProvider(serviceMap map[string]interface{}) *mockprovider.Provider {
 	if serviceMap == nil {
 		serviceMap = map[string]interface{}{
@@ -28,6 +28,8 @@ type outofbandCreateInvitationRequest struct { // nolint: unused,deadcode
 		// Attachments is intended to provide the possibility to include files, links or even JSON payload to the message.
 		// required: true
 		Attachments []*decorator.Attachment `json:"attachments"`
+		// BaseURL of the invitation URL, the response holds no invitation URL when it is empty.
+		BaseURL string `json:"base_url"`
 	}
 }
 
@@ -37,6 +39,52 @@ type outofbandCreateInvitationRequest struct { // nolint: unused,deadcode
 //
 // swagger:response outofbandCreateInvitationResponse
 type outofbandCreateInvitationResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Invitation struct{ *protocol.Invitation } `json:"invitation"`
+		// InvitationURL is the invitation encoded in the `oob` query parameter of the base URL.
+		InvitationURL string `json:"invitation_url"`
+	}
+}
+
+// outofbandAcceptInvitationURLRequest model
+//
+// This is used for operation to accept the invitation of an invitation URL.
+//
+// swagger:parameters outofbandAcceptInvitationURL
+type outofbandAcceptInvitationURLRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// InvitationURL with the `oob` query parameter, or its shortened URL.
+		// required: true
+		InvitationURL      string `json:"invitation_url"`
+		MyLabel            string `json:"my_label"`
+		RouterConnections  string `json:"router_connections"`
+		ReuseConnection    string `json:"reuse_connection"`
+		ReuseAnyConnection bool   `json:"reuse_any_connection"`
+	}
+}
+
+// outofbandParseInvitationURLRequest model
+//
+// This is used for operation to parse an invitation URL.
+//
+// swagger:parameters outofbandParseInvitationURL
+type outofbandParseInvitationURLRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// InvitationURL with the `oob` query parameter, or its shortened URL.
+		// required: true
+		InvitationURL string `json:"invitation_url"`
+	}
+}
+
+// outofbandParseInvitationURLResponse model
+//
+// Represents a ParseInvitationURL response message.
+//
+// swagger:response outofbandParseInvitationURLResponse
+type outofbandParseInvitationURLResponse struct { // nolint: unused,deadcode
 	// in: body
 	Body struct {
 		Invitation struct{ *protocol.Invitation } `json:"invitation"`
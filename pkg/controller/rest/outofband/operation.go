@@ -22,13 +22,15 @@ import (
 
 // constants for the OutOfBand protocol operations.
 const (
-	OperationID      = "/outofband"
-	CreateInvitation = OperationID + "/create-invitation"
-	AcceptRequest    = OperationID + "/accept-request"
-	AcceptInvitation = OperationID + "/accept-invitation"
-	Actions          = OperationID + "/actions"
-	ActionContinue   = OperationID + "/{piid}/action-continue"
-	ActionStop       = OperationID + "/{piid}/action-stop"
+	OperationID         = "/outofband"
+	CreateInvitation    = OperationID + "/create-invitation"
+	AcceptRequest       = OperationID + "/accept-request"
+	AcceptInvitation    = OperationID + "/accept-invitation"
+	AcceptInvitationURL = OperationID + "/accept-invitation-url"
+	ParseInvitationURL  = OperationID + "/parse-invitation-url"
+	Actions             = OperationID + "/actions"
+	ActionContinue      = OperationID + "/{piid}/action-continue"
+	ActionStop          = OperationID + "/{piid}/action-stop"
 )
 
 // Operation is controller REST service controller for outofband.
@@ -61,6 +63,8 @@ func (c *Operation) registerHandler() {
 	c.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateInvitation, http.MethodPost, c.CreateInvitation),
 		cmdutil.NewHTTPHandler(AcceptInvitation, http.MethodPost, c.AcceptInvitation),
+		cmdutil.NewHTTPHandler(AcceptInvitationURL, http.MethodPost, c.AcceptInvitationURL),
+		cmdutil.NewHTTPHandler(ParseInvitationURL, http.MethodPost, c.ParseInvitationURL),
 		cmdutil.NewHTTPHandler(Actions, http.MethodGet, c.Actions),
 		cmdutil.NewHTTPHandler(ActionContinue, http.MethodPost, c.ActionContinue),
 		cmdutil.NewHTTPHandler(ActionStop, http.MethodPost, c.ActionStop),
@@ -128,3 +132,25 @@ func (c *Operation) CreateInvitation(rw http.ResponseWriter, req *http.Request)
 func (c *Operation) AcceptInvitation(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(c.command.AcceptInvitation, rw, req.Body)
 }
+
+// AcceptInvitationURL swagger:route POST /outofband/accept-invitation-url outofband outofbandAcceptInvitationURL
+//
+// Accepts the invitation of an invitation URL, shortened URLs are fetched to get the invitation.
+//
+// Responses:
+//    default: genericError
+//        200: outofbandAcceptInvitationResponse
+func (c *Operation) AcceptInvitationURL(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.AcceptInvitationURL, rw, req.Body)
+}
+
+// ParseInvitationURL swagger:route POST /outofband/parse-invitation-url outofband outofbandParseInvitationURL
+//
+// Returns the invitation of an invitation URL, shortened URLs are fetched to get the invitation.
+//
+// Responses:
+//    default: genericError
+//        200: outofbandParseInvitationURLResponse
+func (c *Operation) ParseInvitationURL(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.ParseInvitationURL, rw, req.Body)
+}
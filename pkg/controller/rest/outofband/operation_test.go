@@ -110,6 +110,59 @@ func TestOperation_AcceptInvitation(t *testing.T) {
 	require.NotEmpty(t, res["connection_id"])
 }
 
+func TestOperation_AcceptInvitationURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	invitationURL, err := client.InvitationURL("https://example.com/invite", &client.Invitation{ID: "inv-id"})
+	require.NoError(t, err)
+
+	b, code, err := sendRequestToHandler(
+		handlerLookup(t, operation, AcceptInvitationURL),
+		bytes.NewBufferString(`{
+			"invitation_url":"`+invitationURL+`",
+			"my_label":"label"
+		}`),
+		AcceptInvitationURL,
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+
+	res := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+	require.NotEmpty(t, res["connection_id"])
+}
+
+func TestOperation_ParseInvitationURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	invitationURL, err := client.InvitationURL("https://example.com/invite", &client.Invitation{ID: "inv-id"})
+	require.NoError(t, err)
+
+	b, code, err := sendRequestToHandler(
+		handlerLookup(t, operation, ParseInvitationURL),
+		bytes.NewBufferString(`{"invitation_url":"`+invitationURL+`"}`),
+		ParseInvitationURL,
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+
+	res := struct {
+		Invitation *client.Invitation `json:"invitation"`
+	}{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+	require.Equal(t, "inv-id", res.Invitation.ID)
+}
+
 func TestOperation_Actions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+)
+
+// trustPingRequest model
+//
+// This is used for operation to send a trust ping.
+//
+// swagger:parameters trustPing
+type trustPingRequest struct { // nolint: unused,deadcode
+	// in: body
+	Params trustping.PingArgs
+}
+
+// trustPingResponse model
+//
+// Represents a Ping response message.
+//
+// swagger:response trustPingResponse
+type trustPingResponse struct { // nolint: unused,deadcode
+	// in: body
+	Response trustping.PingResponse
+}
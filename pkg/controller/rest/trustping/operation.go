@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the TrustPing protocol operations.
+const (
+	OperationID = "/trustping"
+	Ping        = OperationID + "/ping"
+)
+
+// Operation is controller REST service controller for trust ping.
+type Operation struct {
+	command  *trustping.Command
+	handlers []rest.Handler
+}
+
+// New returns new trust ping rest client protocol instance.
+func New(ctx trustping.Provider) (*Operation, error) {
+	cmd, err := trustping.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("trustping command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	// Add more protocol endpoints here to expose them as controller API endpoints
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(Ping, http.MethodPost, c.Ping),
+	}
+}
+
+// Ping swagger:route POST /trustping/ping trustping trustPing
+//
+// Sends a trust ping over a connection and waits for the response.
+//
+// Responses:
+//
+//	default: genericError
+//	    200: trustPingResponse
+func (c *Operation) Ping(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.Ping, rw, req.Body)
+}
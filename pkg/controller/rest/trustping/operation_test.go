@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(&mockprovider.Provider{ServiceErr: errors.New("error")})
+	require.EqualError(t, err, "trustping command : cannot create a client: failed to create trust ping "+
+		"service: error")
+}
+
+func TestOperation_Ping(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		operation, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		b, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Ping),
+			bytes.NewBufferString(`{"connection_id":"conn-id"}`),
+			Ping,
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		res := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, "ping-id", res["ping_id"])
+	})
+
+	t.Run("ping error", func(t *testing.T) {
+		operation, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingErr: errors.New("ping error"),
+		}})
+		require.NoError(t, err)
+
+		b, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, Ping),
+			bytes.NewBufferString(`{"connection_id":"conn-id"}`),
+			Ping,
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, b.String(), "ping error")
+	})
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
@@ -50,6 +50,8 @@ const (
 	ProposeCredentialPath         = OperationID + "/propose-credential"
 	RequestCredentialPath         = OperationID + "/request-credential"
 	ResolveCredentialManifestPath = OperationID + "/resolve-credential-manifest"
+	ExportPath                    = OperationID + "/export"
+	ImportPath                    = OperationID + "/import"
 )
 
 // provider contains dependencies for the verifiable credential wallet command controller
@@ -120,6 +122,8 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(ProposeCredentialPath, http.MethodPost, o.ProposeCredential),
 		cmdutil.NewHTTPHandler(RequestCredentialPath, http.MethodPost, o.RequestCredential),
 		cmdutil.NewHTTPHandler(ResolveCredentialManifestPath, http.MethodPost, o.ResolveCredentialManifest),
+		cmdutil.NewHTTPHandler(ExportPath, http.MethodPost, o.Export),
+		cmdutil.NewHTTPHandler(ImportPath, http.MethodPost, o.Import),
 	}
 }
 
@@ -348,6 +352,28 @@ func (o *Operation) CreateKeyPair(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.CreateKeyPair, rw, req.Body)
 }
 
+// Export swagger:route POST /vcwallet/export vcwallet exportReq
+//
+// exports all wallet contents and keys as encrypted wallet locked by given passphrase.
+//
+// Responses:
+//    default: genericError
+//        200: exportRes
+func (o *Operation) Export(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Export, rw, req.Body)
+}
+
+// Import swagger:route POST /vcwallet/import vcwallet importReq
+//
+// imports contents and keys of encrypted wallet into wallet.
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) Import(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Import, rw, req.Body)
+}
+
 // Connect swagger:route POST /vcwallet/connect vcwallet connectReq
 //
 // accepts out-of-band invitations and performs DID exchange.
@@ -75,7 +75,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &vcwallet.Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetRESTHandlers(), 21)
+		require.Len(t, cmd.GetRESTHandlers(), 23)
 	})
 }
 
@@ -1214,6 +1214,102 @@ func TestOperation_CreateKeyPair(t *testing.T) {
 	})
 }
 
+func TestOperation_ExportImport(t *testing.T) {
+	const (
+		sampleUser1      = "sample-user-01"
+		sampleUser2      = "sample-user-02"
+		exportPassphrase = "export-passphrase"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	var r exportResponse
+
+	t.Run("export wallet", func(t *testing.T) {
+		request := &vcwallet.ExportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token},
+			Passphrase: exportPassphrase,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ExportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.Export(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&r.Response))
+		require.NotEmpty(t, r.Response)
+		require.NotEmpty(t, r.Response.EncryptedWallet)
+	})
+
+	t.Run("import wallet", func(t *testing.T) {
+		importCtx := newMockProvider(t)
+
+		createSampleUserProfile(t, importCtx, &vcwallet.CreateOrUpdateProfileRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		importToken, importLock := unlockWallet(t, importCtx, &vcwallet.UnlockWalletRequest{
+			UserID:             sampleUser2,
+			LocalKMSPassphrase: samplePassPhrase,
+		})
+
+		defer importLock()
+
+		request := &vcwallet.ImportRequest{
+			WalletAuth:      vcwallet.WalletAuth{UserID: sampleUser2, Auth: importToken},
+			Passphrase:      exportPassphrase,
+			EncryptedWallet: r.Response.EncryptedWallet,
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(importCtx, &vcwallet.Config{})
+		cmd.Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusOK)
+	})
+
+	t.Run("export or import using invalid auth", func(t *testing.T) {
+		cmd := New(mockctx, &vcwallet.Config{})
+
+		rq := httptest.NewRequest(http.MethodPost, ExportPath, getReader(t, &vcwallet.ExportRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase: exportPassphrase,
+		}))
+		rw := httptest.NewRecorder()
+
+		cmd.Export(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid auth token")
+
+		rq = httptest.NewRequest(http.MethodPost, ImportPath, getReader(t, &vcwallet.ImportRequest{
+			WalletAuth:      vcwallet.WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase:      exportPassphrase,
+			EncryptedWallet: r.Response.EncryptedWallet,
+		}))
+		rw = httptest.NewRecorder()
+
+		cmd.Import(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "invalid auth token")
+	})
+}
+
 func TestOperation_Connect(t *testing.T) {
 	const sampleDIDCommUser = "sample-didcomm-user-01"
 
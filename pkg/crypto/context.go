@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crypto
+
+import (
+	"context"
+)
+
+// ContextCrypto is a Crypto whose operations accept a context for cancellation, deadlines and request scoped values.
+// Remote Crypto implementations (eg webkms) use it to abort in-flight calls.
+type ContextCrypto interface {
+	Crypto
+	EncryptContext(ctx context.Context, msg, aad []byte, kh interface{}) ([]byte, []byte, error)
+	DecryptContext(ctx context.Context, cipher, aad, nonce []byte, kh interface{}) ([]byte, error)
+	SignContext(ctx context.Context, msg []byte, kh interface{}) ([]byte, error)
+	VerifyContext(ctx context.Context, signature, msg []byte, kh interface{}) error
+	ComputeMACContext(ctx context.Context, data []byte, kh interface{}) ([]byte, error)
+	VerifyMACContext(ctx context.Context, mac, data []byte, kh interface{}) error
+	WrapKeyContext(ctx context.Context, cek, apu, apv []byte, recPubKey *PublicKey,
+		opts ...WrapKeyOpts) (*RecipientWrappedKey, error)
+	UnwrapKeyContext(ctx context.Context, recWK *RecipientWrappedKey, kh interface{},
+		opts ...WrapKeyOpts) ([]byte, error)
+	SignMultiContext(ctx context.Context, messages [][]byte, kh interface{}) ([]byte, error)
+	VerifyMultiContext(ctx context.Context, messages [][]byte, signature []byte, kh interface{}) error
+	VerifyProofContext(ctx context.Context, revealedMessages [][]byte, proof, nonce []byte, kh interface{}) error
+	DeriveProofContext(ctx context.Context, messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int,
+		kh interface{}) ([]byte, error)
+	BlindContext(ctx context.Context, kh interface{}, values ...map[string]interface{}) ([][]byte, error)
+	GetCorrectnessProofContext(ctx context.Context, kh interface{}) ([]byte, error)
+	SignWithSecretsContext(ctx context.Context, kh interface{}, values map[string]interface{}, secrets []byte,
+		correctnessProof []byte, nonces [][]byte, did string) ([]byte, []byte, error)
+}
+
+// NewContextCrypto returns c if it supports contexts, otherwise it wraps c in an adapter checking the context before
+// each operation. The adapter can't interrupt an operation in progress.
+func NewContextCrypto(c Crypto) ContextCrypto {
+	if cc, ok := c.(ContextCrypto); ok {
+		return cc
+	}
+
+	return &contextCrypto{Crypto: c}
+}
+
+type contextCrypto struct {
+	Crypto
+}
+
+func (c *contextCrypto) EncryptContext(ctx context.Context, msg, aad []byte, kh interface{}) ([]byte, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return c.Encrypt(msg, aad, kh)
+}
+
+func (c *contextCrypto) DecryptContext(ctx context.Context, cipher, aad, nonce []byte, kh interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.Decrypt(cipher, aad, nonce, kh)
+}
+
+func (c *contextCrypto) SignContext(ctx context.Context, msg []byte, kh interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.Sign(msg, kh)
+}
+
+func (c *contextCrypto) VerifyContext(ctx context.Context, signature, msg []byte, kh interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return c.Verify(signature, msg, kh)
+}
+
+func (c *contextCrypto) ComputeMACContext(ctx context.Context, data []byte, kh interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.ComputeMAC(data, kh)
+}
+
+func (c *contextCrypto) VerifyMACContext(ctx context.Context, mac, data []byte, kh interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return c.VerifyMAC(mac, data, kh)
+}
+
+func (c *contextCrypto) WrapKeyContext(ctx context.Context, cek, apu, apv []byte, recPubKey *PublicKey,
+	opts ...WrapKeyOpts) (*RecipientWrappedKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.WrapKey(cek, apu, apv, recPubKey, opts...)
+}
+
+func (c *contextCrypto) UnwrapKeyContext(ctx context.Context, recWK *RecipientWrappedKey, kh interface{},
+	opts ...WrapKeyOpts) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.UnwrapKey(recWK, kh, opts...)
+}
+
+func (c *contextCrypto) SignMultiContext(ctx context.Context, messages [][]byte, kh interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.SignMulti(messages, kh)
+}
+
+func (c *contextCrypto) VerifyMultiContext(ctx context.Context, messages [][]byte, signature []byte,
+	kh interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return c.VerifyMulti(messages, signature, kh)
+}
+
+func (c *contextCrypto) VerifyProofContext(ctx context.Context, revealedMessages [][]byte, proof, nonce []byte,
+	kh interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return c.VerifyProof(revealedMessages, proof, nonce, kh)
+}
+
+func (c *contextCrypto) DeriveProofContext(ctx context.Context, messages [][]byte, bbsSignature, nonce []byte,
+	revealedIndexes []int, kh interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.DeriveProof(messages, bbsSignature, nonce, revealedIndexes, kh)
+}
+
+func (c *contextCrypto) BlindContext(ctx context.Context, kh interface{},
+	values ...map[string]interface{}) ([][]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.Blind(kh, values...)
+}
+
+func (c *contextCrypto) GetCorrectnessProofContext(ctx context.Context, kh interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.GetCorrectnessProof(kh)
+}
+
+func (c *contextCrypto) SignWithSecretsContext(ctx context.Context, kh interface{}, values map[string]interface{},
+	secrets []byte, correctnessProof []byte, nonces [][]byte, did string) ([]byte, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return c.SignWithSecrets(kh, values, secrets, correctnessProof, nonces, did)
+}
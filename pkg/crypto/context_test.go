@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crypto_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+)
+
+func TestNewContextCrypto(t *testing.T) {
+	t.Run("delegates to the crypto", func(t *testing.T) {
+		c := crypto.NewContextCrypto(&mockcrypto.Crypto{SignValue: []byte("signature")})
+
+		sig, err := c.SignContext(context.Background(), []byte("message"), nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("signature"), sig)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		c := crypto.NewContextCrypto(&mockcrypto.Crypto{SignValue: []byte("signature")})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := c.SignContext(ctx, []byte("message"), nil)
+		require.True(t, errors.Is(err, context.Canceled))
+
+		err = c.VerifyContext(ctx, []byte("signature"), []byte("message"), nil)
+		require.True(t, errors.Is(err, context.Canceled))
+
+		_, _, err = c.EncryptContext(ctx, []byte("message"), nil, nil)
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("context crypto returned as is", func(t *testing.T) {
+		c := crypto.NewContextCrypto(&mockcrypto.Crypto{})
+
+		require.Equal(t, c, crypto.NewContextCrypto(c))
+	})
+}
@@ -0,0 +1,331 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pkcs11crypto provides a crypto.Crypto executing the private key operations of pkcs11kms key handles in
+// their PKCS#11 token.
+//
+// It supports ECDSA P-256 and P-384 signatures and ECDH-ES key wrapping (anoncrypt) with NIST P-256 and P-384 keys.
+// Key wrapping only needs the public key of the recipient and is executed in software, key unwrapping derives the
+// shared secret in the token. ECDH-1PU (authcrypt), AEAD, MAC, BBS+ and CL operations are not supported.
+package pkcs11crypto
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	josecipher "github.com/go-jose/go-jose/v3/cipher"
+	hybrid "github.com/google/tink/go/hybrid/subtle"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/pkcs11kms"
+)
+
+var _ cryptoapi.Crypto = (*Crypto)(nil)
+
+// ErrNotSupported is returned for operations the PKCS#11 crypto does not support.
+var ErrNotSupported = errors.New("operation not supported by pkcs11crypto")
+
+var errBadKeyHandleFormat = errors.New("bad key handle format")
+
+// Crypto is a crypto.Crypto for pkcs11kms key handles.
+type Crypto struct {
+	// wrapper wraps keys with recipient public keys, which doesn't involve private keys of the token.
+	wrapper *tinkcrypto.Crypto
+}
+
+// New creates a new Crypto instance.
+func New() (*Crypto, error) {
+	wrapper, err := tinkcrypto.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Crypto{wrapper: wrapper}, nil
+}
+
+// Encrypt is not supported.
+func (c *Crypto) Encrypt(_, _ []byte, _ interface{}) ([]byte, []byte, error) {
+	return nil, nil, fmt.Errorf("encrypt: %w", ErrNotSupported)
+}
+
+// Decrypt is not supported.
+func (c *Crypto) Decrypt(_, _, _ []byte, _ interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("decrypt: %w", ErrNotSupported)
+}
+
+// Sign will sign msg with the private key of kh in its PKCS#11 token. kh must be a *pkcs11kms.KeyHandle of an
+// ECDSA key pair, msg is hashed with SHA-256 for P-256 keys and SHA-384 for P-384 keys.
+// Returns:
+//   - signature in []byte, DER or IEEE P1363 encoded as per the key type
+//   - error in case of errors
+func (c *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
+	keyHandle, der, err := signingKeyHandle(kh)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	signature, err := keyHandle.Sign(digest(keyHandle.KeyType(), msg))
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	if !der {
+		return signature, nil
+	}
+
+	half := len(signature) / 2 //nolint:gomnd
+
+	signature, err = asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(signature[:half]),
+		S: new(big.Int).SetBytes(signature[half:]),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign: marshal DER signature: %w", err)
+	}
+
+	return signature, nil
+}
+
+// Verify will verify a signature for the given msg with the public key of kh, which must be a *pkcs11kms.KeyHandle
+// of an ECDSA key pair or public key. The verification is executed in software.
+// Returns:
+//   - error in case of errors or nil if signature verification was successful
+func (c *Crypto) Verify(signature, msg []byte, kh interface{}) error {
+	keyHandle, der, err := signingKeyHandle(kh)
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+
+	r, s := new(big.Int), new(big.Int)
+
+	if der {
+		sig := ecdsaSignature{}
+
+		rest, err := asn1.Unmarshal(signature, &sig)
+		if err != nil || len(rest) > 0 {
+			return errors.New("verify: invalid DER signature")
+		}
+
+		r, s = sig.R, sig.S
+	} else {
+		size := (keyHandle.PublicKey().Curve.Params().BitSize + 7) / 8 //nolint:gomnd
+		if len(signature) != 2*size {
+			return errors.New("verify: invalid IEEE P1363 signature")
+		}
+
+		r.SetBytes(signature[:size])
+		s.SetBytes(signature[size:])
+	}
+
+	if !ecdsa.Verify(keyHandle.PublicKey(), digest(keyHandle.KeyType(), msg), r, s) {
+		return errors.New("verify: invalid signature")
+	}
+
+	return nil
+}
+
+// ComputeMAC is not supported.
+func (c *Crypto) ComputeMAC(_ []byte, _ interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("computeMAC: %w", ErrNotSupported)
+}
+
+// VerifyMAC is not supported.
+func (c *Crypto) VerifyMAC(_, _ []byte, _ interface{}) error {
+	return fmt.Errorf("verifyMAC: %w", ErrNotSupported)
+}
+
+// WrapKey will execute ECDH-ES key wrapping of cek using apu, apv and recipient public key 'recPubKey'.
+// The WithSender() option (ECDH-1PU key wrapping) is not supported.
+// Returns:
+//   - RecipientWrappedKey containing the wrapped cek value
+//   - error in case of errors
+func (c *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
+	opts ...cryptoapi.WrapKeyOpts) (*cryptoapi.RecipientWrappedKey, error) {
+	if senderKey(opts) != nil {
+		return nil, fmt.Errorf("wrapKey: ECDH-1PU: %w", ErrNotSupported)
+	}
+
+	return c.wrapper.WrapKey(cek, apu, apv, recPubKey, opts...)
+}
+
+// UnwrapKey unwraps a key in recWK wrapped with ECDH-ES using the recipient private key of kh, which must be a
+// *pkcs11kms.KeyHandle of a NIST P ECDH key pair. The shared secret is derived in the PKCS#11 token.
+// The WithSender() option (ECDH-1PU key unwrapping) is not supported.
+// Returns:
+//   - unwrapped key in raw bytes
+//   - error in case of errors
+func (c *Crypto) UnwrapKey(recWK *cryptoapi.RecipientWrappedKey, kh interface{},
+	opts ...cryptoapi.WrapKeyOpts) ([]byte, error) {
+	if recWK == nil {
+		return nil, errors.New("unwrapKey: RecipientWrappedKey is empty")
+	}
+
+	if senderKey(opts) != nil {
+		return nil, fmt.Errorf("unwrapKey: ECDH-1PU: %w", ErrNotSupported)
+	}
+
+	keyHandle, ok := kh.(*pkcs11kms.KeyHandle)
+	if !ok || (keyHandle.KeyType() != kms.NISTP256ECDHKWType && keyHandle.KeyType() != kms.NISTP384ECDHKWType) {
+		return nil, fmt.Errorf("unwrapKey: %w", errBadKeyHandleFormat)
+	}
+
+	curve, err := hybrid.GetCurve(recWK.EPK.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapKey: invalid EPK curve: %w", err)
+	}
+
+	z, err := keyHandle.DeriveSharedSecret(&ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(recWK.EPK.X),
+		Y:     new(big.Int).SetBytes(recWK.EPK.Y),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unwrapKey: %w", err)
+	}
+
+	kek := kdf(recWK.Alg, z, recWK.APU, recWK.APV, cryptoapi.DefKeySize)
+
+	switch recWK.Alg {
+	case tinkcrypto.ECDHESA256KWAlg:
+		block, err := aes.NewCipher(kek)
+		if err != nil {
+			return nil, fmt.Errorf("unwrapKey: failed to create new AES Cipher: %w", err)
+		}
+
+		cek, err := josecipher.KeyUnwrap(block, recWK.EncryptedCEK)
+		if err != nil {
+			return nil, fmt.Errorf("unwrapKey: failed to AES unwrap key: %w", err)
+		}
+
+		return cek, nil
+	case tinkcrypto.ECDHESXC20PKWAlg:
+		aead, err := chacha20poly1305.NewX(kek)
+		if err != nil {
+			return nil, fmt.Errorf("unwrapKey: failed to create new XC20P primitive: %w", err)
+		}
+
+		if len(recWK.EncryptedCEK) < aead.NonceSize() {
+			return nil, errors.New("unwrapKey: invalid XC20P wrapped key")
+		}
+
+		cek, err := aead.Open(nil, recWK.EncryptedCEK[:aead.NonceSize()], recWK.EncryptedCEK[aead.NonceSize():], nil)
+		if err != nil {
+			return nil, fmt.Errorf("unwrapKey: failed to XC20P unwrap key: %w", err)
+		}
+
+		return cek, nil
+	default:
+		return nil, fmt.Errorf("unwrapKey: unsupported JWE KW Alg '%s'", recWK.Alg)
+	}
+}
+
+// SignMulti is not supported.
+func (c *Crypto) SignMulti(_ [][]byte, _ interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("signMulti: %w", ErrNotSupported)
+}
+
+// VerifyMulti is not supported.
+func (c *Crypto) VerifyMulti(_ [][]byte, _ []byte, _ interface{}) error {
+	return fmt.Errorf("verifyMulti: %w", ErrNotSupported)
+}
+
+// VerifyProof is not supported.
+func (c *Crypto) VerifyProof(_ [][]byte, _, _ []byte, _ interface{}) error {
+	return fmt.Errorf("verifyProof: %w", ErrNotSupported)
+}
+
+// DeriveProof is not supported.
+func (c *Crypto) DeriveProof(_ [][]byte, _, _ []byte, _ []int, _ interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("deriveProof: %w", ErrNotSupported)
+}
+
+// Blind is not supported.
+func (c *Crypto) Blind(_ interface{}, _ ...map[string]interface{}) ([][]byte, error) {
+	return nil, fmt.Errorf("blind: %w", ErrNotSupported)
+}
+
+// GetCorrectnessProof is not supported.
+func (c *Crypto) GetCorrectnessProof(_ interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("getCorrectnessProof: %w", ErrNotSupported)
+}
+
+// SignWithSecrets is not supported.
+func (c *Crypto) SignWithSecrets(_ interface{}, _ map[string]interface{}, _ []byte, _ []byte, _ [][]byte,
+	_ string) ([]byte, []byte, error) {
+	return nil, nil, fmt.Errorf("signWithSecrets: %w", ErrNotSupported)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// signingKeyHandle returns kh as the handle of an ECDSA key and whether its signatures are DER encoded.
+func signingKeyHandle(kh interface{}) (*pkcs11kms.KeyHandle, bool, error) {
+	keyHandle, ok := kh.(*pkcs11kms.KeyHandle)
+	if !ok {
+		return nil, false, errBadKeyHandleFormat
+	}
+
+	switch keyHandle.KeyType() {
+	case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER:
+		return keyHandle, true, nil
+	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363:
+		return keyHandle, false, nil
+	default:
+		return nil, false, fmt.Errorf("key type '%s' is not a signing key type", keyHandle.KeyType())
+	}
+}
+
+func digest(kt kms.KeyType, msg []byte) []byte {
+	if kt == kms.ECDSAP384TypeDER || kt == kms.ECDSAP384TypeIEEEP1363 {
+		hash := sha512.Sum384(msg)
+
+		return hash[:]
+	}
+
+	hash := sha256.Sum256(msg)
+
+	return hash[:]
+}
+
+func senderKey(opts []cryptoapi.WrapKeyOpts) interface{} {
+	pOpts := cryptoapi.NewOpt()
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	return pOpts.SenderKey()
+}
+
+// kdf derives the key encryption key from the shared secret z with the Concat KDF of ECDH-ES, just as tinkcrypto.
+func kdf(kwAlg string, z, apu, apv []byte, keySize int) []byte {
+	// suppPubInfo is the encoded length of the output size in bits
+	supPubInfo := make([]byte, 4) //nolint:gomnd
+
+	binary.BigEndian.PutUint32(supPubInfo, uint32(keySize)*8) //nolint:gomnd
+
+	reader := josecipher.NewConcatKDF(crypto.SHA256, z, cryptoutil.LengthPrefix([]byte(kwAlg)),
+		cryptoutil.LengthPrefix(apu), cryptoutil.LengthPrefix(apv), supPubInfo, []byte{})
+
+	kek := make([]byte, keySize)
+
+	_, _ = reader.Read(kek) // nolint:errcheck // ConcatKDF's Read() never returns an error
+
+	return kek
+}
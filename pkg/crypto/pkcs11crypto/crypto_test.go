@@ -0,0 +1,255 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11crypto
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/pkcs11kms"
+	mockpkcs11 "github.com/hyperledger/aries-framework-go/pkg/mock/pkcs11"
+)
+
+func newKMS(t *testing.T) *pkcs11kms.KMS {
+	t.Helper()
+
+	k, err := pkcs11kms.NewWithModule(&mockpkcs11.Module{TokenLabel: "aries", PIN: "1234"}, "aries", "1234")
+	require.NoError(t, err)
+
+	return k
+}
+
+func TestCrypto_SignVerify(t *testing.T) {
+	k := newKMS(t)
+
+	c, err := New()
+	require.NoError(t, err)
+
+	msg := []byte("hello world")
+
+	for _, kt := range []kms.KeyType{
+		kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363,
+	} {
+		t.Run(string(kt), func(t *testing.T) {
+			keyID, kh, err := k.Create(kt)
+			require.NoError(t, err)
+
+			signature, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Verify(signature, msg, kh))
+
+			pubKey, _, err := k.ExportPubKeyBytes(keyID)
+			require.NoError(t, err)
+
+			pubKH, err := k.PubKeyBytesToHandle(pubKey, kt)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Verify(signature, msg, pubKH))
+			require.EqualError(t, c.Verify(signature, []byte("other"), pubKH), "verify: invalid signature")
+
+			_, err = c.Sign(msg, pubKH)
+			require.ErrorIs(t, err, pkcs11kms.ErrPublicKeyHandle)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, kh, err := k.Create(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		require.EqualError(t, c.Verify([]byte("invalid"), msg, kh), "verify: invalid DER signature")
+
+		_, kh, err = k.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		require.EqualError(t, c.Verify([]byte("invalid"), msg, kh), "verify: invalid IEEE P1363 signature")
+
+		_, err = c.Sign(msg, "not a key handle")
+		require.ErrorIs(t, err, errBadKeyHandleFormat)
+
+		require.ErrorIs(t, c.Verify(nil, msg, "not a key handle"), errBadKeyHandleFormat)
+
+		_, kh, err = k.Create(kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		_, err = c.Sign(msg, kh)
+		require.EqualError(t, err, "sign: key type 'NISTP256ECDHKW' is not a signing key type")
+	})
+}
+
+func TestCrypto_WrapUnwrapKey(t *testing.T) {
+	k := newKMS(t)
+
+	c, err := New()
+	require.NoError(t, err)
+
+	cek := random(t, cryptoapi.DefKeySize)
+	apu := []byte("sender")
+	apv := []byte("recipient")
+
+	for _, kt := range []kms.KeyType{kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType} {
+		t.Run(string(kt), func(t *testing.T) {
+			keyID, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			recPubKey := &cryptoapi.PublicKey{}
+			require.NoError(t, json.Unmarshal(pubKeyBytes, recPubKey))
+
+			recPubKey.KID = keyID
+
+			kh, err := k.Get(keyID)
+			require.NoError(t, err)
+
+			wrappedKey, err := c.WrapKey(cek, apu, apv, recPubKey)
+			require.NoError(t, err)
+			require.Equal(t, tinkcrypto.ECDHESA256KWAlg, wrappedKey.Alg)
+
+			unwrapped, err := c.UnwrapKey(wrappedKey, kh)
+			require.NoError(t, err)
+			require.Equal(t, cek, unwrapped)
+
+			wrappedKey, err = c.WrapKey(cek, apu, apv, recPubKey, cryptoapi.WithXC20PKW())
+			require.NoError(t, err)
+			require.Equal(t, tinkcrypto.ECDHESXC20PKWAlg, wrappedKey.Alg)
+
+			unwrapped, err = c.UnwrapKey(wrappedKey, kh)
+			require.NoError(t, err)
+			require.Equal(t, cek, unwrapped)
+
+			// keys wrapped by the software crypto unwrap in the token.
+			wrappedKey, err = c.wrapper.WrapKey(cek, apu, apv, recPubKey)
+			require.NoError(t, err)
+
+			unwrapped, err = c.UnwrapKey(wrappedKey, kh)
+			require.NoError(t, err)
+			require.Equal(t, cek, unwrapped)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		keyID, pubKeyBytes, err := k.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		recPubKey := &cryptoapi.PublicKey{}
+		require.NoError(t, json.Unmarshal(pubKeyBytes, recPubKey))
+
+		kh, err := k.Get(keyID)
+		require.NoError(t, err)
+
+		_, err = c.WrapKey(cek, apu, apv, recPubKey, cryptoapi.WithSender(kh))
+		require.ErrorIs(t, err, ErrNotSupported)
+
+		_, err = c.UnwrapKey(nil, kh)
+		require.EqualError(t, err, "unwrapKey: RecipientWrappedKey is empty")
+
+		wrappedKey, err := c.WrapKey(cek, apu, apv, recPubKey)
+		require.NoError(t, err)
+
+		_, err = c.UnwrapKey(wrappedKey, kh, cryptoapi.WithSender(kh))
+		require.ErrorIs(t, err, ErrNotSupported)
+
+		_, err = c.UnwrapKey(wrappedKey, "not a key handle")
+		require.ErrorIs(t, err, errBadKeyHandleFormat)
+
+		_, signingKH, err := k.Create(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		_, err = c.UnwrapKey(wrappedKey, signingKH)
+		require.ErrorIs(t, err, errBadKeyHandleFormat)
+
+		pubKH, err := k.PubKeyBytesToHandle(pubKeyBytes, kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		_, err = c.UnwrapKey(wrappedKey, pubKH)
+		require.ErrorIs(t, err, pkcs11kms.ErrPublicKeyHandle)
+
+		_, p384KH, err := k.Create(kms.NISTP384ECDHKWType)
+		require.NoError(t, err)
+
+		_, err = c.UnwrapKey(wrappedKey, p384KH)
+		require.EqualError(t, err, "unwrapKey: public key is not on the curve of the private key")
+
+		badWK := *wrappedKey
+		badWK.Alg = "ECDH-ES+A128KW"
+
+		_, err = c.UnwrapKey(&badWK, kh)
+		require.EqualError(t, err, "unwrapKey: unsupported JWE KW Alg 'ECDH-ES+A128KW'")
+
+		badWK = *wrappedKey
+		badWK.EPK.Curve = "unknown"
+
+		_, err = c.UnwrapKey(&badWK, kh)
+		require.Contains(t, err.Error(), "unwrapKey: invalid EPK curve")
+
+		badWK = *wrappedKey
+		badWK.EncryptedCEK = []byte("invalid")
+
+		_, err = c.UnwrapKey(&badWK, kh)
+		require.Contains(t, err.Error(), "unwrapKey: failed to AES unwrap key")
+
+		badWK.Alg = tinkcrypto.ECDHESXC20PKWAlg
+
+		_, err = c.UnwrapKey(&badWK, kh)
+		require.EqualError(t, err, "unwrapKey: invalid XC20P wrapped key")
+
+		badWK.EncryptedCEK = random(t, 64)
+
+		_, err = c.UnwrapKey(&badWK, kh)
+		require.Contains(t, err.Error(), "unwrapKey: failed to XC20P unwrap key")
+	})
+}
+
+func TestCrypto_NotSupported(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	_, _, err = c.Encrypt(nil, nil, nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, err = c.Decrypt(nil, nil, nil, nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, err = c.ComputeMAC(nil, nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	require.ErrorIs(t, c.VerifyMAC(nil, nil, nil), ErrNotSupported)
+
+	_, err = c.SignMulti(nil, nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	require.ErrorIs(t, c.VerifyMulti(nil, nil, nil), ErrNotSupported)
+	require.ErrorIs(t, c.VerifyProof(nil, nil, nil, nil), ErrNotSupported)
+
+	_, err = c.DeriveProof(nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, err = c.Blind(nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, err = c.GetCorrectnessProof(nil)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, _, err = c.SignWithSecrets(nil, nil, nil, nil, nil, "")
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func random(t *testing.T, size int) []byte {
+	t.Helper()
+
+	b := make([]byte, size)
+
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+
+	return b
+}
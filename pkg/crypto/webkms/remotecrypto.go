@@ -8,6 +8,7 @@ package webkms
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
@@ -216,15 +217,17 @@ func New(keystoreURL string, client HTTPClient, opts ...webkmsimpl.Opt) *RemoteC
 	}
 }
 
-func (r *RemoteCrypto) postHTTPRequest(destination string, mReq []byte) (*http.Response, error) {
-	return r.doHTTPRequest(http.MethodPost, destination, mReq)
+func (r *RemoteCrypto) postHTTPRequest(ctx context.Context, destination string,
+	mReq []byte) (*http.Response, error) {
+	return r.doHTTPRequest(ctx, http.MethodPost, destination, mReq)
 }
 
-func (r *RemoteCrypto) getHTTPRequest(destination string) (*http.Response, error) {
-	return r.doHTTPRequest(http.MethodGet, destination, nil)
+func (r *RemoteCrypto) getHTTPRequest(ctx context.Context, destination string) (*http.Response, error) {
+	return r.doHTTPRequest(ctx, http.MethodGet, destination, nil)
 }
 
-func (r *RemoteCrypto) doHTTPRequest(method, destination string, mReq []byte) (*http.Response, error) {
+func (r *RemoteCrypto) doHTTPRequest(ctx context.Context, method, destination string,
+	mReq []byte) (*http.Response, error) {
 	start := time.Now()
 
 	var body io.Reader
@@ -233,7 +236,7 @@ func (r *RemoteCrypto) doHTTPRequest(method, destination string, mReq []byte) (*
 		body = bytes.NewBuffer(mReq)
 	}
 
-	httpReq, err := http.NewRequest(method, destination, body)
+	httpReq, err := http.NewRequestWithContext(ctx, method, destination, body)
 	if err != nil {
 		return nil, fmt.Errorf("build request error: %w", err)
 	}
@@ -268,6 +271,12 @@ func (r *RemoteCrypto) doHTTPRequest(method, destination string, mReq []byte) (*
 //	nonce in []byte
 //	error in case of errors during encryption
 func (r *RemoteCrypto) Encrypt(msg, aad []byte, keyURL interface{}) ([]byte, []byte, error) {
+	return r.EncryptContext(context.Background(), msg, aad, keyURL)
+}
+
+// EncryptContext is Encrypt honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) EncryptContext(ctx context.Context, msg, aad []byte,
+	keyURL interface{}) ([]byte, []byte, error) {
 	startEncrypt := time.Now()
 	destination := fmt.Sprintf("%s", keyURL) + encryptURI
 
@@ -281,7 +290,7 @@ func (r *RemoteCrypto) Encrypt(msg, aad []byte, keyURL interface{}) ([]byte, []b
 		return nil, nil, fmt.Errorf("marshal encryption request for Encrypt failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, nil, fmt.Errorf("posting Encrypt plaintext failed [%s, %w]", destination, err)
 	}
@@ -317,6 +326,12 @@ func (r *RemoteCrypto) Encrypt(msg, aad []byte, keyURL interface{}) ([]byte, []b
 //	plainText in []byte
 //	error in case of errors
 func (r *RemoteCrypto) Decrypt(cipher, aad, nonce []byte, keyURL interface{}) ([]byte, error) {
+	return r.DecryptContext(context.Background(), cipher, aad, nonce, keyURL)
+}
+
+// DecryptContext is Decrypt honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) DecryptContext(ctx context.Context, cipher, aad, nonce []byte,
+	keyURL interface{}) ([]byte, error) {
 	startDecrypt := time.Now()
 	destination := fmt.Sprintf("%s", keyURL) + decryptURI
 
@@ -331,7 +346,7 @@ func (r *RemoteCrypto) Decrypt(cipher, aad, nonce []byte, keyURL interface{}) ([
 		return nil, fmt.Errorf("marshal decryption request for Decrypt failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting Decrypt ciphertext failed [%s, %w]", destination, err)
 	}
@@ -366,6 +381,11 @@ func (r *RemoteCrypto) Decrypt(cipher, aad, nonce []byte, keyURL interface{}) ([
 //	signature in []byte
 //	error in case of errors
 func (r *RemoteCrypto) Sign(msg []byte, keyURL interface{}) ([]byte, error) {
+	return r.SignContext(context.Background(), msg, keyURL)
+}
+
+// SignContext is Sign honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) SignContext(ctx context.Context, msg []byte, keyURL interface{}) ([]byte, error) {
 	startSign := time.Now()
 	destination := fmt.Sprintf("%s", keyURL) + signURI
 
@@ -378,7 +398,7 @@ func (r *RemoteCrypto) Sign(msg []byte, keyURL interface{}) ([]byte, error) {
 		return nil, fmt.Errorf("marshal signature request for Sign failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting Sign message failed [%s, %w]", destination, err)
 	}
@@ -413,6 +433,11 @@ func (r *RemoteCrypto) Sign(msg []byte, keyURL interface{}) ([]byte, error) {
 //
 //	error in case of errors or nil if signature verification was successful
 func (r *RemoteCrypto) Verify(signature, msg []byte, keyURL interface{}) error {
+	return r.VerifyContext(context.Background(), signature, msg, keyURL)
+}
+
+// VerifyContext is Verify honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) VerifyContext(ctx context.Context, signature, msg []byte, keyURL interface{}) error {
 	startVerify := time.Now()
 	destination := fmt.Sprintf("%s", keyURL) + verifyURI
 
@@ -426,7 +451,7 @@ func (r *RemoteCrypto) Verify(signature, msg []byte, keyURL interface{}) error {
 		return fmt.Errorf("marshal verify request for Verify failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return fmt.Errorf("posting Verify signature failed [%s, %w]", destination, err)
 	}
@@ -445,7 +470,13 @@ func (r *RemoteCrypto) Verify(signature, msg []byte, keyURL interface{}) error {
 
 // ComputeMAC remotely computes message authentication code (MAC) for code data with key at keyURL.
 // using a matching MAC primitive in kh key handle.
-func (r *RemoteCrypto) ComputeMAC(data []byte, keyURL interface{}) ([]byte, error) { // nolint:gocyclo
+func (r *RemoteCrypto) ComputeMAC(data []byte, keyURL interface{}) ([]byte, error) {
+	return r.ComputeMACContext(context.Background(), data, keyURL)
+}
+
+// ComputeMACContext is ComputeMAC honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) ComputeMACContext(ctx context.Context, data []byte, // nolint:gocyclo
+	keyURL interface{}) ([]byte, error) {
 	keyHash := string(sha256.New().Sum([]byte(fmt.Sprintf("%s_%s", keyURL, data))))
 
 	if r.opts.ComputeMACCache != nil {
@@ -471,7 +502,7 @@ func (r *RemoteCrypto) ComputeMAC(data []byte, keyURL interface{}) ([]byte, erro
 		return nil, fmt.Errorf("marshal request for ComputeMAC failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting ComputeMAC request failed [%s, %w]", destination, err)
 	}
@@ -509,6 +540,11 @@ func (r *RemoteCrypto) ComputeMAC(data []byte, keyURL interface{}) ([]byte, erro
 // VerifyMAC remotely determines if mac is a correct authentication code (MAC) for data using a key at KeyURL
 // using a matching MAC primitive in kh key handle and returns nil if so, otherwise it returns an error.
 func (r *RemoteCrypto) VerifyMAC(mac, data []byte, keyURL interface{}) error {
+	return r.VerifyMACContext(context.Background(), mac, data, keyURL)
+}
+
+// VerifyMACContext is VerifyMAC honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) VerifyMACContext(ctx context.Context, mac, data []byte, keyURL interface{}) error {
 	startVerifyMAC := time.Now()
 	destination := fmt.Sprintf("%s", keyURL) + verifyMACURI
 
@@ -522,7 +558,7 @@ func (r *RemoteCrypto) VerifyMAC(mac, data []byte, keyURL interface{}) error {
 		return fmt.Errorf("marshal request for VerifyMAC failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return fmt.Errorf("posting VerifyMAC request failed [%s, %w]", destination, err)
 	}
@@ -546,8 +582,14 @@ func (r *RemoteCrypto) VerifyMAC(mac, data []byte, keyURL interface{}) error {
 //
 //	RecipientWrappedKey containing the wrapped cek value
 //	error in case of errors
-func (r *RemoteCrypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey, // nolint:funlen
+func (r *RemoteCrypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey,
 	opts ...crypto.WrapKeyOpts) (*crypto.RecipientWrappedKey, error) {
+	return r.WrapKeyContext(context.Background(), cek, apu, apv, recPubKey, opts...)
+}
+
+// WrapKeyContext is WrapKey honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) WrapKeyContext(ctx context.Context, cek, apu, apv []byte, // nolint:funlen
+	recPubKey *crypto.PublicKey, opts ...crypto.WrapKeyOpts) (*crypto.RecipientWrappedKey, error) {
 	startWrapKey := time.Now()
 	destination := r.keystoreURL + wrapURI
 
@@ -586,7 +628,7 @@ func (r *RemoteCrypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey
 		return nil, fmt.Errorf("marshal wrapKeyReq for WrapKey failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting WrapKey failed [%s, %w]", destination, err)
 	}
@@ -630,6 +672,12 @@ func (r *RemoteCrypto) buildWrappedKeyResponse(respBody []byte, dest string) (*c
 //	unwrapped key in raw bytes
 //	error in case of errors
 func (r *RemoteCrypto) UnwrapKey(recWK *crypto.RecipientWrappedKey, keyURL interface{},
+	opts ...crypto.WrapKeyOpts) ([]byte, error) {
+	return r.UnwrapKeyContext(context.Background(), recWK, keyURL, opts...)
+}
+
+// UnwrapKeyContext is UnwrapKey honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) UnwrapKeyContext(ctx context.Context, recWK *crypto.RecipientWrappedKey, keyURL interface{},
 	opts ...crypto.WrapKeyOpts) ([]byte, error) {
 	startUnwrapKey := time.Now()
 	destination := fmt.Sprintf("%s", keyURL) + unwrapURI
@@ -659,7 +707,7 @@ func (r *RemoteCrypto) UnwrapKey(recWK *crypto.RecipientWrappedKey, keyURL inter
 		return nil, fmt.Errorf("marshal unwrapKeyReq for UnwrapKey failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting UnwrapKey failed [%s, %w]", destination, err)
 	}
@@ -710,6 +758,12 @@ func ksToCryptoPublicKey(ks interface{}) (*crypto.PublicKey, error) {
 //	signature in []byte
 //	error in case of errors
 func (r *RemoteCrypto) SignMulti(messages [][]byte, signerKeyURL interface{}) ([]byte, error) {
+	return r.SignMultiContext(context.Background(), messages, signerKeyURL)
+}
+
+// SignMultiContext is SignMulti honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) SignMultiContext(ctx context.Context, messages [][]byte,
+	signerKeyURL interface{}) ([]byte, error) {
 	startSign := time.Now()
 	destination := fmt.Sprintf("%s", signerKeyURL) + signMultiURI
 
@@ -722,7 +776,7 @@ func (r *RemoteCrypto) SignMulti(messages [][]byte, signerKeyURL interface{}) ([
 		return nil, fmt.Errorf("marshal signature request for BBS+ Sign failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting BBS+ Sign message failed [%s, %w]", destination, err)
 	}
@@ -756,6 +810,12 @@ func (r *RemoteCrypto) SignMulti(messages [][]byte, signerKeyURL interface{}) ([
 //
 //	error in case of errors or nil if signature verification was successful
 func (r *RemoteCrypto) VerifyMulti(messages [][]byte, signature []byte, signerKeyURL interface{}) error {
+	return r.VerifyMultiContext(context.Background(), messages, signature, signerKeyURL)
+}
+
+// VerifyMultiContext is VerifyMulti honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) VerifyMultiContext(ctx context.Context, messages [][]byte, signature []byte,
+	signerKeyURL interface{}) error {
 	startVerify := time.Now()
 	destination := fmt.Sprintf("%s", signerKeyURL) + verifyMultiURI
 
@@ -769,7 +829,7 @@ func (r *RemoteCrypto) VerifyMulti(messages [][]byte, signature []byte, signerKe
 		return fmt.Errorf("marshal verify request for BBS+ Verify failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return fmt.Errorf("posting BBS+ Verify signature failed [%s, %w]", destination, err)
 	}
@@ -792,6 +852,12 @@ func (r *RemoteCrypto) VerifyMulti(messages [][]byte, signature []byte, signerKe
 //
 //	error in case of errors or nil if signature proof verification was successful
 func (r *RemoteCrypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, signerKeyURL interface{}) error {
+	return r.VerifyProofContext(context.Background(), revealedMessages, proof, nonce, signerKeyURL)
+}
+
+// VerifyProofContext is VerifyProof honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) VerifyProofContext(ctx context.Context, revealedMessages [][]byte, proof, nonce []byte,
+	signerKeyURL interface{}) error {
 	startVerifyProof := time.Now()
 	destination := fmt.Sprintf("%s", signerKeyURL) + verifyProofURI
 
@@ -806,7 +872,7 @@ func (r *RemoteCrypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byt
 		return fmt.Errorf("marshal request for BBS+ Verify proof failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return fmt.Errorf("posting BBS+ Verify proof failed [%s, %w]", destination, err)
 	}
@@ -831,6 +897,12 @@ func (r *RemoteCrypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byt
 //	error in case of errors
 func (r *RemoteCrypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int,
 	signerKeyURL interface{}) ([]byte, error) {
+	return r.DeriveProofContext(context.Background(), messages, bbsSignature, nonce, revealedIndexes, signerKeyURL)
+}
+
+// DeriveProofContext is DeriveProof honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) DeriveProofContext(ctx context.Context, messages [][]byte, bbsSignature, nonce []byte,
+	revealedIndexes []int, signerKeyURL interface{}) ([]byte, error) {
 	startDeriveProof := time.Now()
 	destination := fmt.Sprintf("%s", signerKeyURL) + deriveProofURI
 
@@ -846,7 +918,7 @@ func (r *RemoteCrypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte
 		return nil, fmt.Errorf("marshal request for BBS+ Derive proof failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting BBS+ Derive proof message failed [%s, %w]", destination, err)
 	}
@@ -881,6 +953,12 @@ func (r *RemoteCrypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte
 //	blinded values in []byte
 //	error in case of errors
 func (r *RemoteCrypto) Blind(kh interface{}, values ...map[string]interface{}) ([][]byte, error) {
+	return r.BlindContext(context.Background(), kh, values...)
+}
+
+// BlindContext is Blind honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) BlindContext(ctx context.Context, kh interface{},
+	values ...map[string]interface{}) ([][]byte, error) {
 	startBlind := time.Now()
 	destination := fmt.Sprintf("%s", kh) + blindURI
 
@@ -893,7 +971,7 @@ func (r *RemoteCrypto) Blind(kh interface{}, values ...map[string]interface{}) (
 		return nil, fmt.Errorf("marshal request for CL Blind failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, fmt.Errorf("posting CL Blind message failed [%s, %w]", destination, err)
 	}
@@ -928,10 +1006,15 @@ func (r *RemoteCrypto) Blind(kh interface{}, values ...map[string]interface{}) (
 //	correctness proof in []byte
 //	error in case of errors
 func (r *RemoteCrypto) GetCorrectnessProof(kh interface{}) ([]byte, error) {
+	return r.GetCorrectnessProofContext(context.Background(), kh)
+}
+
+// GetCorrectnessProofContext is GetCorrectnessProof honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) GetCorrectnessProofContext(ctx context.Context, kh interface{}) ([]byte, error) {
 	startGet := time.Now()
 	destination := fmt.Sprintf("%s", kh) + correctnessProofURI
 
-	resp, err := r.getHTTPRequest(destination)
+	resp, err := r.getHTTPRequest(ctx, destination)
 	if err != nil {
 		return nil, fmt.Errorf("getting CL CorrectnessProof message failed [%s, %w]", destination, err)
 	}
@@ -968,6 +1051,12 @@ func (r *RemoteCrypto) GetCorrectnessProof(kh interface{}) ([]byte, error) {
 //	correctness proof in []byte
 //	error in case of errors
 func (r *RemoteCrypto) SignWithSecrets(kh interface{}, values map[string]interface{},
+	secrets []byte, correctnessProof []byte, nonces [][]byte, did string) ([]byte, []byte, error) {
+	return r.SignWithSecretsContext(context.Background(), kh, values, secrets, correctnessProof, nonces, did)
+}
+
+// SignWithSecretsContext is SignWithSecrets honouring ctx cancellation and deadline.
+func (r *RemoteCrypto) SignWithSecretsContext(ctx context.Context, kh interface{}, values map[string]interface{},
 	secrets []byte, correctnessProof []byte, nonces [][]byte, did string) ([]byte, []byte, error) {
 	startSign := time.Now()
 	destination := fmt.Sprintf("%s", kh) + signWithSecretsURI
@@ -985,7 +1074,7 @@ func (r *RemoteCrypto) SignWithSecrets(kh interface{}, values map[string]interfa
 		return nil, nil, fmt.Errorf("marshal request for CL SignWithSecrets failed [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	resp, err := r.postHTTPRequest(ctx, destination, httpReqBytes)
 	if err != nil {
 		return nil, nil, fmt.Errorf("posting CL SignWithSecrets message failed [%s, %w]", destination, err)
 	}
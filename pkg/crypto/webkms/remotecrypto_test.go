@@ -8,6 +8,7 @@ package webkms
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
@@ -18,6 +19,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -723,6 +725,31 @@ func TestWrapUnWrapKey(t *testing.T) {
 	})
 }
 
+func TestRemoteCryptoWithCancelledContext(t *testing.T) {
+	var _ crypto.ContextCrypto = (*RemoteCrypto)(nil)
+
+	hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Fail(t, "request must not be sent with a cancelled context")
+	})
+
+	server := httptest.NewServer(hf)
+	defer server.Close()
+
+	defaultKeystoreURL := fmt.Sprintf("%s/%s", strings.ReplaceAll(webkmsimpl.KeystoreEndpoint,
+		"{serverEndpoint}", server.URL), defaultKeyStoreID)
+	defaultKeyURL := defaultKeystoreURL + "/keys/" + defaultKID
+	rCrypto := New(defaultKeystoreURL, server.Client())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := rCrypto.SignContext(ctx, []byte("message"), defaultKeyURL)
+	require.True(t, errors.Is(err, context.Canceled))
+
+	err = rCrypto.VerifyContext(ctx, []byte("signature"), []byte("message"), defaultKeyURL)
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
 func TestRemoteCryptoWithHeadersFunc(t *testing.T) {
 	recipientKH, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
 	require.NoError(t, err)
@@ -54,8 +54,9 @@ func New(p Provider) *Creator {
 	}
 }
 
-// CreatePeerDIDV2 create a peer DID suitable for use in DIDComm V2.
-func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
+// CreatePeerDIDV2 create a peer DID suitable for use in DIDComm V2. The opts are passed to the peer VDR, eg
+// vdrapi.WithOption(peer.NumalgoOpt, 2) creates a numalgo 2 peer DID.
+func (s *Creator) CreatePeerDIDV2(opts ...vdrapi.DIDMethodOption) (*did.Doc, error) {
 	// TODO: add routing keys so edge agents can rotate (currently only cloud agents do)
 	newDID := &did.Doc{Service: []did.Service{{Type: vdrapi.DIDCommV2ServiceType}}}
 
@@ -67,7 +68,7 @@ func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
 	// set KeyAgreement.ID as RecipientKeys as part of DIDComm V2 service
 	newDID.Service[0].RecipientKeys = []string{newDID.KeyAgreement[0].VerificationMethod.ID}
 
-	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID)
+	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating new peer DID via VDR failed: %w", err)
 	}
@@ -8,6 +8,7 @@ package dispatcher
 
 import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
 // ProtocolService is service interface for protocol services available in framework
@@ -39,6 +40,15 @@ type Outbound interface {
 	Forward(interface{}, *service.Destination) error
 }
 
+// SessionOutbound is an Outbound that can also send messages over a duplex transport session opened by the other
+// agent, instead of the service endpoint of its DID.
+type SessionOutbound interface {
+	Outbound
+
+	// SendToSession sends the message from myDID to the agent who owns theirDID over the transport session.
+	SendToSession(msg interface{}, myDID, theirDID string, session transport.Session) error
+}
+
 // MessageTypeTarget represents a service message type mapping value to an OOB target action.
 type MessageTypeTarget struct {
 	MsgType string
@@ -176,6 +176,10 @@ func (handler *MessageHandler) HandleInboundEnvelope(envelope *transport.Envelop
 					return fmt.Errorf("inbound message handler: %w", err)
 				}
 			}
+
+			if envelope.Session != nil {
+				props[transport.SessionProperty] = envelope.Session
+			}
 		}
 
 		_, err = foundService.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, props))
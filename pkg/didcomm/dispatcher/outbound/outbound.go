@@ -64,8 +64,13 @@ type Dispatcher struct {
 	connections          connectionRecorder
 	mediaTypeProfiles    []string
 	didcommV2Handler     *middleware.DIDCommMessageMiddleware
+	outboxOpts           *outboxOpts
+	outbox               *outbox
 }
 
+// Option configures the outbound dispatcher.
+type Option func(o *Dispatcher)
+
 // legacyForward is DIDComm V1 route Forward msg as declared in
 // https://github.com/hyperledger/aries-rfcs/blob/main/concepts/0094-cross-domain-messaging/README.md
 type legacyForward struct {
@@ -78,7 +83,7 @@ type legacyForward struct {
 var logger = log.New("aries-framework/didcomm/dispatcher")
 
 // NewOutbound return new dispatcher outbound instance.
-func NewOutbound(prov provider) (*Dispatcher, error) {
+func NewOutbound(prov provider, opts ...Option) (*Dispatcher, error) {
 	o := &Dispatcher{
 		outboundTransports:   prov.OutboundTransports(),
 		packager:             prov.Packager(),
@@ -97,11 +102,43 @@ func NewOutbound(prov provider) (*Dispatcher, error) {
 		return nil, fmt.Errorf("failed to init connection recorder: %w", err)
 	}
 
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.outboxOpts != nil {
+		o.outbox, err = newOutbox(prov.StorageProvider(), o.outboxOpts, o.sendPacked)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init outbox: %w", err)
+		}
+
+		err = o.outbox.resume()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resume outbox: %w", err)
+		}
+	}
+
 	return o, nil
 }
 
 // SendToDID sends a message from myDID to the agent who owns theirDID.
-func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error { // nolint:funlen,gocyclo,gocognit
+func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
+	return o.sendToDID(msg, myDID, theirDID, nil)
+}
+
+// SendToSession sends a message from myDID to the agent who owns theirDID over the duplex transport session the
+// agent opened, rather than to the service endpoint of theirDID. The message is neither wrapped in forward
+// messages nor queued in the outbox.
+func (o *Dispatcher) SendToSession(msg interface{}, myDID, theirDID string, session transport.Session) error {
+	if session == nil {
+		return errors.New("outboundDispatcher.SendToSession: no transport session")
+	}
+
+	return o.sendToDID(msg, myDID, theirDID, session)
+}
+
+// nolint:funlen,gocyclo,gocognit
+func (o *Dispatcher) sendToDID(msg interface{}, myDID, theirDID string, session transport.Session) error {
 	myDocResolution, err := o.vdRegistry.Resolve(myDID)
 	if err != nil {
 		return fmt.Errorf("failed to resolve my DID: %w", err)
@@ -182,7 +219,7 @@ func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
 	}
 
 	if sendWithAnoncrypt {
-		return o.Send(msg, "", dest)
+		return o.send(msg, "", dest, session)
 	}
 
 	src, err := service.CreateDestination(myDocResolution.DIDDocument)
@@ -194,7 +231,7 @@ func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
 	//  (right now, with only one key type used for sending)
 	key := src.RecipientKeys[0]
 
-	return o.Send(msg, key, dest)
+	return o.send(msg, key, dest, session)
 }
 
 func (o *Dispatcher) defaultMediaTypeProfiles() []string {
@@ -242,33 +279,22 @@ func (o *Dispatcher) getOrCreateConnection(myDID, theirDID string, connectionVer
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
-func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destination) error { // nolint:funlen,gocyclo
-	// check if outbound accepts routing keys, else use recipient keys
-	keys := des.RecipientKeys
-	if routingKeys, err := des.ServiceEndpoint.RoutingKeys(); err == nil && len(routingKeys) > 0 { // DIDComm V2
-		keys = routingKeys
-	} else if len(des.RoutingKeys) > 0 { // DIDComm V1
-		keys = routingKeys
-	}
+func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destination) error {
+	return o.send(msg, senderKey, des, nil)
+}
 
+// nolint:funlen,gocyclo
+func (o *Dispatcher) send(msg interface{}, senderKey string, des *service.Destination,
+	session transport.Session) error {
 	var outboundTransport transport.OutboundTransport
 
-	for _, v := range o.outboundTransports {
-		uri, err := des.ServiceEndpoint.URI()
-		if err != nil {
-			logger.Debugf("destination ServiceEndpoint empty: %w, it will not be checked", err)
-		}
-
-		if v.AcceptRecipient(keys) || v.Accept(uri) {
-			outboundTransport = v
-			break
+	if session == nil {
+		outboundTransport = o.outboundTransport(des)
+		if outboundTransport == nil {
+			return fmt.Errorf("outboundDispatcher.Send: no transport found for destination: %+v", des)
 		}
 	}
 
-	if outboundTransport == nil {
-		return fmt.Errorf("outboundDispatcher.Send: no transport found for destination: %+v", des)
-	}
-
 	req, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("outboundDispatcher.Send: failed marshal to bytes: %w", err)
@@ -298,6 +324,15 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 		return fmt.Errorf("outboundDispatcher.Send: failed to pack msg: %w", err)
 	}
 
+	if session != nil {
+		err = session.Send(packedMsg)
+		if err != nil {
+			return fmt.Errorf("outboundDispatcher.Send: failed to send msg over transport session: %w", err)
+		}
+
+		return nil
+	}
+
 	// set the return route option
 	des.TransportReturnRoute = o.transportReturnRoute
 
@@ -306,6 +341,15 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 		return fmt.Errorf("outboundDispatcher.Send: failed to create forward msg: %w", err)
 	}
 
+	if o.outbox != nil {
+		err = o.outbox.deliver(messageID(req), packedMsg, des)
+		if err != nil {
+			return fmt.Errorf("outboundDispatcher.Send: failed to queue msg in outbox: %w", err)
+		}
+
+		return nil
+	}
+
 	_, err = outboundTransport.Send(packedMsg, des)
 	if err != nil {
 		return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
@@ -314,7 +358,94 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 	return nil
 }
 
-// Forward forwards the message without packing to the destination.
+func (o *Dispatcher) outboundTransport(des *service.Destination) transport.OutboundTransport {
+	// check if outbound accepts routing keys, else use recipient keys
+	keys := des.RecipientKeys
+	if routingKeys, err := des.ServiceEndpoint.RoutingKeys(); err == nil && len(routingKeys) > 0 { // DIDComm V2
+		keys = routingKeys
+	} else if len(des.RoutingKeys) > 0 { // DIDComm V1
+		keys = routingKeys
+	}
+
+	for _, v := range o.outboundTransports {
+		uri, err := des.ServiceEndpoint.URI()
+		if err != nil {
+			logger.Debugf("destination ServiceEndpoint empty: %w, it will not be checked", err)
+		}
+
+		if v.AcceptRecipient(keys) || v.Accept(uri) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// sendPacked sends an already packed message, it is used by the outbox to retry deliveries.
+func (o *Dispatcher) sendPacked(packedMsg []byte, des *service.Destination) error {
+	outboundTransport := o.outboundTransport(des)
+	if outboundTransport == nil {
+		return fmt.Errorf("no transport found for destination: %+v", des)
+	}
+
+	_, err := outboundTransport.Send(packedMsg, des)
+
+	return err
+}
+
+// MessageStatus returns the delivery status of the message with the given ID. It requires the outbox to be
+// enabled with the WithOutbox option.
+func (o *Dispatcher) MessageStatus(msgID string) (MessageStatus, error) {
+	if o.outbox == nil {
+		return "", errors.New("outbox is not enabled")
+	}
+
+	return o.outbox.status(msgID)
+}
+
+// RegisterDeliveryFailedEvent registers a channel to be notified when the outbox gives up delivering a message.
+func (o *Dispatcher) RegisterDeliveryFailedEvent(ch chan<- DeliveryFailedEvent) error {
+	if o.outbox == nil {
+		return errors.New("outbox is not enabled")
+	}
+
+	o.outbox.registerEvent(ch)
+
+	return nil
+}
+
+// UnregisterDeliveryFailedEvent unregisters a channel registered with RegisterDeliveryFailedEvent.
+func (o *Dispatcher) UnregisterDeliveryFailedEvent(ch chan<- DeliveryFailedEvent) error {
+	if o.outbox == nil {
+		return errors.New("outbox is not enabled")
+	}
+
+	o.outbox.unregisterEvent(ch)
+
+	return nil
+}
+
+// Close stops the pending outbox retries, the pending messages are retried when the dispatcher is created again.
+func (o *Dispatcher) Close() error {
+	if o.outbox != nil {
+		o.outbox.stop()
+	}
+
+	return nil
+}
+
+// messageID returns the ID of the DIDComm message, or a generated ID if the message has none.
+func messageID(msg []byte) string {
+	didCommMsg, err := service.ParseDIDCommMsgMap(msg)
+	if err == nil && didCommMsg.ID() != "" {
+		return didCommMsg.ID()
+	}
+
+	return uuid.New().String()
+}
+
+// Forward forwards the message without packing to the destination. Forwarded messages bypass the outbox, so that
+// the mediator can keep the messages it fails to forward for pickup.
 func (o *Dispatcher) Forward(msg interface{}, des *service.Destination) error {
 	var (
 		uri string
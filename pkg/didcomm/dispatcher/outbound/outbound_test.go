@@ -564,6 +564,70 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_SendToSession(t *testing.T) {
+	mockDoc := mockdiddoc.GetMockDIDDoc(t, false)
+	packedMsg := createPackedMsgForForward(t)
+
+	newOutbound := func(t *testing.T) *Dispatcher {
+		t.Helper()
+
+		// no outbound transport is needed to send over a session
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:        &mockpackager.Packager{PackValue: packedMsg},
+			vdr:                  &mockvdr.MockVDRegistry{ResolveValue: mockDoc},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		o.connections = &mockConnectionLookup{
+			getConnectionByDIDsVal: "mock1",
+			getConnectionRecordVal: &connection.Record{},
+		}
+
+		return o
+	}
+
+	msg := service.DIDCommMsgMap{"id": "123", "type": "abc"}
+
+	t.Run("success", func(t *testing.T) {
+		sess := &mockSession{}
+
+		require.NoError(t, newOutbound(t).SendToSession(msg, testDID, "", sess))
+		require.Equal(t, [][]byte{packedMsg}, sess.sent)
+	})
+
+	t.Run("session send error", func(t *testing.T) {
+		err := newOutbound(t).SendToSession(msg, testDID, "", &mockSession{err: errors.New("closed")})
+		require.EqualError(t, err, "outboundDispatcher.Send: failed to send msg over transport session: closed")
+	})
+
+	t.Run("no session", func(t *testing.T) {
+		err := newOutbound(t).SendToSession(msg, testDID, "", nil)
+		require.EqualError(t, err, "outboundDispatcher.SendToSession: no transport session")
+	})
+}
+
+type mockSession struct {
+	sent [][]byte
+	err  error
+}
+
+func (m *mockSession) Send(data []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.sent = append(m.sent, data)
+
+	return nil
+}
+
+func (m *mockSession) Done() <-chan struct{} {
+	return nil
+}
+
 func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 	t.Run("transport route option - value set all", func(t *testing.T) {
 		transportReturnRoute := "all"
@@ -0,0 +1,606 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbound
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	outboxStoreName = "outbox"
+
+	// outboxPendingTag tags the messages waiting for delivery with the hash of their destination.
+	outboxPendingTag = "outbox_pending"
+	// outboxMsgTag tags the messages with the hash of their message ID.
+	outboxMsgTag = "outbox_msg"
+	// outboxSentTag tags the delivered messages, they are pruned once the retention period is over.
+	outboxSentTag = "outbox_sent"
+
+	defaultOutboxMaxAttempts    = 10
+	defaultOutboxInitialBackoff = time.Second
+	defaultOutboxMaxBackoff     = 10 * time.Minute
+	defaultOutboxSentRetention  = 24 * time.Hour
+)
+
+// MessageStatus is the delivery status of a message sent through the outbox.
+type MessageStatus string
+
+const (
+	// MessageStatusPending the message is waiting to be delivered.
+	MessageStatusPending MessageStatus = "pending"
+	// MessageStatusSent the message was delivered to the outbound transport.
+	MessageStatusSent MessageStatus = "sent"
+	// MessageStatusFailed the delivery of the message was abandoned after the maximum number of attempts.
+	MessageStatusFailed MessageStatus = "failed"
+)
+
+// DeliveryFailedEvent is emitted when the outbox gives up delivering a message.
+type DeliveryFailedEvent struct {
+	MessageID   string
+	Destination *service.Destination
+	Attempts    int
+	Err         error
+}
+
+// OutboxOption configures the outbox.
+type OutboxOption func(opts *outboxOpts)
+
+type outboxOpts struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	sentRetention  time.Duration
+}
+
+// WithOutboxMaxAttempts sets the number of delivery attempts of a message before it is marked as failed.
+func WithOutboxMaxAttempts(attempts int) OutboxOption {
+	return func(opts *outboxOpts) {
+		opts.maxAttempts = attempts
+	}
+}
+
+// WithOutboxBackoff sets the delay before the first retry to a destination, the delay doubles with every
+// consecutive failure up to maxBackoff.
+func WithOutboxBackoff(initial, maxBackoff time.Duration) OutboxOption {
+	return func(opts *outboxOpts) {
+		opts.initialBackoff = initial
+		opts.maxBackoff = maxBackoff
+	}
+}
+
+// WithOutboxSentRetention sets how long the status of the delivered messages is kept before they are removed
+// from the outbox.
+func WithOutboxSentRetention(retention time.Duration) OutboxOption {
+	return func(opts *outboxOpts) {
+		opts.sentRetention = retention
+	}
+}
+
+// WithOutbox enables the persistent outbox of the dispatcher. Packed messages that can't be delivered are stored
+// in the storage provider and retried with an exponential backoff per destination, including after a restart of
+// the agent. Send and SendToDID return without error once the message is stored, Forward doesn't use the outbox.
+func WithOutbox(opts ...OutboxOption) Option {
+	return func(o *Dispatcher) {
+		o.outboxOpts = &outboxOpts{
+			maxAttempts:    defaultOutboxMaxAttempts,
+			initialBackoff: defaultOutboxInitialBackoff,
+			maxBackoff:     defaultOutboxMaxBackoff,
+			sentRetention:  defaultOutboxSentRetention,
+		}
+
+		for _, opt := range opts {
+			opt(o.outboxOpts)
+		}
+	}
+}
+
+// outboxRecord is a message stored in the outbox, the packed message is dropped once it's delivered.
+type outboxRecord struct {
+	ID          string             `json:"id"`
+	Packed      []byte             `json:"packed,omitempty"`
+	Destination *outboxDestination `json:"destination,omitempty"`
+	Status      MessageStatus      `json:"status"`
+	Attempts    int                `json:"attempts"`
+	LastError   string             `json:"lastError,omitempty"`
+	Created     time.Time          `json:"created"`
+	Sent        *time.Time         `json:"sent,omitempty"`
+
+	// storeKey is the key of the record in the store, it is set when the record is queried.
+	storeKey string
+}
+
+type outboxDestination struct {
+	RecipientKeys        []string        `json:"recipientKeys,omitempty"`
+	ServiceEndpoint      *model.Endpoint `json:"serviceEndpoint,omitempty"`
+	RoutingKeys          []string        `json:"routingKeys,omitempty"`
+	TransportReturnRoute string          `json:"transportReturnRoute,omitempty"`
+	MediaTypeProfiles    []string        `json:"mediaTypeProfiles,omitempty"`
+}
+
+func (d *outboxDestination) destination() *service.Destination {
+	return &service.Destination{
+		RecipientKeys:        d.RecipientKeys,
+		ServiceEndpoint:      *d.ServiceEndpoint,
+		RoutingKeys:          d.RoutingKeys,
+		TransportReturnRoute: d.TransportReturnRoute,
+		MediaTypeProfiles:    d.MediaTypeProfiles,
+	}
+}
+
+type destinationState struct {
+	failures int
+	timer    *time.Timer
+}
+
+type outbox struct {
+	opts         *outboxOpts
+	store        storage.Store
+	send         func(packed []byte, des *service.Destination) error
+	destinations map[string]*destinationState
+	pruneTimer   *time.Timer
+	stopped      bool
+	lock         sync.Mutex
+	events       []chan<- DeliveryFailedEvent
+	eventsLock   sync.RWMutex
+}
+
+func newOutbox(prov storage.Provider, opts *outboxOpts,
+	send func(packed []byte, des *service.Destination) error) (*outbox, error) {
+	store, err := prov.OpenStore(outboxStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open outbox store: %w", err)
+	}
+
+	err = prov.SetStoreConfig(outboxStoreName, storage.StoreConfiguration{
+		TagNames: []string{outboxPendingTag, outboxMsgTag, outboxSentTag},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set outbox store config: %w", err)
+	}
+
+	return &outbox{
+		opts:         opts,
+		store:        store,
+		send:         send,
+		destinations: map[string]*destinationState{},
+	}, nil
+}
+
+// resume schedules the delivery of the messages left pending by a previous run of the agent.
+func (b *outbox) resume() error {
+	records, err := b.query(outboxPendingTag)
+	if err != nil {
+		return fmt.Errorf("query pending messages: %w", err)
+	}
+
+	b.prune()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, rec := range records {
+		key := destinationKey(rec.Destination.destination())
+
+		if _, ok := b.destinations[key]; !ok {
+			b.destinations[key] = &destinationState{}
+			b.schedule(key, 0)
+		}
+	}
+
+	return nil
+}
+
+// deliver sends the packed message, the message is stored for retries if it can't be delivered.
+func (b *outbox) deliver(msgID string, packed []byte, des *service.Destination) error {
+	key := destinationKey(des)
+
+	rec := &outboxRecord{
+		ID:      msgID,
+		Packed:  packed,
+		Status:  MessageStatusPending,
+		Created: time.Now().UTC(),
+		Destination: &outboxDestination{
+			RecipientKeys:        des.RecipientKeys,
+			ServiceEndpoint:      &des.ServiceEndpoint,
+			RoutingKeys:          des.RoutingKeys,
+			TransportReturnRoute: des.TransportReturnRoute,
+			MediaTypeProfiles:    des.MediaTypeProfiles,
+		},
+	}
+
+	b.lock.Lock()
+
+	if _, ok := b.destinations[key]; ok {
+		// the destination is being retried, queue the message behind the pending ones to keep the order
+		defer b.lock.Unlock()
+
+		return b.save(rec, key)
+	}
+
+	b.lock.Unlock()
+
+	err := b.send(packed, des)
+	if err == nil {
+		return b.save(&outboxRecord{
+			ID: msgID, Status: MessageStatusSent, Attempts: 1, Created: rec.Created, Sent: sentTime(),
+		}, key)
+	}
+
+	logger.Debugf("outbox: failed to deliver message %s, will retry: %v", msgID, err)
+
+	rec.Attempts = 1
+	rec.LastError = err.Error()
+
+	if rec.Attempts >= b.opts.maxAttempts {
+		return b.fail(rec, key)
+	}
+
+	if err = b.save(rec, key); err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.destinations[key]; !ok {
+		b.destinations[key] = &destinationState{failures: 1}
+		b.schedule(key, b.backoff(1))
+	}
+
+	return nil
+}
+
+// flush retries the pending messages of a destination in the order they were sent.
+func (b *outbox) flush(key string) {
+	if b.isStopped() {
+		return
+	}
+
+	records, err := b.query(fmt.Sprintf("%s:%s", outboxPendingTag, key))
+	if err != nil {
+		logger.Errorf("outbox: failed to query pending messages: %v", err)
+
+		b.retryLater(key)
+
+		return
+	}
+
+	for _, rec := range records {
+		if b.isStopped() {
+			return
+		}
+
+		err = b.send(rec.Packed, rec.Destination.destination())
+		if err == nil {
+			err = b.save(&outboxRecord{
+				ID: rec.ID, Status: MessageStatusSent, Attempts: rec.Attempts + 1, Created: rec.Created,
+				Sent: sentTime(),
+			}, key)
+			if err != nil {
+				logger.Errorf("outbox: failed to save message %s status: %v", rec.ID, err)
+			}
+
+			continue
+		}
+
+		rec.Attempts++
+		rec.LastError = err.Error()
+
+		if rec.Attempts >= b.opts.maxAttempts {
+			if err = b.fail(rec, key); err != nil {
+				logger.Errorf("outbox: failed to save message %s status: %v", rec.ID, err)
+			}
+
+			continue
+		}
+
+		if err = b.save(rec, key); err != nil {
+			logger.Errorf("outbox: failed to save message %s status: %v", rec.ID, err)
+		}
+
+		b.retryLater(key)
+
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// new messages could have been queued while flushing
+	records, err = b.query(fmt.Sprintf("%s:%s", outboxPendingTag, key))
+	if err == nil && len(records) > 0 {
+		b.schedule(key, 0)
+
+		return
+	}
+
+	delete(b.destinations, key)
+}
+
+func (b *outbox) retryLater(key string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	state, ok := b.destinations[key]
+	if !ok {
+		state = &destinationState{}
+		b.destinations[key] = state
+	}
+
+	state.failures++
+
+	b.schedule(key, b.backoff(state.failures))
+}
+
+// schedule must be called with the lock held.
+func (b *outbox) schedule(key string, delay time.Duration) {
+	if b.stopped {
+		return
+	}
+
+	state := b.destinations[key]
+	state.timer = time.AfterFunc(delay, func() {
+		b.flush(key)
+	})
+}
+
+func (b *outbox) backoff(failures int) time.Duration {
+	delay := b.opts.initialBackoff
+
+	for i := 1; i < failures && delay < b.opts.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > b.opts.maxBackoff {
+		delay = b.opts.maxBackoff
+	}
+
+	return delay
+}
+
+func (b *outbox) fail(rec *outboxRecord, key string) error {
+	logger.Warnf("outbox: giving up delivery of message %s after %d attempts: %s", rec.ID, rec.Attempts,
+		rec.LastError)
+
+	des := rec.Destination.destination()
+
+	err := b.save(&outboxRecord{
+		ID:        rec.ID,
+		Status:    MessageStatusFailed,
+		Attempts:  rec.Attempts,
+		LastError: rec.LastError,
+		Created:   rec.Created,
+	}, key)
+
+	// the subscribers are notified without holding the lock, so a slow subscriber doesn't block unregistering.
+	b.eventsLock.RLock()
+	events := append([]chan<- DeliveryFailedEvent(nil), b.events...)
+	b.eventsLock.RUnlock()
+
+	event := DeliveryFailedEvent{
+		MessageID:   rec.ID,
+		Destination: des,
+		Attempts:    rec.Attempts,
+		Err:         errors.New(rec.LastError),
+	}
+
+	// a subscriber which doesn't read its events must not stall the retries, nor the direct deliveries.
+	for _, ch := range events {
+		select {
+		case ch <- event:
+		default:
+			logger.Warnf("outbox: dropping delivery failed event of message %s, the subscriber isn't reading", rec.ID)
+		}
+	}
+
+	return err
+}
+
+func sentTime() *time.Time {
+	sent := time.Now().UTC()
+
+	return &sent
+}
+
+func (b *outbox) save(rec *outboxRecord, key string) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal outbox record: %w", err)
+	}
+
+	tags := []storage.Tag{{Name: outboxMsgTag, Value: hash(rec.ID)}}
+
+	switch rec.Status {
+	case MessageStatusPending:
+		tags = append(tags, storage.Tag{Name: outboxPendingTag, Value: key})
+	case MessageStatusSent:
+		tags = append(tags, storage.Tag{Name: outboxSentTag})
+	}
+
+	err = b.store.Put(outboxRecordKey(rec.ID, key), data, tags...)
+	if err != nil {
+		return fmt.Errorf("save outbox record: %w", err)
+	}
+
+	return nil
+}
+
+// prune removes the delivered messages older than the retention period, and schedules the next pruning.
+func (b *outbox) prune() {
+	records, err := b.query(outboxSentTag)
+	if err != nil {
+		logger.Errorf("outbox: failed to query delivered messages: %v", err)
+	}
+
+	for _, rec := range records {
+		if rec.Sent != nil && time.Since(*rec.Sent) < b.opts.sentRetention {
+			continue
+		}
+
+		err = b.store.Delete(rec.storeKey)
+		if err != nil {
+			logger.Errorf("outbox: failed to delete message %s: %v", rec.ID, err)
+		}
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.stopped {
+		b.pruneTimer = time.AfterFunc(b.opts.sentRetention, b.prune)
+	}
+}
+
+func (b *outbox) query(expression string) ([]*outboxRecord, error) {
+	iter, err := b.store.Query(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	defer storage.Close(iter, logger)
+
+	var records []*outboxRecord
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		rec := &outboxRecord{}
+
+		err = json.Unmarshal(value, rec)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal outbox record: %w", err)
+		}
+
+		rec.storeKey, err = iter.Key()
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, rec)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Created.Before(records[j].Created)
+	})
+
+	return records, nil
+}
+
+// status returns the delivery status of the message, a message sent to several destinations is pending or failed
+// as long as one of the deliveries is.
+func (b *outbox) status(msgID string) (MessageStatus, error) {
+	records, err := b.query(fmt.Sprintf("%s:%s", outboxMsgTag, hash(msgID)))
+	if err != nil {
+		return "", fmt.Errorf("query outbox: %w", err)
+	}
+
+	if len(records) == 0 {
+		return "", fmt.Errorf("message %s: %w", msgID, storage.ErrDataNotFound)
+	}
+
+	status := MessageStatusSent
+
+	for _, rec := range records {
+		switch rec.Status {
+		case MessageStatusFailed:
+			return MessageStatusFailed, nil
+		case MessageStatusPending:
+			status = MessageStatusPending
+		}
+	}
+
+	return status, nil
+}
+
+func (b *outbox) stop() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.stopped = true
+
+	if b.pruneTimer != nil {
+		b.pruneTimer.Stop()
+	}
+
+	for _, state := range b.destinations {
+		if state.timer != nil {
+			state.timer.Stop()
+		}
+	}
+}
+
+func (b *outbox) isStopped() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.stopped
+}
+
+func (b *outbox) registerEvent(ch chan<- DeliveryFailedEvent) {
+	b.eventsLock.Lock()
+	defer b.eventsLock.Unlock()
+
+	b.events = append(b.events, ch)
+}
+
+func (b *outbox) unregisterEvent(ch chan<- DeliveryFailedEvent) {
+	b.eventsLock.Lock()
+	defer b.eventsLock.Unlock()
+
+	for i := 0; i < len(b.events); i++ {
+		if b.events[i] == ch {
+			b.events = append(b.events[:i], b.events[i+1:]...)
+			i--
+		}
+	}
+}
+
+// destinationKey identifies the destination by its endpoint, or by its recipient keys for transports
+// accepting recipients.
+func destinationKey(des *service.Destination) string {
+	id, err := des.ServiceEndpoint.URI()
+	if err != nil || id == "" {
+		id = strings.Join(des.RecipientKeys, ",")
+	}
+
+	return hash(id)
+}
+
+func outboxRecordKey(msgID, destKey string) string {
+	return fmt.Sprintf("outbox_%s_%s", destKey, hash(msgID))
+}
+
+func hash(s string) string {
+	h := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,379 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbound
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Run("message delivered on first attempt", func(t *testing.T) {
+		tr := &flakyTransport{}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr, WithOutbox())
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+		require.Equal(t, 1, len(tr.delivered()))
+
+		status, err := o.MessageStatus("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusSent, status)
+	})
+
+	t.Run("messages retried in order until the destination is back", func(t *testing.T) {
+		tr := &flakyTransport{down: true}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr,
+			WithOutbox(WithOutboxBackoff(10*time.Millisecond, 20*time.Millisecond)))
+
+		defer func() {
+			require.NoError(t, o.Close())
+		}()
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+		require.NoError(t, o.Send(outboxMsg("msg-2"), "", outboxDestination1()))
+
+		status, err := o.MessageStatus("msg-2")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusPending, status)
+
+		tr.setDown(false)
+
+		require.Eventually(t, func() bool {
+			return len(tr.delivered()) == 2
+		}, time.Second, 10*time.Millisecond)
+
+		delivered := tr.delivered()
+		require.Contains(t, string(delivered[0]), "msg-1")
+		require.Contains(t, string(delivered[1]), "msg-2")
+
+		require.Eventually(t, func() bool {
+			status, err = o.MessageStatus("msg-2")
+
+			return err == nil && status == MessageStatusSent
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("delivery failed after max attempts", func(t *testing.T) {
+		tr := &flakyTransport{down: true}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr,
+			WithOutbox(WithOutboxMaxAttempts(3), WithOutboxBackoff(time.Millisecond, time.Millisecond)))
+
+		events := make(chan DeliveryFailedEvent, 1)
+		require.NoError(t, o.RegisterDeliveryFailedEvent(events))
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+
+		select {
+		case e := <-events:
+			require.Equal(t, "msg-1", e.MessageID)
+			require.Equal(t, 3, e.Attempts)
+			require.EqualError(t, e.Err, "destination down")
+
+			uri, err := e.Destination.ServiceEndpoint.URI()
+			require.NoError(t, err)
+			require.Equal(t, "http://destination-1", uri)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout waiting for delivery failed event")
+		}
+
+		status, err := o.MessageStatus("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusFailed, status)
+
+		require.NoError(t, o.UnregisterDeliveryFailedEvent(events))
+	})
+
+	t.Run("pending messages survive a restart", func(t *testing.T) {
+		prov := mem.NewProvider()
+
+		tr := &flakyTransport{down: true}
+		o := newOutboxDispatcher(t, prov, tr, WithOutbox(WithOutboxBackoff(time.Hour, time.Hour)))
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+		require.NoError(t, o.Close())
+
+		tr = &flakyTransport{}
+		o = newOutboxDispatcher(t, prov, tr, WithOutbox())
+
+		require.Eventually(t, func() bool {
+			return len(tr.delivered()) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		require.Eventually(t, func() bool {
+			status, err := o.MessageStatus("msg-1")
+
+			return err == nil && status == MessageStatusSent
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("destinations are retried independently", func(t *testing.T) {
+		tr := &flakyTransport{downURI: "http://destination-1"}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr, WithOutbox(WithOutboxBackoff(time.Hour, time.Hour)))
+
+		defer func() {
+			require.NoError(t, o.Close())
+		}()
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+		require.NoError(t, o.Send(outboxMsg("msg-2"), "", &service.Destination{
+			ServiceEndpoint: model.NewDIDCommV1Endpoint("http://destination-2"),
+		}))
+
+		status, err := o.MessageStatus("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusPending, status)
+
+		status, err = o.MessageStatus("msg-2")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusSent, status)
+	})
+
+	t.Run("forwarded messages bypass the outbox", func(t *testing.T) {
+		tr := &flakyTransport{down: true}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr, WithOutbox())
+
+		defer func() {
+			require.NoError(t, o.Close())
+		}()
+
+		err := o.Forward(outboxMsg("msg-1"), outboxDestination1())
+		require.Error(t, err)
+
+		_, err = o.MessageStatus("msg-1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		tr.setDown(false)
+
+		require.NoError(t, o.Forward(outboxMsg("msg-2"), outboxDestination1()))
+
+		delivered := tr.delivered()
+		require.Len(t, delivered, 1)
+		require.Contains(t, string(delivered[0]), "msg-2")
+
+		_, err = o.MessageStatus("msg-2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("pending messages stored without a sent time", func(t *testing.T) {
+		tr := &flakyTransport{down: true}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr, WithOutbox(WithOutboxBackoff(time.Hour, time.Hour)))
+
+		defer func() {
+			require.NoError(t, o.Close())
+		}()
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+
+		raw, err := o.outbox.store.Get(outboxRecordKey("msg-1", destinationKey(outboxDestination1())))
+		require.NoError(t, err)
+		require.NotContains(t, string(raw), `"sent"`)
+	})
+
+	t.Run("delivered messages pruned after the retention period", func(t *testing.T) {
+		tr := &flakyTransport{}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr,
+			WithOutbox(WithOutboxSentRetention(20*time.Millisecond)))
+
+		defer func() {
+			require.NoError(t, o.Close())
+		}()
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+
+		status, err := o.MessageStatus("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusSent, status)
+
+		require.Eventually(t, func() bool {
+			_, err = o.MessageStatus("msg-1")
+
+			return errors.Is(err, storage.ErrDataNotFound)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("no delivery after the outbox is stopped", func(t *testing.T) {
+		tr := &flakyTransport{down: true}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr,
+			WithOutbox(WithOutboxBackoff(time.Hour, time.Hour)))
+
+		require.NoError(t, o.Send(outboxMsg("msg-1"), "", outboxDestination1()))
+		require.NoError(t, o.Close())
+
+		tr.setDown(false)
+		o.outbox.flush(destinationKey(outboxDestination1()))
+
+		require.Empty(t, tr.delivered())
+
+		status, err := o.MessageStatus("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusPending, status)
+	})
+
+	t.Run("subscriber that isn't reading doesn't block the outbox", func(t *testing.T) {
+		tr := &flakyTransport{down: true}
+		o := newOutboxDispatcher(t, mem.NewProvider(), tr, WithOutbox(WithOutboxMaxAttempts(1)))
+
+		slow := make(chan DeliveryFailedEvent)
+		require.NoError(t, o.RegisterDeliveryFailedEvent(slow))
+
+		sent := make(chan error)
+
+		go func() {
+			sent <- o.Send(outboxMsg("msg-1"), "", outboxDestination1())
+		}()
+
+		select {
+		case err := <-sent:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout waiting for the message to be sent")
+		}
+
+		status, err := o.MessageStatus("msg-1")
+		require.NoError(t, err)
+		require.Equal(t, MessageStatusFailed, status)
+
+		require.NoError(t, o.UnregisterDeliveryFailedEvent(slow))
+	})
+
+	t.Run("unknown message status", func(t *testing.T) {
+		o := newOutboxDispatcher(t, mem.NewProvider(), &flakyTransport{}, WithOutbox())
+
+		_, err := o.MessageStatus("unknown")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("outbox not enabled", func(t *testing.T) {
+		o := newOutboxDispatcher(t, mem.NewProvider(), &flakyTransport{})
+
+		_, err := o.MessageStatus("msg-1")
+		require.EqualError(t, err, "outbox is not enabled")
+
+		require.EqualError(t, o.RegisterDeliveryFailedEvent(nil), "outbox is not enabled")
+		require.EqualError(t, o.UnregisterDeliveryFailedEvent(nil), "outbox is not enabled")
+		require.NoError(t, o.Close())
+	})
+
+	t.Run("error opening the outbox store", func(t *testing.T) {
+		_, err := NewOutbound(&mockProvider{
+			packagerValue:        &echoPackager{},
+			storageProvider:      &mockstore.MockStoreProvider{FailNamespace: outboxStoreName},
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeV1PlaintextPayload},
+		}, WithOutbox())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init outbox")
+	})
+
+	t.Run("backoff doubles up to the max backoff", func(t *testing.T) {
+		b := &outbox{opts: &outboxOpts{initialBackoff: time.Second, maxBackoff: 5 * time.Second}}
+
+		require.Equal(t, time.Second, b.backoff(1))
+		require.Equal(t, 2*time.Second, b.backoff(2))
+		require.Equal(t, 4*time.Second, b.backoff(3))
+		require.Equal(t, 5*time.Second, b.backoff(4))
+		require.Equal(t, 5*time.Second, b.backoff(100))
+	})
+}
+
+func newOutboxDispatcher(t *testing.T, prov storage.Provider, tr transport.OutboundTransport,
+	opts ...Option) *Dispatcher {
+	t.Helper()
+
+	o, err := NewOutbound(&mockProvider{
+		packagerValue:           &echoPackager{},
+		outboundTransportsValue: []transport.OutboundTransport{tr},
+		storageProvider:         prov,
+		protoStorageProvider:    mem.NewProvider(),
+		mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+	}, opts...)
+	require.NoError(t, err)
+
+	return o
+}
+
+func outboxMsg(id string) service.DIDCommMsgMap {
+	return service.DIDCommMsgMap{"@id": id, "@type": "https://didcomm.org/test/1.0/message"}
+}
+
+func outboxDestination1() *service.Destination {
+	return &service.Destination{ServiceEndpoint: model.NewDIDCommV1Endpoint("http://destination-1")}
+}
+
+// echoPackager packs messages as is.
+type echoPackager struct{}
+
+func (p *echoPackager) PackMessage(e *transport.Envelope) ([]byte, error) {
+	return e.Message, nil
+}
+
+func (p *echoPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	return &transport.Envelope{Message: encMessage}, nil
+}
+
+// flakyTransport fails to send messages while it's down or to downURI.
+type flakyTransport struct {
+	down    bool
+	downURI string
+	sent    [][]byte
+	lock    sync.Mutex
+}
+
+func (f *flakyTransport) Start(transport.Provider) error {
+	return nil
+}
+
+func (f *flakyTransport) Send(data []byte, destination *service.Destination) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	uri, err := destination.ServiceEndpoint.URI()
+	if err != nil {
+		return "", err
+	}
+
+	if f.down || uri == f.downURI {
+		return "", errors.New("destination down")
+	}
+
+	f.sent = append(f.sent, data)
+
+	return "", nil
+}
+
+func (f *flakyTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (f *flakyTransport) Accept(string) bool {
+	return true
+}
+
+func (f *flakyTransport) setDown(down bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.down = down
+}
+
+func (f *flakyTransport) delivered() [][]byte {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([][]byte{}, f.sent...)
+}
@@ -107,6 +107,10 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 		return nil, errors.New("packMessage: envelope argument is nil")
 	}
 
+	if messageEnvelope.SigningKey != "" || messageEnvelope.MediaTypeProfile == transport.MediaTypeV2SignedEnvelope {
+		return bp.packSigned(messageEnvelope)
+	}
+
 	cty, p, err := bp.getCTYAndPacker(messageEnvelope)
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: %w", err)
@@ -125,6 +129,52 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 	return marshalledEnvelope, nil
 }
 
+// packSigned signs the message as a DIDComm V2 signed envelope with envelope.SigningKey (or envelope.FromKey if not
+// set), then encrypts the signed envelope for envelope.ToKeys (sign-then-encrypt). The signed envelope is returned as
+// is if the signed media type profile is requested or if there are no recipients.
+func (bp *Packager) packSigned(envelope *transport.Envelope) ([]byte, error) {
+	signer, ok := bp.packers[transport.MediaTypeV2SignedEnvelope]
+	if !ok {
+		return nil, errors.New("packMessage: signed envelope packer not found")
+	}
+
+	signingKey := envelope.SigningKey
+	if signingKey == "" {
+		signingKey = string(envelope.FromKey)
+	}
+
+	signedMessage, err := signer.Pack("", envelope.Message, []byte(signingKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("packMessage: failed to sign: %w", err)
+	}
+
+	if envelope.MediaTypeProfile == transport.MediaTypeV2SignedEnvelope || len(envelope.ToKeys) == 0 {
+		return signedMessage, nil
+	}
+
+	cty, p, err := bp.getCTYAndPacker(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("packMessage: %w", err)
+	}
+
+	if cty != transport.MediaTypeV2PlaintextPayload || p == nil {
+		return nil, fmt.Errorf("packMessage: sign-then-encrypt requires a DIDComm V2 media type profile, got: '%s'",
+			envelope.MediaTypeProfile)
+	}
+
+	senderKey, recipients, err := bp.prepareSenderAndRecipientKeys(cty, envelope)
+	if err != nil {
+		return nil, fmt.Errorf("packMessage: %w", err)
+	}
+
+	marshalledEnvelope, err := p.Pack(transport.MediaTypeV2SignedEnvelope, signedMessage, senderKey, recipients)
+	if err != nil {
+		return nil, fmt.Errorf("packMessage: failed to pack signed message: %w", err)
+	}
+
+	return marshalledEnvelope, nil
+}
+
 //nolint:funlen,gocyclo,gocognit
 func (bp *Packager) prepareSenderAndRecipientKeys(cty string, envelope *transport.Envelope) ([]byte, [][]byte, error) {
 	var recipients [][]byte
@@ -277,7 +327,10 @@ func buildSenderKID(senderPubKey *crypto.PublicKey, envelopeSenderKey *transport
 }
 
 type envelopeStub struct {
-	Protected string `json:"protected,omitempty"`
+	Protected  string `json:"protected,omitempty"`
+	Signatures []struct {
+		Protected string `json:"protected,omitempty"`
+	} `json:"signatures,omitempty"`
 }
 
 type headerStub struct {
@@ -298,6 +351,11 @@ func getEncodingType(encMessage []byte) (string, []byte, error) {
 		if err != nil {
 			return "", nil, fmt.Errorf("parse envelope: %w", err)
 		}
+
+		// JWS general JSON serialization has the protected headers in the signatures.
+		if env.Protected == "" && len(env.Signatures) > 0 {
+			env.Protected = env.Signatures[0].Protected
+		}
 	} else {
 		doubleQuote := []byte("\"")
 
@@ -387,6 +445,39 @@ func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error
 		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
+	if encType == transport.MediaTypeV2SignedEnvelope {
+		return envelope, nil
+	}
+
+	return bp.unpackNestedSigned(envelope)
+}
+
+// unpackNestedSigned verifies and unwraps the signed envelope of a sign-then-encrypt message, envelope is returned
+// unchanged if its message is not signed.
+func (bp *Packager) unpackNestedSigned(envelope *transport.Envelope) (*transport.Envelope, error) {
+	innerType, _, err := getEncodingType(envelope.Message)
+	if err != nil || innerType != transport.MediaTypeV2SignedEnvelope {
+		return envelope, nil //nolint:nilerr // plaintext messages have no encoding type.
+	}
+
+	signer, ok := bp.packers[transport.MediaTypeV2SignedEnvelope]
+	if !ok {
+		return nil, errors.New("unpack: signed envelope packer not found")
+	}
+
+	signedEnvelope, err := signer.Unpack(envelope.Message)
+	if err != nil {
+		return nil, fmt.Errorf("unpack: signed message: %w", err)
+	}
+
+	envelope.Message = signedEnvelope.Message
+	envelope.SigningKey = signedEnvelope.SigningKey
+
+	// anoncrypt envelopes have no sender, the signer is the sender of the message.
+	if len(envelope.FromKey) == 0 {
+		envelope.FromKey = signedEnvelope.FromKey
+	}
+
 	return envelope, nil
 }
 
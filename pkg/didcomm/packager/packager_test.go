@@ -8,6 +8,7 @@ package packager_test
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -18,8 +19,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/signed"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -746,6 +749,155 @@ func TestPackager_PackMessage_KeyAgreementID_Failures(t *testing.T) {
 	}
 }
 
+func TestPackager_SignedMessage(t *testing.T) {
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	customKMS, err := localkms.New(localKeyURI, newMockKMSProvider(mockstorage.NewMockStoreProvider(), t))
+	require.NoError(t, err)
+
+	//nolint:dogsled
+	resolveDIDFunc, _, _, fromDID, toDID := newDIDsAndDIDDocResolverFunc(customKMS, kms.X25519ECDHKWType, t)
+
+	_, signingKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	authVM := did.NewVerificationMethodFromBytes(fromDID.ID+"#auth-1", "Ed25519VerificationKey2018", fromDID.ID,
+		signingKey)
+	fromDID.Authentication = []did.Verification{*did.NewReferencedVerification(authVM, did.Authentication)}
+
+	mockedProviders := &mockProvider{
+		kms:    customKMS,
+		crypto: cryptoSvc,
+		vdr: &mockvdr.MockVDRegistry{
+			ResolveFunc: resolveDIDFunc,
+		},
+	}
+
+	authPacker, err := authcrypt.New(mockedProviders, jose.A256CBCHS512)
+	require.NoError(t, err)
+
+	anonPacker, err := anoncrypt.New(mockedProviders, jose.A256GCM)
+	require.NoError(t, err)
+
+	signedPacker, err := signed.New(mockedProviders)
+	require.NoError(t, err)
+
+	mockedProviders.primaryPacker = authPacker
+	mockedProviders.packers = []packer.Packer{authPacker, anonPacker, signedPacker}
+
+	packager, err := New(mockedProviders)
+	require.NoError(t, err)
+
+	msg := []byte(`{"id":"1234","type":"https://didcomm.org/basicmessage/2.0/message","from":"` + fromDID.ID + `"}`)
+
+	t.Run("signed message without encryption", func(t *testing.T) {
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV2SignedEnvelope,
+			Message:          msg,
+			SigningKey:       authVM.ID,
+			ToKeys:           []string{toDID.KeyAgreement[0].VerificationMethod.ID},
+		})
+		require.NoError(t, err)
+		require.Contains(t, string(packMsg), `"signatures"`)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, msg, unpackedMsg.Message)
+		require.Equal(t, authVM.ID, unpackedMsg.SigningKey)
+
+		fromKey := &cryptoapi.PublicKey{}
+		require.NoError(t, json.Unmarshal(unpackedMsg.FromKey, fromKey))
+		require.Equal(t, authVM.ID, fromKey.KID)
+	})
+
+	t.Run("sign-then-encrypt with authcrypt", func(t *testing.T) {
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeDIDCommV2Profile,
+			Message:          msg,
+			FromKey:          []byte(fromDID.KeyAgreement[0].VerificationMethod.ID),
+			SigningKey:       authVM.ID,
+			ToKeys:           []string{toDID.KeyAgreement[0].VerificationMethod.ID},
+		})
+		require.NoError(t, err)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, msg, unpackedMsg.Message)
+		require.Equal(t, authVM.ID, unpackedMsg.SigningKey)
+		require.NotEmpty(t, unpackedMsg.FromKey)
+	})
+
+	t.Run("sign-then-encrypt with anoncrypt", func(t *testing.T) {
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeDIDCommV2Profile,
+			Message:          msg,
+			SigningKey:       authVM.ID,
+			ToKeys:           []string{toDID.KeyAgreement[0].VerificationMethod.ID},
+		})
+		require.NoError(t, err)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, msg, unpackedMsg.Message)
+		require.Equal(t, authVM.ID, unpackedMsg.SigningKey)
+
+		// the signer is the sender of the anoncrypt message
+		fromKey := &cryptoapi.PublicKey{}
+		require.NoError(t, json.Unmarshal(unpackedMsg.FromKey, fromKey))
+		require.Equal(t, authVM.ID, fromKey.KID)
+	})
+
+	t.Run("encrypted message without signature", func(t *testing.T) {
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeDIDCommV2Profile,
+			Message:          msg,
+			ToKeys:           []string{toDID.KeyAgreement[0].VerificationMethod.ID},
+		})
+		require.NoError(t, err)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, msg, unpackedMsg.Message)
+		require.Empty(t, unpackedMsg.SigningKey)
+	})
+
+	t.Run("sign-then-encrypt with a legacy media type profile", func(t *testing.T) {
+		_, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeRFC0019EncryptedEnvelope,
+			Message:          msg,
+			SigningKey:       authVM.ID,
+			ToKeys:           []string{toDID.KeyAgreement[0].VerificationMethod.ID},
+		})
+		require.EqualError(t, err, "packMessage: sign-then-encrypt requires a DIDComm V2 media type profile, "+
+			"got: '"+transport.MediaTypeRFC0019EncryptedEnvelope+"'")
+	})
+
+	t.Run("signing key not found", func(t *testing.T) {
+		_, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV2SignedEnvelope,
+			Message:          msg,
+			SigningKey:       fromDID.ID + "#unknown",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "packMessage: failed to sign")
+	})
+
+	t.Run("signed packer not registered", func(t *testing.T) {
+		noSignerPackager, err := New(&mockProvider{
+			primaryPacker: authPacker,
+			vdr:           mockedProviders.vdr,
+		})
+		require.NoError(t, err)
+
+		_, err = noSignerPackager.PackMessage(&transport.Envelope{
+			Message:    msg,
+			SigningKey: authVM.ID,
+		})
+		require.EqualError(t, err, "packMessage: signed envelope packer not found")
+	})
+}
+
 type resolverFunc func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
 
 //nolint:lll
@@ -0,0 +1,308 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/vmparse"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// Package signed includes a Packer implementation to build and parse DIDComm V2 signed messages (JWS). Signed
+// messages provide non-repudiation of the sender: the signature can be verified by anyone able to resolve the signer's
+// DID, independently of the transport encryption. A signed message is not encrypted, the packager.Packager wraps it in
+// an authcrypt or anoncrypt envelope when recipients are set (sign-then-encrypt).
+
+// Packer represents a DIDComm V2 signed messages Pack/Unpacker.
+type Packer struct {
+	kms           kms.KeyManager
+	cryptoService cryptoapi.Crypto
+	vdrRegistry   vdrapi.Registry
+	flattened     bool
+}
+
+// Opt is a Packer option.
+type Opt func(p *Packer)
+
+// WithFlattenedJSON makes the Packer output signed messages using the flattened JWS JSON serialization instead of the
+// general one. Both syntaxes are supported when unpacking.
+func WithFlattenedJSON() Opt {
+	return func(p *Packer) {
+		p.flattened = true
+	}
+}
+
+// New will create a Packer instance to sign payloads with a key of the sender's DID document. The key is referenced
+// in the signed message with its DID URL, recipients resolve it via the VDR registry to verify the signature.
+func New(ctx packer.Provider, opts ...Opt) (*Packer, error) {
+	k := ctx.KMS()
+	if k == nil {
+		return nil, errors.New("signed: failed to create packer because KMS is empty")
+	}
+
+	c := ctx.Crypto()
+	if c == nil {
+		return nil, errors.New("signed: failed to create packer because crypto service is empty")
+	}
+
+	vdrReg := ctx.VDRegistry()
+	if vdrReg == nil {
+		return nil, errors.New("signed: failed to create packer because vdr registry is empty")
+	}
+
+	p := &Packer{
+		kms:           k,
+		cryptoService: c,
+		vdrRegistry:   vdrReg,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p, nil
+}
+
+// Pack will sign the payload argument with the following arguments:
+// contentType: not used, the payload of a signed message is always a DIDComm V2 plaintext message
+// payload: the payload message that will be signed
+// senderID: the DID URL of the signing key, found in the authentication verification methods of the sender's DID
+// document. If senderID is a DID without a fragment (ie a did:key), its first authentication method is used.
+// recipientsPubKeys: not used, signed messages are not encrypted.
+func (p *Packer) Pack(_ string, payload, senderID []byte, _ [][]byte) ([]byte, error) {
+	vm, kid, err := p.authenticationMethod(string(senderID), true)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: %w", err)
+	}
+
+	keyBytes, kty, _, err := vmparse.VMToBytesTypeCrv(vm)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: %w", err)
+	}
+
+	alg, err := jwsAlgorithm(kty)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: %w", err)
+	}
+
+	kmsKID, err := jwkkid.CreateKID(keyBytes, kty)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to create KMS KID of signing key: %w", err)
+	}
+
+	kh, err := p.kms.Get(kmsKID)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to get signing key from KMS: %w", err)
+	}
+
+	protected := jose.Headers{
+		jose.HeaderType:      p.EncodingType(),
+		jose.HeaderAlgorithm: alg,
+	}
+
+	unprotected := jose.Headers{
+		jose.HeaderKeyID: kid,
+	}
+
+	jws, err := jose.NewJWS(protected, unprotected, payload, &cryptoSigner{kh: kh, crypto: p.cryptoService})
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to sign payload: %w", err)
+	}
+
+	s, err := jws.SerializeJSON(p.flattened, false)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to serialize JWS message: %w", err)
+	}
+
+	return []byte(s), nil
+}
+
+// Unpack will verify the signature of the envelope using the signer's key resolved from its DID document. The
+// returned transport.Envelope has the signed message in Message, the DID URL of the signing key in SigningKey, and
+// the signing key as a marshalled crypto.PublicKey with the DID URL as KID in FromKey (as authcrypt does).
+func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
+	var (
+		kid      string
+		keyBytes []byte
+		kty      kms.KeyType
+	)
+
+	verifier := jose.SignatureVerifierFunc(func(joseHeaders jose.Headers, _, signingInput, signature []byte) error {
+		var ok bool
+
+		kid, ok = joseHeaders.KeyID()
+		if !ok {
+			return errors.New("missing 'kid' header")
+		}
+
+		vm, _, err := p.authenticationMethod(kid, false)
+		if err != nil {
+			return err
+		}
+
+		keyBytes, kty, _, err = vmparse.VMToBytesTypeCrv(vm)
+		if err != nil {
+			return err
+		}
+
+		pubKH, err := p.kms.PubKeyBytesToHandle(keyBytes, kty)
+		if err != nil {
+			return fmt.Errorf("failed to get signer public key handle: %w", err)
+		}
+
+		return p.cryptoService.Verify(signature, signingInput, pubKH)
+	})
+
+	jws, err := jose.ParseJWS(string(envelope), verifier)
+	if err != nil {
+		return nil, fmt.Errorf("signed Unpack: failed to verify JWS message: %w", err)
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != p.EncodingType() {
+		return nil, fmt.Errorf("signed Unpack: unsupported 'typ' protected header: '%s'", typ)
+	}
+
+	err = checkSender(jws.Payload, kid)
+	if err != nil {
+		return nil, fmt.Errorf("signed Unpack: %w", err)
+	}
+
+	fromKey, err := signerPublicKey(kid, keyBytes, kty)
+	if err != nil {
+		return nil, fmt.Errorf("signed Unpack: %w", err)
+	}
+
+	return &transport.Envelope{
+		Message:    jws.Payload,
+		FromKey:    fromKey,
+		SigningKey: kid,
+	}, nil
+}
+
+// signerPublicKey returns the signing key as a marshalled crypto.PublicKey, its KID is the DID URL of the key so the
+// sender's DID can be found from it.
+func signerPublicKey(kid string, keyBytes []byte, kty kms.KeyType) ([]byte, error) {
+	j, err := jwksupport.PubKeyBytesToJWK(keyBytes, kty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert signer public key to JWK: %w", err)
+	}
+
+	pubKey, err := jwksupport.PublicKeyFromJWK(j)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert signer public key: %w", err)
+	}
+
+	pubKey.KID = kid
+
+	return json.Marshal(pubKey)
+}
+
+// authenticationMethod resolves keyID's DID and returns the matching authentication verification method along with
+// its absolute DID URL. If allowDIDOnly is set, keyID can be a DID without a fragment in which case the first
+// authentication method is returned.
+func (p *Packer) authenticationMethod(keyID string, allowDIDOnly bool) (*did.VerificationMethod, string, error) {
+	didID := keyID
+
+	if idx := strings.Index(keyID, "#"); idx > 0 {
+		didID = keyID[:idx]
+	} else if !allowDIDOnly {
+		return nil, "", fmt.Errorf("invalid kid '%s': must be a DID URL", keyID)
+	}
+
+	docResolution, err := p.vdrRegistry.Resolve(didID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve DID %s: %w", didID, err)
+	}
+
+	for _, auth := range docResolution.DIDDocument.Authentication {
+		vm := auth.VerificationMethod
+
+		vmID := vm.ID
+		if strings.HasPrefix(vmID, "#") {
+			vmID = didID + vmID
+		}
+
+		if keyID == didID || keyID == vmID {
+			return &vm, vmID, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("key '%s' not found in authentication verification methods of DID %s", keyID, didID)
+}
+
+// checkSender makes sure the sender of a DIDComm V2 message (its 'from' field) is the signer of the message. The
+// sender is required: a signed message without it would be attributed to no one while proving its origin.
+func checkSender(payload []byte, kid string) error {
+	msg := struct {
+		From string `json:"from,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return fmt.Errorf("failed to unmarshal signed message: %w", err)
+	}
+
+	if msg.From == "" {
+		return errors.New("signed message is missing the 'from' sender")
+	}
+
+	signerDID := strings.Split(kid, "#")[0]
+
+	if strings.Split(msg.From, "#")[0] != signerDID {
+		return fmt.Errorf("message sender '%s' does not match signer DID '%s'", msg.From, signerDID)
+	}
+
+	return nil
+}
+
+func jwsAlgorithm(kty kms.KeyType) (string, error) {
+	switch kty {
+	case kms.ED25519Type:
+		return "EdDSA", nil
+	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP256TypeDER:
+		return "ES256", nil
+	case kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP384TypeDER:
+		return "ES384", nil
+	case kms.ECDSAP521TypeIEEEP1363, kms.ECDSAP521TypeDER:
+		return "ES521", nil
+	case kms.ECDSASecp256k1TypeIEEEP1363, kms.ECDSASecp256k1DER:
+		return "ES256K", nil
+	default:
+		return "", fmt.Errorf("unsupported signing key type '%s'", kty)
+	}
+}
+
+// EncodingType for didcomm.
+func (p *Packer) EncodingType() string {
+	return transport.MediaTypeV2SignedEnvelope
+}
+
+type cryptoSigner struct {
+	kh     interface{}
+	crypto cryptoapi.Crypto
+}
+
+// Sign signs the input using the stored key handle.
+func (c *cryptoSigner) Sign(data []byte) ([]byte, error) {
+	return c.crypto.Sign(data, c.kh)
+}
+
+// Headers returns nil, cryptoSigner doesn't add its own headers.
+func (c *cryptoSigner) Headers() jose.Headers {
+	return nil
+}
@@ -0,0 +1,316 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signed
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const (
+	senderDID = "did:example:alice"
+	payload   = `{"id":"1234","type":"https://didcomm.org/basicmessage/2.0/message","from":"did:example:alice"}`
+)
+
+func TestNew(t *testing.T) {
+	k := createKMS(t)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		p, err := New(newMockProvider(k, c, &mockvdr.MockVDRegistry{}))
+		require.NoError(t, err)
+		require.Equal(t, transport.MediaTypeV2SignedEnvelope, p.EncodingType())
+	})
+
+	t.Run("missing services", func(t *testing.T) {
+		_, err := New(newMockProvider(nil, c, &mockvdr.MockVDRegistry{}))
+		require.EqualError(t, err, "signed: failed to create packer because KMS is empty")
+
+		_, err = New(newMockProvider(k, nil, &mockvdr.MockVDRegistry{}))
+		require.EqualError(t, err, "signed: failed to create packer because crypto service is empty")
+
+		_, err = New(&mockprovider.Provider{KMSValue: k, CryptoValue: c})
+		require.EqualError(t, err, "signed: failed to create packer because vdr registry is empty")
+	})
+}
+
+func TestPackUnpack(t *testing.T) {
+	k := createKMS(t)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		keyType kms.KeyType
+		alg     string
+		opts    []Opt
+	}{
+		{name: "Ed25519 general JSON", keyType: kms.ED25519Type, alg: "EdDSA"},
+		{name: "Ed25519 flattened JSON", keyType: kms.ED25519Type, alg: "EdDSA", opts: []Opt{WithFlattenedJSON()}},
+		{name: "P-256 general JSON", keyType: kms.ECDSAP256TypeIEEEP1363, alg: "ES256"},
+		{
+			name: "P-384 flattened JSON", keyType: kms.ECDSAP384TypeIEEEP1363, alg: "ES384",
+			opts: []Opt{WithFlattenedJSON()},
+		},
+		{name: "P-521 general JSON", keyType: kms.ECDSAP521TypeIEEEP1363, alg: "ES521"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			doc := createDIDDoc(t, k, tc.keyType)
+
+			p, err := New(newMockProvider(k, c, mockRegistry(doc)), tc.opts...)
+			require.NoError(t, err)
+
+			envelope, err := p.Pack("", []byte(payload), []byte(senderDID+"#key-1"), nil)
+			require.NoError(t, err)
+
+			raw := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(envelope, &raw))
+
+			_, isGeneral := raw["signatures"]
+			require.Equal(t, len(tc.opts) == 0, isGeneral)
+
+			env, err := p.Unpack(envelope)
+			require.NoError(t, err)
+			require.Equal(t, payload, string(env.Message))
+			require.Equal(t, senderDID+"#key-1", env.SigningKey)
+
+			fromKey := &cryptoapi.PublicKey{}
+			require.NoError(t, json.Unmarshal(env.FromKey, fromKey))
+			require.Equal(t, senderDID+"#key-1", fromKey.KID)
+			require.NotEmpty(t, fromKey.X)
+
+			jws, err := jose.ParseJWS(string(envelope), &noopVerifier{})
+			require.NoError(t, err)
+
+			alg, ok := jws.ProtectedHeaders.Algorithm()
+			require.True(t, ok)
+			require.Equal(t, tc.alg, alg)
+		})
+	}
+
+	t.Run("sender DID without fragment uses first authentication method", func(t *testing.T) {
+		doc := createDIDDoc(t, k, kms.ED25519Type)
+
+		p, err := New(newMockProvider(k, c, mockRegistry(doc)))
+		require.NoError(t, err)
+
+		envelope, err := p.Pack("", []byte(payload), []byte(senderDID), nil)
+		require.NoError(t, err)
+
+		env, err := p.Unpack(envelope)
+		require.NoError(t, err)
+		require.Equal(t, senderDID+"#key-1", env.SigningKey)
+	})
+}
+
+func TestPackFailures(t *testing.T) {
+	k := createKMS(t)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	doc := createDIDDoc(t, k, kms.ED25519Type)
+
+	t.Run("DID resolution error", func(t *testing.T) {
+		p, err := New(newMockProvider(k, c, &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}))
+		require.NoError(t, err)
+
+		_, err = p.Pack("", []byte(payload), []byte(senderDID+"#key-1"), nil)
+		require.EqualError(t, err, "signed Pack: failed to resolve DID did:example:alice: resolve error")
+	})
+
+	t.Run("key is not an authentication method", func(t *testing.T) {
+		p, err := New(newMockProvider(k, c, mockRegistry(doc)))
+		require.NoError(t, err)
+
+		_, err = p.Pack("", []byte(payload), []byte(senderDID+"#key-2"), nil)
+		require.EqualError(t, err, "signed Pack: key 'did:example:alice#key-2' not found in authentication "+
+			"verification methods of DID did:example:alice")
+	})
+
+	t.Run("signing key not in KMS", func(t *testing.T) {
+		otherKMS := createKMS(t)
+
+		p, err := New(newMockProvider(otherKMS, c, mockRegistry(doc)))
+		require.NoError(t, err)
+
+		_, err = p.Pack("", []byte(payload), []byte(senderDID+"#key-1"), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed Pack: failed to get signing key from KMS")
+	})
+
+	t.Run("unsupported verification method type", func(t *testing.T) {
+		badDoc := &did.Doc{ID: senderDID}
+		vm := did.NewVerificationMethodFromBytes("#key-1", "X25519KeyAgreementKey2019", senderDID, []byte("key"))
+		badDoc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+
+		p, err := New(newMockProvider(k, c, mockRegistry(badDoc)))
+		require.NoError(t, err)
+
+		_, err = p.Pack("", []byte(payload), []byte(senderDID+"#key-1"), nil)
+		require.EqualError(t, err, "signed Pack: vm.Type 'X25519KeyAgreementKey2019' not supported")
+	})
+}
+
+func TestUnpackFailures(t *testing.T) {
+	k := createKMS(t)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	doc := createDIDDoc(t, k, kms.ED25519Type)
+
+	p, err := New(newMockProvider(k, c, mockRegistry(doc)))
+	require.NoError(t, err)
+
+	envelope, err := p.Pack("", []byte(payload), []byte(senderDID+"#key-1"), nil)
+	require.NoError(t, err)
+
+	t.Run("tampered payload", func(t *testing.T) {
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(envelope, &raw))
+
+		raw["payload"] = "eyJpZCI6IjEyMzQifQ"
+
+		tampered, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		_, err = p.Unpack(tampered)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed Unpack: failed to verify JWS message")
+	})
+
+	t.Run("kid is not a DID URL", func(t *testing.T) {
+		_, err = p.Unpack([]byte(strings.Replace(string(envelope), senderDID+"#key-1", senderDID, 1)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid kid 'did:example:alice': must be a DID URL")
+	})
+
+	t.Run("sender is not the signer", func(t *testing.T) {
+		forged, err := p.Pack("", []byte(`{"id":"1234","from":"did:example:mallory"}`),
+			[]byte(senderDID+"#key-1"), nil)
+		require.NoError(t, err)
+
+		_, err = p.Unpack(forged)
+		require.EqualError(t, err, "signed Unpack: message sender 'did:example:mallory' does not match "+
+			"signer DID 'did:example:alice'")
+	})
+
+	t.Run("sender is missing", func(t *testing.T) {
+		anonymous, err := p.Pack("", []byte(`{"id":"1234"}`), []byte(senderDID+"#key-1"), nil)
+		require.NoError(t, err)
+
+		_, err = p.Unpack(anonymous)
+		require.EqualError(t, err, "signed Unpack: signed message is missing the 'from' sender")
+
+		notJSON, err := p.Pack("", []byte("not JSON"), []byte(senderDID+"#key-1"), nil)
+		require.NoError(t, err)
+
+		_, err = p.Unpack(notJSON)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed Unpack: failed to unmarshal signed message")
+	})
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		_, err = p.Unpack([]byte("{}"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed Unpack: failed to verify JWS message")
+	})
+}
+
+func createDIDDoc(t *testing.T, k kms.KeyManager, keyType kms.KeyType) *did.Doc {
+	t.Helper()
+
+	_, pubKey, err := k.CreateAndExportPubKeyBytes(keyType)
+	require.NoError(t, err)
+
+	var vm *did.VerificationMethod
+
+	if keyType == kms.ED25519Type {
+		vm = did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", senderDID, pubKey)
+	} else {
+		j, err := jwksupport.PubKeyBytesToJWK(pubKey, keyType)
+		require.NoError(t, err)
+
+		vm, err = did.NewVerificationMethodFromJWK("#key-1", "JsonWebKey2020", senderDID, j)
+		require.NoError(t, err)
+	}
+
+	doc := &did.Doc{ID: senderDID, VerificationMethod: []did.VerificationMethod{*vm}}
+	doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+
+	return doc
+}
+
+func mockRegistry(doc *did.Doc) *mockvdr.MockVDRegistry {
+	return &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			if didID != doc.ID {
+				return nil, vdrapi.ErrNotFound
+			}
+
+			return &did.DocResolution{DIDDocument: doc}, nil
+		},
+	}
+}
+
+type noopVerifier struct{}
+
+func (v *noopVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
+	return nil
+}
+
+func createKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	k, err := localkms.New("local-lock://test/key/uri", p)
+	require.NoError(t, err)
+
+	return k
+}
+
+func newMockProvider(customKMS kms.KeyManager, customCrypto *tinkcrypto.Crypto,
+	registry vdrapi.Registry) *mockprovider.Provider {
+	p := &mockprovider.Provider{
+		KMSValue:        customKMS,
+		VDRegistryValue: registry,
+	}
+
+	if customCrypto != nil {
+		p.CryptoValue = customCrypto
+	}
+
+	return p
+}
@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Query is a DIDComm V1 discover features query message.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0031-discover-features#query-message-type
+type Query struct {
+	ID      string `json:"@id,omitempty"`
+	Type    string `json:"@type,omitempty"`
+	Query   string `json:"query"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Disclose is a DIDComm V1 discover features disclose message.
+type Disclose struct {
+	ID        string               `json:"@id,omitempty"`
+	Type      string               `json:"@type,omitempty"`
+	Protocols []ProtocolDisclosure `json:"protocols"`
+	Thread    *decorator.Thread    `json:"~thread,omitempty"`
+}
+
+// ProtocolDisclosure is a protocol disclosed by a DIDComm V1 disclose message.
+type ProtocolDisclosure struct {
+	ProtocolID string   `json:"pid"`
+	Roles      []string `json:"roles,omitempty"`
+}
+
+// QueriesV2 is a DIDComm V2 discover features queries message.
+// https://identity.foundation/didcomm-messaging/spec/#discover-features-protocol-20
+type QueriesV2 struct {
+	ID   string        `json:"id,omitempty"`
+	Type string        `json:"type,omitempty"`
+	Body QueriesV2Body `json:"body"`
+}
+
+// QueriesV2Body is the body of the DIDComm V2 queries message.
+type QueriesV2Body struct {
+	Queries []FeatureQuery `json:"queries"`
+}
+
+// DiscloseV2 is a DIDComm V2 discover features disclose message.
+type DiscloseV2 struct {
+	ID       string         `json:"id,omitempty"`
+	Type     string         `json:"type,omitempty"`
+	ThreadID string         `json:"thid,omitempty"`
+	Body     DiscloseV2Body `json:"body"`
+}
+
+// DiscloseV2Body is the body of the DIDComm V2 disclose message.
+type DiscloseV2Body struct {
+	Disclosures []Disclosure `json:"disclosures"`
+}
+
+// FeatureQuery queries the features of a type whose ID matches a pattern. The pattern either is an exact ID or
+// ends with a `*` wildcard matching any suffix, e.g. `https://didcomm.org/trust-ping/*`.
+type FeatureQuery struct {
+	FeatureType string `json:"feature-type"`
+	Match       string `json:"match"`
+}
+
+// Disclosure is a feature supported by an agent.
+type Disclosure struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
+
+// Record holds the features disclosed by the other party of a connection.
+type Record struct {
+	ConnectionID string       `json:"connection_id"`
+	Disclosures  []Disclosure `json:"disclosures"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
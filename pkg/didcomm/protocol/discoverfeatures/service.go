@@ -0,0 +1,632 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// DiscoverFeatures defines the protocol name.
+	DiscoverFeatures = "discover-features"
+	// Spec defines the DIDComm V1 protocol spec.
+	Spec = "https://didcomm.org/discover-features/1.0/"
+	// QueryMsgType defines the DIDComm V1 query message type.
+	QueryMsgType = Spec + "query"
+	// DiscloseMsgType defines the DIDComm V1 disclose message type.
+	DiscloseMsgType = Spec + "disclose"
+
+	// SpecV2 defines the DIDComm V2 protocol spec.
+	SpecV2 = "https://didcomm.org/discover-features/2.0/"
+	// QueriesMsgTypeV2 defines the DIDComm V2 queries message type.
+	QueriesMsgTypeV2 = SpecV2 + "queries"
+	// DiscloseMsgTypeV2 defines the DIDComm V2 disclose message type.
+	DiscloseMsgTypeV2 = SpecV2 + "disclose"
+)
+
+// Feature types, DIDComm V1 queries and disclosures are limited to protocols.
+const (
+	// FeatureTypeProtocol is the feature type of protocols, the feature ID is the protocol identifier URI.
+	FeatureTypeProtocol = "protocol"
+	// FeatureTypeGoalCode is the feature type of goal codes.
+	FeatureTypeGoalCode = "goal-code"
+	// FeatureTypeMediaTypeProfile is the feature type of DIDComm media type profiles. It is not defined by the
+	// DIDComm V2 spec, the framework discloses the media type profiles it was configured with.
+	FeatureTypeMediaTypeProfile = "media-type-profile"
+)
+
+const (
+	storeName      = "discoverfeatures"
+	defaultTimeout = 10 * time.Second
+	wildcard       = "*"
+)
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrTimeout is returned when the disclose was not received in time.
+	ErrTimeout = errors.New("timeout waiting for disclose")
+	// ErrNoDisclosures is returned when the features of a connection were never queried.
+	ErrNoDisclosures = errors.New("no disclosures for the connection")
+
+	logger = log.New("aries-framework/discoverfeatures")
+)
+
+// ProtocolDiscloser is implemented by protocol services disclosing the protocols they support, the protocol services
+// of the framework implement it. Custom protocol services implement it to be discoverable.
+type ProtocolDiscloser interface {
+	// DisclosedProtocols returns the identifier URIs of the supported protocols.
+	DisclosedProtocols() []string
+}
+
+// DisclosurePolicy decides whether a feature is disclosed to the DID of the other party, features are disclosed
+// when it returns true.
+type DisclosurePolicy func(feature *Disclosure, theirDID string) bool
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	AllServices() []dispatcher.ProtocolService
+	MediaTypeProfiles() []string
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// Opt configures the Service.
+type Opt func(s *Service)
+
+// WithDisclosurePolicy sets the policy filtering the disclosed features, all features are disclosed by default.
+func WithDisclosurePolicy(policy DisclosurePolicy) Opt {
+	return func(s *Service) {
+		s.SetDisclosurePolicy(policy)
+	}
+}
+
+// WithGoalCodes sets the goal codes disclosed by the agent.
+func WithGoalCodes(goalCodes ...string) Opt {
+	return func(s *Service) {
+		s.AddGoalCodes(goalCodes...)
+	}
+}
+
+// Service for the discover features protocol.
+type Service struct {
+	outbound          dispatcher.Outbound
+	connectionLookup  connections
+	services          func() []dispatcher.ProtocolService
+	mediaTypeProfiles []string
+	store             storage.Store
+	goalCodes         []string
+	policy            DisclosurePolicy
+	lock              sync.RWMutex
+	responseMap       map[string]chan []Disclosure
+	responseMapLock   sync.RWMutex
+	initialized       bool
+}
+
+// New returns the discover features service.
+func New(prov provider, opts ...Opt) (*Service, error) {
+	svc := Service{}
+
+	for _, opt := range opts {
+		opt(&svc)
+	}
+
+	err := svc.Initialize(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+// Initialize initializes the Service. If Initialize succeeds, any further call is a no-op.
+func (s *Service) Initialize(p interface{}) error {
+	if s.initialized {
+		return nil
+	}
+
+	prov, ok := p.(provider)
+	if !ok {
+		return fmt.Errorf("expected provider of type `%T`, got type `%T`", provider(nil), p)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return err
+	}
+
+	store, err := prov.StorageProvider().OpenStore(storeName)
+	if err != nil {
+		return fmt.Errorf("open discover features store: %w", err)
+	}
+
+	s.outbound = prov.OutboundDispatcher()
+	s.connectionLookup = connectionLookup
+	// services are registered after this one is initialized, they are listed when features are queried.
+	s.services = prov.AllServices
+	s.mediaTypeProfiles = prov.MediaTypeProfiles()
+	s.store = store
+	s.responseMap = make(map[string]chan []Disclosure)
+
+	s.initialized = true
+
+	return nil
+}
+
+// SetDisclosurePolicy sets the policy filtering the disclosed features, nil discloses all features.
+func (s *Service) SetDisclosurePolicy(policy DisclosurePolicy) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.policy = policy
+}
+
+// AddGoalCodes adds goal codes to the features disclosed by the agent.
+func (s *Service) AddGoalCodes(goalCodes ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.goalCodes = append(s.goalCodes, goalCodes...)
+}
+
+// HandleInbound handles inbound discover features messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	// perform action asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case QueryMsgType:
+			err = s.handleQuery(msg, ctx.MyDID(), ctx.TheirDID())
+		case QueriesMsgTypeV2:
+			err = s.handleQueries(msg, ctx.MyDID(), ctx.TheirDID())
+		case DiscloseMsgType, DiscloseMsgTypeV2:
+			err = s.handleDisclose(msg)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%w)\n", err)
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case QueryMsgType, DiscloseMsgType, QueriesMsgTypeV2, DiscloseMsgTypeV2:
+		return true
+	}
+
+	return false
+}
+
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{Spec, SpecV2}
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return DiscoverFeatures
+}
+
+// Features returns the features of the agent disclosed to the given DID of the other party.
+func (s *Service) Features(theirDID string) []Disclosure {
+	var features []Disclosure
+
+	for _, pid := range s.protocols() {
+		features = append(features, Disclosure{FeatureType: FeatureTypeProtocol, ID: pid})
+	}
+
+	s.lock.RLock()
+	goalCodes, policy := s.goalCodes, s.policy
+	s.lock.RUnlock()
+
+	for _, goalCode := range goalCodes {
+		features = append(features, Disclosure{FeatureType: FeatureTypeGoalCode, ID: goalCode})
+	}
+
+	for _, profile := range s.mediaTypeProfiles {
+		features = append(features, Disclosure{FeatureType: FeatureTypeMediaTypeProfile, ID: profile})
+	}
+
+	if policy == nil {
+		return features
+	}
+
+	var disclosed []Disclosure
+
+	for i := range features {
+		if policy(&features[i], theirDID) {
+			disclosed = append(disclosed, features[i])
+		}
+	}
+
+	return disclosed
+}
+
+func (s *Service) protocols() []string {
+	var pids []string
+
+	seen := make(map[string]bool)
+
+	add := func(pid string) {
+		pid = strings.TrimSuffix(pid, "/")
+
+		if !seen[pid] {
+			seen[pid] = true
+			pids = append(pids, pid)
+		}
+	}
+
+	for _, svc := range s.services() {
+		if discloser, ok := svc.(ProtocolDiscloser); ok {
+			for _, pid := range discloser.DisclosedProtocols() {
+				add(pid)
+			}
+		}
+	}
+
+	return pids
+}
+
+func (s *Service) handleQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	query := Query{}
+
+	err := msg.Decode(&query)
+	if err != nil {
+		return fmt.Errorf("query message unmarshal: %w", err)
+	}
+
+	resp := &Disclose{
+		ID:        uuid.New().String(),
+		Type:      DiscloseMsgType,
+		Protocols: []ProtocolDisclosure{},
+		Thread:    &decorator.Thread{ID: msg.ID()},
+	}
+
+	for _, feature := range matchFeatures(s.Features(theirDID), FeatureQuery{
+		FeatureType: FeatureTypeProtocol,
+		Match:       query.Query,
+	}) {
+		resp.Protocols = append(resp.Protocols, ProtocolDisclosure{ProtocolID: feature.ID, Roles: feature.Roles})
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(resp), myDID, theirDID)
+}
+
+func (s *Service) handleQueries(msg service.DIDCommMsg, myDID, theirDID string) error {
+	queries := QueriesV2{}
+
+	err := msg.Decode(&queries)
+	if err != nil {
+		return fmt.Errorf("queries message unmarshal: %w", err)
+	}
+
+	resp := &DiscloseV2{
+		ID:       uuid.New().String(),
+		Type:     DiscloseMsgTypeV2,
+		ThreadID: msg.ID(),
+		Body: DiscloseV2Body{
+			Disclosures: matchFeatures(s.Features(theirDID), queries.Body.Queries...),
+		},
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(resp), myDID, theirDID)
+}
+
+func (s *Service) handleDisclose(msg service.DIDCommMsg) error {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("disclose thread ID: %w", err)
+	}
+
+	var disclosures []Disclosure
+
+	if msg.Type() == DiscloseMsgTypeV2 {
+		disclose := DiscloseV2{}
+
+		err = msg.Decode(&disclose)
+		if err != nil {
+			return fmt.Errorf("disclose message unmarshal: %w", err)
+		}
+
+		disclosures = disclose.Body.Disclosures
+	} else {
+		disclose := Disclose{}
+
+		err = msg.Decode(&disclose)
+		if err != nil {
+			return fmt.Errorf("disclose message unmarshal: %w", err)
+		}
+
+		for _, protocol := range disclose.Protocols {
+			disclosures = append(disclosures, Disclosure{
+				FeatureType: FeatureTypeProtocol,
+				ID:          protocol.ProtocolID,
+				Roles:       protocol.Roles,
+			})
+		}
+	}
+
+	// check if there is a query waiting for this disclose
+	responseCh := s.getResponseCh(thID)
+	if responseCh == nil {
+		logger.Debugf("ignoring disclose with unknown thread ID %s", thID)
+
+		return nil
+	}
+
+	// the channel is buffered for one disclose, duplicate disclosures are dropped
+	select {
+	case responseCh <- disclosures:
+	default:
+		logger.Warnf("dropping duplicate disclose for thread %s", thID)
+	}
+
+	return nil
+}
+
+// QueryOption configures a features query.
+type QueryOption func(opts *queryOpts)
+
+type queryOpts struct {
+	queries []FeatureQuery
+	timeout time.Duration
+}
+
+// WithQueries sets the feature queries, all features are queried by default. DIDComm V1 connections only support
+// a single protocol query.
+func WithQueries(queries ...FeatureQuery) QueryOption {
+	return func(opts *queryOpts) {
+		opts.queries = queries
+	}
+}
+
+// WithTimeout sets how long to wait for the disclose, defaults to 10 seconds.
+func WithTimeout(timeout time.Duration) QueryOption {
+	return func(opts *queryOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// Query queries the features of the other party of the connection and waits for their disclosure. The DIDComm
+// version of the query is the one of the connection. The disclosures replace the ones of the connection record
+// matching the queries. Returns ErrTimeout if the disclose is not received in time.
+func (s *Service) Query(connectionID string, options ...QueryOption) ([]Disclosure, error) {
+	opts := &queryOpts{timeout: defaultTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	req, err := queryMessage(msgID, conn.DIDCommVersion, opts.queries)
+	if err != nil {
+		return nil, err
+	}
+
+	// register chan for callback processing
+	responseCh := make(chan []Disclosure, 1)
+	s.setResponseCh(msgID, responseCh)
+
+	defer s.setResponseCh(msgID, nil)
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send query: %w", err)
+	}
+
+	// callback processing (to make this function look like a sync function)
+	select {
+	case disclosures := <-responseCh:
+		err = s.saveDisclosures(connectionID, disclosures, queriesOf(req))
+		if err != nil {
+			return nil, err
+		}
+
+		return disclosures, nil
+	case <-time.After(opts.timeout):
+		return nil, ErrTimeout
+	}
+}
+
+// Disclosures returns the features disclosed by the other party of the connection when it was last queried.
+// Returns ErrNoDisclosures if its features were never queried.
+func (s *Service) Disclosures(connectionID string) ([]Disclosure, error) {
+	record, err := s.getRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrNoDisclosures
+		}
+
+		return nil, err
+	}
+
+	return record.Disclosures, nil
+}
+
+func queryMessage(msgID string, version service.Version, queries []FeatureQuery) (interface{}, error) {
+	if version == service.V2 {
+		if len(queries) == 0 {
+			queries = []FeatureQuery{
+				{FeatureType: FeatureTypeProtocol, Match: wildcard},
+				{FeatureType: FeatureTypeGoalCode, Match: wildcard},
+				{FeatureType: FeatureTypeMediaTypeProfile, Match: wildcard},
+			}
+		}
+
+		return &QueriesV2{
+			ID:   msgID,
+			Type: QueriesMsgTypeV2,
+			Body: QueriesV2Body{Queries: queries},
+		}, nil
+	}
+
+	if len(queries) == 0 {
+		queries = []FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: wildcard}}
+	}
+
+	if len(queries) != 1 || queries[0].FeatureType != FeatureTypeProtocol {
+		return nil, errors.New("DIDComm V1 connections only support a single protocol query")
+	}
+
+	return &Query{
+		ID:    msgID,
+		Type:  QueryMsgType,
+		Query: queries[0].Match,
+	}, nil
+}
+
+func queriesOf(req interface{}) []FeatureQuery {
+	switch msg := req.(type) {
+	case *QueriesV2:
+		return msg.Body.Queries
+	case *Query:
+		return []FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: msg.Query}}
+	}
+
+	return nil
+}
+
+func (s *Service) saveDisclosures(connectionID string, disclosures []Disclosure, queries []FeatureQuery) error {
+	record, err := s.getRecord(connectionID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			return err
+		}
+
+		record = &Record{ConnectionID: connectionID}
+	}
+
+	var kept []Disclosure
+
+	// disclosures matching the queries are superseded
+	for _, disclosure := range record.Disclosures {
+		if len(matchFeatures([]Disclosure{disclosure}, queries...)) == 0 {
+			kept = append(kept, disclosure)
+		}
+	}
+
+	record.Disclosures = append(kept, disclosures...)
+	record.UpdatedAt = time.Now()
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal disclosures record: %w", err)
+	}
+
+	err = s.store.Put(connectionID, recordBytes)
+	if err != nil {
+		return fmt.Errorf("save disclosures record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getRecord(connectionID string) (*Record, error) {
+	recordBytes, err := s.store.Get(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get disclosures record: %w", err)
+	}
+
+	record := &Record{}
+
+	err = json.Unmarshal(recordBytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal disclosures record: %w", err)
+	}
+
+	return record, nil
+}
+
+// matchFeatures returns the features matching any of the queries.
+func matchFeatures(features []Disclosure, queries ...FeatureQuery) []Disclosure {
+	matched := []Disclosure{}
+
+	for _, feature := range features {
+		for _, query := range queries {
+			if feature.FeatureType == query.FeatureType && match(query.Match, feature.ID) {
+				matched = append(matched, feature)
+
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+func match(pattern, id string) bool {
+	if strings.HasSuffix(pattern, wildcard) {
+		return strings.HasPrefix(id, strings.TrimSuffix(pattern, wildcard))
+	}
+
+	return pattern == id
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) getResponseCh(msgID string) chan []Disclosure {
+	s.responseMapLock.RLock()
+	defer s.responseMapLock.RUnlock()
+
+	return s.responseMap[msgID]
+}
+
+func (s *Service) setResponseCh(msgID string, responseCh chan []Disclosure) {
+	s.responseMapLock.Lock()
+	defer s.responseMapLock.Unlock()
+
+	if responseCh == nil {
+		delete(s.responseMap, msgID)
+	} else {
+		s.responseMap[msgID] = responseCh
+	}
+}
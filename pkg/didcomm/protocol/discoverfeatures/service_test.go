@@ -0,0 +1,365 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/legacyconnection"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+
+	customPID = "https://example.com/custom/1.0"
+)
+
+func TestServiceNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+		require.Equal(t, DiscoverFeatures, svc.Name())
+
+		// second init is no-op
+		require.NoError(t, svc.Initialize(newProvider(&mockdispatcher.MockOutbound{})))
+	})
+
+	t.Run("failure, not given a valid provider", func(t *testing.T) {
+		svc := Service{}
+
+		err := svc.Initialize("not a provider")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected provider of type")
+	})
+
+	t.Run("failure, open store", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{})
+		storeProv := mockstore.NewMockStoreProvider()
+		storeProv.FailNamespace = storeName
+		prov.StorageProviderValue = storeProv
+
+		_, err := New(prov)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open discover features store")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc := &Service{}
+
+	require.True(t, svc.Accept(QueryMsgType))
+	require.True(t, svc.Accept(DiscloseMsgType))
+	require.True(t, svc.Accept(QueriesMsgTypeV2))
+	require.True(t, svc.Accept(DiscloseMsgTypeV2))
+	require.False(t, svc.Accept(trustping.PingMsgType))
+
+	_, err := svc.HandleOutbound(nil, MYDID, THEIRDID)
+	require.EqualError(t, err, "not implemented")
+}
+
+func TestService_Features(t *testing.T) {
+	prov := newProvider(&mockdispatcher.MockOutbound{})
+	svc, err := New(prov, WithGoalCodes("issue-vc"))
+	require.NoError(t, err)
+
+	prov.ServiceMap = map[string]interface{}{
+		DiscoverFeatures:    svc,
+		trustping.TrustPing: &trustping.Service{},
+		"custom":            &customService{},
+	}
+
+	features := svc.Features(THEIRDID)
+	require.ElementsMatch(t, []Disclosure{
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/1.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/discover-features/2.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust_ping/1.0"},
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"},
+		{FeatureType: FeatureTypeProtocol, ID: customPID},
+		{FeatureType: FeatureTypeGoalCode, ID: "issue-vc"},
+		{FeatureType: FeatureTypeMediaTypeProfile, ID: "didcomm/v2"},
+	}, features)
+
+	svc.SetDisclosurePolicy(func(feature *Disclosure, theirDID string) bool {
+		require.Equal(t, THEIRDID, theirDID)
+
+		return feature.FeatureType == FeatureTypeProtocol && feature.ID != customPID
+	})
+
+	features = svc.Features(THEIRDID)
+	require.Len(t, features, 4)
+	require.NotContains(t, features, Disclosure{FeatureType: FeatureTypeProtocol, ID: customPID})
+}
+
+func TestService_FrameworkProtocols(t *testing.T) {
+	prov := newProvider(&mockdispatcher.MockOutbound{})
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	prov.ServiceMap = map[string]interface{}{
+		DiscoverFeatures:                  svc,
+		trustping.TrustPing:               &trustping.Service{},
+		didexchange.DIDExchange:           &didexchange.Service{},
+		legacyconnection.LegacyConnection: &legacyconnection.Service{},
+		outofband.Name:                    &outofband.Service{},
+		outofbandv2.Name:                  &outofbandv2.Service{},
+		mediator.Coordination:             &mediator.Service{},
+		messagepickup.MessagePickup:       &messagepickup.Service{},
+		introduce.Introduce:               &introduce.Service{},
+		issuecredential.Name:              &issuecredential.Service{},
+		presentproof.Name:                 &presentproof.Service{},
+	}
+
+	require.ElementsMatch(t, []string{
+		"https://didcomm.org/discover-features/1.0",
+		"https://didcomm.org/discover-features/2.0",
+		"https://didcomm.org/trust_ping/1.0",
+		"https://didcomm.org/trust-ping/2.0",
+		"https://didcomm.org/didexchange/1.0",
+		"https://didcomm.org/connections/1.0",
+		"https://didcomm.org/out-of-band/1.0",
+		"https://didcomm.org/out-of-band/2.0",
+		"https://didcomm.org/coordinatemediation/1.0",
+		"https://didcomm.org/coordinate-mediation/2.0",
+		"https://didcomm.org/routing/1.0",
+		"https://didcomm.org/routing/2.0",
+		"https://didcomm.org/messagepickup/1.0",
+		"https://didcomm.org/messagepickup/3.0",
+		"https://didcomm.org/introduce/1.0",
+		"https://didcomm.org/issue-credential/2.0",
+		"https://didcomm.org/issue-credential/3.0",
+		"https://didcomm.org/present-proof/2.0",
+		"https://didcomm.org/present-proof/3.0",
+	}, svc.protocols())
+}
+
+func TestService_Query(t *testing.T) {
+	t.Run("DIDComm V2 query all features", func(t *testing.T) {
+		alice, _ := newConnectedServices(t, service.V2)
+
+		disclosures, err := alice.Query("conn")
+		require.NoError(t, err)
+		require.Contains(t, disclosures, Disclosure{
+			FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0",
+		})
+		require.Contains(t, disclosures, Disclosure{FeatureType: FeatureTypeGoalCode, ID: "issue-vc"})
+		require.Contains(t, disclosures, Disclosure{FeatureType: FeatureTypeMediaTypeProfile, ID: "didcomm/v2"})
+
+		cached, err := alice.Disclosures("conn")
+		require.NoError(t, err)
+		require.Equal(t, disclosures, cached)
+	})
+
+	t.Run("DIDComm V2 query replaces the matching cached disclosures", func(t *testing.T) {
+		alice, bob := newConnectedServices(t, service.V2)
+
+		_, err := alice.Query("conn")
+		require.NoError(t, err)
+
+		bob.SetDisclosurePolicy(func(feature *Disclosure, _ string) bool {
+			return feature.ID != "https://didcomm.org/trust_ping/1.0"
+		})
+
+		disclosures, err := alice.Query("conn", WithQueries(FeatureQuery{
+			FeatureType: FeatureTypeProtocol,
+			Match:       "https://didcomm.org/trust*",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []Disclosure{{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"}},
+			disclosures)
+
+		cached, err := alice.Disclosures("conn")
+		require.NoError(t, err)
+		require.NotContains(t, cached, Disclosure{
+			FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust_ping/1.0",
+		})
+		require.Contains(t, cached, Disclosure{FeatureType: FeatureTypeGoalCode, ID: "issue-vc"})
+	})
+
+	t.Run("DIDComm V1 query", func(t *testing.T) {
+		alice, _ := newConnectedServices(t, service.V1)
+
+		disclosures, err := alice.Query("conn", WithQueries(FeatureQuery{
+			FeatureType: FeatureTypeProtocol,
+			Match:       "https://didcomm.org/trust_ping/1.0",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []Disclosure{{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust_ping/1.0"}},
+			disclosures)
+	})
+
+	t.Run("DIDComm V1 connections only support a protocol query", func(t *testing.T) {
+		alice, _ := newConnectedServices(t, service.V1)
+
+		_, err := alice.Query("conn", WithQueries(FeatureQuery{FeatureType: FeatureTypeGoalCode, Match: "*"}))
+		require.EqualError(t, err, "DIDComm V1 connections only support a single protocol query")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn")
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{SendErr: errors.New("send error")})
+		saveConnection(t, prov, service.V2)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn")
+		require.EqualError(t, err, "send query: send error")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{})
+		saveConnection(t, prov, service.V2)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn", WithTimeout(time.Millisecond))
+		require.ErrorIs(t, err, ErrTimeout)
+
+		_, err = svc.Disclosures("conn")
+		require.ErrorIs(t, err, ErrNoDisclosures)
+	})
+
+	t.Run("duplicate disclose doesn't block", func(t *testing.T) {
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		responseCh := make(chan []Disclosure, 1)
+		svc.setResponseCh("query", responseCh)
+
+		defer svc.setResponseCh("query", nil)
+
+		for i := 0; i < 2; i++ {
+			err = svc.handleDisclose(service.NewDIDCommMsgMap(&DiscloseV2{
+				ID:       "disclose",
+				Type:     DiscloseMsgTypeV2,
+				ThreadID: "query",
+				Body: DiscloseV2Body{
+					Disclosures: []Disclosure{{FeatureType: FeatureTypeGoalCode, ID: "issue-vc"}},
+				},
+			}))
+			require.NoError(t, err)
+		}
+
+		require.Len(t, <-responseCh, 1)
+	})
+}
+
+func TestMatchFeatures(t *testing.T) {
+	features := []Disclosure{
+		{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust_ping/1.0"},
+		{FeatureType: FeatureTypeGoalCode, ID: "https://didcomm.org/trust_ping/1.0"},
+	}
+
+	require.Len(t, matchFeatures(features, FeatureQuery{FeatureType: FeatureTypeProtocol, Match: "*"}), 1)
+	require.Len(t, matchFeatures(features, FeatureQuery{FeatureType: FeatureTypeProtocol, Match: "https://*"}), 1)
+	require.Empty(t, matchFeatures(features, FeatureQuery{FeatureType: FeatureTypeProtocol, Match: "https://"}))
+	require.Empty(t, matchFeatures(features))
+}
+
+type customService struct {
+	trustping.Service
+}
+
+func (c *customService) Accept(string) bool {
+	return false
+}
+
+func (c *customService) DisclosedProtocols() []string {
+	return []string{customPID + "/"}
+}
+
+// newConnectedServices returns the services of two agents connected by connection `conn`, their outbound messages
+// are delivered to each other.
+func newConnectedServices(t *testing.T, version service.Version) (*Service, *Service) {
+	t.Helper()
+
+	var alice, bob *Service
+
+	aliceProv := newProvider(&mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, err := bob.HandleInbound(msg.(service.DIDCommMsgMap), service.NewDIDCommContext(theirDID, myDID, nil))
+
+			return err
+		},
+	})
+	saveConnection(t, aliceProv, version)
+
+	bobProv := newProvider(&mockdispatcher.MockOutbound{
+		ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+			_, err := alice.HandleInbound(msg.(service.DIDCommMsgMap), service.NewDIDCommContext(theirDID, myDID, nil))
+
+			return err
+		},
+	})
+
+	var err error
+
+	alice, err = New(aliceProv)
+	require.NoError(t, err)
+
+	bob, err = New(bobProv, WithGoalCodes("issue-vc"))
+	require.NoError(t, err)
+
+	bobProv.ServiceMap = map[string]interface{}{
+		DiscoverFeatures:    bob,
+		trustping.TrustPing: &trustping.Service{},
+	}
+
+	return alice, bob
+}
+
+func newProvider(outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+		MediaTypeProfilesValue:            []string{"didcomm/v2"},
+	}
+}
+
+func saveConnection(t *testing.T, prov *mockprovider.Provider, version service.Version) {
+	t.Helper()
+
+	r, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	err = r.SaveConnectionRecord(&connection.Record{
+		ConnectionID:   "conn",
+		MyDID:          MYDID,
+		TheirDID:       THEIRDID,
+		State:          connection.StateNameCompleted,
+		DIDCommVersion: version,
+	})
+	require.NoError(t, err)
+}
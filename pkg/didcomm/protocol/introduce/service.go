@@ -882,3 +882,9 @@ func (s *Service) Accept(msgType string) bool {
 
 	return false
 }
+
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{IntroduceSpec}
+}
@@ -905,6 +905,12 @@ func (s *Service) Accept(msgType string) bool {
 	return false
 }
 
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{SpecV2, SpecV3}
+}
+
 // redirectInfo reads web redirect info decorator from given DIDComm Msg.
 func redirectInfo(msg service.DIDCommMsg) map[string]interface{} {
 	var redirectInfo struct {
@@ -298,6 +298,12 @@ func (s *Service) Accept(msgType string) bool {
 		msgType == AckMsgType
 }
 
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{PIURI}
+}
+
 // HandleOutbound handles outbound connection messages.
 func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
 	return "", errors.New("not implemented")
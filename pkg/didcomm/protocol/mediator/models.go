@@ -56,3 +56,149 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// Deny route deny message, sent by the mediator when it rejects the route request.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#mediation-deny
+type Deny struct {
+	Type string `json:"@type,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
+
+// KeylistQuery route keylist query message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#key-list-query
+type KeylistQuery struct {
+	Type     string    `json:"@type,omitempty"`
+	ID       string    `json:"@id,omitempty"`
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// Paginate keylist query pagination request.
+type Paginate struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// Keylist route keylist message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#key-list
+type Keylist struct {
+	Type       string       `json:"@type,omitempty"`
+	ID         string       `json:"@id,omitempty"`
+	Keys       []KeylistKey `json:"keys"`
+	Pagination *Pagination  `json:"pagination,omitempty"`
+}
+
+// KeylistKey route keylist entry.
+type KeylistKey struct {
+	RecipientKey string `json:"recipient_key,omitempty"`
+}
+
+// Pagination keylist pagination details.
+type Pagination struct {
+	Count     int `json:"count"`
+	Offset    int `json:"offset"`
+	Remaining int `json:"remaining"`
+}
+
+// RequestV2 mediate request message of the coordinate mediation 2.0 protocol.
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-request
+type RequestV2 struct {
+	ID   string                 `json:"id,omitempty"`
+	Type string                 `json:"type,omitempty"`
+	Body map[string]interface{} `json:"body"`
+}
+
+// GrantV2 mediate grant message of the coordinate mediation 2.0 protocol.
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-grant
+type GrantV2 struct {
+	ID       string      `json:"id,omitempty"`
+	Type     string      `json:"type,omitempty"`
+	ThreadID string      `json:"thid,omitempty"`
+	Body     GrantV2Body `json:"body"`
+}
+
+// GrantV2Body is the body of the coordinate mediation 2.0 mediate grant message.
+type GrantV2Body struct {
+	RoutingDID []string `json:"routing_did"`
+}
+
+// DenyV2 mediate deny message of the coordinate mediation 2.0 protocol.
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-deny
+type DenyV2 struct {
+	ID       string                 `json:"id,omitempty"`
+	Type     string                 `json:"type,omitempty"`
+	ThreadID string                 `json:"thid,omitempty"`
+	Body     map[string]interface{} `json:"body"`
+}
+
+// KeylistUpdateV2 keylist update message of the coordinate mediation 2.0 protocol.
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update
+type KeylistUpdateV2 struct {
+	ID   string              `json:"id,omitempty"`
+	Type string              `json:"type,omitempty"`
+	Body KeylistUpdateV2Body `json:"body"`
+}
+
+// KeylistUpdateV2Body is the body of the coordinate mediation 2.0 keylist update message.
+type KeylistUpdateV2Body struct {
+	Updates []UpdateV2 `json:"updates"`
+}
+
+// UpdateV2 DID based route update of the coordinate mediation 2.0 protocol.
+type UpdateV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+}
+
+// KeylistUpdateResponseV2 keylist update response message of the coordinate mediation 2.0 protocol.
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update-response
+type KeylistUpdateResponseV2 struct {
+	ID       string                      `json:"id,omitempty"`
+	Type     string                      `json:"type,omitempty"`
+	ThreadID string                      `json:"thid,omitempty"`
+	Body     KeylistUpdateResponseV2Body `json:"body"`
+}
+
+// KeylistUpdateResponseV2Body is the body of the coordinate mediation 2.0 keylist update response message.
+type KeylistUpdateResponseV2Body struct {
+	Updated []UpdateResponseV2 `json:"updated"`
+}
+
+// UpdateResponseV2 DID based route update response of the coordinate mediation 2.0 protocol.
+type UpdateResponseV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Result       string `json:"result,omitempty"`
+}
+
+// KeylistQueryV2 keylist query message of the coordinate mediation 2.0 protocol.
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-query
+type KeylistQueryV2 struct {
+	ID   string             `json:"id,omitempty"`
+	Type string             `json:"type,omitempty"`
+	Body KeylistQueryV2Body `json:"body"`
+}
+
+// KeylistQueryV2Body is the body of the coordinate mediation 2.0 keylist query message.
+type KeylistQueryV2Body struct {
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// KeylistV2 keylist message of the coordinate mediation 2.0 protocol.
+// https://didcomm.org/coordinate-mediation/2.0/#keylist
+type KeylistV2 struct {
+	ID       string        `json:"id,omitempty"`
+	Type     string        `json:"type,omitempty"`
+	ThreadID string        `json:"thid,omitempty"`
+	Body     KeylistV2Body `json:"body"`
+}
+
+// KeylistV2Body is the body of the coordinate mediation 2.0 keylist message.
+type KeylistV2Body struct {
+	Keys       []KeylistKeyV2 `json:"keys"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+// KeylistKeyV2 DID based keylist entry of the coordinate mediation 2.0 protocol.
+type KeylistKeyV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
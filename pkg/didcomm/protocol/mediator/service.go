@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package mediator
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -53,6 +57,48 @@ const (
 
 	// KeyListUpdateResponseMsgType defines the route coordination key list update message response type.
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
+
+	// DenyMsgType defines the route coordination request deny message type.
+	DenyMsgType = CoordinationSpec + "mediate-deny"
+
+	// KeylistQueryMsgType defines the route coordination key list query message type.
+	KeylistQueryMsgType = CoordinationSpec + "keylist_query"
+
+	// KeylistMsgType defines the route coordination key list message type.
+	KeylistMsgType = CoordinationSpec + "keylist"
+)
+
+// routing protocols of the forward messages handled by the router.
+const (
+	routingSpec   = "https://didcomm.org/routing/1.0/"
+	routingSpecV2 = "https://didcomm.org/routing/2.0/"
+)
+
+// constants for coordinate mediation 2.0 spec types.
+const (
+	// CoordinationSpecV2 defines the coordinate mediation 2.0 spec.
+	CoordinationSpecV2 = "https://didcomm.org/coordinate-mediation/2.0/"
+
+	// RequestMsgTypeV2 defines the coordinate mediation 2.0 request message type.
+	RequestMsgTypeV2 = CoordinationSpecV2 + "mediate-request"
+
+	// GrantMsgTypeV2 defines the coordinate mediation 2.0 grant message type.
+	GrantMsgTypeV2 = CoordinationSpecV2 + "mediate-grant"
+
+	// DenyMsgTypeV2 defines the coordinate mediation 2.0 deny message type.
+	DenyMsgTypeV2 = CoordinationSpecV2 + "mediate-deny"
+
+	// KeylistUpdateMsgTypeV2 defines the coordinate mediation 2.0 key list update message type.
+	KeylistUpdateMsgTypeV2 = CoordinationSpecV2 + "keylist-update"
+
+	// KeylistUpdateResponseMsgTypeV2 defines the coordinate mediation 2.0 key list update response message type.
+	KeylistUpdateResponseMsgTypeV2 = CoordinationSpecV2 + "keylist-update-response"
+
+	// KeylistQueryMsgTypeV2 defines the coordinate mediation 2.0 key list query message type.
+	KeylistQueryMsgTypeV2 = CoordinationSpecV2 + "keylist-query"
+
+	// KeylistMsgTypeV2 defines the coordinate mediation 2.0 key list message type.
+	KeylistMsgTypeV2 = CoordinationSpecV2 + "keylist"
 )
 
 // constants for key list update processing
@@ -67,6 +113,12 @@ const (
 	// server error while storing the key.
 	serverError = "server_error"
 
+	// the key is routed for another agent.
+	clientError = "client_error"
+
+	// the key was not routed.
+	noChange = "no_change"
+
 	// key save success.
 	success = "success"
 )
@@ -79,6 +131,9 @@ const (
 	routeConfigDataKey = "route_config_%s"
 
 	routeGrantKey = "grant_%s"
+
+	// tag of the route keys, the value identifies the agent the keys are routed for.
+	routeKeyTagName = "route_key"
 )
 
 const (
@@ -91,6 +146,9 @@ var ErrConnectionNotFound = errors.New("connection not found")
 // ErrRouterNotRegistered router not registered error.
 var ErrRouterNotRegistered = errors.New("router not registered")
 
+// ErrMediationDenied mediation denied error.
+var ErrMediationDenied = errors.New("mediation denied")
+
 // provider contains dependencies for the Routing protocol and is typically created by using aries.Context().
 type provider interface {
 	OutboundDispatcher() dispatcher.Outbound
@@ -110,6 +168,8 @@ type ClientOption func(opts *ClientOptions)
 // ClientOptions holds options for the router client.
 type ClientOptions struct {
 	Timeout time.Duration
+	// CoordinationV2 registers with the router using the coordinate mediation 2.0 protocol.
+	CoordinationV2 bool
 }
 
 // Options is a container for route protocol options.
@@ -129,6 +189,7 @@ type callback struct {
 type routerConnectionEntry struct {
 	ConnectionID   string          `json:"connectionID"`
 	DIDCommVersion service.Version `json:"didcomm_version,omitempty"`
+	CoordinationV2 bool            `json:"coordination_v2,omitempty"`
 }
 
 type connections interface {
@@ -150,6 +211,8 @@ type Service struct {
 	vdRegistry           vdr.Registry
 	keylistUpdateMap     map[string]chan *KeylistUpdateResponse
 	keylistUpdateMapLock sync.RWMutex
+	keylistMap           map[string]chan *Keylist
+	keylistMapLock       sync.RWMutex
 	callbacks            chan *callback
 	messagePickupSvc     messagepickup.ProtocolService
 	keyAgreementType     kms.KeyType
@@ -186,7 +249,7 @@ func (s *Service) Initialize(p interface{}) error {
 	}
 
 	err = prov.StorageProvider().SetStoreConfig(Coordination,
-		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey}})
+		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey, routeKeyTagName}})
 	if err != nil {
 		return fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -213,6 +276,7 @@ func (s *Service) Initialize(p interface{}) error {
 	s.vdRegistry = prov.VDRegistry()
 	s.connectionLookup = connectionLookup
 	s.keylistUpdateMap = make(map[string]chan *KeylistUpdateResponse)
+	s.keylistMap = make(map[string]chan *Keylist)
 	s.callbacks = make(chan *callback)
 	s.messagePickupSvc = messagePickupSvc
 	s.keyAgreementType = prov.KeyAgreementType()
@@ -238,7 +302,7 @@ func (s *Service) listenForCallbacks() {
 		}
 
 		switch c.msg.Type() {
-		case RequestMsgType:
+		case RequestMsgType, RequestMsgTypeV2:
 			err := s.handleInboundRequest(c)
 			if err != nil {
 				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
@@ -251,10 +315,29 @@ func (s *Service) listenForCallbacks() {
 
 func (s *Service) handleUserRejection(c *callback) {
 	logger.Infof("user aborted response action for msgID=%s", c.msg.ID())
+
+	var deny interface{} = &Deny{
+		ID:   c.msg.ID(),
+		Type: DenyMsgType,
+	}
+
+	if c.msg.Type() == RequestMsgTypeV2 {
+		deny = &DenyV2{
+			ID:       uuid.New().String(),
+			Type:     DenyMsgTypeV2,
+			ThreadID: c.msg.ID(),
+			Body:     map[string]interface{}{},
+		}
+	}
+
+	err := s.outbound.SendToDID(service.NewDIDCommMsgMap(deny), c.myDID, c.theirDID)
+	if err != nil {
+		logger.Errorf("failed to send mediate deny for msgID=%s : %s", c.msg.ID(), err)
+	}
 }
 
 func triggersActionEvent(msgType string) bool {
-	return msgType == RequestMsgType
+	return msgType == RequestMsgType || msgType == RequestMsgTypeV2
 }
 
 func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string) error {
@@ -311,12 +394,16 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		var err error
 
 		switch msg.Type() {
-		case GrantMsgType:
+		case GrantMsgType, GrantMsgTypeV2, DenyMsgType, DenyMsgTypeV2:
 			err = s.saveGrant(msg)
-		case KeylistUpdateMsgType:
+		case KeylistUpdateMsgType, KeylistUpdateMsgTypeV2:
 			err = s.handleKeylistUpdate(msg, ctx.MyDID(), ctx.TheirDID())
-		case KeylistUpdateResponseMsgType:
+		case KeylistUpdateResponseMsgType, KeylistUpdateResponseMsgTypeV2:
 			err = s.handleKeylistUpdateResponse(msg)
+		case KeylistQueryMsgType, KeylistQueryMsgTypeV2:
+			err = s.handleKeylistQuery(msg, ctx.MyDID(), ctx.TheirDID())
+		case KeylistMsgType, KeylistMsgTypeV2:
+			err = s.handleKeylist(msg)
 		case service.ForwardMsgType, service.ForwardMsgTypeV2:
 			err = s.handleForward(msg)
 		}
@@ -358,7 +445,7 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 	}
 
 	switch msg.Type() {
-	case RequestMsgType:
+	case RequestMsgType, RequestMsgTypeV2:
 		return "", s.handleOutboundRequest(msg, myDID, theirDID)
 	default:
 		return "", fmt.Errorf("invalid or unsupported outbound message type %s", msg.Type())
@@ -368,14 +455,22 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType,
-		service.ForwardMsgTypeV2:
+	case RequestMsgType, GrantMsgType, DenyMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType,
+		KeylistQueryMsgType, KeylistMsgType, service.ForwardMsgType, service.ForwardMsgTypeV2,
+		RequestMsgTypeV2, GrantMsgTypeV2, DenyMsgTypeV2, KeylistUpdateMsgTypeV2, KeylistUpdateResponseMsgTypeV2,
+		KeylistQueryMsgTypeV2, KeylistMsgTypeV2:
 		return true
 	}
 
 	return false
 }
 
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{CoordinationSpec, CoordinationSpecV2, routingSpec, routingSpecV2}
+}
+
 // Name of the service.
 func (s *Service) Name() string {
 	return Coordination
@@ -388,12 +483,14 @@ func (s *Service) handleInboundRequest(c *callback) error {
 	// unmarshal the payload
 	request := &Request{}
 
-	err := c.msg.Decode(request)
-	if err != nil {
+	if c.msg.Type() == RequestMsgTypeV2 {
+		// coordinate mediation 2.0 is only defined for DIDComm v2
+		request.DIDCommV2 = true
+	} else if err := c.msg.Decode(request); err != nil {
 		return fmt.Errorf("handleInboundRequest: route request message unmarshal : %w", err)
 	}
 
-	err = validateRequestVersion(s.mediaTypeProfiles, request.DIDCommV2)
+	err := validateRequestVersion(s.mediaTypeProfiles, request.DIDCommV2)
 	if err != nil {
 		return err
 	}
@@ -428,6 +525,16 @@ func (s *Service) handleInboundRequest(c *callback) error {
 		return fmt.Errorf("handleInboundRequest: failed to handle inbound request : %w", err)
 	}
 
+	if c.msg.Type() == RequestMsgTypeV2 {
+		// the routing keys are did:key DIDs, the recipient resolves the endpoint from its mediator connection
+		return s.outbound.SendToDID(service.NewDIDCommMsgMap(&GrantV2{
+			ID:       uuid.New().String(),
+			Type:     GrantMsgTypeV2,
+			ThreadID: c.msg.ID(),
+			Body:     GrantV2Body{RoutingDID: grant.RoutingKeys},
+		}), c.myDID, c.theirDID)
+	}
+
 	return s.outbound.SendToDID(service.NewDIDCommMsgMap(grant), c.myDID, c.theirDID)
 }
 
@@ -483,9 +590,7 @@ func outboundGrant(
 
 func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID string) error {
 	// unmarshal the payload
-	keyUpdate := &KeylistUpdate{}
-
-	err := msg.Decode(keyUpdate)
+	keyUpdate, err := decodeKeylistUpdate(msg)
 	if err != nil {
 		return fmt.Errorf("route key list update message unmarshal : %w", err)
 	}
@@ -494,53 +599,154 @@ func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID st
 
 	// update the db
 	for _, v := range keyUpdate.Updates {
-		if v.Action == add {
-			val := theirDID
-			result := success
-
-			toKey := dataKey(v.RecipientKey)
-
-			err = s.routeStore.Put(toKey, []byte(val))
-			if err != nil {
-				logger.Errorf("failed to add the route key to store : %s", err)
+		var result string
 
-				result = serverError
-			}
-
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
-				Action:       v.Action,
-				Result:       result,
-			})
-		} else if v.Action == remove {
-			// TODO remove from the store
-
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
-				Action:       v.Action,
-				Result:       serverError,
-			})
+		switch v.Action {
+		case add:
+			result = s.addRouteKey(v.RecipientKey, theirDID)
+		case remove:
+			result = s.removeRouteKey(v.RecipientKey, theirDID)
+		default:
+			continue
 		}
+
+		// construct the response doc
+		updates = append(updates, UpdateResponse{
+			RecipientKey: v.RecipientKey,
+			Action:       v.Action,
+			Result:       result,
+		})
 	}
 
 	// send the key update response
-	updateResponse := &KeylistUpdateResponse{
+	var updateResponse interface{} = &KeylistUpdateResponse{
 		Type:    KeylistUpdateResponseMsgType,
 		ID:      msg.ID(),
 		Updated: updates,
 	}
 
+	if msg.Type() == KeylistUpdateMsgTypeV2 {
+		updated := make([]UpdateResponseV2, len(updates))
+
+		for i, u := range updates {
+			updated[i] = UpdateResponseV2{RecipientDID: u.RecipientKey, Action: u.Action, Result: u.Result}
+		}
+
+		updateResponse = &KeylistUpdateResponseV2{
+			ID:       uuid.New().String(),
+			Type:     KeylistUpdateResponseMsgTypeV2,
+			ThreadID: msg.ID(),
+			Body:     KeylistUpdateResponseV2Body{Updated: updated},
+		}
+	}
+
 	return s.outbound.SendToDID(service.NewDIDCommMsgMap(updateResponse), myDID, theirDID)
 }
 
+// decodeKeylistUpdate decodes a key list update of either protocol version, coordinate mediation 2.0 recipient
+// DIDs are mapped to recipient keys.
+func decodeKeylistUpdate(msg service.DIDCommMsg) (*KeylistUpdate, error) {
+	keyUpdate := &KeylistUpdate{}
+
+	if msg.Type() != KeylistUpdateMsgTypeV2 {
+		return keyUpdate, msg.Decode(keyUpdate)
+	}
+
+	keyUpdateV2 := &KeylistUpdateV2{}
+
+	err := msg.Decode(keyUpdateV2)
+	if err != nil {
+		return nil, err
+	}
+
+	keyUpdate.ID = keyUpdateV2.ID
+	keyUpdate.Type = keyUpdateV2.Type
+
+	for _, u := range keyUpdateV2.Body.Updates {
+		keyUpdate.Updates = append(keyUpdate.Updates, Update{RecipientKey: u.RecipientDID, Action: u.Action})
+	}
+
+	return keyUpdate, nil
+}
+
+func (s *Service) addRouteKey(recKey, theirDID string) string {
+	owner, err := s.getRouteOwner(recKey)
+	if err == nil {
+		// a route can't be taken over by another connection
+		if string(owner) != theirDID {
+			return clientError
+		}
+
+		return noChange
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		logger.Errorf("failed to get the route key from store : %s", err)
+
+		return serverError
+	}
+
+	err = s.routeStore.Put(dataKey(recKey), []byte(theirDID),
+		storage.Tag{Name: routeKeyTagName, Value: didTag(theirDID)})
+	if err != nil {
+		logger.Errorf("failed to add the route key to store : %s", err)
+
+		return serverError
+	}
+
+	return success
+}
+
+func (s *Service) removeRouteKey(recKey, theirDID string) string {
+	owner, err := s.routeStore.Get(dataKey(recKey))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return noChange
+	}
+
+	if err != nil {
+		logger.Errorf("failed to get the route key from store : %s", err)
+
+		return serverError
+	}
+
+	if string(owner) != theirDID {
+		return clientError
+	}
+
+	err = s.routeStore.Delete(dataKey(recKey))
+	if err != nil {
+		logger.Errorf("failed to remove the route key from store : %s", err)
+
+		return serverError
+	}
+
+	return success
+}
+
 func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	respMsg := &KeylistUpdateResponse{}
 
-	err := msg.Decode(respMsg)
-	if err != nil {
+	if msg.Type() == KeylistUpdateResponseMsgTypeV2 {
+		respMsgV2 := &KeylistUpdateResponseV2{}
+
+		err := msg.Decode(respMsgV2)
+		if err != nil {
+			return fmt.Errorf("route keylist update response message unmarshal : %w", err)
+		}
+
+		// coordinate mediation 2.0 responses are threaded to the update
+		respMsg.ID = respMsgV2.ThreadID
+		respMsg.Type = respMsgV2.Type
+
+		for _, u := range respMsgV2.Body.Updated {
+			respMsg.Updated = append(respMsg.Updated, UpdateResponse{
+				RecipientKey: u.RecipientDID,
+				Action:       u.Action,
+				Result:       u.Result,
+			})
+		}
+	} else if err := msg.Decode(respMsg); err != nil {
 		return fmt.Errorf("route keylist update response message unmarshal : %w", err)
 	}
 
@@ -555,6 +761,189 @@ func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	return nil
 }
 
+func (s *Service) handleKeylistQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	var paginate *Paginate
+
+	if msg.Type() == KeylistQueryMsgTypeV2 {
+		query := &KeylistQueryV2{}
+
+		err := msg.Decode(query)
+		if err != nil {
+			return fmt.Errorf("route keylist query message unmarshal : %w", err)
+		}
+
+		paginate = query.Body.Paginate
+	} else {
+		query := &KeylistQuery{}
+
+		err := msg.Decode(query)
+		if err != nil {
+			return fmt.Errorf("route keylist query message unmarshal : %w", err)
+		}
+
+		paginate = query.Paginate
+	}
+
+	keys, err := s.getRouteKeys(theirDID)
+	if err != nil {
+		return fmt.Errorf("route keylist query : %w", err)
+	}
+
+	page, pagination := paginateKeys(keys, paginate)
+
+	if msg.Type() == KeylistQueryMsgTypeV2 {
+		keylist := &KeylistV2{
+			ID:       uuid.New().String(),
+			Type:     KeylistMsgTypeV2,
+			ThreadID: msg.ID(),
+			Body:     KeylistV2Body{Keys: []KeylistKeyV2{}, Pagination: pagination},
+		}
+
+		for _, k := range page {
+			keylist.Body.Keys = append(keylist.Body.Keys, KeylistKeyV2{RecipientDID: k})
+		}
+
+		return s.outbound.SendToDID(service.NewDIDCommMsgMap(keylist), myDID, theirDID)
+	}
+
+	keylist := &Keylist{
+		ID:         msg.ID(),
+		Type:       KeylistMsgType,
+		Keys:       []KeylistKey{},
+		Pagination: pagination,
+	}
+
+	for _, k := range page {
+		keylist.Keys = append(keylist.Keys, KeylistKey{RecipientKey: k})
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(keylist), myDID, theirDID)
+}
+
+func paginateKeys(keys []string, paginate *Paginate) ([]string, *Pagination) {
+	offset, end := 0, len(keys)
+
+	if paginate != nil {
+		if paginate.Offset > 0 {
+			offset = paginate.Offset
+		}
+
+		if offset > len(keys) {
+			offset = len(keys)
+		}
+
+		if paginate.Limit > 0 && offset+paginate.Limit < end {
+			end = offset + paginate.Limit
+		}
+	}
+
+	return keys[offset:end], &Pagination{
+		Count:     end - offset,
+		Offset:    offset,
+		Remaining: len(keys) - end,
+	}
+}
+
+func (s *Service) handleKeylist(msg service.DIDCommMsg) error {
+	keylist := &Keylist{}
+
+	if msg.Type() == KeylistMsgTypeV2 {
+		keylistV2 := &KeylistV2{}
+
+		err := msg.Decode(keylistV2)
+		if err != nil {
+			return fmt.Errorf("route keylist message unmarshal : %w", err)
+		}
+
+		// coordinate mediation 2.0 responses are threaded to the query
+		keylist.ID = keylistV2.ThreadID
+		keylist.Type = keylistV2.Type
+		keylist.Pagination = keylistV2.Body.Pagination
+
+		for _, k := range keylistV2.Body.Keys {
+			keylist.Keys = append(keylist.Keys, KeylistKey{RecipientKey: k.RecipientDID})
+		}
+	} else if err := msg.Decode(keylist); err != nil {
+		return fmt.Errorf("route keylist message unmarshal : %w", err)
+	}
+
+	keylistCh := s.getKeylistCh(keylist.ID)
+	if keylistCh == nil {
+		return nil
+	}
+
+	// the requester only waits for the first keylist, late and duplicate ones are dropped
+	select {
+	case keylistCh <- keylist:
+	default:
+		logger.Warnf("dropping keylist for thread %s, the requester isn't waiting for it", keylist.ID)
+	}
+
+	return nil
+}
+
+// getRouteOwner returns the DID of the agent the key is routed for. Route keys saved before they were tagged with
+// their agent are tagged when they are first used, keylist queries can't list them before.
+func (s *Service) getRouteOwner(recKey string) ([]byte, error) {
+	owner, err := s.routeStore.Get(dataKey(recKey))
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.routeStore.GetTags(dataKey(recKey))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if tag.Name == routeKeyTagName {
+			return owner, nil
+		}
+	}
+
+	err = s.routeStore.Put(dataKey(recKey), owner, storage.Tag{Name: routeKeyTagName, Value: didTag(string(owner))})
+	if err != nil {
+		return nil, fmt.Errorf("tag route key: %w", err)
+	}
+
+	return owner, nil
+}
+
+// getRouteKeys returns the sorted keys routed for the given agent.
+func (s *Service) getRouteKeys(theirDID string) ([]string, error) {
+	records, err := s.routeStore.Query(fmt.Sprintf("%s:%s", routeKeyTagName, didTag(theirDID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query route store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	var keys []string
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		key, err := records.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key from records: %w", err)
+		}
+
+		keys = append(keys, strings.TrimPrefix(key, dataKey("")))
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
 func (s *Service) handleForward(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	forward := &model.Forward{}
@@ -567,9 +956,12 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 	// TODO Open question - https://github.com/hyperledger/aries-framework-go/issues/965 Mismatch between Route
 	//  Coordination and Forward RFC. For now assume, the TO field contains the recipient key (DIDComm V2 uses
 	//  keyAgreement.ID, double check if this to do comment is still needed).
-	toKey := dataKey(forward.To)
+	theirDID, err := s.getRouteOwner(forward.To)
+	if errors.Is(err, storage.ErrDataNotFound) && strings.Contains(forward.To, "#") {
+		// coordinate mediation 2.0 routes recipient DIDs, while the forward is addressed to one of the DID's keys
+		theirDID, err = s.getRouteOwner(strings.Split(forward.To, "#")[0])
+	}
 
-	theirDID, err := s.routeStore.Get(toKey)
 	if err != nil {
 		return fmt.Errorf("route key fetch : %w", err)
 	}
@@ -581,12 +973,22 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 
 	err = s.outbound.Forward(forward.Msg, dest)
 	if err != nil && s.messagePickupSvc != nil {
-		return s.messagePickupSvc.AddMessage(forward.Msg, string(theirDID))
+		return s.messagePickupSvc.AddMessageForRecipient(forward.Msg, string(theirDID), forwardRecipientDID(forward.To))
 	}
 
 	return err
 }
 
+// forwardRecipientDID returns the DID a forward message is addressed to, or an empty string if it's addressed to a
+// raw key.
+func forwardRecipientDID(to string) string {
+	if !strings.HasPrefix(to, "did:") {
+		return ""
+	}
+
+	return strings.Split(to, "#")[0]
+}
+
 // Register registers the agent with the router on the other end of the connection identified by
 // connectionID. This method blocks until a response is received from the router or it times out.
 // The agent is registered with the router and retrieves the router endpoint and routing keys.
@@ -607,10 +1009,12 @@ func (s *Service) Register(connectionID string, options ...ClientOption) error {
 			Timing: decorator.Timing{},
 		},
 		opts.Timeout,
+		opts.CoordinationV2,
 	)
 }
 
-func (s *Service) doRegistration(record *connection.Record, req *Request, timeout time.Duration) error {
+func (s *Service) doRegistration(record *connection.Record, req *Request, timeout time.Duration,
+	coordinationV2 bool) error {
 	// check if router is already registered
 	err := s.ensureConnectionExists(record.ConnectionID)
 	if err == nil {
@@ -621,16 +1025,32 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 		return fmt.Errorf("ensure connection exists: %w", err)
 	}
 
-	// TODO: would this be better served as time.Now().Add(timeout).Unix() as pkg/doc/verifiable/credential.go
-	// demonstrates? additionally `ExpiresTime` would need to be migrated to int64
-	req.ExpiresTime = time.Now().UTC().Add(timeout)
+	var msg service.DIDCommMsgMap
 
-	if record.DIDCommVersion == service.V2 {
-		req.DIDCommV2 = true
+	if coordinationV2 {
+		if record.DIDCommVersion != service.V2 {
+			return errors.New("coordinate mediation 2.0 requires a DIDComm v2 connection")
+		}
+
+		msg = service.NewDIDCommMsgMap(&RequestV2{
+			ID:   req.ID,
+			Type: RequestMsgTypeV2,
+			Body: map[string]interface{}{},
+		})
+	} else {
+		// TODO: would this be better served as time.Now().Add(timeout).Unix() as pkg/doc/verifiable/credential.go
+		// demonstrates? additionally `ExpiresTime` would need to be migrated to int64
+		req.ExpiresTime = time.Now().UTC().Add(timeout)
+
+		if record.DIDCommVersion == service.V2 {
+			req.DIDCommV2 = true
+		}
+
+		msg = service.NewDIDCommMsgMap(req)
 	}
 
 	// send message to the router
-	if err = s.outbound.SendToDID(service.NewDIDCommMsgMap(req), record.MyDID, record.TheirDID); err != nil {
+	if err = s.outbound.SendToDID(msg, record.MyDID, record.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
 
@@ -640,6 +1060,15 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 		return fmt.Errorf("get grant for request ID '%s': %w", req.ID, err)
 	}
 
+	if coordinationV2 && grant.Endpoint == "" {
+		// coordinate mediation 2.0 grants only carry the routing DIDs, the router is reached at the endpoint
+		// of the mediator connection
+		grant.Endpoint, err = s.routerEndpoint(record.TheirDID)
+		if err != nil {
+			return fmt.Errorf("get router endpoint : %w", err)
+		}
+	}
+
 	err = s.saveRouterConfig(record.ConnectionID, &config{
 		RouterEndpoint: grant.Endpoint,
 		RoutingKeys:    grant.RoutingKeys,
@@ -651,7 +1080,20 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 	logger.Debugf("saved router config from inbound grant: %+v", grant)
 
 	// save the connectionID of the router
-	return s.saveRouterConnectionID(record.ConnectionID, record.DIDCommVersion)
+	return s.saveRouterConnectionEntry(&routerConnectionEntry{
+		ConnectionID:   record.ConnectionID,
+		DIDCommVersion: record.DIDCommVersion,
+		CoordinationV2: coordinationV2,
+	})
+}
+
+func (s *Service) routerEndpoint(routerDID string) (string, error) {
+	dest, err := service.GetDestination(routerDID, s.vdRegistry)
+	if err != nil {
+		return "", fmt.Errorf("get destination : %w", err)
+	}
+
+	return dest.ServiceEndpoint.URI()
 }
 
 func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
@@ -670,6 +1112,29 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 		return nil, fmt.Errorf("store: %w", err)
 	}
 
+	msg, err := service.ParseDIDCommMsgMap(src)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal grant: %w", err)
+	}
+
+	switch msg.Type() {
+	case DenyMsgType, DenyMsgTypeV2:
+		return nil, ErrMediationDenied
+	case GrantMsgTypeV2:
+		grantV2 := &GrantV2{}
+
+		err = msg.Decode(grantV2)
+		if err != nil {
+			return nil, fmt.Errorf("decode grant: %w", err)
+		}
+
+		return &Grant{
+			ID:          grantV2.ThreadID,
+			Type:        grantV2.Type,
+			RoutingKeys: grantV2.Body.RoutingDID,
+		}, nil
+	}
+
 	var grant *Grant
 
 	err = json.Unmarshal(src, &grant)
@@ -680,13 +1145,19 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 	return grant, nil
 }
 
+// saveGrant saves the mediate grant or deny received in response to the request with the message thread ID.
 func (s *Service) saveGrant(grant service.DIDCommMsg) error {
 	src, err := json.Marshal(grant)
 	if err != nil {
 		return fmt.Errorf("marshal grant: %w", err)
 	}
 
-	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID()), src)
+	thID, err := grant.ThreadID()
+	if err != nil {
+		return fmt.Errorf("grant thread ID: %w", err)
+	}
+
+	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, thID), src)
 }
 
 // Unregister unregisters the agent with the router.
@@ -759,7 +1230,7 @@ func (s *Service) GetConnections(options ...ConnectionOption) ([]string, error)
 //  recKeys to the Router
 func (s *Service) AddKey(connID, recKey string) error {
 	// check if router is already registered
-	err := s.ensureConnectionExists(connID)
+	entry, err := s.getRouterConnectionEntry(connID)
 	if err != nil {
 		return fmt.Errorf("ensure connection exists: %w", err)
 	}
@@ -777,7 +1248,7 @@ func (s *Service) AddKey(connID, recKey string) error {
 	keyUpdateCh := make(chan *KeylistUpdateResponse)
 	s.setKeyUpdateResponseCh(msgID, keyUpdateCh)
 
-	keyUpdate := &KeylistUpdate{
+	var keyUpdate interface{} = &KeylistUpdate{
 		ID:   msgID,
 		Type: KeylistUpdateMsgType,
 		Updates: []Update{
@@ -788,6 +1259,19 @@ func (s *Service) AddKey(connID, recKey string) error {
 		},
 	}
 
+	if entry.CoordinationV2 {
+		keyUpdate = &KeylistUpdateV2{
+			ID:   msgID,
+			Type: KeylistUpdateMsgTypeV2,
+			Body: KeylistUpdateV2Body{Updates: []UpdateV2{
+				{
+					RecipientDID: recKey,
+					Action:       add,
+				},
+			}},
+		}
+	}
+
 	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(keyUpdate), conn.MyDID, conn.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
@@ -807,6 +1291,56 @@ func (s *Service) AddKey(connID, recKey string) error {
 	return nil
 }
 
+// KeylistQuery queries the keys the registered router routes for the agent. This method blocks until a response
+// is received from the router or it times out. The whole key list is requested when paginate is nil.
+func (s *Service) KeylistQuery(connID string, paginate *Paginate) (*Keylist, error) {
+	// check if router is already registered
+	entry, err := s.getRouterConnectionEntry(connID)
+	if err != nil {
+		return nil, fmt.Errorf("ensure connection exists: %w", err)
+	}
+
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	// generate message ID
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	keylistCh := make(chan *Keylist, 1)
+	s.setKeylistCh(msgID, keylistCh)
+
+	defer s.setKeylistCh(msgID, nil)
+
+	var query interface{} = &KeylistQuery{
+		ID:       msgID,
+		Type:     KeylistQueryMsgType,
+		Paginate: paginate,
+	}
+
+	if entry.CoordinationV2 {
+		query = &KeylistQueryV2{
+			ID:   msgID,
+			Type: KeylistQueryMsgTypeV2,
+			Body: KeylistQueryV2Body{Paginate: paginate},
+		}
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(query), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send keylist query: %w", err)
+	}
+
+	select {
+	case keylist := <-keylistCh:
+		return keylist, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for keylist from the router")
+	}
+}
+
 // Config fetches the router config - endpoint and routingKeys.
 func (s *Service) Config(connID string) (*Config, error) {
 	// check if router is already registered
@@ -845,6 +1379,44 @@ func (s *Service) setKeyUpdateResponseCh(msgID string, keyUpdateCh chan *Keylist
 	}
 }
 
+func (s *Service) getKeylistCh(msgID string) chan *Keylist {
+	s.keylistMapLock.RLock()
+	defer s.keylistMapLock.RUnlock()
+
+	return s.keylistMap[msgID]
+}
+
+func (s *Service) setKeylistCh(msgID string, keylistCh chan *Keylist) {
+	s.keylistMapLock.Lock()
+	defer s.keylistMapLock.Unlock()
+
+	if keylistCh == nil {
+		delete(s.keylistMap, msgID)
+	} else {
+		s.keylistMap[msgID] = keylistCh
+	}
+}
+
+func (s *Service) getRouterConnectionEntry(connID string) (*routerConnectionEntry, error) {
+	src, err := s.routeStore.Get(fmt.Sprintf(routeConnIDDataKey, connID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, ErrRouterNotRegistered
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &routerConnectionEntry{}
+
+	err = json.Unmarshal(src, entry)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal router connection entry: %w", err)
+	}
+
+	return entry, nil
+}
+
 func (s *Service) ensureConnectionExists(connID string) error {
 	_, err := s.routeStore.Get(fmt.Sprintf(routeConnIDDataKey, connID))
 	if errors.Is(err, storage.ErrDataNotFound) {
@@ -859,17 +1431,20 @@ func (s *Service) deleteRouterConnectionID(connID string) error {
 }
 
 func (s *Service) saveRouterConnectionID(connID string, didcommVersion service.Version) error {
-	data := &routerConnectionEntry{
+	return s.saveRouterConnectionEntry(&routerConnectionEntry{
 		ConnectionID:   connID,
 		DIDCommVersion: didcommVersion,
-	}
+	})
+}
 
+func (s *Service) saveRouterConnectionEntry(data *routerConnectionEntry) error {
 	dataBytes, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("marshalling router connection ID data: %w", err)
 	}
 
-	return s.routeStore.Put(fmt.Sprintf(routeConnIDDataKey, connID), dataBytes, storage.Tag{Name: routeConnIDDataKey})
+	return s.routeStore.Put(fmt.Sprintf(routeConnIDDataKey, data.ConnectionID), dataBytes,
+		storage.Tag{Name: routeConnIDDataKey})
 }
 
 type config struct {
@@ -918,8 +1493,10 @@ func (s *Service) getConnection(routerConnID string) (*connection.Record, error)
 func (s *Service) handleOutboundRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
 	req := &Request{}
 
-	err := msg.Decode(req)
-	if err != nil {
+	coordinationV2 := msg.Type() == RequestMsgTypeV2
+	if coordinationV2 {
+		req.ID = msg.ID()
+	} else if err := msg.Decode(req); err != nil {
 		return fmt.Errorf("failed to decode request : %w", err)
 	}
 
@@ -929,13 +1506,21 @@ func (s *Service) handleOutboundRequest(msg service.DIDCommMsg, myDID, theirDID
 			myDID, theirDID, err)
 	}
 
-	return s.doRegistration(record, req, updateTimeout)
+	return s.doRegistration(record, req, updateTimeout, coordinationV2)
 }
 
 func dataKey(id string) string {
 	return "route-" + id
 }
 
+// didTag returns the tag value identifying the agent with the given DID; DIDs can't be used as is since the
+// storage query syntax reserves ':'.
+func didTag(theirDID string) string {
+	h := sha256.Sum256([]byte(theirDID))
+
+	return hex.EncodeToString(h[:])
+}
+
 func parseClientOpts(options ...ClientOption) *ClientOptions {
 	opts := &ClientOptions{
 		Timeout: updateTimeout,
@@ -19,10 +19,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher/outbound"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	mockmessagep "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/messagepickup"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
@@ -106,6 +109,16 @@ func TestServiceAccept(t *testing.T) {
 	require.Equal(t, true, s.Accept(KeylistUpdateMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateResponseMsgType))
 	require.Equal(t, true, s.Accept(service.ForwardMsgType))
+	require.Equal(t, true, s.Accept(DenyMsgType))
+	require.Equal(t, true, s.Accept(KeylistQueryMsgType))
+	require.Equal(t, true, s.Accept(KeylistMsgType))
+	require.Equal(t, true, s.Accept(RequestMsgTypeV2))
+	require.Equal(t, true, s.Accept(GrantMsgTypeV2))
+	require.Equal(t, true, s.Accept(DenyMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistUpdateMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistUpdateResponseMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistQueryMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistMsgTypeV2))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
 
@@ -424,8 +437,8 @@ func TestEvents(t *testing.T) {
 		}
 	})
 
-	t.Run("stopping inbound request event does not dispatch outbound grant", func(t *testing.T) {
-		dispatched := make(chan struct{})
+	t.Run("stopping inbound request event dispatches outbound deny", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsgMap)
 		svc, err := New(&mockprovider.Provider{
 			ServiceMap: map[string]interface{}{
 				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
@@ -435,7 +448,7 @@ func TestEvents(t *testing.T) {
 			KMSValue:                          &mockkms.KeyManager{},
 			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
 				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
-					dispatched <- struct{}{}
+					dispatched <- msg.(service.DIDCommMsgMap)
 					return nil
 				},
 			},
@@ -458,9 +471,11 @@ func TestEvents(t *testing.T) {
 		}
 
 		select {
-		case <-dispatched:
-			require.Fail(t, "stopping the protocol flow should not result in an outbound message dispatch")
+		case msg := <-dispatched:
+			require.Equal(t, DenyMsgType, msg.Type())
+			require.Equal(t, "123", msg.ID())
 		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
 		}
 	})
 
@@ -646,7 +661,7 @@ func TestServiceUpdateKeyListMsg(t *testing.T) {
 	t.Run("test service handle request msg - verify outbound message", func(t *testing.T) {
 		update := make(map[string]updateResult)
 		update["ABC"] = updateResult{action: add, result: success}
-		update["XYZ"] = updateResult{action: remove, result: serverError}
+		update["XYZ"] = updateResult{action: remove, result: noChange}
 		update[""] = updateResult{action: add, result: success}
 
 		svc, err := New(&mockprovider.Provider{
@@ -903,6 +918,104 @@ func TestMessagePickup(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test service handle inbound message pick up - recipient DID", func(t *testing.T) {
+		content := []byte(`{"ciphertext": "qQyzvajdvCDJbwxM"}`)
+		added := make(chan string, 1)
+
+		svc, err := New(
+			&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{
+						AddMessageForRecipientFunc: func(message []byte, theirDID, recipientDID string) error {
+							require.Equal(t, content, message)
+							require.Equal(t, "did:example:123", theirDID)
+							added <- recipientDID
+							return nil
+						},
+					},
+				},
+				StorageProviderValue:              mockstore.NewMockStoreProvider(),
+				ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+				KMSValue:                          &mockkms.KeyManager{},
+				OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+					ValidateForward: func(_ interface{}, _ *service.Destination) error {
+						return errors.New("websocket connection failed")
+					},
+				},
+				VDRegistryValue: &mockvdr.MockVDRegistry{
+					ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+						return &did.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t, false)}, nil
+					},
+				},
+			})
+		require.NoError(t, err)
+
+		err = svc.routeStore.Put(dataKey("did:example:alice"), []byte("did:example:123"))
+		require.NoError(t, err)
+
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), "did:example:alice#key-1", content))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:alice", <-added)
+	})
+
+	t.Run("test service handle inbound message pick up - outbound dispatcher with outbox", func(t *testing.T) {
+		content := []byte(`{"ciphertext": "qQyzvajdvCDJbwxM"}`)
+		added := make(chan string, 1)
+
+		prov := &mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{
+					AddMessageForRecipientFunc: func(message []byte, theirDID, recipientDID string) error {
+						require.Equal(t, content, message)
+						added <- theirDID
+						return nil
+					},
+				},
+			},
+			StorageProviderValue:              mem.NewProvider(),
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					return &did.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t, false)}, nil
+				},
+			},
+		}
+
+		dispatcher, err := outbound.NewOutbound(&outboundProvider{
+			Provider: prov,
+			transports: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{
+				SendErr:     errors.New("websocket connection failed"),
+				AcceptValue: true,
+			}},
+		}, outbound.WithOutbox())
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, dispatcher.Close())
+		}()
+
+		prov.OutboundDispatcherValue = dispatcher
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		to := randomID()
+
+		err = svc.routeStore.Put(dataKey(to), []byte("did:example:123"))
+		require.NoError(t, err)
+
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), to, content))
+		require.NoError(t, err)
+
+		select {
+		case theirDID := <-added:
+			require.Equal(t, "did:example:123", theirDID)
+		default:
+			require.Fail(t, "message not added for pickup")
+		}
+	})
+
 	t.Run("test service handle inbound message pick up - add message error", func(t *testing.T) {
 		to := randomID()
 
@@ -1540,6 +1653,584 @@ func TestGetConnections(t *testing.T) {
 	})
 }
 
+func TestMediationDeny(t *testing.T) {
+	t.Run("test register route - mediation denied", func(t *testing.T) {
+		msgID := make(chan string)
+
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					msgID <- msg.(service.DIDCommMsgMap).ID()
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		go func() {
+			id := <-msgID
+			_, e := svc.HandleInbound(service.NewDIDCommMsgMap(&Deny{
+				ID:   id,
+				Type: DenyMsgType,
+			}), service.EmptyDIDCommContext())
+			require.NoError(t, e)
+		}()
+
+		err = svc.Register("conn")
+		require.True(t, errors.Is(err, ErrMediationDenied))
+
+		_, err = svc.Config("conn")
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("test coordinate mediation 2.0 request - stop dispatches deny", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsgMap)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					dispatched <- msg.(service.DIDCommMsgMap)
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		events := make(chan service.DIDCommAction)
+		require.NoError(t, svc.RegisterActionEvent(events))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&RequestV2{
+			ID:   "123",
+			Type: RequestMsgTypeV2,
+			Body: map[string]interface{}{},
+		}), service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case e := <-events:
+			e.Stop(errors.New("denied"))
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		select {
+		case msg := <-dispatched:
+			require.Equal(t, DenyMsgTypeV2, msg.Type())
+			thID, e := msg.ThreadID()
+			require.NoError(t, e)
+			require.Equal(t, "123", thID)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+	})
+}
+
+func TestCoordinationV2(t *testing.T) {
+	t.Run("test mediator grants coordinate mediation 2.0 request", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsgMap)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			MediaTypeProfilesValue:            []string{transport.MediaTypeDIDCommV2Profile},
+			KeyAgreementTypeValue:             kms.X25519ECDHKWType,
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					dispatched <- msg.(service.DIDCommMsgMap)
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		events := make(chan service.DIDCommAction)
+		require.NoError(t, svc.RegisterActionEvent(events))
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&RequestV2{
+			ID:   "123",
+			Type: RequestMsgTypeV2,
+			Body: map[string]interface{}{},
+		}), service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case e := <-events:
+			e.Continue(&Options{RoutingKeys: []string{"did:example:routing"}})
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+
+		select {
+		case msg := <-dispatched:
+			grant := &GrantV2{}
+			require.NoError(t, msg.Decode(grant))
+			require.Equal(t, GrantMsgTypeV2, grant.Type)
+			require.Equal(t, "123", grant.ThreadID)
+			require.Equal(t, []string{"did:example:routing"}, grant.Body.RoutingDID)
+		case <-time.After(time.Second):
+			require.Fail(t, "timeout")
+		}
+	})
+
+	t.Run("test register route - success", func(t *testing.T) {
+		msgID := make(chan string)
+
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					reqMsgMap, ok := msg.(service.DIDCommMsgMap)
+					require.True(t, ok)
+					require.Equal(t, RequestMsgTypeV2, reqMsgMap.Type())
+
+					msgID <- reqMsgMap.ID()
+					return nil
+				},
+			},
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					return &did.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t, true)}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+			DIDCommVersion: service.V2,
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		go func() {
+			id := <-msgID
+			_, e := svc.HandleInbound(service.NewDIDCommMsgMap(&GrantV2{
+				ID:       randomID(),
+				Type:     GrantMsgTypeV2,
+				ThreadID: id,
+				Body:     GrantV2Body{RoutingDID: []string{"did:example:routing"}},
+			}), service.EmptyDIDCommContext())
+			require.NoError(t, e)
+		}()
+
+		err = svc.Register("conn", func(opts *ClientOptions) {
+			opts.CoordinationV2 = true
+		})
+		require.NoError(t, err)
+
+		conf, err := svc.Config("conn")
+		require.NoError(t, err)
+		require.Equal(t, "https://localhost:8090", conf.Endpoint())
+		require.Equal(t, []string{"did:example:routing"}, conf.Keys())
+
+		entry, err := svc.getRouterConnectionEntry("conn")
+		require.NoError(t, err)
+		require.True(t, entry.CoordinationV2)
+	})
+
+	t.Run("test register route - requires didcomm v2 connection", func(t *testing.T) {
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		err = svc.Register("conn", func(opts *ClientOptions) {
+			opts.CoordinationV2 = true
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "requires a DIDComm v2 connection")
+	})
+
+	t.Run("test keylist update - add and remove recipient DIDs", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsgMap, 1)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mem.NewProvider(),
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					dispatched <- msg.(service.DIDCommMsgMap)
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		update := func(action string) *KeylistUpdateResponseV2 {
+			msg := service.NewDIDCommMsgMap(&KeylistUpdateV2{
+				ID:   randomID(),
+				Type: KeylistUpdateMsgTypeV2,
+				Body: KeylistUpdateV2Body{Updates: []UpdateV2{{
+					RecipientDID: "did:example:recipient",
+					Action:       action,
+				}}},
+			})
+
+			_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+
+			resp := &KeylistUpdateResponseV2{}
+			require.NoError(t, (<-dispatched).Decode(resp))
+			require.Equal(t, KeylistUpdateResponseMsgTypeV2, resp.Type)
+			require.Equal(t, msg.ID(), resp.ThreadID)
+			require.Len(t, resp.Body.Updated, 1)
+			require.Equal(t, "did:example:recipient", resp.Body.Updated[0].RecipientDID)
+
+			return resp
+		}
+
+		require.Equal(t, success, update(add).Body.Updated[0].Result)
+		require.Equal(t, noChange, update(add).Body.Updated[0].Result)
+
+		theirDID, err := svc.routeStore.Get(dataKey("did:example:recipient"))
+		require.NoError(t, err)
+		require.Equal(t, THEIRDID, string(theirDID))
+
+		require.Equal(t, success, update(remove).Body.Updated[0].Result)
+		require.Equal(t, noChange, update(remove).Body.Updated[0].Result)
+
+		// routes of other connections can be neither taken over nor removed
+		require.NoError(t, svc.routeStore.Put(dataKey("did:example:recipient"), []byte("did:example:other")))
+
+		require.Equal(t, clientError, update(add).Body.Updated[0].Result)
+		require.Equal(t, clientError, update(remove).Body.Updated[0].Result)
+
+		theirDID, err = svc.routeStore.Get(dataKey("did:example:recipient"))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:other", string(theirDID))
+	})
+}
+
+func TestKeylistQuery(t *testing.T) {
+	t.Run("test mediator handles keylist query", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsgMap, 1)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mem.NewProvider(),
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					dispatched <- msg.(service.DIDCommMsgMap)
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.handleKeylistUpdate(generateKeyUpdateListMsgPayload(t, randomID(), []Update{
+			{RecipientKey: "key-c", Action: add},
+			{RecipientKey: "key-a", Action: add},
+			{RecipientKey: "key-b", Action: add},
+		}), MYDID, THEIRDID))
+		<-dispatched
+
+		// keys routed for other agents are not listed
+		require.NoError(t, svc.handleKeylistUpdate(generateKeyUpdateListMsgPayload(t, randomID(), []Update{
+			{RecipientKey: "key-other", Action: add},
+		}), MYDID, "otherDID"))
+		<-dispatched
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&KeylistQuery{
+			ID:   "query-1",
+			Type: KeylistQueryMsgType,
+		}), service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		keylist := &Keylist{}
+		require.NoError(t, (<-dispatched).Decode(keylist))
+		require.Equal(t, KeylistMsgType, keylist.Type)
+		require.Equal(t, "query-1", keylist.ID)
+		require.Equal(t, []KeylistKey{{RecipientKey: "key-a"}, {RecipientKey: "key-b"}, {RecipientKey: "key-c"}},
+			keylist.Keys)
+		require.Equal(t, &Pagination{Count: 3, Offset: 0, Remaining: 0}, keylist.Pagination)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&KeylistQueryV2{
+			ID:   "query-2",
+			Type: KeylistQueryMsgTypeV2,
+			Body: KeylistQueryV2Body{Paginate: &Paginate{Limit: 1, Offset: 1}},
+		}), service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		keylistV2 := &KeylistV2{}
+		require.NoError(t, (<-dispatched).Decode(keylistV2))
+		require.Equal(t, KeylistMsgTypeV2, keylistV2.Type)
+		require.Equal(t, "query-2", keylistV2.ThreadID)
+		require.Equal(t, []KeylistKeyV2{{RecipientDID: "key-b"}}, keylistV2.Body.Keys)
+		require.Equal(t, &Pagination{Count: 1, Offset: 1, Remaining: 1}, keylistV2.Body.Pagination)
+	})
+
+	t.Run("test mediator lists route keys saved before they were tagged once used", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommMsgMap, 1)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mem.NewProvider(),
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					dispatched <- msg.(service.DIDCommMsgMap)
+					return nil
+				},
+				ValidateForward: func(interface{}, *service.Destination) error {
+					return nil
+				},
+			},
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(string, ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					return &did.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t, false)}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		// route keys were saved without tag by earlier versions
+		require.NoError(t, svc.routeStore.Put(dataKey("key-forward"), []byte(THEIRDID)))
+		require.NoError(t, svc.routeStore.Put(dataKey("key-update"), []byte(THEIRDID)))
+
+		keys, err := svc.getRouteKeys(THEIRDID)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		require.NoError(t, svc.handleForward(service.NewDIDCommMsgMap(model.Forward{
+			Type: service.ForwardMsgType,
+			ID:   randomID(),
+			To:   "key-forward",
+			Msg:  []byte("msg"),
+		})))
+
+		require.NoError(t, svc.handleKeylistUpdate(generateKeyUpdateListMsgPayload(t, randomID(), []Update{
+			{RecipientKey: "key-update", Action: add},
+		}), MYDID, THEIRDID))
+
+		updateResponse := &KeylistUpdateResponse{}
+		require.NoError(t, (<-dispatched).Decode(updateResponse))
+		require.Equal(t, noChange, updateResponse.Updated[0].Result)
+
+		keys, err = svc.getRouteKeys(THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key-forward", "key-update"}, keys)
+	})
+
+	t.Run("test keylist is dropped if the requester isn't waiting for it", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		keylistCh := make(chan *Keylist, 1)
+		svc.setKeylistCh("query-1", keylistCh)
+
+		keylist := service.NewDIDCommMsgMap(&Keylist{ID: "query-1", Type: KeylistMsgType})
+
+		require.NoError(t, svc.handleKeylist(keylist))
+		// the duplicate doesn't block the inbound handler
+		require.NoError(t, svc.handleKeylist(keylist))
+		require.Len(t, keylistCh, 1)
+	})
+
+	t.Run("test mediator handles keylist query - unmarshal error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleKeylistQuery(&service.DIDCommMsgMap{"@type": KeylistQueryMsgType, "paginate": "invalid"},
+			MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route keylist query message unmarshal")
+	})
+
+	t.Run("test paginate keys", func(t *testing.T) {
+		keys := []string{"a", "b", "c"}
+
+		page, pagination := paginateKeys(keys, nil)
+		require.Equal(t, keys, page)
+		require.Equal(t, &Pagination{Count: 3}, pagination)
+
+		page, pagination = paginateKeys(keys, &Paginate{Limit: 2})
+		require.Equal(t, []string{"a", "b"}, page)
+		require.Equal(t, &Pagination{Count: 2, Remaining: 1}, pagination)
+
+		page, pagination = paginateKeys(keys, &Paginate{Offset: 5})
+		require.Empty(t, page)
+		require.Equal(t, &Pagination{Offset: 3}, pagination)
+	})
+
+	t.Run("test keylist query - success", func(t *testing.T) {
+		for _, coordinationV2 := range []bool{false, true} {
+			s := make(map[string]mockstore.DBEntry)
+
+			var svc *Service
+
+			svc, err := New(&mockprovider.Provider{
+				ServiceMap: map[string]interface{}{
+					messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+				},
+				StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+				ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+				KMSValue:                          &mockkms.KeyManager{},
+				OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+					ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+						require.Equal(t, MYDID, myDID)
+						require.Equal(t, THEIRDID, theirDID)
+
+						query := msg.(service.DIDCommMsgMap)
+
+						var reply interface{} = &Keylist{
+							ID:   query.ID(),
+							Type: KeylistMsgType,
+							Keys: []KeylistKey{{RecipientKey: "key-a"}},
+						}
+
+						if coordinationV2 {
+							require.Equal(t, KeylistQueryMsgTypeV2, query.Type())
+
+							reply = &KeylistV2{
+								ID:       randomID(),
+								Type:     KeylistMsgTypeV2,
+								ThreadID: query.ID(),
+								Body:     KeylistV2Body{Keys: []KeylistKeyV2{{RecipientDID: "key-a"}}},
+							}
+						} else {
+							require.Equal(t, KeylistQueryMsgType, query.Type())
+						}
+
+						go func() {
+							_, e := svc.HandleInbound(service.NewDIDCommMsgMap(reply), service.EmptyDIDCommContext())
+							require.NoError(t, e)
+						}()
+
+						return nil
+					},
+				},
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, svc.saveRouterConnectionEntry(&routerConnectionEntry{
+				ConnectionID:   "conn",
+				CoordinationV2: coordinationV2,
+			}))
+
+			connRec := &connection.Record{
+				ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+			}
+			connBytes, err := json.Marshal(connRec)
+			require.NoError(t, err)
+			s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+			keylist, err := svc.KeylistQuery("conn", nil)
+			require.NoError(t, err)
+			require.Equal(t, []KeylistKey{{RecipientKey: "key-a"}}, keylist.Keys)
+		}
+	})
+
+	t.Run("test keylist query - router not registered", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.KeylistQuery("conn", nil)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+
+	t.Run("test keylist query - send error", func(t *testing.T) {
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{SendErr: errors.New("send error")},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn", ""))
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		_, err = svc.KeylistQuery("conn", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send keylist query")
+	})
+}
+
 func generateRequestMsgPayload(t *testing.T, id string) service.DIDCommMsg {
 	requestBytes, err := json.Marshal(&Request{
 		Type: RequestMsgType,
@@ -1642,3 +2333,17 @@ func (c *connectionsStub) GetConnectionRecord(id string) (*connection.Record, er
 
 	return nil, nil
 }
+
+// outboundProvider adds the outbound transports to the mock provider, as required by the outbound dispatcher.
+type outboundProvider struct {
+	*mockprovider.Provider
+	transports []transport.OutboundTransport
+}
+
+func (p *outboundProvider) OutboundTransports() []transport.OutboundTransport {
+	return p.transports
+}
+
+func (p *outboundProvider) TransportReturnRoute() string {
+	return ""
+}
@@ -57,6 +57,8 @@ type Message struct {
 	ID        string    `json:"id"`
 	AddedTime time.Time `json:"added_time"`
 	Message   []byte    `json:"msg,omitempty"`
+	// RecipientDID is the DID the message is addressed to, Pickup 3.0 requests may be restricted to it.
+	RecipientDID string `json:"recipient_did,omitempty"`
 }
 
 // Noop message
@@ -65,3 +67,109 @@ type Noop struct {
 	Type string `json:"@type,omitempty"`
 	ID   string `json:"@id,omitempty"`
 }
+
+// StatusRequestV3 sent by the recipient to the mediator to request the status of its message queue.
+// https://didcomm.org/messagepickup/3.0/#status-request
+type StatusRequestV3 struct {
+	ID   string              `json:"id,omitempty"`
+	Type string              `json:"type,omitempty"`
+	Body StatusRequestV3Body `json:"body"`
+}
+
+// StatusRequestV3Body is the body of the Pickup 3.0 status-request message.
+type StatusRequestV3Body struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
+
+// StatusV3 details about the messages queued for the recipient.
+// https://didcomm.org/messagepickup/3.0/#status
+type StatusV3 struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	ThreadID string       `json:"thid,omitempty"`
+	Body     StatusV3Body `json:"body"`
+}
+
+// StatusV3Body is the body of the Pickup 3.0 status message.
+type StatusV3Body struct {
+	RecipientDID         string `json:"recipient_did,omitempty"`
+	MessageCount         int    `json:"message_count"`
+	LongestWaitedSeconds int64  `json:"longest_waited_seconds,omitempty"`
+	NewestReceivedTime   int64  `json:"newest_received_time,omitempty"`
+	OldestReceivedTime   int64  `json:"oldest_received_time,omitempty"`
+	TotalBytes           int    `json:"total_bytes,omitempty"`
+	LiveDelivery         bool   `json:"live_delivery"`
+}
+
+// DeliveryRequest a request to have up to limit queued messages sent inside a delivery message.
+// https://didcomm.org/messagepickup/3.0/#delivery-request
+type DeliveryRequest struct {
+	ID   string              `json:"id,omitempty"`
+	Type string              `json:"type,omitempty"`
+	Body DeliveryRequestBody `json:"body"`
+}
+
+// DeliveryRequestBody is the body of the Pickup 3.0 delivery-request message.
+type DeliveryRequestBody struct {
+	Limit        int    `json:"limit"`
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
+
+// Delivery a message that contains queued messages as attachments, the attachment ID being the message ID.
+// https://didcomm.org/messagepickup/3.0/#message-delivery
+type Delivery struct {
+	ID          string                    `json:"id,omitempty"`
+	Type        string                    `json:"type,omitempty"`
+	ThreadID    string                    `json:"thid,omitempty"`
+	Body        DeliveryBody              `json:"body"`
+	Attachments []*decorator.AttachmentV2 `json:"attachments"`
+}
+
+// DeliveryBody is the body of the Pickup 3.0 delivery message.
+type DeliveryBody struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
+
+// MessagesReceived acknowledges the delivered messages, allowing the mediator to remove them from the queue.
+// https://didcomm.org/messagepickup/3.0/#messages-received
+type MessagesReceived struct {
+	ID       string               `json:"id,omitempty"`
+	Type     string               `json:"type,omitempty"`
+	ThreadID string               `json:"thid,omitempty"`
+	Body     MessagesReceivedBody `json:"body"`
+}
+
+// MessagesReceivedBody is the body of the Pickup 3.0 messages-received message.
+type MessagesReceivedBody struct {
+	MessageIDList []string `json:"message_id_list"`
+}
+
+// LiveDeliveryChange turns live delivery of messages on or off for the current connection.
+// https://didcomm.org/messagepickup/3.0/#live-mode
+type LiveDeliveryChange struct {
+	ID   string                 `json:"id,omitempty"`
+	Type string                 `json:"type,omitempty"`
+	Body LiveDeliveryChangeBody `json:"body"`
+}
+
+// LiveDeliveryChangeBody is the body of the Pickup 3.0 live-delivery-change message.
+type LiveDeliveryChangeBody struct {
+	LiveDelivery bool `json:"live_delivery"`
+	// RecipientDID restricts live delivery to the messages addressed to it.
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
+
+// ProblemReportV3 reports a Pickup 3.0 request that can't be satisfied.
+// https://identity.foundation/didcomm-messaging/spec/#problem-reports
+type ProblemReportV3 struct {
+	ID       string              `json:"id,omitempty"`
+	Type     string              `json:"type,omitempty"`
+	ThreadID string              `json:"thid,omitempty"`
+	Body     ProblemReportV3Body `json:"body"`
+}
+
+// ProblemReportV3Body is the body of the Pickup 3.0 problem report.
+type ProblemReportV3Body struct {
+	Code    string `json:"code"`
+	Comment string `json:"comment,omitempty"`
+}
@@ -0,0 +1,709 @@
+/*
+Copyright Scoir Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// queued Pickup 3.0 messages are stored one per record and tagged with the recipient, so that they can be
+	// acknowledged and removed individually.
+	queueTagName       = "pickup_queue"
+	queueKeyPrefix     = "pickup_msg_"
+	recipientKeyPrefix = "pickup_recipient_"
+)
+
+type recipientV3 struct {
+	DID string `json:"DID"`
+}
+
+// StatusRequestV3 requests the status of the Pickup 3.0 message queue held by the mediator.
+func (s *Service) StatusRequestV3(connectionID string) (*StatusV3, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	statusCh := make(chan StatusV3, 1)
+	s.setStatusV3Ch(msgID, statusCh)
+
+	defer s.setStatusV3Ch(msgID, nil)
+
+	req := &StatusRequestV3{
+		ID:   msgID,
+		Type: StatusRequestMsgTypeV3,
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send status request: %w", err)
+	}
+
+	select {
+	case sts := <-statusCh:
+		return &sts, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for status")
+	}
+}
+
+// DeliveryRequest requests up to limit queued messages from the mediator, processes the delivered messages and
+// acknowledges them so that the mediator can remove them from the queue. Returns the number of messages processed.
+func (s *Service) DeliveryRequest(connectionID string, limit int) (int, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return -1, err
+	}
+
+	msgID := uuid.New().String()
+
+	deliveryCh := make(chan Delivery, 1)
+	s.setDeliveryCh(msgID, deliveryCh)
+
+	defer s.setDeliveryCh(msgID, nil)
+
+	// the mediator answers with a status message when there is nothing to deliver
+	statusCh := make(chan StatusV3, 1)
+	s.setStatusV3Ch(msgID, statusCh)
+
+	defer s.setStatusV3Ch(msgID, nil)
+
+	req := &DeliveryRequest{
+		ID:   msgID,
+		Type: DeliveryRequestMsgType,
+		Body: DeliveryRequestBody{Limit: limit},
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return -1, fmt.Errorf("send delivery request: %w", err)
+	}
+
+	select {
+	case delivery := <-deliveryCh:
+		return s.processDelivery(&delivery, conn.MyDID, conn.TheirDID)
+	case <-statusCh:
+		return 0, nil
+	case <-time.After(updateTimeout):
+		return -1, errors.New("timeout waiting for delivery")
+	}
+}
+
+// LiveDeliveryChange turns live delivery on or off. While live delivery is enabled, the mediator pushes
+// messages over the current session as soon as they arrive instead of waiting for a delivery request.
+func (s *Service) LiveDeliveryChange(connectionID string, enabled bool) error {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	req := &LiveDeliveryChange{
+		ID:   uuid.New().String(),
+		Type: LiveDeliveryChangeMsgType,
+		Body: LiveDeliveryChangeBody{LiveDelivery: enabled},
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send live delivery change: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleStatusRequestV3(msg service.DIDCommMsg, myDID, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	request := &StatusRequestV3{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("status request message unmarshal: %w", err)
+	}
+
+	err = s.registerRecipientV3(theirDID)
+	if err != nil {
+		return fmt.Errorf("status request register recipient: %w", err)
+	}
+
+	msgs, err := s.getQueuedMessages(theirDID, request.Body.RecipientDID)
+	if err != nil {
+		return fmt.Errorf("status request get queued messages: %w", err)
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(s.statusV3(msg.ID(), request.Body.RecipientDID,
+		theirDID, msgs)), myDID, theirDID)
+}
+
+func (s *Service) statusV3(thID, recipientDID, theirDID string, msgs []*Message) *StatusV3 {
+	sts := &StatusV3{
+		ID:       uuid.New().String(),
+		Type:     StatusMsgTypeV3,
+		ThreadID: thID,
+		Body: StatusV3Body{
+			RecipientDID: recipientDID,
+			MessageCount: len(msgs),
+			LiveDelivery: s.liveSession(theirDID) != nil,
+		},
+	}
+
+	if len(msgs) == 0 {
+		return sts
+	}
+
+	for _, m := range msgs {
+		sts.Body.TotalBytes += len(m.Message)
+	}
+
+	// queued messages are sorted from the oldest to the newest
+	oldest, newest := msgs[0].AddedTime, msgs[len(msgs)-1].AddedTime
+
+	sts.Body.OldestReceivedTime = oldest.Unix()
+	sts.Body.NewestReceivedTime = newest.Unix()
+	sts.Body.LongestWaitedSeconds = int64(time.Since(oldest).Seconds())
+
+	return sts
+}
+
+func (s *Service) handleStatusV3(msg service.DIDCommMsg) error {
+	statusMsg := &StatusV3{}
+
+	err := msg.Decode(statusMsg)
+	if err != nil {
+		return fmt.Errorf("status message unmarshal: %w", err)
+	}
+
+	statusCh := s.getStatusV3Ch(statusMsg.ThreadID)
+	if statusCh == nil {
+		return nil
+	}
+
+	// the requester only waits for the first status, late and duplicate ones are dropped
+	select {
+	case statusCh <- *statusMsg:
+	default:
+		logger.Warnf("dropping status for thread %s, the requester isn't waiting for it", statusMsg.ThreadID)
+	}
+
+	return nil
+}
+
+func (s *Service) handleDeliveryRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	request := &DeliveryRequest{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("delivery request message unmarshal: %w", err)
+	}
+
+	err = s.registerRecipientV3(theirDID)
+	if err != nil {
+		return fmt.Errorf("delivery request register recipient: %w", err)
+	}
+
+	msgs, err := s.getQueuedMessages(theirDID, request.Body.RecipientDID)
+	if err != nil {
+		return fmt.Errorf("delivery request get queued messages: %w", err)
+	}
+
+	if len(msgs) == 0 {
+		return s.outbound.SendToDID(service.NewDIDCommMsgMap(s.statusV3(msg.ID(), request.Body.RecipientDID,
+			theirDID, msgs)), myDID, theirDID)
+	}
+
+	if request.Body.Limit > 0 && request.Body.Limit < len(msgs) {
+		msgs = msgs[:request.Body.Limit]
+	}
+
+	delivery := newDelivery(msg.ID(), request.Body.RecipientDID, msgs)
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(delivery), myDID, theirDID)
+}
+
+func newDelivery(thID, recipientDID string, msgs []*Message) *Delivery {
+	delivery := &Delivery{
+		ID:          uuid.New().String(),
+		Type:        DeliveryMsgType,
+		ThreadID:    thID,
+		Body:        DeliveryBody{RecipientDID: recipientDID},
+		Attachments: make([]*decorator.AttachmentV2, len(msgs)),
+	}
+
+	for i, m := range msgs {
+		delivery.Attachments[i] = &decorator.AttachmentV2{
+			ID:          m.ID,
+			LastModTime: m.AddedTime,
+			Data: decorator.AttachmentData{
+				Base64: base64.StdEncoding.EncodeToString(m.Message),
+			},
+		}
+	}
+
+	return delivery
+}
+
+func (s *Service) handleDelivery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	delivery := &Delivery{}
+
+	err := msg.Decode(delivery)
+	if err != nil {
+		return fmt.Errorf("delivery message unmarshal: %w", err)
+	}
+
+	// deliveries requested through DeliveryRequest are processed by the caller
+	deliveryCh := s.getDeliveryCh(delivery.ThreadID)
+	if deliveryCh != nil {
+		select {
+		case deliveryCh <- *delivery:
+		default:
+			// the messages aren't acknowledged, the mediator keeps them queued
+			logger.Warnf("dropping delivery for thread %s, the requester isn't waiting for it", delivery.ThreadID)
+		}
+
+		return nil
+	}
+
+	// unsolicited deliveries are pushed by the mediator in live mode
+	_, err = s.processDelivery(delivery, myDID, theirDID)
+
+	return err
+}
+
+func (s *Service) processDelivery(delivery *Delivery, myDID, theirDID string) (int, error) {
+	var (
+		processed int
+		received  []string
+	)
+
+	for _, att := range delivery.Attachments {
+		if att == nil {
+			continue
+		}
+
+		// the message is acknowledged even if it can't be handled, otherwise it would be redelivered forever
+		received = append(received, att.ID)
+
+		data, err := att.Data.Fetch()
+		if err != nil {
+			logger.Errorf("error fetching delivered message %s: %w", att.ID, err)
+
+			continue
+		}
+
+		err = s.handle(&Message{ID: att.ID, AddedTime: att.LastModTime, Message: data})
+		if err != nil {
+			logger.Errorf("error handling delivered message %s: %w", att.ID, err)
+
+			continue
+		}
+
+		processed++
+	}
+
+	if len(received) == 0 {
+		return processed, nil
+	}
+
+	ack := &MessagesReceived{
+		ID:       uuid.New().String(),
+		Type:     MessagesReceivedMsgType,
+		ThreadID: delivery.ThreadID,
+		Body:     MessagesReceivedBody{MessageIDList: received},
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(ack), myDID, theirDID); err != nil {
+		return processed, fmt.Errorf("send messages received: %w", err)
+	}
+
+	return processed, nil
+}
+
+func (s *Service) handleMessagesReceived(msg service.DIDCommMsg, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	ack := &MessagesReceived{}
+
+	err := msg.Decode(ack)
+	if err != nil {
+		return fmt.Errorf("messages received message unmarshal: %w", err)
+	}
+
+	for _, id := range ack.Body.MessageIDList {
+		err = s.msgStore.Delete(queueKey(theirDID, id))
+		if err != nil {
+			return fmt.Errorf("messages received delete message %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) handleLiveDeliveryChange(msg service.DIDCommMsg, ctx service.DIDCommContext) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	myDID, theirDID := ctx.MyDID(), ctx.TheirDID()
+
+	change := &LiveDeliveryChange{}
+
+	err := msg.Decode(change)
+	if err != nil {
+		return fmt.Errorf("live delivery change message unmarshal: %w", err)
+	}
+
+	err = s.registerRecipientV3(theirDID)
+	if err != nil {
+		return fmt.Errorf("live delivery change register recipient: %w", err)
+	}
+
+	if !change.Body.LiveDelivery {
+		s.endLiveSession(theirDID, nil)
+
+		return nil
+	}
+
+	// live delivery is bound to the duplex transport session the change was received on
+	session, hasSession := ctx.All()[transport.SessionProperty].(transport.Session)
+	outbound, canSend := s.outbound.(dispatcher.SessionOutbound)
+
+	if !hasSession || session == nil || !canSend {
+		report := &ProblemReportV3{
+			ID:       uuid.New().String(),
+			Type:     ProblemReportMsgTypeV3,
+			ThreadID: msg.ID(),
+			Body: ProblemReportV3Body{
+				Code:    LiveModeNotSupportedCode,
+				Comment: "live delivery requires a duplex transport session with the 'all' return route option",
+			},
+		}
+
+		return s.outbound.SendToDID(service.NewDIDCommMsgMap(report), myDID, theirDID)
+	}
+
+	ls := &liveSession{
+		myDID:        myDID,
+		recipientDID: change.Body.RecipientDID,
+		session:      session,
+		outbound:     outbound,
+		stop:         make(chan struct{}),
+	}
+
+	s.startLiveSession(theirDID, ls)
+
+	// flush the queue, messages remain queued until the recipient acknowledges them
+	msgs, err := s.getQueuedMessages(theirDID, ls.recipientDID)
+	if err != nil {
+		return fmt.Errorf("live delivery change get queued messages: %w", err)
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	s.sendLive(theirDID, ls, msgs)
+
+	return nil
+}
+
+func (s *Service) addMessageV3(message []byte, theirDID, recipientDID string) error {
+	m := &Message{
+		ID:           uuid.New().String(),
+		AddedTime:    time.Now(),
+		Message:      message,
+		RecipientDID: recipientDID,
+	}
+
+	err := s.putQueuedMessage(theirDID, m)
+	if err != nil {
+		return fmt.Errorf("unable to queue message: %w", err)
+	}
+
+	if ls := s.liveSession(theirDID); ls != nil && (ls.recipientDID == "" || ls.recipientDID == recipientDID) {
+		s.sendLive(theirDID, ls, []*Message{m})
+	}
+
+	return nil
+}
+
+// sendLive pushes msgs over the live session, live mode ends if the session is gone. The messages stay queued until
+// they are acknowledged.
+func (s *Service) sendLive(theirDID string, ls *liveSession, msgs []*Message) {
+	err := ls.outbound.SendToSession(service.NewDIDCommMsgMap(newDelivery("", ls.recipientDID, msgs)), ls.myDID,
+		theirDID, ls.session)
+	if err != nil {
+		logger.Warnf("live delivery to %s failed, disabling live mode: %s", theirDID, err)
+
+		s.endLiveSession(theirDID, ls)
+	}
+}
+
+func (s *Service) isRecipientV3(theirDID string) (bool, error) {
+	_, err := s.msgStore.Get(recipientKeyPrefix + theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// registerRecipientV3 marks the recipient as a Pickup 3.0 recipient and moves any messages waiting in its
+// legacy inbox to the Pickup 3.0 queue. Legacy status and batch pickup requests of the recipient are served
+// from the queue afterwards.
+func (s *Service) registerRecipientV3(theirDID string) error {
+	usesV3, err := s.isRecipientV3(theirDID)
+	if err != nil || usesV3 {
+		return err
+	}
+
+	outbox, err := s.getInbox(theirDID)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get inbox: %w", err)
+	}
+
+	if outbox != nil {
+		msgs, e := outbox.DecodeMessages()
+		if e != nil {
+			return fmt.Errorf("decode inbox messages: %w", e)
+		}
+
+		for _, m := range msgs {
+			e = s.putQueuedMessage(theirDID, m)
+			if e != nil {
+				return fmt.Errorf("queue inbox message: %w", e)
+			}
+		}
+
+		e = s.msgStore.Delete(theirDID)
+		if e != nil {
+			return fmt.Errorf("delete inbox: %w", e)
+		}
+	}
+
+	b, err := json.Marshal(&recipientV3{DID: theirDID})
+	if err != nil {
+		return err
+	}
+
+	return s.msgStore.Put(recipientKeyPrefix+theirDID, b)
+}
+
+func (s *Service) putQueuedMessage(theirDID string, m *Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return s.msgStore.Put(queueKey(theirDID, m.ID), b, storage.Tag{Name: queueTagName, Value: queueTag(theirDID)})
+}
+
+// getQueuedMessages returns the messages queued for the recipient, from the oldest to the newest. If recipientDID is
+// set, only the messages addressed to it are returned.
+func (s *Service) getQueuedMessages(theirDID, recipientDID string) ([]*Message, error) {
+	iter, err := s.msgStore.Query(queueTagName + ":" + queueTag(theirDID))
+	if err != nil {
+		return nil, fmt.Errorf("query queue: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var msgs []*Message
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("get next queued message: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("get queued message: %w", err)
+		}
+
+		m := &Message{}
+
+		err = json.Unmarshal(value, m)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal queued message: %w", err)
+		}
+
+		if recipientDID == "" || m.RecipientDID == recipientDID {
+			msgs = append(msgs, m)
+		}
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("get next queued message: %w", err)
+		}
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].AddedTime.Before(msgs[j].AddedTime)
+	})
+
+	return msgs, nil
+}
+
+// queueInbox returns the legacy inbox view of the recipient's queue, for legacy status requests.
+func (s *Service) queueInbox(theirDID string) (*inbox, error) {
+	msgs, err := s.getQueuedMessages(theirDID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	outbox := &inbox{DID: theirDID, MessageCount: len(msgs)}
+
+	for _, m := range msgs {
+		outbox.TotalSize += len(m.Message)
+	}
+
+	if len(msgs) > 0 {
+		// like in the legacy inbox, the delivered and removed times are updated when a message is added
+		outbox.LastAddedTime = msgs[len(msgs)-1].AddedTime
+		outbox.LastDeliveredTime = outbox.LastAddedTime
+		outbox.LastRemovedTime = outbox.LastAddedTime
+	}
+
+	return outbox, nil
+}
+
+// pickupQueuedMessages removes up to batchSize messages from the recipient's queue and returns them, for legacy
+// batch pickup requests.
+func (s *Service) pickupQueuedMessages(theirDID string, batchSize int) ([]*Message, error) {
+	msgs, err := s.getQueuedMessages(theirDID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if batchSize < len(msgs) {
+		msgs = msgs[:batchSize]
+	}
+
+	for _, m := range msgs {
+		err = s.msgStore.Delete(queueKey(theirDID, m.ID))
+		if err != nil {
+			return nil, fmt.Errorf("delete queued message %s: %w", m.ID, err)
+		}
+	}
+
+	return msgs, nil
+}
+
+// queueTag returns the tag value for the recipient's queue; DIDs can't be used as is since the storage
+// query syntax reserves ':'.
+func queueTag(theirDID string) string {
+	h := sha256.Sum256([]byte(theirDID))
+
+	return hex.EncodeToString(h[:])
+}
+
+func queueKey(theirDID, msgID string) string {
+	return queueKeyPrefix + queueTag(theirDID) + "_" + msgID
+}
+
+// liveSession is the transport session live delivery is enabled on, for the messages addressed to recipientDID
+// if it is set.
+type liveSession struct {
+	myDID        string
+	recipientDID string
+	session      transport.Session
+	outbound     dispatcher.SessionOutbound
+	stop         chan struct{}
+}
+
+func (s *Service) liveSession(theirDID string) *liveSession {
+	s.liveSessionsLock.RLock()
+	defer s.liveSessionsLock.RUnlock()
+
+	return s.liveSessions[theirDID]
+}
+
+// startLiveSession enables live delivery over ls until it is disabled or the transport session is closed.
+func (s *Service) startLiveSession(theirDID string, ls *liveSession) {
+	s.endLiveSession(theirDID, nil)
+
+	s.liveSessionsLock.Lock()
+	s.liveSessions[theirDID] = ls
+	s.liveSessionsLock.Unlock()
+
+	go func() {
+		select {
+		case <-ls.session.Done():
+			s.endLiveSession(theirDID, ls)
+		case <-ls.stop:
+		}
+	}()
+}
+
+// endLiveSession disables live delivery for theirDID, if ls is set only if live delivery is still enabled over it.
+func (s *Service) endLiveSession(theirDID string, ls *liveSession) {
+	s.liveSessionsLock.Lock()
+	defer s.liveSessionsLock.Unlock()
+
+	current, ok := s.liveSessions[theirDID]
+	if !ok || (ls != nil && current != ls) {
+		return
+	}
+
+	delete(s.liveSessions, theirDID)
+	close(current.stop)
+}
+
+func (s *Service) getStatusV3Ch(thID string) chan StatusV3 {
+	s.v3MapLock.RLock()
+	defer s.v3MapLock.RUnlock()
+
+	return s.statusV3Map[thID]
+}
+
+func (s *Service) setStatusV3Ch(thID string, statusCh chan StatusV3) {
+	s.v3MapLock.Lock()
+	defer s.v3MapLock.Unlock()
+
+	if statusCh == nil {
+		delete(s.statusV3Map, thID)
+	} else {
+		s.statusV3Map[thID] = statusCh
+	}
+}
+
+func (s *Service) getDeliveryCh(thID string) chan Delivery {
+	s.v3MapLock.RLock()
+	defer s.v3MapLock.RUnlock()
+
+	return s.deliveryMap[thID]
+}
+
+func (s *Service) setDeliveryCh(thID string, deliveryCh chan Delivery) {
+	s.v3MapLock.Lock()
+	defer s.v3MapLock.Unlock()
+
+	if deliveryCh == nil {
+		delete(s.deliveryMap, thID)
+	} else {
+		s.deliveryMap[thID] = deliveryCh
+	}
+}
@@ -0,0 +1,631 @@
+/*
+Copyright Scoir Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+func TestPickupV3_Mediator(t *testing.T) {
+	t.Run("status request, delivery request and messages received", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		statusReq := service.NewDIDCommMsgMap(&StatusRequestV3{ID: "status-1", Type: StatusRequestMsgTypeV3})
+		require.NoError(t, svc.handleStatusRequestV3(statusReq, MYDID, THEIRDID))
+
+		sts := &StatusV3{}
+		require.NoError(t, (<-sent).Decode(sts))
+		require.Equal(t, StatusMsgTypeV3, sts.Type)
+		require.Equal(t, "status-1", sts.ThreadID)
+		require.Equal(t, 0, sts.Body.MessageCount)
+
+		// the recipient is now a Pickup 3.0 recipient, messages are queued individually
+		require.NoError(t, svc.AddMessage([]byte("msg-1"), THEIRDID))
+		time.Sleep(time.Millisecond)
+		require.NoError(t, svc.AddMessage([]byte("msg-2"), THEIRDID))
+
+		require.NoError(t, svc.handleStatusRequestV3(statusReq, MYDID, THEIRDID))
+		require.NoError(t, (<-sent).Decode(sts))
+		require.Equal(t, 2, sts.Body.MessageCount)
+		require.Equal(t, 10, sts.Body.TotalBytes)
+		require.False(t, sts.Body.LiveDelivery)
+
+		deliveryReq := service.NewDIDCommMsgMap(&DeliveryRequest{
+			ID:   "delivery-1",
+			Type: DeliveryRequestMsgType,
+			Body: DeliveryRequestBody{Limit: 1},
+		})
+		require.NoError(t, svc.handleDeliveryRequest(deliveryReq, MYDID, THEIRDID))
+
+		delivery := &Delivery{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Equal(t, DeliveryMsgType, delivery.Type)
+		require.Equal(t, "delivery-1", delivery.ThreadID)
+		require.Len(t, delivery.Attachments, 1)
+
+		data, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "msg-1", string(data))
+
+		// delivered messages stay queued until they are acknowledged
+		msgs, err := svc.getQueuedMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+
+		ack := service.NewDIDCommMsgMap(&MessagesReceived{
+			ID:   "ack-1",
+			Type: MessagesReceivedMsgType,
+			Body: MessagesReceivedBody{MessageIDList: []string{delivery.Attachments[0].ID}},
+		})
+		require.NoError(t, svc.handleMessagesReceived(ack, THEIRDID))
+
+		msgs, err = svc.getQueuedMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, "msg-2", string(msgs[0].Message))
+	})
+
+	t.Run("delivery request with an empty queue returns status", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		deliveryReq := service.NewDIDCommMsgMap(&DeliveryRequest{
+			ID:   "delivery-1",
+			Type: DeliveryRequestMsgType,
+			Body: DeliveryRequestBody{Limit: 10},
+		})
+		require.NoError(t, svc.handleDeliveryRequest(deliveryReq, MYDID, THEIRDID))
+
+		sts := &StatusV3{}
+		require.NoError(t, (<-sent).Decode(sts))
+		require.Equal(t, StatusMsgTypeV3, sts.Type)
+		require.Equal(t, "delivery-1", sts.ThreadID)
+		require.Equal(t, 0, sts.Body.MessageCount)
+	})
+
+	t.Run("legacy inbox is moved to the Pickup 3.0 queue", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		require.NoError(t, svc.AddMessage([]byte("legacy"), THEIRDID))
+
+		statusReq := service.NewDIDCommMsgMap(&StatusRequestV3{ID: "status-1", Type: StatusRequestMsgTypeV3})
+		require.NoError(t, svc.handleStatusRequestV3(statusReq, MYDID, THEIRDID))
+
+		sts := &StatusV3{}
+		require.NoError(t, (<-sent).Decode(sts))
+		require.Equal(t, 1, sts.Body.MessageCount)
+
+		_, err := svc.getInbox(THEIRDID)
+		require.Error(t, err)
+	})
+
+	t.Run("live delivery", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		statusReq := service.NewDIDCommMsgMap(&StatusRequestV3{ID: "status-1", Type: StatusRequestMsgTypeV3})
+		require.NoError(t, svc.handleStatusRequestV3(statusReq, MYDID, THEIRDID))
+		<-sent
+
+		require.NoError(t, svc.AddMessage([]byte("queued"), THEIRDID))
+
+		change := service.NewDIDCommMsgMap(&LiveDeliveryChange{
+			ID:   "live-1",
+			Type: LiveDeliveryChangeMsgType,
+			Body: LiveDeliveryChangeBody{LiveDelivery: true},
+		})
+		sess := &mockSession{done: make(chan struct{})}
+		require.NoError(t, svc.handleLiveDeliveryChange(change, sessionContext(sess)))
+
+		// queued messages are flushed when live delivery is enabled
+		delivery := &Delivery{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Len(t, delivery.Attachments, 1)
+
+		// new messages are pushed as they arrive
+		require.NoError(t, svc.AddMessage([]byte("pushed"), THEIRDID))
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Len(t, delivery.Attachments, 1)
+
+		data, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "pushed", string(data))
+
+		require.NoError(t, svc.handleStatusRequestV3(statusReq, MYDID, THEIRDID))
+
+		sts := &StatusV3{}
+		require.NoError(t, (<-sent).Decode(sts))
+		require.True(t, sts.Body.LiveDelivery)
+		require.Equal(t, 2, sts.Body.MessageCount)
+
+		change = service.NewDIDCommMsgMap(&LiveDeliveryChange{
+			ID:   "live-2",
+			Type: LiveDeliveryChangeMsgType,
+			Body: LiveDeliveryChangeBody{LiveDelivery: false},
+		})
+		require.NoError(t, svc.handleLiveDeliveryChange(change, sessionContext(sess)))
+		require.Nil(t, svc.liveSession(THEIRDID))
+	})
+
+	t.Run("live delivery requires a transport session", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		change := service.NewDIDCommMsgMap(&LiveDeliveryChange{
+			ID:   "live-1",
+			Type: LiveDeliveryChangeMsgType,
+			Body: LiveDeliveryChangeBody{LiveDelivery: true},
+		})
+		require.NoError(t, svc.handleLiveDeliveryChange(change, service.NewDIDCommContext(MYDID, THEIRDID, nil)))
+
+		report := &ProblemReportV3{}
+		require.NoError(t, (<-sent).Decode(report))
+		require.Equal(t, ProblemReportMsgTypeV3, report.Type)
+		require.Equal(t, "live-1", report.ThreadID)
+		require.Equal(t, LiveModeNotSupportedCode, report.Body.Code)
+		require.Nil(t, svc.liveSession(THEIRDID))
+	})
+
+	t.Run("live delivery ends with the transport session", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		var sessions []transport.Session
+
+		svc.outbound.(*mockdispatcher.MockOutbound).ValidateSendToSession = func(msg interface{}, myDID,
+			theirDID string, session transport.Session) error {
+			sessions = append(sessions, session)
+
+			return nil
+		}
+
+		change := service.NewDIDCommMsgMap(&LiveDeliveryChange{
+			ID:   "live-1",
+			Type: LiveDeliveryChangeMsgType,
+			Body: LiveDeliveryChangeBody{LiveDelivery: true},
+		})
+
+		sess := &mockSession{done: make(chan struct{})}
+		require.NoError(t, svc.handleLiveDeliveryChange(change, sessionContext(sess)))
+		require.NotNil(t, svc.liveSession(THEIRDID))
+
+		// messages are pushed over the session which requested live mode
+		require.NoError(t, svc.AddMessage([]byte("pushed"), THEIRDID))
+		require.Equal(t, []transport.Session{sess}, sessions)
+
+		close(sess.done)
+
+		require.Eventually(t, func() bool {
+			return svc.liveSession(THEIRDID) == nil
+		}, time.Second, 10*time.Millisecond)
+
+		// once the session is closed, messages are queued only
+		require.NoError(t, svc.AddMessage([]byte("queued"), THEIRDID))
+		require.Len(t, sessions, 1)
+
+		msgs, err := svc.getQueuedMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+	})
+
+	t.Run("live delivery send error disables live mode", func(t *testing.T) {
+		svc := getServiceV3(t, nil, errors.New("send error"))
+
+		svc.startLiveSession(THEIRDID, &liveSession{
+			myDID:    MYDID,
+			session:  &mockSession{},
+			outbound: svc.outbound.(dispatcher.SessionOutbound),
+			stop:     make(chan struct{}),
+		})
+		require.NoError(t, svc.registerRecipientV3(THEIRDID))
+
+		require.NoError(t, svc.AddMessage([]byte("msg"), THEIRDID))
+		require.Empty(t, svc.liveSession(THEIRDID))
+
+		require.Nil(t, svc.liveSession(THEIRDID))
+
+		msgs, err := svc.getQueuedMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+	})
+
+	t.Run("requests restricted to a recipient DID", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		require.NoError(t, svc.registerRecipientV3(THEIRDID))
+		require.NoError(t, svc.AddMessageForRecipient([]byte("for-alice"), THEIRDID, "did:example:alice"))
+		require.NoError(t, svc.AddMessageForRecipient([]byte("for-bob"), THEIRDID, "did:example:bob"))
+
+		statusReq := service.NewDIDCommMsgMap(&StatusRequestV3{
+			ID:   "status-1",
+			Type: StatusRequestMsgTypeV3,
+			Body: StatusRequestV3Body{RecipientDID: "did:example:alice"},
+		})
+		require.NoError(t, svc.handleStatusRequestV3(statusReq, MYDID, THEIRDID))
+
+		sts := &StatusV3{}
+		require.NoError(t, (<-sent).Decode(sts))
+		require.Equal(t, "did:example:alice", sts.Body.RecipientDID)
+		require.Equal(t, 1, sts.Body.MessageCount)
+
+		deliveryReq := service.NewDIDCommMsgMap(&DeliveryRequest{
+			ID:   "delivery-1",
+			Type: DeliveryRequestMsgType,
+			Body: DeliveryRequestBody{Limit: 10, RecipientDID: "did:example:bob"},
+		})
+		require.NoError(t, svc.handleDeliveryRequest(deliveryReq, MYDID, THEIRDID))
+
+		delivery := &Delivery{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Equal(t, "did:example:bob", delivery.Body.RecipientDID)
+		require.Len(t, delivery.Attachments, 1)
+
+		data, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "for-bob", string(data))
+
+		// unrestricted requests return all the messages
+		statusReq = service.NewDIDCommMsgMap(&StatusRequestV3{ID: "status-2", Type: StatusRequestMsgTypeV3})
+		require.NoError(t, svc.handleStatusRequestV3(statusReq, MYDID, THEIRDID))
+		require.NoError(t, (<-sent).Decode(sts))
+		require.Equal(t, 2, sts.Body.MessageCount)
+	})
+
+	t.Run("live delivery restricted to a recipient DID", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		require.NoError(t, svc.registerRecipientV3(THEIRDID))
+		require.NoError(t, svc.AddMessageForRecipient([]byte("for-alice"), THEIRDID, "did:example:alice"))
+		require.NoError(t, svc.AddMessageForRecipient([]byte("for-bob"), THEIRDID, "did:example:bob"))
+
+		change := service.NewDIDCommMsgMap(&LiveDeliveryChange{
+			ID:   "live-1",
+			Type: LiveDeliveryChangeMsgType,
+			Body: LiveDeliveryChangeBody{LiveDelivery: true, RecipientDID: "did:example:bob"},
+		})
+		sess := &mockSession{done: make(chan struct{})}
+		require.NoError(t, svc.handleLiveDeliveryChange(change, sessionContext(sess)))
+
+		// only the queued messages addressed to the recipient DID are flushed
+		delivery := &Delivery{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Equal(t, "did:example:bob", delivery.Body.RecipientDID)
+		require.Len(t, delivery.Attachments, 1)
+
+		data, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "for-bob", string(data))
+
+		// and only the new messages addressed to it are pushed
+		require.NoError(t, svc.AddMessageForRecipient([]byte("for-alice"), THEIRDID, "did:example:alice"))
+		require.NoError(t, svc.AddMessageForRecipient([]byte("for-bob"), THEIRDID, "did:example:bob"))
+
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Len(t, delivery.Attachments, 1)
+
+		data, err = delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "for-bob", string(data))
+		require.Empty(t, sent)
+
+		msgs, err := svc.getQueuedMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 4)
+	})
+
+	t.Run("legacy requests of a Pickup 3.0 recipient are served from the queue", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		require.NoError(t, svc.AddMessage([]byte("legacy"), THEIRDID))
+		require.NoError(t, svc.registerRecipientV3(THEIRDID))
+		require.NoError(t, svc.AddMessage([]byte("queued"), THEIRDID))
+
+		statusReq := service.NewDIDCommMsgMap(&StatusRequest{
+			ID:     "status-1",
+			Type:   StatusRequestMsgType,
+			Thread: &decorator.Thread{ID: "thread-1"},
+		})
+		require.NoError(t, svc.handleStatusRequest(statusReq, MYDID, THEIRDID))
+
+		sts := &Status{}
+		require.NoError(t, (<-sent).Decode(sts))
+		require.Equal(t, 2, sts.MessageCount)
+		require.Equal(t, len("legacy")+len("queued"), sts.TotalSize)
+
+		batchPickup := service.NewDIDCommMsgMap(&BatchPickup{ID: "batch-1", Type: BatchPickupMsgType, BatchSize: 1})
+		require.NoError(t, svc.handleBatchPickup(batchPickup, MYDID, THEIRDID))
+
+		batch := &Batch{}
+		require.NoError(t, (<-sent).Decode(batch))
+		require.Len(t, batch.Messages, 1)
+		require.Equal(t, "legacy", string(batch.Messages[0].Message))
+
+		// picked up messages are removed from the queue
+		msgs, err := svc.getQueuedMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, "queued", string(msgs[0].Message))
+	})
+
+	t.Run("decode errors", func(t *testing.T) {
+		svc := getServiceV3(t, nil, nil)
+
+		msg := service.DIDCommMsgMap{"body": "invalid"}
+
+		err := svc.handleStatusRequestV3(msg, MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "status request message unmarshal")
+
+		err = svc.handleStatusV3(msg)
+		require.Contains(t, err.Error(), "status message unmarshal")
+
+		err = svc.handleDeliveryRequest(msg, MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "delivery request message unmarshal")
+
+		err = svc.handleDelivery(msg, MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "delivery message unmarshal")
+
+		err = svc.handleMessagesReceived(msg, THEIRDID)
+		require.Contains(t, err.Error(), "messages received message unmarshal")
+
+		err = svc.handleLiveDeliveryChange(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.Contains(t, err.Error(), "live delivery change message unmarshal")
+	})
+}
+
+func TestPickupV3_Recipient(t *testing.T) {
+	t.Run("status request", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		go func() {
+			req := <-sent
+			require.Equal(t, StatusRequestMsgTypeV3, req.Type())
+
+			require.NoError(t, svc.handleStatusV3(service.NewDIDCommMsgMap(&StatusV3{
+				ID:       "status-1",
+				Type:     StatusMsgTypeV3,
+				ThreadID: req.ID(),
+				Body:     StatusV3Body{MessageCount: 3},
+			})))
+		}()
+
+		sts, err := svc.StatusRequestV3("conn")
+		require.NoError(t, err)
+		require.Equal(t, 3, sts.Body.MessageCount)
+	})
+
+	t.Run("delivery request", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		go func() {
+			req := <-sent
+			require.Equal(t, DeliveryRequestMsgType, req.Type())
+
+			require.NoError(t, svc.handleDelivery(service.NewDIDCommMsgMap(&Delivery{
+				ID:       "delivery-1",
+				Type:     DeliveryMsgType,
+				ThreadID: req.ID(),
+				Attachments: []*decorator.AttachmentV2{
+					{
+						ID:   "msg-1",
+						Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte("a"))},
+					},
+					{ID: "msg-2", Data: decorator.AttachmentData{}},
+				},
+			}), MYDID, THEIRDID))
+		}()
+
+		count, err := svc.DeliveryRequest("conn", 2)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		ack := &MessagesReceived{}
+		require.NoError(t, (<-sent).Decode(ack))
+		require.Equal(t, MessagesReceivedMsgType, ack.Type)
+		require.Equal(t, []string{"msg-1", "msg-2"}, ack.Body.MessageIDList)
+	})
+
+	t.Run("delivery request with nothing to deliver", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		go func() {
+			req := <-sent
+
+			require.NoError(t, svc.handleStatusV3(service.NewDIDCommMsgMap(&StatusV3{
+				ID:       "status-1",
+				Type:     StatusMsgTypeV3,
+				ThreadID: req.ID(),
+			})))
+		}()
+
+		count, err := svc.DeliveryRequest("conn", 2)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("unsolicited delivery is processed and acknowledged", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(`{
+			"id": "delivery-1",
+			"type": "https://didcomm.org/messagepickup/3.0/delivery",
+			"body": {},
+			"attachments": [{"id": "msg-1", "data": {"base64": "YQ=="}}]
+		}`))
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case m := <-sent:
+			ack := &MessagesReceived{}
+			require.NoError(t, m.Decode(ack))
+			require.Equal(t, []string{"msg-1"}, ack.Body.MessageIDList)
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "didn't receive messages received")
+		}
+	})
+
+	t.Run("late and duplicate responses don't block", func(t *testing.T) {
+		svc := getServiceV3(t, nil, nil)
+
+		svc.setDeliveryCh("thread-1", make(chan Delivery, 1))
+		svc.setStatusV3Ch("thread-1", make(chan StatusV3, 1))
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, svc.handleDelivery(service.NewDIDCommMsgMap(&Delivery{
+				ID:       "delivery-1",
+				Type:     DeliveryMsgType,
+				ThreadID: "thread-1",
+			}), MYDID, THEIRDID))
+
+			require.NoError(t, svc.handleStatusV3(service.NewDIDCommMsgMap(&StatusV3{
+				ID:       "status-1",
+				Type:     StatusMsgTypeV3,
+				ThreadID: "thread-1",
+			})))
+		}
+	})
+
+	t.Run("live delivery change", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := getServiceV3(t, sent, nil)
+
+		require.NoError(t, svc.LiveDeliveryChange("conn", true))
+
+		change := &LiveDeliveryChange{}
+		require.NoError(t, (<-sent).Decode(change))
+		require.True(t, change.Body.LiveDelivery)
+	})
+
+	t.Run("send errors", func(t *testing.T) {
+		svc := getServiceV3(t, nil, errors.New("send error"))
+
+		_, err := svc.StatusRequestV3("conn")
+		require.Contains(t, err.Error(), "send status request")
+
+		_, err = svc.DeliveryRequest("conn", 1)
+		require.Contains(t, err.Error(), "send delivery request")
+
+		err = svc.LiveDeliveryChange("conn", true)
+		require.Contains(t, err.Error(), "send live delivery change")
+	})
+
+	t.Run("connection errors", func(t *testing.T) {
+		svc := getServiceV3(t, nil, nil)
+
+		_, err := svc.StatusRequestV3("unknown")
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+
+		_, err = svc.DeliveryRequest("unknown", 1)
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+
+		err = svc.LiveDeliveryChange("unknown", true)
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+}
+
+func TestPickupV3_Accept(t *testing.T) {
+	svc, err := getService()
+	require.NoError(t, err)
+
+	for _, msgType := range []string{
+		StatusRequestMsgTypeV3, StatusMsgTypeV3, DeliveryRequestMsgType, DeliveryMsgType,
+		MessagesReceivedMsgType, LiveDeliveryChangeMsgType,
+	} {
+		require.True(t, svc.Accept(msgType))
+	}
+}
+
+func sessionContext(sess transport.Session) service.DIDCommContext {
+	return service.NewDIDCommContext(MYDID, THEIRDID, map[string]interface{}{transport.SessionProperty: sess})
+}
+
+type mockSession struct {
+	done chan struct{}
+}
+
+func (m *mockSession) Send([]byte) error {
+	return nil
+}
+
+func (m *mockSession) Done() <-chan struct{} {
+	return m.done
+}
+
+func getServiceV3(t *testing.T, sent chan service.DIDCommMsgMap, sendErr error) *Service {
+	t.Helper()
+
+	provider := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				if sendErr != nil {
+					return sendErr
+				}
+
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+
+				msgMap, ok := msg.(service.DIDCommMsgMap)
+				require.True(t, ok)
+
+				// round trip through JSON as the transport would
+				b, err := json.Marshal(msgMap)
+				require.NoError(t, err)
+
+				msgMap, err = service.ParseDIDCommMsgMap(b)
+				require.NoError(t, err)
+
+				sent <- msgMap
+
+				return nil
+			},
+		},
+		PackagerValue: &mockPackager{},
+	}
+
+	r, err := connection.NewRecorder(provider)
+	require.NoError(t, err)
+
+	err = r.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "completed",
+	})
+	require.NoError(t, err)
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	svc.msgHandler = func(*transport.Envelope) error { return nil }
+
+	return svc
+}
@@ -39,6 +39,27 @@ const (
 	BatchMsgType = Spec + "batch"
 	// NoopMsgType defines the protocol request-credential message type.
 	NoopMsgType = Spec + "noop"
+
+	// SpecV3 defines the Pickup 3.0 protocol spec.
+	SpecV3 = "https://didcomm.org/messagepickup/3.0/"
+	// StatusRequestMsgTypeV3 defines the Pickup 3.0 status-request message type.
+	StatusRequestMsgTypeV3 = SpecV3 + "status-request"
+	// StatusMsgTypeV3 defines the Pickup 3.0 status message type.
+	StatusMsgTypeV3 = SpecV3 + "status"
+	// DeliveryRequestMsgType defines the Pickup 3.0 delivery-request message type.
+	DeliveryRequestMsgType = SpecV3 + "delivery-request"
+	// DeliveryMsgType defines the Pickup 3.0 delivery message type.
+	DeliveryMsgType = SpecV3 + "delivery"
+	// MessagesReceivedMsgType defines the Pickup 3.0 messages-received message type.
+	MessagesReceivedMsgType = SpecV3 + "messages-received"
+	// LiveDeliveryChangeMsgType defines the Pickup 3.0 live-delivery-change message type.
+	LiveDeliveryChangeMsgType = SpecV3 + "live-delivery-change"
+	// ProblemReportMsgTypeV3 defines the DIDComm V2 problem report message type sent by the Pickup 3.0 mediator.
+	ProblemReportMsgTypeV3 = "https://didcomm.org/report-problem/2.0/problem-report"
+
+	// LiveModeNotSupportedCode is the problem report code sent when live delivery is requested outside of a
+	// duplex transport session.
+	LiveModeNotSupportedCode = "e.m.live-mode-not-supported"
 )
 
 const (
@@ -79,6 +100,11 @@ type Service struct {
 	batchMapLock     sync.RWMutex
 	statusMap        map[string]chan Status
 	statusMapLock    sync.RWMutex
+	statusV3Map      map[string]chan StatusV3
+	deliveryMap      map[string]chan Delivery
+	v3MapLock        sync.RWMutex
+	liveSessions     map[string]*liveSession
+	liveSessionsLock sync.RWMutex
 	inboxLock        sync.Mutex
 	initialized      bool
 }
@@ -123,6 +149,9 @@ func (s *Service) Initialize(p interface{}) error {
 	s.msgHandler = prov.InboundMessageHandler()
 	s.batchMap = make(map[string]chan Batch)
 	s.statusMap = make(map[string]chan Status)
+	s.statusV3Map = make(map[string]chan StatusV3)
+	s.deliveryMap = make(map[string]chan Delivery)
+	s.liveSessions = make(map[string]*liveSession)
 
 	s.initialized = true
 
@@ -146,6 +175,18 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 			err = s.handleBatch(msg)
 		case NoopMsgType:
 			err = s.handleNoop(msg)
+		case StatusRequestMsgTypeV3:
+			err = s.handleStatusRequestV3(msg, ctx.MyDID(), ctx.TheirDID())
+		case StatusMsgTypeV3:
+			err = s.handleStatusV3(msg)
+		case DeliveryRequestMsgType:
+			err = s.handleDeliveryRequest(msg, ctx.MyDID(), ctx.TheirDID())
+		case DeliveryMsgType:
+			err = s.handleDelivery(msg, ctx.MyDID(), ctx.TheirDID())
+		case MessagesReceivedMsgType:
+			err = s.handleMessagesReceived(msg, ctx.TheirDID())
+		case LiveDeliveryChangeMsgType:
+			err = s.handleLiveDeliveryChange(msg, ctx)
 		}
 
 		if err != nil {
@@ -164,13 +205,21 @@ func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, err
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType:
+	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType,
+		StatusRequestMsgTypeV3, StatusMsgTypeV3, DeliveryRequestMsgType, DeliveryMsgType,
+		MessagesReceivedMsgType, LiveDeliveryChangeMsgType:
 		return true
 	}
 
 	return false
 }
 
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{Spec, SpecV3}
+}
+
 // Name of the service.
 func (s *Service) Name() string {
 	return MessagePickup
@@ -209,7 +258,19 @@ func (s *Service) handleStatusRequest(msg service.DIDCommMsg, myDID, theirDID st
 
 	logger.Debugf("retrieving stored messages for %s\n", theirDID)
 
-	outbox, err := s.getInbox(theirDID)
+	usesV3, err := s.isRecipientV3(theirDID)
+	if err != nil {
+		return fmt.Errorf("status request check pickup version: %w", err)
+	}
+
+	var outbox *inbox
+
+	if usesV3 {
+		outbox, err = s.queueInbox(theirDID)
+	} else {
+		outbox, err = s.getInbox(theirDID)
+	}
+
 	if err != nil {
 		return fmt.Errorf("error in status request getting inbox: %w", err)
 	}
@@ -253,36 +314,25 @@ func (s *Service) handleBatchPickup(msg service.DIDCommMsg, myDID, theirDID stri
 		return fmt.Errorf("batch pickup message unmarshal : %w", err)
 	}
 
-	outbox, err := s.getInbox(theirDID)
-	if err != nil {
-		return fmt.Errorf("batch pickup get inbox: %w", err)
-	}
-
-	msgs, err := outbox.DecodeMessages()
+	usesV3, err := s.isRecipientV3(theirDID)
 	if err != nil {
-		return fmt.Errorf("batch pickup decode : %w", err)
+		return fmt.Errorf("batch pickup check pickup version: %w", err)
 	}
 
-	end := len(msgs)
-	if request.BatchSize < end {
-		end = request.BatchSize
-	}
-
-	outbox.LastDeliveredTime = time.Now()
-	outbox.LastRemovedTime = time.Now()
-
-	err = outbox.EncodeMessages(msgs[end:])
-	if err != nil {
-		return fmt.Errorf("batch pickup encode: %w", err)
-	}
+	var msgs []*Message
 
-	err = s.putInbox(theirDID, outbox)
-	if err != nil {
-		return fmt.Errorf("batch pick up put inbox: %w", err)
+	if usesV3 {
+		msgs, err = s.pickupQueuedMessages(theirDID, request.BatchSize)
+		if err != nil {
+			return fmt.Errorf("batch pickup queued messages: %w", err)
+		}
+	} else {
+		msgs, err = s.pickupInboxMessages(theirDID, request.BatchSize)
+		if err != nil {
+			return err
+		}
 	}
 
-	msgs = msgs[0:end]
-
 	batch := Batch{
 		Type:     BatchMsgType,
 		ID:       msg.ID(),
@@ -302,6 +352,39 @@ func (s *Service) handleBatchPickup(msg service.DIDCommMsg, myDID, theirDID stri
 	return s.outbound.SendToDID(msgMap, myDID, theirDID)
 }
 
+// pickupInboxMessages removes up to batchSize messages from the legacy inbox of the recipient and returns them.
+func (s *Service) pickupInboxMessages(theirDID string, batchSize int) ([]*Message, error) {
+	outbox, err := s.getInbox(theirDID)
+	if err != nil {
+		return nil, fmt.Errorf("batch pickup get inbox: %w", err)
+	}
+
+	msgs, err := outbox.DecodeMessages()
+	if err != nil {
+		return nil, fmt.Errorf("batch pickup decode : %w", err)
+	}
+
+	end := len(msgs)
+	if batchSize < end {
+		end = batchSize
+	}
+
+	outbox.LastDeliveredTime = time.Now()
+	outbox.LastRemovedTime = time.Now()
+
+	err = outbox.EncodeMessages(msgs[end:])
+	if err != nil {
+		return nil, fmt.Errorf("batch pickup encode: %w", err)
+	}
+
+	err = s.putInbox(theirDID, outbox)
+	if err != nil {
+		return nil, fmt.Errorf("batch pick up put inbox: %w", err)
+	}
+
+	return msgs[0:end], nil
+}
+
 func (s *Service) handleBatch(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	batchMsg := &Batch{}
@@ -371,11 +454,27 @@ func (r *inbox) EncodeMessages(msg []*Message) error {
 	return nil
 }
 
-// AddMessage add message to inbox.
+// AddMessage add message to inbox. Messages for recipients using Pickup 3.0 are pushed over their live
+// session when live delivery is enabled, or queued until the recipient acknowledges them otherwise.
 func (s *Service) AddMessage(message []byte, theirDID string) error {
+	return s.AddMessageForRecipient(message, theirDID, "")
+}
+
+// AddMessageForRecipient adds a message addressed to recipientDID to the inbox of theirDID, so that Pickup 3.0
+// requests restricted to recipientDID return it. Legacy inboxes ignore the recipient DID.
+func (s *Service) AddMessageForRecipient(message []byte, theirDID, recipientDID string) error {
 	s.inboxLock.Lock()
 	defer s.inboxLock.Unlock()
 
+	usesV3, err := s.isRecipientV3(theirDID)
+	if err != nil {
+		return fmt.Errorf("unable to check pickup version: %w", err)
+	}
+
+	if usesV3 {
+		return s.addMessageV3(message, theirDID, recipientDID)
+	}
+
 	outbox, err := s.createInbox(theirDID)
 	if err != nil {
 		return fmt.Errorf("unable to pull messages: %w", err)
@@ -0,0 +1,165 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+)
+
+const mimeTypeApplicationJSON = "application/json"
+
+// errFormatNotFound is returned when a message has no attachment of the requested format.
+var errFormatNotFound = errors.New("format not found")
+
+// AddCredentialOffer creates a CL credential offer of the given credential definition with the issuer
+// and attaches it to the offer-credential message in the hlindy/cred-abstract@v2.0 format.
+//
+// The attributes of the credential are taken from the credential preview of the message.
+func AddCredentialOffer(msg *issuecredential.OfferCredentialV2, issuer cl.Issuer, schemaID, credDefID string) error {
+	offer, err := issuer.OfferCredential()
+	if err != nil {
+		return fmt.Errorf("anoncreds: offer credential: %w", err)
+	}
+
+	attachment, err := newAttachment(&CredentialOffer{
+		SchemaID:  schemaID,
+		CredDefID: credDefID,
+		Nonce:     offer.Nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("anoncreds: %w", err)
+	}
+
+	msg.Formats = append(msg.Formats, issuecredential.Format{AttachID: attachment.ID, Format: CredentialAbstractFormat})
+	msg.OffersAttach = append(msg.OffersAttach, *attachment)
+
+	return nil
+}
+
+// AddProofRequest sets a nonce generated by the verifier on the proof request and attaches it to the
+// request-presentation message in the hlindy/proof-req@v2.0 format.
+func AddProofRequest(msg *presentproof.RequestPresentationV2, verifier cl.Verifier, request *ProofRequest) error {
+	_, items, err := request.subProofs()
+	if err != nil {
+		return fmt.Errorf("anoncreds: %w", err)
+	}
+
+	presentationRequest, err := verifier.RequestPresentation(items)
+	if err != nil {
+		return fmt.Errorf("anoncreds: request presentation: %w", err)
+	}
+
+	request.Nonce = presentationRequest.Nonce
+
+	attachment, err := newAttachment(request)
+	if err != nil {
+		return fmt.Errorf("anoncreds: %w", err)
+	}
+
+	msg.Formats = append(msg.Formats, presentproof.Format{AttachID: attachment.ID, Format: ProofRequestFormat})
+	msg.RequestPresentationsAttach = append(msg.RequestPresentationsAttach, *attachment)
+
+	return nil
+}
+
+func newAttachment(payload interface{}) (*decorator.Attachment, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal attachment contents: %w", err)
+	}
+
+	return &decorator.Attachment{
+		ID:       uuid.New().String(),
+		MimeType: mimeTypeApplicationJSON,
+		Data: decorator.AttachmentData{
+			Base64: base64.StdEncoding.EncodeToString(raw),
+		},
+	}, nil
+}
+
+type attachmentFormat struct {
+	attachID string
+	format   string
+}
+
+func issueCredentialFormats(formats []issuecredential.Format) []attachmentFormat {
+	result := make([]attachmentFormat, len(formats))
+
+	for i := range formats {
+		result[i] = attachmentFormat{attachID: formats[i].AttachID, format: formats[i].Format}
+	}
+
+	return result
+}
+
+func presentProofFormats(formats []presentproof.Format) []attachmentFormat {
+	result := make([]attachmentFormat, len(formats))
+
+	for i := range formats {
+		result[i] = attachmentFormat{attachID: formats[i].AttachID, format: formats[i].Format}
+	}
+
+	return result
+}
+
+func hasFormat(formats []attachmentFormat, format string) bool {
+	for _, f := range formats {
+		if f.format == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readAttachment unmarshals the contents of the attachment of the given format into v.
+// It returns errFormatNotFound if the formats do not contain the given format.
+func readAttachment(format string, formats []attachmentFormat, attachments []decorator.Attachment,
+	v interface{}) error {
+	var attachID string
+
+	for _, f := range formats {
+		if f.format == format {
+			attachID = f.attachID
+
+			break
+		}
+	}
+
+	if attachID == "" {
+		return errFormatNotFound
+	}
+
+	for i := range attachments {
+		if attachments[i].ID != attachID {
+			continue
+		}
+
+		contents, err := attachments[i].Data.Fetch()
+		if err != nil {
+			return fmt.Errorf("fetch %s attachment: %w", format, err)
+		}
+
+		if err = json.Unmarshal(contents, v); err != nil {
+			return fmt.Errorf("unmarshal %s attachment: %w", format, err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no attachment with ID '%s' for format %s", attachID, format)
+}
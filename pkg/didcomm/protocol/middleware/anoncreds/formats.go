@@ -0,0 +1,244 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+)
+
+const (
+	// CredentialAbstractFormat is the attachment format of the AnonCreds credential offer.
+	CredentialAbstractFormat = "hlindy/cred-abstract@v2.0"
+	// CredentialRequestFormat is the attachment format of the AnonCreds credential request.
+	CredentialRequestFormat = "hlindy/cred-req@v2.0"
+	// CredentialFormat is the attachment format of the AnonCreds credential.
+	CredentialFormat = "hlindy/cred@v2.0"
+	// ProofRequestFormat is the attachment format of the AnonCreds proof request.
+	ProofRequestFormat = "hlindy/proof-req@v2.0"
+	// ProofFormat is the attachment format of the AnonCreds proof.
+	ProofFormat = "hlindy/proof@v2.0"
+)
+
+// CredentialOffer is the payload of the hlindy/cred-abstract@v2.0 attachment.
+type CredentialOffer struct {
+	SchemaID  string `json:"schema_id,omitempty"`
+	CredDefID string `json:"cred_def_id"`
+	Nonce     []byte `json:"nonce"`
+}
+
+// CredentialRequest is the payload of the hlindy/cred-req@v2.0 attachment.
+// The blinding factor of the blinded master secret never leaves the prover.
+type CredentialRequest struct {
+	ProverDID                 string `json:"prover_did"`
+	CredDefID                 string `json:"cred_def_id"`
+	BlindedMS                 []byte `json:"blinded_ms"`
+	BlindedMSCorrectnessProof []byte `json:"blinded_ms_correctness_proof"`
+	Nonce                     []byte `json:"nonce"`
+}
+
+// Credential is the payload of the hlindy/cred@v2.0 attachment.
+type Credential struct {
+	SchemaID                  string                     `json:"schema_id,omitempty"`
+	CredDefID                 string                     `json:"cred_def_id"`
+	Values                    map[string]CredentialValue `json:"values"`
+	Signature                 []byte                     `json:"signature"`
+	SignatureCorrectnessProof []byte                     `json:"signature_correctness_proof"`
+}
+
+// CredentialValue is the raw value of a credential attribute along with its AnonCreds encoding.
+type CredentialValue struct {
+	Raw     string `json:"raw"`
+	Encoded string `json:"encoded"`
+}
+
+// ProofRequest is the payload of the hlindy/proof-req@v2.0 attachment.
+//
+// Every requested attribute and predicate must be restricted to exactly one credential definition,
+// the attributes and predicates of the same credential definition are proven by a single credential.
+type ProofRequest struct {
+	Name                string                    `json:"name,omitempty"`
+	Version             string                    `json:"version,omitempty"`
+	Nonce               []byte                    `json:"nonce"`
+	RequestedAttributes map[string]*AttributeInfo `json:"requested_attributes"`
+	RequestedPredicates map[string]*PredicateInfo `json:"requested_predicates,omitempty"`
+}
+
+// AttributeInfo describes an attribute requested to be revealed.
+type AttributeInfo struct {
+	Name         string        `json:"name"`
+	Restrictions []Restriction `json:"restrictions"`
+}
+
+// PredicateInfo describes a predicate requested to be proven, p_type is one of ">=", "<=", ">" or "<".
+type PredicateInfo struct {
+	Name         string        `json:"name"`
+	PType        string        `json:"p_type"`
+	PValue       int32         `json:"p_value"`
+	Restrictions []Restriction `json:"restrictions"`
+}
+
+// Restriction restricts the credentials that may be used to satisfy a requested attribute or predicate.
+type Restriction struct {
+	CredDefID string `json:"cred_def_id"`
+}
+
+// Proof is the payload of the hlindy/proof@v2.0 attachment.
+type Proof struct {
+	Proof          []byte         `json:"proof"`
+	RequestedProof RequestedProof `json:"requested_proof"`
+	Identifiers    []Identifier   `json:"identifiers"`
+}
+
+// RequestedProof maps the referents of the proof request to the sub proofs of the proof.
+type RequestedProof struct {
+	RevealedAttrs map[string]*RevealedAttribute `json:"revealed_attrs"`
+	Predicates    map[string]*SubProofReference `json:"predicates,omitempty"`
+}
+
+// RevealedAttribute is a revealed attribute value and the sub proof it's revealed by.
+type RevealedAttribute struct {
+	SubProofIndex int    `json:"sub_proof_index"`
+	Raw           string `json:"raw"`
+	Encoded       string `json:"encoded"`
+}
+
+// SubProofReference refers to the sub proof of a predicate.
+type SubProofReference struct {
+	SubProofIndex int `json:"sub_proof_index"`
+}
+
+// Identifier identifies the credential definition of a sub proof.
+type Identifier struct {
+	SchemaID  string `json:"schema_id,omitempty"`
+	CredDefID string `json:"cred_def_id"`
+}
+
+var predicateTypes = map[string]string{ // nolint: gochecknoglobals
+	">=": "GE",
+	"<=": "LE",
+	">":  "GT",
+	"<":  "LT",
+}
+
+// subProofs returns the credential definitions and the matching CL presentation request items of the proof request,
+// sorted by credential definition ID.
+func (r *ProofRequest) subProofs() ([]string, []*cl.PresentationRequestItem, error) {
+	if len(r.RequestedAttributes) == 0 && len(r.RequestedPredicates) == 0 {
+		return nil, nil, fmt.Errorf("proof request has no requested attributes or predicates")
+	}
+
+	items := map[string]*cl.PresentationRequestItem{}
+
+	item := func(credDefID string) *cl.PresentationRequestItem {
+		if _, ok := items[credDefID]; !ok {
+			items[credDefID] = &cl.PresentationRequestItem{}
+		}
+
+		return items[credDefID]
+	}
+
+	for _, referent := range sortedKeys(r.RequestedAttributes) {
+		attr := r.RequestedAttributes[referent]
+
+		credDefID, err := restrictedCredDefID(referent, attr.Restrictions)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		item(credDefID).RevealedAttrs = append(item(credDefID).RevealedAttrs, attr.Name)
+	}
+
+	for _, referent := range sortedKeys(r.RequestedPredicates) {
+		predicate := r.RequestedPredicates[referent]
+
+		credDefID, err := restrictedCredDefID(referent, predicate.Restrictions)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pType, ok := predicateTypes[predicate.PType]
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported predicate type '%s' of referent '%s'", predicate.PType, referent)
+		}
+
+		item(credDefID).Predicates = append(item(credDefID).Predicates, &cl.Predicate{
+			Attr:  predicate.Name,
+			PType: pType,
+			Value: predicate.PValue,
+		})
+	}
+
+	credDefIDs := sortedKeys(items)
+	result := make([]*cl.PresentationRequestItem, len(credDefIDs))
+
+	for i, credDefID := range credDefIDs {
+		result[i] = items[credDefID]
+	}
+
+	return credDefIDs, result, nil
+}
+
+func restrictedCredDefID(referent string, restrictions []Restriction) (string, error) {
+	if len(restrictions) != 1 || restrictions[0].CredDefID == "" {
+		return "", fmt.Errorf("referent '%s' must be restricted to exactly one credential definition", referent)
+	}
+
+	return restrictions[0].CredDefID, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// encodeValue encodes the raw attribute value as AnonCreds does: 32-bit integers are kept as is,
+// any other value is encoded as the decimal representation of its SHA-256 hash.
+func encodeValue(raw string) string {
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil && i >= math.MinInt32 && i <= math.MaxInt32 {
+		return raw
+	}
+
+	hash := sha256.Sum256([]byte(raw))
+
+	return new(big.Int).SetBytes(hash[:]).String()
+}
+
+func toCredentialValues(values map[string]interface{}) map[string]CredentialValue {
+	result := make(map[string]CredentialValue, len(values))
+
+	for name, value := range values {
+		raw := fmt.Sprint(value)
+
+		result[name] = CredentialValue{Raw: raw, Encoded: encodeValue(raw)}
+	}
+
+	return result
+}
+
+func fromCredentialValues(values map[string]CredentialValue) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+
+	for name, value := range values {
+		result[name] = value.Raw
+	}
+
+	return result
+}
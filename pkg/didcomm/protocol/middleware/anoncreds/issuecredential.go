@@ -0,0 +1,342 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+)
+
+const (
+	stateNameOfferSent          = "offer-sent"
+	stateNameRequestReceived    = "request-received"
+	stateNameOfferReceived      = "offer-received"
+	stateNameCredentialReceived = "credential-received"
+	namesKey                    = "names"
+)
+
+// IssueCredential returns the issue-credential middleware handling the AnonCreds attachment formats.
+//
+// Issuer (WithIssuer): the offer attached with AddCredentialOffer is kept when the offer is sent and the
+// credential is issued with the values of the credential preview of the offer when the request is accepted
+// with an issue-credential message.
+//
+// Prover (WithProver): a credential request is attached to the request-credential message the offer is accepted
+// with, and the received credential is processed and saved in the CredentialStore instead of the verifiable store.
+func IssueCredential(p Provider, opts ...Opt) (issuecredential.Middleware, error) {
+	m, err := newMiddleware(p, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			if strings.HasPrefix(metadata.Message().Type(), issuecredential.SpecV3) {
+				return next.Handle(metadata)
+			}
+
+			var err error
+
+			switch metadata.StateName() {
+			case stateNameOfferSent:
+				err = m.saveOffer(metadata)
+			case stateNameRequestReceived:
+				err = m.issueCredential(metadata)
+			case stateNameOfferReceived:
+				err = m.requestCredential(metadata)
+			case stateNameCredentialReceived:
+				err = m.saveCredential(metadata)
+			}
+
+			if err != nil {
+				return fmt.Errorf("anoncreds: %w", err)
+			}
+
+			return next.Handle(metadata)
+		})
+	}, nil
+}
+
+func (m *middleware) saveOffer(metadata issuecredential.Metadata) error {
+	if len(m.issuers) == 0 {
+		return nil
+	}
+
+	offerMsg := metadata.OfferCredentialV2()
+	if offerMsg == nil {
+		// the issuer starts the protocol with the offer.
+		offerMsg = &issuecredential.OfferCredentialV2{}
+
+		if err := metadata.Message().Decode(offerMsg); err != nil {
+			return fmt.Errorf("decode offer credential: %w", err)
+		}
+	}
+
+	offer := &CredentialOffer{}
+
+	err := readAttachment(CredentialAbstractFormat, issueCredentialFormats(offerMsg.Formats), offerMsg.OffersAttach,
+		offer)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := m.issuers[offer.CredDefID]; !ok {
+		return fmt.Errorf("no issuer of credential definition '%s'", offer.CredDefID)
+	}
+
+	values := map[string]interface{}{}
+
+	for _, attr := range offerMsg.CredentialPreview.Attributes {
+		values[attr.Name] = attr.Value
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	return putJSON(m.state, offerKey(thID), &offerRecord{Offer: offer, Values: values})
+}
+
+func (m *middleware) issueCredential(metadata issuecredential.Metadata) error {
+	credentialMsg := metadata.IssueCredentialV2()
+	if len(m.issuers) == 0 || credentialMsg == nil ||
+		hasFormat(issueCredentialFormats(credentialMsg.Formats), CredentialFormat) {
+		return nil
+	}
+
+	requestMsg := &issuecredential.RequestCredentialV2{}
+	if err := metadata.Message().Decode(requestMsg); err != nil {
+		return fmt.Errorf("decode request credential: %w", err)
+	}
+
+	request := &CredentialRequest{}
+
+	err := readAttachment(CredentialRequestFormat, issueCredentialFormats(requestMsg.Formats),
+		requestMsg.RequestsAttach, request)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	record := &offerRecord{}
+	if err = getJSON(m.state, offerKey(thID), record); err != nil {
+		return fmt.Errorf("get credential offer of thread '%s': %w", thID, err)
+	}
+
+	if request.CredDefID != record.Offer.CredDefID {
+		return fmt.Errorf("credential request of credential definition '%s' does not match the offer of '%s'",
+			request.CredDefID, record.Offer.CredDefID)
+	}
+
+	issuer, ok := m.issuers[request.CredDefID]
+	if !ok {
+		return fmt.Errorf("no issuer of credential definition '%s'", request.CredDefID)
+	}
+
+	credential, err := issuer.IssueCredential(record.Values, &cl.CredentialRequest{
+		BlindedCredentialSecrets: &cl.BlindedCredentialSecrets{
+			Handle:           request.BlindedMS,
+			CorrectnessProof: request.BlindedMSCorrectnessProof,
+		},
+		Nonce:    request.Nonce,
+		ProverID: request.ProverDID,
+	}, &cl.CredentialOffer{Nonce: record.Offer.Nonce})
+	if err != nil {
+		return fmt.Errorf("issue credential: %w", err)
+	}
+
+	attachment, err := newAttachment(&Credential{
+		SchemaID:                  record.Offer.SchemaID,
+		CredDefID:                 record.Offer.CredDefID,
+		Values:                    toCredentialValues(credential.Values),
+		Signature:                 credential.Signature,
+		SignatureCorrectnessProof: credential.SigProof,
+	})
+	if err != nil {
+		return err
+	}
+
+	credentialMsg.Formats = append(credentialMsg.Formats, issuecredential.Format{
+		AttachID: attachment.ID,
+		Format:   CredentialFormat,
+	})
+	credentialMsg.CredentialsAttach = append(credentialMsg.CredentialsAttach, *attachment)
+
+	return m.state.Delete(offerKey(thID))
+}
+
+func (m *middleware) requestCredential(metadata issuecredential.Metadata) error {
+	if m.prover == nil || metadata.ProposeCredentialV2() != nil {
+		return nil
+	}
+
+	offerMsg := &issuecredential.OfferCredentialV2{}
+	if err := metadata.Message().Decode(offerMsg); err != nil {
+		return fmt.Errorf("decode offer credential: %w", err)
+	}
+
+	offer := &CredentialOffer{}
+
+	err := readAttachment(CredentialAbstractFormat, issueCredentialFormats(offerMsg.Formats), offerMsg.OffersAttach,
+		offer)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	requestMsg := metadata.RequestCredentialV2()
+	if requestMsg == nil {
+		return errors.New("request credential was not provided")
+	}
+
+	if hasFormat(issueCredentialFormats(requestMsg.Formats), CredentialRequestFormat) {
+		return nil
+	}
+
+	credDefs, err := m.credentialDefinitions(offer.CredDefID)
+	if err != nil {
+		return err
+	}
+
+	request, err := m.prover.RequestCredential(&cl.CredentialOffer{Nonce: offer.Nonce}, credDefs[0], m.proverID)
+	if err != nil {
+		return fmt.Errorf("request credential: %w", err)
+	}
+
+	attachment, err := newAttachment(&CredentialRequest{
+		ProverDID:                 request.ProverID,
+		CredDefID:                 offer.CredDefID,
+		BlindedMS:                 request.BlindedCredentialSecrets.Handle,
+		BlindedMSCorrectnessProof: request.BlindedCredentialSecrets.CorrectnessProof,
+		Nonce:                     request.Nonce,
+	})
+	if err != nil {
+		return err
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	err = putJSON(m.state, requestKey(thID), &requestRecord{
+		SchemaID:  offer.SchemaID,
+		CredDefID: offer.CredDefID,
+		Request:   request,
+	})
+	if err != nil {
+		return fmt.Errorf("save credential request: %w", err)
+	}
+
+	requestMsg.Formats = append(requestMsg.Formats, issuecredential.Format{
+		AttachID: attachment.ID,
+		Format:   CredentialRequestFormat,
+	})
+	requestMsg.RequestsAttach = append(requestMsg.RequestsAttach, *attachment)
+
+	return nil
+}
+
+func (m *middleware) saveCredential(metadata issuecredential.Metadata) error {
+	if m.prover == nil {
+		return nil
+	}
+
+	credentialMsg := &issuecredential.IssueCredentialV2{}
+	if err := metadata.Message().Decode(credentialMsg); err != nil {
+		return fmt.Errorf("decode issue credential: %w", err)
+	}
+
+	credential := &Credential{}
+
+	err := readAttachment(CredentialFormat, issueCredentialFormats(credentialMsg.Formats),
+		credentialMsg.CredentialsAttach, credential)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	record := &requestRecord{}
+	if err = getJSON(m.state, requestKey(thID), record); err != nil {
+		return fmt.Errorf("get credential request of thread '%s': %w", thID, err)
+	}
+
+	if credential.CredDefID != record.CredDefID {
+		return fmt.Errorf("credential of credential definition '%s' does not match the request of '%s'",
+			credential.CredDefID, record.CredDefID)
+	}
+
+	credDefs, err := m.credentialDefinitions(record.CredDefID)
+	if err != nil {
+		return err
+	}
+
+	processed, err := m.prover.ProcessCredential(&cl.Credential{
+		Signature: credential.Signature,
+		Values:    fromCredentialValues(credential.Values),
+		SigProof:  credential.SignatureCorrectnessProof,
+	}, record.Request, credDefs[0])
+	if err != nil {
+		return fmt.Errorf("process credential: %w", err)
+	}
+
+	name := uuid.New().String()
+	if names := metadata.CredentialNames(); len(names) > 0 && names[0] != "" {
+		name = names[0]
+	}
+
+	err = m.credentials.Save(&CredentialRecord{
+		Name:       name,
+		SchemaID:   record.SchemaID,
+		CredDefID:  record.CredDefID,
+		Credential: processed,
+	})
+	if err != nil {
+		return fmt.Errorf("save credential: %w", err)
+	}
+
+	properties := metadata.Properties()
+	properties[namesKey] = []string{name}
+
+	// the AnonCreds credential is not a verifiable credential the default middleware could save.
+	if len(credentialMsg.CredentialsAttach) == 1 {
+		properties[mdissuecredential.SkipCredentialSaveKey] = true
+	}
+
+	return m.state.Delete(requestKey(thID))
+}
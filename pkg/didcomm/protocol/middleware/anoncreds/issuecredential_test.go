@@ -0,0 +1,278 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
+)
+
+type icMetadata struct {
+	stateName   string
+	msg         service.DIDCommMsgMap
+	offer       *issuecredential.OfferCredentialV2
+	propose     *issuecredential.ProposeCredentialV2
+	request     *issuecredential.RequestCredentialV2
+	credential  *issuecredential.IssueCredentialV2
+	names       []string
+	properties  map[string]interface{}
+	nextInvoked bool
+}
+
+func handleIssueCredential(t *testing.T, mw issuecredential.Middleware, md *icMetadata) error {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	if md.properties == nil {
+		md.properties = map[string]interface{}{}
+	}
+
+	metadata := mocks.NewMockMetadata(ctrl)
+	metadata.EXPECT().StateName().Return(md.stateName).AnyTimes()
+	metadata.EXPECT().Message().Return(md.msg).AnyTimes()
+	metadata.EXPECT().OfferCredentialV2().Return(md.offer).AnyTimes()
+	metadata.EXPECT().ProposeCredentialV2().Return(md.propose).AnyTimes()
+	metadata.EXPECT().RequestCredentialV2().Return(md.request).AnyTimes()
+	metadata.EXPECT().IssueCredentialV2().Return(md.credential).AnyTimes()
+	metadata.EXPECT().CredentialNames().Return(md.names).AnyTimes()
+	metadata.EXPECT().Properties().Return(md.properties).AnyTimes()
+
+	return mw(issuecredential.HandlerFunc(func(issuecredential.Metadata) error {
+		md.nextInvoked = true
+
+		return nil
+	})).Handle(metadata)
+}
+
+func newOffer(t *testing.T) *issuecredential.OfferCredentialV2 {
+	t.Helper()
+
+	offer := &issuecredential.OfferCredentialV2{
+		Type: issuecredential.OfferCredentialMsgTypeV2,
+		CredentialPreview: issuecredential.PreviewCredential{
+			Type: issuecredential.CredentialPreviewMsgTypeV2,
+			Attributes: []issuecredential.Attribute{
+				{Name: "name", Value: "Alice"},
+				{Name: "age", Value: "30"},
+			},
+		},
+	}
+
+	require.NoError(t, AddCredentialOffer(offer, &issuer{}, testSchemaID, testCredDefID))
+
+	return offer
+}
+
+func TestIssueCredential(t *testing.T) {
+	t.Run("issue and save credential", func(t *testing.T) {
+		issuerProvider, holderProvider := newProvider(), newProvider()
+
+		issuerMW, err := IssueCredential(issuerProvider, WithIssuer(testCredDefID, &issuer{}))
+		require.NoError(t, err)
+
+		credDefs, err := NewCredentialDefinitionStore(holderProvider.StorageProvider())
+		require.NoError(t, err)
+
+		credDef, err := (&issuer{}).GetCredentialDefinition()
+		require.NoError(t, err)
+		require.NoError(t, credDefs.Put(testCredDefID, credDef))
+
+		holderMW, err := IssueCredential(holderProvider, WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		offer := newOffer(t)
+		offerMsg := newMsg(offer)
+
+		// the issuer sends the offer.
+		md := &icMetadata{stateName: stateNameOfferSent, msg: offerMsg}
+		require.NoError(t, handleIssueCredential(t, issuerMW, md))
+		require.True(t, md.nextInvoked)
+
+		// the holder accepts the offer.
+		request := &issuecredential.RequestCredentialV2{}
+		md = &icMetadata{stateName: stateNameOfferReceived, msg: offerMsg, request: request}
+		require.NoError(t, handleIssueCredential(t, holderMW, md))
+		require.Len(t, request.RequestsAttach, 1)
+		require.Equal(t, CredentialRequestFormat, request.Formats[0].Format)
+
+		credentialRequest := &CredentialRequest{}
+		require.NoError(t, readAttachment(CredentialRequestFormat, issueCredentialFormats(request.Formats),
+			request.RequestsAttach, credentialRequest))
+		require.Equal(t, &CredentialRequest{
+			ProverDID:                 testProverDID,
+			CredDefID:                 testCredDefID,
+			BlindedMS:                 []byte("blinded ms"),
+			BlindedMSCorrectnessProof: []byte("correctness proof"),
+			Nonce:                     []byte("request nonce"),
+		}, credentialRequest)
+
+		// the issuer accepts the request.
+		credentialMsg := &issuecredential.IssueCredentialV2{}
+		md = &icMetadata{
+			stateName:  stateNameRequestReceived,
+			msg:        newReply(request),
+			credential: credentialMsg,
+		}
+		require.NoError(t, handleIssueCredential(t, issuerMW, md))
+		require.Len(t, credentialMsg.CredentialsAttach, 1)
+
+		credential := &Credential{}
+		require.NoError(t, readAttachment(CredentialFormat, issueCredentialFormats(credentialMsg.Formats),
+			credentialMsg.CredentialsAttach, credential))
+		require.Equal(t, testSchemaID, credential.SchemaID)
+		require.Equal(t, map[string]CredentialValue{
+			"name": {Raw: "Alice", Encoded: encodeValue("Alice")},
+			"age":  {Raw: "30", Encoded: "30"},
+		}, credential.Values)
+
+		// the offer is consumed.
+		md.credential = &issuecredential.IssueCredentialV2{}
+		require.Contains(t, handleIssueCredential(t, issuerMW, md).Error(),
+			"anoncreds: get credential offer of thread 'thread-id'")
+
+		// the holder accepts the credential.
+		md = &icMetadata{
+			stateName: stateNameCredentialReceived,
+			msg:       newReply(credentialMsg),
+			names:     []string{"my credential"},
+		}
+		require.NoError(t, handleIssueCredential(t, holderMW, md))
+		require.Equal(t, true, md.properties[mdissuecredential.SkipCredentialSaveKey])
+		require.Equal(t, []string{"my credential"}, md.properties[namesKey])
+
+		credentials, err := NewCredentialStore(holderProvider.StorageProvider())
+		require.NoError(t, err)
+
+		record, err := credentials.Get("my credential")
+		require.NoError(t, err)
+		require.Equal(t, testCredDefID, record.CredDefID)
+		require.Equal(t, testSchemaID, record.SchemaID)
+		require.Equal(t, []byte("processed signature"), record.Credential.Signature)
+		require.Equal(t, map[string]interface{}{"name": "Alice", "age": "30"}, record.Credential.Values)
+	})
+
+	t.Run("ignores other formats and roles", func(t *testing.T) {
+		mw, err := IssueCredential(newProvider())
+		require.NoError(t, err)
+
+		for _, stateName := range []string{
+			stateNameOfferSent, stateNameRequestReceived, stateNameOfferReceived, stateNameCredentialReceived,
+		} {
+			md := &icMetadata{stateName: stateName, msg: newMsg(newOffer(t))}
+			require.NoError(t, handleIssueCredential(t, mw, md))
+			require.True(t, md.nextInvoked)
+		}
+
+		mw, err = IssueCredential(newProvider(), WithIssuer(testCredDefID, &issuer{}),
+			WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		request := &issuecredential.RequestCredentialV2{}
+
+		for _, md := range []*icMetadata{
+			{stateName: stateNameOfferSent, msg: newMsg(&issuecredential.OfferCredentialV2{})},
+			{stateName: stateNameOfferReceived, msg: newMsg(&issuecredential.OfferCredentialV2{}), request: request},
+			{
+				stateName: stateNameOfferReceived, msg: newMsg(newOffer(t)), request: request,
+				propose: &issuecredential.ProposeCredentialV2{},
+			},
+			{
+				stateName: stateNameRequestReceived, msg: newReply(&issuecredential.RequestCredentialV2{}),
+				credential: &issuecredential.IssueCredentialV2{},
+			},
+			{stateName: stateNameCredentialReceived, msg: newReply(&issuecredential.IssueCredentialV2{})},
+			{stateName: stateNameOfferSent, msg: service.DIDCommMsgMap{"@type": issuecredential.OfferCredentialMsgTypeV3}},
+		} {
+			require.NoError(t, handleIssueCredential(t, mw, md))
+			require.True(t, md.nextInvoked)
+		}
+
+		require.Empty(t, request.RequestsAttach)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		p := newProvider()
+
+		mw, err := IssueCredential(p, WithIssuer("did:sov:other:3:CL:1:default", &issuer{}),
+			WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		err = handleIssueCredential(t, mw, &icMetadata{stateName: stateNameOfferSent, msg: newMsg(newOffer(t))})
+		require.EqualError(t, err, "anoncreds: no issuer of credential definition '"+testCredDefID+"'")
+
+		err = handleIssueCredential(t, mw, &icMetadata{
+			stateName: stateNameOfferReceived,
+			msg:       newMsg(newOffer(t)),
+		})
+		require.EqualError(t, err, "anoncreds: request credential was not provided")
+
+		err = handleIssueCredential(t, mw, &icMetadata{
+			stateName: stateNameOfferReceived,
+			msg:       newMsg(newOffer(t)),
+			request:   &issuecredential.RequestCredentialV2{},
+		})
+		require.Contains(t, err.Error(), "anoncreds: get credential definition '"+testCredDefID+"'")
+
+		credentialMsg := &issuecredential.IssueCredentialV2{}
+		attachment, err := newAttachment(&Credential{CredDefID: testCredDefID})
+		require.NoError(t, err)
+
+		credentialMsg.Formats = []issuecredential.Format{{AttachID: attachment.ID, Format: CredentialFormat}}
+		credentialMsg.CredentialsAttach = append(credentialMsg.CredentialsAttach, *attachment)
+
+		err = handleIssueCredential(t, mw, &icMetadata{
+			stateName: stateNameCredentialReceived,
+			msg:       newReply(credentialMsg),
+		})
+		require.Contains(t, err.Error(), "anoncreds: get credential request of thread 'thread-id'")
+
+		credentialMsg.CredentialsAttach[0].ID = "unknown"
+
+		err = handleIssueCredential(t, mw, &icMetadata{
+			stateName: stateNameCredentialReceived,
+			msg:       newReply(credentialMsg),
+		})
+		require.EqualError(t, err, "anoncreds: no attachment with ID '"+attachment.ID+"' for format "+CredentialFormat)
+	})
+
+	t.Run("issue credential error", func(t *testing.T) {
+		mw, err := IssueCredential(newProvider(), WithIssuer(testCredDefID, &issuer{issueErr: errors.New("failed")}))
+		require.NoError(t, err)
+
+		require.NoError(t, handleIssueCredential(t, mw, &icMetadata{
+			stateName: stateNameOfferSent,
+			msg:       newMsg(&issuecredential.ProposeCredentialV2{}),
+			offer:     newOffer(t),
+		}))
+
+		request, err := newAttachment(&CredentialRequest{CredDefID: testCredDefID})
+		require.NoError(t, err)
+
+		requestMsg := &issuecredential.RequestCredentialV2{
+			Formats:        []issuecredential.Format{{AttachID: request.ID, Format: CredentialRequestFormat}},
+			RequestsAttach: []decorator.Attachment{*request},
+		}
+
+		err = handleIssueCredential(t, mw, &icMetadata{
+			stateName:  stateNameRequestReceived,
+			msg:        newReply(requestMsg),
+			credential: &issuecredential.IssueCredentialV2{},
+		})
+		require.EqualError(t, err, "anoncreds: issue credential: failed")
+	})
+}
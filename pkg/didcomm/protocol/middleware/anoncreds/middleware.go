@@ -0,0 +1,192 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package anoncreds provides the issue-credential and present-proof middlewares handling the AnonCreds (CL)
+// attachment formats (hlindy/cred-abstract@v2.0, hlindy/cred-req@v2.0, hlindy/cred@v2.0, hlindy/proof-req@v2.0
+// and hlindy/proof@v2.0) with the CL services of pkg/doc/cl.
+//
+// Usage:
+//
+//	icMiddleware, err := anoncreds.IssueCredential(ctx,
+//	    anoncreds.WithIssuer(credDefID, issuer),
+//	    anoncreds.WithProver(prover, proverDID),
+//	)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	err = anoncreds.RegisterIssueCredentialMiddleware(icMiddleware, ctx)
+//	if err != nil {
+//	    panic(err)
+//	}
+//
+// The middlewares only handle the roles they are configured for (issuer, prover and/or verifier) and
+// ignore messages without AnonCreds attachments, so they can be registered next to the default middlewares.
+package anoncreds
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Provider contains dependencies for the AnonCreds middlewares.
+type Provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// ServiceProvider provides the protocol services the middlewares are registered in.
+type ServiceProvider interface {
+	Service(name string) (interface{}, error)
+}
+
+// Opt configures the AnonCreds middlewares.
+type Opt func(o *options)
+
+type options struct {
+	issuers  map[string]cl.Issuer
+	prover   cl.Prover
+	proverID string
+	verifier cl.Verifier
+	credDefs CredentialDefinitionReader
+}
+
+// WithIssuer enables the issuer role for the given credential definition.
+// It may be used multiple times to issue credentials of several credential definitions.
+func WithIssuer(credDefID string, issuer cl.Issuer) Opt {
+	return func(o *options) {
+		o.issuers[credDefID] = issuer
+	}
+}
+
+// WithProver enables the prover (holder) role, proverID is the DID of the prover sent in credential requests.
+func WithProver(prover cl.Prover, proverID string) Opt {
+	return func(o *options) {
+		o.prover = prover
+		o.proverID = proverID
+	}
+}
+
+// WithVerifier enables the verifier role.
+func WithVerifier(verifier cl.Verifier) Opt {
+	return func(o *options) {
+		o.verifier = verifier
+	}
+}
+
+// WithCredentialDefinitionReader sets the reader resolving the credential definitions of the offered credentials
+// and the received proofs. Defaults to a CredentialDefinitionStore backed by the storage provider.
+func WithCredentialDefinitionReader(reader CredentialDefinitionReader) Opt {
+	return func(o *options) {
+		o.credDefs = reader
+	}
+}
+
+type middleware struct {
+	options
+	state       storage.Store
+	credentials *CredentialStore
+}
+
+func newMiddleware(p Provider, opts []Opt) (*middleware, error) {
+	m := &middleware{options: options{issuers: map[string]cl.Issuer{}}}
+
+	for i := range opts {
+		opts[i](&m.options)
+	}
+
+	var err error
+
+	if m.credDefs == nil {
+		m.credDefs, err = NewCredentialDefinitionStore(p.StorageProvider())
+		if err != nil {
+			return nil, fmt.Errorf("anoncreds: credential definition store: %w", err)
+		}
+	}
+
+	m.credentials, err = NewCredentialStore(p.StorageProvider())
+	if err != nil {
+		return nil, fmt.Errorf("anoncreds: credential store: %w", err)
+	}
+
+	m.state, err = p.ProtocolStateStorageProvider().OpenStore(StateStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("anoncreds: open state store: %w", err)
+	}
+
+	return m, nil
+}
+
+// credentialDefinitions resolves the credential definitions of the given IDs.
+func (m *middleware) credentialDefinitions(credDefIDs ...string) ([]*cl.CredentialDefinition, error) {
+	credDefs := make([]*cl.CredentialDefinition, len(credDefIDs))
+
+	for i, credDefID := range credDefIDs {
+		if issuer, ok := m.issuers[credDefID]; ok {
+			credDef, err := issuer.GetCredentialDefinition()
+			if err != nil {
+				return nil, fmt.Errorf("get credential definition '%s': %w", credDefID, err)
+			}
+
+			credDefs[i] = credDef
+
+			continue
+		}
+
+		credDef, err := m.credDefs.GetCredentialDefinition(credDefID)
+		if err != nil {
+			return nil, err
+		}
+
+		credDefs[i] = credDef
+	}
+
+	return credDefs, nil
+}
+
+// RegisterIssueCredentialMiddleware registers the middleware in the issue-credential service
+// looked up from the ServiceProvider.
+func RegisterIssueCredentialMiddleware(mw issuecredential.Middleware, p ServiceProvider) error {
+	typelessSvc, err := p.Service(issuecredential.Name)
+	if err != nil {
+		return fmt.Errorf("anoncreds: failed to lookup issuecredential service: %w", err)
+	}
+
+	svc, ok := typelessSvc.(interface {
+		AddMiddleware(...issuecredential.Middleware)
+	})
+	if !ok {
+		return errors.New("anoncreds: unable to cast the issuecredential service to the required interface type")
+	}
+
+	svc.AddMiddleware(mw)
+
+	return nil
+}
+
+// RegisterPresentProofMiddleware registers the middleware in the present-proof service
+// looked up from the ServiceProvider.
+func RegisterPresentProofMiddleware(mw presentproof.Middleware, p ServiceProvider) error {
+	typelessSvc, err := p.Service(presentproof.Name)
+	if err != nil {
+		return fmt.Errorf("anoncreds: failed to lookup presentproof service: %w", err)
+	}
+
+	svc, ok := typelessSvc.(interface {
+		AddMiddleware(...presentproof.Middleware)
+	})
+	if !ok {
+		return errors.New("anoncreds: unable to cast the presentproof service to the required interface type")
+	}
+
+	svc.AddMiddleware(mw)
+
+	return nil
+}
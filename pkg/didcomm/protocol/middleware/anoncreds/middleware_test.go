@@ -0,0 +1,313 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	testCredDefID = "did:sov:issuer:3:CL:1:default"
+	testSchemaID  = "did:sov:issuer:2:person:1.0"
+	testProverDID = "did:sov:prover"
+	testThreadID  = "thread-id"
+)
+
+type provider struct {
+	storage      storage.Provider
+	stateStorage storage.Provider
+}
+
+func newProvider() *provider {
+	return &provider{storage: mem.NewProvider(), stateStorage: mem.NewProvider()}
+}
+
+func (p *provider) StorageProvider() storage.Provider {
+	return p.storage
+}
+
+func (p *provider) ProtocolStateStorageProvider() storage.Provider {
+	return p.stateStorage
+}
+
+type serviceProvider struct {
+	svc interface{}
+	err error
+}
+
+func (p *serviceProvider) Service(string) (interface{}, error) {
+	return p.svc, p.err
+}
+
+type issuer struct {
+	issueErr error
+}
+
+func (i *issuer) GetCredentialDefinition() (*cl.CredentialDefinition, error) {
+	return &cl.CredentialDefinition{CredPubKey: []byte("pub key"), Attrs: []string{"name", "age"}}, nil
+}
+
+func (i *issuer) OfferCredential() (*cl.CredentialOffer, error) {
+	return &cl.CredentialOffer{Nonce: []byte("offer nonce")}, nil
+}
+
+func (i *issuer) IssueCredential(values map[string]interface{}, request *cl.CredentialRequest,
+	offer *cl.CredentialOffer) (*cl.Credential, error) {
+	if i.issueErr != nil {
+		return nil, i.issueErr
+	}
+
+	if !bytes.Equal(request.BlindedCredentialSecrets.Handle, []byte("blinded ms")) ||
+		!bytes.Equal(offer.Nonce, []byte("offer nonce")) || request.ProverID != testProverDID {
+		return nil, errors.New("unexpected request or offer")
+	}
+
+	return &cl.Credential{Signature: []byte("signature"), SigProof: []byte("sig proof"), Values: values}, nil
+}
+
+type prover struct {
+	proofErr error
+}
+
+func (p *prover) RequestCredential(offer *cl.CredentialOffer, _ *cl.CredentialDefinition,
+	proverID string) (*cl.CredentialRequest, error) {
+	return &cl.CredentialRequest{
+		BlindedCredentialSecrets: &cl.BlindedCredentialSecrets{
+			Handle:           []byte("blinded ms"),
+			BlindingFactor:   []byte("blinding factor"),
+			CorrectnessProof: []byte("correctness proof"),
+		},
+		Nonce:    []byte("request nonce"),
+		ProverID: proverID,
+	}, nil
+}
+
+func (p *prover) ProcessCredential(credential *cl.Credential, request *cl.CredentialRequest,
+	_ *cl.CredentialDefinition) (*cl.Credential, error) {
+	if !bytes.Equal(request.BlindedCredentialSecrets.BlindingFactor, []byte("blinding factor")) {
+		return nil, errors.New("unexpected request")
+	}
+
+	return &cl.Credential{
+		Signature: append([]byte("processed "), credential.Signature...),
+		Values:    credential.Values,
+		SigProof:  credential.SigProof,
+	}, nil
+}
+
+func (p *prover) CreateProof(request *cl.PresentationRequest, credentials []*cl.Credential,
+	credDefs []*cl.CredentialDefinition) (*cl.Proof, error) {
+	if p.proofErr != nil {
+		return nil, p.proofErr
+	}
+
+	if len(request.Items) != len(credentials) || len(request.Items) != len(credDefs) {
+		return nil, errors.New("not enough credentials")
+	}
+
+	proof := &testProof{Nonce: request.Nonce, RevealedValues: make([]map[string]string, len(request.Items))}
+
+	// the proof reveals the encoded values of the credentials, like a CL proof.
+	for i, item := range request.Items {
+		proof.RevealedValues[i] = map[string]string{}
+
+		for _, attr := range item.RevealedAttrs {
+			proof.RevealedValues[i][attr] = encodeValue(fmt.Sprint(credentials[i].Values[attr]))
+		}
+	}
+
+	proofBytes, err := json.Marshal(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cl.Proof{Proof: proofBytes}, nil
+}
+
+type testProof struct {
+	Nonce          []byte
+	RevealedValues []map[string]string
+}
+
+type verifier struct {
+	verifyErr error
+}
+
+func (v *verifier) RequestPresentation(items []*cl.PresentationRequestItem) (*cl.PresentationRequest, error) {
+	return &cl.PresentationRequest{Items: items, Nonce: []byte("presentation nonce")}, nil
+}
+
+func (v *verifier) VerifyProof(proof *cl.Proof, request *cl.PresentationRequest, _ []*cl.CredentialDefinition) error {
+	if v.verifyErr != nil {
+		return v.verifyErr
+	}
+
+	parsed := &testProof{}
+	if err := json.Unmarshal(proof.Proof, parsed); err != nil || !bytes.Equal(parsed.Nonce, request.Nonce) ||
+		len(parsed.RevealedValues) != len(request.Items) {
+		return errors.New("invalid proof")
+	}
+
+	for i, item := range request.Items {
+		for attr, encoded := range item.RevealedValues {
+			if parsed.RevealedValues[i][attr] != encoded {
+				return fmt.Errorf("unexpected value of attribute '%s'", attr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// newMsg returns the message starting the thread.
+func newMsg(payload interface{}) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(payload)
+	msg["@id"] = testThreadID
+
+	return msg
+}
+
+// newReply returns the message replying in the thread.
+func newReply(payload interface{}) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(payload)
+	msg["@id"] = uuid.New().String()
+	msg["~thread"] = map[string]interface{}{"thid": testThreadID}
+
+	return msg
+}
+
+func TestNewMiddleware(t *testing.T) {
+	t.Run("storage errors", func(t *testing.T) {
+		_, err := IssueCredential(&provider{
+			storage: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		})
+		require.EqualError(t, err, "anoncreds: credential definition store: open store: open error")
+
+		_, err = PresentProof(&provider{
+			storage: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		}, WithCredentialDefinitionReader(&CredentialDefinitionStore{}))
+		require.EqualError(t, err, "anoncreds: credential store: open store: open error")
+
+		_, err = PresentProof(&provider{
+			storage:      mem.NewProvider(),
+			stateStorage: &mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		})
+		require.EqualError(t, err, "anoncreds: open state store: open error")
+	})
+
+	t.Run("register middlewares", func(t *testing.T) {
+		icMiddleware, err := IssueCredential(newProvider())
+		require.NoError(t, err)
+
+		ppMiddleware, err := PresentProof(newProvider())
+		require.NoError(t, err)
+
+		icService := &issuecredential.Service{}
+		require.NoError(t, RegisterIssueCredentialMiddleware(icMiddleware, &serviceProvider{svc: icService}))
+
+		ppService := &presentproof.Service{}
+		require.NoError(t, RegisterPresentProofMiddleware(ppMiddleware, &serviceProvider{svc: ppService}))
+
+		err = RegisterIssueCredentialMiddleware(icMiddleware, &serviceProvider{err: errors.New("not found")})
+		require.EqualError(t, err, "anoncreds: failed to lookup issuecredential service: not found")
+
+		err = RegisterPresentProofMiddleware(ppMiddleware, &serviceProvider{svc: icService})
+		require.EqualError(t, err,
+			"anoncreds: unable to cast the presentproof service to the required interface type")
+	})
+}
+
+func TestCredentialStore(t *testing.T) {
+	store, err := NewCredentialStore(mem.NewProvider())
+	require.NoError(t, err)
+
+	require.EqualError(t, store.Save(&CredentialRecord{}), "credential name is required")
+
+	for _, record := range []*CredentialRecord{
+		{Name: "first", CredDefID: testCredDefID, Credential: &cl.Credential{Signature: []byte("1")}},
+		{Name: "second", CredDefID: testCredDefID, Credential: &cl.Credential{Signature: []byte("2")}},
+		{Name: "other", CredDefID: "did:sov:other:3:CL:1:default", Credential: &cl.Credential{}},
+	} {
+		require.NoError(t, store.Save(record))
+	}
+
+	record, err := store.Get("first")
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), record.Credential.Signature)
+
+	records, err := store.GetByCredentialDefinition(testCredDefID)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	require.NoError(t, store.Delete("first"))
+
+	_, err = store.Get("first")
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	_, err = NewCredentialStore(&mockstorage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")})
+	require.EqualError(t, err, "open store: open error")
+}
+
+func TestProofRequest_SubProofs(t *testing.T) {
+	restrictions := []Restriction{{CredDefID: testCredDefID}}
+	otherRestrictions := []Restriction{{CredDefID: "did:sov:other:3:CL:1:default"}}
+
+	request := &ProofRequest{
+		RequestedAttributes: map[string]*AttributeInfo{
+			"attr2": {Name: "name", Restrictions: restrictions},
+			"attr1": {Name: "degree", Restrictions: otherRestrictions},
+		},
+		RequestedPredicates: map[string]*PredicateInfo{
+			"pred1": {Name: "age", PType: ">=", PValue: 18, Restrictions: restrictions},
+		},
+	}
+
+	credDefIDs, items, err := request.subProofs()
+	require.NoError(t, err)
+	require.Equal(t, []string{testCredDefID, "did:sov:other:3:CL:1:default"}, credDefIDs)
+	require.Equal(t, []*cl.PresentationRequestItem{
+		{RevealedAttrs: []string{"name"}, Predicates: []*cl.Predicate{{Attr: "age", PType: "GE", Value: 18}}},
+		{RevealedAttrs: []string{"degree"}},
+	}, items)
+
+	request.RequestedPredicates["pred1"].PType = "=="
+
+	_, _, err = request.subProofs()
+	require.EqualError(t, err, "unsupported predicate type '==' of referent 'pred1'")
+
+	request.RequestedAttributes["attr1"].Restrictions = nil
+
+	_, _, err = request.subProofs()
+	require.EqualError(t, err, "referent 'attr1' must be restricted to exactly one credential definition")
+
+	_, _, err = (&ProofRequest{}).subProofs()
+	require.EqualError(t, err, "proof request has no requested attributes or predicates")
+}
+
+func TestEncodeValue(t *testing.T) {
+	require.Equal(t, "30", encodeValue("30"))
+	require.Equal(t, "-30", encodeValue("-30"))
+	// as encoded by the Indy SDK.
+	require.Equal(t, "68086943237164982734333428280784300550565381723532936263016368251445461241953",
+		encodeValue("101 Wilson Lane"))
+	require.NotEqual(t, "4294967296", encodeValue("4294967296"))
+}
@@ -0,0 +1,374 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+)
+
+const (
+	stateNameRequestSent          = "request-sent"
+	stateNamePresentationReceived = "presentation-received"
+)
+
+// PresentProof returns the present-proof middleware handling the AnonCreds attachment formats.
+//
+// Verifier (WithVerifier): the proof request attached with AddProofRequest is kept when the request is sent and
+// the received proof is verified against it when the presentation is accepted.
+//
+// Prover (WithProver): when the request is accepted with a presentation, a proof is created from the credentials
+// of the CredentialStore and attached to the presentation.
+//
+// The raw values of the revealed attributes are checked against their encoding, and the CL verifier checks
+// that the proof reveals these encoded values.
+func PresentProof(p Provider, opts ...Opt) (presentproof.Middleware, error) {
+	m, err := newMiddleware(p, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+			if strings.HasPrefix(metadata.Message().Type(), presentproof.SpecV3) {
+				return next.Handle(metadata)
+			}
+
+			var err error
+
+			switch metadata.StateName() {
+			case stateNameRequestSent:
+				err = m.saveProofRequest(metadata)
+			case stateNamePresentationReceived:
+				err = m.verifyProof(metadata)
+			case stateNameRequestReceived:
+				err = m.createProof(metadata)
+			}
+
+			if err != nil {
+				return fmt.Errorf("anoncreds: %w", err)
+			}
+
+			return next.Handle(metadata)
+		})
+	}, nil
+}
+
+func (m *middleware) saveProofRequest(metadata presentproof.Metadata) error {
+	if m.verifier == nil {
+		return nil
+	}
+
+	requestMsg := metadata.RequestPresentation()
+	if requestMsg == nil {
+		// the verifier starts the protocol with the request.
+		requestMsg = &presentproof.RequestPresentationV2{}
+
+		if err := metadata.Message().Decode(requestMsg); err != nil {
+			return fmt.Errorf("decode request presentation: %w", err)
+		}
+	}
+
+	request := &ProofRequest{}
+
+	err := readAttachment(ProofRequestFormat, presentProofFormats(requestMsg.Formats),
+		requestMsg.RequestPresentationsAttach, request)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = request.subProofs(); err != nil {
+		return err
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	return putJSON(m.state, proofRequestKey(thID), request)
+}
+
+func (m *middleware) verifyProof(metadata presentproof.Metadata) error { // nolint: gocyclo
+	if m.verifier == nil {
+		return nil
+	}
+
+	presentationMsg := &presentproof.PresentationV2{}
+	if err := metadata.Message().Decode(presentationMsg); err != nil {
+		return fmt.Errorf("decode presentation: %w", err)
+	}
+
+	proof := &Proof{}
+
+	err := readAttachment(ProofFormat, presentProofFormats(presentationMsg.Formats),
+		presentationMsg.PresentationsAttach, proof)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	thID, err := metadata.Message().ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	request := &ProofRequest{}
+	if err = getJSON(m.state, proofRequestKey(thID), request); err != nil {
+		return fmt.Errorf("get proof request of thread '%s': %w", thID, err)
+	}
+
+	credDefIDs, items, err := request.subProofs()
+	if err != nil {
+		return err
+	}
+
+	if err = checkRequestedProof(request, proof, credDefIDs, items); err != nil {
+		return err
+	}
+
+	credDefs, err := m.credentialDefinitions(credDefIDs...)
+	if err != nil {
+		return err
+	}
+
+	err = m.verifier.VerifyProof(&cl.Proof{Proof: proof.Proof}, &cl.PresentationRequest{
+		Items: items,
+		Nonce: request.Nonce,
+	}, credDefs)
+	if err != nil {
+		return fmt.Errorf("verify proof: %w", err)
+	}
+
+	// the AnonCreds proof is not a verifiable presentation the default middleware could save.
+	if len(presentationMsg.PresentationsAttach) == 1 {
+		metadata.Properties()[mdpresentproof.SkipPresentationSaveKey] = true
+	}
+
+	return m.state.Delete(proofRequestKey(thID))
+}
+
+// checkRequestedProof checks that the proof answers every referent of the request with the expected sub proof,
+// and sets the encoded values of the revealed attributes the CL proof must reveal to the items.
+func checkRequestedProof(request *ProofRequest, proof *Proof, credDefIDs []string, // nolint: gocyclo
+	items []*cl.PresentationRequestItem) error {
+	if len(proof.Identifiers) != len(credDefIDs) {
+		return fmt.Errorf("proof has %d identifiers, expected %d", len(proof.Identifiers), len(credDefIDs))
+	}
+
+	for i, credDefID := range credDefIDs {
+		if proof.Identifiers[i].CredDefID != credDefID {
+			return fmt.Errorf("sub proof %d is of credential definition '%s', expected '%s'",
+				i, proof.Identifiers[i].CredDefID, credDefID)
+		}
+	}
+
+	subProofIndex := subProofIndexes(credDefIDs)
+
+	for referent, attr := range request.RequestedAttributes {
+		revealed, ok := proof.RequestedProof.RevealedAttrs[referent]
+		if !ok {
+			return fmt.Errorf("attribute '%s' is not revealed", referent)
+		}
+
+		i := subProofIndex[attr.Restrictions[0].CredDefID]
+		if revealed.SubProofIndex != i {
+			return fmt.Errorf("attribute '%s' is revealed by an unexpected sub proof", referent)
+		}
+
+		if revealed.Encoded != encodeValue(revealed.Raw) {
+			return fmt.Errorf("attribute '%s' has an invalid encoded value", referent)
+		}
+
+		if !setRevealedValue(items[i], attr.Name, revealed.Encoded) {
+			return fmt.Errorf("attribute '%s' is revealed with another value by another referent", referent)
+		}
+	}
+
+	for referent, predicate := range request.RequestedPredicates {
+		ref, ok := proof.RequestedProof.Predicates[referent]
+		if !ok {
+			return fmt.Errorf("predicate '%s' is not proven", referent)
+		}
+
+		if ref.SubProofIndex != subProofIndex[predicate.Restrictions[0].CredDefID] {
+			return fmt.Errorf("predicate '%s' is proven by an unexpected sub proof", referent)
+		}
+	}
+
+	return nil
+}
+
+// setRevealedValue sets the encoded value of the revealed attribute to the item, unless it has another one.
+func setRevealedValue(item *cl.PresentationRequestItem, attr, encoded string) bool {
+	if item.RevealedValues == nil {
+		item.RevealedValues = map[string]string{}
+	}
+
+	if value, ok := item.RevealedValues[attr]; ok && value != encoded {
+		return false
+	}
+
+	item.RevealedValues[attr] = encoded
+
+	return true
+}
+
+func (m *middleware) createProof(metadata presentproof.Metadata) error {
+	presentationMsg := metadata.Presentation()
+	if m.prover == nil || presentationMsg == nil ||
+		hasFormat(presentProofFormats(presentationMsg.Formats), ProofFormat) {
+		return nil
+	}
+
+	requestMsg := &presentproof.RequestPresentationV2{}
+	if err := metadata.Message().Decode(requestMsg); err != nil {
+		return fmt.Errorf("decode request presentation: %w", err)
+	}
+
+	request := &ProofRequest{}
+
+	err := readAttachment(ProofRequestFormat, presentProofFormats(requestMsg.Formats),
+		requestMsg.RequestPresentationsAttach, request)
+	if errors.Is(err, errFormatNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	credDefIDs, items, err := request.subProofs()
+	if err != nil {
+		return err
+	}
+
+	records := make([]*CredentialRecord, len(credDefIDs))
+	credentials := make([]*cl.Credential, len(credDefIDs))
+
+	for i, credDefID := range credDefIDs {
+		records[i], err = m.findCredential(credDefID, items[i])
+		if err != nil {
+			return err
+		}
+
+		credentials[i] = records[i].Credential
+	}
+
+	credDefs, err := m.credentialDefinitions(credDefIDs...)
+	if err != nil {
+		return err
+	}
+
+	clProof, err := m.prover.CreateProof(&cl.PresentationRequest{Items: items, Nonce: request.Nonce},
+		credentials, credDefs)
+	if err != nil {
+		return fmt.Errorf("create proof: %w", err)
+	}
+
+	attachment, err := newAttachment(newProof(request, clProof, records, credDefIDs))
+	if err != nil {
+		return err
+	}
+
+	presentationMsg.Formats = append(presentationMsg.Formats, presentproof.Format{
+		AttachID: attachment.ID,
+		Format:   ProofFormat,
+	})
+	presentationMsg.PresentationsAttach = append(presentationMsg.PresentationsAttach, *attachment)
+
+	return nil
+}
+
+// findCredential returns the first credential of the credential definition having all the attributes of the item.
+func (m *middleware) findCredential(credDefID string, item *cl.PresentationRequestItem) (*CredentialRecord, error) {
+	records, err := m.credentials.GetByCredentialDefinition(credDefID)
+	if err != nil {
+		return nil, fmt.Errorf("get credentials: %w", err)
+	}
+
+	attrs := append([]string{}, item.RevealedAttrs...)
+	for _, predicate := range item.Predicates {
+		attrs = append(attrs, predicate.Attr)
+	}
+
+	for _, record := range records {
+		if hasAttributes(record.Credential, attrs) {
+			return record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no credential of credential definition '%s' satisfies the proof request", credDefID)
+}
+
+func hasAttributes(credential *cl.Credential, attrs []string) bool {
+	for _, attr := range attrs {
+		if _, ok := credential.Values[attr]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newProof(request *ProofRequest, clProof *cl.Proof, records []*CredentialRecord, credDefIDs []string) *Proof {
+	subProofIndex := subProofIndexes(credDefIDs)
+
+	proof := &Proof{
+		Proof: clProof.Proof,
+		RequestedProof: RequestedProof{
+			RevealedAttrs: map[string]*RevealedAttribute{},
+			Predicates:    map[string]*SubProofReference{},
+		},
+		Identifiers: make([]Identifier, len(records)),
+	}
+
+	for i, record := range records {
+		proof.Identifiers[i] = Identifier{SchemaID: record.SchemaID, CredDefID: record.CredDefID}
+	}
+
+	for referent, attr := range request.RequestedAttributes {
+		i := subProofIndex[attr.Restrictions[0].CredDefID]
+		raw := fmt.Sprint(records[i].Credential.Values[attr.Name])
+
+		proof.RequestedProof.RevealedAttrs[referent] = &RevealedAttribute{
+			SubProofIndex: i,
+			Raw:           raw,
+			Encoded:       encodeValue(raw),
+		}
+	}
+
+	for referent, predicate := range request.RequestedPredicates {
+		proof.RequestedProof.Predicates[referent] = &SubProofReference{
+			SubProofIndex: subProofIndex[predicate.Restrictions[0].CredDefID],
+		}
+	}
+
+	return proof
+}
+
+func subProofIndexes(credDefIDs []string) map[string]int {
+	indexes := make(map[string]int, len(credDefIDs))
+
+	for i, credDefID := range credDefIDs {
+		indexes[credDefID] = i
+	}
+
+	return indexes
+}
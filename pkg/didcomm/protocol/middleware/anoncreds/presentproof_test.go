@@ -0,0 +1,332 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
+)
+
+type ppMetadata struct {
+	stateName    string
+	msg          service.DIDCommMsgMap
+	request      *presentproof.RequestPresentationV2
+	presentation *presentproof.PresentationV2
+	properties   map[string]interface{}
+	nextInvoked  bool
+}
+
+func handlePresentProof(t *testing.T, mw presentproof.Middleware, md *ppMetadata) error {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	if md.properties == nil {
+		md.properties = map[string]interface{}{}
+	}
+
+	metadata := mocks.NewMockMetadata(ctrl)
+	metadata.EXPECT().StateName().Return(md.stateName).AnyTimes()
+	metadata.EXPECT().Message().Return(md.msg).AnyTimes()
+	metadata.EXPECT().RequestPresentation().Return(md.request).AnyTimes()
+	metadata.EXPECT().Presentation().Return(md.presentation).AnyTimes()
+	metadata.EXPECT().Properties().Return(md.properties).AnyTimes()
+
+	return mw(presentproof.HandlerFunc(func(presentproof.Metadata) error {
+		md.nextInvoked = true
+
+		return nil
+	})).Handle(metadata)
+}
+
+func newProofRequest(t *testing.T) *presentproof.RequestPresentationV2 {
+	t.Helper()
+
+	restrictions := []Restriction{{CredDefID: testCredDefID}}
+
+	msg := &presentproof.RequestPresentationV2{Type: presentproof.RequestPresentationMsgTypeV2}
+
+	require.NoError(t, AddProofRequest(msg, &verifier{}, &ProofRequest{
+		Name:    "proof of age",
+		Version: "1.0",
+		RequestedAttributes: map[string]*AttributeInfo{
+			"attr1_referent": {Name: "name", Restrictions: restrictions},
+		},
+		RequestedPredicates: map[string]*PredicateInfo{
+			"pred1_referent": {Name: "age", PType: ">=", PValue: 18, Restrictions: restrictions},
+		},
+	}))
+
+	return msg
+}
+
+func newProverProvider(t *testing.T) *provider {
+	t.Helper()
+
+	p := newProvider()
+
+	credentials, err := NewCredentialStore(p.StorageProvider())
+	require.NoError(t, err)
+
+	require.NoError(t, credentials.Save(&CredentialRecord{
+		Name:      "no age",
+		CredDefID: testCredDefID,
+		Credential: &cl.Credential{
+			Signature: []byte("signature"),
+			Values:    map[string]interface{}{"name": "Bob"},
+		},
+	}))
+	require.NoError(t, credentials.Save(&CredentialRecord{
+		Name:      "alice",
+		SchemaID:  testSchemaID,
+		CredDefID: testCredDefID,
+		Credential: &cl.Credential{
+			Signature: []byte("signature"),
+			Values:    map[string]interface{}{"name": "Alice", "age": "30"},
+		},
+	}))
+
+	credDefs, err := NewCredentialDefinitionStore(p.StorageProvider())
+	require.NoError(t, err)
+	require.NoError(t, credDefs.Put(testCredDefID, &cl.CredentialDefinition{Attrs: []string{"name", "age"}}))
+
+	return p
+}
+
+func TestPresentProof(t *testing.T) {
+	t.Run("create and verify proof", func(t *testing.T) {
+		verifierMW, err := PresentProof(newProvider(), WithIssuer(testCredDefID, &issuer{}),
+			WithVerifier(&verifier{}))
+		require.NoError(t, err)
+
+		proverMW, err := PresentProof(newProverProvider(t), WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		requestMsg := newMsg(newProofRequest(t))
+
+		// the verifier sends the request.
+		md := &ppMetadata{stateName: stateNameRequestSent, msg: requestMsg}
+		require.NoError(t, handlePresentProof(t, verifierMW, md))
+		require.True(t, md.nextInvoked)
+
+		// the prover accepts the request.
+		presentation := &presentproof.PresentationV2{}
+		md = &ppMetadata{stateName: stateNameRequestReceived, msg: requestMsg, presentation: presentation}
+		require.NoError(t, handlePresentProof(t, proverMW, md))
+		require.Len(t, presentation.PresentationsAttach, 1)
+
+		proof := &Proof{}
+		require.NoError(t, readAttachment(ProofFormat, presentProofFormats(presentation.Formats),
+			presentation.PresentationsAttach, proof))
+		require.Equal(t, []Identifier{{SchemaID: testSchemaID, CredDefID: testCredDefID}}, proof.Identifiers)
+		require.Equal(t, &RevealedAttribute{SubProofIndex: 0, Raw: "Alice", Encoded: encodeValue("Alice")},
+			proof.RequestedProof.RevealedAttrs["attr1_referent"])
+		require.Equal(t, &SubProofReference{SubProofIndex: 0}, proof.RequestedProof.Predicates["pred1_referent"])
+
+		// the verifier accepts the presentation.
+		md = &ppMetadata{stateName: stateNamePresentationReceived, msg: newReply(presentation)}
+		require.NoError(t, handlePresentProof(t, verifierMW, md))
+		require.Equal(t, true, md.properties[mdpresentproof.SkipPresentationSaveKey])
+
+		// the proof request is consumed.
+		err = handlePresentProof(t, verifierMW, md)
+		require.Contains(t, err.Error(), "anoncreds: get proof request of thread 'thread-id'")
+	})
+
+	t.Run("ignores other formats and roles", func(t *testing.T) {
+		mw, err := PresentProof(newProvider())
+		require.NoError(t, err)
+
+		for _, stateName := range []string{
+			stateNameRequestSent, stateNamePresentationReceived, stateNameRequestReceived,
+		} {
+			md := &ppMetadata{
+				stateName:    stateName,
+				msg:          newMsg(newProofRequest(t)),
+				presentation: &presentproof.PresentationV2{},
+			}
+			require.NoError(t, handlePresentProof(t, mw, md))
+			require.True(t, md.nextInvoked)
+		}
+
+		mw, err = PresentProof(newProvider(), WithVerifier(&verifier{}), WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		for _, md := range []*ppMetadata{
+			{stateName: stateNameRequestSent, msg: newMsg(&presentproof.RequestPresentationV2{})},
+			{stateName: stateNamePresentationReceived, msg: newReply(&presentproof.PresentationV2{})},
+			{stateName: stateNameRequestReceived, msg: newMsg(newProofRequest(t))},
+			{
+				stateName: stateNameRequestReceived, msg: newMsg(&presentproof.RequestPresentationV2{}),
+				presentation: &presentproof.PresentationV2{},
+			},
+			{
+				stateName: stateNameRequestSent,
+				msg:       service.DIDCommMsgMap{"@type": presentproof.RequestPresentationMsgTypeV3},
+			},
+		} {
+			require.NoError(t, handlePresentProof(t, mw, md))
+			require.True(t, md.nextInvoked)
+		}
+	})
+
+	t.Run("verification errors", func(t *testing.T) {
+		verifierMW, err := PresentProof(newProvider(), WithIssuer(testCredDefID, &issuer{}),
+			WithVerifier(&verifier{verifyErr: errors.New("invalid")}))
+		require.NoError(t, err)
+
+		proverMW, err := PresentProof(newProverProvider(t), WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		requestMsg := newMsg(newProofRequest(t))
+
+		// the verifier sends the request in reply to a proposal.
+		require.NoError(t, handlePresentProof(t, verifierMW, &ppMetadata{
+			stateName: stateNameRequestSent,
+			msg:       newMsg(&presentproof.ProposePresentationV2{}),
+			request:   newProofRequest(t),
+		}))
+
+		presentation := &presentproof.PresentationV2{}
+		require.NoError(t, handlePresentProof(t, proverMW, &ppMetadata{
+			stateName:    stateNameRequestReceived,
+			msg:          requestMsg,
+			presentation: presentation,
+		}))
+
+		md := &ppMetadata{stateName: stateNamePresentationReceived, msg: newReply(presentation)}
+		require.EqualError(t, handlePresentProof(t, verifierMW, md), "anoncreds: verify proof: invalid")
+		require.Nil(t, md.properties[mdpresentproof.SkipPresentationSaveKey])
+
+		proof := &Proof{}
+		require.NoError(t, readAttachment(ProofFormat, presentProofFormats(presentation.Formats),
+			presentation.PresentationsAttach, proof))
+
+		proof.RequestedProof.RevealedAttrs["attr1_referent"].Raw = "Mallory"
+		require.EqualError(t, checkProof(t, proof), "attribute 'attr1_referent' has an invalid encoded value")
+
+		proof.RequestedProof.RevealedAttrs["attr1_referent"].SubProofIndex = 1
+		require.EqualError(t, checkProof(t, proof), "attribute 'attr1_referent' is revealed by an unexpected sub proof")
+
+		delete(proof.RequestedProof.RevealedAttrs, "attr1_referent")
+		require.EqualError(t, checkProof(t, proof), "attribute 'attr1_referent' is not revealed")
+
+		delete(proof.RequestedProof.Predicates, "pred1_referent")
+		proof.RequestedProof.RevealedAttrs["attr1_referent"] = &RevealedAttribute{Raw: "1", Encoded: "1"}
+		require.EqualError(t, checkProof(t, proof), "predicate 'pred1_referent' is not proven")
+
+		proof.Identifiers[0].CredDefID = "did:sov:other:3:CL:1:default"
+		require.EqualError(t, checkProof(t, proof),
+			"sub proof 0 is of credential definition 'did:sov:other:3:CL:1:default', expected '"+testCredDefID+"'")
+
+		proof.Identifiers = nil
+		require.EqualError(t, checkProof(t, proof), "proof has 0 identifiers, expected 1")
+	})
+
+	t.Run("tampered revealed attributes", func(t *testing.T) {
+		verifierMW, err := PresentProof(newProvider(), WithIssuer(testCredDefID, &issuer{}),
+			WithVerifier(&verifier{}))
+		require.NoError(t, err)
+
+		proverMW, err := PresentProof(newProverProvider(t), WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		requestMsg := newMsg(newProofRequest(t))
+		require.NoError(t, handlePresentProof(t, verifierMW, &ppMetadata{stateName: stateNameRequestSent,
+			msg: requestMsg}))
+
+		presentation := &presentproof.PresentationV2{}
+		require.NoError(t, handlePresentProof(t, proverMW, &ppMetadata{
+			stateName:    stateNameRequestReceived,
+			msg:          requestMsg,
+			presentation: presentation,
+		}))
+
+		proof := &Proof{}
+		require.NoError(t, readAttachment(ProofFormat, presentProofFormats(presentation.Formats),
+			presentation.PresentationsAttach, proof))
+
+		// the raw and encoded values are consistent, but not the values revealed by the CL proof.
+		proof.RequestedProof.RevealedAttrs["attr1_referent"].Raw = "Mallory"
+		proof.RequestedProof.RevealedAttrs["attr1_referent"].Encoded = encodeValue("Mallory")
+
+		attachment, err := newAttachment(proof)
+		require.NoError(t, err)
+
+		attachment.ID = presentation.PresentationsAttach[0].ID
+		presentation.PresentationsAttach[0] = *attachment
+
+		md := &ppMetadata{stateName: stateNamePresentationReceived, msg: newReply(presentation)}
+		require.EqualError(t, handlePresentProof(t, verifierMW, md),
+			"anoncreds: verify proof: unexpected value of attribute 'name'")
+		require.False(t, md.nextInvoked)
+
+		// referents revealing the same attribute must reveal the same value.
+		requestPresentation := newProofRequest(t)
+		request := &ProofRequest{}
+		require.NoError(t, readAttachment(ProofRequestFormat, presentProofFormats(requestPresentation.Formats),
+			requestPresentation.RequestPresentationsAttach, request))
+
+		request.RequestedAttributes["attr2_referent"] = request.RequestedAttributes["attr1_referent"]
+		proof.RequestedProof.RevealedAttrs["attr2_referent"] = &RevealedAttribute{Raw: "Alice",
+			Encoded: encodeValue("Alice")}
+
+		credDefIDs, items, err := request.subProofs()
+		require.NoError(t, err)
+		require.ErrorContains(t, checkRequestedProof(request, proof, credDefIDs, items),
+			"is revealed with another value by another referent")
+	})
+
+	t.Run("proof errors", func(t *testing.T) {
+		proverMW, err := PresentProof(newProverProvider(t), WithProver(&prover{proofErr: errors.New("failed")},
+			testProverDID))
+		require.NoError(t, err)
+
+		err = handlePresentProof(t, proverMW, &ppMetadata{
+			stateName:    stateNameRequestReceived,
+			msg:          newMsg(newProofRequest(t)),
+			presentation: &presentproof.PresentationV2{},
+		})
+		require.EqualError(t, err, "anoncreds: create proof: failed")
+
+		proverMW, err = PresentProof(newProvider(), WithProver(&prover{}, testProverDID))
+		require.NoError(t, err)
+
+		err = handlePresentProof(t, proverMW, &ppMetadata{
+			stateName:    stateNameRequestReceived,
+			msg:          newMsg(newProofRequest(t)),
+			presentation: &presentproof.PresentationV2{},
+		})
+		require.EqualError(t, err, "anoncreds: no credential of credential definition '"+testCredDefID+
+			"' satisfies the proof request")
+	})
+}
+
+func checkProof(t *testing.T, proof *Proof) error {
+	t.Helper()
+
+	request := &ProofRequest{}
+	msg := newProofRequest(t)
+
+	require.NoError(t, readAttachment(ProofRequestFormat, presentProofFormats(msg.Formats),
+		msg.RequestPresentationsAttach, request))
+
+	credDefIDs, items, err := request.subProofs()
+	require.NoError(t, err)
+
+	return checkRequestedProof(request, proof, credDefIDs, items)
+}
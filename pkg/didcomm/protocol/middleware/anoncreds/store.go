@@ -0,0 +1,214 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package anoncreds
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// CredentialStoreName is the name of the store of the AnonCreds credentials.
+	CredentialStoreName = "anoncreds_credentials"
+	// CredentialDefinitionStoreName is the name of the store of the CL credential definitions.
+	CredentialDefinitionStoreName = "anoncreds_credential_definitions"
+	// StateStoreName is the name of the protocol state store of the pending offers and requests.
+	StateStoreName = "anoncreds_state"
+
+	credDefIDTagName = "credDefID"
+)
+
+var logger = log.New("aries-framework/didcomm/middleware/anoncreds")
+
+// CredentialDefinitionReader resolves CL credential definitions by their ID (e.g. from a ledger).
+type CredentialDefinitionReader interface {
+	GetCredentialDefinition(credDefID string) (*cl.CredentialDefinition, error)
+}
+
+// CredentialDefinitionStore is a CredentialDefinitionReader backed by a local store.
+type CredentialDefinitionStore struct {
+	store storage.Store
+}
+
+// NewCredentialDefinitionStore returns a new CredentialDefinitionStore.
+func NewCredentialDefinitionStore(p storage.Provider) (*CredentialDefinitionStore, error) {
+	store, err := p.OpenStore(CredentialDefinitionStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	return &CredentialDefinitionStore{store: store}, nil
+}
+
+// Put stores the credential definition under the given ID.
+func (s *CredentialDefinitionStore) Put(credDefID string, credDef *cl.CredentialDefinition) error {
+	return putJSON(s.store, credDefID, credDef)
+}
+
+// GetCredentialDefinition returns the credential definition stored under the given ID.
+func (s *CredentialDefinitionStore) GetCredentialDefinition(credDefID string) (*cl.CredentialDefinition, error) {
+	var credDef *cl.CredentialDefinition
+
+	if err := getJSON(s.store, credDefID, &credDef); err != nil {
+		return nil, fmt.Errorf("get credential definition '%s': %w", credDefID, err)
+	}
+
+	return credDef, nil
+}
+
+// CredentialRecord is an AnonCreds credential held by the prover.
+type CredentialRecord struct {
+	Name       string         `json:"name"`
+	SchemaID   string         `json:"schemaID,omitempty"`
+	CredDefID  string         `json:"credDefID"`
+	Credential *cl.Credential `json:"credential"`
+}
+
+// CredentialStore stores the AnonCreds credentials of the prover.
+type CredentialStore struct {
+	store storage.Store
+}
+
+// NewCredentialStore returns a new CredentialStore.
+func NewCredentialStore(p storage.Provider) (*CredentialStore, error) {
+	store, err := p.OpenStore(CredentialStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	err = p.SetStoreConfig(CredentialStoreName, storage.StoreConfiguration{TagNames: []string{credDefIDTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("set store config: %w", err)
+	}
+
+	return &CredentialStore{store: store}, nil
+}
+
+// Save stores the credential record under its name.
+func (s *CredentialStore) Save(record *CredentialRecord) error {
+	if record.Name == "" {
+		return errors.New("credential name is required")
+	}
+
+	return putJSON(s.store, record.Name, record, storage.Tag{
+		Name:  credDefIDTagName,
+		Value: tagValue(record.CredDefID),
+	})
+}
+
+// Get returns the credential record stored under the given name.
+func (s *CredentialStore) Get(name string) (*CredentialRecord, error) {
+	var record *CredentialRecord
+
+	if err := getJSON(s.store, name, &record); err != nil {
+		return nil, fmt.Errorf("get credential '%s': %w", name, err)
+	}
+
+	return record, nil
+}
+
+// Delete removes the credential record stored under the given name.
+func (s *CredentialStore) Delete(name string) error {
+	return s.store.Delete(name)
+}
+
+// GetByCredentialDefinition returns the credential records issued for the given credential definition.
+func (s *CredentialStore) GetByCredentialDefinition(credDefID string) ([]*CredentialRecord, error) {
+	itr, err := s.store.Query(credDefIDTagName + ":" + tagValue(credDefID))
+	if err != nil {
+		return nil, fmt.Errorf("query store: %w", err)
+	}
+
+	defer func() {
+		if errClose := itr.Close(); errClose != nil {
+			logger.Errorf("failed to close iterator: %s", errClose.Error())
+		}
+	}()
+
+	var records []*CredentialRecord
+
+	more, err := itr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("next: %w", err)
+	}
+
+	for more {
+		value, err := itr.Value()
+		if err != nil {
+			return nil, fmt.Errorf("value: %w", err)
+		}
+
+		var record *CredentialRecord
+
+		if err = json.Unmarshal(value, &record); err != nil {
+			return nil, fmt.Errorf("unmarshal credential record: %w", err)
+		}
+
+		records = append(records, record)
+
+		more, err = itr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("next: %w", err)
+		}
+	}
+
+	return records, nil
+}
+
+// tagValue encodes the credential definition ID, which usually contains colons, as a valid tag value.
+func tagValue(credDefID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(credDefID))
+}
+
+// offerRecord is the credential offer sent by the issuer, stored until the credential is issued.
+type offerRecord struct {
+	Offer  *CredentialOffer       `json:"offer"`
+	Values map[string]interface{} `json:"values"`
+}
+
+// requestRecord is the credential request sent by the prover, stored until the credential is received.
+type requestRecord struct {
+	SchemaID  string                `json:"schemaID,omitempty"`
+	CredDefID string                `json:"credDefID"`
+	Request   *cl.CredentialRequest `json:"request"`
+}
+
+func offerKey(thID string) string {
+	return "offer_" + thID
+}
+
+func requestKey(thID string) string {
+	return "request_" + thID
+}
+
+func proofRequestKey(thID string) string {
+	return "proofreq_" + thID
+}
+
+func putJSON(store storage.Store, key string, v interface{}, tags ...storage.Tag) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	return store.Put(key, raw, tags...)
+}
+
+func getJSON(store storage.Store, key string, v interface{}) error {
+	raw, err := store.Get(key)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
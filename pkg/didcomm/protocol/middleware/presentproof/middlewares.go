@@ -31,6 +31,10 @@ import (
 )
 
 const (
+	// SkipPresentationSaveKey is present in metadata properties as `true` then received presentation will not be
+	// saved in verifiable store by middleware.
+	SkipPresentationSaveKey = "skip-presentation-save"
+
 	stateNamePresentationReceived = "presentation-received"
 	stateNameRequestReceived      = "request-received"
 	myDIDKey                      = "myDID"
@@ -57,18 +61,46 @@ type Provider interface {
 	JSONLDDocumentLoader() ld.DocumentLoader
 }
 
+// OptSP represents option function for the SavePresentation middleware.
+type OptSP func(o *spOptions)
+
+// WithChallengeVerifier allows providing the verifier of the challenges of the received presentations
+// (e.g. challenge store), so that presentations with unknown or already used challenges are rejected.
+func WithChallengeVerifier(verifier verifiable.ChallengeVerifier) OptSP {
+	return func(o *spOptions) {
+		o.challengeVerifier = verifier
+	}
+}
+
+type spOptions struct {
+	challengeVerifier verifiable.ChallengeVerifier
+}
+
 // SavePresentation the helper function for the present proof protocol which saves the presentations.
-func SavePresentation(p Provider) presentproof.Middleware {
+func SavePresentation(p Provider, opts ...OptSP) presentproof.Middleware {
 	vdr := p.VDRegistry()
 	store := p.VerifiableStore()
 	documentLoader := p.JSONLDDocumentLoader()
 
+	options := &spOptions{}
+
+	for i := range opts {
+		opts[i](options)
+	}
+
 	return func(next presentproof.Handler) presentproof.Handler {
 		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
 			if metadata.StateName() != stateNamePresentationReceived {
 				return next.Handle(metadata)
 			}
 
+			properties := metadata.Properties()
+
+			// skip storage if SkipPresentationSaveKey is enabled
+			if skip, ok := properties[SkipPresentationSaveKey].(bool); ok && skip {
+				return next.Handle(metadata)
+			}
+
 			msg := metadata.Message()
 
 			attachments, err := getAttachments(msg)
@@ -76,7 +108,7 @@ func SavePresentation(p Provider) presentproof.Middleware {
 				return fmt.Errorf("get attachments: %w", err)
 			}
 
-			presentations, err := toVerifiablePresentation(vdr, attachments, documentLoader)
+			presentations, err := toVerifiablePresentation(vdr, attachments, documentLoader, options)
 			if err != nil {
 				return fmt.Errorf("to verifiable presentation: %w", err)
 			}
@@ -86,7 +118,6 @@ func SavePresentation(p Provider) presentproof.Middleware {
 			}
 
 			var names []string
-			properties := metadata.Properties()
 
 			// nolint: errcheck
 			myDID, _ := properties[myDIDKey].(string)
@@ -458,21 +489,27 @@ func getName(idx int, id string, metadata presentproof.Metadata) string {
 }
 
 func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.AttachmentData,
-	documentLoader ld.DocumentLoader) ([]*verifiable.Presentation, error) {
+	documentLoader ld.DocumentLoader, options *spOptions) ([]*verifiable.Presentation, error) {
 	var presentations []*verifiable.Presentation
 
+	opts := []verifiable.PresentationOpt{
+		verifiable.WithPresPublicKeyFetcher(
+			verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher(),
+		),
+		verifiable.WithPresJSONLDDocumentLoader(documentLoader),
+	}
+
+	if options.challengeVerifier != nil {
+		opts = append(opts, verifiable.WithPresChallengeVerifier(options.challengeVerifier))
+	}
+
 	for i := range data {
 		raw, err := data[i].Fetch()
 		if err != nil {
 			return nil, fmt.Errorf("fetch: %w", err)
 		}
 
-		presentation, err := verifiable.ParsePresentation(raw,
-			verifiable.WithPresPublicKeyFetcher(
-				verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher(),
-			),
-			verifiable.WithPresJSONLDDocumentLoader(documentLoader),
-		)
+		presentation, err := verifiable.ParsePresentation(raw, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("parse presentation: %w", err)
 		}
@@ -72,9 +72,18 @@ func TestSavePresentation(t *testing.T) {
 		require.NoError(t, SavePresentation(provider)(next).Handle(metadata))
 	})
 
+	t.Run("Skips saving", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{SkipPresentationSaveKey: true})
+
+		require.NoError(t, SavePresentation(provider)(next).Handle(metadata))
+	})
+
 	t.Run("Presentations not provided", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{})
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.PresentationV2{
 			Type: presentproof.PresentationMsgTypeV2,
 		}))
@@ -86,6 +95,7 @@ func TestSavePresentation(t *testing.T) {
 	t.Run("Marshal presentation error", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{})
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.PresentationV2{
 			Type: presentproof.PresentationMsgTypeV2,
 			PresentationsAttach: []decorator.Attachment{
@@ -100,6 +110,7 @@ func TestSavePresentation(t *testing.T) {
 	t.Run("Decode error", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{})
 		metadata.EXPECT().Message().Return(service.DIDCommMsgMap{"@type": map[int]int{}})
 
 		err := SavePresentation(provider)(next).Handle(metadata)
@@ -109,6 +120,7 @@ func TestSavePresentation(t *testing.T) {
 	t.Run("Invalid presentation", func(t *testing.T) {
 		metadata := mocks.NewMockMetadata(ctrl)
 		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{})
 		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.PresentationV2{
 			Type: presentproof.PresentationMsgTypeV2,
 			PresentationsAttach: []decorator.Attachment{
@@ -226,6 +238,34 @@ func TestSavePresentation(t *testing.T) {
 		require.Equal(t, props["names"], []string{vcName})
 	})
 
+	t.Run("Challenge verification error", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Properties().Return(map[string]interface{}{})
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.PresentationV2{
+			Type: presentproof.PresentationMsgTypeV2,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(vpJWS))}},
+			},
+		}))
+
+		loader, err := ldtestutil.DocumentLoader()
+		require.NoError(t, err)
+
+		registry := mocksvdr.NewMockRegistry(ctrl)
+		registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").Return(
+			&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(nil)
+		provider.EXPECT().JSONLDDocumentLoader().Return(loader)
+
+		err = SavePresentation(provider, WithChallengeVerifier(&challengeVerifier{}))(next).Handle(metadata)
+		require.EqualError(t, err, "to verifiable presentation: parse presentation: "+
+			"check challenge of presentation: nonce of JWT presentation is not defined")
+	})
+
 	t.Run("Success v3", func(t *testing.T) {
 		const vcName = "vc-name"
 
@@ -266,6 +306,12 @@ func TestSavePresentation(t *testing.T) {
 	})
 }
 
+type challengeVerifier struct{}
+
+func (v *challengeVerifier) VerifyChallenge(string, []string) error {
+	return nil
+}
+
 func TestPresentationDefinition(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -185,6 +185,12 @@ func (s *Service) Accept(msgType string) bool {
 	return msgType == InvitationMsgType
 }
 
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{PIURI}
+}
+
 // HandleInbound handles inbound messages.
 func (s *Service) HandleInbound(msg service.DIDCommMsg, didCommCtx service.DIDCommContext) (string, error) {
 	logger.Debugf("oob/2.0 inbound message: %s", msg)
@@ -370,6 +370,13 @@ func (s *Service) Use(items ...Middleware) {
 	s.middleware = handler
 }
 
+// AddMiddleware appends the given Middleware to the chain of middlewares.
+func (s *Service) AddMiddleware(mw ...Middleware) {
+	for i := len(mw) - 1; i >= 0; i-- {
+		s.middleware = mw[i](s.middleware)
+	}
+}
+
 // HandleInbound handles inbound message (presentproof protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, ctx.MyDID(), ctx.TheirDID())
@@ -912,3 +919,9 @@ func (s *Service) Accept(msgType string) bool {
 
 	return false
 }
+
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{SpecV2, SpecV3}
+}
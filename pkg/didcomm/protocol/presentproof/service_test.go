@@ -209,6 +209,38 @@ func TestService_Use(t *testing.T) {
 		_, _, err = svc.execute(&done{}, &metaData{})
 		require.EqualError(t, err, "middleware: "+msgErr)
 	})
+
+	t.Run("Success (add middleware)", func(t *testing.T) {
+		storeProvider := storageMocks.NewMockProvider(ctrl)
+		storeProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil).Times(1)
+		storeProvider.EXPECT().SetStoreConfig(Name, gomock.Any()).Return(nil)
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(nil)
+		provider.EXPECT().StorageProvider().Return(storeProvider).Times(2)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		var calls []string
+		svc.Use(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				calls = append(calls, "used")
+				return next.Handle(metadata)
+			})
+		})
+		svc.AddMiddleware(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				calls = append(calls, "added")
+				return next.Handle(metadata)
+			})
+		})
+
+		_, _, err = svc.execute(&done{}, &metaData{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"added", "used"}, calls)
+	})
 }
 
 func TestService_ActionContinue(t *testing.T) {
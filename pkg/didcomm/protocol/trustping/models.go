@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Ping is a DIDComm V1 trust ping message.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0048-trust-ping#messages
+type Ping struct {
+	ID                string `json:"@id,omitempty"`
+	Type              string `json:"@type,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	ResponseRequested bool   `json:"response_requested"`
+}
+
+// PingResponse is a DIDComm V1 trust ping response message.
+type PingResponse struct {
+	ID      string            `json:"@id,omitempty"`
+	Type    string            `json:"@type,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+	Thread  *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// PingV2 is a DIDComm V2 trust ping message.
+// https://identity.foundation/didcomm-messaging/spec/#trust-ping-protocol-20
+type PingV2 struct {
+	ID   string     `json:"id,omitempty"`
+	Type string     `json:"type,omitempty"`
+	Body PingV2Body `json:"body"`
+}
+
+// PingV2Body is the body of the DIDComm V2 trust ping message.
+type PingV2Body struct {
+	ResponseRequested bool `json:"response_requested"`
+}
+
+// PingResponseV2 is a DIDComm V2 trust ping response message.
+type PingResponseV2 struct {
+	ID       string   `json:"id,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	ThreadID string   `json:"thid,omitempty"`
+	Body     struct{} `json:"body"`
+}
+
+// PingResult is the outcome of a trust ping answered by the other party.
+type PingResult struct {
+	// PingID is the ID of the ping message, it is the thread ID of the response.
+	PingID string `json:"ping_id"`
+	// Comment of the response, DIDComm V1 only.
+	Comment string `json:"comment,omitempty"`
+	// RoundTrip is the time elapsed between sending the ping and receiving its response.
+	RoundTrip time.Duration `json:"round_trip"`
+}
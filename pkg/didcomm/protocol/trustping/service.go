@@ -0,0 +1,347 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// TrustPing defines the protocol name.
+	TrustPing = "trustping"
+	// Spec defines the DIDComm V1 protocol spec.
+	Spec = "https://didcomm.org/trust_ping/1.0/"
+	// PingMsgType defines the DIDComm V1 ping message type.
+	PingMsgType = Spec + "ping"
+	// PingResponseMsgType defines the DIDComm V1 ping response message type.
+	PingResponseMsgType = Spec + "ping_response"
+
+	// SpecV2 defines the DIDComm V2 protocol spec.
+	SpecV2 = "https://didcomm.org/trust-ping/2.0/"
+	// PingMsgTypeV2 defines the DIDComm V2 ping message type.
+	PingMsgTypeV2 = SpecV2 + "ping"
+	// PingResponseMsgTypeV2 defines the DIDComm V2 ping response message type.
+	PingResponseMsgTypeV2 = SpecV2 + "ping-response"
+)
+
+const defaultTimeout = 10 * time.Second
+
+var (
+	// ErrConnectionNotFound connection not found error.
+	ErrConnectionNotFound = errors.New("connection not found")
+	// ErrTimeout is returned when the ping response was not received in time.
+	ErrTimeout = errors.New("timeout waiting for ping response")
+
+	logger = log.New("aries-framework/trustping")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+type pingResponse struct {
+	comment    string
+	receivedAt time.Time
+}
+
+// Service for the trust ping protocol.
+type Service struct {
+	outbound         dispatcher.Outbound
+	connectionLookup connections
+	responseMap      map[string]chan pingResponse
+	responseMapLock  sync.RWMutex
+	initialized      bool
+}
+
+// New returns the trust ping service.
+func New(prov provider) (*Service, error) {
+	svc := Service{}
+
+	err := svc.Initialize(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+// Initialize initializes the Service. If Initialize succeeds, any further call is a no-op.
+func (s *Service) Initialize(p interface{}) error {
+	if s.initialized {
+		return nil
+	}
+
+	prov, ok := p.(provider)
+	if !ok {
+		return fmt.Errorf("expected provider of type `%T`, got type `%T`", provider(nil), p)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return err
+	}
+
+	s.outbound = prov.OutboundDispatcher()
+	s.connectionLookup = connectionLookup
+	s.responseMap = make(map[string]chan pingResponse)
+
+	s.initialized = true
+
+	return nil
+}
+
+// HandleInbound handles inbound trust ping messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	// perform action asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case PingMsgType, PingMsgTypeV2:
+			err = s.handlePing(msg, ctx.MyDID(), ctx.TheirDID())
+		case PingResponseMsgType, PingResponseMsgTypeV2:
+			err = s.handlePingResponse(msg)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%w)\n", err)
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case PingMsgType, PingResponseMsgType, PingMsgTypeV2, PingResponseMsgTypeV2:
+		return true
+	}
+
+	return false
+}
+
+// DisclosedProtocols returns the identifier URIs of the protocols of the service, they are disclosed by the
+// discover-features protocol.
+func (s *Service) DisclosedProtocols() []string {
+	return []string{Spec, SpecV2}
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return TrustPing
+}
+
+func (s *Service) handlePing(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// response_requested defaults to true when absent.
+	request := struct {
+		ResponseRequested *bool `json:"response_requested"`
+		Body              struct {
+			ResponseRequested *bool `json:"response_requested"`
+		} `json:"body"`
+	}{}
+
+	err := msg.Decode(&request)
+	if err != nil {
+		return fmt.Errorf("ping message unmarshal: %w", err)
+	}
+
+	responseRequested := request.ResponseRequested
+
+	if msg.Type() == PingMsgTypeV2 {
+		responseRequested = request.Body.ResponseRequested
+	}
+
+	if responseRequested != nil && !*responseRequested {
+		return nil
+	}
+
+	var resp interface{}
+
+	if msg.Type() == PingMsgTypeV2 {
+		resp = &PingResponseV2{
+			ID:       uuid.New().String(),
+			Type:     PingResponseMsgTypeV2,
+			ThreadID: msg.ID(),
+		}
+	} else {
+		resp = &PingResponse{
+			ID:   uuid.New().String(),
+			Type: PingResponseMsgType,
+			Thread: &decorator.Thread{
+				ID: msg.ID(),
+			},
+		}
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(resp), myDID, theirDID)
+}
+
+func (s *Service) handlePingResponse(msg service.DIDCommMsg) error {
+	receivedAt := time.Now()
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("ping response thread ID: %w", err)
+	}
+
+	response := struct {
+		Comment string `json:"comment,omitempty"`
+	}{}
+
+	err = msg.Decode(&response)
+	if err != nil {
+		return fmt.Errorf("ping response message unmarshal: %w", err)
+	}
+
+	// check if there is a ping waiting for this response
+	responseCh := s.getResponseCh(thID)
+	if responseCh != nil {
+		// the channel is buffered for one response, duplicate responses are dropped
+		select {
+		case responseCh <- pingResponse{comment: response.Comment, receivedAt: receivedAt}:
+		default:
+			logger.Warnf("dropping duplicate trust ping response for thread %s", thID)
+		}
+	}
+
+	return nil
+}
+
+// PingOption configures a trust ping.
+type PingOption func(opts *pingOpts)
+
+type pingOpts struct {
+	comment string
+	timeout time.Duration
+}
+
+// WithComment sets the comment of a DIDComm V1 ping, DIDComm V2 pings have no comment.
+func WithComment(comment string) PingOption {
+	return func(opts *pingOpts) {
+		opts.comment = comment
+	}
+}
+
+// WithTimeout sets how long to wait for the ping response, defaults to 10 seconds.
+func WithTimeout(timeout time.Duration) PingOption {
+	return func(opts *pingOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// Ping sends a ping requesting a response over the connection and waits for the response. The DIDComm version of
+// the ping is the one of the connection. Returns ErrTimeout if the response is not received in time.
+func (s *Service) Ping(connectionID string, options ...PingOption) (*PingResult, error) {
+	opts := &pingOpts{timeout: defaultTimeout}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID := uuid.New().String()
+
+	var req interface{}
+
+	if conn.DIDCommVersion == service.V2 {
+		req = &PingV2{
+			ID:   msgID,
+			Type: PingMsgTypeV2,
+			Body: PingV2Body{ResponseRequested: true},
+		}
+	} else {
+		req = &Ping{
+			ID:                msgID,
+			Type:              PingMsgType,
+			Comment:           opts.comment,
+			ResponseRequested: true,
+		}
+	}
+
+	// register chan for callback processing
+	responseCh := make(chan pingResponse, 1)
+	s.setResponseCh(msgID, responseCh)
+
+	defer s.setResponseCh(msgID, nil)
+
+	sentAt := time.Now()
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(req), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send ping: %w", err)
+	}
+
+	// callback processing (to make this function look like a sync function)
+	select {
+	case resp := <-responseCh:
+		return &PingResult{
+			PingID:    msgID,
+			Comment:   resp.comment,
+			RoundTrip: resp.receivedAt.Sub(sentAt),
+		}, nil
+	case <-time.After(opts.timeout):
+		return nil, ErrTimeout
+	}
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) getResponseCh(msgID string) chan pingResponse {
+	s.responseMapLock.RLock()
+	defer s.responseMapLock.RUnlock()
+
+	return s.responseMap[msgID]
+}
+
+func (s *Service) setResponseCh(msgID string, responseCh chan pingResponse) {
+	s.responseMapLock.Lock()
+	defer s.responseMapLock.Unlock()
+
+	if responseCh == nil {
+		delete(s.responseMap, msgID)
+	} else {
+		s.responseMap[msgID] = responseCh
+	}
+}
@@ -0,0 +1,358 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+)
+
+func TestServiceNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+		require.Equal(t, TrustPing, svc.Name())
+
+		// second init is no-op
+		require.NoError(t, svc.Initialize(newProvider(&mockdispatcher.MockOutbound{})))
+	})
+
+	t.Run("failure, not given a valid provider", func(t *testing.T) {
+		svc := Service{}
+
+		err := svc.Initialize("not a provider")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected provider of type")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc := &Service{}
+
+	require.True(t, svc.Accept(PingMsgType))
+	require.True(t, svc.Accept(PingResponseMsgType))
+	require.True(t, svc.Accept(PingMsgTypeV2))
+	require.True(t, svc.Accept(PingResponseMsgTypeV2))
+	require.False(t, svc.Accept("https://didcomm.org/messagepickup/1.0/status"))
+
+	_, err := svc.HandleOutbound(nil, MYDID, THEIRDID)
+	require.EqualError(t, err, "not implemented")
+}
+
+func TestService_HandlePing(t *testing.T) {
+	t.Run("DIDComm V1 ping with response requested", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		ping := service.NewDIDCommMsgMap(&Ping{ID: "ping-1", Type: PingMsgType, ResponseRequested: true})
+
+		_, err = svc.HandleInbound(ping, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case resp := <-sent:
+			require.Equal(t, PingResponseMsgType, resp.Type())
+
+			thID, err := resp.ThreadID()
+			require.NoError(t, err)
+			require.Equal(t, "ping-1", thID)
+		case <-time.After(time.Second):
+			require.Fail(t, "ping response was not sent")
+		}
+	})
+
+	t.Run("DIDComm V1 ping without response_requested defaults to a response", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		ping := service.DIDCommMsgMap{"@id": "ping-2", "@type": PingMsgType}
+
+		_, err = svc.HandleInbound(ping, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case resp := <-sent:
+			require.Equal(t, PingResponseMsgType, resp.Type())
+		case <-time.After(time.Second):
+			require.Fail(t, "ping response was not sent")
+		}
+	})
+
+	t.Run("DIDComm V2 ping with response requested", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		ping := service.NewDIDCommMsgMap(&PingV2{
+			ID:   "ping-3",
+			Type: PingMsgTypeV2,
+			Body: PingV2Body{ResponseRequested: true},
+		})
+
+		_, err = svc.HandleInbound(ping, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		select {
+		case resp := <-sent:
+			require.Equal(t, PingResponseMsgTypeV2, resp.Type())
+
+			isV2, err := service.IsDIDCommV2(&resp)
+			require.NoError(t, err)
+			require.True(t, isV2)
+
+			thID, err := resp.ThreadID()
+			require.NoError(t, err)
+			require.Equal(t, "ping-3", thID)
+		case <-time.After(time.Second):
+			require.Fail(t, "ping response was not sent")
+		}
+	})
+
+	t.Run("no response requested", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 1)
+
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				sent <- msg.(service.DIDCommMsgMap)
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		ping := service.NewDIDCommMsgMap(&Ping{ID: "ping-4", Type: PingMsgType})
+
+		err = svc.handlePing(ping, MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		pingV2 := service.NewDIDCommMsgMap(&PingV2{ID: "ping-5", Type: PingMsgTypeV2})
+
+		err = svc.handlePing(pingV2, MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		require.Empty(t, sent)
+	})
+}
+
+func TestService_Ping(t *testing.T) {
+	t.Run("DIDComm V1 round trip", func(t *testing.T) {
+		var svc *Service
+
+		prov := newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, MYDID, myDID)
+				require.Equal(t, THEIRDID, theirDID)
+
+				ping := &Ping{}
+				require.NoError(t, msg.(service.DIDCommMsgMap).Decode(ping))
+				require.Equal(t, PingMsgType, ping.Type)
+				require.Equal(t, "hello", ping.Comment)
+				require.True(t, ping.ResponseRequested)
+
+				// the other party answers the ping.
+				return svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{
+					ID:      "response-1",
+					Type:    PingResponseMsgType,
+					Comment: "hi",
+					Thread:  &decorator.Thread{ID: ping.ID},
+				}))
+			},
+		})
+
+		saveConnection(t, prov, service.V1)
+
+		var err error
+
+		svc, err = New(prov)
+		require.NoError(t, err)
+
+		result, err := svc.Ping("conn", WithComment("hello"))
+		require.NoError(t, err)
+		require.NotEmpty(t, result.PingID)
+		require.Equal(t, "hi", result.Comment)
+		require.True(t, result.RoundTrip >= 0)
+		require.Empty(t, svc.responseMap)
+	})
+
+	t.Run("DIDComm V2 round trip", func(t *testing.T) {
+		var svc *Service
+
+		prov := newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, _, _ string) error {
+				ping := &PingV2{}
+				require.NoError(t, msg.(service.DIDCommMsgMap).Decode(ping))
+				require.Equal(t, PingMsgTypeV2, ping.Type)
+				require.True(t, ping.Body.ResponseRequested)
+
+				go func() {
+					_, err := svc.HandleInbound(service.NewDIDCommMsgMap(&PingResponseV2{
+						ID:       "response-2",
+						Type:     PingResponseMsgTypeV2,
+						ThreadID: ping.ID,
+					}), service.NewDIDCommContext(MYDID, THEIRDID, nil))
+					require.NoError(t, err)
+				}()
+
+				return nil
+			},
+		})
+
+		saveConnection(t, prov, service.V2)
+
+		var err error
+
+		svc, err = New(prov)
+		require.NoError(t, err)
+
+		result, err := svc.Ping("conn")
+		require.NoError(t, err)
+		require.NotEmpty(t, result.PingID)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{})
+
+		saveConnection(t, prov, service.V1)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Ping("conn", WithTimeout(10*time.Millisecond))
+		require.True(t, errors.Is(err, ErrTimeout))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		prov := newProvider(&mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(_ interface{}, _, _ string) error {
+				return errors.New("send error")
+			},
+		})
+
+		saveConnection(t, prov, service.V1)
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Ping("conn")
+		require.EqualError(t, err, "send ping: send error")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, err := New(newProvider(&mockdispatcher.MockOutbound{}))
+		require.NoError(t, err)
+
+		_, err = svc.Ping("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+}
+
+func TestService_HandlePingResponse(t *testing.T) {
+	svc, err := New(newProvider(&mockdispatcher.MockOutbound{}))
+	require.NoError(t, err)
+
+	t.Run("unexpected response is ignored", func(t *testing.T) {
+		err = svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{
+			ID:     "response",
+			Type:   PingResponseMsgType,
+			Thread: &decorator.Thread{ID: "unknown"},
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("duplicate response doesn't block", func(t *testing.T) {
+		responseCh := make(chan pingResponse, 1)
+		svc.setResponseCh("ping", responseCh)
+
+		defer svc.setResponseCh("ping", nil)
+
+		for i := 0; i < 2; i++ {
+			err = svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{
+				ID:      "response",
+				Type:    PingResponseMsgType,
+				Comment: "pong",
+				Thread:  &decorator.Thread{ID: "ping"},
+			}))
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, "pong", (<-responseCh).comment)
+	})
+
+	t.Run("invalid thread", func(t *testing.T) {
+		err = svc.handlePingResponse(service.DIDCommMsgMap{
+			"@type":   PingResponseMsgType,
+			"~thread": map[string]interface{}{"thid": "ping"},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ping response thread ID")
+	})
+}
+
+func newProvider(outbound *mockdispatcher.MockOutbound) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           outbound,
+	}
+}
+
+func saveConnection(t *testing.T, prov *mockprovider.Provider, version service.Version) {
+	t.Helper()
+
+	r, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	err = r.SaveConnectionRecord(&connection.Record{
+		ConnectionID:   "conn",
+		MyDID:          MYDID,
+		TheirDID:       THEIRDID,
+		State:          connection.StateNameCompleted,
+		DIDCommVersion: version,
+	})
+	require.NoError(t, err)
+}
@@ -24,6 +24,9 @@ const (
 	MediaTypeV2EncryptedEnvelopeV1PlaintextPayload = MediaTypeV2EncryptedEnvelope + ";cty=" + MediaTypeV1PlaintextPayload
 	// MediaTypeV2PlaintextPayload is the media type for DIDComm V1 JWE payloads as per Aries 044.
 	MediaTypeV2PlaintextPayload = "application/didcomm-plain+json"
+	// MediaTypeV2SignedEnvelope is the media type for DIDComm V2 signed (JWS) envelopes as per the DIF DIDComm spec.
+	// When used as a media type profile, messages are signed but not encrypted.
+	MediaTypeV2SignedEnvelope = "application/didcomm-signed+json"
 
 	// below are pre-defined profiles supported by the framework as per
 	// https://github.com/hyperledger/aries-rfcs/tree/master/features/0044-didcomm-file-and-mime-types#defined-profiles.
@@ -57,7 +60,7 @@ func MediaTypeProfiles() []string {
 
 // IsDIDCommV2 returns true iff mtp is one of:
 // MediaTypeV2EncryptedEnvelope, MediaTypeV2EncryptedEnvelopeV1PlaintextPayload, MediaTypeAIP2RFC0587Profile,
-// MediaTypeDIDCommV2Profile, or MediaTypeV2PlaintextPayload.
+// MediaTypeDIDCommV2Profile, MediaTypeV2PlaintextPayload or MediaTypeV2SignedEnvelope.
 func IsDIDCommV2(mtp string) bool {
 	v2MTPs := map[string]struct{}{
 		MediaTypeV2EncryptedEnvelope:                   {},
@@ -65,6 +68,7 @@ func IsDIDCommV2(mtp string) bool {
 		MediaTypeAIP2RFC0587Profile:                    {},
 		MediaTypeDIDCommV2Profile:                      {},
 		MediaTypeV2PlaintextPayload:                    {},
+		MediaTypeV2SignedEnvelope:                      {},
 	}
 
 	_, ok := v2MTPs[mtp]
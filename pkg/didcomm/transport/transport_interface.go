@@ -36,6 +36,24 @@ type Envelope struct {
 	ToKeys []string
 	// ToKey holds the key that was used to decrypt an inbound message
 	ToKey []byte
+	// SigningKey is the ID of the key (DID URL or did:key) signing an outbound message in a DIDComm V2 signed
+	// envelope before it's encrypted, or the one that signed an inbound message.
+	SigningKey string
+	// Session is the duplex transport session an inbound message was received on, set when the sender asked for
+	// messages to be returned over it with the 'all' return route option.
+	Session Session
+}
+
+// SessionProperty is the DIDCommContext property holding the Session an inbound message was received on.
+const SessionProperty = "transport_session"
+
+// Session is a duplex transport session, such as a WebSocket connection, over which outbound messages can be sent
+// back to the agent on the other end while it is open.
+type Session interface {
+	// Send sends a packed message over the session.
+	Send(data []byte) error
+	// Done returns a channel that is closed when the session is closed.
+	Done() <-chan struct{}
 }
 
 // InboundMessageHandler handles the inbound requests. The transport will unpack the payload prior to the
@@ -8,6 +8,7 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -81,6 +82,9 @@ func (d *connPool) remove(verKey string) {
 func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 	verKeys := []string{}
 
+	sess := &session{conn: conn, done: make(chan struct{})}
+
+	defer close(sess.done)
 	defer d.close(conn, verKeys)
 
 	go keepConnAlive(conn, outbound, pingFrequency)
@@ -109,7 +113,7 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 			logger.Errorf("unmarshal transport decorator : %v", err)
 		}
 
-		d.addKey(unpackMsg, trans, conn)
+		d.addKey(unpackMsg, trans, sess)
 
 		messageHandler := d.msgHandler
 
@@ -120,7 +124,7 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 	}
 }
 
-func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transport, conn *websocket.Conn) {
+func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transport, sess *session) {
 	var fromKey string
 
 	if len(unpackMsg.FromKey) == legacyKeyLen {
@@ -138,14 +142,16 @@ func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transp
 	}
 
 	if trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
+		unpackMsg.Session = sess
+
 		if fromKey != "" {
-			d.add(fromKey, conn)
+			d.add(fromKey, sess.conn)
 		}
 
 		keyAgreementIDs := checkKeyAgreementIDs(unpackMsg.Message)
 
 		for _, kaID := range keyAgreementIDs {
-			d.add(kaID, conn)
+			d.add(kaID, sess.conn)
 		}
 
 		if fromKey == "" && len(keyAgreementIDs) == 0 {
@@ -154,6 +160,28 @@ func (d *connPool) addKey(unpackMsg *transport.Envelope, trans *decorator.Transp
 	}
 }
 
+// session is the transport.Session of a websocket connection.
+type session struct {
+	conn *websocket.Conn
+	done chan struct{}
+}
+
+// Send writes a packed message to the websocket connection.
+func (s *session) Send(data []byte) error {
+	select {
+	case <-s.done:
+		return errors.New("websocket session is closed")
+	default:
+	}
+
+	return s.conn.Write(context.Background(), websocket.MessageText, data)
+}
+
+// Done returns a channel that is closed when the websocket connection is closed.
+func (s *session) Done() <-chan struct{} {
+	return s.done
+}
+
 func (d *connPool) close(conn *websocket.Conn, verKeys []string) {
 	if err := conn.Close(websocket.StatusNormalClosure,
 		"closing the connection"); websocket.CloseStatus(err) != websocket.StatusNormalClosure {
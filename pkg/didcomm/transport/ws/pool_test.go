@@ -138,6 +138,53 @@ func TestConnectionStore(t *testing.T) {
 	})
 }
 
+func TestSession(t *testing.T) {
+	request := createTransportDecRequest(t, decorator.TransportReturnRouteAll)
+
+	port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+	inbound, err := NewInbound(port, "", "", "")
+	require.NoError(t, err)
+
+	sessions := make(chan transport.Session, 1)
+
+	transportProvider := &mockTransportProvider{
+		packagerValue: &mockpackager.Packager{UnpackValue: &transport.Envelope{Message: request}},
+		frameworkID:   uuid.New().String(),
+		executeInbound: func(envelope *transport.Envelope) error {
+			sessions <- envelope.Session
+			return nil
+		},
+	}
+
+	require.NoError(t, inbound.Start(transportProvider))
+
+	client, cleanup := websocketClient(t, port)
+
+	ctx := context.Background()
+
+	require.NoError(t, client.Write(ctx, websocket.MessageText, request))
+
+	sess := <-sessions
+	require.NotNil(t, sess)
+
+	// messages sent over the session are received by the agent which opened it
+	require.NoError(t, sess.Send([]byte("Hello")))
+
+	_, message, err := client.Read(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Hello", string(message))
+
+	cleanup()
+
+	select {
+	case <-sess.Done():
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "session isn't closed with the connection")
+	}
+
+	require.EqualError(t, sess.Send([]byte("Hello")), "websocket session is closed")
+}
+
 func TestCheckKeyAgreementIDs(t *testing.T) {
 	t.Run("fail: didcomm v1", func(t *testing.T) {
 		tests := []struct {
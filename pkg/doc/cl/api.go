@@ -64,7 +64,8 @@ type Verifier interface {
 	// 		request as *PresentationRequest
 	//		error in case of errors
 	RequestPresentation(items []*PresentationRequestItem) (*PresentationRequest, error)
-	// VerifyProof verifies given Proof according to PresentationRequest and CredDefs
+	// VerifyProof verifies given Proof according to PresentationRequest and CredDefs,
+	// including the RevealedValues of its items
 	// returns:
 	//		error in case of errors or nil if proof verification was successful
 	VerifyProof(proof *Proof, presentationRequest *PresentationRequest, credDefs []*CredentialDefinition) error
@@ -46,9 +46,12 @@ type PresentationRequest struct {
 }
 
 // PresentationRequestItem consists of revealed attributes and predicates upon which CL Proof is generated.
+// RevealedValues, if set, maps the revealed attributes to the encoded values the verifier expects the Proof
+// to reveal, a Proof revealing other values fails verification.
 type PresentationRequestItem struct {
-	RevealedAttrs []string
-	Predicates    []*Predicate
+	RevealedAttrs  []string
+	RevealedValues map[string]string
+	Predicates     []*Predicate
 }
 
 // Predicate defines predicate for CL Proof.
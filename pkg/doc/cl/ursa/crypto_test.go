@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/google/tink/go/keyset"
+	"github.com/hyperledger/ursa-wrapper-go/pkg/libursa/ursa"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
@@ -111,7 +112,7 @@ func TestCL(t *testing.T) {
 		// 4. Prover verifies credential
 		originalSignature = credential.Signature
 
-		err = prover.ProcessCredential(credential, request, credDef)
+		_, err = prover.ProcessCredential(credential, request, credDef)
 		require.NoError(t, err)
 		require.NotEmpty(t, credential.Signature)
 		require.NotEmpty(t, credential.SigProof)
@@ -137,6 +138,15 @@ func TestCL(t *testing.T) {
 		// 3. Verifier verifies resulting proof
 		err = verifier.VerifyProof(proof, presentation, []*cl.CredentialDefinition{credDef})
 		require.NoError(t, err)
+
+		// 4. Verifier verifies the revealed values of the proof
+		_, encoded := ursa.EncodeValue("aaa")
+		presentation.Items[0].RevealedValues = map[string]string{"attr2": encoded}
+
+		err = verifier.VerifyProof(proof, presentation, []*cl.CredentialDefinition{credDef})
+		require.NoError(t, err)
+
+		presentation.Items[0].RevealedValues = nil
 	})
 
 	t.Run("test CL failures", func(t *testing.T) {
@@ -161,7 +171,7 @@ func TestCL(t *testing.T) {
 		require.Error(t, err)
 
 		// Prover fails to process credential with unmatched credDef
-		err = prover.ProcessCredential(credential, request, credDef2)
+		_, err = prover.ProcessCredential(credential, request, credDef2)
 		require.Error(t, err)
 
 		// Prover fails to create proof with unmatched credDefs
@@ -181,6 +191,20 @@ func TestCL(t *testing.T) {
 		err = verifier.VerifyProof(invalid, presentation, []*cl.CredentialDefinition{credDef})
 		require.Error(t, err)
 
+		// Verifier fails to verify proof revealing other values than expected
+		_, encoded := ursa.EncodeValue("bbb")
+		tampered := &cl.PresentationRequest{
+			Items: []*cl.PresentationRequestItem{{
+				RevealedAttrs:  presentation.Items[0].RevealedAttrs,
+				RevealedValues: map[string]string{"attr2": encoded},
+				Predicates:     presentation.Items[0].Predicates,
+			}},
+			Nonce: presentation.Nonce,
+		}
+
+		err = verifier.VerifyProof(proof, tampered, []*cl.CredentialDefinition{credDef})
+		require.EqualError(t, err, "sub proof 0 reveals an unexpected value of attribute 'attr2'")
+
 		//  Verifier fails to verify proof for other credDef
 		err = verifier.VerifyProof(proof, presentation, []*cl.CredentialDefinition{credDef2})
 		require.Error(t, err)
@@ -10,6 +10,10 @@ SPDX-License-Identifier: Apache-2.0
 package ursa
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
 	"github.com/hyperledger/ursa-wrapper-go/pkg/libursa/ursa"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
@@ -308,6 +312,54 @@ func verifyProof(
 	return err
 }
 
+// ursaProof holds the revealed attributes of the sub proofs of an ursa proof.
+type ursaProof struct {
+	Proofs []struct {
+		PrimaryProof struct {
+			EqProof struct {
+				RevealedAttrs map[string]string `json:"revealed_attrs"`
+			} `json:"eq_proof"`
+		} `json:"primary_proof"`
+	} `json:"proofs"`
+}
+
+// checkRevealedValues checks that the proof reveals the expected encoded values of the attributes of the items.
+func checkRevealedValues(proof *cl.Proof, items []*cl.PresentationRequestItem) error {
+	parsed := &ursaProof{}
+
+	err := json.Unmarshal(proof.Proof, parsed)
+	if err != nil {
+		return fmt.Errorf("parse proof: %w", err)
+	}
+
+	if len(parsed.Proofs) != len(items) {
+		return fmt.Errorf("proof has %d sub proofs, expected %d", len(parsed.Proofs), len(items))
+	}
+
+	for i, item := range items {
+		revealed := parsed.Proofs[i].PrimaryProof.EqProof.RevealedAttrs
+
+		for attr, expected := range item.RevealedValues {
+			if !equalEncodedValues(revealed[attr], expected) {
+				return fmt.Errorf("sub proof %d reveals an unexpected value of attribute '%s'", i, attr)
+			}
+		}
+	}
+
+	return nil
+}
+
+func equalEncodedValues(a, b string) bool {
+	x, ok := new(big.Int).SetString(a, 10)
+	if !ok {
+		return false
+	}
+
+	y, ok := new(big.Int).SetString(b, 10)
+
+	return ok && x.Cmp(y) == 0
+}
+
 func processSubProofVerifier(
 	verifier *ursa.ProofVerifier,
 	item *subProofItem,
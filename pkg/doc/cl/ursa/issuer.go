@@ -18,6 +18,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
+var _ cl.Issuer = (*Issuer)(nil)
+
 // Issuer is an ursa implementation of the CL Issuer API.
 type Issuer struct {
 	crypto crypto.Crypto
@@ -16,6 +16,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
 )
 
+var _ cl.Prover = (*Prover)(nil)
+
 // Prover is an ursa implementation of the CL Prover API.
 type Prover struct {
 	crypto crypto.Crypto
@@ -75,10 +77,10 @@ func (s *Prover) ProcessCredential(
 	credential *cl.Credential,
 	credRequest *cl.CredentialRequest,
 	credDef *cl.CredentialDefinition,
-) error {
+) (*cl.Credential, error) {
 	blindedVals, err := s.crypto.Blind(s.kh, credential.Values)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = processCredentialSignature(
@@ -87,8 +89,11 @@ func (s *Prover) ProcessCredential(
 		credDef,
 		blindedVals[0],
 	)
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	return credential, nil
 }
 
 // CreateProof composes Proof for the provided Credentials for CredDefs
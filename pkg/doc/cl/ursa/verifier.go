@@ -15,6 +15,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/cl"
 )
 
+var _ cl.Verifier = (*Verifier)(nil)
+
 // Verifier is an ursa implementation of the CL Verifier API.
 type Verifier struct{}
 
@@ -59,6 +61,9 @@ func (s *Verifier) VerifyProof(proof *cl.Proof,
 	}
 
 	err := verifyProof(proof, subProofItems, presentationRequest.Nonce)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return checkRevealedValues(proof, presentationRequest.Items)
 }
@@ -144,6 +144,35 @@ func (h Headers) ContentType() (string, bool) {
 	return h.stringValue(HeaderContentType)
 }
 
+// X509CertificateChain gets the X.509 certificate chain from JOSE headers. Each element of the chain is
+// a base64 (not base64url) encoded DER certificate, the first one contains the signing key.
+func (h Headers) X509CertificateChain() ([]string, bool) {
+	raw, ok := h[HeaderX509CertificateChain]
+	if !ok {
+		return nil, false
+	}
+
+	switch chain := raw.(type) {
+	case []string:
+		return chain, true
+	case []interface{}:
+		certs := make([]string, len(chain))
+
+		for i, c := range chain {
+			cert, ok := c.(string)
+			if !ok {
+				return nil, false
+			}
+
+			certs[i] = cert
+		}
+
+		return certs, true
+	default:
+		return nil, false
+	}
+}
+
 func (h Headers) stringValue(key string) (string, bool) {
 	raw, ok := h[key]
 	if !ok {
@@ -57,3 +57,37 @@ func TestHeaders_GetJWK(t *testing.T) {
 	require.False(t, ok)
 	require.Nil(t, parsedJWK)
 }
+
+func TestHeaders_X509CertificateChain(t *testing.T) {
+	headers := Headers{}
+
+	// x5c is not present
+	chain, ok := headers.X509CertificateChain()
+	require.False(t, ok)
+	require.Nil(t, chain)
+
+	headers["x5c"] = []string{"cert1", "cert2"}
+	chain, ok = headers.X509CertificateChain()
+	require.True(t, ok)
+	require.Equal(t, []string{"cert1", "cert2"}, chain)
+
+	// x5c unmarshalled from JSON
+	err := json.Unmarshal([]byte(`{"x5c":["cert1","cert2"]}`), &headers)
+	require.NoError(t, err)
+
+	chain, ok = headers.X509CertificateChain()
+	require.True(t, ok)
+	require.Equal(t, []string{"cert1", "cert2"}, chain)
+
+	// x5c is not an array of strings
+	headers["x5c"] = []interface{}{"cert1", 2}
+	chain, ok = headers.X509CertificateChain()
+	require.False(t, ok)
+	require.Nil(t, chain)
+
+	// x5c is not an array
+	headers["x5c"] = "cert1"
+	chain, ok = headers.X509CertificateChain()
+	require.False(t, ok)
+	require.Nil(t, chain)
+}
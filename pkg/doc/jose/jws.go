@@ -152,6 +152,40 @@ func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 		b64Signature), nil
 }
 
+// SerializeJSON makes JWS JSON Serialization (https://tools.ietf.org/html/rfc7515#section-7.2), using the general
+// syntax or, if flattened is true, the flattened one. The unprotected headers (if any) are set as the "header" member.
+func (s JSONWebSignature) SerializeJSON(flattened, detached bool) (string, error) {
+	byteHeaders, err := json.Marshal(s.joseHeaders)
+	if err != nil {
+		return "", fmt.Errorf("marshal JWS JOSE Headers: %w", err)
+	}
+
+	sig := jwsJSONSignature{
+		Protected: base64.RawURLEncoding.EncodeToString(byteHeaders),
+		Header:    s.UnprotectedHeaders,
+		Signature: base64.RawURLEncoding.EncodeToString(s.signature),
+	}
+
+	var rawJWS jwsJSON
+
+	if !detached {
+		rawJWS.Payload = base64.RawURLEncoding.EncodeToString(s.Payload)
+	}
+
+	if flattened {
+		rawJWS.jwsJSONSignature = sig
+	} else {
+		rawJWS.Signatures = []jwsJSONSignature{sig}
+	}
+
+	jwsBytes, err := json.Marshal(rawJWS)
+	if err != nil {
+		return "", fmt.Errorf("marshal JWS JSON: %w", err)
+	}
+
+	return string(jwsBytes), nil
+}
+
 // Signature returns a copy of JWS signature.
 func (s JSONWebSignature) Signature() []byte {
 	if s.signature == nil {
@@ -212,7 +246,8 @@ func WithJWSDetachedPayload(payload []byte) JWSParseOpt {
 	}
 }
 
-// ParseJWS parses serialized JWS. Currently only JWS Compact Serialization parsing is supported.
+// ParseJWS parses serialized JWS, in the Compact Serialization or in the JSON Serialization (general or flattened
+// syntax). JSON serialized JWS with more than one signature are not supported.
 func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSONWebSignature, error) {
 	pOpts := &jwsParseOpts{}
 
@@ -221,9 +256,7 @@ func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSO
 	}
 
 	if strings.HasPrefix(jws, "{") {
-		// TODO support JWS JSON serialization format
-		//  https://github.com/hyperledger/aries-framework-go/issues/1331
-		return nil, errors.New("JWS JSON serialization is not supported")
+		return parseJSON(jws, verifier, pOpts)
 	}
 
 	return parseCompacted(jws, verifier, pOpts)
@@ -275,6 +308,86 @@ func parseCompacted(jwsCompact string, verifier SignatureVerifier, opts *jwsPars
 	}, nil
 }
 
+// jwsJSON is the JWS JSON Serialization, the signature members are set for the flattened syntax.
+type jwsJSON struct {
+	Payload    string             `json:"payload,omitempty"`
+	Signatures []jwsJSONSignature `json:"signatures,omitempty"`
+	jwsJSONSignature
+}
+
+type jwsJSONSignature struct {
+	Protected string  `json:"protected,omitempty"`
+	Header    Headers `json:"header,omitempty"`
+	Signature string  `json:"signature,omitempty"`
+}
+
+func parseJSON(jwsJSONStr string, verifier SignatureVerifier, opts *jwsParseOpts) (*JSONWebSignature, error) {
+	var rawJWS jwsJSON
+
+	err := json.Unmarshal([]byte(jwsJSONStr), &rawJWS)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal JWS JSON: %w", err)
+	}
+
+	sig := rawJWS.jwsJSONSignature
+
+	switch len(rawJWS.Signatures) {
+	case 0:
+	case 1:
+		if sig.Signature != "" {
+			return nil, errors.New("invalid JWS JSON format: both general and flattened syntax are used")
+		}
+
+		sig = rawJWS.Signatures[0]
+	default:
+		return nil, fmt.Errorf("JWS JSON serialization with %d signatures is not supported", len(rawJWS.Signatures))
+	}
+
+	var protectedHeaders Headers
+
+	if sig.Protected != "" {
+		protectedHeaders, err = decodeHeaders(sig.Protected)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	joseHeaders := mergeHeaders(protectedHeaders, sig.Header)
+
+	err = checkJWSHeaders(joseHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := parseCompactedPayload(rawJWS.Payload, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sInput, err := signingInput(joseHeaders, sig.Protected, payload)
+	if err != nil {
+		return nil, fmt.Errorf("build signing input: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 signature: %w", err)
+	}
+
+	err = verifier.Verify(joseHeaders, payload, sInput, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONWebSignature{
+		ProtectedHeaders:   protectedHeaders,
+		UnprotectedHeaders: sig.Header,
+		Payload:            payload,
+		signature:          signature,
+		joseHeaders:        protectedHeaders,
+	}, nil
+}
+
 func parseCompactedPayload(jwsPayload string, opts *jwsParseOpts) ([]byte, error) {
 	if len(opts.detachedPayload) > 0 {
 		return opts.detachedPayload, nil
@@ -289,7 +402,21 @@ func parseCompactedPayload(jwsPayload string, opts *jwsParseOpts) ([]byte, error
 }
 
 func parseCompactedHeaders(parts []string) (Headers, error) {
-	headersBytes, err := base64.RawURLEncoding.DecodeString(parts[jwsHeaderPart])
+	joseHeaders, err := decodeHeaders(parts[jwsHeaderPart])
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkJWSHeaders(joseHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	return joseHeaders, nil
+}
+
+func decodeHeaders(b64Headers string) (Headers, error) {
+	headersBytes, err := base64.RawURLEncoding.DecodeString(b64Headers)
 	if err != nil {
 		return nil, fmt.Errorf("decode base64 header: %w", err)
 	}
@@ -301,11 +428,6 @@ func parseCompactedHeaders(parts []string) (Headers, error) {
 		return nil, fmt.Errorf("unmarshal JSON headers: %w", err)
 	}
 
-	err = checkJWSHeaders(joseHeaders)
-	if err != nil {
-		return nil, err
-	}
-
 	return joseHeaders, nil
 }
 
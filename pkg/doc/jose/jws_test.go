@@ -191,10 +191,10 @@ func TestParseJWS(t *testing.T) {
 	require.NotNil(t, parsedJWS)
 	require.Equal(t, jws, parsedJWS)
 
-	// Parse not compact JWS format
+	// Parse JWS JSON without signature
 	parsedJWS, err = ParseJWS(`{"some": "JSON"}`, &testVerifier{})
 	require.Error(t, err)
-	require.EqualError(t, err, "JWS JSON serialization is not supported")
+	require.EqualError(t, err, "alg JWS header is not defined")
 	require.Nil(t, parsedJWS)
 
 	// Parse invalid compact JWS format
@@ -252,6 +252,111 @@ func TestIsCompactJWS(t *testing.T) {
 	require.False(t, IsCompactJWS(""))
 }
 
+func TestJSONWebSignature_SerializeJSON(t *testing.T) {
+	jws, err := NewJWS(Headers{"typ": "application/didcomm-signed+json"}, Headers{"kid": "did:example:alice#key-1"},
+		[]byte("payload"), &testSigner{
+			headers:   Headers{"alg": "EdDSA"},
+			signature: []byte("signature"),
+		})
+	require.NoError(t, err)
+
+	signingInput, err := signingInput(jws.joseHeaders, "", jws.Payload)
+	require.NoError(t, err)
+
+	verifier := SignatureVerifierFunc(func(joseHeaders Headers, payload, sInput, signature []byte) error {
+		require.Equal(t, Headers{
+			"typ": "application/didcomm-signed+json",
+			"alg": "EdDSA",
+			"kid": "did:example:alice#key-1",
+		}, joseHeaders)
+		require.Equal(t, []byte("payload"), payload)
+		require.Equal(t, signingInput, sInput)
+		require.Equal(t, []byte("signature"), signature)
+
+		return nil
+	})
+
+	t.Run("general syntax", func(t *testing.T) {
+		jwsJSON, err := jws.SerializeJSON(false, false)
+		require.NoError(t, err)
+		require.Contains(t, jwsJSON, `"signatures":[{"protected":`)
+		require.Contains(t, jwsJSON, `"header":{"kid":"did:example:alice#key-1"}`)
+
+		parsedJWS, err := ParseJWS(jwsJSON, verifier)
+		require.NoError(t, err)
+		require.Equal(t, jws, parsedJWS)
+	})
+
+	t.Run("flattened syntax", func(t *testing.T) {
+		jwsJSON, err := jws.SerializeJSON(true, false)
+		require.NoError(t, err)
+		require.NotContains(t, jwsJSON, "signatures")
+
+		parsedJWS, err := ParseJWS(jwsJSON, verifier)
+		require.NoError(t, err)
+		require.Equal(t, jws, parsedJWS)
+
+		// the JWS JSON can be serialized in the compact format, without unprotected headers
+		jwsCompact, err := parsedJWS.SerializeCompact(false)
+		require.NoError(t, err)
+
+		_, err = ParseJWS(jwsCompact, &testVerifier{})
+		require.NoError(t, err)
+	})
+
+	t.Run("detached payload", func(t *testing.T) {
+		jwsJSON, err := jws.SerializeJSON(true, true)
+		require.NoError(t, err)
+		require.NotContains(t, jwsJSON, "payload")
+
+		parsedJWS, err := ParseJWS(jwsJSON, verifier, WithJWSDetachedPayload([]byte("payload")))
+		require.NoError(t, err)
+		require.Equal(t, jws, parsedJWS)
+	})
+
+	t.Run("alg in unprotected headers", func(t *testing.T) {
+		protected := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT"}`))
+
+		parsedJWS, err := ParseJWS(`{"payload":"cGF5bG9hZA","protected":"`+protected+
+			`","header":{"alg":"EdDSA"},"signature":"c2lnbmF0dXJl"}`, &testVerifier{})
+		require.NoError(t, err)
+		require.Equal(t, Headers{"typ": "JWT"}, parsedJWS.ProtectedHeaders)
+		require.Equal(t, Headers{"alg": "EdDSA"}, parsedJWS.UnprotectedHeaders)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		jwsJSON, err := jws.SerializeJSON(false, false)
+		require.NoError(t, err)
+
+		_, err = ParseJWS(jwsJSON, &testVerifier{err: errors.New("invalid signature")})
+		require.EqualError(t, err, "invalid signature")
+
+		_, err = ParseJWS(`{"signatures":[{"signature":"c2lnbmF0dXJl"},{"signature":"c2lnbmF0dXJl"}]}`,
+			&testVerifier{})
+		require.EqualError(t, err, "JWS JSON serialization with 2 signatures is not supported")
+
+		_, err = ParseJWS(`{"signature":"c2lnbmF0dXJl","signatures":[{"signature":"c2lnbmF0dXJl"}]}`,
+			&testVerifier{})
+		require.EqualError(t, err, "invalid JWS JSON format: both general and flattened syntax are used")
+
+		_, err = ParseJWS(`{"signatures":"invalid"}`, &testVerifier{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal JWS JSON")
+
+		_, err = ParseJWS(`{"protected":"invalid","signature":"c2lnbmF0dXJl"}`, &testVerifier{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal JSON headers")
+
+		_, err = ParseJWS(`{"payload":"`+strings.Repeat("*", 4)+`","header":{"alg":"EdDSA"}}`, &testVerifier{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode base64 payload")
+
+		_, err = ParseJWS(`{"header":{"alg":"EdDSA"},"signature":"*"}`, &testVerifier{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode base64 signature")
+	})
+}
+
 type testSigner struct {
 	headers   Headers
 	signature []byte
@@ -35,6 +35,7 @@ type Claims jwt.Claims
 type parseOpts struct {
 	detachedPayload []byte
 	sigVerifier     jose.SignatureVerifier
+	allowedTypes    []string
 }
 
 // ParseOpt is the JWT Parser option.
@@ -54,6 +55,13 @@ func WithSignatureVerifier(signatureVerifier jose.SignatureVerifier) ParseOpt {
 	}
 }
 
+// WithAllowedTypes option is for accepting explicitly typed JWTs (e.g. "kb+jwt") besides the "JWT" typ.
+func WithAllowedTypes(types ...string) ParseOpt {
+	return func(opts *parseOpts) {
+		opts.allowedTypes = types
+	}
+}
+
 type signatureVerifierFunc func(joseHeaders jose.Headers, payload, signingInput, signature []byte) error
 
 func (v signatureVerifierFunc) Verify(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
@@ -161,13 +169,13 @@ func parseJWS(jwtSerialized string, opts *parseOpts) (*JSONWebToken, error) {
 		return nil, fmt.Errorf("parse JWT from compact JWS: %w", err)
 	}
 
-	return mapJWSToJWT(jws)
+	return mapJWSToJWT(jws, opts.allowedTypes...)
 }
 
-func mapJWSToJWT(jws *jose.JSONWebSignature) (*JSONWebToken, error) {
+func mapJWSToJWT(jws *jose.JSONWebSignature, allowedTypes ...string) (*JSONWebToken, error) {
 	headers := jws.ProtectedHeaders
 
-	err := checkHeaders(headers)
+	err := checkHeaders(headers, allowedTypes...)
 	if err != nil {
 		return nil, fmt.Errorf("check JWT headers: %w", err)
 	}
@@ -250,13 +258,13 @@ func isValidJSON(s string) bool {
 	return err == nil
 }
 
-func checkHeaders(headers map[string]interface{}) error {
+func checkHeaders(headers map[string]interface{}, allowedTypes ...string) error {
 	if _, ok := headers[jose.HeaderAlgorithm]; !ok {
 		return errors.New("alg header is not defined")
 	}
 
 	typ, ok := headers[jose.HeaderType]
-	if ok && typ != TypeJWT {
+	if ok && typ != TypeJWT && !isAllowedType(typ, allowedTypes) {
 		return errors.New("typ is not JWT")
 	}
 
@@ -268,6 +276,16 @@ func checkHeaders(headers map[string]interface{}) error {
 	return nil
 }
 
+func isAllowedType(typ interface{}, allowedTypes []string) bool {
+	for _, t := range allowedTypes {
+		if typ == t {
+			return true
+		}
+	}
+
+	return false
+}
+
 // PayloadToMap transforms interface to map.
 func PayloadToMap(i interface{}) (map[string]interface{}, error) {
 	if reflect.ValueOf(i).Kind() == reflect.Map {
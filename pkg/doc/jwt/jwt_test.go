@@ -179,6 +179,11 @@ func TestParse(t *testing.T) {
 	r.Contains(err.Error(), "typ is not JWT")
 	r.Nil(token)
 
+	// explicitly typed JWT
+	token, err = Parse(jws, WithSignatureVerifier(verifier), WithAllowedTypes("JWM"))
+	r.NoError(err)
+	r.NotNil(token)
+
 	// content type is not empty (equals to JWT)
 	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "JWT", "cty": "JWT"}
 	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
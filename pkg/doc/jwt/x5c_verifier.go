@@ -0,0 +1,263 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// OIDExtKeyUsageDocumentSigning is the document signing extended key usage (RFC 9336).
+var OIDExtKeyUsageDocumentSigning = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 36} // nolint: gochecknoglobals
+
+// IssuerBinding checks that the leaf certificate of the 'x5c' chain belongs to the 'iss' of the token.
+type IssuerBinding func(leaf *x509.Certificate, issuer string) error
+
+// X5CVerifier verifies JWS signed by the key of the leaf certificate of the X.509 certificate chain
+// set in the 'x5c' JOSE header. The chain is validated against the trust anchors without revocation checks,
+// and the leaf certificate must be bound to the 'iss' claim of the token.
+// It can be used as the signature verifier of both JWT and SD-JWT (see sdjwt/verifier.WithSignatureVerifier).
+type X5CVerifier struct {
+	roots         *x509.CertPool
+	intermediates []*x509.Certificate
+	keyUsages     []x509.ExtKeyUsage
+	keyUsageOIDs  []asn1.ObjectIdentifier
+	currentTime   time.Time
+	issuerBinding IssuerBinding
+	verifiers     map[string]verifier.SignatureVerifier
+}
+
+// X5COpt is an option of the X5CVerifier.
+type X5COpt func(v *X5CVerifier)
+
+// WithX5CIntermediates adds intermediate certificates which may be used to build a path from the leaf
+// certificate to a trust anchor in addition to the ones from the 'x5c' JOSE header.
+func WithX5CIntermediates(certs ...*x509.Certificate) X5COpt {
+	return func(v *X5CVerifier) {
+		v.intermediates = append(v.intermediates, certs...)
+	}
+}
+
+// WithX5CKeyUsages sets acceptable extended key usages of the leaf certificate instead of document signing.
+func WithX5CKeyUsages(usages ...x509.ExtKeyUsage) X5COpt {
+	return func(v *X5CVerifier) {
+		v.keyUsages = usages
+		v.keyUsageOIDs = nil
+	}
+}
+
+// WithX5CKeyUsageOIDs sets acceptable extended key usages, unknown to crypto/x509, of the leaf certificate.
+// OIDExtKeyUsageDocumentSigning is required by default.
+func WithX5CKeyUsageOIDs(oids ...asn1.ObjectIdentifier) X5COpt {
+	return func(v *X5CVerifier) {
+		v.keyUsageOIDs = oids
+		v.keyUsages = nil
+	}
+}
+
+// WithX5CIssuerBinding sets the check of the leaf certificate against the 'iss' claim of the token.
+// By default, the 'iss' must be one of the URI subject alternative names of the certificate, or the host of
+// the 'iss' URL one of its DNS names.
+func WithX5CIssuerBinding(binding IssuerBinding) X5COpt {
+	return func(v *X5CVerifier) {
+		v.issuerBinding = binding
+	}
+}
+
+// WithX5CVerificationTime sets the time at which the certificate chain is checked to be valid.
+// The current time is used by default.
+func WithX5CVerificationTime(t time.Time) X5COpt {
+	return func(v *X5CVerifier) {
+		v.currentTime = t
+	}
+}
+
+// NewX5CVerifier creates a new X5CVerifier which trusts certificate chains ending in one of the given roots.
+func NewX5CVerifier(roots *x509.CertPool, opts ...X5COpt) *X5CVerifier {
+	v := &X5CVerifier{
+		roots:         roots,
+		keyUsageOIDs:  []asn1.ObjectIdentifier{OIDExtKeyUsageDocumentSigning},
+		issuerBinding: bindIssuerBySAN,
+		verifiers:     make(map[string]verifier.SignatureVerifier),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	for _, sv := range []verifier.SignatureVerifier{
+		verifier.NewECDSAES256SignatureVerifier(),
+		verifier.NewECDSAES384SignatureVerifier(),
+		verifier.NewECDSAES521SignatureVerifier(),
+		verifier.NewEd25519SignatureVerifier(),
+		verifier.NewRSAPS256SignatureVerifier(),
+		verifier.NewRSARS256SignatureVerifier(),
+	} {
+		v.verifiers[sv.Algorithm()] = sv
+	}
+
+	return v
+}
+
+// Verify verifies JSON Web Token. Public key is taken from the leaf certificate of the 'x5c' JOSE Header
+// once its chain is validated.
+func (v *X5CVerifier) Verify(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
+	alg, ok := joseHeaders.Algorithm()
+	if !ok {
+		return errors.New("'alg' JOSE header is not present")
+	}
+
+	sv, ok := v.verifiers[alg]
+	if !ok {
+		return fmt.Errorf("unsupported JWS alg %s", alg)
+	}
+
+	leaf, err := v.verifyChain(joseHeaders)
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := certificatePublicKey(leaf)
+	if err != nil {
+		return err
+	}
+
+	err = sv.Verify(pubKey, signingInput, signature)
+	if err != nil {
+		return err
+	}
+
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return fmt.Errorf("unmarshal x5c token claims: %w", err)
+	}
+
+	if claims.Issuer == "" {
+		return errors.New("'iss' claim is required to bind the x5c certificate")
+	}
+
+	err = v.issuerBinding(leaf, claims.Issuer)
+	if err != nil {
+		return fmt.Errorf("x5c certificate is not bound to issuer %s: %w", claims.Issuer, err)
+	}
+
+	return nil
+}
+
+func (v *X5CVerifier) verifyChain(joseHeaders jose.Headers) (*x509.Certificate, error) {
+	chain, ok := joseHeaders.X509CertificateChain()
+	if !ok || len(chain) == 0 {
+		return nil, errors.New("'x5c' JOSE header is not present")
+	}
+
+	certs := make([]*x509.Certificate, len(chain))
+
+	for i, c := range chain {
+		// Unlike other JOSE values, x5c certificates are base64 (not base64url) encoded.
+		der, err := base64.StdEncoding.DecodeString(c)
+		if err != nil {
+			return nil, fmt.Errorf("decode x5c certificate %d: %w", i, err)
+		}
+
+		certs[i], err = x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse x5c certificate %d: %w", i, err)
+		}
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, c := range append(certs[1:], v.intermediates...) {
+		intermediates.AddCert(c)
+	}
+
+	keyUsages := v.keyUsages
+	if len(keyUsages) == 0 {
+		// usages unknown to crypto/x509 are checked on the leaf certificate below.
+		keyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   v.currentTime,
+		KeyUsages:     keyUsages,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify x5c certificate chain: %w", err)
+	}
+
+	if len(v.keyUsageOIDs) > 0 && !hasKeyUsageOID(certs[0], v.keyUsageOIDs) {
+		return nil, errors.New("verify x5c certificate chain: leaf certificate has no acceptable extended key usage")
+	}
+
+	return certs[0], nil
+}
+
+func hasKeyUsageOID(cert *x509.Certificate, oids []asn1.ObjectIdentifier) bool {
+	for _, usage := range cert.UnknownExtKeyUsage {
+		for _, oid := range oids {
+			if usage.Equal(oid) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// bindIssuerBySAN checks that the issuer is a URI subject alternative name of the certificate, or that the host
+// of the issuer URL is one of its DNS names.
+func bindIssuerBySAN(leaf *x509.Certificate, issuer string) error {
+	for _, uri := range leaf.URIs {
+		if uri.String() == issuer {
+			return nil
+		}
+	}
+
+	u, err := url.Parse(issuer)
+	if err == nil && u.Host != "" && len(leaf.DNSNames) > 0 && leaf.VerifyHostname(u.Hostname()) == nil {
+		return nil
+	}
+
+	return errors.New("no matching subject alternative name")
+}
+
+func certificatePublicKey(cert *x509.Certificate) (*verifier.PublicKey, error) {
+	switch key := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		j, err := jwksupport.JWKFromKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("create JWK from x5c certificate key: %w", err)
+		}
+
+		return &verifier.PublicKey{Type: "JsonWebKey2020", JWK: j}, nil
+	case ed25519.PublicKey:
+		return &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: key}, nil
+	case *rsa.PublicKey:
+		return &verifier.PublicKey{Type: "RsaVerificationKey2018", Value: x509.MarshalPKCS1PublicKey(key)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported x5c certificate key type %T", cert.PublicKey)
+	}
+}
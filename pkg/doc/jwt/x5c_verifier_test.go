@@ -0,0 +1,341 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+func TestX5CVerifier_Verify(t *testing.T) {
+	ca := newTestCert(t, "Root CA", nil, nil, 0)
+	intermediate := newTestCert(t, "Intermediate CA", ca, nil, 0)
+
+	_, leafKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	leaf := newTestCert(t, "Issuer", intermediate, leafKey, 0)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	t.Run("verify JWT signed by EdDSA with x5c chain", func(t *testing.T) {
+		jws := newTestX5CJWT(t, NewEd25519Signer(leafKey), leaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.NoError(t, err)
+	})
+
+	t.Run("verify JWT signed by ES256 with intermediate certificate given as option", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		ecLeaf := newTestCert(t, "EC Issuer", intermediate, ecKey, 0)
+		jws := newTestX5CJWT(t, &es256Signer{privKey: ecKey}, ecLeaf)
+
+		_, err = Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify x5c certificate chain")
+
+		_, err = Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots, WithX5CIntermediates(intermediate.cert))))
+		require.NoError(t, err)
+	})
+
+	t.Run("verify JWT signed by RS256 with x5c chain", func(t *testing.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		rsaLeaf := newTestCert(t, "RSA Issuer", ca, rsaKey, 0)
+		jws := newTestX5CJWT(t, NewRS256Signer(rsaKey, nil), rsaLeaf)
+
+		_, err = Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.NoError(t, err)
+	})
+
+	t.Run("chain with untrusted root", func(t *testing.T) {
+		otherCA := newTestCert(t, "Other CA", nil, nil, 0)
+		otherRoots := x509.NewCertPool()
+		otherRoots.AddCert(otherCA.cert)
+
+		jws := newTestX5CJWT(t, NewEd25519Signer(leafKey), leaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(otherRoots)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify x5c certificate chain")
+	})
+
+	t.Run("expired leaf certificate", func(t *testing.T) {
+		expiredLeaf := newTestCert(t, "Issuer", intermediate, leafKey, -48*time.Hour)
+		jws := newTestX5CJWT(t, NewEd25519Signer(leafKey), expiredLeaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "certificate has expired or is not yet valid")
+
+	})
+
+	t.Run("chain is not valid at the verification time", func(t *testing.T) {
+		jws := newTestX5CJWT(t, NewEd25519Signer(leafKey), leaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots,
+			WithX5CVerificationTime(time.Now().Add(48*time.Hour)))))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "certificate has expired or is not yet valid")
+	})
+
+	t.Run("leaf certificate key usage", func(t *testing.T) {
+		jws := newTestX5CJWT(t, NewEd25519Signer(leafKey), leaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots,
+			WithX5CKeyUsages(x509.ExtKeyUsageServerAuth))))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "incompatible key usage")
+	})
+
+	t.Run("leaf certificate without document signing key usage", func(t *testing.T) {
+		codeSigningLeaf := newTestCert(t, "Issuer", intermediate, leafKey, 0, func(c *x509.Certificate) {
+			c.UnknownExtKeyUsage = nil
+			c.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+		})
+		jws := newTestX5CJWT(t, NewEd25519Signer(leafKey), codeSigningLeaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.ErrorContains(t, err,
+			"verify x5c certificate chain: leaf certificate has no acceptable extended key usage")
+
+		_, err = Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots,
+			WithX5CKeyUsages(x509.ExtKeyUsageCodeSigning))))
+		require.NoError(t, err)
+	})
+
+	t.Run("leaf certificate without extended key usage", func(t *testing.T) {
+		noUsageLeaf := newTestCert(t, "Issuer", intermediate, leafKey, 0, func(c *x509.Certificate) {
+			c.UnknownExtKeyUsage = nil
+		})
+		jws := newTestX5CJWT(t, NewEd25519Signer(leafKey), noUsageLeaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.ErrorContains(t, err,
+			"verify x5c certificate chain: leaf certificate has no acceptable extended key usage")
+	})
+
+	t.Run("leaf certificate bound to the issuer by URI", func(t *testing.T) {
+		uriLeaf := newTestCert(t, "Issuer", intermediate, leafKey, 0, func(c *x509.Certificate) {
+			c.DNSNames = nil
+			c.URIs = []*url.URL{{Scheme: "did", Opaque: "example:issuer"}}
+		})
+
+		jws := newTestX5CJWTWithIssuer(t, "did:example:issuer", NewEd25519Signer(leafKey), uriLeaf, intermediate)
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.NoError(t, err)
+
+		jws = newTestX5CJWTWithIssuer(t, "did:example:other", NewEd25519Signer(leafKey), uriLeaf, intermediate)
+		_, err = Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.ErrorContains(t, err,
+			"x5c certificate is not bound to issuer did:example:other: no matching subject alternative name")
+	})
+
+	t.Run("leaf certificate of another issuer", func(t *testing.T) {
+		jws := newTestX5CJWTWithIssuer(t, "https://attacker.example.com", NewEd25519Signer(leafKey), leaf,
+			intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.ErrorContains(t, err, "x5c certificate is not bound to issuer https://attacker.example.com: "+
+			"no matching subject alternative name")
+	})
+
+	t.Run("token without issuer", func(t *testing.T) {
+		jws := newTestX5CJWTWithIssuer(t, "", NewEd25519Signer(leafKey), leaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.ErrorContains(t, err, "'iss' claim is required to bind the x5c certificate")
+	})
+
+	t.Run("custom issuer binding", func(t *testing.T) {
+		jws := newTestX5CJWTWithIssuer(t, "did:example:issuer", NewEd25519Signer(leafKey), leaf, intermediate)
+
+		_, err := Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots,
+			WithX5CIssuerBinding(func(cert *x509.Certificate, issuer string) error {
+				require.Equal(t, leaf.cert.Raw, cert.Raw)
+				require.Equal(t, "did:example:issuer", issuer)
+
+				return nil
+			}))))
+		require.NoError(t, err)
+
+		_, err = Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots,
+			WithX5CIssuerBinding(func(*x509.Certificate, string) error {
+				return errors.New("unknown issuer")
+			}))))
+		require.ErrorContains(t, err, "x5c certificate is not bound to issuer did:example:issuer: unknown issuer")
+	})
+
+	t.Run("signature is not made by the leaf certificate key", func(t *testing.T) {
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jws := newTestX5CJWT(t, NewEd25519Signer(otherKey), leaf, intermediate)
+
+		_, err = Parse(jws, WithSignatureVerifier(NewX5CVerifier(roots)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ed25519: invalid signature")
+	})
+
+	t.Run("invalid JOSE headers", func(t *testing.T) {
+		v := NewX5CVerifier(roots)
+		x5c := []string{base64.StdEncoding.EncodeToString(leaf.cert.Raw)}
+
+		err := v.Verify(jose.Headers{jose.HeaderX509CertificateChain: x5c}, nil, nil, nil)
+		require.EqualError(t, err, "'alg' JOSE header is not present")
+
+		err = v.Verify(jose.Headers{jose.HeaderAlgorithm: "HS256", jose.HeaderX509CertificateChain: x5c},
+			nil, nil, nil)
+		require.EqualError(t, err, "unsupported JWS alg HS256")
+
+		err = v.Verify(jose.Headers{jose.HeaderAlgorithm: "EdDSA"}, nil, nil, nil)
+		require.EqualError(t, err, "'x5c' JOSE header is not present")
+
+		err = v.Verify(jose.Headers{jose.HeaderAlgorithm: "EdDSA", jose.HeaderX509CertificateChain: []string{"!"}},
+			nil, nil, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode x5c certificate 0")
+
+		err = v.Verify(jose.Headers{
+			jose.HeaderAlgorithm:            "EdDSA",
+			jose.HeaderX509CertificateChain: []string{base64.StdEncoding.EncodeToString([]byte("not a cert"))},
+		}, nil, nil, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse x5c certificate 0")
+	})
+}
+
+type testCert struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// newTestCert creates a certificate for the given key signed by the issuer, or a self-signed CA certificate
+// for a new key if the issuer is nil. The certificate validity is shifted by the given offset.
+// Leaf certificates are document signing certificates of issuer.example.com unless changed by the options.
+func newTestCert(t *testing.T, name string, issuer *testCert, key crypto.Signer, shift time.Duration,
+	opts ...func(*x509.Certificate)) *testCert {
+	t.Helper()
+
+	isCA := key == nil
+
+	if isCA {
+		var err error
+
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(shift - time.Hour),
+		NotAfter:              time.Now().Add(shift + time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+
+	parent, parentKey := template, key
+
+	if isCA {
+		template.IsCA = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.UnknownExtKeyUsage = []asn1.ObjectIdentifier{OIDExtKeyUsageDocumentSigning}
+		template.DNSNames = []string{"issuer.example.com"}
+	}
+
+	for _, opt := range opts {
+		opt(template)
+	}
+
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key}
+}
+
+func newTestX5CJWT(t *testing.T, signer jose.Signer, chain ...*testCert) string {
+	t.Helper()
+
+	return newTestX5CJWTWithIssuer(t, "https://issuer.example.com", signer, chain...)
+}
+
+func newTestX5CJWTWithIssuer(t *testing.T, iss string, signer jose.Signer, chain ...*testCert) string {
+	t.Helper()
+
+	x5c := make([]string, len(chain))
+	for i, c := range chain {
+		x5c[i] = base64.StdEncoding.EncodeToString(c.cert.Raw)
+	}
+
+	token, err := NewSigned(&Claims{Issuer: iss},
+		jose.Headers{jose.HeaderX509CertificateChain: x5c}, signer)
+	require.NoError(t, err)
+
+	jws, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return jws
+}
+
+type es256Signer struct {
+	privKey *ecdsa.PrivateKey
+}
+
+func (s *es256Signer) Sign(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privKey, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	const keySize = 32
+
+	signature := make([]byte, 2*keySize)
+	r.FillBytes(signature[:keySize])
+	sig.FillBytes(signature[keySize:])
+
+	return signature, nil
+}
+
+func (s *es256Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "ES256"}
+}
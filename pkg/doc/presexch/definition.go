@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasd2023"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
@@ -56,6 +57,10 @@ const (
 	FormatLDPVC = "ldp_vc"
 	// FormatLDPVP presentation exchange format.
 	FormatLDPVP = "ldp_vp"
+	// FormatSDJWT presentation exchange format (SD-JWT VC).
+	FormatSDJWT = "vc+sd-jwt"
+
+	credentialSubject = "credentialSubject"
 )
 
 var errPathNotApplicable = errors.New("path not applicable")
@@ -81,17 +86,19 @@ func (v *Preference) isRequired() bool {
 
 // Format describes PresentationDefinition`s Format field.
 type Format struct {
-	Jwt   *JwtType `json:"jwt,omitempty"`
-	JwtVC *JwtType `json:"jwt_vc,omitempty"`
-	JwtVP *JwtType `json:"jwt_vp,omitempty"`
-	Ldp   *LdpType `json:"ldp,omitempty"`
-	LdpVC *LdpType `json:"ldp_vc,omitempty"`
-	LdpVP *LdpType `json:"ldp_vp,omitempty"`
+	Jwt   *JwtType   `json:"jwt,omitempty"`
+	JwtVC *JwtType   `json:"jwt_vc,omitempty"`
+	JwtVP *JwtType   `json:"jwt_vp,omitempty"`
+	Ldp   *LdpType   `json:"ldp,omitempty"`
+	LdpVC *LdpType   `json:"ldp_vc,omitempty"`
+	LdpVP *LdpType   `json:"ldp_vp,omitempty"`
+	SDJWT *SDJWTType `json:"vc+sd-jwt,omitempty"`
 }
 
 func (f *Format) notNil() bool {
 	return f != nil &&
-		(f.Jwt != nil || f.JwtVC != nil || f.JwtVP != nil || f.Ldp != nil || f.LdpVC != nil || f.LdpVP != nil)
+		(f.Jwt != nil || f.JwtVC != nil || f.JwtVP != nil || f.Ldp != nil || f.LdpVC != nil || f.LdpVP != nil ||
+			f.SDJWT != nil)
 }
 
 // JwtType contains alg.
@@ -99,6 +106,12 @@ type JwtType struct {
 	Alg []string `json:"alg,omitempty"`
 }
 
+// SDJWTType contains the algorithms of the SD-JWT and of its Key Binding JWT.
+type SDJWTType struct {
+	SDJWTAlg []string `json:"sd-jwt_alg_values,omitempty"`
+	KBJWTAlg []string `json:"kb-jwt_alg_values,omitempty"`
+}
+
 // LdpType contains proof_type.
 type LdpType struct {
 	ProofType []string `json:"proof_type,omitempty"`
@@ -623,7 +636,21 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 			continue
 		}
 
-		if constraints.LimitDisclosure.isRequired() || predicate {
+		if constraints.LimitDisclosure.isRequired() && !predicate && credential.SDJWTHashAlg != "" {
+			credential, err = limitSDJWTDisclosures(constraints, credentialSrc, credential, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("limit SD-JWT disclosures: %w", err)
+			}
+
+			credential.ID = tmpID(credential.ID)
+		} else if constraints.LimitDisclosure.isRequired() && !predicate && hasECDSASD(credential) {
+			credential, err = limitECDSASDDisclosures(constraints, credentialSrc, credential, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("limit ecdsa-sd-2023 disclosures: %w", err)
+			}
+
+			credential.ID = tmpID(credential.ID)
+		} else if constraints.LimitDisclosure.isRequired() || predicate {
 			template := credentialSrc
 
 			var contexts []interface{}
@@ -785,6 +812,130 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 	return credential.GenerateBBSSelectiveDisclosure(doc, []byte(uuid.New().String()), opts...)
 }
 
+// limitSDJWTDisclosures creates a new SD-JWT VC with the disclosures of the credential subject claims selected
+// by the constraints fields only.
+func limitSDJWTDisclosures(constraints *Constraints, src []byte, credential *verifiable.Credential,
+	opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
+	matchedKeys, err := matchFieldPaths(constraints, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimPaths []string
+
+	for _, keys := range matchedKeys {
+		if claimPath, ok := subjectClaimPath(keys); ok {
+			claimPaths = append(claimPaths, claimPath)
+		}
+	}
+
+	disclosureOpt := verifiable.DiscloseClaims(claimPaths...)
+
+	for _, claimPath := range claimPaths {
+		if claimPath == "" {
+			// the whole credential subject is selected
+			disclosureOpt = verifiable.DiscloseAll()
+		}
+	}
+
+	vcSDJWT, err := credential.MarshalWithDisclosure(disclosureOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifiable.ParseCredential([]byte(vcSDJWT), append(opts, verifiable.WithDisabledProofCheck())...)
+}
+
+// limitECDSASDDisclosures derives a new VC with ecdsa-sd-2023 proof which discloses the values selected by
+// the constraints fields only (along with the ones made mandatory by the issuer).
+func limitECDSASDDisclosures(constraints *Constraints, src []byte, credential *verifiable.Credential,
+	opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
+	matchedKeys, err := matchFieldPaths(constraints, src)
+	if err != nil {
+		return nil, err
+	}
+
+	pointers := make([]string, len(matchedKeys))
+
+	for i, keys := range matchedKeys {
+		pointers[i] = jsonPointer(keys)
+	}
+
+	return credential.GenerateECDSASelectiveDisclosure(pointers, opts...)
+}
+
+// matchFieldPaths evaluates the JSON paths of the constraints fields against the credential
+// and returns the JSON path keys of the matched values.
+func matchFieldPaths(constraints *Constraints, src []byte) ([][]interface{}, error) {
+	var matchedKeys [][]interface{}
+
+	for _, f := range constraints.Fields {
+		paths, err := jsonpathkeys.ParsePaths(f.Path...)
+		if err != nil {
+			return nil, err
+		}
+
+		eval, err := jsonpathkeys.EvalPathsInReader(bytes.NewReader(src), paths)
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+			result, ok := eval.Next()
+			if !ok {
+				break
+			}
+
+			matchedKeys = append(matchedKeys, result.Keys)
+		}
+	}
+
+	return matchedKeys, nil
+}
+
+// jsonPointer returns JSON pointer (RFC 6901) of the value from its JSON path keys.
+func jsonPointer(keys []interface{}) string {
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+
+	var pointer string
+
+	for _, k := range keys {
+		switch v := k.(type) {
+		case int:
+			pointer += fmt.Sprintf("/%d", v)
+		default:
+			pointer += "/" + escaper.Replace(fmt.Sprintf("%s", v))
+		}
+	}
+
+	return pointer
+}
+
+// subjectClaimPath returns the path of a credential subject claim relative to the credential subject
+// (e.g. "degree.type" or "nationalities[1]") from its JSON path keys.
+func subjectClaimPath(keys []interface{}) (string, bool) {
+	if len(keys) == 0 || fmt.Sprintf("%s", keys[0]) != credentialSubject {
+		return "", false
+	}
+
+	var path string
+
+	for _, k := range keys[1:] {
+		switch v := k.(type) {
+		case int:
+			path += fmt.Sprintf("[%d]", v)
+		default:
+			if path != "" {
+				path += "."
+			}
+
+			path += fmt.Sprintf("%s", v)
+		}
+	}
+
+	return path, true
+}
+
 func enhanceRevealDoc(explicitPaths map[string]bool, limitedCred, vcBytes []byte) ([]byte, error) {
 	var err error
 
@@ -866,6 +1017,16 @@ func hasBBS(vc *verifiable.Credential) bool {
 	return false
 }
 
+func hasECDSASD(vc *verifiable.Credential) bool {
+	for _, proof := range vc.Proofs {
+		if proof["type"] == ecdsasd2023.SignatureType && proof["cryptosuite"] == ecdsasd2023.Cryptosuite {
+			return true
+		}
+	}
+
+	return false
+}
+
 func hasProofWithType(vc *verifiable.Credential, proofType string) bool {
 	for _, proof := range vc.Proofs {
 		if proof["type"] == proofType {
@@ -997,7 +1158,7 @@ func (a byID) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 //nolint:funlen,gocyclo
 func filterFormat(format *Format, credentials []*verifiable.Credential) (string, []*verifiable.Credential) {
-	var ldpCreds, ldpvcCreds, ldpvpCreds, jwtCreds, jwtvcCreds, jwtvpCreds []*verifiable.Credential
+	var ldpCreds, ldpvcCreds, ldpvpCreds, jwtCreds, jwtvcCreds, jwtvpCreds, sdJWTCreds []*verifiable.Credential
 
 	for _, credential := range credentials {
 		if credByProof(credential, format.Ldp) {
@@ -1028,6 +1189,15 @@ func filterFormat(format *Format, credentials []*verifiable.Credential) (string,
 			alg, hasAlg = pJWT.Headers.Algorithm()
 		}
 
+		// an SD-JWT VC is of the vc+sd-jwt format only
+		if credential.SDJWTHashAlg != "" {
+			if hasAlg && sdJWTAlgMatch(alg, format.SDJWT) {
+				sdJWTCreds = append(sdJWTCreds, credential)
+			}
+
+			continue
+		}
+
 		if hasAlg && algMatch(alg, format.Jwt) {
 			jwtCreds = append(jwtCreds, credential)
 		}
@@ -1065,6 +1235,10 @@ func filterFormat(format *Format, credentials []*verifiable.Credential) (string,
 		return FormatJWTVP, jwtvpCreds
 	}
 
+	if len(sdJWTCreds) > 0 {
+		return FormatSDJWT, sdJWTCreds
+	}
+
 	return "", nil
 }
 
@@ -1090,6 +1264,14 @@ func algMatch(credAlg string, jwtType *JwtType) bool {
 	return false
 }
 
+func sdJWTAlgMatch(credAlg string, sdJWTType *SDJWTType) bool {
+	if sdJWTType == nil {
+		return false
+	}
+
+	return algMatch(credAlg, &JwtType{Alg: sdJWTType.SDJWTAlg})
+}
+
 func credByProof(c *verifiable.Credential, ldp *LdpType) bool {
 	if ldp == nil {
 		return false
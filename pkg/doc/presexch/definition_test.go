@@ -26,9 +26,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 	. "github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	sdjwtissuer "github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasd2023"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -1521,6 +1524,198 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 	})
 }
 
+func TestPresentationDefinition_CreateVP_SDJWT(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	issuerID := "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		ID:      "http://example.edu/credentials/1872",
+		Issued:  util.NewTime(time.Now()),
+		Issuer:  verifiable.Issuer{ID: issuerID},
+		Subject: []verifiable.Subject{{
+			ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: map[string]interface{}{
+				"name": "Jayden Doe",
+				"degree": map[string]interface{}{
+					"type": "BachelorDegree",
+					"name": "Bachelor of Science and Arts",
+				},
+			},
+		}},
+	}
+
+	ed25519Signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	sdJWT, err := jwtClaims.MarshalSDJWT(verifiable.EdDSA, ed25519Signer, issuerID+"#keys-1",
+		sdjwtissuer.WithStructuredClaims(true))
+	require.NoError(t, err)
+
+	credOpts := []verifiable.CredentialOpt{
+		verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(lddl),
+	}
+
+	sdJWTVC, err := verifiable.ParseCredential([]byte(sdJWT), credOpts...)
+	require.NoError(t, err)
+	require.Len(t, sdJWTVC.SDJWTDisclosures, 3)
+
+	required := Required
+
+	pd := &PresentationDefinition{
+		ID: uuid.New().String(),
+		Format: &Format{
+			SDJWT: &SDJWTType{SDJWTAlg: []string{"EdDSA"}},
+		},
+		InputDescriptors: []*InputDescriptor{{
+			ID: uuid.New().String(),
+			Schema: []*Schema{{
+				URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+			}},
+			Constraints: &Constraints{
+				LimitDisclosure: &required,
+				Fields: []*Field{{
+					Path: []string{"$.credentialSubject.degree.type"},
+				}},
+			},
+		}},
+	}
+
+	t.Run("limit disclosure", func(t *testing.T) {
+		vp, err := pd.CreateVP([]*verifiable.Credential{sdJWTVC}, lddl, credOpts...)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		ps, ok := vp.CustomFields["presentation_submission"].(*PresentationSubmission)
+		require.True(t, ok)
+		require.Equal(t, FormatSDJWT, ps.DescriptorMap[0].Format)
+
+		presented, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.Len(t, presented.SDJWTDisclosures, 1)
+		require.Equal(t, sdJWTVC.JWT, presented.JWT)
+
+		subject, ok := presented.Subject.([]verifiable.Subject)
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"type": "BachelorDegree"}, subject[0].CustomFields["degree"])
+		require.NotContains(t, subject[0].CustomFields, "name")
+
+		// the verifier receives the serialized presentation
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		receivedVP, err := verifiable.ParsePresentation(vpBytes, verifiable.WithPresDisabledProofCheck(),
+			verifiable.WithPresJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+
+		matched, err := pd.Match(receivedVP, lddl, WithCredentialOptions(credOpts...))
+		require.NoError(t, err)
+
+		matchedVC := matched[pd.InputDescriptors[0].ID]
+		require.NotNil(t, matchedVC)
+		require.Len(t, matchedVC.SDJWTDisclosures, 1)
+	})
+
+	t.Run("SD-JWT VC is not of the JWT VC format", func(t *testing.T) {
+		jwtPD := &PresentationDefinition{
+			ID:               uuid.New().String(),
+			Format:           &Format{JwtVC: &JwtType{Alg: []string{"EdDSA"}}},
+			InputDescriptors: pd.InputDescriptors,
+		}
+
+		_, err := jwtPD.CreateVP([]*verifiable.Credential{sdJWTVC}, lddl, credOpts...)
+		require.EqualError(t, err, errMsgSchema)
+	})
+}
+
+func TestPresentationDefinition_CreateVP_ECDSASD(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	issuerID := "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI, "https://w3id.org/security/data-integrity/v1"},
+		Types:   []string{verifiable.VCType},
+		ID:      "http://example.edu/credentials/1872",
+		Issued:  util.NewTime(time.Now()),
+		Issuer:  verifiable.Issuer{ID: issuerID},
+		Subject: []verifiable.Subject{{
+			ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: map[string]interface{}{
+				"name": "Jayden Doe",
+				"degree": map[string]interface{}{
+					"type": "BachelorDegree",
+					"name": "Bachelor of Science and Arts",
+				},
+			},
+		}},
+		CustomContext: []interface{}{map[string]interface{}{"@vocab": "https://example.org/vocab#"}},
+	}
+
+	ecSigner, err := newCryptoSigner(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           "DataIntegrityProof",
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		Suite:                   ecdsasd2023.NewWithMandatoryPointers(nil, suite.WithSigner(ecSigner)),
+		VerificationMethod:      issuerID + "#keys-1",
+	}, jsonld.WithDocumentLoader(lddl))
+	require.NoError(t, err)
+
+	required := Required
+
+	pd := &PresentationDefinition{
+		ID: uuid.New().String(),
+		Format: &Format{
+			LdpVC: &LdpType{ProofType: []string{"DataIntegrityProof"}},
+		},
+		InputDescriptors: []*InputDescriptor{{
+			ID: uuid.New().String(),
+			Schema: []*Schema{{
+				URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+			}},
+			Constraints: &Constraints{
+				LimitDisclosure: &required,
+				Fields: []*Field{{
+					Path: []string{"$.credentialSubject.degree.type"},
+				}},
+			},
+		}},
+	}
+
+	vp, err := pd.CreateVP([]*verifiable.Credential{vc}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+	require.NoError(t, err)
+	require.Len(t, vp.Credentials(), 1)
+
+	presented, ok := vp.Credentials()[0].(*verifiable.Credential)
+	require.True(t, ok)
+	require.Equal(t, vc.Issuer, presented.Issuer)
+
+	subject, ok := presented.Subject.([]verifiable.Subject)
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"type": "BachelorDegree"}, subject[0].CustomFields["degree"])
+	require.NotContains(t, subject[0].CustomFields, "name")
+
+	// the derived proof of the presented credential is valid
+	presented.ID = vc.ID
+
+	vcBytes, err := json.Marshal(presented)
+	require.NoError(t, err)
+
+	_, err = verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(lddl),
+		verifiable.WithPublicKeyFetcher(func(issuerID, keyID string) (*verifier.PublicKey, error) {
+			return &verifier.PublicKey{Type: "Multikey", Value: ecSigner.PublicKeyBytes()}, nil
+		}))
+	require.NoError(t, err)
+}
+
 func createEdDSAJWS(t *testing.T, cred *verifiable.Credential, signer verifiable.Signer,
 	keyID string, minimize bool) string {
 	t.Helper()
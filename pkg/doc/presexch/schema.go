@@ -115,6 +115,26 @@ const DefinitionJSONSchemaV1 = `
                ],
                "additionalProperties":false
             },
+            "^vc\\+sd-jwt$":{
+               "type":"object",
+               "properties":{
+                  "sd-jwt_alg_values":{
+                     "type":"array",
+                     "minItems":1,
+                     "items":{
+                        "type":"string"
+                     }
+                  },
+                  "kb-jwt_alg_values":{
+                     "type":"array",
+                     "minItems":1,
+                     "items":{
+                        "type":"string"
+                     }
+                  }
+               },
+               "additionalProperties":false
+            },
             "^ldp_vc$|^ldp_vp$|^ldp$":{
                "type":"object",
                "properties":{
@@ -24,11 +24,30 @@ const (
 
 	SDAlgorithmKey = "_sd_alg"
 	SDKey          = "_sd"
+	CNFKey         = "cnf"
 
-	disclosureParts = 3
-	saltIndex       = 0
-	nameIndex       = 1
-	valueIndex      = 2
+	// ArrayElementDigestKey is the key of the digest replacing a selectively disclosable array element.
+	ArrayElementDigestKey = "..."
+
+	// KeyBindingJWTType is the "typ" header of the Key Binding JWT.
+	KeyBindingJWTType = "kb+jwt"
+
+	disclosureParts             = 3
+	arrayElementDisclosureParts = 2
+	saltIndex                   = 0
+	nameIndex                   = 1
+	valueIndex                  = 2
+	arrayElementValueIndex      = 1
+)
+
+// DisclosureClaimType is the type of a disclosure.
+type DisclosureClaimType int
+
+const (
+	// DisclosureClaimTypeObject is the disclosure of an object property: [salt, name, value].
+	DisclosureClaimTypeObject DisclosureClaimType = iota
+	// DisclosureClaimTypeArrayElement is the disclosure of an array element: [salt, value].
+	DisclosureClaimTypeArrayElement
 )
 
 // Payload represents SD-JWT payload.
@@ -43,20 +62,25 @@ type Payload struct {
 
 	SD    []string `json:"_sd,omitempty"`
 	SDAlg string   `json:"_sd_alg,omitempty"`
+
+	// CNF holds the holder public key (under "jwk") the Key Binding JWT must be signed with.
+	CNF map[string]interface{} `json:"cnf,omitempty"`
 }
 
 // SDJWT holds SD-JWT info.
 type SDJWT struct {
 	JWTSerialized string
 	Disclosures   []string
+	KeyBindingJWT string
 }
 
-// DisclosureClaim defines claim.
+// DisclosureClaim defines claim. The Name of an array element disclosure is empty.
 type DisclosureClaim struct {
 	Disclosure string
 	Salt       string
 	Name       string
 	Value      interface{}
+	Type       DisclosureClaimType
 }
 
 // GetDisclosureClaims de-codes disclosures.
@@ -88,8 +112,9 @@ func getDisclosureClaim(disclosure string) (*DisclosureClaim, error) {
 		return nil, fmt.Errorf("failed to unmarshal disclosure array: %w", err)
 	}
 
-	if len(disclosureArr) != disclosureParts {
-		return nil, fmt.Errorf("disclosure array size[%d] must be %d", len(disclosureArr), disclosureParts)
+	if len(disclosureArr) != disclosureParts && len(disclosureArr) != arrayElementDisclosureParts {
+		return nil, fmt.Errorf("disclosure array size[%d] must be %d or %d", len(disclosureArr), disclosureParts,
+			arrayElementDisclosureParts)
 	}
 
 	salt, ok := disclosureArr[saltIndex].(string)
@@ -97,17 +122,31 @@ func getDisclosureClaim(disclosure string) (*DisclosureClaim, error) {
 		return nil, fmt.Errorf("disclosure salt type[%T] must be string", disclosureArr[saltIndex])
 	}
 
+	if len(disclosureArr) == arrayElementDisclosureParts {
+		return &DisclosureClaim{
+			Disclosure: disclosure,
+			Salt:       salt,
+			Value:      disclosureArr[arrayElementValueIndex],
+			Type:       DisclosureClaimTypeArrayElement,
+		}, nil
+	}
+
 	name, ok := disclosureArr[nameIndex].(string)
 	if !ok {
 		return nil, fmt.Errorf("disclosure name type[%T] must be string", disclosureArr[nameIndex])
 	}
 
+	if name == SDKey || name == ArrayElementDigestKey {
+		return nil, fmt.Errorf("disclosure claim name '%s' is reserved", name)
+	}
+
 	claim := &DisclosureClaim{Disclosure: disclosure, Salt: salt, Name: name, Value: disclosureArr[valueIndex]}
 
 	return claim, nil
 }
 
-// ParseSDJWT parses SD-JWT serialized token into SDJWT parts.
+// ParseSDJWT parses SD-JWT serialized token into SDJWT parts. A presentation ends with the separator optionally
+// followed by the Key Binding JWT.
 func ParseSDJWT(sdJWTSerialized string) *SDJWT {
 	parts := strings.Split(sdJWTSerialized, DisclosureSeparator)
 
@@ -116,9 +155,41 @@ func ParseSDJWT(sdJWTSerialized string) *SDJWT {
 		disclosures = parts[1:]
 	}
 
+	var keyBindingJWT string
+
+	// disclosures are base64url encoded, so the last part is the Key Binding JWT if it contains a dot
+	last := len(disclosures) - 1
+	if last >= 0 && (disclosures[last] == "" || strings.Contains(disclosures[last], ".")) {
+		keyBindingJWT = disclosures[last]
+		disclosures = disclosures[:last]
+	}
+
 	jwtSerialized := parts[0]
 
-	return &SDJWT{JWTSerialized: jwtSerialized, Disclosures: disclosures}
+	return &SDJWT{JWTSerialized: jwtSerialized, Disclosures: disclosures, KeyBindingJWT: keyBindingJWT}
+}
+
+// GetSDHash calculates the sd_hash of the Key Binding JWT: the hash of the SD-JWT presentation without the Key Binding
+// JWT (with the trailing separator), using the _sd_alg hash function of the SD-JWT.
+func GetSDHash(sdJWT *SDJWT, signedJWT *afgjwt.JSONWebToken) (string, error) {
+	var claims map[string]interface{}
+
+	err := signedJWT.DecodeClaims(&claims)
+	if err != nil {
+		return "", err
+	}
+
+	cryptoHash, err := GetCryptoHashFromClaims(claims)
+	if err != nil {
+		return "", err
+	}
+
+	presentation := sdJWT.JWTSerialized + DisclosureSeparator
+	for _, disclosure := range sdJWT.Disclosures {
+		presentation += disclosure + DisclosureSeparator
+	}
+
+	return GetHash(cryptoHash, presentation)
 }
 
 // GetHash calculates hash of data using hash function identified by hash.
@@ -138,7 +209,8 @@ func GetHash(hash crypto.Hash, value string) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(result), nil
 }
 
-// VerifyDisclosuresInSDJWT checks for disclosure inclusion in SD-JWT.
+// VerifyDisclosuresInSDJWT checks for disclosure inclusion in SD-JWT. A disclosure digest may be in an "_sd" array
+// or an array element of the SD-JWT payload (at any depth), or in the value of another disclosure.
 func VerifyDisclosuresInSDJWT(disclosures []string, signedJWT *afgjwt.JSONWebToken) error {
 	var claims map[string]interface{}
 
@@ -147,23 +219,30 @@ func VerifyDisclosuresInSDJWT(disclosures []string, signedJWT *afgjwt.JSONWebTok
 		return err
 	}
 
-	// check that the _sd_alg claim is present
-	sdAlg, err := getSDAlg(claims)
+	cryptoHash, err := GetCryptoHashFromClaims(claims)
 	if err != nil {
 		return err
 	}
 
-	// check that _sd_alg value is understood and the hash algorithm is deemed secure.
-	cryptoHash, err := getCryptoHash(sdAlg)
+	claimsDisclosureDigests := make(map[string]bool)
+
+	err = collectDisclosureDigests(claims, claimsDisclosureDigests)
 	if err != nil {
 		return err
 	}
 
-	claimsDisclosureDigests, err := getDisclosureDigests(claims)
+	disclosureClaims, err := GetDisclosureClaims(disclosures)
 	if err != nil {
 		return err
 	}
 
+	for _, dc := range disclosureClaims {
+		err = collectDisclosureDigests(dc.Value, claimsDisclosureDigests)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, disclosure := range disclosures {
 		digest, err := GetHash(cryptoHash, disclosure)
 		if err != nil {
@@ -178,6 +257,219 @@ func VerifyDisclosuresInSDJWT(disclosures []string, signedJWT *afgjwt.JSONWebTok
 	return nil
 }
 
+// GetDisclosedClaims returns the claims of the SD-JWT where the digests of the given disclosures are replaced
+// (recursively) by the disclosed claims. Undisclosed and decoy digests and the _sd_alg claim are removed.
+func GetDisclosedClaims(disclosures []string, signedJWT *afgjwt.JSONWebToken) (map[string]interface{}, error) {
+	var claims map[string]interface{}
+
+	err := signedJWT.DecodeClaims(&claims)
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoHash, err := GetCryptoHashFromClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	disclosureClaims, err := GetDisclosureClaims(disclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	digests := make(map[string]*DisclosureClaim, len(disclosureClaims))
+
+	for _, dc := range disclosureClaims {
+		digest, err := GetHash(cryptoHash, dc.Disclosure)
+		if err != nil {
+			return nil, err
+		}
+
+		digests[digest] = dc
+	}
+
+	disclosedClaims, err := discloseObject(claims, digests)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(disclosedClaims, SDAlgorithmKey)
+
+	return disclosedClaims, nil
+}
+
+func discloseValue(value interface{}, digests map[string]*DisclosureClaim) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return discloseObject(v, digests)
+	case []interface{}:
+		return discloseArray(v, digests)
+	default:
+		return value, nil
+	}
+}
+
+func discloseObject(obj map[string]interface{}, digests map[string]*DisclosureClaim) (map[string]interface{}, error) {
+	disclosed := make(map[string]interface{}, len(obj))
+
+	for k, v := range obj {
+		if k == SDKey {
+			continue
+		}
+
+		value, err := discloseValue(v, digests)
+		if err != nil {
+			return nil, err
+		}
+
+		disclosed[k] = value
+	}
+
+	sd, err := stringArray(obj[SDKey])
+	if err != nil {
+		return nil, fmt.Errorf("get disclosure digests: %w", err)
+	}
+
+	for _, digest := range sd {
+		dc, ok := digests[digest]
+		if !ok {
+			// not disclosed or decoy digest
+			continue
+		}
+
+		if dc.Type != DisclosureClaimTypeObject {
+			return nil, fmt.Errorf("disclosure digest '%s' of an array element found in an object", digest)
+		}
+
+		if _, exists := disclosed[dc.Name]; exists {
+			return nil, fmt.Errorf("claim '%s' is disclosed more than once", dc.Name)
+		}
+
+		value, err := discloseValue(dc.Value, digests)
+		if err != nil {
+			return nil, err
+		}
+
+		disclosed[dc.Name] = value
+	}
+
+	return disclosed, nil
+}
+
+func discloseArray(arr []interface{}, digests map[string]*DisclosureClaim) ([]interface{}, error) {
+	disclosed := make([]interface{}, 0, len(arr))
+
+	for _, element := range arr {
+		digest, isDigest := ArrayElementDigest(element)
+		if !isDigest {
+			value, err := discloseValue(element, digests)
+			if err != nil {
+				return nil, err
+			}
+
+			disclosed = append(disclosed, value)
+
+			continue
+		}
+
+		dc, ok := digests[digest]
+		if !ok {
+			// not disclosed or decoy digest
+			continue
+		}
+
+		if dc.Type != DisclosureClaimTypeArrayElement {
+			return nil, fmt.Errorf("disclosure digest '%s' of an object property found in an array", digest)
+		}
+
+		value, err := discloseValue(dc.Value, digests)
+		if err != nil {
+			return nil, err
+		}
+
+		disclosed = append(disclosed, value)
+	}
+
+	return disclosed, nil
+}
+
+// ArrayElementDigest returns the digest of a selectively disclosable array element ({"...": digest}).
+func ArrayElementDigest(element interface{}) (string, bool) {
+	obj, ok := element.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", false
+	}
+
+	digest, ok := obj[ArrayElementDigestKey].(string)
+
+	return digest, ok
+}
+
+// collectDisclosureDigests adds the digests found (at any depth) in value to digests, a digest must not appear
+// more than once.
+func collectDisclosureDigests(value interface{}, digests map[string]bool) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if digest, ok := ArrayElementDigest(v); ok {
+			return addDisclosureDigest(digest, digests)
+		}
+
+		sd, err := stringArray(v[SDKey])
+		if err != nil {
+			return fmt.Errorf("get disclosure digests: %w", err)
+		}
+
+		for _, digest := range sd {
+			err = addDisclosureDigest(digest, digests)
+			if err != nil {
+				return err
+			}
+		}
+
+		for k, child := range v {
+			if k == SDKey {
+				continue
+			}
+
+			err = collectDisclosureDigests(child, digests)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			err := collectDisclosureDigests(child, digests)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func addDisclosureDigest(digest string, digests map[string]bool) error {
+	if digests[digest] {
+		return fmt.Errorf("duplicate digest '%s' found in SD-JWT", digest)
+	}
+
+	digests[digest] = true
+
+	return nil
+}
+
+// GetCryptoHashFromClaims returns the hash algorithm of the SD-JWT claims "_sd_alg" claim.
+func GetCryptoHashFromClaims(claims map[string]interface{}) (crypto.Hash, error) {
+	// check that the _sd_alg claim is present
+	sdAlg, err := getSDAlg(claims)
+	if err != nil {
+		return 0, err
+	}
+
+	// check that _sd_alg value is understood and the hash algorithm is deemed secure.
+	return getCryptoHash(sdAlg)
+}
+
 func getCryptoHash(sdAlg string) (crypto.Hash, error) {
 	var err error
 
@@ -207,20 +499,6 @@ func getSDAlg(claims map[string]interface{}) (string, error) {
 	return str, nil
 }
 
-func getDisclosureDigests(claims map[string]interface{}) (map[string]bool, error) {
-	disclosuresObj, ok := claims[SDKey]
-	if !ok {
-		return nil, nil
-	}
-
-	disclosures, err := stringArray(disclosuresObj)
-	if err != nil {
-		return nil, fmt.Errorf("get disclosure digests: %w", err)
-	}
-
-	return sliceToMap(disclosures), nil
-}
-
 func stringArray(entry interface{}) ([]string, error) {
 	if entry == nil {
 		return nil, nil
@@ -243,13 +521,3 @@ func stringArray(entry interface{}) ([]string, error) {
 
 	return result, nil
 }
-
-func sliceToMap(ids []string) map[string]bool {
-	// convert slice to map
-	values := make(map[string]bool)
-	for _, id := range ids {
-		values[id] = true
-	}
-
-	return values
-}
@@ -51,6 +51,16 @@ func TestParseSDJWT(t *testing.T) {
 		sdJWT := ParseSDJWT(specSDJWT)
 		require.Equal(t, 7, len(sdJWT.Disclosures))
 	})
+	t.Run("success - with key binding JWT", func(t *testing.T) {
+		parsed := ParseSDJWT(sdJWT + DisclosureSeparator + "a.b.c")
+		require.Equal(t, 1, len(parsed.Disclosures))
+		require.Equal(t, "a.b.c", parsed.KeyBindingJWT)
+	})
+	t.Run("success - presentation without key binding JWT", func(t *testing.T) {
+		parsed := ParseSDJWT(sdJWT + DisclosureSeparator)
+		require.Equal(t, 1, len(parsed.Disclosures))
+		require.Empty(t, parsed.KeyBindingJWT)
+	})
 }
 
 func TestVerifyDisclosuresInSDJWT(t *testing.T) {
@@ -125,6 +135,42 @@ func TestVerifyDisclosuresInSDJWT(t *testing.T) {
 			"disclosure digest 'X9yH0Ajrdm1Oij4tWso9UzzKJvPoDxwmuEcO3XAdRC0' not found in SD-JWT disclosure digests")
 	})
 
+	t.Run("error - duplicate digest", func(t *testing.T) {
+		disclosure, digest := createTestDisclosure(t, "salt", "given_name", "John")
+
+		for _, payload := range []map[string]interface{}{
+			{SDAlgorithmKey: testAlg, SDKey: []string{digest, digest}},
+			{SDAlgorithmKey: testAlg, SDKey: []string{digest}, "address": map[string]interface{}{
+				SDKey: []string{digest},
+			}},
+			{SDAlgorithmKey: testAlg, "nationalities": []interface{}{
+				map[string]interface{}{ArrayElementDigestKey: digest},
+				map[string]interface{}{ArrayElementDigestKey: digest},
+			}},
+		} {
+			signedJWT, err := afjwt.NewSigned(payload, nil, signer)
+			r.NoError(err)
+
+			err = VerifyDisclosuresInSDJWT([]string{disclosure}, signedJWT)
+			r.EqualError(err, fmt.Sprintf("duplicate digest '%s' found in SD-JWT", digest))
+		}
+	})
+
+	t.Run("error - duplicate digest in a disclosure", func(t *testing.T) {
+		nested, nestedDigest := createTestDisclosure(t, "salt", "street", "Main St")
+		address, addressDigest := createTestDisclosure(t, "salt", "address",
+			map[string]interface{}{SDKey: []string{nestedDigest}})
+
+		signedJWT, err := afjwt.NewSigned(map[string]interface{}{
+			SDAlgorithmKey: testAlg,
+			SDKey:          []string{addressDigest, nestedDigest},
+		}, nil, signer)
+		r.NoError(err)
+
+		err = VerifyDisclosuresInSDJWT([]string{address, nested}, signedJWT)
+		r.EqualError(err, fmt.Sprintf("duplicate digest '%s' found in SD-JWT", nestedDigest))
+	})
+
 	t.Run("error - missing algorithm", func(t *testing.T) {
 		payload := &Payload{
 			Issuer: "issuer",
@@ -191,6 +237,114 @@ func TestVerifyDisclosuresInSDJWT(t *testing.T) {
 	})
 }
 
+func TestGetDisclosedClaims(t *testing.T) {
+	r := require.New(t)
+
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	r.NoError(err)
+
+	signer := afjwt.NewEd25519Signer(privKey)
+
+	street, streetDigest := createTestDisclosure(t, "salt1", "street", "Main St")
+	address, addressDigest := createTestDisclosure(t, "salt2", "address",
+		map[string]interface{}{SDKey: []string{streetDigest}, "country": "US"})
+	element, elementDigest := createTestDisclosure(t, "salt3", "DE")
+	_, undisclosedDigest := createTestDisclosure(t, "salt4", "FR")
+
+	payload := map[string]interface{}{
+		"iss":          "issuer",
+		SDAlgorithmKey: testAlg,
+		SDKey:          []string{addressDigest, "decoy"},
+		"nationalities": []interface{}{
+			"US",
+			map[string]interface{}{ArrayElementDigestKey: elementDigest},
+			map[string]interface{}{ArrayElementDigestKey: undisclosedDigest},
+		},
+	}
+
+	signedJWT, err := afjwt.NewSigned(payload, nil, signer)
+	r.NoError(err)
+
+	t.Run("success - nested and array element disclosures", func(t *testing.T) {
+		r.NoError(VerifyDisclosuresInSDJWT([]string{street, address, element}, signedJWT))
+
+		claims, err := GetDisclosedClaims([]string{street, address, element}, signedJWT)
+		r.NoError(err)
+		r.Equal(map[string]interface{}{
+			"iss":           "issuer",
+			"address":       map[string]interface{}{"street": "Main St", "country": "US"},
+			"nationalities": []interface{}{"US", "DE"},
+		}, claims)
+	})
+
+	t.Run("success - parent disclosed only", func(t *testing.T) {
+		claims, err := GetDisclosedClaims([]string{address}, signedJWT)
+		r.NoError(err)
+		r.Equal(map[string]interface{}{"country": "US"}, claims["address"])
+		r.Equal([]interface{}{"US"}, claims["nationalities"])
+	})
+
+	t.Run("error - nested disclosure without its parent", func(t *testing.T) {
+		err := VerifyDisclosuresInSDJWT([]string{street}, signedJWT)
+		r.Error(err)
+		r.Contains(err.Error(), "not found in SD-JWT disclosure digests")
+	})
+
+	t.Run("error - array element disclosure in an object", func(t *testing.T) {
+		invalidJWT, err := afjwt.NewSigned(map[string]interface{}{
+			SDAlgorithmKey: testAlg,
+			SDKey:          []string{elementDigest},
+		}, nil, signer)
+		r.NoError(err)
+
+		claims, err := GetDisclosedClaims([]string{element}, invalidJWT)
+		r.Error(err)
+		r.Nil(claims)
+		r.Contains(err.Error(), "of an array element found in an object")
+	})
+
+	t.Run("error - object property disclosure in an array", func(t *testing.T) {
+		invalidJWT, err := afjwt.NewSigned(map[string]interface{}{
+			SDAlgorithmKey: testAlg,
+			"array":        []interface{}{map[string]interface{}{ArrayElementDigestKey: streetDigest}},
+		}, nil, signer)
+		r.NoError(err)
+
+		claims, err := GetDisclosedClaims([]string{street}, invalidJWT)
+		r.Error(err)
+		r.Nil(claims)
+		r.Contains(err.Error(), "of an object property found in an array")
+	})
+
+	t.Run("error - claim disclosed more than once", func(t *testing.T) {
+		invalidJWT, err := afjwt.NewSigned(map[string]interface{}{
+			SDAlgorithmKey: testAlg,
+			SDKey:          []string{streetDigest},
+			"street":       "Other St",
+		}, nil, signer)
+		r.NoError(err)
+
+		claims, err := GetDisclosedClaims([]string{street}, invalidJWT)
+		r.Error(err)
+		r.Nil(claims)
+		r.Contains(err.Error(), "claim 'street' is disclosed more than once")
+	})
+}
+
+func createTestDisclosure(t *testing.T, content ...interface{}) (string, string) {
+	t.Helper()
+
+	disclosureJSON, err := json.Marshal(content)
+	require.NoError(t, err)
+
+	disclosure := base64.RawURLEncoding.EncodeToString(disclosureJSON)
+
+	digest, err := GetHash(crypto.SHA256, disclosure)
+	require.NoError(t, err)
+
+	return disclosure, digest
+}
+
 func TestGetDisclosureClaims(t *testing.T) {
 	r := require.New(t)
 
@@ -216,8 +370,8 @@ func TestGetDisclosureClaims(t *testing.T) {
 		r.Contains(err.Error(), "failed to unmarshal disclosure array")
 	})
 
-	t.Run("error - invalid disclosure array (not three parts)", func(t *testing.T) {
-		disclosureArr := []interface{}{"name", "value"}
+	t.Run("error - invalid disclosure array (not two or three parts)", func(t *testing.T) {
+		disclosureArr := []interface{}{"salt", "name", "value", "extra"}
 		disclosureJSON, err := json.Marshal(disclosureArr)
 		require.NoError(t, err)
 
@@ -227,7 +381,7 @@ func TestGetDisclosureClaims(t *testing.T) {
 		disclosureClaims, err := GetDisclosureClaims(sdJWT.Disclosures)
 		r.Error(err)
 		r.Nil(disclosureClaims)
-		r.Contains(err.Error(), "disclosure array size[2] must be 3")
+		r.Contains(err.Error(), "disclosure array size[4] must be 3 or 2")
 	})
 
 	t.Run("error - invalid disclosure array (name is not a string)", func(t *testing.T) {
@@ -243,6 +397,16 @@ func TestGetDisclosureClaims(t *testing.T) {
 		r.Nil(disclosureClaims)
 		r.Contains(err.Error(), "disclosure name type[float64] must be string")
 	})
+
+	t.Run("error - reserved disclosure name", func(t *testing.T) {
+		for _, name := range []string{SDKey, ArrayElementDigestKey} {
+			disclosure, _ := createTestDisclosure(t, "salt", name, "value")
+
+			disclosureClaims, err := GetDisclosureClaims([]string{disclosure})
+			r.EqualError(err, fmt.Sprintf("disclosure claim name '%s' is reserved", name))
+			r.Nil(disclosureClaims)
+		}
+	})
 }
 
 type NoopSignatureVerifier struct {
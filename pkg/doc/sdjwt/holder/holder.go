@@ -8,6 +8,10 @@ package holder
 
 import (
 	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	afgjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
@@ -16,9 +20,11 @@ import (
 
 const notFound = -1
 
-// Claim defines claim.
+// Claim defines claim. Path is the path of the claim in the SD-JWT payload, e.g. "given_name", "address.street"
+// or "nationalities[1]" (the Name of an array element is empty).
 type Claim struct {
 	Name  string
+	Path  string
 	Value interface{}
 }
 
@@ -72,45 +78,185 @@ func Parse(sdJWTSerialized string, opts ...ParseOpt) ([]*Claim, error) {
 		return nil, err
 	}
 
-	return getClaims(sdJWT.Disclosures)
+	claims, _, err := getClaims(sdJWT.Disclosures, signedJWT)
+
+	return claims, err
 }
 
-func getClaims(disclosures []string) ([]*Claim, error) {
+// getClaims returns the claims of the disclosures and the index of their parent disclosure (notFound for the
+// claims of the SD-JWT payload), the disclosure of the object or array holding their digest.
+func getClaims(disclosures []string, signedJWT *afgjwt.JSONWebToken) ([]*Claim, []int, error) {
 	disclosureClaims, err := common.GetDisclosureClaims(disclosures)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get claims from disclosures: %w", err)
+		return nil, nil, fmt.Errorf("failed to get claims from disclosures: %w", err)
+	}
+
+	var payload map[string]interface{}
+
+	err = signedJWT.DecodeClaims(&payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get claims from disclosures: %w", err)
+	}
+
+	cryptoHash, err := common.GetCryptoHashFromClaims(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get claims from disclosures: %w", err)
+	}
+
+	w := &claimsWalker{
+		claims:  make([]*Claim, len(disclosureClaims)),
+		parents: make([]int, len(disclosureClaims)),
+		digests: make(map[string]int, len(disclosureClaims)),
 	}
 
-	var claims []*Claim
-	for _, disclosure := range disclosureClaims {
-		claims = append(claims,
-			&Claim{
-				Name:  disclosure.Name,
-				Value: disclosure.Value,
-			})
+	for i, disclosure := range disclosureClaims {
+		digest, err := common.GetHash(cryptoHash, disclosure.Disclosure)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get claims from disclosures: %w", err)
+		}
+
+		w.claims[i] = &Claim{Name: disclosure.Name, Path: disclosure.Name, Value: disclosure.Value}
+		w.parents[i] = notFound
+		w.digests[digest] = i
 	}
 
-	return claims, nil
+	w.walk(payload, "", notFound)
+
+	return w.claims, w.parents, nil
+}
+
+// claimsWalker sets the path and parent of the disclosed claims, walking the SD-JWT payload and the disclosed values.
+type claimsWalker struct {
+	claims  []*Claim
+	parents []int
+	digests map[string]int
+}
+
+func (w *claimsWalker) walk(value interface{}, path string, parent int) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if k != common.SDKey {
+				w.walk(child, joinPath(path, k), parent)
+			}
+		}
+
+		sd, _ := v[common.SDKey].([]interface{})
+
+		for _, digest := range sd {
+			if d, ok := digest.(string); ok {
+				w.visit(d, func(claim *Claim) string { return joinPath(path, claim.Name) }, parent)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			elementPath := path + "[" + strconv.Itoa(i) + "]"
+
+			if digest, ok := common.ArrayElementDigest(element); ok {
+				w.visit(digest, func(*Claim) string { return elementPath }, parent)
+
+				continue
+			}
+
+			w.walk(element, elementPath, parent)
+		}
+	}
+}
+
+func (w *claimsWalker) visit(digest string, path func(claim *Claim) string, parent int) {
+	index, ok := w.digests[digest]
+	if !ok {
+		// not disclosed or decoy digest
+		return
+	}
+
+	// a disclosure is visited once, even if its digest is repeated
+	delete(w.digests, digest)
+
+	claim := w.claims[index]
+	claim.Path = path(claim)
+	w.parents[index] = parent
+
+	w.walk(claim.Value, claim.Path, index)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// BindingPayload represents the Key Binding JWT payload.
+type BindingPayload struct {
+	Nonce    string           `json:"nonce,omitempty"`
+	Audience string           `json:"aud,omitempty"`
+	IssuedAt *jwt.NumericDate `json:"iat,omitempty"`
+	SDHash   string           `json:"sd_hash,omitempty"`
+}
+
+// BindingInfo defines the Key Binding JWT: its payload, signed by Signer with the key of the SD-JWT "cnf" claim.
+type BindingInfo struct {
+	Payload BindingPayload
+	Signer  jose.Signer
+	Headers jose.Headers
+}
+
+// options holds options for the holder.
+type options struct {
+	holderBinding *BindingInfo
+}
+
+// Option is a holder option.
+type Option func(opts *options)
+
+// WithHolderBinding option to append the Key Binding JWT to the disclosed SD-JWT. The Key Binding JWT sd_hash
+// is computed from the disclosed SD-JWT and its iat defaults to now.
+func WithHolderBinding(info *BindingInfo) Option {
+	return func(opts *options) {
+		opts.holderBinding = info
+	}
 }
 
 // DiscloseClaims discloses claims with specified claim names.
-func DiscloseClaims(sdJWTSerialized string, claimNames []string) (string, error) {
+func DiscloseClaims(sdJWTSerialized string, claimNames []string, opts ...Option) (string, error) {
+	hOpts := &options{}
+
+	for _, opt := range opts {
+		opt(hOpts)
+	}
+
 	sdJWT := common.ParseSDJWT(sdJWTSerialized)
 
 	if len(sdJWT.Disclosures) == 0 {
 		return "", fmt.Errorf("no disclosures found in SD-JWT")
 	}
 
-	disclosures, err := common.GetDisclosureClaims(sdJWT.Disclosures)
+	signedJWT, err := afgjwt.Parse(sdJWT.JWTSerialized, afgjwt.WithSignatureVerifier(&NoopSignatureVerifier{}))
 	if err != nil {
 		return "", err
 	}
 
-	var selectedDisclosures []string
+	claims, parents, err := getClaims(sdJWT.Disclosures, signedJWT)
+	if err != nil {
+		return "", err
+	}
+
+	selected := make([]bool, len(claims))
 
 	for _, claimName := range claimNames {
-		if index := getDisclosureByClaimName(claimName, disclosures); index != notFound {
-			selectedDisclosures = append(selectedDisclosures, sdJWT.Disclosures[index])
+		// the disclosures of the objects and arrays holding the claim are disclosed as well
+		for index := getDisclosureByClaimName(claimName, claims); index != notFound; index = parents[index] {
+			selected[index] = true
+		}
+	}
+
+	var selectedDisclosures []string
+
+	for index, disclosure := range sdJWT.Disclosures {
+		if selected[index] {
+			selectedDisclosures = append(selectedDisclosures, disclosure)
 		}
 	}
 
@@ -119,12 +265,55 @@ func DiscloseClaims(sdJWTSerialized string, claimNames []string) (string, error)
 		combinedFormatForPresentation += common.DisclosureSeparator + disclosure
 	}
 
-	return combinedFormatForPresentation, nil
+	if hOpts.holderBinding == nil {
+		return combinedFormatForPresentation, nil
+	}
+
+	keyBindingJWT, err := createKeyBindingJWT(
+		&common.SDJWT{JWTSerialized: sdJWT.JWTSerialized, Disclosures: selectedDisclosures}, hOpts.holderBinding)
+	if err != nil {
+		return "", fmt.Errorf("create key binding JWT: %w", err)
+	}
+
+	return combinedFormatForPresentation + common.DisclosureSeparator + keyBindingJWT, nil
+}
+
+func createKeyBindingJWT(sdJWT *common.SDJWT, info *BindingInfo) (string, error) {
+	signedJWT, err := afgjwt.Parse(sdJWT.JWTSerialized, afgjwt.WithSignatureVerifier(&NoopSignatureVerifier{}))
+	if err != nil {
+		return "", err
+	}
+
+	payload := info.Payload
+
+	payload.SDHash, err = common.GetSDHash(sdJWT, signedJWT)
+	if err != nil {
+		return "", err
+	}
+
+	if payload.IssuedAt == nil {
+		payload.IssuedAt = jwt.NewNumericDate(time.Now())
+	}
+
+	headers := jose.Headers{}
+	for k, v := range info.Headers {
+		headers[k] = v
+	}
+
+	headers[jose.HeaderType] = common.KeyBindingJWTType
+
+	keyBindingJWT, err := afgjwt.NewSigned(payload, headers, info.Signer)
+	if err != nil {
+		return "", err
+	}
+
+	return keyBindingJWT.Serialize(false)
 }
 
-func getDisclosureByClaimName(name string, disclosures []*common.DisclosureClaim) int {
-	for index, disclosure := range disclosures {
-		if disclosure.Name == name {
+// getDisclosureByClaimName returns the index of the disclosure of the claim with the given name or path.
+func getDisclosureByClaimName(name string, claims []*Claim) int {
+	for index, claim := range claims {
+		if (claim.Name != "" && claim.Name == name) || claim.Path == name {
 			return index
 		}
 	}
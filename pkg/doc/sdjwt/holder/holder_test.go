@@ -122,6 +122,79 @@ func TestDiscloseClaims(t *testing.T) {
 		require.Equal(t, sdJWTSerialized, sdJWTDisclosed)
 	})
 
+	t.Run("success - nested claim path discloses its parents", func(t *testing.T) {
+		structured, err := issuer.New(testIssuer, map[string]interface{}{
+			"given_name": "Albert",
+			"address":    map[string]interface{}{"street": "Main St", "country": "US"},
+		}, nil, signer, issuer.WithRecursiveClaimsObjects([]string{"address"}))
+		r.NoError(err)
+
+		structuredSerialized, err := structured.Serialize(false)
+		r.NoError(err)
+
+		sdJWTDisclosed, err := DiscloseClaims(structuredSerialized, []string{"address.street"})
+		r.NoError(err)
+
+		claims, err := Parse(sdJWTDisclosed)
+		r.NoError(err)
+		r.Len(claims, 2)
+
+		var paths []string
+		for _, claim := range claims {
+			paths = append(paths, claim.Path)
+		}
+
+		r.ElementsMatch([]string{"address", "address.street"}, paths)
+	})
+
+	t.Run("success - with holder binding", func(t *testing.T) {
+		_, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		sdJWTDisclosed, err := DiscloseClaims(sdJWTSerialized, []string{"given_name"},
+			WithHolderBinding(&BindingInfo{
+				Payload: BindingPayload{Nonce: "nonce", Audience: "https://example.com/verifier"},
+				Signer:  afjwt.NewEd25519Signer(holderPrivKey),
+			}))
+		r.NoError(err)
+
+		sdJWT := common.ParseSDJWT(sdJWTDisclosed)
+		r.Len(sdJWT.Disclosures, 1)
+		r.NotEmpty(sdJWT.KeyBindingJWT)
+
+		keyBindingJWT, err := afjwt.Parse(sdJWT.KeyBindingJWT, afjwt.WithSignatureVerifier(&NoopSignatureVerifier{}),
+			afjwt.WithAllowedTypes(common.KeyBindingJWTType))
+		r.NoError(err)
+
+		typ, _ := keyBindingJWT.Headers.Type() // nolint:errcheck
+		r.Equal(common.KeyBindingJWTType, typ)
+
+		var payload BindingPayload
+		r.NoError(keyBindingJWT.DecodeClaims(&payload))
+		r.Equal("nonce", payload.Nonce)
+		r.Equal("https://example.com/verifier", payload.Audience)
+		r.NotNil(payload.IssuedAt)
+
+		signedJWT, err := afjwt.Parse(sdJWT.JWTSerialized, afjwt.WithSignatureVerifier(&NoopSignatureVerifier{}))
+		r.NoError(err)
+
+		sdHash, err := common.GetSDHash(sdJWT, signedJWT)
+		r.NoError(err)
+		r.Equal(sdHash, payload.SDHash)
+	})
+
+	t.Run("error - holder binding signing error", func(t *testing.T) {
+		sdJWTDisclosed, err := DiscloseClaims(sdJWTSerialized, []string{"given_name"},
+			WithHolderBinding(&BindingInfo{
+				Payload: BindingPayload{Nonce: "nonce"},
+				Signer:  &mockSigner{err: fmt.Errorf("signing failed")},
+			}))
+		r.Error(err)
+		r.Empty(sdJWTDisclosed)
+		r.Contains(err.Error(), "create key binding JWT")
+		r.Contains(err.Error(), "signing failed")
+	})
+
 	t.Run("error - no disclosure(s)", func(t *testing.T) {
 		sdJWT := common.ParseSDJWT(sdJWTSerialized)
 
@@ -142,18 +215,72 @@ func TestDiscloseClaims(t *testing.T) {
 func TestGetClaims(t *testing.T) {
 	r := require.New(t)
 
+	_, privKey, e := ed25519.GenerateKey(rand.Reader)
+	r.NoError(e)
+
+	token, e := issuer.New(testIssuer, map[string]interface{}{"given_name": "John"}, nil,
+		afjwt.NewEd25519Signer(privKey))
+	r.NoError(e)
+
 	t.Run("success", func(t *testing.T) {
-		claims, err := getClaims([]string{additionalDisclosure})
+		claims, parents, err := getClaims(token.Disclosures, token.SignedJWT)
 		r.NoError(err)
 		r.Len(claims, 1)
+		r.Equal("given_name", claims[0].Path)
+		r.Equal([]int{notFound}, parents)
+	})
+
+	t.Run("success - structured claims", func(t *testing.T) {
+		structured, err := issuer.New(testIssuer, map[string]interface{}{
+			"address":       map[string]interface{}{"street": "Main St", "country": "US"},
+			"nationalities": []interface{}{"US", "DE"},
+		}, nil, afjwt.NewEd25519Signer(privKey),
+			issuer.WithStructuredClaims(true),
+			issuer.WithRecursiveClaimsObjects([]string{"address", "nationalities"}))
+		r.NoError(err)
+
+		claims, parents, err := getClaims(structured.Disclosures, structured.SignedJWT)
+		r.NoError(err)
+		r.Len(claims, 6)
+
+		paths := make(map[string]string)
+
+		for i, claim := range claims {
+			parent := ""
+			if parents[i] != notFound {
+				parent = claims[parents[i]].Path
+			}
+
+			paths[claim.Path] = parent
+		}
+
+		r.Equal(map[string]string{
+			"address":          "",
+			"address.street":   "address",
+			"address.country":  "address",
+			"nationalities":    "",
+			"nationalities[0]": "nationalities",
+			"nationalities[1]": "nationalities",
+		}, paths)
 	})
 
 	t.Run("error - not base64 encoded ", func(t *testing.T) {
-		claims, err := getClaims([]string{"!!!"})
+		claims, _, err := getClaims([]string{"!!!"}, token.SignedJWT)
 		r.Error(err)
 		r.Nil(claims)
 		r.Contains(err.Error(), "failed to decode disclosure")
 	})
+
+	t.Run("error - missing _sd_alg", func(t *testing.T) {
+		signedJWT, err := afjwt.NewSigned(map[string]interface{}{"iss": testIssuer}, nil,
+			afjwt.NewEd25519Signer(privKey))
+		r.NoError(err)
+
+		claims, _, err := getClaims([]string{additionalDisclosure}, signedJWT)
+		r.Error(err)
+		r.Nil(claims)
+		r.Contains(err.Error(), "_sd_alg must be present in SD-JWT")
+	})
 }
 
 func TestWithJWTDetachedPayload(t *testing.T) {
@@ -184,3 +311,15 @@ const additionalDisclosure = `WyIzanFjYjY3ejl3a3MwOHp3aUs3RXlRIiwgImdpdmVuX25hbW
 
 // nolint: lll
 const specSDJWT = `eyJhbGciOiAiUlMyNTYiLCAia2lkIjogImNBRUlVcUowY21MekQxa3pHemhlaUJhZzBZUkF6VmRsZnhOMjgwTmdIYUEifQ.eyJfc2QiOiBbIk5ZQ29TUktFWXdYZHBlNXlkdUpYQ3h4aHluRVU4ei1iNFR5TmlhcDc3VVkiLCAiU1k4bjJCYmtYOWxyWTNleEhsU3dQUkZYb0QwOUdGOGE5Q1BPLUc4ajIwOCIsICJUUHNHTlBZQTQ2d21CeGZ2MnpuT0poZmRvTjVZMUdrZXpicGFHWkNUMWFjIiwgIlprU0p4eGVHbHVJZFlCYjdDcWtaYkpWbTB3MlY1VXJSZU5UekFRQ1lCanciLCAibDlxSUo5SlRRd0xHN09MRUlDVEZCVnhtQXJ3OFBqeTY1ZEQ2bXRRVkc1YyIsICJvMVNBc0ozM1lNaW9POXBYNVZlQU0xbHh1SEY2aFpXMmtHZGtLS0JuVmxvIiwgInFxdmNxbmN6QU1nWXg3RXlrSTZ3d3RzcHl2eXZLNzkwZ2U3TUJiUS1OdXMiXSwgImlzcyI6ICJodHRwczovL2V4YW1wbGUuY29tL2lzc3VlciIsICJpYXQiOiAxNTE2MjM5MDIyLCAiZXhwIjogMTUxNjI0NzAyMiwgIl9zZF9hbGciOiAic2hhLTI1NiIsICJjbmYiOiB7Imp3ayI6IHsia3R5IjogIlJTQSIsICJuIjogInBtNGJPSEJnLW9ZaEF5UFd6UjU2QVdYM3JVSVhwMTFfSUNEa0dnUzZXM1pXTHRzLWh6d0kzeDY1NjU5a2c0aFZvOWRiR29DSkUzWkdGX2VhZXRFMzBVaEJVRWdwR3dyRHJRaUo5enFwcm1jRmZyM3F2dmtHanR0aDhaZ2wxZU0yYkpjT3dFN1BDQkhXVEtXWXMxNTJSN2c2SmcyT1ZwaC1hOHJxLXE3OU1oS0c1UW9XX21UejEwUVRfNkg0YzdQaldHMWZqaDhocFdObmJQX3B2NmQxelN3WmZjNWZsNnlWUkwwRFYwVjNsR0hLZTJXcWZfZU5HakJyQkxWa2xEVGs4LXN0WF9NV0xjUi1FR21YQU92MFVCV2l0U19kWEpLSnUtdlhKeXcxNG5IU0d1eFRJSzJoeDFwdHRNZnQ5Q3N2cWltWEtlRFRVMTRxUUwxZUU3aWhjdyIsICJlIjogIkFRQUIifX19.xqgKrDO6dK_oBL3fiqdcq_elaIGxM6Z-RyuysglGyddR1O1IiE3mIk8kCpoqcRLR88opkVWN2392K_XYfAuAmeT9kJVisD8ZcgNcv-MQlWW9s8WaViXxBRe7EZWkWRQcQVR6jf95XZ5H2-_KA54POq3L42xjk0y5vDr8yc08Reak6vvJVvjXpp-Wk6uxsdEEAKFspt_EYIvISFJhfTuQqyhCjnaW13X312MSQBPwjbHn74ylUqVLljDvqcemxeqjh42KWJq4C3RqNJ7anA2i3FU1kB4-KNZWsijY7-op49iL7BrnIBxdlAMrbHEkoGTbFWdl7Ki17GHtDxxa1jaxQg~WyJkcVR2WE14UzBHYTNEb2FHbmU5eDBRIiwgInN1YiIsICJqb2huX2RvZV80MiJd~WyIzanFjYjY3ejl3a3MwOHp3aUs3RXlRIiwgImdpdmVuX25hbWUiLCAiSm9obiJd~WyJxUVdtakpsMXMxUjRscWhFTkxScnJ3IiwgImZhbWlseV9uYW1lIiwgIkRvZSJd~WyJLVXhTNWhFX1hiVmFjckdBYzdFRnd3IiwgImVtYWlsIiwgImpvaG5kb2VAZXhhbXBsZS5jb20iXQ~WyIzcXZWSjFCQURwSERTUzkzOVEtUml3IiwgInBob25lX251bWJlciIsICIrMS0yMDItNTU1LTAxMDEiXQ~WyIweEd6bjNNaXFzY3RaSV9PcERsQWJRIiwgImFkZHJlc3MiLCB7InN0cmVldF9hZGRyZXNzIjogIjEyMyBNYWluIFN0IiwgImxvY2FsaXR5IjogIkFueXRvd24iLCAicmVnaW9uIjogIkFueXN0YXRlIiwgImNvdW50cnkiOiAiVVMifV0~WyJFUktNMENOZUZKa2FENW1UWFZfWDh3IiwgImJpcnRoZGF0ZSIsICIxOTQwLTAxLTAxIl0`
+
+type mockSigner struct {
+	err error
+}
+
+func (s *mockSigner) Sign(_ []byte) ([]byte, error) {
+	return nil, s.err
+}
+
+func (s *mockSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA"}
+}
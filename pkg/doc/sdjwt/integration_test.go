@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	afjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/holder"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
@@ -72,4 +73,82 @@ func TestSDJWTFlow(t *testing.T) {
 
 		fmt.Println(fmt.Sprintf("verified claims: %+v", verifiedClaims))
 	})
+	t.Run("success - with holder binding", func(t *testing.T) {
+		holderPubKey, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		holderPublicJWK, err := jwksupport.JWKFromKey(holderPubKey)
+		r.NoError(err)
+
+		// Issuer will issue SD-JWT for specified claims and holder public key.
+		token, err := issuer.New(testIssuer, claims, nil, signer, issuer.WithHolderPublicKey(holderPublicJWK))
+		r.NoError(err)
+
+		sdJWTSerialized, err := token.Serialize(false)
+		r.NoError(err)
+
+		// Holder will disclose only sub-set of claims to verifier and prove possession of its key.
+		sdJWTDisclosed, err := holder.DiscloseClaims(sdJWTSerialized, []string{"given_name"},
+			holder.WithHolderBinding(&holder.BindingInfo{
+				Payload: holder.BindingPayload{Nonce: "nonce", Audience: "https://example.com/verifier"},
+				Signer:  afjwt.NewEd25519Signer(holderPrivKey),
+			}))
+		r.NoError(err)
+
+		fmt.Println(fmt.Sprintf("holder SD-JWT with key binding: %s", sdJWTDisclosed))
+
+		// Verifier will validate holder SD-JWT, its key binding and create verified claims.
+		verifiedClaims, err := verifier.Parse(sdJWTDisclosed, verifier.WithSignatureVerifier(signatureVerifier),
+			verifier.WithKeyBindingRequired(true), verifier.WithExpectedNonceForKeyBinding("nonce"),
+			verifier.WithExpectedAudienceForKeyBinding("https://example.com/verifier"))
+		r.NoError(err)
+
+		// expected claims iss, exp, iat, nbf, cnf, given_name; last_name was not disclosed
+		r.Equal(6, len(verifiedClaims))
+	})
+
+	t.Run("success - structured and recursive claims", func(t *testing.T) {
+		complexClaims := map[string]interface{}{
+			"given_name": "Albert",
+			"address": map[string]interface{}{
+				"street_address": "123 Main St",
+				"country":        "US",
+			},
+			"degrees": map[string]interface{}{
+				"type": "BachelorDegree",
+				"year": "2010",
+			},
+			"nationalities": []interface{}{"US", "DE"},
+		}
+
+		// Issuer will issue SD-JWT with nested disclosures and decoy digests.
+		token, err := issuer.New(testIssuer, complexClaims, nil, signer,
+			issuer.WithStructuredClaims(true),
+			issuer.WithRecursiveClaimsObjects([]string{"degrees"}),
+			issuer.WithNonSelectivelyDisclosableClaims([]string{"address.country"}),
+			issuer.WithDecoyDigests(true))
+		r.NoError(err)
+
+		sdJWTSerialized, err := token.Serialize(false)
+		r.NoError(err)
+
+		// expected disclosures given_name, address.street_address, degrees, degrees.type, degrees.year
+		// and the two nationalities
+		claims, err := holder.Parse(sdJWTSerialized, holder.WithSignatureVerifier(signatureVerifier))
+		r.NoError(err)
+		r.Equal(7, len(claims))
+
+		// Holder will disclose the street, the degree type (and so its parent degrees) and the second nationality.
+		sdJWTDisclosed, err := holder.DiscloseClaims(sdJWTSerialized,
+			[]string{"address.street_address", "degrees.type", "nationalities[1]"})
+		r.NoError(err)
+
+		verifiedClaims, err := verifier.Parse(sdJWTDisclosed, verifier.WithSignatureVerifier(signatureVerifier))
+		r.NoError(err)
+
+		r.NotContains(verifiedClaims, "given_name")
+		r.Equal(map[string]interface{}{"street_address": "123 Main St", "country": "US"}, verifiedClaims["address"])
+		r.Equal(map[string]interface{}{"type": "BachelorDegree"}, verifiedClaims["degrees"])
+		r.Equal([]interface{}{"DE"}, verifiedClaims["nationalities"])
+	})
 }
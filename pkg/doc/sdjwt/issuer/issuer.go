@@ -13,12 +13,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	afgjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
 )
@@ -27,6 +31,12 @@ const (
 	defaultHash     = crypto.SHA256
 	defaultSaltSize = 128 / 8
 
+	maxDecoyDigests = 3
+
+	vcKey                = "vc"
+	credentialSubjectKey = "credentialSubject"
+	idKey                = "id"
+
 	year = 365 * 24 * 60 * time.Minute
 )
 
@@ -44,6 +54,13 @@ type newOpts struct {
 
 	HashAlg crypto.Hash
 
+	HolderPublicKey *jwk.JWK
+
+	structuredClaims       bool
+	nonSDClaims            map[string]bool
+	recursiveClaimsObjects map[string]bool
+	addDecoyDigests        bool
+
 	jsonMarshal func(v interface{}) ([]byte, error)
 	getSalt     func() (string, error)
 }
@@ -107,6 +124,47 @@ func WithHashAlgorithm(alg crypto.Hash) NewOpt {
 	}
 }
 
+// WithHolderPublicKey is an option for SD-JWT payload, the holder public key is embedded as the "cnf" claim.
+// The holder must then prove possession of the corresponding private key with a Key Binding JWT.
+func WithHolderPublicKey(holderJWK *jwk.JWK) NewOpt {
+	return func(opts *newOpts) {
+		opts.HolderPublicKey = holderJWK
+	}
+}
+
+// WithStructuredClaims is an option for nested claims: the structure of the nested objects is kept in the SD-JWT
+// payload, each object having its own "_sd" digests, and the array elements are disclosed one by one as
+// {"...": digest}. By default, the top level claims only are selectively disclosable.
+func WithStructuredClaims(flag bool) NewOpt {
+	return func(opts *newOpts) {
+		opts.structuredClaims = flag
+	}
+}
+
+// WithNonSelectivelyDisclosableClaims is an option for the claims always disclosed, e.g. "address.country" or
+// "nationalities[0]" (with structured claims).
+func WithNonSelectivelyDisclosableClaims(claims []string) NewOpt {
+	return func(opts *newOpts) {
+		opts.nonSDClaims = toSet(claims)
+	}
+}
+
+// WithRecursiveClaimsObjects is an option for the claims objects (e.g. "address") disclosed as a whole, their
+// own claims being selectively disclosable as well: the disclosure of the object holds the "_sd" digests of its claims.
+// With structured claims, arrays may be disclosed as a whole as well, holding the digests of their elements.
+func WithRecursiveClaimsObjects(claims []string) NewOpt {
+	return func(opts *newOpts) {
+		opts.recursiveClaimsObjects = toSet(claims)
+	}
+}
+
+// WithDecoyDigests is an option to add decoy digests to each "_sd" array, hiding the number of claims.
+func WithDecoyDigests(flag bool) NewOpt {
+	return func(opts *newOpts) {
+		opts.addDecoyDigests = flag
+	}
+}
+
 // New creates new signed Selective Disclosure JWT based on input claims.
 func New(issuer string, claims interface{}, headers jose.Headers,
 	signer jose.Signer, opts ...NewOpt) (*SelectiveDisclosureJWT, error) {
@@ -132,22 +190,11 @@ func New(issuer string, claims interface{}, headers jose.Headers,
 		return nil, err
 	}
 
-	disclosures, err := createDisclosures(claimsMap, nOpts)
+	sdClaims, disclosures, err := createDisclosures(claimsMap, "", nOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	var hashedDisclosures []string
-
-	for _, disclosure := range disclosures {
-		hashedDisclosure, inErr := common.GetHash(nOpts.HashAlg, disclosure)
-		if inErr != nil {
-			return nil, fmt.Errorf("hash disclosure: %w", inErr)
-		}
-
-		hashedDisclosures = append(hashedDisclosures, hashedDisclosure)
-	}
-
 	payload := &common.Payload{
 		Issuer:    issuer,
 		ID:        nOpts.ID,
@@ -155,10 +202,87 @@ func New(issuer string, claims interface{}, headers jose.Headers,
 		IssuedAt:  nOpts.IssuedAt,
 		Expiry:    nOpts.Expiry,
 		NotBefore: nOpts.NotBefore,
-		SD:        hashedDisclosures,
 		SDAlg:     strings.ToLower(nOpts.HashAlg.String()),
 	}
 
+	if nOpts.HolderPublicKey != nil {
+		payload.CNF = map[string]interface{}{"jwk": nOpts.HolderPublicKey}
+	}
+
+	payloadMap, err := afgjwt.PayloadToMap(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range sdClaims {
+		payloadMap[k] = v
+	}
+
+	signedJWT, err := afgjwt.NewSigned(payloadMap, headers, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SelectiveDisclosureJWT{Disclosures: disclosures, SignedJWT: signedJWT}, nil
+}
+
+// NewFromVC creates new signed Selective Disclosure JWT based on the JWT claims of a verifiable credential (with the
+// "vc" claim). The claims of the credential subject, but its id, are selectively disclosable, the paths of the
+// options are relative to the credential subject. The JWT claims (iss, nbf, exp etc.) are taken from vcClaims,
+// the options setting them are ignored.
+func NewFromVC(vcClaims map[string]interface{}, headers jose.Headers,
+	signer jose.Signer, opts ...NewOpt) (*SelectiveDisclosureJWT, error) {
+	nOpts := &newOpts{
+		jsonMarshal: json.Marshal,
+		getSalt:     generateSalt,
+		HashAlg:     defaultHash,
+	}
+
+	for _, opt := range opts {
+		opt(nOpts)
+	}
+
+	vc, ok := vcClaims[vcKey].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("vc claim must be an object")
+	}
+
+	subject, ok := vc[credentialSubjectKey].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("credentialSubject must be an object")
+	}
+
+	nonSDClaims := map[string]bool{idKey: true}
+	for k := range nOpts.nonSDClaims {
+		nonSDClaims[k] = true
+	}
+
+	nOpts.nonSDClaims = nonSDClaims
+
+	sdSubject, disclosures, err := createDisclosures(subject, "", nOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	sdVC := make(map[string]interface{}, len(vc))
+	for k, v := range vc {
+		sdVC[k] = v
+	}
+
+	sdVC[credentialSubjectKey] = sdSubject
+
+	payload := make(map[string]interface{}, len(vcClaims)+2)
+	for k, v := range vcClaims {
+		payload[k] = v
+	}
+
+	payload[vcKey] = sdVC
+	payload[common.SDAlgorithmKey] = strings.ToLower(nOpts.HashAlg.String())
+
+	if nOpts.HolderPublicKey != nil {
+		payload[common.CNFKey] = map[string]interface{}{"jwk": nOpts.HolderPublicKey}
+	}
+
 	signedJWT, err := afgjwt.NewSigned(payload, headers, signer)
 	if err != nil {
 		return nil, err
@@ -202,35 +326,195 @@ func (j *SelectiveDisclosureJWT) Serialize(detached bool) (string, error) {
 	return combinedFormatForPresentation, nil
 }
 
-func createDisclosures(claims map[string]interface{}, opts *newOpts) ([]string, error) {
-	var disclosures []string
+// createDisclosures returns the claims object with the "_sd" digests of its selectively disclosable claims,
+// and the disclosures (including the nested ones). path is the path of the claims object, empty at the top level.
+func createDisclosures(claims map[string]interface{}, path string,
+	opts *newOpts) (map[string]interface{}, []string, error) {
+	sdClaims := make(map[string]interface{})
+
+	var (
+		disclosures []string
+		digests     []string
+	)
 
 	for key, value := range claims {
-		disclosure, err := createDisclosure(key, value, opts)
+		if key == common.SDKey || key == common.ArrayElementDigestKey {
+			return nil, nil, fmt.Errorf("claim name '%s' is reserved", key)
+		}
+
+		claimPath := key
+		if path != "" {
+			claimPath = path + "." + key
+		}
+
+		obj, isObj := value.(map[string]interface{})
+		_, isArr := value.([]interface{})
+
+		switch {
+		case opts.nonSDClaims[claimPath],
+			opts.structuredClaims && (isObj || isArr) && !opts.recursiveClaimsObjects[claimPath]:
+			v, nested, err := createNestedDisclosures(value, claimPath, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			sdClaims[key] = v
+			disclosures = append(disclosures, nested...)
+
+			continue
+		case isObj && opts.recursiveClaimsObjects[claimPath]:
+			v, nested, err := createDisclosures(obj, claimPath, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			value = v
+			disclosures = append(disclosures, nested...)
+		case opts.structuredClaims:
+			v, nested, err := createNestedDisclosures(value, claimPath, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			value = v
+			disclosures = append(disclosures, nested...)
+		}
+
+		disclosure, digest, err := createDisclosure([]interface{}{key, value}, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		disclosures = append(disclosures, disclosure)
+		digests = append(digests, digest)
+	}
+
+	decoys, err := createDecoyDigests(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	digests = append(digests, decoys...)
+
+	if len(digests) > 0 {
+		// sorted, so the digests order doesn't reveal the claims order
+		sort.Strings(digests)
+
+		sdClaims[common.SDKey] = digests
+	}
+
+	return sdClaims, disclosures, nil
+}
+
+// createNestedDisclosures creates the disclosures of the claims of value, a structured object or an array,
+// the other values are not changed.
+func createNestedDisclosures(value interface{}, path string, opts *newOpts) (interface{}, []string, error) {
+	if !opts.structuredClaims {
+		return value, nil, nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return createDisclosures(v, path, opts)
+	case []interface{}:
+		return createArrayDisclosures(v, path, opts)
+	default:
+		return value, nil, nil
+	}
+}
+
+// createArrayDisclosures replaces the selectively disclosable elements of arr with {"...": digest}.
+func createArrayDisclosures(arr []interface{}, path string, opts *newOpts) ([]interface{}, []string, error) {
+	sdArr := make([]interface{}, 0, len(arr))
+
+	var disclosures []string
+
+	for i, element := range arr {
+		elementPath := path + "[" + strconv.Itoa(i) + "]"
+
+		value, nested, err := createNestedDisclosures(element, elementPath, opts)
 		if err != nil {
-			return nil, fmt.Errorf("create disclosure: %w", err)
+			return nil, nil, err
+		}
+
+		disclosures = append(disclosures, nested...)
+
+		if opts.nonSDClaims[elementPath] {
+			sdArr = append(sdArr, value)
+
+			continue
+		}
+
+		disclosure, digest, err := createDisclosure([]interface{}{value}, opts)
+		if err != nil {
+			return nil, nil, err
 		}
 
 		disclosures = append(disclosures, disclosure)
+		sdArr = append(sdArr, map[string]interface{}{common.ArrayElementDigestKey: digest})
 	}
 
-	return disclosures, nil
+	return sdArr, disclosures, nil
 }
 
-func createDisclosure(key string, value interface{}, opts *newOpts) (string, error) {
+// createDisclosure creates the disclosure [salt, content...] (content being a claim name and value, or an array
+// element) and returns it with its digest.
+func createDisclosure(content []interface{}, opts *newOpts) (string, string, error) {
 	salt, err := opts.getSalt()
 	if err != nil {
-		return "", fmt.Errorf("generate salt: %w", err)
+		return "", "", fmt.Errorf("create disclosure: generate salt: %w", err)
+	}
+
+	disclosureBytes, err := opts.jsonMarshal(append([]interface{}{salt}, content...))
+	if err != nil {
+		return "", "", fmt.Errorf("create disclosure: marshal disclosure: %w", err)
+	}
+
+	disclosure := base64.RawURLEncoding.EncodeToString(disclosureBytes)
+
+	digest, err := common.GetHash(opts.HashAlg, disclosure)
+	if err != nil {
+		return "", "", fmt.Errorf("hash disclosure: %w", err)
 	}
 
-	disclosure := []interface{}{salt, key, value}
+	return disclosure, digest, nil
+}
 
-	disclosureBytes, err := opts.jsonMarshal(disclosure)
+func createDecoyDigests(opts *newOpts) ([]string, error) {
+	if !opts.addDecoyDigests {
+		return nil, nil
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(maxDecoyDigests))
 	if err != nil {
-		return "", fmt.Errorf("marshal disclosure: %w", err)
+		return nil, fmt.Errorf("create decoy digests: %w", err)
+	}
+
+	decoys := make([]string, n.Int64()+1)
+
+	for i := range decoys {
+		salt, err := generateSalt()
+		if err != nil {
+			return nil, fmt.Errorf("create decoy digests: %w", err)
+		}
+
+		decoys[i], err = common.GetHash(opts.HashAlg, salt)
+		if err != nil {
+			return nil, fmt.Errorf("create decoy digests: %w", err)
+		}
+	}
+
+	return decoys, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		set[v] = true
 	}
 
-	return base64.RawURLEncoding.EncodeToString(disclosureBytes), nil
+	return set
 }
 
 func generateSalt() (string, error) {
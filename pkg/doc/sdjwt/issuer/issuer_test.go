@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	afjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
 )
@@ -74,6 +76,39 @@ func TestNew(t *testing.T) {
 		r.NoError(err)
 	})
 
+	t.Run("Create JWS with holder public key", func(t *testing.T) {
+		r := require.New(t)
+
+		pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		holderPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		holderJWK, err := jwksupport.JWKFromKey(holderPubKey)
+		r.NoError(err)
+
+		token, err := New(issuer, claims, nil, afjwt.NewEd25519Signer(privKey), WithHolderPublicKey(holderJWK))
+		r.NoError(err)
+
+		sdJWTSerialized, err := token.Serialize(false)
+		r.NoError(err)
+
+		var parsedClaims map[string]interface{}
+		err = verifyEd25519ViaGoJose(common.ParseSDJWT(sdJWTSerialized).JWTSerialized, pubKey, &parsedClaims)
+		r.NoError(err)
+
+		cnf, ok := parsedClaims[common.CNFKey].(map[string]interface{})
+		r.True(ok)
+
+		jwkBytes, err := json.Marshal(cnf["jwk"])
+		r.NoError(err)
+
+		parsedJWK := &jwk.JWK{}
+		r.NoError(parsedJWK.UnmarshalJSON(jwkBytes))
+		r.Equal(holderPubKey, parsedJWK.Key)
+	})
+
 	t.Run("Create JWS signed by RS256", func(t *testing.T) {
 		r := require.New(t)
 
@@ -165,6 +200,108 @@ func TestNew(t *testing.T) {
 		r.NoError(err)
 	})
 
+	t.Run("Create Structured Claims JWS", func(t *testing.T) {
+		r := require.New(t)
+
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		complexClaims := createComplexClaims()
+		complexClaims["nationalities"] = []interface{}{"US", "DE"}
+
+		token, err := New(issuer, complexClaims, nil, afjwt.NewEd25519Signer(privKey),
+			WithStructuredClaims(true),
+			WithNonSelectivelyDisclosableClaims([]string{"address.country", "nationalities[0]"}))
+		r.NoError(err)
+
+		// 6 top level claims (but the structured address and nationalities), 3 address claims and 1 array element
+		r.Len(token.Disclosures, 10)
+
+		var parsedClaims map[string]interface{}
+		r.NoError(token.DecodeClaims(&parsedClaims))
+		r.Len(parsedClaims[common.SDKey], 6)
+
+		address, ok := parsedClaims["address"].(map[string]interface{})
+		r.True(ok)
+		r.Equal("US", address["country"])
+		r.Len(address[common.SDKey], 3)
+
+		nationalities, ok := parsedClaims["nationalities"].([]interface{})
+		r.True(ok)
+		r.Len(nationalities, 2)
+		r.Equal("US", nationalities[0])
+
+		_, ok = common.ArrayElementDigest(nationalities[1])
+		r.True(ok)
+
+		disclosureClaims, err := common.GetDisclosureClaims(token.Disclosures)
+		r.NoError(err)
+
+		arrayElements := 0
+
+		for _, dc := range disclosureClaims {
+			if dc.Type == common.DisclosureClaimTypeArrayElement {
+				arrayElements++
+
+				r.Equal("DE", dc.Value)
+			}
+		}
+
+		r.Equal(1, arrayElements)
+	})
+
+	t.Run("Create Recursive Claims JWS", func(t *testing.T) {
+		r := require.New(t)
+
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		token, err := New(issuer, createComplexClaims(), nil, afjwt.NewEd25519Signer(privKey),
+			WithRecursiveClaimsObjects([]string{"address"}))
+		r.NoError(err)
+
+		// 7 top level claims and 4 address claims
+		r.Len(token.Disclosures, 11)
+
+		var parsedClaims map[string]interface{}
+		r.NoError(token.DecodeClaims(&parsedClaims))
+		r.Len(parsedClaims[common.SDKey], 7)
+		r.NotContains(parsedClaims, "address")
+
+		disclosureClaims, err := common.GetDisclosureClaims(token.Disclosures)
+		r.NoError(err)
+
+		for _, dc := range disclosureClaims {
+			if dc.Name == "address" {
+				address, ok := dc.Value.(map[string]interface{})
+				r.True(ok)
+				r.Len(address[common.SDKey], 4)
+				r.Len(address, 1)
+			}
+		}
+	})
+
+	t.Run("Create JWS with decoy digests", func(t *testing.T) {
+		r := require.New(t)
+
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		token, err := New(issuer, claims, nil, afjwt.NewEd25519Signer(privKey), WithDecoyDigests(true),
+			WithNonSelectivelyDisclosableClaims([]string{"given_name"}))
+		r.NoError(err)
+		r.Empty(token.Disclosures)
+
+		var parsedClaims map[string]interface{}
+		r.NoError(token.DecodeClaims(&parsedClaims))
+		r.Equal("John", parsedClaims["given_name"])
+
+		sd, ok := parsedClaims[common.SDKey].([]interface{})
+		r.True(ok)
+		r.GreaterOrEqual(len(sd), 1)
+		r.LessOrEqual(len(sd), maxDecoyDigests)
+	})
+
 	t.Run("error - wrong hash function", func(t *testing.T) {
 		r := require.New(t)
 
@@ -209,6 +346,85 @@ func TestNew(t *testing.T) {
 		r.Nil(token)
 		r.Contains(err.Error(), "create disclosure: marshal disclosure: marshal error")
 	})
+
+	t.Run("error - reserved claim name", func(t *testing.T) {
+		r := require.New(t)
+
+		privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		r.NoError(err)
+
+		for _, reserved := range []map[string]interface{}{
+			{"given_name": "John", common.SDKey: []string{"digest"}},
+			{"address": map[string]interface{}{common.ArrayElementDigestKey: "digest"}},
+		} {
+			token, err := New(issuer, reserved, nil, afjwt.NewRS256Signer(privKey, nil), WithStructuredClaims(true))
+			r.Error(err)
+			r.Nil(token)
+			r.Contains(err.Error(), "is reserved")
+		}
+	})
+}
+
+func TestNewFromVC(t *testing.T) {
+	r := require.New(t)
+
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	r.NoError(err)
+
+	signer := afjwt.NewEd25519Signer(privKey)
+
+	vcClaims := map[string]interface{}{
+		"iss": issuer,
+		"sub": "did:example:holder",
+		"vc": map[string]interface{}{
+			"type": []interface{}{"VerifiableCredential"},
+			"credentialSubject": map[string]interface{}{
+				"id":         "did:example:holder",
+				"given_name": "John",
+				"degree": map[string]interface{}{
+					"type": "BachelorDegree",
+				},
+			},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		token, err := NewFromVC(vcClaims, nil, signer, WithStructuredClaims(true))
+		r.NoError(err)
+		r.Len(token.Disclosures, 2)
+
+		var parsedClaims map[string]interface{}
+		r.NoError(token.DecodeClaims(&parsedClaims))
+		r.Equal(issuer, parsedClaims["iss"])
+		r.Equal("sha-256", parsedClaims[common.SDAlgorithmKey])
+
+		vc, ok := parsedClaims["vc"].(map[string]interface{})
+		r.True(ok)
+		r.Equal([]interface{}{"VerifiableCredential"}, vc["type"])
+
+		subject, ok := vc["credentialSubject"].(map[string]interface{})
+		r.True(ok)
+		r.Equal("did:example:holder", subject["id"])
+		r.Len(subject[common.SDKey], 1)
+		r.Contains(subject, "degree")
+
+		// the original claims are not changed
+		r.NotContains(vcClaims["vc"].(map[string]interface{})["credentialSubject"], common.SDKey)
+	})
+
+	t.Run("error - missing vc claim", func(t *testing.T) {
+		token, err := NewFromVC(map[string]interface{}{"iss": issuer}, nil, signer)
+		r.Nil(token)
+		r.EqualError(err, "vc claim must be an object")
+	})
+
+	t.Run("error - several subjects", func(t *testing.T) {
+		token, err := NewFromVC(map[string]interface{}{
+			"vc": map[string]interface{}{"credentialSubject": []interface{}{}},
+		}, nil, signer)
+		r.Nil(token)
+		r.EqualError(err, "credentialSubject must be an object")
+	})
 }
 
 func TestJSONWebToken_DecodeClaims(t *testing.T) {
@@ -288,12 +504,9 @@ func TestJSONWebToken_createDisclosure(t *testing.T) {
 		expectedDisclosureWithSpaces := "WyIzanFjYjY3ejl3a3MwOHp3aUs3RXlRIiwgImdpdmVuX25hbWUiLCAiSm9obiJd"
 		expectedHashWithSpaces := expectedHashWithSpaces
 
-		disclosure, err := createDisclosure("given_name", "John", nOpts)
+		disclosure, dh, err := createDisclosure([]interface{}{"given_name", "John"}, nOpts)
 		require.NoError(t, err)
 		require.Equal(t, expectedDisclosureWithSpaces, disclosure)
-
-		dh, err := common.GetHash(defaultHash, disclosure)
-		require.NoError(t, err)
 		require.Equal(t, expectedHashWithSpaces, dh)
 	})
 
@@ -308,7 +521,7 @@ func TestJSONWebToken_createDisclosure(t *testing.T) {
 				return "_26bc4LT-ac6q2KI6cBW5es", nil
 			}))
 
-		disclosure, err := createDisclosure("family_name", "Möbius", nOpts)
+		disclosure, _, err := createDisclosure([]interface{}{"family_name", "Möbius"}, nOpts)
 		require.NoError(t, err)
 		require.Equal(t, expectedDisclosureWithoutSpaces, disclosure)
 
@@ -318,7 +531,7 @@ func TestJSONWebToken_createDisclosure(t *testing.T) {
 				return "_26bc4LT-ac6q2KI6cBW5es", nil
 			}))
 
-		disclosure, err = createDisclosure("family_name", "Möbius", nOpts)
+		disclosure, _, err = createDisclosure([]interface{}{"family_name", "Möbius"}, nOpts)
 		require.NoError(t, err)
 		require.Equal(t, expectedDisclosureWithSpaces, disclosure)
 	})
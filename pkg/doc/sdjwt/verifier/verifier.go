@@ -7,13 +7,24 @@ SPDX-License-Identifier: Apache-2.0
 package verifier
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	afgjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const (
+	// DefaultKeyBindingMaxAge is the default maximum age of the Key Binding JWT.
+	DefaultKeyBindingMaxAge = 5 * time.Minute
+
+	leeway = time.Minute
 )
 
 // jwtParseOpts holds options for the SD-JWT parsing.
@@ -21,6 +32,11 @@ type parseOpts struct {
 	detachedPayload   []byte
 	sigVerifier       jose.SignatureVerifier
 	signingAlgorithms []string
+
+	keyBindingRequired bool
+	expectedAudience   string
+	expectedNonce      string
+	keyBindingMaxAge   time.Duration
 }
 
 // ParseOpt is the SD-JWT Parser option.
@@ -47,16 +63,60 @@ func WithSigningAlgorithms(algorithms []string) ParseOpt {
 	}
 }
 
-// Parse parses input JWT in serialized form into JSON Web Token.
-func Parse(sdJWTSerialized string, opts ...ParseOpt) (map[string]interface{}, error) {
+// WithKeyBindingRequired option is for requiring the Key Binding JWT proving possession of the "cnf" key. The
+// expected nonce and audience of the Key Binding JWT are then required, so it can't be replayed.
+func WithKeyBindingRequired(flag bool) ParseOpt {
+	return func(opts *parseOpts) {
+		opts.keyBindingRequired = flag
+	}
+}
+
+// WithExpectedAudienceForKeyBinding option is for defining the expected "aud" of the Key Binding JWT.
+func WithExpectedAudienceForKeyBinding(audience string) ParseOpt {
+	return func(opts *parseOpts) {
+		opts.expectedAudience = audience
+	}
+}
+
+// WithExpectedNonceForKeyBinding option is for defining the expected "nonce" of the Key Binding JWT.
+func WithExpectedNonceForKeyBinding(nonce string) ParseOpt {
+	return func(opts *parseOpts) {
+		opts.expectedNonce = nonce
+	}
+}
+
+// WithKeyBindingMaxAge option is for defining how long after its "iat" the Key Binding JWT is accepted,
+// DefaultKeyBindingMaxAge by default. Zero disables the check.
+func WithKeyBindingMaxAge(maxAge time.Duration) ParseOpt {
+	return func(opts *parseOpts) {
+		opts.keyBindingMaxAge = maxAge
+	}
+}
+
+func newParseOpts(opts []ParseOpt) (*parseOpts, error) {
 	pOpts := &parseOpts{
 		signingAlgorithms: []string{"EdDSA", "RS256"},
+		keyBindingMaxAge:  DefaultKeyBindingMaxAge,
 	}
 
 	for _, opt := range opts {
 		opt(pOpts)
 	}
 
+	if pOpts.keyBindingRequired && (pOpts.expectedNonce == "" || pOpts.expectedAudience == "") {
+		return nil, errors.New("expected nonce and audience are required when key binding is required")
+	}
+
+	return pOpts, nil
+}
+
+// Parse parses input JWT in serialized form into JSON Web Token.
+func Parse(sdJWTSerialized string, opts ...ParseOpt) (map[string]interface{}, error) {
+	pOpts, err := newParseOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var jwtOpts []afgjwt.ParseOpt
 	jwtOpts = append(jwtOpts,
 		afgjwt.WithSignatureVerifier(pOpts.sigVerifier),
@@ -99,29 +159,23 @@ func Parse(sdJWTSerialized string, opts ...ParseOpt) (map[string]interface{}, er
 		return nil, err
 	}
 
-	return getVerifiedPayload(sdJWT.Disclosures, signedJWT)
-}
-
-func getVerifiedPayload(disclosures []string, signedJWT *afgjwt.JSONWebToken) (map[string]interface{}, error) {
-	disclosureClaims, err := common.GetDisclosureClaims(disclosures)
+	// Verify the Key Binding JWT (if provided, or required).
+	err = verifyKeyBinding(sdJWT, signedJWT, pOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get verified claims: %w", err)
+		return nil, fmt.Errorf("verify key binding: %w", err)
 	}
 
-	var claims map[string]interface{}
+	return getVerifiedPayload(sdJWT.Disclosures, signedJWT)
+}
 
-	err = signedJWT.DecodeClaims(&claims)
+// getVerifiedPayload replaces the digests of the disclosures (in the SD-JWT payload and recursively in the
+// disclosures) with the disclosed claims and array elements.
+func getVerifiedPayload(disclosures []string, signedJWT *afgjwt.JSONWebToken) (map[string]interface{}, error) {
+	claims, err := common.GetDisclosedClaims(disclosures, signedJWT)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get verified claims: %w", err)
 	}
 
-	for _, dc := range disclosureClaims {
-		claims[dc.Name] = dc.Value
-	}
-
-	delete(claims, common.SDKey)
-	delete(claims, common.SDAlgorithmKey)
-
 	return claims, nil
 }
 
@@ -193,3 +247,126 @@ func verifySDJWT(signedJWT *afgjwt.JSONWebToken) error {
 
 	return nil
 }
+
+// VerifyKeyBinding verifies the Key Binding JWT of the SD-JWT presentation, signedJWT being its SD-JWT which
+// signature is already verified. The Key Binding JWT options apply, the other options are ignored.
+func VerifyKeyBinding(sdJWTSerialized string, signedJWT *afgjwt.JSONWebToken, opts ...ParseOpt) error {
+	pOpts, err := newParseOpts(opts)
+	if err != nil {
+		return err
+	}
+
+	err = verifyKeyBinding(common.ParseSDJWT(sdJWTSerialized), signedJWT, pOpts)
+	if err != nil {
+		return fmt.Errorf("verify key binding: %w", err)
+	}
+
+	return nil
+}
+
+// keyBindingPayload represents the Key Binding JWT payload.
+type keyBindingPayload struct {
+	Nonce    string           `json:"nonce"`
+	Audience string           `json:"aud"`
+	IssuedAt *jwt.NumericDate `json:"iat"`
+	SDHash   string           `json:"sd_hash"`
+}
+
+func verifyKeyBinding(sdJWT *common.SDJWT, signedJWT *afgjwt.JSONWebToken, pOpts *parseOpts) error {
+	if sdJWT.KeyBindingJWT == "" {
+		if pOpts.keyBindingRequired {
+			return errors.New("key binding JWT is required")
+		}
+
+		return nil
+	}
+
+	holderKey, err := getHolderPublicKey(signedJWT)
+	if err != nil {
+		return err
+	}
+
+	sigVerifier, err := afgjwt.GetVerifier(&sigverifier.PublicKey{JWK: holderKey})
+	if err != nil {
+		return fmt.Errorf("get key binding JWT verifier: %w", err)
+	}
+
+	keyBindingJWT, err := afgjwt.Parse(sdJWT.KeyBindingJWT, afgjwt.WithSignatureVerifier(sigVerifier),
+		afgjwt.WithAllowedTypes(common.KeyBindingJWTType))
+	if err != nil {
+		return fmt.Errorf("parse key binding JWT: %w", err)
+	}
+
+	if typ, _ := keyBindingJWT.Headers.Type(); typ != common.KeyBindingJWTType { // nolint:errcheck
+		return fmt.Errorf("key binding JWT typ must be %s", common.KeyBindingJWTType)
+	}
+
+	var payload keyBindingPayload
+
+	err = keyBindingJWT.DecodeClaims(&payload)
+	if err != nil {
+		return fmt.Errorf("decode key binding JWT claims: %w", err)
+	}
+
+	err = verifyKeyBindingPayload(&payload, pOpts)
+	if err != nil {
+		return err
+	}
+
+	sdHash, err := common.GetSDHash(sdJWT, signedJWT)
+	if err != nil {
+		return err
+	}
+
+	if payload.SDHash != sdHash {
+		return errors.New("key binding JWT sd_hash doesn't match the presented SD-JWT")
+	}
+
+	return nil
+}
+
+func verifyKeyBindingPayload(payload *keyBindingPayload, pOpts *parseOpts) error {
+	if pOpts.expectedNonce != "" && payload.Nonce != pOpts.expectedNonce {
+		return errors.New("key binding JWT nonce doesn't match the expected nonce")
+	}
+
+	if pOpts.expectedAudience != "" && payload.Audience != pOpts.expectedAudience {
+		return errors.New("key binding JWT aud doesn't match the expected audience")
+	}
+
+	if payload.IssuedAt == nil {
+		return errors.New("key binding JWT iat is missing")
+	}
+
+	now := time.Now()
+	issuedAt := payload.IssuedAt.Time()
+
+	if issuedAt.After(now.Add(leeway)) {
+		return errors.New("key binding JWT is issued in the future")
+	}
+
+	if pOpts.keyBindingMaxAge > 0 && issuedAt.Before(now.Add(-pOpts.keyBindingMaxAge-leeway)) {
+		return errors.New("key binding JWT is too old")
+	}
+
+	return nil
+}
+
+func getHolderPublicKey(signedJWT *afgjwt.JSONWebToken) (*jwk.JWK, error) {
+	var claims struct {
+		CNF struct {
+			JWK *jwk.JWK `json:"jwk"`
+		} `json:"cnf"`
+	}
+
+	err := signedJWT.DecodeClaims(&claims)
+	if err != nil {
+		return nil, fmt.Errorf("decode cnf claim: %w", err)
+	}
+
+	if claims.CNF.JWK == nil {
+		return nil, errors.New("SD-JWT cnf claim with the holder jwk is missing")
+	}
+
+	return claims.CNF.JWK, nil
+}
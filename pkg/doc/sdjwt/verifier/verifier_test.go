@@ -21,7 +21,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	afjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/holder"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
 )
 
@@ -280,3 +283,143 @@ func buildJWS(signer jose.Signer, claims interface{}) (string, error) {
 }
 
 const additionalDisclosure = `WyIzanFjYjY3ejl3a3MwOHp3aUs3RXlRIiwgImdpdmVuX25hbWUiLCAiSm9obiJd`
+
+func TestKeyBinding(t *testing.T) {
+	r := require.New(t)
+
+	pubKey, privKey, e := ed25519.GenerateKey(rand.Reader)
+	r.NoError(e)
+
+	holderPubKey, holderPrivKey, e := ed25519.GenerateKey(rand.Reader)
+	r.NoError(e)
+
+	holderJWK, e := jwksupport.JWKFromKey(holderPubKey)
+	r.NoError(e)
+
+	token, e := issuer.New(testIssuer, map[string]interface{}{"given_name": "Albert"}, nil,
+		afjwt.NewEd25519Signer(privKey), issuer.WithHolderPublicKey(holderJWK))
+	r.NoError(e)
+
+	sdJWTSerialized, e := token.Serialize(false)
+	r.NoError(e)
+
+	sigVerifier, e := afjwt.NewEd25519Verifier(pubKey)
+	r.NoError(e)
+
+	const (
+		testAudience = "https://example.com/verifier"
+		testNonce    = "nonce"
+	)
+
+	present := func(payload holder.BindingPayload, signer jose.Signer) string {
+		presentation, err := holder.DiscloseClaims(sdJWTSerialized, []string{"given_name"},
+			holder.WithHolderBinding(&holder.BindingInfo{Payload: payload, Signer: signer}))
+		r.NoError(err)
+
+		return presentation
+	}
+
+	holderSigner := afjwt.NewEd25519Signer(holderPrivKey)
+	presentation := present(holder.BindingPayload{Nonce: testNonce, Audience: testAudience}, holderSigner)
+
+	t.Run("success", func(t *testing.T) {
+		claims, err := Parse(presentation, WithSignatureVerifier(sigVerifier), WithKeyBindingRequired(true),
+			WithExpectedNonceForKeyBinding(testNonce), WithExpectedAudienceForKeyBinding(testAudience))
+		r.NoError(err)
+		r.Equal("Albert", claims["given_name"])
+		r.NotNil(claims[common.CNFKey])
+	})
+
+	t.Run("error - key binding required", func(t *testing.T) {
+		sdJWTDisclosed, err := holder.DiscloseClaims(sdJWTSerialized, []string{"given_name"})
+		r.NoError(err)
+
+		_, err = Parse(sdJWTDisclosed, WithSignatureVerifier(sigVerifier), WithKeyBindingRequired(true),
+			WithExpectedNonceForKeyBinding(testNonce), WithExpectedAudienceForKeyBinding(testAudience))
+		r.EqualError(err, "verify key binding: key binding JWT is required")
+	})
+
+	t.Run("error - key binding required without expected nonce or audience", func(t *testing.T) {
+		_, err := Parse(presentation, WithSignatureVerifier(sigVerifier), WithKeyBindingRequired(true))
+		r.EqualError(err, "expected nonce and audience are required when key binding is required")
+
+		_, err = Parse(presentation, WithSignatureVerifier(sigVerifier), WithKeyBindingRequired(true),
+			WithExpectedNonceForKeyBinding(testNonce))
+		r.EqualError(err, "expected nonce and audience are required when key binding is required")
+
+		_, err = Parse(presentation, WithSignatureVerifier(sigVerifier), WithKeyBindingRequired(true),
+			WithExpectedAudienceForKeyBinding(testAudience))
+		r.EqualError(err, "expected nonce and audience are required when key binding is required")
+	})
+
+	t.Run("error - unexpected nonce or audience", func(t *testing.T) {
+		_, err := Parse(presentation, WithSignatureVerifier(sigVerifier), WithExpectedNonceForKeyBinding("other"))
+		r.EqualError(err, "verify key binding: key binding JWT nonce doesn't match the expected nonce")
+
+		_, err = Parse(presentation, WithSignatureVerifier(sigVerifier), WithExpectedAudienceForKeyBinding("other"))
+		r.EqualError(err, "verify key binding: key binding JWT aud doesn't match the expected audience")
+	})
+
+	t.Run("error - not fresh", func(t *testing.T) {
+		old := present(holder.BindingPayload{IssuedAt: jwt.NewNumericDate(time.Now().Add(-time.Hour))}, holderSigner)
+
+		_, err := Parse(old, WithSignatureVerifier(sigVerifier))
+		r.EqualError(err, "verify key binding: key binding JWT is too old")
+
+		_, err = Parse(old, WithSignatureVerifier(sigVerifier), WithKeyBindingMaxAge(2*time.Hour))
+		r.NoError(err)
+
+		future := present(holder.BindingPayload{IssuedAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}, holderSigner)
+
+		_, err = Parse(future, WithSignatureVerifier(sigVerifier))
+		r.EqualError(err, "verify key binding: key binding JWT is issued in the future")
+	})
+
+	t.Run("error - signed with another key", func(t *testing.T) {
+		_, otherPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		r.NoError(err)
+
+		other := present(holder.BindingPayload{}, afjwt.NewEd25519Signer(otherPrivKey))
+
+		_, err = Parse(other, WithSignatureVerifier(sigVerifier))
+		r.Error(err)
+		r.Contains(err.Error(), "parse key binding JWT")
+	})
+
+	t.Run("error - sd_hash mismatch", func(t *testing.T) {
+		sdJWT := common.ParseSDJWT(presentation)
+
+		// the key binding JWT was computed over the disclosure, which is removed
+		_, err := Parse(sdJWT.JWTSerialized+common.DisclosureSeparator+sdJWT.KeyBindingJWT,
+			WithSignatureVerifier(sigVerifier))
+		r.EqualError(err, "verify key binding: key binding JWT sd_hash doesn't match the presented SD-JWT")
+	})
+
+	t.Run("error - no cnf", func(t *testing.T) {
+		noCNFToken, err := issuer.New(testIssuer, map[string]interface{}{"given_name": "Albert"}, nil,
+			afjwt.NewEd25519Signer(privKey))
+		r.NoError(err)
+
+		noCNF, err := noCNFToken.Serialize(false)
+		r.NoError(err)
+
+		sdJWT := common.ParseSDJWT(presentation)
+
+		_, err = Parse(noCNF+common.DisclosureSeparator+sdJWT.KeyBindingJWT, WithSignatureVerifier(sigVerifier))
+		r.EqualError(err, "verify key binding: SD-JWT cnf claim with the holder jwk is missing")
+	})
+
+	t.Run("error - invalid typ", func(t *testing.T) {
+		keyBindingJWT, err := afjwt.NewSigned(map[string]interface{}{"iat": time.Now().Unix()}, nil, holderSigner)
+		r.NoError(err)
+
+		keyBindingJWTSerialized, err := keyBindingJWT.Serialize(false)
+		r.NoError(err)
+
+		sdJWT := common.ParseSDJWT(presentation)
+
+		_, err = Parse(sdJWT.JWTSerialized+common.DisclosureSeparator+sdJWT.Disclosures[0]+
+			common.DisclosureSeparator+keyBindingJWTSerialized, WithSignatureVerifier(sigVerifier))
+		r.EqualError(err, "verify key binding: key binding JWT typ must be kb+jwt")
+	})
+}
@@ -132,6 +132,61 @@ func (p *Processor) GetCanonicalDocument(doc map[string]interface{}, opts ...Pro
 	return []byte(result), nil
 }
 
+// GetCanonicalNQuads canonizes RDF dataset given as N-Quads. Along with the canonical N-Quads it returns
+// the map of the blank node identifiers of the input dataset to the issued canonical ones (e.g. "b0" -> "c14n0").
+func (p *Processor) GetCanonicalNQuads(nquads string) (string, map[string]string, error) {
+	dataset, err := ld.ParseNQuads(nquads)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse N-Quads: %w", err)
+	}
+
+	type blankNodeRef struct {
+		quad     *ld.Quad
+		position int
+		label    string
+	}
+
+	var refs []blankNodeRef
+
+	for graphName, quads := range dataset.Graphs {
+		for _, quad := range quads {
+			for i, node := range []ld.Node{quad.Subject, quad.Object} {
+				if ld.IsBlankNode(node) {
+					refs = append(refs, blankNodeRef{quad: quad, position: i, label: node.GetValue()})
+				}
+			}
+
+			if strings.HasPrefix(graphName, "_:") {
+				refs = append(refs, blankNodeRef{quad: quad, position: 2, label: graphName})
+			}
+		}
+	}
+
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.Format = format
+
+	// Normalisation algorithm replaces the blank node identifiers of the quads in place.
+	view, err := ld.NewNormalisationAlgorithm(p.algorithm).Main(dataset, ldOptions)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to normalize N-Quads: %w", err)
+	}
+
+	result, ok := view.(string)
+	if !ok {
+		return "", nil, fmt.Errorf("failed to normalize N-Quads, invalid view")
+	}
+
+	labels := make(map[string]string, len(refs))
+
+	for _, ref := range refs {
+		node := [...]ld.Node{ref.quad.Subject, ref.quad.Object, ref.quad.Graph}[ref.position]
+
+		labels[strings.TrimPrefix(ref.label, "_:")] = strings.TrimPrefix(node.GetValue(), "_:")
+	}
+
+	return result, labels, nil
+}
+
 // AppendExternalContexts appends external context(s) to the JSON-LD context which can have one
 // or several contexts already.
 func AppendExternalContexts(context interface{}, extraContexts ...string) []interface{} {
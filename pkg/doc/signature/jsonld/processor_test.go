@@ -10,6 +10,8 @@ import (
 	_ "embed"
 	"encoding/json"
 	"log"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -348,6 +350,34 @@ func TestTransformBlankNodes(t *testing.T) {
 	require.Equal(t, ge, gt)
 }
 
+func TestGetCanonicalNQuads(t *testing.T) {
+	t.Run("canonize N-Quads with blank nodes", func(t *testing.T) {
+		const nquads = "_:b1 <http://schema.org/name> \"Alice\" .\n" +
+			"_:b0 <http://schema.org/knows> _:b1 .\n" +
+			"_:b0 <http://schema.org/name> \"Bob\" _:g .\n"
+
+		view, labels, err := jsonld.Default().GetCanonicalNQuads(nquads)
+		require.NoError(t, err)
+		require.Len(t, labels, 3)
+
+		expected := nquads
+		for label, canonicalLabel := range labels {
+			expected = strings.ReplaceAll(expected, "_:"+label+" ", "_:"+canonicalLabel+" ")
+		}
+
+		lines := strings.SplitAfter(expected, "\n")
+		sort.Strings(lines)
+
+		require.Equal(t, strings.Join(lines, ""), view)
+	})
+
+	t.Run("invalid N-Quads", func(t *testing.T) {
+		_, _, err := jsonld.Default().GetCanonicalNQuads("not a quad\n")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse N-Quads")
+	})
+}
+
 func BenchmarkGetCanonicalDocument(b *testing.B) {
 	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
 		URL:     "http://localhost:8652/dummy.jsonld",
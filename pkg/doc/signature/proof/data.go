@@ -85,8 +85,9 @@ func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]i
 
 func prepareCanonicalProofOptions(suite signatureSuite, proofOptions map[string]interface{},
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	// created is optional in Data Integrity proofs
 	value, ok := proofOptions[jsonldCreated]
-	if !ok || value == nil {
+	if (!ok || value == nil) && proofOptions[jsonldType] != DataIntegrityProof {
 		return nil, errors.New("created is missing")
 	}
 
@@ -77,6 +77,14 @@ func TestPrepareCanonicalProofOptions(t *testing.T) {
 	require.NotNil(t, err)
 	require.Nil(t, canonicalProofOptions)
 	require.Contains(t, err.Error(), "created is missing")
+
+	// created is optional in Data Integrity proofs
+	proofOptions[jsonldType] = DataIntegrityProof
+	canonicalProofOptions, err = prepareCanonicalProofOptions(
+		&mockSignatureSuite{}, proofOptions, ldtestutil.WithDocumentLoader(t))
+
+	require.NoError(t, err)
+	require.NotEmpty(t, canonicalProofOptions)
 }
 
 func TestCreateVerifyData(t *testing.T) {
@@ -38,8 +38,16 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldCryptosuite is a key for cryptosuite of the Data Integrity proof.
+	jsonldCryptosuite = "cryptosuite"
 
 	ed25519Signature2020 = "Ed25519Signature2020"
+
+	// ecdsaSD2023 cryptosuite requires base64url multibase encoding of the proof value.
+	ecdsaSD2023 = "ecdsa-sd-2023"
+
+	// DataIntegrityProof is the type of W3C Data Integrity proofs, the proof algorithm is defined by its cryptosuite.
+	DataIntegrityProof = "DataIntegrityProof"
 )
 
 // Proof is cryptographic proof of the integrity of the DID Document.
@@ -54,18 +62,25 @@ type Proof struct {
 	Domain                  string
 	Nonce                   []byte
 	Challenge               string
+	Cryptosuite             string
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
 }
 
-// NewProof creates new proof.
+// NewProof creates new proof. The created time is optional only in Data Integrity proofs.
 func NewProof(emap map[string]interface{}) (*Proof, error) {
-	created := stringEntry(emap[jsonldCreated])
+	var (
+		timeValue *util.TimeWrapper
+		err       error
+	)
 
-	timeValue, err := util.ParseTimeWrapper(created)
-	if err != nil {
-		return nil, err
+	created := stringEntry(emap[jsonldCreated])
+	if created != "" || stringEntry(emap[jsonldType]) != DataIntegrityProof {
+		timeValue, err = util.ParseTimeWrapper(created)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var (
@@ -112,6 +127,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Domain:                  stringEntry(emap[jsonldDomain]),
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
+		Cryptosuite:             stringEntry(emap[jsonldCryptosuite]),
 		CapabilityChain:         capabilityChain,
 	}, nil
 }
@@ -149,7 +165,7 @@ func decodeBase64(s string) ([]byte, error) {
 
 // DecodeProofValue decodes proofValue basing on proof type.
 func DecodeProofValue(s, proofType string) ([]byte, error) {
-	if proofType == ed25519Signature2020 {
+	if proofType == ed25519Signature2020 || proofType == DataIntegrityProof {
 		_, value, err := multibase.Decode(s)
 		if err == nil {
 			return value, nil
@@ -179,6 +195,10 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	emap := make(map[string]interface{})
 	emap[jsonldType] = p.Type
 
+	if p.Cryptosuite != "" {
+		emap[jsonldCryptosuite] = p.Cryptosuite
+	}
+
 	if p.Creator != "" {
 		emap[jsonldCreator] = p.Creator
 	}
@@ -192,7 +212,7 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	}
 
 	if len(p.ProofValue) > 0 {
-		emap[jsonldProofValue] = EncodeProofValue(p.ProofValue, p.Type)
+		emap[jsonldProofValue] = p.encodeProofValue()
 	}
 
 	if len(p.JWS) > 0 {
@@ -224,7 +244,7 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 
 // EncodeProofValue decodes proofValue basing on proof type.
 func EncodeProofValue(proofValue []byte, proofType string) string {
-	if proofType == ed25519Signature2020 {
+	if proofType == ed25519Signature2020 || proofType == DataIntegrityProof {
 		encoded, _ := multibase.Encode(multibase.Base58BTC, proofValue) //nolint: errcheck
 		return encoded
 	}
@@ -232,6 +252,15 @@ func EncodeProofValue(proofValue []byte, proofType string) string {
 	return base64.RawURLEncoding.EncodeToString(proofValue)
 }
 
+func (p *Proof) encodeProofValue() string {
+	if p.Type == DataIntegrityProof && p.Cryptosuite == ecdsaSD2023 {
+		encoded, _ := multibase.Encode(multibase.Base64url, p.ProofValue) //nolint: errcheck
+		return encoded
+	}
+
+	return EncodeProofValue(p.ProofValue, p.Type)
+}
+
 // PublicKeyID provides ID of public key to be used to independently verify the proof.
 // "verificationMethod" field is checked first. If not empty, its value is returned.
 // Otherwise, "creator" field is returned if not empty. Otherwise, error is returned.
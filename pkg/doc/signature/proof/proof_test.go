@@ -75,6 +75,42 @@ func TestProof(t *testing.T) {
 	require.Equal(t, []byte(""), p.Nonce)
 	require.Equal(t, proofValueBytes, p.ProofValue)
 
+	// test Data Integrity proof
+	p, err = NewProof(map[string]interface{}{
+		"type":               "DataIntegrityProof",
+		"cryptosuite":        "eddsa-rdfc-2022",
+		"verificationMethod": "did:example:123456#key1",
+		"created":            "2018-03-15T00:00:00Z",
+		"proofValue":         proofValueMultibase,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, DataIntegrityProof, p.Type)
+	require.Equal(t, "eddsa-rdfc-2022", p.Cryptosuite)
+	require.Equal(t, proofValueBytes, p.ProofValue)
+	require.Equal(t, proofValueMultibase, p.JSONLdObject()["proofValue"])
+	require.Equal(t, "eddsa-rdfc-2022", p.JSONLdObject()["cryptosuite"])
+
+	// test Data Integrity proof without created time
+	p, err = NewProof(map[string]interface{}{
+		"type":               "DataIntegrityProof",
+		"cryptosuite":        "eddsa-rdfc-2022",
+		"verificationMethod": "did:example:123456#key1",
+		"proofValue":         proofValueMultibase,
+	})
+	require.NoError(t, err)
+
+	require.Nil(t, p.Created)
+	require.NotContains(t, p.JSONLdObject(), "created")
+
+	// created time is required in other proofs
+	_, err = NewProof(map[string]interface{}{
+		"type":               "Ed25519Signature2020",
+		"verificationMethod": "did:example:123456#key1",
+		"proofValue":         proofValueMultibase,
+	})
+	require.Error(t, err)
+
 	// test created time with milliseconds section
 	p, err = NewProof(map[string]interface{}{
 		"type":               "type",
@@ -403,6 +439,7 @@ func TestProof_JSONLdObject(t *testing.T) {
 	r.Equal("internal", pJSONLd["domain"])
 	r.Equal("abc", pJSONLd["nonce"])
 	r.Equal("sample-challenge-xyz", pJSONLd["challenge"])
+	r.NotContains(pJSONLd, "cryptosuite")
 
 	// test created time with milliseconds section
 	created, err = time.Parse(time.RFC3339Nano, "2018-03-15T00:00:00.972Z")
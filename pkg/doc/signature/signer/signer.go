@@ -39,6 +39,20 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// dataIntegritySuite is implemented by the signature suites of W3C Data Integrity proofs ("DataIntegrityProof"
+// type), which are distinguished by the cryptosuite.
+type dataIntegritySuite interface {
+	// Cryptosuite returns the name of the Data Integrity cryptosuite (e.g. "eddsa-rdfc-2022")
+	Cryptosuite() string
+}
+
+// proofValueSuite is implemented by the signature suites (e.g. ecdsa-sd-2023) which create the proof value
+// on their own instead of signing the Create Verify Hash data.
+type proofValueSuite interface {
+	// CreateProofValue creates the value of the proof for the document
+	CreateProofValue(doc map[string]interface{}, p *proof.Proof, opts ...jsonld.ProcessorOpts) ([]byte, error)
+}
+
 // DocumentSigner implements signing of JSONLD documents.
 type DocumentSigner struct {
 	signatureSuites []SignatureSuite
@@ -56,6 +70,7 @@ type Context struct {
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	Cryptosuite             string                        // optional, to choose between Data Integrity suites
 }
 
 // New returns new instance of document verifier.
@@ -92,7 +107,7 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		return err
 	}
 
-	suite, err := signer.getSignatureSuite(context.SignatureType)
+	suite, err := signer.getSignatureSuite(context.SignatureType, context.Cryptosuite)
 	if err != nil {
 		return err
 	}
@@ -116,6 +131,10 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		CapabilityChain:         context.CapabilityChain,
 	}
 
+	if diSuite, ok := suite.(dataIntegritySuite); ok {
+		p.Cryptosuite = diSuite.Cryptosuite()
+	}
+
 	// TODO support custom proof purpose
 	//  (https://github.com/hyperledger/aries-framework-go/issues/1586)
 	if p.ProofPurpose == "" {
@@ -126,6 +145,15 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		p.JWS = proof.CreateDetachedJWTHeader(suite.Alg()) + ".."
 	}
 
+	if pvSuite, ok := suite.(proofValueSuite); ok {
+		p.ProofValue, err = pvSuite.CreateProofValue(jsonLdObject, p, append(opts, jsonld.WithValidateRDF())...)
+		if err != nil {
+			return err
+		}
+
+		return proof.AddProof(jsonLdObject, p)
+	}
+
 	message, err := proof.CreateVerifyData(suite, jsonLdObject, p, append(opts, jsonld.WithValidateRDF())...)
 	if err != nil {
 		return err
@@ -150,12 +178,22 @@ func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Pro
 	}
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (signer *DocumentSigner) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type and the cryptosuite (if any).
+func (signer *DocumentSigner) getSignatureSuite(signatureType, cryptosuite string) (SignatureSuite, error) {
 	for _, s := range signer.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+		if !s.Accept(signatureType) {
+			continue
+		}
+
+		if diSuite, ok := s.(dataIntegritySuite); ok && cryptosuite != "" && diSuite.Cryptosuite() != cryptosuite {
+			continue
 		}
+
+		return s, nil
+	}
+
+	if cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", signatureType, cryptosuite)
 	}
 
 	return nil, fmt.Errorf("signature type %s not supported", signatureType)
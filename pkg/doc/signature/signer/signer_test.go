@@ -15,7 +15,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -67,6 +69,63 @@ func TestDocumentSigner_Sign(t *testing.T) {
 	require.Contains(t, proofMap, "jws")
 }
 
+func TestDocumentSigner_SignDataIntegrityProof(t *testing.T) {
+	const doc = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1", "https://w3id.org/security/data-integrity/v1"],
+  "type": ["VerifiableCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+}`
+
+	edSigner, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	ecSigner, err := newCryptoSigner(kmsapi.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	s := New(
+		eddsardfc2022.New(suite.WithSigner(edSigner)),
+		ecdsardfc2019.New(suite.WithSigner(ecSigner)),
+	)
+
+	context := &Context{
+		SignatureType:      proof.DataIntegrityProof,
+		VerificationMethod: "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+	}
+
+	for _, cryptosuite := range []string{"", eddsardfc2022.Cryptosuite, ecdsardfc2019.Cryptosuite} {
+		context.Cryptosuite = cryptosuite
+
+		signedDoc, err := s.Sign(context, []byte(doc), ldtestutil.WithDocumentLoader(t))
+		require.NoError(t, err)
+
+		var signedMap map[string]interface{}
+		require.NoError(t, json.Unmarshal(signedDoc, &signedMap))
+
+		proofs, ok := signedMap["proof"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, proofs, 1)
+
+		proofMap, ok := proofs[0].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, proof.DataIntegrityProof, proofMap["type"])
+		require.Contains(t, proofMap, "proofValue")
+
+		if cryptosuite == "" {
+			// the first Data Integrity suite is used
+			require.Equal(t, eddsardfc2022.Cryptosuite, proofMap["cryptosuite"])
+		} else {
+			require.Equal(t, cryptosuite, proofMap["cryptosuite"])
+		}
+	}
+
+	context.Cryptosuite = "bbs-2023"
+
+	_, err = s.Sign(context, []byte(doc), ldtestutil.WithDocumentLoader(t))
+	require.EqualError(t, err, "signature type DataIntegrityProof with cryptosuite bbs-2023 not supported")
+}
+
 func TestDocumentSigner_SignErrors(t *testing.T) {
 	context := getSignatureContext()
 	signer, err := newCryptoSigner(kmsapi.ED25519Type)
@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdsardfc2019 implements the ecdsa-rdfc-2019 cryptosuite of W3C Data Integrity proofs
+// ("DataIntegrityProof" type) as defined in the Data Integrity ECDSA Cryptosuites specification.
+// It uses the RDF Dataset Canonicalization Algorithm [RDFC-1.0] to transform the input document into its
+// canonical form.
+// It uses ECDSA [FIPS-186-5] as the signature algorithm with either P-256 curve and SHA-256 [RFC6234]
+// message digest algorithm, or P-384 curve and SHA-384 message digest algorithm.
+package ecdsardfc2019
+
+import (
+	"crypto"
+	_ "crypto/sha256" // register SHA-256 hash function
+	_ "crypto/sha512" // register SHA-384 hash function
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Suite implements ecdsa-rdfc-2019 signature suite for a single curve.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+	curve           string
+	hash            crypto.Hash
+}
+
+const (
+	// SignatureType is the signature type of the Data Integrity proofs.
+	SignatureType = proof.DataIntegrityProof
+	// Cryptosuite is the name of the cryptosuite.
+	Cryptosuite   = "ecdsa-rdfc-2019"
+	rdfDataSetAlg = "URDNA2015"
+
+	p256Curve = "P-256"
+	p384Curve = "P-384"
+
+	// sizes of the uncompressed public keys.
+	p256PublicKeySize = 65
+	p384PublicKeySize = 97
+)
+
+// New an instance of ecdsa-rdfc-2019 signature suite for P-256 keys.
+func New(opts ...suite.Opt) *Suite {
+	return newSuite(p256Curve, crypto.SHA256, opts...)
+}
+
+// NewP384 an instance of ecdsa-rdfc-2019 signature suite for P-384 keys.
+func NewP384(opts ...suite.Opt) *Suite {
+	return newSuite(p384Curve, crypto.SHA384, opts...)
+}
+
+func newSuite(curve string, hash crypto.Hash, opts ...suite.Opt) *Suite {
+	s := &Suite{
+		jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg),
+		curve:           curve,
+		hash:            hash,
+	}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// ecdsa-rdfc-2019 signature suite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest made by the hash function of the suite curve.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	h := s.hash.New()
+	h.Write(doc) //nolint:errcheck // hash.Hash never returns an error
+
+	return h.Sum(nil)
+}
+
+// Accept will accept only Data Integrity proof type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns the name of the cryptosuite.
+func (s *Suite) Cryptosuite() string {
+	return Cryptosuite
+}
+
+// AcceptPublicKey accepts ECDSA public keys of the suite curve.
+func (s *Suite) AcceptPublicKey(pubKey *verifier.PublicKey) bool {
+	if pubKey.JWK != nil {
+		return pubKey.JWK.Kty == "EC" && pubKey.JWK.Crv == s.curve
+	}
+
+	if s.curve == p384Curve {
+		return len(pubKey.Value) == p384PublicKeySize
+	}
+
+	return len(pubKey.Value) == p256PublicKeySize
+}
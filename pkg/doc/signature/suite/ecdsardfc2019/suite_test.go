@@ -0,0 +1,212 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsardfc2019
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+)
+
+// Test vectors of the Data Integrity ECDSA Cryptosuites specification
+// (https://www.w3.org/TR/vc-di-ecdsa/#representation-ecdsa-rdfc-2019-with-curve-p-256).
+const (
+	examplesContextURL = "https://www.w3.org/ns/credentials/examples/v2"
+	secretKeyMultibase = "z42twTcNeSYcnqg1FLuSFs2bsGH3ZqbRHFmvS9XMsYhjxvHN"
+	publicKeyMultibase = "zDnaepBuvsQ8cpsWrVKw8fbpGpvPeNSjVPTWoq6cRqaYzBKVP"
+	hashData           = "3a8a522f689025727fb9d1f0fa99a618da023e8494ac74f51015d009d35abc2e" +
+		"517744132ae165a5349155bef0bb0cf2258fff99dfe1dbd914b938d775a36017"
+)
+
+var (
+	//go:embed testdata/examples_v2.jsonld
+	examplesContext []byte //nolint:gochecknoglobals
+	//go:embed testdata/credential.jsonld
+	credentialJSON []byte //nolint:gochecknoglobals
+	//go:embed testdata/proof_options.jsonld
+	proofOptionsJSON []byte //nolint:gochecknoglobals
+	//go:embed testdata/canonical_credential.nq
+	canonicalCredential string //nolint:gochecknoglobals
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	require.Len(t, New().GetDigest([]byte("test doc")), 32)
+	require.Len(t, NewP384().GetDigest([]byte("test doc")), 48)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("DataIntegrityProof"))
+	require.False(t, ss.Accept("JsonWebSignature2020"))
+	require.Equal(t, "ecdsa-rdfc-2019", ss.Cryptosuite())
+	require.Equal(t, "ecdsa-rdfc-2019", NewP384().Cryptosuite())
+}
+
+func TestSignatureSuite_AcceptPublicKey(t *testing.T) {
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	p256Bytes := elliptic.Marshal(p256Key.Curve, p256Key.X, p256Key.Y) //nolint:staticcheck
+	p384Bytes := elliptic.Marshal(p384Key.Curve, p384Key.X, p384Key.Y) //nolint:staticcheck
+
+	require.True(t, New().AcceptPublicKey(&verifier.PublicKey{Value: p256Bytes}))
+	require.False(t, New().AcceptPublicKey(&verifier.PublicKey{Value: p384Bytes}))
+	require.True(t, NewP384().AcceptPublicKey(&verifier.PublicKey{Value: p384Bytes}))
+	require.False(t, NewP384().AcceptPublicKey(&verifier.PublicKey{Value: p256Bytes}))
+
+	p256JWK, err := jwksupport.JWKFromKey(&p256Key.PublicKey)
+	require.NoError(t, err)
+
+	p384JWK, err := jwksupport.JWKFromKey(&p384Key.PublicKey)
+	require.NoError(t, err)
+
+	require.True(t, New().AcceptPublicKey(&verifier.PublicKey{JWK: p256JWK}))
+	require.False(t, New().AcceptPublicKey(&verifier.PublicKey{JWK: p384JWK}))
+	require.True(t, NewP384().AcceptPublicKey(&verifier.PublicKey{JWK: p384JWK}))
+	require.False(t, NewP384().AcceptPublicKey(&verifier.PublicKey{JWK: p256JWK}))
+}
+
+func TestSignatureSuite_TestVector(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     examplesContextURL,
+		Content: examplesContext,
+	})
+	require.NoError(t, err)
+
+	opt := jsonld.WithDocumentLoader(loader)
+	privKey, pubKey := specKeyPair(t)
+
+	t.Run("canonical document", func(t *testing.T) {
+		doc, err := New().GetCanonicalDocument(readJSON(t, credentialJSON), opt)
+		require.NoError(t, err)
+		require.Equal(t, canonicalCredential, string(doc))
+	})
+
+	t.Run("hash data", func(t *testing.T) {
+		verifyData, err := proof.CreateVerifyHash(New(), readJSON(t, credentialJSON),
+			readJSON(t, proofOptionsJSON), opt)
+		require.NoError(t, err)
+		require.Equal(t, hashData, hex.EncodeToString(verifyData))
+	})
+
+	// ECDSA signatures are not deterministic, so the credential is signed with the key of the test vectors
+	// instead of comparing the proof value with the one of the specification.
+	for _, withCreated := range []bool{true, false} {
+		proofOptions := readJSON(t, proofOptionsJSON)
+		if !withCreated {
+			delete(proofOptions, "created")
+		}
+
+		verifyData, err := proof.CreateVerifyHash(New(), readJSON(t, credentialJSON), proofOptions, opt)
+		require.NoError(t, err)
+
+		delete(proofOptions, "@context")
+		proofOptions["proofValue"] = proof.EncodeProofValue(sign(t, privKey, verifyData), proof.DataIntegrityProof)
+
+		credential := readJSON(t, credentialJSON)
+		credential["proof"] = proofOptions
+
+		credentialBytes, err := json.Marshal(credential)
+		require.NoError(t, err)
+
+		v, err := verifier.New(&testKeyResolver{publicKey: pubKey}, New(suite.WithVerifier(NewPublicKeyVerifier())))
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(credentialBytes, opt), "created: %t", withCreated)
+	}
+}
+
+// specKeyPair decodes the multibase key pair of the specification test vectors (the keys are prefixed with
+// the p256-priv 0x8626 and p256-pub 0x8024 multicodec headers, the public key is compressed).
+func specKeyPair(t *testing.T) (*ecdsa.PrivateKey, *verifier.PublicKey) {
+	t.Helper()
+
+	_, secretKey, err := multibase.Decode(secretKeyMultibase)
+	require.NoError(t, err)
+
+	_, publicKey, err := multibase.Decode(publicKeyMultibase)
+	require.NoError(t, err)
+
+	curve := elliptic.P256()
+
+	privKey := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(secretKey[2:])}
+	privKey.Curve = curve
+	privKey.X, privKey.Y = curve.ScalarBaseMult(secretKey[2:])
+
+	x, y := elliptic.UnmarshalCompressed(curve, publicKey[2:])
+	require.NotNil(t, x)
+	require.True(t, privKey.X.Cmp(x) == 0 && privKey.Y.Cmp(y) == 0)
+
+	return privKey, &verifier.PublicKey{
+		Type:  "Multikey",
+		Value: elliptic.Marshal(curve, x, y), //nolint:staticcheck
+	}
+}
+
+func sign(t *testing.T, privKey *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+
+	digest := sha256.Sum256(data)
+
+	r, s, err := ecdsa.Sign(rand.Reader, privKey, digest[:])
+	require.NoError(t, err)
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signature
+}
+
+func readJSON(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+
+	var doc map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	return doc
+}
+
+type testKeyResolver struct {
+	publicKey *verifier.PublicKey
+}
+
+func (r *testKeyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	return r.publicKey, nil
+}
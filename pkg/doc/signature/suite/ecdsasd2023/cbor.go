@@ -0,0 +1,219 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// CBOR (RFC 8949) major types used by the ecdsa-sd-2023 proof values.
+const (
+	cborUint       = 0
+	cborByteString = 2
+	cborTextString = 3
+	cborArray      = 4
+	cborMap        = 5
+
+	cborMaxDepth = 8
+)
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+// cborEncode encodes a value made of uint64, []byte, string, []interface{} and map[uint64]interface{} values
+// using the deterministic encoding (preferred serialization with the map keys sorted).
+func cborEncode(v interface{}) ([]byte, error) {
+	return cborAppend(nil, v)
+}
+
+func cborAppend(b []byte, v interface{}) ([]byte, error) {
+	var err error
+
+	switch t := v.(type) {
+	case uint64:
+		return cborAppendHead(b, cborUint, t), nil
+	case []byte:
+		return append(cborAppendHead(b, cborByteString, uint64(len(t))), t...), nil
+	case string:
+		return append(cborAppendHead(b, cborTextString, uint64(len(t))), t...), nil
+	case []interface{}:
+		b = cborAppendHead(b, cborArray, uint64(len(t)))
+
+		for _, item := range t {
+			b, err = cborAppend(b, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return b, nil
+	case map[uint64]interface{}:
+		keys := make([]uint64, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+		b = cborAppendHead(b, cborMap, uint64(len(t)))
+
+		for _, k := range keys {
+			b = cborAppendHead(b, cborUint, k)
+
+			b, err = cborAppend(b, t[k])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return b, nil
+	default:
+		return nil, fmt.Errorf("cbor: unsupported type %T", v)
+	}
+}
+
+func cborAppendHead(b []byte, major byte, n uint64) []byte {
+	const (
+		maxImmediate = 23
+		uint8Follows = 24
+	)
+
+	mt := major << 5 //nolint:gomnd // major type is the 3 high-order bits
+
+	switch {
+	case n <= maxImmediate:
+		return append(b, mt|byte(n))
+	case n <= 0xff:
+		return append(b, mt|uint8Follows, byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, mt|(uint8Follows+1)), uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, mt|(uint8Follows+2)), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, mt|(uint8Follows+3)), n)
+	}
+}
+
+// cborDecode decodes a single CBOR data item encoded by cborEncode.
+func cborDecode(data []byte) (interface{}, error) {
+	v, rest, err := cborDecodeItem(data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 {
+		return nil, errors.New("cbor: unexpected data after the data item")
+	}
+
+	return v, nil
+}
+
+//nolint:gocyclo
+func cborDecodeItem(data []byte, depth int) (interface{}, []byte, error) {
+	if depth > cborMaxDepth {
+		return nil, nil, errors.New("cbor: data item is nested too deeply")
+	}
+
+	major, n, data, err := cborDecodeHead(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case cborUint:
+		return n, data, nil
+	case cborByteString, cborTextString:
+		if uint64(len(data)) < n {
+			return nil, nil, errCBORTruncated
+		}
+
+		if major == cborTextString {
+			return string(data[:n]), data[n:], nil
+		}
+
+		return append([]byte{}, data[:n]...), data[n:], nil
+	case cborArray:
+		if uint64(len(data)) < n {
+			return nil, nil, errCBORTruncated
+		}
+
+		items := make([]interface{}, n)
+
+		for i := range items {
+			items[i], data, err = cborDecodeItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return items, data, nil
+	case cborMap:
+		if uint64(len(data)) < n {
+			return nil, nil, errCBORTruncated
+		}
+
+		m := make(map[uint64]interface{}, n)
+
+		for i := uint64(0); i < n; i++ {
+			var key, value interface{}
+
+			key, data, err = cborDecodeItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			k, ok := key.(uint64)
+			if !ok {
+				return nil, nil, errors.New("cbor: only unsigned integer map keys are supported")
+			}
+
+			value, data, err = cborDecodeItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			m[k] = value
+		}
+
+		return m, data, nil
+	default:
+		return nil, nil, fmt.Errorf("cbor: unsupported major type %d", major)
+	}
+}
+
+func cborDecodeHead(data []byte) (byte, uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, 0, nil, errCBORTruncated
+	}
+
+	major, info := data[0]>>5, data[0]&0x1f //nolint:gomnd // major type and additional information bits
+	data = data[1:]
+
+	var size int
+
+	switch {
+	case info < 24: //nolint:gomnd // immediate value
+		return major, uint64(info), data, nil
+	case info <= 27: //nolint:gomnd // 1, 2, 4 or 8 bytes value follows
+		size = 1 << (info - 24)
+	default:
+		return 0, 0, nil, fmt.Errorf("cbor: unsupported additional information %d", info)
+	}
+
+	if len(data) < size {
+		return 0, 0, nil, errCBORTruncated
+	}
+
+	var n uint64
+
+	for _, b := range data[:size] {
+		n = n<<8 | uint64(b) //nolint:gomnd // big-endian byte
+	}
+
+	return major, n, data[size:], nil
+}
@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var (
+	// CBOR tag prefixes of the base and derived proof values.
+	baseProofHeader    = []byte{0xd9, 0x5d, 0x00}
+	derivedProofHeader = []byte{0xd9, 0x5d, 0x01}
+
+	// multicodec prefix of the compressed P-256 public key.
+	p256MulticodecPrefix = []byte{0x80, 0x24}
+)
+
+const (
+	baseProofComponents    = 5
+	derivedProofComponents = 5
+
+	canonicalLabelPrefix = "c14n"
+	hmacLabelPrefix      = "u"
+)
+
+// baseProofValue holds the components of the base proof created by the issuer.
+type baseProofValue struct {
+	baseSignature     []byte
+	publicKey         []byte
+	hmacKey           []byte
+	signatures        [][]byte
+	mandatoryPointers []string
+}
+
+// derivedProofValue holds the components of the proof derived by the holder for the revealed document.
+type derivedProofValue struct {
+	baseSignature []byte
+	publicKey     []byte
+	signatures    [][]byte
+	// labelMap maps canonical blank node labels of the revealed document to the HMAC-based labels.
+	labelMap         map[string]string
+	mandatoryIndexes []int
+}
+
+func (v *baseProofValue) marshal() ([]byte, error) {
+	pointers := make([]interface{}, len(v.mandatoryPointers))
+	for i, p := range v.mandatoryPointers {
+		pointers[i] = p
+	}
+
+	components, err := cborEncode([]interface{}{
+		v.baseSignature, v.publicKey, v.hmacKey, bytesArray(v.signatures), pointers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, baseProofHeader...), components...), nil
+}
+
+func (v *derivedProofValue) marshal() ([]byte, error) {
+	labelMap := make(map[uint64]interface{}, len(v.labelMap))
+
+	for canonicalLabel, hmacLabel := range v.labelMap {
+		k, err := strconv.ParseUint(strings.TrimPrefix(canonicalLabel, canonicalLabelPrefix), 10, 64)
+		if err != nil || !strings.HasPrefix(canonicalLabel, canonicalLabelPrefix) {
+			return nil, fmt.Errorf("invalid canonical blank node label %s", canonicalLabel)
+		}
+
+		labelMap[k], err = base64.RawURLEncoding.DecodeString(strings.TrimPrefix(hmacLabel, hmacLabelPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid HMAC blank node label %s", hmacLabel)
+		}
+	}
+
+	indexes := make([]interface{}, len(v.mandatoryIndexes))
+	for i, idx := range v.mandatoryIndexes {
+		indexes[i] = uint64(idx)
+	}
+
+	components, err := cborEncode([]interface{}{
+		v.baseSignature, v.publicKey, bytesArray(v.signatures), labelMap, indexes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, derivedProofHeader...), components...), nil
+}
+
+func isBaseProofValue(proofValue []byte) bool {
+	return bytes.HasPrefix(proofValue, baseProofHeader)
+}
+
+func parseBaseProofValue(proofValue []byte) (*baseProofValue, error) {
+	components, err := decodeComponents(proofValue, baseProofHeader, baseProofComponents)
+	if err != nil {
+		return nil, fmt.Errorf("parse base proof value: %w", err)
+	}
+
+	v := &baseProofValue{}
+
+	var ok bool
+
+	v.baseSignature, ok = components[0].([]byte)
+	if !ok {
+		return nil, errors.New("parse base proof value: invalid base signature")
+	}
+
+	v.publicKey, ok = components[1].([]byte)
+	if !ok {
+		return nil, errors.New("parse base proof value: invalid public key")
+	}
+
+	v.hmacKey, ok = components[2].([]byte)
+	if !ok {
+		return nil, errors.New("parse base proof value: invalid HMAC key")
+	}
+
+	v.signatures, err = toBytesArray(components[3])
+	if err != nil {
+		return nil, fmt.Errorf("parse base proof value: %w", err)
+	}
+
+	pointers, ok := components[4].([]interface{})
+	if !ok {
+		return nil, errors.New("parse base proof value: invalid mandatory pointers")
+	}
+
+	for _, p := range pointers {
+		pointer, ok := p.(string)
+		if !ok {
+			return nil, errors.New("parse base proof value: invalid mandatory pointer")
+		}
+
+		v.mandatoryPointers = append(v.mandatoryPointers, pointer)
+	}
+
+	return v, nil
+}
+
+//nolint:gocyclo
+func parseDerivedProofValue(proofValue []byte) (*derivedProofValue, error) {
+	components, err := decodeComponents(proofValue, derivedProofHeader, derivedProofComponents)
+	if err != nil {
+		return nil, fmt.Errorf("parse derived proof value: %w", err)
+	}
+
+	v := &derivedProofValue{labelMap: make(map[string]string)}
+
+	var ok bool
+
+	v.baseSignature, ok = components[0].([]byte)
+	if !ok {
+		return nil, errors.New("parse derived proof value: invalid base signature")
+	}
+
+	v.publicKey, ok = components[1].([]byte)
+	if !ok {
+		return nil, errors.New("parse derived proof value: invalid public key")
+	}
+
+	v.signatures, err = toBytesArray(components[2])
+	if err != nil {
+		return nil, fmt.Errorf("parse derived proof value: %w", err)
+	}
+
+	labelMap, ok := components[3].(map[uint64]interface{})
+	if !ok {
+		return nil, errors.New("parse derived proof value: invalid label map")
+	}
+
+	for k, label := range labelMap {
+		l, ok := label.([]byte)
+		if !ok {
+			return nil, errors.New("parse derived proof value: invalid label map")
+		}
+
+		v.labelMap[canonicalLabelPrefix+strconv.FormatUint(k, 10)] =
+			hmacLabelPrefix + base64.RawURLEncoding.EncodeToString(l)
+	}
+
+	indexes, ok := components[4].([]interface{})
+	if !ok {
+		return nil, errors.New("parse derived proof value: invalid mandatory indexes")
+	}
+
+	for _, idx := range indexes {
+		i, ok := idx.(uint64)
+		if !ok {
+			return nil, errors.New("parse derived proof value: invalid mandatory index")
+		}
+
+		v.mandatoryIndexes = append(v.mandatoryIndexes, int(i))
+	}
+
+	return v, nil
+}
+
+func decodeComponents(proofValue, header []byte, count int) ([]interface{}, error) {
+	if !bytes.HasPrefix(proofValue, header) {
+		return nil, errors.New("invalid proof value header")
+	}
+
+	decoded, err := cborDecode(proofValue[len(header):])
+	if err != nil {
+		return nil, err
+	}
+
+	components, ok := decoded.([]interface{})
+	if !ok || len(components) != count {
+		return nil, fmt.Errorf("expected array of %d components", count)
+	}
+
+	return components, nil
+}
+
+func bytesArray(values [][]byte) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+
+	return result
+}
+
+func toBytesArray(v interface{}) ([][]byte, error) {
+	values, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid signatures")
+	}
+
+	result := make([][]byte, len(values))
+
+	for i, value := range values {
+		result[i], ok = value.([]byte)
+		if !ok {
+			return nil, errors.New("invalid signature")
+		}
+	}
+
+	return result, nil
+}
+
+// marshalPublicKey encodes the P-256 public key as compressed point prefixed with its multicodec.
+func marshalPublicKey(pubKey *ecdsa.PublicKey) []byte {
+	point := elliptic.MarshalCompressed(pubKey.Curve, pubKey.X, pubKey.Y)
+
+	return append(append([]byte{}, p256MulticodecPrefix...), point...)
+}
+
+func unmarshalPublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	if !bytes.HasPrefix(b, p256MulticodecPrefix) {
+		return nil, errors.New("public key is not a P-256 multikey")
+	}
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), b[len(p256MulticodecPrefix):])
+	if x == nil {
+		return nil, errors.New("invalid P-256 public key")
+	}
+
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// skolemPrefix is the prefix of the IRIs given to the blank nodes of the document, so that the statements
+	// of the selected parts of the document can be matched with the statements of the whole document.
+	skolemPrefix = "urn:bnid:"
+
+	jsonldContext = "@context"
+	jsonldID      = "@id"
+)
+
+// skolemize returns a copy of the compact JSON-LD document where every node object without an identifier
+// gets a "urn:bnid:" IRI.
+func skolemize(doc map[string]interface{}) map[string]interface{} {
+	counter := 0
+
+	return skolemizeValue(doc, &counter).(map[string]interface{})
+}
+
+func skolemizeValue(v interface{}, counter *int) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t)+1)
+
+		for k, value := range t {
+			if k == jsonldContext {
+				result[k] = value
+
+				continue
+			}
+
+			result[k] = skolemizeValue(value, counter)
+		}
+
+		if isNodeObject(t) && t["id"] == nil && t[jsonldID] == nil {
+			result[jsonldID] = fmt.Sprintf("%sb%d", skolemPrefix, *counter)
+			*counter++
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(t))
+
+		for i, value := range t {
+			result[i] = skolemizeValue(value, counter)
+		}
+
+		return result
+	default:
+		return v
+	}
+}
+
+func isNodeObject(obj map[string]interface{}) bool {
+	for _, k := range []string{"@value", "@list", "@set"} {
+		if _, ok := obj[k]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unskolemize removes the "urn:bnid:" identifiers given by skolemize from the document.
+func unskolemize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+
+		for k, value := range t {
+			if id, ok := value.(string); ok && k == jsonldID && strings.HasPrefix(id, skolemPrefix) {
+				continue
+			}
+
+			if k == jsonldContext {
+				result[k] = value
+
+				continue
+			}
+
+			result[k] = unskolemize(value)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(t))
+
+		for i, value := range t {
+			result[i] = unskolemize(value)
+		}
+
+		return result
+	default:
+		return v
+	}
+}
+
+// selectJSONLD creates a JSON-LD document with the values of the document selected by JSON pointers (RFC 6901).
+// The identifiers and types of the node objects on the paths to the selected values are kept as well.
+func selectJSONLD(doc map[string]interface{}, pointers []string) (map[string]interface{}, error) {
+	selection := nodeSkeleton(doc).(map[string]interface{})
+	selection[jsonldContext] = doc[jsonldContext]
+
+	for _, pointer := range pointers {
+		paths, err := parseJSONPointer(pointer)
+		if err != nil {
+			return nil, err
+		}
+
+		err = selectPaths(doc, selection, paths)
+		if err != nil {
+			return nil, fmt.Errorf("select JSON pointer %s: %w", pointer, err)
+		}
+	}
+
+	return removeGaps(selection).(map[string]interface{}), nil
+}
+
+func parseJSONPointer(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer: %s", pointer)
+	}
+
+	paths := strings.Split(pointer[1:], "/")
+
+	for i, p := range paths {
+		paths[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+
+	return paths, nil
+}
+
+func selectPaths(doc, selection interface{}, paths []string) error {
+	value, selected := doc, selection
+
+	for i, path := range paths {
+		var (
+			child, selectedChild interface{}
+			set                  func(v interface{})
+		)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+
+			child, ok = v[path]
+			if !ok {
+				return fmt.Errorf("property %s is not found", path)
+			}
+
+			s := selected.(map[string]interface{})
+			selectedChild = s[path]
+			set = func(v interface{}) { s[path] = v }
+		case []interface{}:
+			idx, err := strconv.Atoi(path)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return fmt.Errorf("array index %s is out of range", path)
+			}
+
+			child = v[idx]
+
+			s := selected.([]interface{})
+			selectedChild = s[idx]
+			set = func(v interface{}) { s[idx] = v }
+		default:
+			return errors.New("path goes beyond a value")
+		}
+
+		switch {
+		case i == len(paths)-1:
+			selectedChild = copyValue(child)
+		case selectedChild == nil:
+			selectedChild = nodeSkeleton(child)
+		}
+
+		set(selectedChild)
+
+		value, selected = child, selectedChild
+	}
+
+	return nil
+}
+
+// nodeSkeleton creates an empty container for the selected values of a JSON object or array.
+func nodeSkeleton(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		skeleton := make(map[string]interface{})
+
+		for _, k := range []string{"id", jsonldID, "type", "@type"} {
+			if value, ok := t[k]; ok {
+				skeleton[k] = copyValue(value)
+			}
+		}
+
+		return skeleton
+	case []interface{}:
+		return make([]interface{}, len(t))
+	default:
+		return v
+	}
+}
+
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for k, value := range t {
+			result[k] = copyValue(value)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(t))
+		for i, value := range t {
+			result[i] = copyValue(value)
+		}
+
+		return result
+	default:
+		return v
+	}
+}
+
+// removeGaps removes the array elements which were not selected.
+func removeGaps(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, value := range t {
+			if k != jsonldContext {
+				t[k] = removeGaps(value)
+			}
+		}
+
+		return t
+	case []interface{}:
+		result := make([]interface{}, 0, len(t))
+
+		for _, value := range t {
+			if value != nil {
+				result = append(result, removeGaps(value))
+			}
+		}
+
+		return result
+	default:
+		return v
+	}
+}
@@ -0,0 +1,593 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdsasd2023 implements the ecdsa-sd-2023 selective disclosure cryptosuite of W3C Data Integrity
+// proofs ("DataIntegrityProof" type) as defined in the Data Integrity ECDSA Cryptosuites specification.
+// The issuer creates a base proof which signs every statement of the canonical document (RDFC-1.0 with
+// HMAC-based blank node labels) with an ephemeral P-256 key, and signs the ephemeral key along with the hash
+// of the mandatory statements with its own P-256 key. The holder derives a proof for the parts of the document
+// selected by JSON pointers, and the verifier checks the signatures of the revealed statements only.
+package ecdsasd2023
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Suite implements ecdsa-sd-2023 signature suite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor   *jsonld.Processor
+	mandatoryPointers []string
+}
+
+const (
+	// SignatureType is the signature type of the Data Integrity proofs.
+	SignatureType = proof.DataIntegrityProof
+	// Cryptosuite is the name of the cryptosuite.
+	Cryptosuite   = "ecdsa-sd-2023"
+	rdfDataSetAlg = "URDNA2015"
+
+	p256Curve         = "P-256"
+	p256PublicKeySize = 65
+	p256ScalarSize    = 32
+	hmacKeySize       = 32
+)
+
+//nolint:gochecknoglobals
+var (
+	skolemIRIRegexp      = regexp.MustCompile(`<` + skolemPrefix + `([^>]+)>`)
+	canonicalLabelRegexp = regexp.MustCompile(`_:(` + canonicalLabelPrefix + `[0-9]+)`)
+)
+
+// New an instance of ecdsa-sd-2023 signature suite. It can be used to verify proofs and derive proofs
+// from base proofs.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// NewWithMandatoryPointers an instance of ecdsa-sd-2023 signature suite for creation of base proofs.
+// The values selected by the mandatory JSON pointers are always disclosed by the holder.
+func NewWithMandatoryPointers(mandatoryPointers []string, opts ...suite.Opt) *Suite {
+	s := New(opts...)
+	s.mandatoryPointers = mandatoryPointers
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// ecdsa-sd-2023 signature suite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns SHA-256 digest of the document.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only Data Integrity proof type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns the name of the cryptosuite.
+func (s *Suite) Cryptosuite() string {
+	return Cryptosuite
+}
+
+// AcceptPublicKey accepts ECDSA P-256 public keys.
+func (s *Suite) AcceptPublicKey(pubKey *verifier.PublicKey) bool {
+	if pubKey.JWK != nil {
+		return pubKey.JWK.Kty == "EC" && pubKey.JWK.Crv == p256Curve
+	}
+
+	return len(pubKey.Value) == p256PublicKeySize
+}
+
+// CreateProofValue creates the value of the base proof for the document.
+func (s *Suite) CreateProofValue(doc map[string]interface{}, p *proof.Proof,
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	proofHash, err := s.proofConfigHash(doc, p, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	hmacKey := make([]byte, hmacKeySize)
+
+	_, err = rand.Read(hmacKey)
+	if err != nil {
+		return nil, fmt.Errorf("generate HMAC key: %w", err)
+	}
+
+	cd, err := s.canonicalize(proof.GetCopyWithoutProof(doc), hmacKey, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	mandatory, _, err := cd.selectStatements(s, s.mandatoryPointers, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("select mandatory statements: %w", err)
+	}
+
+	ephemeralKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate ephemeral key: %w", err)
+	}
+
+	publicKey := marshalPublicKey(&ephemeralKey.PublicKey)
+
+	var (
+		mandatoryStatements []string
+		signatures          [][]byte
+	)
+
+	for i, statement := range cd.statements {
+		if mandatory[i] {
+			mandatoryStatements = append(mandatoryStatements, statement)
+
+			continue
+		}
+
+		signature, err := signStatement(ephemeralKey, statement)
+		if err != nil {
+			return nil, err
+		}
+
+		signatures = append(signatures, signature)
+	}
+
+	baseSignature, err := s.Sign(signatureData(proofHash, publicKey, mandatoryStatements))
+	if err != nil {
+		return nil, err
+	}
+
+	return (&baseProofValue{
+		baseSignature:     baseSignature,
+		publicKey:         publicKey,
+		hmacKey:           hmacKey,
+		signatures:        signatures,
+		mandatoryPointers: s.mandatoryPointers,
+	}).marshal()
+}
+
+// SelectiveDisclosure creates a document with the mandatory values and the values selected by JSON pointers
+// of the document secured with ecdsa-sd-2023 base proof, along with the derived proof.
+//
+//nolint:funlen
+func (s *Suite) SelectiveDisclosure(doc map[string]interface{}, selectivePointers []string,
+	opts ...jsonld.ProcessorOpts) (map[string]interface{}, error) {
+	p, err := getBaseProof(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := parseBaseProofValue(p.ProofValue)
+	if err != nil {
+		return nil, err
+	}
+
+	cd, err := s.canonicalize(proof.GetCopyWithoutProof(doc), base.hmacKey, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	mandatory, _, err := cd.selectStatements(s, base.mandatoryPointers, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("select mandatory statements: %w", err)
+	}
+
+	combinedPointers := append(append([]string{}, base.mandatoryPointers...), selectivePointers...)
+	if len(combinedPointers) == 0 {
+		return nil, errors.New("no values are selected for disclosure")
+	}
+
+	combined, selection, err := cd.selectStatements(s, combinedPointers, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("select statements: %w", err)
+	}
+
+	if len(base.signatures) != len(cd.statements)-len(mandatory) {
+		return nil, errors.New("base proof signatures do not match the document statements")
+	}
+
+	derived := &derivedProofValue{
+		baseSignature: base.baseSignature,
+		publicKey:     base.publicKey,
+	}
+
+	nonMandatoryIdx, combinedIdx := 0, 0
+
+	for i := range cd.statements {
+		if !mandatory[i] {
+			if combined[i] {
+				derived.signatures = append(derived.signatures, base.signatures[nonMandatoryIdx])
+			}
+
+			nonMandatoryIdx++
+		}
+
+		if combined[i] {
+			if mandatory[i] {
+				derived.mandatoryIndexes = append(derived.mandatoryIndexes, combinedIdx)
+			}
+
+			combinedIdx++
+		}
+	}
+
+	derived.labelMap, err = s.revealedLabelMap(cd, selection, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedProof := *p
+
+	derivedProof.ProofValue, err = derived.marshal()
+	if err != nil {
+		return nil, fmt.Errorf("create derived proof value: %w", err)
+	}
+
+	revealedDoc := unskolemize(selection).(map[string]interface{})
+
+	err = proof.AddProof(revealedDoc, &derivedProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return revealedDoc, nil
+}
+
+// VerifyProofValue verifies the base or derived proof of the document.
+func (s *Suite) VerifyProofValue(pubKey *verifier.PublicKey, doc map[string]interface{}, p *proof.Proof,
+	opts ...jsonld.ProcessorOpts) error {
+	var (
+		statements []string
+		mandatory  map[int]bool
+		v          *derivedProofValue
+		err        error
+	)
+
+	docWithoutProof := proof.GetCopyWithoutProof(doc)
+
+	if isBaseProofValue(p.ProofValue) {
+		statements, mandatory, v, err = s.baseProofStatements(docWithoutProof, p.ProofValue, opts...)
+	} else {
+		statements, mandatory, v, err = s.derivedProofStatements(docWithoutProof, p.ProofValue, opts...)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var mandatoryStatements, nonMandatoryStatements []string
+
+	for i, statement := range statements {
+		if mandatory[i] {
+			mandatoryStatements = append(mandatoryStatements, statement)
+		} else {
+			nonMandatoryStatements = append(nonMandatoryStatements, statement)
+		}
+	}
+
+	if len(v.signatures) != len(nonMandatoryStatements) {
+		return fmt.Errorf("signature count %d does not match non-mandatory statement count %d",
+			len(v.signatures), len(nonMandatoryStatements))
+	}
+
+	proofHash, err := s.proofConfigHash(doc, p, opts...)
+	if err != nil {
+		return err
+	}
+
+	err = s.Verify(pubKey, signatureData(proofHash, v.publicKey, mandatoryStatements), v.baseSignature)
+	if err != nil {
+		return fmt.Errorf("verify base signature: %w", err)
+	}
+
+	ephemeralKey, err := unmarshalPublicKey(v.publicKey)
+	if err != nil {
+		return err
+	}
+
+	for i, statement := range nonMandatoryStatements {
+		if !verifyStatement(ephemeralKey, statement, v.signatures[i]) {
+			return fmt.Errorf("invalid signature of statement %d", i)
+		}
+	}
+
+	return nil
+}
+
+// baseProofStatements returns the statements of the document secured with base proof along with the indexes
+// of the mandatory ones.
+func (s *Suite) baseProofStatements(doc map[string]interface{}, proofValue []byte,
+	opts ...jsonld.ProcessorOpts) ([]string, map[int]bool, *derivedProofValue, error) {
+	base, err := parseBaseProofValue(proofValue)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cd, err := s.canonicalize(doc, base.hmacKey, opts...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	mandatory, _, err := cd.selectStatements(s, base.mandatoryPointers, opts...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("select mandatory statements: %w", err)
+	}
+
+	return cd.statements, mandatory, &derivedProofValue{
+		baseSignature: base.baseSignature,
+		publicKey:     base.publicKey,
+		signatures:    base.signatures,
+	}, nil
+}
+
+// derivedProofStatements returns the statements of the revealed document with the blank node labels replaced
+// by the HMAC-based labels of the derived proof, along with the indexes of the mandatory ones.
+func (s *Suite) derivedProofStatements(doc map[string]interface{}, proofValue []byte,
+	opts ...jsonld.ProcessorOpts) ([]string, map[int]bool, *derivedProofValue, error) {
+	derived, err := parseDerivedProofValue(proofValue)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	canonicalDoc, err := s.GetCanonicalDocument(doc, opts...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var missingLabel string
+
+	relabeled := canonicalLabelRegexp.ReplaceAllStringFunc(string(canonicalDoc), func(label string) string {
+		hmacLabel, ok := derived.labelMap[strings.TrimPrefix(label, "_:")]
+		if !ok {
+			missingLabel = label
+		}
+
+		return "_:" + hmacLabel
+	})
+
+	if missingLabel != "" {
+		return nil, nil, nil, fmt.Errorf("blank node %s is not found in the label map", missingLabel)
+	}
+
+	statements := splitStatements(relabeled)
+	sort.Strings(statements)
+
+	mandatory := make(map[int]bool, len(derived.mandatoryIndexes))
+
+	for _, idx := range derived.mandatoryIndexes {
+		if idx < 0 || idx >= len(statements) {
+			return nil, nil, nil, fmt.Errorf("mandatory index %d is out of range", idx)
+		}
+
+		mandatory[idx] = true
+	}
+
+	return statements, mandatory, derived, nil
+}
+
+// proofConfigHash returns SHA-256 digest of the canonical proof configuration (the proof without its value).
+func (s *Suite) proofConfigHash(doc map[string]interface{}, p *proof.Proof,
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	proofConfig := p.JSONLdObject()
+	delete(proofConfig, "proofValue")
+
+	proofConfig[jsonldContext] = doc[jsonldContext]
+
+	canonicalProofConfig, err := s.GetCanonicalDocument(proofConfig, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize proof configuration: %w", err)
+	}
+
+	return s.GetDigest(canonicalProofConfig), nil
+}
+
+// canonicalDocument holds the canonical statements of the document with HMAC-based blank node labels.
+type canonicalDocument struct {
+	// skolemized is the document with "urn:bnid:" IRIs given to the blank nodes.
+	skolemized map[string]interface{}
+	// statements are the sorted canonical N-Quads with HMAC-based blank node labels.
+	statements []string
+	// labels maps skolem IDs to HMAC-based blank node labels.
+	labels map[string]string
+	index  map[string]int
+}
+
+func (s *Suite) canonicalize(doc map[string]interface{}, hmacKey []byte,
+	opts ...jsonld.ProcessorOpts) (*canonicalDocument, error) {
+	skolemized := skolemize(doc)
+
+	skolemizedStatements, err := s.GetCanonicalDocument(copyValue(skolemized).(map[string]interface{}), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize document: %w", err)
+	}
+
+	_, canonicalLabels, err := s.jsonldProcessor.GetCanonicalNQuads(deskolemize(string(skolemizedStatements)))
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize document: %w", err)
+	}
+
+	cd := &canonicalDocument{
+		skolemized: skolemized,
+		labels:     make(map[string]string, len(canonicalLabels)),
+		index:      make(map[string]int),
+	}
+
+	for id, canonicalLabel := range canonicalLabels {
+		mac := hmac.New(sha256.New, hmacKey)
+		mac.Write([]byte(canonicalLabel)) //nolint:errcheck // hash.Hash never returns an error
+
+		cd.labels[id] = hmacLabelPrefix + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	cd.statements = splitStatements(cd.relabel(string(skolemizedStatements)))
+	sort.Strings(cd.statements)
+
+	for i, statement := range cd.statements {
+		cd.index[statement] = i
+	}
+
+	return cd, nil
+}
+
+// selectStatements returns the indexes of the statements of the values selected by JSON pointers along with
+// the selection document.
+func (cd *canonicalDocument) selectStatements(s *Suite, pointers []string,
+	opts ...jsonld.ProcessorOpts) (map[int]bool, map[string]interface{}, error) {
+	selected := make(map[int]bool)
+
+	if len(pointers) == 0 {
+		return selected, nil, nil
+	}
+
+	selection, err := selectJSONLD(cd.skolemized, pointers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selectionStatements, err := s.GetCanonicalDocument(copyValue(selection).(map[string]interface{}), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, statement := range splitStatements(cd.relabel(string(selectionStatements))) {
+		idx, ok := cd.index[statement]
+		if !ok {
+			return nil, nil, fmt.Errorf("selected statement is not found in the document: %s", statement)
+		}
+
+		selected[idx] = true
+	}
+
+	return selected, selection, nil
+}
+
+// relabel replaces skolem IRIs with the HMAC-based blank node labels.
+func (cd *canonicalDocument) relabel(statements string) string {
+	return skolemIRIRegexp.ReplaceAllStringFunc(statements, func(iri string) string {
+		return "_:" + cd.labels[strings.TrimSuffix(strings.TrimPrefix(iri, "<"+skolemPrefix), ">")]
+	})
+}
+
+// revealedLabelMap maps the canonical blank node labels of the revealed document to the HMAC-based labels.
+func (s *Suite) revealedLabelMap(cd *canonicalDocument, selection map[string]interface{},
+	opts ...jsonld.ProcessorOpts) (map[string]string, error) {
+	if selection == nil {
+		return map[string]string{}, nil
+	}
+
+	selectionStatements, err := s.GetCanonicalDocument(copyValue(selection).(map[string]interface{}), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, canonicalLabels, err := s.jsonldProcessor.GetCanonicalNQuads(deskolemize(string(selectionStatements)))
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize revealed document: %w", err)
+	}
+
+	labelMap := make(map[string]string, len(canonicalLabels))
+
+	for id, canonicalLabel := range canonicalLabels {
+		labelMap[canonicalLabel] = cd.labels[id]
+	}
+
+	return labelMap, nil
+}
+
+func getBaseProof(doc map[string]interface{}) (*proof.Proof, error) {
+	proofs, err := proof.GetProofs(doc)
+	if err != nil {
+		return nil, fmt.Errorf("get base proof: %w", err)
+	}
+
+	for _, p := range proofs {
+		if p.Type == SignatureType && p.Cryptosuite == Cryptosuite && isBaseProofValue(p.ProofValue) {
+			return p, nil
+		}
+	}
+
+	return nil, errors.New("ecdsa-sd-2023 base proof is not found")
+}
+
+// deskolemize replaces skolem IRIs with blank node identifiers.
+func deskolemize(statements string) string {
+	return skolemIRIRegexp.ReplaceAllString(statements, "_:$1")
+}
+
+func splitStatements(nquads string) []string {
+	statements := strings.SplitAfter(nquads, "\n")
+
+	result := statements[:0]
+
+	for _, statement := range statements {
+		if strings.TrimSpace(statement) != "" {
+			result = append(result, statement)
+		}
+	}
+
+	return result
+}
+
+func signatureData(proofHash, publicKey []byte, mandatoryStatements []string) []byte {
+	mandatoryHash := sha256.Sum256([]byte(strings.Join(mandatoryStatements, "")))
+
+	data := append(append([]byte{}, proofHash...), publicKey...)
+
+	return append(data, mandatoryHash[:]...)
+}
+
+// signStatement signs the statement with the ephemeral key, the signature is in IEEE P1363 format.
+func signStatement(key *ecdsa.PrivateKey, statement string) ([]byte, error) {
+	digest := sha256.Sum256([]byte(statement))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign statement: %w", err)
+	}
+
+	signature := make([]byte, 2*p256ScalarSize)
+	r.FillBytes(signature[:p256ScalarSize])
+	s.FillBytes(signature[p256ScalarSize:])
+
+	return signature, nil
+}
+
+func verifyStatement(key *ecdsa.PublicKey, statement string, signature []byte) bool {
+	if len(signature) != 2*p256ScalarSize {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(statement))
+
+	r := new(big.Int).SetBytes(signature[:p256ScalarSize])
+	s := new(big.Int).SetBytes(signature[p256ScalarSize:])
+
+	return ecdsa.Verify(key, digest[:], r, s)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+)
+
+const testCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/security/data-integrity/v1",
+    {"@vocab": "https://example.org/vocab#"}
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "name": "Jayden Doe",
+    "degree": {"type": "BachelorDegree", "name": "Bachelor of Science and Arts"},
+    "boards": [
+      {"year": "2022", "name": "Example Board"},
+      {"year": "2023", "name": "Another Board"}
+    ]
+  }
+}`
+
+const verificationMethod = "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1"
+
+func TestSuite_SelectiveDisclosure(t *testing.T) {
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pubKey := &verifier.PublicKey{
+		Type:  "Multikey",
+		Value: elliptic.Marshal(elliptic.P256(), issuerKey.X, issuerKey.Y), //nolint:staticcheck
+	}
+
+	docVerifier, err := verifier.New(&testKeyResolver{pubKey: pubKey},
+		New(suite.WithVerifier(NewPublicKeyVerifier())))
+	require.NoError(t, err)
+
+	baseDoc := signBaseProof(t, issuerKey, []string{"/issuer", "/issuanceDate"})
+
+	t.Run("verify base proof", func(t *testing.T) {
+		docBytes, err := json.Marshal(baseDoc)
+		require.NoError(t, err)
+
+		require.NoError(t, docVerifier.Verify(docBytes, ldtestutil.WithDocumentLoader(t)))
+	})
+
+	t.Run("derive and verify proof", func(t *testing.T) {
+		revealed, err := New().SelectiveDisclosure(copyValue(baseDoc).(map[string]interface{}),
+			[]string{"/credentialSubject/degree/name", "/credentialSubject/boards/1/year"},
+			ldtestutil.WithDocumentLoader(t))
+		require.NoError(t, err)
+
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", revealed["issuer"])
+		require.Equal(t, "http://example.edu/credentials/1872", revealed["id"])
+
+		subject, ok := revealed["credentialSubject"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject["id"])
+		require.NotContains(t, subject, "name")
+		require.Equal(t, map[string]interface{}{
+			"type": "BachelorDegree",
+			"name": "Bachelor of Science and Arts",
+		}, subject["degree"])
+		require.Equal(t, []interface{}{map[string]interface{}{"year": "2023"}}, subject["boards"])
+
+		proofs, err := proof.GetProofs(revealed)
+		require.NoError(t, err)
+		require.Len(t, proofs, 1)
+		require.Equal(t, Cryptosuite, proofs[0].Cryptosuite)
+		require.False(t, isBaseProofValue(proofs[0].ProofValue))
+
+		revealedBytes, err := json.Marshal(revealed)
+		require.NoError(t, err)
+
+		require.NoError(t, docVerifier.Verify(revealedBytes, ldtestutil.WithDocumentLoader(t)))
+
+		// the revealed values cannot be changed
+		subject["boards"] = []interface{}{map[string]interface{}{"year": "2024"}}
+
+		revealedBytes, err = json.Marshal(revealed)
+		require.NoError(t, err)
+
+		err = docVerifier.Verify(revealedBytes, ldtestutil.WithDocumentLoader(t))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid signature of statement")
+
+		// nor the mandatory ones can be dropped
+		delete(subject, "boards")
+		delete(revealed, "issuanceDate")
+
+		revealedBytes, err = json.Marshal(revealed)
+		require.NoError(t, err)
+
+		require.Error(t, docVerifier.Verify(revealedBytes, ldtestutil.WithDocumentLoader(t)))
+	})
+
+	t.Run("derive proof with mandatory values only", func(t *testing.T) {
+		revealed, err := New().SelectiveDisclosure(copyValue(baseDoc).(map[string]interface{}), nil,
+			ldtestutil.WithDocumentLoader(t))
+		require.NoError(t, err)
+		require.NotContains(t, revealed, "credentialSubject")
+
+		revealedBytes, err := json.Marshal(revealed)
+		require.NoError(t, err)
+
+		require.NoError(t, docVerifier.Verify(revealedBytes, ldtestutil.WithDocumentLoader(t)))
+	})
+
+	t.Run("derive proof errors", func(t *testing.T) {
+		_, err := New().SelectiveDisclosure(copyValue(baseDoc).(map[string]interface{}),
+			[]string{"/credentialSubject/unknown"}, ldtestutil.WithDocumentLoader(t))
+		require.EqualError(t, err, "select statements: select JSON pointer /credentialSubject/unknown: "+
+			"property unknown is not found")
+
+		_, err = New().SelectiveDisclosure(copyValue(baseDoc).(map[string]interface{}),
+			[]string{"credentialSubject"}, ldtestutil.WithDocumentLoader(t))
+		require.EqualError(t, err, "select statements: invalid JSON pointer: credentialSubject")
+
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(testCredential), &doc))
+
+		_, err = New().SelectiveDisclosure(doc, []string{"/issuer"}, ldtestutil.WithDocumentLoader(t))
+		require.EqualError(t, err, "get base proof: proof not found")
+	})
+
+	t.Run("verify base proof with wrong issuer key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		otherVerifier, err := verifier.New(&testKeyResolver{pubKey: &verifier.PublicKey{
+			Type:  "Multikey",
+			Value: elliptic.Marshal(elliptic.P256(), otherKey.X, otherKey.Y), //nolint:staticcheck
+		}}, New(suite.WithVerifier(NewPublicKeyVerifier())))
+		require.NoError(t, err)
+
+		docBytes, err := json.Marshal(baseDoc)
+		require.NoError(t, err)
+
+		err = otherVerifier.Verify(docBytes, ldtestutil.WithDocumentLoader(t))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify base signature")
+	})
+}
+
+func TestSuite_AcceptPublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	s := New()
+	require.True(t, s.Accept("DataIntegrityProof"))
+	require.Equal(t, "ecdsa-sd-2023", s.Cryptosuite())
+	require.True(t, s.AcceptPublicKey(&verifier.PublicKey{
+		Value: elliptic.Marshal(elliptic.P256(), key.X, key.Y), //nolint:staticcheck
+	}))
+	require.False(t, s.AcceptPublicKey(&verifier.PublicKey{Value: make([]byte, 32)}))
+}
+
+func TestProofValue(t *testing.T) {
+	t.Run("base proof value", func(t *testing.T) {
+		v := &baseProofValue{
+			baseSignature:     []byte("base signature"),
+			publicKey:         []byte("public key"),
+			hmacKey:           make([]byte, 32),
+			signatures:        [][]byte{[]byte("sig1"), make([]byte, 300)},
+			mandatoryPointers: []string{"/issuer"},
+		}
+
+		b, err := v.marshal()
+		require.NoError(t, err)
+		require.True(t, isBaseProofValue(b))
+
+		parsed, err := parseBaseProofValue(b)
+		require.NoError(t, err)
+		require.Equal(t, v, parsed)
+
+		_, err = parseDerivedProofValue(b)
+		require.EqualError(t, err, "parse derived proof value: invalid proof value header")
+
+		_, err = parseBaseProofValue(b[:len(b)-1])
+		require.EqualError(t, err, "parse base proof value: cbor: unexpected end of data")
+	})
+
+	t.Run("derived proof value", func(t *testing.T) {
+		v := &derivedProofValue{
+			baseSignature:    []byte("base signature"),
+			publicKey:        []byte("public key"),
+			signatures:       [][]byte{[]byte("sig1")},
+			labelMap:         map[string]string{"c14n0": "uAAEC", "c14n12": "uAwQF"},
+			mandatoryIndexes: []int{0, 2, 70000},
+		}
+
+		b, err := v.marshal()
+		require.NoError(t, err)
+
+		parsed, err := parseDerivedProofValue(b)
+		require.NoError(t, err)
+		require.Equal(t, v, parsed)
+
+		v.labelMap = map[string]string{"b0": "uAAEC"}
+
+		_, err = v.marshal()
+		require.EqualError(t, err, "invalid canonical blank node label b0")
+	})
+}
+
+func signBaseProof(t *testing.T, key *ecdsa.PrivateKey, mandatoryPointers []string) map[string]interface{} {
+	t.Helper()
+
+	s := signer.New(NewWithMandatoryPointers(mandatoryPointers, suite.WithSigner(&testSigner{key: key})))
+
+	signedDoc, err := s.Sign(&signer.Context{
+		SignatureType:      proof.DataIntegrityProof,
+		VerificationMethod: verificationMethod,
+	}, []byte(testCredential), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &doc))
+
+	proofs, ok := doc["proof"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, proofs, 1)
+
+	proofMap, ok := proofs[0].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, Cryptosuite, proofMap["cryptosuite"])
+	require.Regexp(t, "^u", proofMap["proofValue"])
+
+	return doc
+}
+
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *testSigner) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, 2*p256ScalarSize)
+	r.FillBytes(signature[:p256ScalarSize])
+	sig.FillBytes(signature[p256ScalarSize:])
+
+	return signature, nil
+}
+
+func (s *testSigner) Alg() string {
+	return "ES256"
+}
+
+type testKeyResolver struct {
+	pubKey *verifier.PublicKey
+}
+
+func (r *testKeyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	return r.pubKey, nil
+}
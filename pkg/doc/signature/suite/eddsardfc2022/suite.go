@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eddsardfc2022 implements the eddsa-rdfc-2022 cryptosuite of W3C Data Integrity proofs
+// ("DataIntegrityProof" type) as defined in the Data Integrity EdDSA Cryptosuites specification.
+// It uses the RDF Dataset Canonicalization Algorithm [RDFC-1.0] to transform the input document into its
+// canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm and
+// Ed25519 [ED25519] as the signature algorithm.
+package eddsardfc2022
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Suite implements eddsa-rdfc-2022 signature suite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureType is the signature type of the Data Integrity proofs.
+	SignatureType = proof.DataIntegrityProof
+	// Cryptosuite is the name of the cryptosuite.
+	Cryptosuite   = "eddsa-rdfc-2022"
+	rdfDataSetAlg = "URDNA2015"
+
+	ed25519PublicKeySize = 32
+)
+
+// New an instance of eddsa-rdfc-2022 signature suite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// eddsa-rdfc-2022 signature suite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only Data Integrity proof type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns the name of the cryptosuite.
+func (s *Suite) Cryptosuite() string {
+	return Cryptosuite
+}
+
+// AcceptPublicKey accepts Ed25519 public keys.
+func (s *Suite) AcceptPublicKey(pubKey *verifier.PublicKey) bool {
+	if pubKey.JWK != nil {
+		return pubKey.JWK.Kty == "OKP" && pubKey.JWK.Crv == "Ed25519"
+	}
+
+	return len(pubKey.Value) == ed25519PublicKeySize
+}
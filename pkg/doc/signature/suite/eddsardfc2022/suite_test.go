@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsardfc2022
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+)
+
+// Test vectors of the Data Integrity EdDSA Cryptosuites specification
+// (https://www.w3.org/TR/vc-di-eddsa/#representation-eddsa-rdfc-2022).
+const (
+	examplesContextURL = "https://www.w3.org/ns/credentials/examples/v2"
+	secretKeyMultibase = "z3u2en7t5LR2WtQH5PfFqMqwVHBeXouLzo6haApm8XHqvjxq"
+	publicKeyMultibase = "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+	hashData           = "bea7b7acfbad0126b135104024a5f1733e705108f42d59668b05c0c50004c6b0" +
+		"517744132ae165a5349155bef0bb0cf2258fff99dfe1dbd914b938d775a36017"
+	proofValue = "z2YwC8z3ap7yx1nZYCg4L3j3ApHsF8kgPdSb5xoS1VR7vPG3F561B52hYnQF9iseabecm3ijx4K1FBTQsCZahKZme"
+)
+
+var (
+	//go:embed testdata/examples_v2.jsonld
+	examplesContext []byte //nolint:gochecknoglobals
+	//go:embed testdata/credential.jsonld
+	credentialJSON []byte //nolint:gochecknoglobals
+	//go:embed testdata/proof_options.jsonld
+	proofOptionsJSON []byte //nolint:gochecknoglobals
+	//go:embed testdata/canonical_credential.nq
+	canonicalCredential string //nolint:gochecknoglobals
+	//go:embed testdata/signed_credential.jsonld
+	signedCredentialJSON []byte //nolint:gochecknoglobals
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Len(t, digest, 32)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("DataIntegrityProof"))
+	require.False(t, ss.Accept("Ed25519Signature2020"))
+	require.Equal(t, "eddsa-rdfc-2022", ss.Cryptosuite())
+}
+
+func TestSignatureSuite_AcceptPublicKey(t *testing.T) {
+	ss := New()
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	require.True(t, ss.AcceptPublicKey(&verifier.PublicKey{Value: pubKey}))
+	require.False(t, ss.AcceptPublicKey(&verifier.PublicKey{Value: []byte("not an ed25519 key")}))
+
+	j, err := jwksupport.JWKFromKey(pubKey)
+	require.NoError(t, err)
+
+	require.True(t, ss.AcceptPublicKey(&verifier.PublicKey{JWK: j}))
+
+	j.Crv = "X25519"
+	require.False(t, ss.AcceptPublicKey(&verifier.PublicKey{JWK: j}))
+}
+
+func TestSignatureSuite_TestVector(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader(ldcontext.Document{
+		URL:     examplesContextURL,
+		Content: examplesContext,
+	})
+	require.NoError(t, err)
+
+	opt := jsonld.WithDocumentLoader(loader)
+	privKey, pubKey := specKeyPair(t)
+
+	t.Run("canonical document", func(t *testing.T) {
+		doc, err := New().GetCanonicalDocument(readJSON(t, credentialJSON), opt)
+		require.NoError(t, err)
+		require.Equal(t, canonicalCredential, string(doc))
+	})
+
+	t.Run("hash data and proof value", func(t *testing.T) {
+		verifyData, err := proof.CreateVerifyHash(New(), readJSON(t, credentialJSON),
+			readJSON(t, proofOptionsJSON), opt)
+		require.NoError(t, err)
+		require.Equal(t, hashData, hex.EncodeToString(verifyData))
+
+		require.Equal(t, proofValue,
+			proof.EncodeProofValue(ed25519.Sign(privKey, verifyData), proof.DataIntegrityProof))
+	})
+
+	t.Run("verify signed credential", func(t *testing.T) {
+		v, err := verifier.New(&testKeyResolver{publicKey: pubKey}, New(suite.WithVerifier(NewPublicKeyVerifier())))
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(signedCredentialJSON, opt))
+	})
+
+	t.Run("verify proof without created", func(t *testing.T) {
+		proofOptions := readJSON(t, proofOptionsJSON)
+		delete(proofOptions, "created")
+
+		verifyData, err := proof.CreateVerifyHash(New(), readJSON(t, credentialJSON), proofOptions, opt)
+		require.NoError(t, err)
+
+		delete(proofOptions, "@context")
+		proofOptions["proofValue"] = proof.EncodeProofValue(ed25519.Sign(privKey, verifyData), proof.DataIntegrityProof)
+
+		credential := readJSON(t, credentialJSON)
+		credential["proof"] = proofOptions
+
+		credentialBytes, err := json.Marshal(credential)
+		require.NoError(t, err)
+
+		v, err := verifier.New(&testKeyResolver{publicKey: pubKey}, New(suite.WithVerifier(NewPublicKeyVerifier())))
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(credentialBytes, opt))
+	})
+}
+
+// specKeyPair decodes the multibase key pair of the specification test vectors
+// (the keys are prefixed with the ed25519-priv 0x8026 and ed25519-pub 0xed01 multicodec headers).
+func specKeyPair(t *testing.T) (ed25519.PrivateKey, *verifier.PublicKey) {
+	t.Helper()
+
+	_, secretKey, err := multibase.Decode(secretKeyMultibase)
+	require.NoError(t, err)
+
+	_, publicKey, err := multibase.Decode(publicKeyMultibase)
+	require.NoError(t, err)
+
+	privKey := ed25519.NewKeyFromSeed(secretKey[2:])
+	require.Equal(t, publicKey[2:], []byte(privKey.Public().(ed25519.PublicKey)))
+
+	return privKey, &verifier.PublicKey{Type: "Ed25519VerificationKey2020", Value: publicKey[2:]}
+}
+
+func readJSON(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+
+	var doc map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	return doc
+}
+
+type testKeyResolver struct {
+	publicKey *verifier.PublicKey
+}
+
+func (r *testKeyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	return r.publicKey, nil
+}
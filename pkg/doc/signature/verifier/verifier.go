@@ -34,6 +34,24 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// dataIntegritySuite is implemented by the signature suites of W3C Data Integrity proofs ("DataIntegrityProof"
+// type), which are distinguished by the cryptosuite and, as some cryptosuites (e.g. ecdsa-rdfc-2019) hash
+// the document depending on the key curve, by the public key.
+type dataIntegritySuite interface {
+	// Cryptosuite returns the name of the Data Integrity cryptosuite (e.g. "eddsa-rdfc-2022")
+	Cryptosuite() string
+
+	// AcceptPublicKey checks whether the public key can be used to verify proofs of this suite
+	AcceptPublicKey(pubKey *PublicKey) bool
+}
+
+// proofValueSuite is implemented by the signature suites (e.g. ecdsa-sd-2023) which verify the proof value
+// on their own instead of checking the signature of the Create Verify Hash data.
+type proofValueSuite interface {
+	// VerifyProofValue verifies the proof of the document against public key
+	VerifyProofValue(pubKey *PublicKey, doc map[string]interface{}, p *proof.Proof, opts ...jsonld.ProcessorOpts) error
+}
+
 // PublicKey contains a result of public key resolution.
 type PublicKey struct {
 	Type  string
@@ -96,11 +114,20 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 			return err
 		}
 
-		suite, err := dv.getSignatureSuite(p.Type)
+		suite, err := dv.getSignatureSuite(p, publicKey)
 		if err != nil {
 			return err
 		}
 
+		if pvSuite, ok := suite.(proofValueSuite); ok {
+			err = pvSuite.VerifyProofValue(publicKey, jsonLdObject, p, opts...)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
 		message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
 		if err != nil {
 			return err
@@ -120,15 +147,27 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 	return nil
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type, and the cryptosuite and public key
+// in case of Data Integrity proof.
+func (dv *DocumentVerifier) getSignatureSuite(p *proof.Proof, publicKey *PublicKey) (SignatureSuite, error) {
 	for _, s := range dv.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+		if !s.Accept(p.Type) {
+			continue
+		}
+
+		if diSuite, ok := s.(dataIntegritySuite); ok &&
+			(diSuite.Cryptosuite() != p.Cryptosuite || !diSuite.AcceptPublicKey(publicKey)) {
+			continue
 		}
+
+		return s, nil
+	}
+
+	if p.Cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", p.Type, p.Cryptosuite)
 	}
 
-	return nil, fmt.Errorf("signature type %s not supported", signatureType)
+	return nil, fmt.Errorf("signature type %s not supported", p.Type)
 }
 
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
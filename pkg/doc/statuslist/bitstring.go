@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	bitsPerByte = 8
+
+	// multibaseBase64URL is the multibase prefix of the base64url (no padding) encoding used by BitstringStatusList.
+	multibaseBase64URL = "u"
+)
+
+// BitString is the uncompressed status list. Bit 0 is the left-most (most significant) bit of the first byte.
+type BitString struct {
+	bits []byte
+}
+
+// NewBitString creates a BitString of size bits, all of them unset.
+func NewBitString(size int) *BitString {
+	return &BitString{bits: make([]byte, (size+bitsPerByte-1)/bitsPerByte)}
+}
+
+// Len returns the number of bits of the BitString.
+func (b *BitString) Len() int {
+	return len(b.bits) * bitsPerByte
+}
+
+// Set sets or clears the bit at index.
+func (b *BitString) Set(index int, value bool) error {
+	if index < 0 || index >= b.Len() {
+		return fmt.Errorf("index %d out of range [0, %d)", index, b.Len())
+	}
+
+	mask := byte(1) << (bitsPerByte - 1 - index%bitsPerByte)
+
+	if value {
+		b.bits[index/bitsPerByte] |= mask
+	} else {
+		b.bits[index/bitsPerByte] &^= mask
+	}
+
+	return nil
+}
+
+// Get returns the bit at index.
+func (b *BitString) Get(index int) (bool, error) {
+	if index < 0 || index >= b.Len() {
+		return false, fmt.Errorf("index %d out of range [0, %d)", index, b.Len())
+	}
+
+	mask := byte(1) << (bitsPerByte - 1 - index%bitsPerByte)
+
+	return b.bits[index/bitsPerByte]&mask != 0, nil
+}
+
+// Encode GZIP compresses the BitString and encodes it in base64url without padding, prefixed with the multibase
+// base64url code if multibase is set (as BitstringStatusList does, StatusList2021 does not).
+func (b *BitString) Encode(multibase bool) (string, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	_, err := w.Write(b.bits)
+	if err != nil {
+		return "", fmt.Errorf("compress bitstring: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return "", fmt.Errorf("compress bitstring: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(buf.Bytes())
+
+	if multibase {
+		return multibaseBase64URL + encoded, nil
+	}
+
+	return encoded, nil
+}
+
+// DecodeBitString decodes an encodedList of a StatusList2021 or BitstringStatusList credential. Lists of more than
+// MaxListSize entries are rejected.
+func DecodeBitString(encodedList string) (*BitString, error) {
+	// a GZIP stream encoded in base64 always starts with "H4s", so the multibase prefix is unambiguous
+	encodedList = strings.TrimPrefix(encodedList, multibaseBase64URL)
+
+	compressed, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encodedList, "="))
+	if err != nil {
+		// some issuers use the standard base64 alphabet
+		compressed, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encodedList, "="))
+		if err != nil {
+			return nil, fmt.Errorf("decode encodedList: %w", err)
+		}
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	defer r.Close() // nolint:errcheck
+
+	bits, err := io.ReadAll(io.LimitReader(r, MaxListSize/bitsPerByte+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress encodedList: %w", err)
+	}
+
+	if len(bits) > MaxListSize/bitsPerByte {
+		return nil, fmt.Errorf("encodedList exceeds %d entries", MaxListSize)
+	}
+
+	if len(bits) == 0 {
+		return nil, errors.New("empty encodedList")
+	}
+
+	return &BitString{bits: bits}, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitString(t *testing.T) {
+	t.Run("test set and get", func(t *testing.T) {
+		bits := NewBitString(20)
+		require.Equal(t, 24, bits.Len())
+
+		require.NoError(t, bits.Set(0, true))
+		require.NoError(t, bits.Set(9, true))
+		require.Equal(t, []byte{0x80, 0x40, 0x00}, bits.bits)
+
+		set, err := bits.Get(9)
+		require.NoError(t, err)
+		require.True(t, set)
+
+		set, err = bits.Get(10)
+		require.NoError(t, err)
+		require.False(t, set)
+
+		require.NoError(t, bits.Set(9, false))
+
+		set, err = bits.Get(9)
+		require.NoError(t, err)
+		require.False(t, set)
+	})
+
+	t.Run("test index out of range", func(t *testing.T) {
+		bits := NewBitString(8)
+
+		require.EqualError(t, bits.Set(8, true), "index 8 out of range [0, 8)")
+
+		_, err := bits.Get(-1)
+		require.EqualError(t, err, "index -1 out of range [0, 8)")
+	})
+
+	t.Run("test encode and decode", func(t *testing.T) {
+		bits := NewBitString(DefaultListSize)
+		require.NoError(t, bits.Set(94567, true))
+
+		for _, multibase := range []bool{false, true} {
+			encoded, err := bits.Encode(multibase)
+			require.NoError(t, err)
+			require.Equal(t, multibase, strings.HasPrefix(encoded, multibaseBase64URL))
+
+			decoded, err := DecodeBitString(encoded)
+			require.NoError(t, err)
+			require.Equal(t, bits, decoded)
+		}
+	})
+
+	t.Run("test decode StatusList2021 spec example", func(t *testing.T) {
+		// nolint:lll
+		decoded, err := DecodeBitString("H4sIAAAAAAAAA-3BMQEAAADCoPVPbQwfoAAAAAAAAAAAAAAAAAAAAIC3AYbSVKsAQAAA")
+		require.NoError(t, err)
+		require.Equal(t, DefaultListSize, decoded.Len())
+
+		set, err := decoded.Get(94567)
+		require.NoError(t, err)
+		require.False(t, set)
+	})
+
+	t.Run("test decode errors", func(t *testing.T) {
+		_, err := DecodeBitString("!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode encodedList")
+
+		_, err = DecodeBitString("YWJj")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decompress encodedList")
+
+		encoded, err := NewBitString(MaxListSize + bitsPerByte).Encode(true)
+		require.NoError(t, err)
+
+		_, err = DecodeBitString(encoded)
+		require.EqualError(t, err, "encodedList exceeds 134217728 entries")
+	})
+}
@@ -0,0 +1,294 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreName is the name of the store holding the status lists of an Issuer.
+	StoreName = "statuslist"
+
+	currentListKeyPrefix = "current_"
+	listCountKey         = "list_count"
+)
+
+// SignFunc signs the status list credential and returns it serialized, e.g. as JSON-LD with an embedded proof or as
+// a JWT. The result is what the Issuer serves at the status list credential URL.
+type SignFunc func(vc *verifiable.Credential) ([]byte, error)
+
+// Issuer allocates status list entries to the credentials it issues and revokes or suspends them by setting their
+// bit in the status list. The status lists are kept in storage, so they survive restarts.
+type Issuer struct {
+	store     storage.Store
+	issuerID  string
+	urlPrefix string
+	sign      SignFunc
+	listType  ListType
+	listSize  int
+	lock      sync.Mutex
+}
+
+// IssuerOpt is an Issuer option.
+type IssuerOpt func(i *Issuer)
+
+// WithListType sets the type of the status lists created, StatusList2021 by default.
+func WithListType(listType ListType) IssuerOpt {
+	return func(i *Issuer) {
+		i.listType = listType
+	}
+}
+
+// WithListSize sets the number of entries of the status lists created, DefaultListSize by default.
+func WithListSize(size int) IssuerOpt {
+	return func(i *Issuer) {
+		i.listSize = size
+	}
+}
+
+// listRecord is a status list as kept in storage.
+type listRecord struct {
+	Type       ListType `json:"type"`
+	Purpose    string   `json:"purpose"`
+	Bits       []byte   `json:"bits"`
+	Allocated  int      `json:"allocated"`
+	Credential []byte   `json:"credential"`
+}
+
+// NewIssuer creates a status list Issuer for the credentials issued by issuerID. The status list credentials are
+// identified by the URL urlPrefix/<n> where they must be served, see Issuer.StatusListCredential.
+func NewIssuer(provider storage.Provider, issuerID, urlPrefix string, sign SignFunc,
+	opts ...IssuerOpt) (*Issuer, error) {
+	store, err := provider.OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open status list store: %w", err)
+	}
+
+	i := &Issuer{
+		store:     store,
+		issuerID:  issuerID,
+		urlPrefix: urlPrefix,
+		sign:      sign,
+		listType:  StatusList2021,
+		listSize:  DefaultListSize,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	if i.listType != StatusList2021 && i.listType != BitstringStatusList {
+		return nil, fmt.Errorf("unsupported status list type: %s", i.listType)
+	}
+
+	if i.listSize <= 0 {
+		return nil, fmt.Errorf("invalid status list size: %d", i.listSize)
+	}
+
+	return i, nil
+}
+
+// Allocate allocates an entry with the given purpose (PurposeRevocation or PurposeSuspension) in the current
+// status list, creating a new list when the current one is full. The entry is to be set as the credentialStatus
+// of the issued credential.
+func (i *Issuer) Allocate(purpose string) (*verifiable.TypedID, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	listID, rec, err := i.currentList(purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []storage.Operation
+
+	if rec == nil || rec.Allocated >= len(rec.Bits)*bitsPerByte {
+		var count int
+
+		count, listID, rec, err = i.newList(purpose)
+		if err != nil {
+			return nil, err
+		}
+
+		// the list count is saved with the new list, so a failure doesn't leave a gap in the list URLs
+		ops = append(ops, storage.Operation{Key: listCountKey, Value: []byte(strconv.Itoa(count))})
+	}
+
+	index := rec.Allocated
+	rec.Allocated++
+
+	recBytes, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal status list: %w", err)
+	}
+
+	err = i.store.Batch(append(ops,
+		storage.Operation{Key: listID, Value: recBytes},
+		storage.Operation{Key: currentListKeyPrefix + purpose, Value: []byte(listID)},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("save status list: %w", err)
+	}
+
+	entry := &Entry{
+		ID:                   listID + "#" + strconv.Itoa(index),
+		Type:                 string(rec.Type) + entrySuffix,
+		StatusPurpose:        purpose,
+		StatusListIndex:      index,
+		StatusListCredential: listID,
+	}
+
+	return entry.TypedID(), nil
+}
+
+// SetStatus sets (e.g. revokes or suspends) or clears (e.g. reinstates a suspended credential) the status of the
+// credential with the given credentialStatus, and re-signs its status list credential.
+func (i *Issuer) SetStatus(status *verifiable.TypedID, value bool) error {
+	entry, err := ParseEntry(status)
+	if err != nil {
+		return err
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	rec, err := i.getList(entry.StatusListCredential)
+	if err != nil {
+		return err
+	}
+
+	if rec.Purpose != entry.StatusPurpose {
+		return fmt.Errorf("status purpose %s doesn't match the status list purpose %s", entry.StatusPurpose,
+			rec.Purpose)
+	}
+
+	if entry.StatusListIndex >= rec.Allocated {
+		return fmt.Errorf("status list index %d is not allocated", entry.StatusListIndex)
+	}
+
+	bits := &BitString{bits: rec.Bits}
+
+	err = bits.Set(entry.StatusListIndex, value)
+	if err != nil {
+		return err
+	}
+
+	rec.Credential, err = i.signList(entry.StatusListCredential, rec.Type, rec.Purpose, bits)
+	if err != nil {
+		return err
+	}
+
+	return i.putList(entry.StatusListCredential, rec)
+}
+
+// StatusListCredential returns the signed status list credential with the given URL.
+func (i *Issuer) StatusListCredential(listID string) ([]byte, error) {
+	rec, err := i.getList(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.Credential, nil
+}
+
+func (i *Issuer) currentList(purpose string) (string, *listRecord, error) {
+	listID, err := i.store.Get(currentListKeyPrefix + purpose)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return "", nil, nil
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("get current status list: %w", err)
+	}
+
+	rec, err := i.getList(string(listID))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return string(listID), rec, nil
+}
+
+func (i *Issuer) newList(purpose string) (int, string, *listRecord, error) {
+	count := 0
+
+	countBytes, err := i.store.Get(listCountKey)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return 0, "", nil, fmt.Errorf("get status list count: %w", err)
+	}
+
+	if err == nil {
+		count, err = strconv.Atoi(string(countBytes))
+		if err != nil {
+			return 0, "", nil, fmt.Errorf("parse status list count: %w", err)
+		}
+	}
+
+	count++
+
+	listID := i.urlPrefix + "/" + strconv.Itoa(count)
+	bits := NewBitString(i.listSize)
+
+	vcBytes, err := i.signList(listID, i.listType, purpose, bits)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	return count, listID, &listRecord{Type: i.listType, Purpose: purpose, Bits: bits.bits, Credential: vcBytes}, nil
+}
+
+func (i *Issuer) signList(listID string, listType ListType, purpose string, bits *BitString) ([]byte, error) {
+	vc, err := NewCredential(listType, listID, i.issuerID, purpose, bits)
+	if err != nil {
+		return nil, err
+	}
+
+	vcBytes, err := i.sign(vc)
+	if err != nil {
+		return nil, fmt.Errorf("sign status list credential: %w", err)
+	}
+
+	return vcBytes, nil
+}
+
+func (i *Issuer) getList(listID string) (*listRecord, error) {
+	recBytes, err := i.store.Get(listID)
+	if err != nil {
+		return nil, fmt.Errorf("get status list %s: %w", listID, err)
+	}
+
+	rec := &listRecord{}
+
+	err = json.Unmarshal(recBytes, rec)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal status list %s: %w", listID, err)
+	}
+
+	return rec, nil
+}
+
+func (i *Issuer) putList(listID string, rec *listRecord) error {
+	recBytes, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal status list: %w", err)
+	}
+
+	err = i.store.Put(listID, recBytes)
+	if err != nil {
+		return fmt.Errorf("save status list %s: %w", listID, err)
+	}
+
+	return nil
+}
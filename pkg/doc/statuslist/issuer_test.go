@@ -0,0 +1,291 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const (
+	issuerDID = "did:example:issuer"
+	urlPrefix = "https://example.com/status"
+)
+
+func TestIssuer(t *testing.T) {
+	t.Run("test allocate", func(t *testing.T) {
+		issuer, _ := newTestIssuer(t, mem.NewProvider(), WithListSize(16))
+
+		status, err := issuer.Allocate(PurposeRevocation)
+		require.NoError(t, err)
+
+		entry, err := ParseEntry(status)
+		require.NoError(t, err)
+		require.Equal(t, &Entry{
+			ID:                   urlPrefix + "/1#0",
+			Type:                 "StatusList2021Entry",
+			StatusPurpose:        PurposeRevocation,
+			StatusListIndex:      0,
+			StatusListCredential: urlPrefix + "/1",
+		}, entry)
+
+		// suspension entries are allocated in another list
+		status, err = issuer.Allocate(PurposeSuspension)
+		require.NoError(t, err)
+		require.Equal(t, urlPrefix+"/2#0", status.ID)
+
+		for i := 1; i < 16; i++ {
+			status, err = issuer.Allocate(PurposeRevocation)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, urlPrefix+"/1#15", status.ID)
+
+		// the list is full
+		status, err = issuer.Allocate(PurposeRevocation)
+		require.NoError(t, err)
+		require.Equal(t, urlPrefix+"/3#0", status.ID)
+	})
+
+	t.Run("test allocate survives restart", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		issuer, _ := newTestIssuer(t, provider)
+
+		_, err := issuer.Allocate(PurposeRevocation)
+		require.NoError(t, err)
+
+		issuer, _ = newTestIssuer(t, provider)
+
+		status, err := issuer.Allocate(PurposeRevocation)
+		require.NoError(t, err)
+		require.Equal(t, urlPrefix+"/1#1", status.ID)
+	})
+
+	t.Run("test set status", func(t *testing.T) {
+		issuer, pub := newTestIssuer(t, mem.NewProvider())
+
+		status, err := issuer.Allocate(PurposeRevocation)
+		require.NoError(t, err)
+
+		require.NoError(t, issuer.SetStatus(status, true))
+
+		vcBytes, err := issuer.StatusListCredential(urlPrefix + "/1")
+		require.NoError(t, err)
+
+		loader, err := ldtestutil.DocumentLoader()
+		require.NoError(t, err)
+
+		vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pub, kms.ED25519)))
+		require.NoError(t, err)
+		require.Equal(t, issuerDID, vc.Issuer.ID)
+
+		subject, err := parseListSubject(vc)
+		require.NoError(t, err)
+		require.Equal(t, string(StatusList2021), subject.Type)
+		require.Equal(t, PurposeRevocation, subject.StatusPurpose)
+
+		bits, err := DecodeBitString(subject.EncodedList)
+		require.NoError(t, err)
+
+		set, err := bits.Get(0)
+		require.NoError(t, err)
+		require.True(t, set)
+
+		require.NoError(t, issuer.SetStatus(status, false))
+	})
+
+	t.Run("test BitstringStatusList", func(t *testing.T) {
+		issuer, _ := newTestIssuer(t, mem.NewProvider(), WithListType(BitstringStatusList))
+
+		status, err := issuer.Allocate(PurposeSuspension)
+		require.NoError(t, err)
+		require.Equal(t, "BitstringStatusListEntry", status.Type)
+
+		vc, err := NewCredential(BitstringStatusList, urlPrefix+"/1", issuerDID, PurposeSuspension,
+			NewBitString(DefaultListSize))
+		require.NoError(t, err)
+		require.Equal(t, []string{BitstringStatusListContext}, vc.Context)
+		require.Equal(t, []string{"VerifiableCredential", "BitstringStatusListCredential"}, vc.Types)
+
+		subject, err := parseListSubject(vc)
+		require.NoError(t, err)
+		require.Equal(t, string(BitstringStatusList), subject.Type)
+		require.True(t, strings.HasPrefix(subject.EncodedList, multibaseBase64URL))
+	})
+
+	t.Run("test set status errors", func(t *testing.T) {
+		issuer, _ := newTestIssuer(t, mem.NewProvider())
+
+		status, err := issuer.Allocate(PurposeRevocation)
+		require.NoError(t, err)
+
+		_, err = issuer.Allocate(PurposeSuspension)
+		require.NoError(t, err)
+
+		err = issuer.SetStatus(&verifiable.TypedID{Type: "unknown"}, true)
+		require.EqualError(t, err, "unsupported credential status type: unknown")
+
+		entry, err := ParseEntry(status)
+		require.NoError(t, err)
+
+		entry.StatusListIndex = 1
+		err = issuer.SetStatus(entry.TypedID(), true)
+		require.EqualError(t, err, "status list index 1 is not allocated")
+
+		entry.StatusPurpose = PurposeSuspension
+		err = issuer.SetStatus(entry.TypedID(), true)
+		require.EqualError(t, err, "status purpose suspension doesn't match the status list purpose revocation")
+
+		entry.StatusListCredential = urlPrefix + "/3"
+		err = issuer.SetStatus(entry.TypedID(), true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get status list "+urlPrefix+"/3")
+	})
+
+	t.Run("test sign error", func(t *testing.T) {
+		issuer, err := NewIssuer(mem.NewProvider(), issuerDID, urlPrefix,
+			func(*verifiable.Credential) ([]byte, error) {
+				return nil, errors.New("sign error")
+			})
+		require.NoError(t, err)
+
+		_, err = issuer.Allocate(PurposeRevocation)
+		require.EqualError(t, err, "sign status list credential: sign error")
+	})
+
+	t.Run("test list count not incremented on failure", func(t *testing.T) {
+		signErr := errors.New("sign error")
+
+		issuer, err := NewIssuer(mem.NewProvider(), issuerDID, urlPrefix,
+			func(*verifiable.Credential) ([]byte, error) {
+				return []byte("{}"), signErr
+			})
+		require.NoError(t, err)
+
+		_, err = issuer.Allocate(PurposeRevocation)
+		require.ErrorIs(t, err, signErr)
+
+		signErr = nil
+
+		status, err := issuer.Allocate(PurposeRevocation)
+		require.NoError(t, err)
+		require.Equal(t, urlPrefix+"/1#0", status.ID)
+	})
+
+	t.Run("test new issuer errors", func(t *testing.T) {
+		_, err := NewIssuer(&storage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+			issuerDID, urlPrefix, nil)
+		require.EqualError(t, err, "open status list store: open error")
+
+		_, err = NewIssuer(mem.NewProvider(), issuerDID, urlPrefix, nil, WithListType("unknown"))
+		require.EqualError(t, err, "unsupported status list type: unknown")
+
+		_, err = NewIssuer(mem.NewProvider(), issuerDID, urlPrefix, nil, WithListSize(0))
+		require.EqualError(t, err, "invalid status list size: 0")
+	})
+}
+
+func TestParseEntry(t *testing.T) {
+	t.Run("test numeric index", func(t *testing.T) {
+		entry, err := ParseEntry(&verifiable.TypedID{
+			Type: "StatusList2021Entry",
+			CustomFields: verifiable.CustomFields{
+				statusPurposeField:        PurposeRevocation,
+				statusListIndexField:      float64(5),
+				statusListCredentialField: urlPrefix + "/1",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5, entry.StatusListIndex)
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		_, err := ParseEntry(nil)
+		require.EqualError(t, err, "credential status is not defined")
+
+		status := &verifiable.TypedID{Type: "StatusList2021Entry", CustomFields: verifiable.CustomFields{}}
+
+		_, err = ParseEntry(status)
+		require.EqualError(t, err, "credential status statusPurpose is missing")
+
+		status.CustomFields[statusPurposeField] = PurposeRevocation
+
+		_, err = ParseEntry(status)
+		require.EqualError(t, err, "credential status statusListCredential is missing")
+
+		status.CustomFields[statusListCredentialField] = urlPrefix + "/1"
+
+		_, err = ParseEntry(status)
+		require.EqualError(t, err, "credential status statusListIndex is missing")
+
+		status.CustomFields[statusListIndexField] = "a"
+
+		_, err = ParseEntry(status)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid credential status statusListIndex")
+
+		status.CustomFields[statusListIndexField] = "-1"
+
+		_, err = ParseEntry(status)
+		require.EqualError(t, err, "invalid credential status statusListIndex: -1")
+
+		status.CustomFields[statusListIndexField] = -0.5
+
+		_, err = ParseEntry(status)
+		require.EqualError(t, err, "invalid credential status statusListIndex: -0.5")
+
+		status.CustomFields[statusListIndexField] = 1.5
+
+		_, err = ParseEntry(status)
+		require.EqualError(t, err, "invalid credential status statusListIndex: 1.5")
+
+		status.CustomFields[statusListIndexField] = 1e20
+
+		_, err = ParseEntry(status)
+		require.EqualError(t, err, "invalid credential status statusListIndex: 1e+20")
+	})
+}
+
+func newTestIssuer(t *testing.T, provider *mem.Provider, opts ...IssuerOpt) (*Issuer, ed25519.PublicKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := signature.GetEd25519Signer(priv, pub)
+
+	issuer, err := NewIssuer(provider, issuerDID, urlPrefix, func(vc *verifiable.Credential) ([]byte, error) {
+		claims, e := vc.JWTClaims(false)
+		if e != nil {
+			return nil, e
+		}
+
+		jws, e := claims.MarshalJWS(verifiable.EdDSA, signer, issuerDID+"#key-1")
+		if e != nil {
+			return nil, e
+		}
+
+		return []byte(jws), nil
+	}, opts...)
+	require.NoError(t, err)
+
+	return issuer, pub
+}
@@ -0,0 +1,212 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package statuslist implements the StatusList2021 and Bitstring Status List credential status methods:
+// an Issuer allocating status entries and revoking or suspending credentials, and a Verifier rejecting
+// revoked or suspended credentials.
+package statuslist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// ListType is the type of a status list.
+type ListType string
+
+const (
+	// StatusList2021 is the type of the StatusList2021 status method.
+	// Reference: https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
+	StatusList2021 ListType = "StatusList2021"
+
+	// BitstringStatusList is the type of the Bitstring Status List status method.
+	// Reference: https://www.w3.org/TR/vc-bitstring-status-list/
+	BitstringStatusList ListType = "BitstringStatusList"
+)
+
+const (
+	// StatusList2021Context is the JSON-LD context of the StatusList2021 credentials.
+	StatusList2021Context = "https://w3id.org/vc/status-list/2021/v1"
+
+	// BitstringStatusListContext is the JSON-LD context of the BitstringStatusList credentials (VC data model 2.0).
+	BitstringStatusListContext = "https://www.w3.org/ns/credentials/v2"
+
+	// PurposeRevocation is the status purpose of a status list revoking credentials.
+	PurposeRevocation = "revocation"
+
+	// PurposeSuspension is the status purpose of a status list suspending credentials.
+	PurposeSuspension = "suspension"
+
+	// DefaultListSize is the default number of entries of a status list, the minimum recommended for herd privacy.
+	DefaultListSize = 131072
+	// MaxListSize is the maximum number of entries of a status list, larger lists are rejected when decoded.
+	MaxListSize = 1 << 27
+
+	vcContext = "https://www.w3.org/2018/credentials/v1"
+	vcType    = "VerifiableCredential"
+
+	credentialSuffix = "Credential"
+	entrySuffix      = "Entry"
+
+	statusPurposeField        = "statusPurpose"
+	statusListIndexField      = "statusListIndex"
+	statusListCredentialField = "statusListCredential"
+	encodedListField          = "encodedList"
+)
+
+var (
+	// ErrRevoked is returned when the credential was revoked by its issuer.
+	ErrRevoked = errors.New("credential is revoked")
+
+	// ErrSuspended is returned when the credential was suspended by its issuer.
+	ErrSuspended = errors.New("credential is suspended")
+)
+
+// Entry is the credentialStatus of a credential referencing an index in a status list.
+type Entry struct {
+	ID                   string
+	Type                 string
+	StatusPurpose        string
+	StatusListIndex      int
+	StatusListCredential string
+}
+
+// ParseEntry parses the credentialStatus of a credential.
+func ParseEntry(status *verifiable.TypedID) (*Entry, error) {
+	if status == nil {
+		return nil, errors.New("credential status is not defined")
+	}
+
+	if status.Type != string(StatusList2021)+entrySuffix && status.Type != string(BitstringStatusList)+entrySuffix {
+		return nil, fmt.Errorf("unsupported credential status type: %s", status.Type)
+	}
+
+	entry := &Entry{ID: status.ID, Type: status.Type}
+
+	var ok bool
+
+	entry.StatusPurpose, ok = status.CustomFields[statusPurposeField].(string)
+	if !ok || entry.StatusPurpose == "" {
+		return nil, fmt.Errorf("credential status %s is missing", statusPurposeField)
+	}
+
+	entry.StatusListCredential, ok = status.CustomFields[statusListCredentialField].(string)
+	if !ok || entry.StatusListCredential == "" {
+		return nil, fmt.Errorf("credential status %s is missing", statusListCredentialField)
+	}
+
+	// the index is a string, though some issuers encode it as a number
+	switch index := status.CustomFields[statusListIndexField].(type) {
+	case string:
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential status %s: %w", statusListIndexField, err)
+		}
+
+		entry.StatusListIndex = i
+	case float64:
+		if index != math.Trunc(index) || index < 0 || index > math.MaxInt32 {
+			return nil, fmt.Errorf("invalid credential status %s: %v", statusListIndexField, index)
+		}
+
+		entry.StatusListIndex = int(index)
+	default:
+		return nil, fmt.Errorf("credential status %s is missing", statusListIndexField)
+	}
+
+	if entry.StatusListIndex < 0 {
+		return nil, fmt.Errorf("invalid credential status %s: %d", statusListIndexField, entry.StatusListIndex)
+	}
+
+	return entry, nil
+}
+
+// TypedID returns the entry as the credentialStatus of a credential.
+func (e *Entry) TypedID() *verifiable.TypedID {
+	return &verifiable.TypedID{
+		ID:   e.ID,
+		Type: e.Type,
+		CustomFields: verifiable.CustomFields{
+			statusPurposeField:        e.StatusPurpose,
+			statusListIndexField:      strconv.Itoa(e.StatusListIndex),
+			statusListCredentialField: e.StatusListCredential,
+		},
+	}
+}
+
+// NewCredential creates the unsigned status list credential listID of issuer, with the given type, purpose
+// and bitstring.
+func NewCredential(listType ListType, listID, issuer, purpose string, bits *BitString) (*verifiable.Credential, error) {
+	encodedList, err := bits.Encode(listType == BitstringStatusList)
+	if err != nil {
+		return nil, err
+	}
+
+	context := []string{vcContext, StatusList2021Context}
+	if listType == BitstringStatusList {
+		context = []string{BitstringStatusListContext}
+	}
+
+	return &verifiable.Credential{
+		Context: context,
+		ID:      listID,
+		Types:   []string{vcType, string(listType) + credentialSuffix},
+		Issuer:  verifiable.Issuer{ID: issuer},
+		Issued:  util.NewTime(time.Now().UTC()),
+		Subject: []verifiable.Subject{{
+			ID: listID + "#list",
+			CustomFields: verifiable.CustomFields{
+				"type":             string(listType),
+				statusPurposeField: purpose,
+				encodedListField:   encodedList,
+			},
+		}},
+	}, nil
+}
+
+// listSubject is the credentialSubject of a status list credential.
+type listSubject struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	StatusPurpose string `json:"statusPurpose"`
+	EncodedList   string `json:"encodedList"`
+}
+
+func parseListSubject(vc *verifiable.Credential) (*listSubject, error) {
+	subjectBytes, err := json.Marshal(vc.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("marshal status list credential subject: %w", err)
+	}
+
+	var subjects []listSubject
+
+	err = json.Unmarshal(subjectBytes, &subjects)
+	if err != nil {
+		subjects = make([]listSubject, 1)
+
+		err = json.Unmarshal(subjectBytes, &subjects[0])
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal status list credential subject: %w", err)
+		}
+	}
+
+	if len(subjects) != 1 {
+		return nil, errors.New("status list credential must have exactly one subject")
+	}
+
+	if subjects[0].Type != string(StatusList2021) && subjects[0].Type != string(BitstringStatusList) {
+		return nil, fmt.Errorf("unsupported status list type: %s", subjects[0].Type)
+	}
+
+	return &subjects[0], nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// DefaultCacheTTL is the default duration a fetched status list is cached by a Verifier.
+	DefaultCacheTTL = 5 * time.Minute
+
+	// maxCredentialSize is the maximum size of a fetched status list credential, enough for an encodedList of
+	// MaxListSize entries which don't compress.
+	maxCredentialSize = 32 * 1024 * 1024
+	fetchTimeout      = 10 * time.Second
+)
+
+// Fetcher fetches the status list credential at the given URL.
+type Fetcher func(statusListCredentialURL string) ([]byte, error)
+
+// HTTPFetcher returns a Fetcher getting the status list credentials with the given HTTP client, a nil client
+// times out. Status list credentials larger than 32 MiB are rejected.
+func HTTPFetcher(client *http.Client) Fetcher {
+	if client == nil {
+		client = &http.Client{Timeout: fetchTimeout}
+	}
+
+	return func(statusListCredentialURL string) ([]byte, error) {
+		resp, err := client.Get(statusListCredentialURL) // nolint:noctx
+		if err != nil {
+			return nil, fmt.Errorf("get status list credential: %w", err)
+		}
+
+		defer resp.Body.Close() // nolint:errcheck
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxCredentialSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("read status list credential: %w", err)
+		}
+
+		if len(body) > maxCredentialSize {
+			return nil, fmt.Errorf("status list credential exceeds %d bytes", maxCredentialSize)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("get status list credential: http status %d: %s", resp.StatusCode, body)
+		}
+
+		return body, nil
+	}
+}
+
+// Verifier checks the StatusList2021 and BitstringStatusList credential statuses, it implements
+// verifiable.CredentialStatusChecker. The status lists fetched are cached.
+type Verifier struct {
+	fetch    Fetcher
+	vcOpts   []verifiable.CredentialOpt
+	cacheTTL time.Duration
+	cache    map[string]*cachedList
+	lock     sync.Mutex
+}
+
+type cachedList struct {
+	issuer  string
+	subject *listSubject
+	bits    *BitString
+	expiry  time.Time
+}
+
+// VerifierOpt is a Verifier option.
+type VerifierOpt func(v *Verifier)
+
+// WithFetcher sets the Fetcher of the status list credentials, an HTTPFetcher with a nil client by default.
+func WithFetcher(fetcher Fetcher) VerifierOpt {
+	return func(v *Verifier) {
+		v.fetch = fetcher
+	}
+}
+
+// WithCacheTTL sets the duration a fetched status list is cached, DefaultCacheTTL by default. Zero disables caching.
+func WithCacheTTL(ttl time.Duration) VerifierOpt {
+	return func(v *Verifier) {
+		v.cacheTTL = ttl
+	}
+}
+
+// WithCredentialOpts sets the options parsing the status list credentials, e.g. its public key fetcher and
+// JSON-LD document loader to check its proof. The status list credentials must be signed, a public key fetcher
+// is required to verify them.
+func WithCredentialOpts(opts ...verifiable.CredentialOpt) VerifierOpt {
+	return func(v *Verifier) {
+		v.vcOpts = opts
+	}
+}
+
+// NewVerifier creates a status list Verifier.
+func NewVerifier(opts ...VerifierOpt) *Verifier {
+	v := &Verifier{
+		fetch:    HTTPFetcher(nil),
+		cacheTTL: DefaultCacheTTL,
+		cache:    map[string]*cachedList{},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// CheckStatus checks the credentialStatus of vc, it returns ErrRevoked or ErrSuspended if its bit is set in the
+// status list.
+func (v *Verifier) CheckStatus(vc *verifiable.Credential) error {
+	entry, err := ParseEntry(vc.Status)
+	if err != nil {
+		return err
+	}
+
+	list, err := v.getList(entry.StatusListCredential)
+	if err != nil {
+		return err
+	}
+
+	if list.issuer != vc.Issuer.ID {
+		return fmt.Errorf("status list issuer %s doesn't match the credential issuer %s", list.issuer, vc.Issuer.ID)
+	}
+
+	if list.subject.StatusPurpose != entry.StatusPurpose {
+		return fmt.Errorf("status purpose %s doesn't match the status list purpose %s", entry.StatusPurpose,
+			list.subject.StatusPurpose)
+	}
+
+	set, err := list.bits.Get(entry.StatusListIndex)
+	if err != nil {
+		return fmt.Errorf("invalid status list index: %w", err)
+	}
+
+	if !set {
+		return nil
+	}
+
+	switch entry.StatusPurpose {
+	case PurposeRevocation:
+		return ErrRevoked
+	case PurposeSuspension:
+		return ErrSuspended
+	default:
+		return fmt.Errorf("credential status %s is set", entry.StatusPurpose)
+	}
+}
+
+func (v *Verifier) getList(listID string) (*cachedList, error) {
+	v.lock.Lock()
+	list, ok := v.cache[listID]
+	v.lock.Unlock()
+
+	if ok && time.Now().Before(list.expiry) {
+		return list, nil
+	}
+
+	vcBytes, err := v.fetch(listID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status list credential %s: %w", listID, err)
+	}
+
+	vc, err := verifiable.ParseCredential(vcBytes, v.vcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse status list credential %s: %w", listID, err)
+	}
+
+	// an unsigned status list would let anyone serving it reinstate revoked credentials
+	if vc.JWT == "" && len(vc.Proofs) == 0 {
+		return nil, fmt.Errorf("status list credential %s is not signed", listID)
+	}
+
+	if vc.Expired != nil && vc.Expired.Time.Before(time.Now()) {
+		return nil, fmt.Errorf("status list credential %s is expired", listID)
+	}
+
+	subject, err := parseListSubject(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	bits, err := DecodeBitString(subject.EncodedList)
+	if err != nil {
+		return nil, err
+	}
+
+	list = &cachedList{issuer: vc.Issuer.ID, subject: subject, bits: bits, expiry: time.Now().Add(v.cacheTTL)}
+
+	if v.cacheTTL > 0 {
+		v.lock.Lock()
+		v.cache[listID] = list
+		v.lock.Unlock()
+	}
+
+	return list, nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestVerifier(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	issuer, pub := newTestIssuer(t, mem.NewProvider())
+
+	revocation, err := issuer.Allocate(PurposeRevocation)
+	require.NoError(t, err)
+
+	suspension, err := issuer.Allocate(PurposeSuspension)
+	require.NoError(t, err)
+
+	newVerifier := func(opts ...VerifierOpt) *Verifier {
+		return NewVerifier(append([]VerifierOpt{
+			WithFetcher(issuer.StatusListCredential),
+			WithCredentialOpts(verifiable.WithJSONLDDocumentLoader(loader),
+				verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pub, kms.ED25519))),
+		}, opts...)...)
+	}
+
+	t.Run("test revocation", func(t *testing.T) {
+		vc := newTestCredential(revocation)
+		v := newVerifier(WithCacheTTL(0))
+
+		require.NoError(t, v.CheckStatus(vc))
+
+		require.NoError(t, issuer.SetStatus(revocation, true))
+		defer func() { require.NoError(t, issuer.SetStatus(revocation, false)) }()
+
+		require.ErrorIs(t, v.CheckStatus(vc), ErrRevoked)
+	})
+
+	t.Run("test suspension", func(t *testing.T) {
+		vc := newTestCredential(suspension)
+		v := newVerifier(WithCacheTTL(0))
+
+		require.NoError(t, issuer.SetStatus(suspension, true))
+
+		require.ErrorIs(t, v.CheckStatus(vc), ErrSuspended)
+
+		require.NoError(t, issuer.SetStatus(suspension, false))
+
+		require.NoError(t, v.CheckStatus(vc))
+	})
+
+	t.Run("test parse credential with status check", func(t *testing.T) {
+		vcBytes, err := newTestCredential(revocation).MarshalJSON()
+		require.NoError(t, err)
+
+		vcOpts := []verifiable.CredentialOpt{
+			verifiable.WithJSONLDDocumentLoader(loader), verifiable.WithDisabledProofCheck(),
+			verifiable.WithStatusCheck(newVerifier(WithCacheTTL(0))),
+		}
+
+		_, err = verifiable.ParseCredential(vcBytes, vcOpts...)
+		require.NoError(t, err)
+
+		require.NoError(t, issuer.SetStatus(revocation, true))
+		defer func() { require.NoError(t, issuer.SetStatus(revocation, false)) }()
+
+		_, err = verifiable.ParseCredential(vcBytes, vcOpts...)
+		require.ErrorIs(t, err, ErrRevoked)
+	})
+
+	t.Run("test cache", func(t *testing.T) {
+		fetched := 0
+
+		v := newVerifier(WithFetcher(func(url string) ([]byte, error) {
+			fetched++
+
+			return issuer.StatusListCredential(url)
+		}))
+
+		vc := newTestCredential(revocation)
+
+		require.NoError(t, v.CheckStatus(vc))
+		require.NoError(t, v.CheckStatus(vc))
+		require.Equal(t, 1, fetched)
+
+		v.cache[revocation.CustomFields[statusListCredentialField].(string)].expiry = time.Now()
+
+		require.NoError(t, v.CheckStatus(vc))
+		require.Equal(t, 2, fetched)
+	})
+
+	t.Run("test HTTP fetcher", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			vcBytes, err := issuer.StatusListCredential(urlPrefix + r.URL.Path)
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = w.Write(vcBytes) // nolint:errcheck
+		}))
+		defer server.Close()
+
+		vcBytes, err := HTTPFetcher(server.Client())(server.URL + "/1")
+		require.NoError(t, err)
+
+		listVCBytes, err := issuer.StatusListCredential(urlPrefix + "/1")
+		require.NoError(t, err)
+		require.Equal(t, listVCBytes, vcBytes)
+
+		_, err = HTTPFetcher(server.Client())(server.URL + "/5")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "http status 404")
+
+		vcBytes, err = HTTPFetcher(nil)(server.URL + "/1")
+		require.NoError(t, err)
+		require.Equal(t, listVCBytes, vcBytes)
+	})
+
+	t.Run("test HTTP fetcher - too large credential", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(make([]byte, maxCredentialSize+1)) // nolint:errcheck
+		}))
+		defer server.Close()
+
+		_, err := HTTPFetcher(server.Client())(server.URL + "/1")
+		require.EqualError(t, err, "status list credential exceeds 33554432 bytes")
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		v := newVerifier()
+
+		vc := newTestCredential(revocation)
+		vc.Issuer.ID = "did:example:other"
+
+		err := v.CheckStatus(vc)
+		require.EqualError(t, err, "status list issuer did:example:issuer doesn't match the credential issuer "+
+			"did:example:other")
+
+		entry, err := ParseEntry(suspension)
+		require.NoError(t, err)
+
+		entry.StatusPurpose = PurposeRevocation
+
+		err = v.CheckStatus(newTestCredential(entry.TypedID()))
+		require.EqualError(t, err, "status purpose revocation doesn't match the status list purpose suspension")
+
+		entry.StatusPurpose = PurposeSuspension
+		entry.StatusListIndex = DefaultListSize
+
+		err = v.CheckStatus(newTestCredential(entry.TypedID()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid status list index")
+
+		err = v.CheckStatus(newTestCredential(&verifiable.TypedID{Type: "unknown"}))
+		require.EqualError(t, err, "unsupported credential status type: unknown")
+
+		v = newVerifier(WithFetcher(func(string) ([]byte, error) {
+			return nil, errors.New("fetch error")
+		}))
+
+		err = v.CheckStatus(newTestCredential(revocation))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fetch error")
+
+		v = newVerifier(WithCredentialOpts(verifiable.WithJSONLDDocumentLoader(loader),
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(make(ed25519.PublicKey, ed25519.PublicKeySize),
+				kms.ED25519))))
+
+		err = v.CheckStatus(newTestCredential(revocation))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse status list credential")
+	})
+
+	t.Run("test unsigned status list rejected", func(t *testing.T) {
+		listID := revocation.CustomFields[statusListCredentialField].(string)
+
+		v := newVerifier(WithFetcher(func(url string) ([]byte, error) {
+			vc, err := NewCredential(StatusList2021, url, issuerDID, PurposeRevocation, NewBitString(DefaultListSize))
+			if err != nil {
+				return nil, err
+			}
+
+			return vc.MarshalJSON()
+		}))
+
+		err := v.CheckStatus(newTestCredential(revocation))
+		require.EqualError(t, err, "status list credential "+listID+" is not signed")
+	})
+}
+
+func newTestCredential(status *verifiable.TypedID) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{vcContext},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{vcType},
+		Subject: "did:example:holder",
+		Issuer:  verifiable.Issuer{ID: issuerDID},
+		Issued:  util.NewTime(time.Now()),
+		Status:  status,
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ChallengeVerifier verifies the challenge and domain of the presentation against the ones issued by the verifier,
+// so that the presentation cannot be replayed.
+type ChallengeVerifier interface {
+	// VerifyChallenge checks that the challenge was issued for one of the domains and was not used before.
+	// The challenge is used up by the check.
+	VerifyChallenge(challenge string, domains []string) error
+}
+
+// WithPresChallengeVerifier option enforces that the challenge and domain of the embedded proofs of the parsed
+// presentation ("nonce" and "aud" claims of JWT presentation) were issued by the verifier and were not used before.
+func WithPresChallengeVerifier(verifier ChallengeVerifier) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.challengeVerifier = verifier
+	}
+}
+
+func checkPresentationChallenge(vp *Presentation, vpRaw *rawPresentation, verifier ChallengeVerifier) error {
+	challenge, domains, err := presentationChallenge(vp, vpRaw)
+	if err != nil {
+		return err
+	}
+
+	return verifier.VerifyChallenge(challenge, domains)
+}
+
+// presentationChallenge returns the challenge and domains of JWT presentation or of its embedded proofs.
+func presentationChallenge(vp *Presentation, vpRaw *rawPresentation) (string, []string, error) {
+	if vpRaw.jwtClaims != nil {
+		if vpRaw.jwtClaims.Nonce == "" {
+			return "", nil, errors.New("nonce of JWT presentation is not defined")
+		}
+
+		return vpRaw.jwtClaims.Nonce, vpRaw.jwtClaims.Audience, nil
+	}
+
+	if len(vp.Proofs) == 0 {
+		return "", nil, errors.New("presentation has no proof")
+	}
+
+	challenge, _ := vp.Proofs[0]["challenge"].(string)
+	domains := proofDomains(vp.Proofs[0]["domain"])
+
+	// every proof must be bound to the challenge, not only the first one.
+	for _, proof := range vp.Proofs {
+		proofChallenge, ok := proof["challenge"].(string)
+		if !ok || proofChallenge == "" {
+			return "", nil, errors.New("challenge of proof is not defined")
+		}
+
+		if proofChallenge != challenge || !reflect.DeepEqual(proofDomains(proof["domain"]), domains) {
+			return "", nil, errors.New("proofs of presentation have different challenges or domains")
+		}
+	}
+
+	return challenge, domains, nil
+}
+
+// proofDomains returns the domains of the proof, "domain" can be either a string or an array of strings.
+func proofDomains(domain interface{}) []string {
+	switch d := domain.(type) {
+	case string:
+		return []string{d}
+	case []interface{}:
+		var domains []string
+
+		for _, v := range d {
+			if s, ok := v.(string); ok {
+				domains = append(domains, s)
+			}
+		}
+
+		return domains
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockChallengeVerifier struct {
+	challenge string
+	domains   []string
+	err       error
+}
+
+func (v *mockChallengeVerifier) VerifyChallenge(challenge string, domains []string) error {
+	v.challenge, v.domains = challenge, domains
+
+	return v.err
+}
+
+func TestWithPresChallengeVerifier(t *testing.T) {
+	vpWithProofs := func(t *testing.T, proofs ...Proof) []byte {
+		t.Helper()
+
+		var vp map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(validPresentation), &vp))
+
+		switch len(proofs) {
+		case 0:
+		case 1:
+			vp["proof"] = proofs[0]
+		default:
+			vp["proof"] = proofs
+		}
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	t.Run("embedded proof", func(t *testing.T) {
+		verifier := &mockChallengeVerifier{}
+
+		_, err := newTestPresentation(t, vpWithProofs(t, Proof{
+			"type":      "Ed25519Signature2018",
+			"challenge": "challenge-1",
+			"domain":    "example.com",
+		}), WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.NoError(t, err)
+		require.Equal(t, "challenge-1", verifier.challenge)
+		require.Equal(t, []string{"example.com"}, verifier.domains)
+
+		_, err = newTestPresentation(t, vpWithProofs(t,
+			Proof{"type": "Ed25519Signature2018", "challenge": "challenge-2", "domain": []string{"a.com", "b.com"}},
+			Proof{"type": "Ed25519Signature2018", "challenge": "challenge-2", "domain": []string{"a.com", "b.com"}},
+		), WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.NoError(t, err)
+		require.Equal(t, "challenge-2", verifier.challenge)
+		require.Equal(t, []string{"a.com", "b.com"}, verifier.domains)
+
+		_, err = newTestPresentation(t, vpWithProofs(t,
+			Proof{"type": "Ed25519Signature2018", "challenge": "challenge-3", "domain": "a.com"},
+			Proof{"type": "Ed25519Signature2018", "challenge": "challenge-3", "domain": []string{"a.com"}},
+		), WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.NoError(t, err)
+		require.Equal(t, "challenge-3", verifier.challenge)
+		require.Equal(t, []string{"a.com"}, verifier.domains)
+
+		_, err = newTestPresentation(t, vpWithProofs(t, Proof{"type": "Ed25519Signature2018", "challenge": "c"}),
+			WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.NoError(t, err)
+		require.Empty(t, verifier.domains)
+	})
+
+	t.Run("JWT presentation", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation), WithPresDisabledProofCheck())
+		require.NoError(t, err)
+
+		claims, err := vp.JWTClaims([]string{"did:example:verifier"}, false)
+		require.NoError(t, err)
+
+		claims.Nonce = "jwt-nonce"
+
+		vpJWT, err := claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		verifier := &mockChallengeVerifier{}
+
+		_, err = newTestPresentation(t, []byte(vpJWT), WithPresChallengeVerifier(verifier))
+		require.NoError(t, err)
+		require.Equal(t, "jwt-nonce", verifier.challenge)
+		require.Equal(t, []string{"did:example:verifier"}, verifier.domains)
+
+		claims.Nonce = ""
+
+		vpJWT, err = claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, []byte(vpJWT), WithPresChallengeVerifier(verifier))
+		require.EqualError(t, err, "check challenge of presentation: nonce of JWT presentation is not defined")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		verifier := &mockChallengeVerifier{err: errors.New("challenge is used")}
+
+		_, err := newTestPresentation(t, vpWithProofs(t, Proof{"type": "Ed25519Signature2018", "challenge": "c"}),
+			WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.EqualError(t, err, "check challenge of presentation: challenge is used")
+
+		_, err = newTestPresentation(t, vpWithProofs(t), WithPresChallengeVerifier(verifier))
+		require.EqualError(t, err, "check challenge of presentation: presentation has no proof")
+
+		_, err = newTestPresentation(t, vpWithProofs(t, Proof{"type": "Ed25519Signature2018"}),
+			WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.EqualError(t, err, "check challenge of presentation: challenge of proof is not defined")
+
+		_, err = newTestPresentation(t, vpWithProofs(t,
+			Proof{"type": "Ed25519Signature2018", "challenge": "c1"},
+			Proof{"type": "Ed25519Signature2018", "challenge": "c2"},
+		), WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.EqualError(t, err,
+			"check challenge of presentation: proofs of presentation have different challenges or domains")
+
+		_, err = newTestPresentation(t, vpWithProofs(t,
+			Proof{"type": "Ed25519Signature2018", "challenge": "c", "domain": "a.com"},
+			Proof{"type": "Ed25519Signature2018", "challenge": "c", "domain": "b.com"},
+		), WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.EqualError(t, err,
+			"check challenge of presentation: proofs of presentation have different challenges or domains")
+
+		_, err = newTestPresentation(t, vpWithProofs(t,
+			Proof{"type": "Ed25519Signature2018", "challenge": "c"},
+			Proof{"type": "Ed25519Signature2018"},
+		), WithPresDisabledProofCheck(), WithPresChallengeVerifier(verifier))
+		require.EqualError(t, err, "check challenge of presentation: challenge of proof is not defined")
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	docjsonld "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
@@ -501,6 +502,8 @@ type Credential struct {
 	// Subject can be a string, map, slice of maps, struct (Subject or any custom), slice of structs.
 	Subject        interface{}
 	Issuer         Issuer
+	// Issued and Expired are "issuanceDate" and "expirationDate" of VC Data Model 1.1 credential,
+	// or "validFrom" and "validUntil" of VC Data Model 2.0 credential (see DataModelVersion).
 	Issued         *util.TimeWrapper
 	Expired        *util.TimeWrapper
 	Proofs         []Proof
@@ -511,6 +514,12 @@ type Credential struct {
 	RefreshService []TypedID
 	JWT            string
 
+	// SDJWTHashAlg, SDJWTDisclosures and SDHolderBinding are set when the credential is an SD-JWT VC: the hash
+	// algorithm of the disclosures, the disclosures and the Key Binding JWT (if any).
+	SDJWTHashAlg     string
+	SDJWTDisclosures []*common.DisclosureClaim
+	SDHolderBinding  string
+
 	CustomFields CustomFields
 }
 
@@ -522,6 +531,8 @@ type rawCredential struct {
 	Subject        json.RawMessage   `json:"credentialSubject,omitempty"`
 	Issued         *util.TimeWrapper `json:"issuanceDate,omitempty"`
 	Expired        *util.TimeWrapper `json:"expirationDate,omitempty"`
+	ValidFrom      *util.TimeWrapper `json:"validFrom,omitempty"`
+	ValidUntil     *util.TimeWrapper `json:"validUntil,omitempty"`
 	Proof          json.RawMessage   `json:"proof,omitempty"`
 	Status         *TypedID          `json:"credentialStatus,omitempty"`
 	Issuer         json.RawMessage   `json:"issuer,omitempty"`
@@ -569,6 +580,7 @@ type CredentialTemplate func() *Credential
 // credentialOpts holds options for the Verifiable Credential decoding.
 type credentialOpts struct {
 	publicKeyFetcher      PublicKeyFetcher
+	x5cVerifier           *jwt.X5CVerifier
 	disabledCustomSchema  bool
 	schemaLoader          *CredentialSchemaLoader
 	modelValidationMode   vcModelValidationMode
@@ -578,10 +590,19 @@ type credentialOpts struct {
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
 	defaultSchema         string
+	statusChecker         CredentialStatusChecker
+	dataModelVersion      DataModelVersion
+	verificationPolicy    *VerificationPolicy
+	sdJWTKeyBinding       *sdJWTKeyBindingOpts
 
 	jsonldCredentialOpts
 }
 
+type sdJWTKeyBindingOpts struct {
+	nonce    string
+	audience string
+}
+
 // CredentialOpt is the Verifiable Credential decoding option.
 type CredentialOpt func(opts *credentialOpts)
 
@@ -614,6 +635,14 @@ func WithPublicKeyFetcher(fetcher PublicKeyFetcher) CredentialOpt {
 	}
 }
 
+// WithX5CVerifier sets the verifier of JWS signed by a key of the X.509 certificate chain from the 'x5c'
+// JOSE header. It's used instead of the public key fetcher when decoding JWS which has such a header.
+func WithX5CVerifier(v *jwt.X5CVerifier) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.x5cVerifier = v
+	}
+}
+
 // WithCredentialSchemaLoader option is used to define custom credentials schema loader.
 // If not defined, the default one is created with default HTTP client to download the schema
 // and no caching of the schemas.
@@ -702,6 +731,26 @@ func WithEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) CredentialOp
 	}
 }
 
+// CredentialStatusChecker checks the credentialStatus of a Verifiable Credential, e.g. that it is not revoked.
+type CredentialStatusChecker interface {
+	CheckStatus(vc *Credential) error
+}
+
+// WithSDJWTKeyBinding option to require the Key Binding JWT of SD-JWT VCs, proving the possession of the key of
+// their "cnf" claim, with the given nonce and audience. The nonce and audience are required.
+func WithSDJWTKeyBinding(nonce, audience string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.sdJWTKeyBinding = &sdJWTKeyBindingOpts{nonce: nonce, audience: audience}
+	}
+}
+
+// WithStatusCheck option to check the credentialStatus of the VC (if defined) using the given checker.
+func WithStatusCheck(checker CredentialStatusChecker) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusChecker = checker
+	}
+}
+
 // parseIssuer parses raw issuer.
 //
 // Issuer can be defined by:
@@ -819,12 +868,38 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, err
 	}
 
+	if vcOpts.statusChecker != nil && vc.Status != nil {
+		err = vcOpts.statusChecker.CheckStatus(vc)
+		if err != nil {
+			return nil, fmt.Errorf("check credential status: %w", err)
+		}
+	}
+
 	vc.JWT = externalJWT
 
+	if vcOpts.verificationPolicy != nil {
+		err = reportToError(vcOpts.verificationPolicy.verifyCredentialReport(vc, !vcOpts.disabledProofCheck))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isSDJWT(externalJWT) {
+		err = vc.setSDJWT(externalJWT)
+		if err != nil {
+			return nil, fmt.Errorf("parse SD-JWT: %w", err)
+		}
+	}
+
 	return vc, nil
 }
 
 func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts) error {
+	err := validateBaseContexts(vc.Context, vcOpts.dataModelVersion)
+	if err != nil {
+		return err
+	}
+
 	// Credential and type constraint.
 	switch vcOpts.modelValidationMode {
 	case combinedValidation:
@@ -858,7 +933,7 @@ func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts
 		return errors.New("violated type constraint: not base only type defined")
 	}
 
-	if len(vc.Context) > 1 || vc.Context[0] != baseContext {
+	if len(vc.Context) > 1 || vc.Context[0] != vc.DataModelVersion().baseContext() {
 		return errors.New("violated @context constraint: not base only @context defined")
 	}
 
@@ -867,6 +942,10 @@ func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts
 
 func (vc *Credential) validateBaseContextWithExtendedValidation(vcOpts *credentialOpts, vcBytes []byte) error {
 	for _, vcContext := range vc.Context {
+		if vcContext == ContextV2 {
+			continue
+		}
+
 		if _, ok := vcOpts.allowedCustomContexts[vcContext]; !ok {
 			return fmt.Errorf("not allowed @context: %s", vcContext)
 		}
@@ -886,7 +965,7 @@ func (vc *Credential) validateJSONLD(vcBytes []byte, vcOpts *credentialOpts) err
 		docjsonld.WithDocumentLoader(vcOpts.jsonldCredentialOpts.jsonldDocumentLoader),
 		docjsonld.WithExternalContext(vcOpts.jsonldCredentialOpts.externalContext),
 		docjsonld.WithStrictValidation(vcOpts.strictValidation),
-		docjsonld.WithStrictContextURIPosition(baseContext),
+		docjsonld.WithStrictContextURIPosition(vc.DataModelVersion().baseContext()),
 	)
 }
 
@@ -977,6 +1056,8 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		return nil, fmt.Errorf("fill credential subject from raw: %w", err)
 	}
 
+	issued, expired := validityPeriodFromRaw(raw, dataModelVersion(context))
+
 	return &Credential{
 		Context:        context,
 		CustomContext:  customContext,
@@ -984,8 +1065,8 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		Types:          types,
 		Subject:        subjects,
 		Issuer:         issuer,
-		Issued:         raw.Issued,
-		Expired:        raw.Expired,
+		Issued:         issued,
+		Expired:        expired,
 		Proofs:         proofs,
 		Status:         raw.Status,
 		Schemas:        schemas,
@@ -997,6 +1078,30 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 	}, nil
 }
 
+// validityPeriodFromRaw returns the validity period fields of the given data model version.
+// The fields of the other data model version are kept as custom fields.
+func validityPeriodFromRaw(raw *rawCredential, version DataModelVersion) (*util.TimeWrapper, *util.TimeWrapper) {
+	issued, expired := raw.Issued, raw.Expired
+	customFields := map[string]*util.TimeWrapper{vcValidFromField: raw.ValidFrom, vcValidUntilField: raw.ValidUntil}
+
+	if version == DataModelV2 {
+		issued, expired = raw.ValidFrom, raw.ValidUntil
+		customFields = map[string]*util.TimeWrapper{vcIssuanceDateField: raw.Issued, vcExpirationDateField: raw.Expired}
+	}
+
+	for k, v := range customFields {
+		if v != nil {
+			if raw.CustomFields == nil {
+				raw.CustomFields = make(CustomFields)
+			}
+
+			raw.CustomFields[k] = v
+		}
+	}
+
+	return issued, expired
+}
+
 func parseTypedID(data json.RawMessage) ([]TypedID, error) {
 	if len(data) == 0 {
 		return nil, nil
@@ -1047,12 +1152,36 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, string, error) {
 		externalVCStr = jwtHolder.JWT
 	}
 
+	enveloped, err := decodeEnvelopedCredential(vcData)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if enveloped != "" {
+		externalVCStr = enveloped
+	}
+
+	if isSDJWT(externalVCStr) { // External proof, is checked by the SD-JWT JWS.
+		if vcOpts.publicKeyFetcher == nil && vcOpts.x5cVerifier == nil && !vcOpts.disabledProofCheck {
+			return nil, "", errors.New("public key fetcher is not defined")
+		}
+
+		vcDecodedBytes, err := decodeCredSDJWT(externalVCStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher,
+			vcOpts.x5cVerifier, vcOpts.sdJWTKeyBinding)
+		if err != nil {
+			return nil, "", fmt.Errorf("SD-JWT decoding: %w", err)
+		}
+
+		return vcDecodedBytes, externalVCStr, nil
+	}
+
 	if jwt.IsJWS(externalVCStr) { // External proof, is checked by JWS.
-		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
+		if vcOpts.publicKeyFetcher == nil && vcOpts.x5cVerifier == nil && !vcOpts.disabledProofCheck {
 			return nil, "", errors.New("public key fetcher is not defined")
 		}
 
-		vcDecodedBytes, err := decodeCredJWS(externalVCStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher)
+		vcDecodedBytes, err := decodeCredJWS(externalVCStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher,
+			vcOpts.x5cVerifier)
 		if err != nil {
 			return nil, "", fmt.Errorf("JWS decoding: %w", err)
 		}
@@ -1060,6 +1189,10 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, string, error) {
 		return vcDecodedBytes, externalVCStr, nil
 	}
 
+	if enveloped != "" {
+		return nil, "", errors.New("decode enveloped credential: neither JWT nor SD-JWT is enveloped")
+	}
+
 	if jwt.IsJWTUnsecured(vcStr) { // Embedded proof.
 		vcData, e = decodeCredJWTUnsecured(vcStr)
 		if e != nil {
@@ -1075,7 +1208,7 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, string, error) {
 func JWTVCToJSON(vc []byte) ([]byte, error) {
 	vc = bytes.Trim(vc, "\"' ")
 
-	return decodeCredJWS(string(vc), false, nil)
+	return decodeCredJWS(string(vc), false, nil, nil)
 }
 
 func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
@@ -1196,13 +1329,14 @@ func subjectMapToRaw(subject map[string]interface{}) (json.RawMessage, error) {
 }
 
 func (vc *Credential) validateJSONSchema(data []byte, opts *credentialOpts) error {
-	return validateCredentialUsingJSONSchema(data, vc.Schemas, opts)
+	return validateCredentialUsingJSONSchema(data, vc.Schemas, vc.DataModelVersion(), opts)
 }
 
-func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *credentialOpts) error {
+func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, version DataModelVersion,
+	opts *credentialOpts) error {
 	// Validate that the Verifiable Credential conforms to the serialization of the Verifiable Credential data model
 	// (https://w3c.github.io/vc-data-model/#example-1-a-simple-example-of-a-verifiable-credential)
-	schemaLoader, err := getSchemaLoader(schemas, opts)
+	schemaLoader, err := getSchemaLoader(schemas, version, opts)
 	if err != nil {
 		return err
 	}
@@ -1222,19 +1356,27 @@ func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *cre
 	return nil
 }
 
-func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSONLoader, error) {
+func getSchemaLoader(schemas []TypedID, version DataModelVersion,
+	opts *credentialOpts) (gojsonschema.JSONLoader, error) {
 	if opts.disabledCustomSchema {
-		return defaultSchemaLoaderWithOpts(opts), nil
+		return defaultSchemaLoaderWithOpts(version, opts), nil
 	}
 
 	for _, schema := range schemas {
 		switch schema.Type {
-		case jsonSchema2018Type:
+		case jsonSchema2018Type, jsonSchemaType:
 			customSchemaData, err := getJSONSchema(schema.ID, opts)
 			if err != nil {
 				return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
 			}
 
+			return gojsonschema.NewBytesLoader(customSchemaData), nil
+		case jsonSchemaCredentialType:
+			customSchemaData, err := getCredentialJSONSchema(schema.ID, opts)
+			if err != nil {
+				return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
+			}
+
 			return gojsonschema.NewBytesLoader(customSchemaData), nil
 		default:
 			logger.Warnf("unsupported credential schema: %s. Using default schema for validation", schema.Type)
@@ -1242,7 +1384,39 @@ func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSON
 	}
 
 	// If no custom schema is chosen, use default one
-	return defaultSchemaLoaderWithOpts(opts), nil
+	return defaultSchemaLoaderWithOpts(version, opts), nil
+}
+
+// getCredentialJSONSchema loads JSON schema from "credentialSubject.jsonSchema" of JsonSchemaCredential.
+// The proof of the schema credential is not checked.
+func getCredentialJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
+	schemaVCBytes, err := getJSONSchema(url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaVC struct {
+		Type    interface{} `json:"type,omitempty"`
+		Subject struct {
+			JSONSchema json.RawMessage `json:"jsonSchema,omitempty"`
+		} `json:"credentialSubject,omitempty"`
+	}
+
+	err = json.Unmarshal(schemaVCBytes, &schemaVC)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal JSON schema credential: %w", err)
+	}
+
+	types, err := decodeType(schemaVC.Type)
+	if err != nil || !contains(types, jsonSchemaCredentialType) {
+		return nil, fmt.Errorf("credential is not of %s type", jsonSchemaCredentialType)
+	}
+
+	if len(schemaVC.Subject.JSONSchema) == 0 {
+		return nil, errors.New("jsonSchema is not defined in JSON schema credential")
+	}
+
+	return schemaVC.Subject.JSONSchema, nil
 }
 
 type schemaOpts struct {
@@ -1293,11 +1467,15 @@ func JSONSchemaLoader(opts ...SchemaOpt) string {
 	return fmt.Sprintf(DefaultSchemaTemplate, required)
 }
 
-func defaultSchemaLoaderWithOpts(opts *credentialOpts) gojsonschema.JSONLoader {
+func defaultSchemaLoaderWithOpts(version DataModelVersion, opts *credentialOpts) gojsonschema.JSONLoader {
 	if opts.defaultSchema != "" {
 		return gojsonschema.NewStringLoader(opts.defaultSchema)
 	}
 
+	if version == DataModelV2 {
+		return defaultSchemaLoaderV2
+	}
+
 	return defaultSchemaLoader()
 }
 
@@ -1466,12 +1644,16 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		Evidence:       vc.Evidence,
 		RefreshService: rawRefreshService,
 		TermsOfUse:     rawTermsOfUse,
-		Issued:         vc.Issued,
-		Expired:        vc.Expired,
 		JWT:            vc.JWT,
 		CustomFields:   vc.CustomFields,
 	}
 
+	if vc.DataModelVersion() == DataModelV2 {
+		r.ValidFrom, r.ValidUntil = vc.Issued, vc.Expired
+	} else {
+		r.Issued, r.Expired = vc.Issued, vc.Expired
+	}
+
 	return r, nil
 }
 
@@ -1514,8 +1696,12 @@ func typedIDsToRaw(typedIDs []TypedID) ([]byte, error) {
 // MarshalJSON converts Verifiable Credential to JSON bytes.
 func (vc *Credential) MarshalJSON() ([]byte, error) {
 	if vc.JWT != "" {
-		// If vc.JWT exists, marshal only the JWT, since all other values should be unchanged
-		// from when the JWT was parsed.
+		// If vc.JWT exists, marshal only the JWT (and the SD-JWT disclosures), since all other values should be
+		// unchanged from when the JWT was parsed.
+		if vc.SDJWTHashAlg != "" {
+			return []byte("\"" + vc.sdJWTCombinedFormat(vc.SDJWTDisclosures, vc.SDHolderBinding) + "\""), nil
+		}
+
 		return []byte("\"" + vc.JWT + "\""), nil
 	}
 
@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasd2023"
+	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
+)
+
+// GenerateECDSASelectiveDisclosure generates ecdsa-sd-2023 selective disclosure of the credential values
+// selected by JSON pointers (e.g. "/credentialSubject/degree/type") from the ecdsa-sd-2023 base proof.
+// The values made mandatory by the issuer, as well as the issuer and issuance date, are always disclosed.
+func (vc *Credential) GenerateECDSASelectiveDisclosure(selectivePointers []string,
+	opts ...CredentialOpt) (*Credential, error) {
+	if len(vc.Proofs) == 0 {
+		return nil, errors.New("expected at least one proof present")
+	}
+
+	vcOpts := getCredentialOpts(opts)
+	jsonldProcessorOpts := mapJSONLDProcessorOpts(&vcOpts.jsonldCredentialOpts)
+
+	vcDoc, err := jsonutil.ToMap(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	pointers := append([]string{}, selectivePointers...)
+
+	for _, property := range []string{"issuer", "issuanceDate", "validFrom"} {
+		if _, ok := vcDoc[property]; ok {
+			pointers = append(pointers, "/"+property)
+		}
+	}
+
+	vcWithSelectiveDisclosureDoc, err := ecdsasd2023.New().SelectiveDisclosure(vcDoc, pointers,
+		jsonldProcessorOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("create VC selective disclosure: %w", err)
+	}
+
+	vcWithSelectiveDisclosureBytes, err := json.Marshal(vcWithSelectiveDisclosureDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, WithDisabledProofCheck())
+
+	return ParseCredential(vcWithSelectiveDisclosureBytes, opts...)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasd2023"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCredential_GenerateECDSASelectiveDisclosure(t *testing.T) {
+	vcJSON := `
+	{
+	 "@context": [
+	   "https://www.w3.org/2018/credentials/v1",
+	   "https://w3id.org/citizenship/v1",
+	   "https://w3id.org/security/data-integrity/v1"
+	 ],
+	 "id": "https://issuer.oidp.uscis.gov/credentials/83627465",
+	 "type": ["VerifiableCredential", "PermanentResidentCard"],
+	 "issuer": "did:example:489398593",
+	 "issuanceDate": "2019-12-03T12:19:52Z",
+	 "expirationDate": "2029-12-03T12:19:52Z",
+	 "credentialSubject": {
+	   "id": "did:example:b34ca6cd37bbf23",
+	   "type": ["PermanentResident", "Person"],
+	   "givenName": "JOHN",
+	   "familyName": "SMITH",
+	   "gender": "Male",
+	   "birthDate": "1958-07-17"
+	 }
+	}`
+
+	cryptoSigner, err := newCryptoSigner(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	loader := createTestDocumentLoader(t)
+
+	vc, err := parseTestCredential(t, []byte(vcJSON))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "DataIntegrityProof",
+		SignatureRepresentation: SignatureProofValue,
+		Suite: ecdsasd2023.NewWithMandatoryPointers([]string{"/expirationDate"},
+			suite.WithSigner(cryptoSigner)),
+		VerificationMethod: "did:example:489398593#key1",
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+	require.Len(t, vc.Proofs, 1)
+	require.Equal(t, "ecdsa-sd-2023", vc.Proofs[0]["cryptosuite"])
+	require.True(t, strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "u"))
+
+	pubKeyFetcher := WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+		return &sigverifier.PublicKey{Type: "Multikey", Value: cryptoSigner.PublicKeyBytes()}, nil
+	})
+
+	t.Run("selective disclosure of the credential subject claims", func(t *testing.T) {
+		vcWithSelectiveDisclosure, err := vc.GenerateECDSASelectiveDisclosure(
+			[]string{"/credentialSubject/givenName"}, WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+
+		require.Equal(t, vc.ID, vcWithSelectiveDisclosure.ID)
+		require.Equal(t, vc.Issuer, vcWithSelectiveDisclosure.Issuer)
+		require.Equal(t, vc.Issued, vcWithSelectiveDisclosure.Issued)
+		require.Equal(t, vc.Expired, vcWithSelectiveDisclosure.Expired)
+
+		subjects, ok := vcWithSelectiveDisclosure.Subject.([]Subject)
+		require.True(t, ok)
+		require.Len(t, subjects, 1)
+		require.Equal(t, "did:example:b34ca6cd37bbf23", subjects[0].ID)
+		require.Equal(t, CustomFields{
+			"type":      []interface{}{"PermanentResident", "Person"},
+			"givenName": "JOHN",
+		}, subjects[0].CustomFields)
+
+		vcBytes, err := vcWithSelectiveDisclosure.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, pubKeyFetcher)
+		require.NoError(t, err)
+
+		// revealed claims cannot be modified
+		_, err = parseTestCredential(t, []byte(strings.Replace(string(vcBytes), "JOHN", "JANE", 1)),
+			pubKeyFetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+	})
+
+	t.Run("credential with base proof is verifiable as well", func(t *testing.T) {
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, pubKeyFetcher)
+		require.NoError(t, err)
+	})
+
+	t.Run("no ecdsa-sd-2023 base proof", func(t *testing.T) {
+		_, err := (&Credential{}).GenerateECDSASelectiveDisclosure([]string{"/credentialSubject/givenName"})
+		require.EqualError(t, err, "expected at least one proof present")
+
+		vcCopy := *vc
+		vcCopy.Proofs = []Proof{{
+			"type":       "Ed25519Signature2018",
+			"created":    "2023-01-01T00:00:00Z",
+			"proofValue": "abc",
+		}}
+
+		_, err = vcCopy.GenerateECDSASelectiveDisclosure([]string{"/credentialSubject/givenName"},
+			WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ecdsa-sd-2023 base proof is not found")
+	})
+}
@@ -5,15 +5,20 @@ SPDX-License-Identifier: Apache-2.0
 
 package verifiable
 
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
 // MarshalJWS serializes JWT into signed form (JWS).
 func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
 	return marshalJWS(jcc, signatureAlg, signer, keyID)
 }
 
-func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) (*JWTCredClaims, error) {
+func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher,
+	x5cVerifier *jwt.X5CVerifier) (*JWTCredClaims, error) {
 	var claims JWTCredClaims
 
-	err := unmarshalJWS(rawJwt, checkProof, fetcher, &claims)
+	err := unmarshalJWS(rawJwt, checkProof, fetcher, x5cVerifier, &claims)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +26,9 @@ func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher
 	return &claims, err
 }
 
-func decodeCredJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) ([]byte, error) {
+func decodeCredJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher,
+	x5cVerifier *jwt.X5CVerifier) ([]byte, error) {
 	return decodeCredJWT(rawJwt, func(vcJWTBytes string) (*JWTCredClaims, error) {
-		return unmarshalJWSClaims(rawJwt, checkProof, fetcher)
+		return unmarshalJWSClaims(rawJwt, checkProof, fetcher, x5cVerifier)
 	})
 }
@@ -38,7 +38,7 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 				Type:  kms.RSARS256,
 				Value: signer.PublicKeyBytes(),
 			}, nil
-		})
+		}, nil)
 		require.NoError(t, err)
 
 		vcRaw := new(rawCredential)
@@ -70,7 +70,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 	validJWS := createRS256JWS(t, []byte(jwtTestCredential), signer, false)
 
 	t.Run("Successful JWS decoding", func(t *testing.T) {
-		vcBytes, err := decodeCredJWS(string(validJWS), true, pkFetcher)
+		vcBytes, err := decodeCredJWS(string(validJWS), true, pkFetcher, nil)
 		require.NoError(t, err)
 
 		vcRaw := new(rawCredential)
@@ -83,7 +83,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 	})
 
 	t.Run("Invalid serialized JWS", func(t *testing.T) {
-		jws, err := decodeCredJWS("invalid JWS", true, pkFetcher)
+		jws, err := decodeCredJWS("invalid JWS", true, pkFetcher, nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unmarshal VC JWT claims")
 		require.Nil(t, jws)
@@ -106,7 +106,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 		jwtCompact, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
 		require.NoError(t, err)
 
-		jws, err := decodeCredJWS(jwtCompact, true, pkFetcher)
+		jws, err := decodeCredJWS(jwtCompact, true, pkFetcher, nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unmarshal VC JWT claims")
 		require.Nil(t, jws)
@@ -124,7 +124,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 			}, nil
 		}
 
-		jws, err := decodeCredJWS(string(validJWS), true, pkFetcherOther)
+		jws, err := decodeCredJWS(string(validJWS), true, pkFetcherOther, nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unmarshal VC JWT claims")
 		require.Nil(t, jws)
@@ -42,17 +42,18 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 
 	// currently jwt encoding supports only single subject (by the spec)
 	jwtClaims := &jwt.Claims{
-		Issuer:    vc.Issuer.ID,                           // iss
-		NotBefore: josejwt.NewNumericDate(vc.Issued.Time), // nbf
-		ID:        vc.ID,                                  // jti
-		Subject:   subjectID,                              // sub
+		Issuer:  vc.Issuer.ID, // iss
+		ID:      vc.ID,        // jti
+		Subject: subjectID,    // sub
 	}
 
 	if vc.Expired != nil {
 		jwtClaims.Expiry = josejwt.NewNumericDate(vc.Expired.Time) // exp
 	}
 
+	// validFrom of VC Data Model 2.0 credential is optional.
 	if vc.Issued != nil {
+		jwtClaims.NotBefore = josejwt.NewNumericDate(vc.Issued.Time) // nbf
 		jwtClaims.IssuedAt = josejwt.NewNumericDate(vc.Issued.Time)
 	}
 
@@ -120,23 +121,33 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 		refineVCIssuerFromJWTClaims(vcMap, iss)
 	}
 
+	issuanceDateField, expirationDateField := vcIssuanceDateField, vcExpirationDateField
+
+	contexts, _, err := decodeContext(vcMap["@context"])
+	isV2 := err == nil && dataModelVersion(contexts) == DataModelV2
+
+	if isV2 {
+		issuanceDateField, expirationDateField = vcValidFromField, vcValidUntilField
+	}
+
 	if nbf := claims.NotBefore; nbf != nil {
 		nbfTime := nbf.Time().UTC()
-		vcMap[vcIssuanceDateField] = nbfTime.Format(time.RFC3339)
+		vcMap[issuanceDateField] = nbfTime.Format(time.RFC3339)
 	}
 
 	if jti := claims.ID; jti != "" {
 		vcMap[vcIDField] = jti
 	}
 
-	if iat := claims.IssuedAt; iat != nil {
+	// validFrom of VC Data Model 2.0 credential is not the time of issuance.
+	if iat := claims.IssuedAt; iat != nil && !isV2 {
 		iatTime := iat.Time().UTC()
-		vcMap[vcIssuanceDateField] = iatTime.Format(time.RFC3339)
+		vcMap[issuanceDateField] = iatTime.Format(time.RFC3339)
 	}
 
 	if exp := claims.Expiry; exp != nil {
 		expTime := exp.Time().UTC()
-		vcMap[vcExpirationDateField] = expTime.Format(time.RFC3339)
+		vcMap[expirationDateField] = expTime.Format(time.RFC3339)
 	}
 }
 
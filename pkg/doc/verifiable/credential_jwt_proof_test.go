@@ -6,9 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
+	"net/url"
 	"testing"
 	"time"
 
@@ -16,6 +25,10 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
@@ -153,6 +166,85 @@ func TestParseCredentialFromJWS_EdDSA(t *testing.T) {
 	require.Equal(t, vc, vcFromJWS)
 }
 
+func TestParseCredentialFromJWS_X5C(t *testing.T) {
+	testCred := []byte(jwtTestCredential)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	roots, x5c := createTestCertChain(t, ed25519.PublicKey(signer.PublicKeyBytes()),
+		"did:example:76e12ec712ebc6f1c221ebfeb1f")
+
+	t.Run("Decoding credential from JWS with x5c chain", func(t *testing.T) {
+		vcFromJWT, err := parseTestCredential(t,
+			createX5CJWS(t, testCred, signer, x5c),
+			WithX5CVerifier(jwt.NewX5CVerifier(roots)))
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, testCred)
+		require.NoError(t, err)
+
+		vcFromJWT.JWT = ""
+		require.Equal(t, vc, vcFromJWT)
+	})
+
+	t.Run("Decoding credential from SD-JWT with x5c chain", func(t *testing.T) {
+		vc, err := parseTestCredential(t, testCred)
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		claims, err := jwt.PayloadToMap(jwtClaims)
+		require.NoError(t, err)
+
+		sdJWT, err := issuer.NewFromVC(claims, jose.Headers{jose.HeaderX509CertificateChain: x5c},
+			getJWTSigner(signer, "EdDSA"))
+		require.NoError(t, err)
+
+		sdJWTStr, err := sdJWT.Serialize(false)
+		require.NoError(t, err)
+
+		vcFromSDJWT, err := parseTestCredential(t, []byte(sdJWTStr+common.DisclosureSeparator),
+			WithX5CVerifier(jwt.NewX5CVerifier(roots)))
+		require.NoError(t, err)
+		require.NotEmpty(t, vcFromSDJWT.SDJWTDisclosures)
+	})
+
+	t.Run("Decoding credential from JWS with kid when x5c verifier is set", func(t *testing.T) {
+		vcJWS := createEdDSAJWS(t, testCred, signer, false)
+
+		_, err := parseTestCredential(t, vcJWS,
+			WithX5CVerifier(jwt.NewX5CVerifier(roots)),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcJWS, WithX5CVerifier(jwt.NewX5CVerifier(roots)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key fetcher is not defined")
+	})
+
+	t.Run("Untrusted x5c chain", func(t *testing.T) {
+		_, err := parseTestCredential(t,
+			createX5CJWS(t, testCred, signer, x5c),
+			WithX5CVerifier(jwt.NewX5CVerifier(x509.NewCertPool())))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify x5c certificate chain")
+	})
+
+	t.Run("x5c chain of another issuer", func(t *testing.T) {
+		otherRoots, otherX5C := createTestCertChain(t, ed25519.PublicKey(signer.PublicKeyBytes()),
+			"did:example:other")
+
+		_, err := parseTestCredential(t,
+			createX5CJWS(t, testCred, signer, otherX5C),
+			WithX5CVerifier(jwt.NewX5CVerifier(otherRoots)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"x5c certificate is not bound to issuer did:example:76e12ec712ebc6f1c221ebfeb1f")
+	})
+}
+
 func TestParseCredentialFromUnsecuredJWT(t *testing.T) {
 	testCred := []byte(jwtTestCredential)
 
@@ -309,3 +401,64 @@ func createUnsecuredJWT(t *testing.T, cred []byte, minimize bool) []byte {
 
 	return []byte(vcJWT)
 }
+
+func createX5CJWS(t *testing.T, cred []byte, signer Signer, x5c []string) []byte {
+	vc, err := parseTestCredential(t, cred)
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	token, err := jwt.NewSigned(jwtClaims, jose.Headers{jose.HeaderX509CertificateChain: x5c},
+		getJWTSigner(signer, "EdDSA"))
+	require.NoError(t, err)
+
+	vcJWT, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return []byte(vcJWT)
+}
+
+// createTestCertChain creates a root CA certificate and a document signing leaf certificate of the given public key
+// and issuer ID issued by it. It returns a pool with the root certificate and the x5c chain of the leaf certificate.
+func createTestCertChain(t *testing.T, pubKey interface{}, issuerID string) (*x509.CertPool, []string) {
+	issuerURI, err := url.Parse(issuerID)
+	require.NoError(t, err)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber:       big.NewInt(2),
+		Subject:            pkix.Name{CommonName: "Example University"},
+		NotBefore:          time.Now().Add(-time.Hour),
+		NotAfter:           time.Now().Add(time.Hour),
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		URIs:               []*url.URL{issuerURI},
+		UnknownExtKeyUsage: []asn1.ObjectIdentifier{jwt.OIDExtKeyUsageDocumentSigning},
+	}
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, caCert, pubKey, caKey)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	return roots, []string{base64.StdEncoding.EncodeToString(leafDER)}
+}
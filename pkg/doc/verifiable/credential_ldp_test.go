@@ -25,12 +25,15 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
@@ -708,6 +711,110 @@ func TestParseCredentialFromLinkedDataProof_JsonWebSignature2020_ecdsaP256(t *te
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_DataIntegrityProof(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyType kms.KeyType
+		suite   func(opts ...suite.Opt) signer.SignatureSuite
+	}{
+		{
+			name:    "eddsa-rdfc-2022",
+			keyType: kms.ED25519Type,
+			suite: func(opts ...suite.Opt) signer.SignatureSuite {
+				return eddsardfc2022.New(opts...)
+			},
+		},
+		{
+			name:    "ecdsa-rdfc-2019 with P-256 key",
+			keyType: kms.ECDSAP256TypeIEEEP1363,
+			suite: func(opts ...suite.Opt) signer.SignatureSuite {
+				return ecdsardfc2019.New(opts...)
+			},
+		},
+		{
+			name:    "ecdsa-rdfc-2019 with P-384 key",
+			keyType: kms.ECDSAP384TypeIEEEP1363,
+			suite: func(opts ...suite.Opt) signer.SignatureSuite {
+				return ecdsardfc2019.NewP384(opts...)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			cryptoSigner, err := newCryptoSigner(tc.keyType)
+			r.NoError(err)
+
+			ldpContext := &LinkedDataProofContext{
+				SignatureType:           "DataIntegrityProof",
+				SignatureRepresentation: SignatureProofValue,
+				Suite:                   tc.suite(suite.WithSigner(cryptoSigner)),
+				VerificationMethod:      "did:example:123456#key1",
+			}
+
+			vc, err := parseTestCredential(t, []byte(validCredential))
+			r.NoError(err)
+
+			vc.Context = append(vc.Context, "https://w3id.org/security/data-integrity/v1")
+
+			err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+			r.NoError(err)
+			r.Len(vc.Proofs, 1)
+			r.Equal("DataIntegrityProof", vc.Proofs[0]["type"])
+			r.Equal(strings.Split(tc.name, " ")[0], vc.Proofs[0]["cryptosuite"])
+			r.True(strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+
+			vcBytes, err := json.Marshal(vc)
+			r.NoError(err)
+
+			publicKey := &sigverifier.PublicKey{Type: "Multikey", Value: cryptoSigner.PublicKeyBytes()}
+
+			// the suites are chosen by the proof cryptosuite and the public key
+			vcWithLdp, err := parseTestCredential(t, vcBytes,
+				WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+					return publicKey, nil
+				}))
+			r.NoError(err)
+			r.Equal(vc, vcWithLdp)
+
+			// modified credential
+			vc.Issuer.ID = "did:example:other"
+
+			vcBytes, err = json.Marshal(vc)
+			r.NoError(err)
+
+			_, err = parseTestCredential(t, vcBytes,
+				WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+					return publicKey, nil
+				}))
+			r.Error(err)
+			r.Contains(err.Error(), "check embedded proof")
+		})
+	}
+
+	t.Run("unsupported cryptosuite", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Proofs = []Proof{{
+			"type":               "DataIntegrityProof",
+			"cryptosuite":        "bbs-2023",
+			"created":            "2023-01-01T00:00:00Z",
+			"verificationMethod": "did:example:123456#key1",
+			"proofValue":         "z123",
+		}}
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithPublicKeyFetcher(SingleKey([]byte("key"), kms.ED25519)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported cryptosuite: bbs-2023")
+	})
+}
+
 func TestParseCredentialFromLinkedDataProof_EcdsaSecp256k1Signature2019(t *testing.T) {
 	r := require.New(t)
 
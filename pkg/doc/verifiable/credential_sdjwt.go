@@ -0,0 +1,252 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/holder"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
+	sdjwtverifier "github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/verifier"
+)
+
+// sdJWTSubjectPathPrefix is the path of the credential subject claims in the SD-JWT VC payload.
+const sdJWTSubjectPathPrefix = "vc.credentialSubject."
+
+// MarshalSDJWT serializes JWT claims into a signed SD-JWT VC, in the combined format (the JWS followed by all the
+// disclosures). The claims of the credential subject are selectively disclosable, the options (e.g. structured
+// or recursive claims, holder public key) are the SD-JWT issuer ones, see issuer.NewFromVC.
+func (jcc *JWTCredClaims) MarshalSDJWT(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...issuer.NewOpt) (string, error) {
+	algName, err := signatureAlg.name()
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := jwt.PayloadToMap(jcc)
+	if err != nil {
+		return "", fmt.Errorf("convert JWT claims: %w", err)
+	}
+
+	headers := map[string]interface{}{
+		jose.HeaderKeyID: keyID,
+	}
+
+	token, err := issuer.NewFromVC(claims, headers, getJWTSigner(signer, algName), opts...)
+	if err != nil {
+		return "", fmt.Errorf("create SD-JWT: %w", err)
+	}
+
+	combinedFormat, err := token.Serialize(false)
+	if err != nil {
+		return "", err
+	}
+
+	return combinedFormat + common.DisclosureSeparator, nil
+}
+
+// marshalDisclosureOpts holds options for the SD-JWT VC serialization with selected disclosures.
+type marshalDisclosureOpts struct {
+	discloseAll   bool
+	claimPaths    []string
+	holderBinding *holder.BindingInfo
+}
+
+// MarshalDisclosureOpt is an option of Credential.MarshalWithDisclosure.
+type MarshalDisclosureOpt func(opts *marshalDisclosureOpts)
+
+// DiscloseAll option to disclose all the claims of the SD-JWT VC.
+func DiscloseAll() MarshalDisclosureOpt {
+	return func(opts *marshalDisclosureOpts) {
+		opts.discloseAll = true
+	}
+}
+
+// DiscloseClaims option to disclose the claims with the given paths relative to the credential subject, e.g.
+// "given_name", "address.street" or "nationalities[1]". All the claims of a structured object or array are
+// disclosed by its path, and the disclosures of the objects or arrays holding a claim are disclosed with it.
+func DiscloseClaims(claimPaths ...string) MarshalDisclosureOpt {
+	return func(opts *marshalDisclosureOpts) {
+		opts.claimPaths = append(opts.claimPaths, claimPaths...)
+	}
+}
+
+// DiscloseWithHolderBinding option to append a Key Binding JWT, signed with the key of the SD-JWT "cnf" claim.
+func DiscloseWithHolderBinding(info *holder.BindingInfo) MarshalDisclosureOpt {
+	return func(opts *marshalDisclosureOpts) {
+		opts.holderBinding = info
+	}
+}
+
+// MarshalWithDisclosure serializes the SD-JWT VC in the combined format with the selected disclosures only,
+// e.g. for a presentation. None of the disclosures is selected by default.
+func (vc *Credential) MarshalWithDisclosure(opts ...MarshalDisclosureOpt) (string, error) {
+	if vc.JWT == "" || vc.SDJWTHashAlg == "" {
+		return "", errors.New("credential is not an SD-JWT VC")
+	}
+
+	mOpts := &marshalDisclosureOpts{}
+
+	for _, opt := range opts {
+		opt(mOpts)
+	}
+
+	combinedFormat := vc.sdJWTCombinedFormat(vc.SDJWTDisclosures, "")
+
+	claims, err := holder.Parse(combinedFormat)
+	if err != nil {
+		return "", fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	var selected []string
+
+	for _, claim := range claims {
+		if mOpts.discloseAll || isClaimSelected(claim.Path, mOpts.claimPaths) {
+			selected = append(selected, claim.Path)
+		}
+	}
+
+	if len(vc.SDJWTDisclosures) == 0 && mOpts.holderBinding == nil {
+		return combinedFormat, nil
+	}
+
+	var holderOpts []holder.Option
+
+	if mOpts.holderBinding != nil {
+		holderOpts = append(holderOpts, holder.WithHolderBinding(mOpts.holderBinding))
+	}
+
+	disclosed, err := holder.DiscloseClaims(combinedFormat, selected, holderOpts...)
+	if err != nil {
+		return "", fmt.Errorf("disclose claims: %w", err)
+	}
+
+	if mOpts.holderBinding == nil {
+		// the disclosures are terminated by a separator when there is no Key Binding JWT
+		disclosed += common.DisclosureSeparator
+	}
+
+	return disclosed, nil
+}
+
+func isClaimSelected(path string, subjectPaths []string) bool {
+	for _, subjectPath := range subjectPaths {
+		selectedPath := sdJWTSubjectPathPrefix + subjectPath
+
+		if path == selectedPath || strings.HasPrefix(path, selectedPath+".") ||
+			strings.HasPrefix(path, selectedPath+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (vc *Credential) sdJWTCombinedFormat(disclosures []*common.DisclosureClaim, keyBindingJWT string) string {
+	combinedFormat := vc.JWT + common.DisclosureSeparator
+
+	for _, disclosure := range disclosures {
+		combinedFormat += disclosure.Disclosure + common.DisclosureSeparator
+	}
+
+	return combinedFormat + keyBindingJWT
+}
+
+func (vc *Credential) setSDJWT(combinedFormat string) error {
+	sdJWT := common.ParseSDJWT(combinedFormat)
+
+	disclosures, err := common.GetDisclosureClaims(sdJWT.Disclosures)
+	if err != nil {
+		return err
+	}
+
+	token, err := jwt.Parse(sdJWT.JWTSerialized, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return fmt.Errorf("parse JWT: %w", err)
+	}
+
+	var claims map[string]interface{}
+
+	err = token.DecodeClaims(&claims)
+	if err != nil {
+		return err
+	}
+
+	sdAlg, ok := claims[common.SDAlgorithmKey].(string)
+	if !ok {
+		return fmt.Errorf("%s must be present in SD-JWT", common.SDAlgorithmKey)
+	}
+
+	vc.JWT = sdJWT.JWTSerialized
+	vc.SDJWTHashAlg = sdAlg
+	vc.SDJWTDisclosures = disclosures
+	vc.SDHolderBinding = sdJWT.KeyBindingJWT
+
+	return nil
+}
+
+// isSDJWT checks if vcStr is an SD-JWT in the combined format (a JWS followed by disclosures).
+func isSDJWT(vcStr string) bool {
+	if !strings.Contains(vcStr, common.DisclosureSeparator) {
+		return false
+	}
+
+	return jwt.IsJWS(vcStr[:strings.Index(vcStr, common.DisclosureSeparator)])
+}
+
+// decodeCredSDJWT checks the SD-JWT signature and disclosures, and returns the VC with the disclosed claims.
+// The Key Binding JWT is checked when keyBinding is set, otherwise it's ignored.
+func decodeCredSDJWT(rawSDJWT string, checkProof bool, fetcher PublicKeyFetcher,
+	x5cVerifier *jwt.X5CVerifier, keyBinding *sdJWTKeyBindingOpts) ([]byte, error) {
+	sdJWT := common.ParseSDJWT(rawSDJWT)
+
+	return decodeCredJWT(sdJWT.JWTSerialized, func(string) (*JWTCredClaims, error) {
+		token, err := jwt.Parse(sdJWT.JWTSerialized,
+			jwt.WithSignatureVerifier(getJWSVerifier(checkProof, fetcher, x5cVerifier)))
+		if err != nil {
+			return nil, fmt.Errorf("parse JWT: %w", err)
+		}
+
+		if keyBinding != nil {
+			err = sdjwtverifier.VerifyKeyBinding(rawSDJWT, token, sdjwtverifier.WithKeyBindingRequired(true),
+				sdjwtverifier.WithExpectedNonceForKeyBinding(keyBinding.nonce),
+				sdjwtverifier.WithExpectedAudienceForKeyBinding(keyBinding.audience))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = common.VerifyDisclosuresInSDJWT(sdJWT.Disclosures, token)
+		if err != nil {
+			return nil, err
+		}
+
+		claims, err := common.GetDisclosedClaims(sdJWT.Disclosures, token)
+		if err != nil {
+			return nil, err
+		}
+
+		claimsBytes, err := json.Marshal(claims)
+		if err != nil {
+			return nil, err
+		}
+
+		var credClaims JWTCredClaims
+
+		err = json.Unmarshal(claimsBytes, &credClaims)
+		if err != nil {
+			return nil, err
+		}
+
+		return &credClaims, nil
+	})
+}
@@ -0,0 +1,181 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	afjwt "github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/holder"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/issuer"
+	sdjwtverifier "github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestJWTCredClaimsMarshalSDJWT(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	keyFetcher := createDIDKeyFetcher(t, signer.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")
+
+	vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	// the SD-JWT verifier rejects an expired SD-JWT
+	vc.Expired = nil
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	t.Run("issue and parse SD-JWT VC", func(t *testing.T) {
+		sdJWT, err := jwtClaims.MarshalSDJWT(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#"+keyID)
+		require.NoError(t, err)
+		require.True(t, strings.HasSuffix(sdJWT, common.DisclosureSeparator))
+
+		parsed, err := parseTestCredential(t, []byte(sdJWT), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+
+		require.Equal(t, "sha-256", parsed.SDJWTHashAlg)
+		require.Len(t, parsed.SDJWTDisclosures, 1)
+		require.Equal(t, "degree", parsed.SDJWTDisclosures[0].Name)
+		require.Equal(t, vc.Subject, parsed.Subject)
+		require.Equal(t, vc.Issuer, parsed.Issuer)
+		require.False(t, strings.Contains(parsed.JWT, common.DisclosureSeparator))
+
+		vcBytes, err := parsed.MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, `"`+sdJWT+`"`, string(vcBytes))
+
+		// the SD-JWT VC is an SD-JWT
+		claims, err := sdjwtverifier.Parse(sdJWT, sdjwtverifier.WithSignatureVerifier(&noVerifier{}))
+		require.NoError(t, err)
+		require.Contains(t, claims, "vc")
+	})
+
+	t.Run("issue structured SD-JWT VC and disclose claims", func(t *testing.T) {
+		sdJWT, err := jwtClaims.MarshalSDJWT(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#"+keyID,
+			issuer.WithStructuredClaims(true))
+		require.NoError(t, err)
+
+		parsed, err := parseTestCredential(t, []byte(sdJWT), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.Len(t, parsed.SDJWTDisclosures, 2)
+
+		disclosed, err := parsed.MarshalWithDisclosure(DiscloseClaims("degree.type"))
+		require.NoError(t, err)
+
+		limited, err := parseTestCredential(t, []byte(disclosed), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.Len(t, limited.SDJWTDisclosures, 1)
+
+		subject, ok := limited.Subject.([]Subject)
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"type": "BachelorDegree"}, subject[0].CustomFields["degree"])
+
+		disclosed, err = parsed.MarshalWithDisclosure(DiscloseClaims("degree"))
+		require.NoError(t, err)
+
+		limited, err = parseTestCredential(t, []byte(disclosed), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.Equal(t, parsed.Subject, limited.Subject)
+
+		disclosed, err = parsed.MarshalWithDisclosure(DiscloseAll())
+		require.NoError(t, err)
+		require.Equal(t, sdJWT, disclosed)
+
+		disclosed, err = parsed.MarshalWithDisclosure()
+		require.NoError(t, err)
+		require.Equal(t, parsed.JWT+common.DisclosureSeparator, disclosed)
+	})
+
+	t.Run("disclose with holder binding", func(t *testing.T) {
+		holderPubKey, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		holderJWK, err := jwksupport.JWKFromKey(holderPubKey)
+		require.NoError(t, err)
+
+		sdJWT, err := jwtClaims.MarshalSDJWT(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#"+keyID,
+			issuer.WithHolderPublicKey(holderJWK))
+		require.NoError(t, err)
+
+		parsed, err := parseTestCredential(t, []byte(sdJWT), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+
+		disclosed, err := parsed.MarshalWithDisclosure(DiscloseAll(),
+			DiscloseWithHolderBinding(&holder.BindingInfo{
+				Payload: holder.BindingPayload{Nonce: "nonce", Audience: "https://example.com/verifier"},
+				Signer:  afjwt.NewEd25519Signer(holderPrivKey),
+			}))
+		require.NoError(t, err)
+
+		presented, err := parseTestCredential(t, []byte(disclosed), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.NotEmpty(t, presented.SDHolderBinding)
+
+		_, err = sdjwtverifier.Parse(disclosed, sdjwtverifier.WithSignatureVerifier(&noVerifier{}),
+			sdjwtverifier.WithKeyBindingRequired(true),
+			sdjwtverifier.WithExpectedNonceForKeyBinding("nonce"),
+			sdjwtverifier.WithExpectedAudienceForKeyBinding("https://example.com/verifier"))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(disclosed), WithPublicKeyFetcher(keyFetcher),
+			WithSDJWTKeyBinding("nonce", "https://example.com/verifier"))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(disclosed), WithPublicKeyFetcher(keyFetcher),
+			WithSDJWTKeyBinding("other", "https://example.com/verifier"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding JWT nonce doesn't match the expected nonce")
+
+		_, err = parseTestCredential(t, []byte(disclosed), WithPublicKeyFetcher(keyFetcher),
+			WithSDJWTKeyBinding("nonce", ""))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected nonce and audience are required when key binding is required")
+
+		// the SD-JWT without its Key Binding JWT
+		_, err = parseTestCredential(t, []byte(disclosed[:strings.LastIndex(disclosed, common.DisclosureSeparator)+1]),
+			WithPublicKeyFetcher(keyFetcher), WithSDJWTKeyBinding("nonce", "https://example.com/verifier"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding JWT is required")
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		sdJWT, err := jwtClaims.MarshalSDJWT(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#"+keyID)
+		require.NoError(t, err)
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(sdJWT), WithPublicKeyFetcher(
+			createDIDKeyFetcher(t, otherSigner.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "SD-JWT decoding")
+	})
+
+	t.Run("error - disclosure not in SD-JWT", func(t *testing.T) {
+		sdJWT, err := jwtClaims.MarshalSDJWT(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#"+keyID)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t,
+			[]byte(sdJWT+"WyIzanFjYjY3ejl3a3MwOHp3aUs3RXlRIiwgImdpdmVuX25hbWUiLCAiSm9obiJd~"),
+			WithPublicKeyFetcher(keyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found in SD-JWT disclosure digests")
+	})
+
+	t.Run("error - not an SD-JWT VC", func(t *testing.T) {
+		_, err := vc.MarshalWithDisclosure(DiscloseAll())
+		require.EqualError(t, err, "credential is not an SD-JWT VC")
+	})
+}
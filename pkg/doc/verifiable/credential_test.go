@@ -148,7 +148,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = "https://www.w3.org/2018/credentials/v1"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -159,7 +159,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = "https://www.w3.org/2018/credentials/v2"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context: @context does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -171,7 +171,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context is required")
 	})
@@ -186,7 +186,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -200,7 +200,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context.0: @context.0 does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -216,7 +216,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context.0: @context.0 does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -230,7 +230,7 @@ func TestValidateVerCredContext(t *testing.T) {
 // 	raw.ID = "not valid credential ID URL"
 // 	bytes, err := json.Marshal(raw)
 // 	require.NoError(t, err)
-// 	err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+// 	err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 // 	require.Error(t, err)
 // 	require.Contains(t, err.Error(), "id: Does not match format 'uri'")
 // }
@@ -243,7 +243,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = []string{}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "Array must have at least 1 items")
 	})
@@ -255,7 +255,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = []string{"NotVerifiableCredential"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "Does not match pattern '^VerifiableCredential$")
 	})
@@ -267,7 +267,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = "VerifiableCredential"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -279,7 +279,7 @@ func TestValidateVerCredType(t *testing.T) {
 			raw.Type = []string{"UniversityDegreeCredentail", "VerifiableCredential"}
 			bytes, err := json.Marshal(raw)
 			require.NoError(t, err)
-			err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+			err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 			require.NoError(t, err)
 		})
 }
@@ -292,7 +292,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		raw.Subject = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSubject is required")
 	})
@@ -304,7 +304,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(singleCredentialSubject), &raw.Subject))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -315,7 +315,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(multipleCredentialSubjects), &raw.Subject))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -328,7 +328,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		raw.Subject = invalidNumericSubject
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSubject: Invalid type.")
 	})
@@ -342,7 +342,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		raw.Issuer = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer is required")
 	})
@@ -359,7 +359,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -370,7 +370,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(issuerAsObject), &raw.Issuer))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -386,7 +386,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer: Invalid type")
 	})
@@ -403,7 +403,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer: Does not match format 'uri'")
 	})
@@ -424,7 +424,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		bytes, err := json.Marshal(raw)
 
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer.id: Does not match format 'uri'")
 	})
@@ -438,7 +438,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		raw.Issued = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuanceDate is required")
 	})
@@ -451,7 +451,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuanceDate: Does not match format 'date-time'")
 	})
@@ -464,7 +464,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	}
 }
@@ -487,7 +487,7 @@ func TestValidateVerCredProof(t *testing.T) {
 		raw.Proof = proofBytes
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 	t.Run("test verifiable credential with empty proof", func(t *testing.T) {
@@ -497,7 +497,7 @@ func TestValidateVerCredProof(t *testing.T) {
 		raw.Proof = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 }
@@ -510,7 +510,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		raw.Expired = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -522,7 +522,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "expirationDate: Does not match format 'date-time'")
 	})
@@ -535,7 +535,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	}
 }
@@ -548,7 +548,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -559,7 +559,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{Type: "CredentialStatusList2017"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus: id is required")
 	})
@@ -571,7 +571,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{ID: "https://example.edu/status/24"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus: type is required")
 	})
@@ -583,7 +583,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{ID: "invalid URL", Type: "CredentialStatusList2017"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus.id: Does not match format 'uri'")
 	})
@@ -597,7 +597,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -608,7 +608,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{Type: "JsonSchemaValidator2018"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema: id is required")
 	})
@@ -620,7 +620,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{ID: "https://example.org/examples/degree.json"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema: type is required")
 	})
@@ -632,7 +632,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{ID: "invalid URL", Type: "JsonSchemaValidator2018"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema.id: Does not match format 'uri'")
 	})
@@ -646,7 +646,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		raw.RefreshService = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -657,7 +657,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{Type: "ManualRefreshService2018"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService: id is required")
 	})
@@ -669,7 +669,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{ID: "https://example.edu/refresh/3732"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService: type is required")
 	})
@@ -681,7 +681,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{ID: "invalid URL", Type: "ManualRefreshService2018"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService.id: Does not match format 'uri'")
 	})
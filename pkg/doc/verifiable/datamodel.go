@@ -0,0 +1,279 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	// ContextV1 is the base context of VC Data Model 1.1.
+	ContextV1 = baseContext
+
+	// ContextV2 is the base context of VC Data Model 2.0.
+	// https://www.w3.org/TR/vc-data-model-2.0/#base-context
+	ContextV2 = "https://www.w3.org/ns/credentials/v2"
+
+	// https://www.w3.org/TR/vc-json-schema/#jsonschema
+	jsonSchemaType = "JsonSchema"
+
+	// https://www.w3.org/TR/vc-json-schema/#jsonschemacredential
+	jsonSchemaCredentialType = "JsonSchemaCredential"
+
+	// https://www.w3.org/TR/vc-data-model-2.0/#enveloped-verifiable-credentials
+	envelopedVCType = "EnvelopedVerifiableCredential"
+
+	vcValidFromField  = "validFrom"
+	vcValidUntilField = "validUntil"
+
+	dataURLScheme    = "data:"
+	jwtVCMediaType   = "application/vc+jwt"
+	sdJWTVCMediaType = "application/vc+sd-jwt"
+)
+
+// DataModelVersion is a version of the W3C Verifiable Credentials Data Model.
+type DataModelVersion int
+
+const (
+	// DataModelV1 is VC Data Model 1.1 (base context https://www.w3.org/2018/credentials/v1).
+	DataModelV1 DataModelVersion = iota + 1
+
+	// DataModelV2 is VC Data Model 2.0 (base context https://www.w3.org/ns/credentials/v2).
+	DataModelV2
+)
+
+// String returns the name of the data model version.
+func (v DataModelVersion) String() string {
+	switch v {
+	case DataModelV1:
+		return "VC Data Model 1.1"
+	case DataModelV2:
+		return "VC Data Model 2.0"
+	default:
+		return fmt.Sprintf("unknown VC Data Model (%d)", int(v))
+	}
+}
+
+func (v DataModelVersion) baseContext() string {
+	if v == DataModelV2 {
+		return ContextV2
+	}
+
+	return ContextV1
+}
+
+// dataModelVersion defines the data model version by the base (first) context. VC Data Model 1.1 is assumed
+// if the VC Data Model 2.0 base context is not defined.
+func dataModelVersion(contexts []string) DataModelVersion {
+	if len(contexts) > 0 && contexts[0] == ContextV2 {
+		return DataModelV2
+	}
+
+	return DataModelV1
+}
+
+// validateBaseContexts checks that the contexts do not mix base contexts of the different data model versions,
+// that the VC Data Model 2.0 base context is the first one and, if required, that the data model version
+// is the expected one.
+func validateBaseContexts(contexts []string, expected DataModelVersion) error {
+	var hasV1, hasV2 bool
+
+	for i, c := range contexts {
+		switch c {
+		case ContextV1:
+			hasV1 = true
+		case ContextV2:
+			if i > 0 {
+				return fmt.Errorf("violated @context constraint: %s must be the first context", ContextV2)
+			}
+
+			hasV2 = true
+		}
+	}
+
+	if hasV1 && hasV2 {
+		return errors.New("violated @context constraint: base contexts of VC Data Model 1.1 and 2.0 are mixed")
+	}
+
+	if expected != 0 && dataModelVersion(contexts) != expected {
+		return fmt.Errorf("violated @context constraint: %s is expected", expected)
+	}
+
+	return nil
+}
+
+// DataModelVersion returns the data model version of the credential defined by its base context.
+func (vc *Credential) DataModelVersion() DataModelVersion {
+	return dataModelVersion(vc.Context)
+}
+
+// DataModelVersion returns the data model version of the presentation defined by its base context.
+func (vp *Presentation) DataModelVersion() DataModelVersion {
+	return dataModelVersion(vp.Context)
+}
+
+// WithDataModelVersion option restricts the data model version of the parsed credential.
+// By default, both VC Data Model 1.1 and 2.0 credentials are accepted.
+func WithDataModelVersion(version DataModelVersion) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.dataModelVersion = version
+	}
+}
+
+// WithPresDataModelVersion option restricts the data model version of the parsed presentation.
+// By default, both VC Data Model 1.1 and 2.0 presentations are accepted.
+func WithPresDataModelVersion(version DataModelVersion) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.dataModelVersion = version
+	}
+}
+
+// WithPresentationDataModel sets the base context of the presentation of the given data model version.
+func WithPresentationDataModel(version DataModelVersion) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		p.Context = []string{version.baseContext()}
+
+		return nil
+	}
+}
+
+// WithEnvelopedCredentials adds the credentials secured by an enveloping proof (JWT or SD-JWT)
+// into the presentation as VC Data Model 2.0 enveloped credentials.
+func WithEnvelopedCredentials(cs ...*Credential) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		for _, c := range cs {
+			enveloped, err := c.Envelope()
+			if err != nil {
+				return err
+			}
+
+			p.credentials = append(p.credentials, enveloped)
+		}
+
+		return nil
+	}
+}
+
+// Envelope returns VC Data Model 2.0 enveloped credential of the credential secured by an enveloping proof
+// (JWT or SD-JWT). The secured credential is put into "id" of the envelope as data URL.
+func (vc *Credential) Envelope() (map[string]interface{}, error) {
+	if vc.JWT == "" {
+		return nil, errors.New("credential is not secured by an enveloping proof")
+	}
+
+	mediaType, payload := jwtVCMediaType, vc.JWT
+	if vc.SDJWTHashAlg != "" {
+		mediaType, payload = sdJWTVCMediaType, vc.sdJWTCombinedFormat(vc.SDJWTDisclosures, vc.SDHolderBinding)
+	}
+
+	return map[string]interface{}{
+		"@context": ContextV2,
+		"id":       dataURLScheme + mediaType + "," + payload,
+		"type":     envelopedVCType,
+	}, nil
+}
+
+// envelopedCredential is VC Data Model 2.0 enveloped credential.
+type envelopedCredential struct {
+	ID   string      `json:"id,omitempty"`
+	Type interface{} `json:"type,omitempty"`
+}
+
+// decodeEnvelopedCredential returns the secured credential of the enveloped credential data,
+// or an empty string if the data is not an enveloped credential.
+func decodeEnvelopedCredential(data []byte) (string, error) {
+	var enveloped envelopedCredential
+
+	if err := json.Unmarshal(data, &enveloped); err != nil {
+		return "", nil //nolint:nilerr // not a JSON object, so not an enveloped credential
+	}
+
+	types, err := decodeType(enveloped.Type)
+	if err != nil || !contains(types, envelopedVCType) {
+		return "", nil //nolint:nilerr // not an enveloped credential
+	}
+
+	payload, err := decodeDataURL(enveloped.ID)
+	if err != nil {
+		return "", fmt.Errorf("decode enveloped credential: %w", err)
+	}
+
+	return payload, nil
+}
+
+func isEnvelopedCredential(cred map[string]interface{}) bool {
+	types, err := decodeType(cred["type"])
+
+	return err == nil && contains(types, envelopedVCType)
+}
+
+// decodeDataURL decodes the data of the data URL (RFC 2397).
+func decodeDataURL(dataURL string) (string, error) {
+	if !strings.HasPrefix(dataURL, dataURLScheme) {
+		return "", errors.New("id is not a data URL")
+	}
+
+	i := strings.Index(dataURL, ",")
+	if i < 0 {
+		return "", errors.New("invalid data URL")
+	}
+
+	header, data := dataURL[len(dataURLScheme):i], dataURL[i+1:]
+
+	if strings.HasSuffix(header, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return "", fmt.Errorf("invalid data URL: %w", err)
+		}
+
+		return string(decoded), nil
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid data URL: %w", err)
+	}
+
+	return decoded, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonSchemaV2 is the default JSON schema of VC Data Model 2.0 credential. It differs from the VC Data Model 1.1
+// one by the base context and the validity period fields. None of the validity period fields is required.
+func jsonSchemaV2() string {
+	template := strings.NewReplacer(
+		`"const": "`+ContextV1+`"`, `"const": "`+ContextV2+`"`,
+		`"issuanceDate": {`, `"validFrom": {`,
+		`"expirationDate": {`, `"validUntil": {`,
+	).Replace(DefaultSchemaTemplate)
+
+	return fmt.Sprintf(template,
+		fmt.Sprintf(",%q,%q,%q", schemaPropertyType, schemaPropertyCredentialSubject, schemaPropertyIssuer))
+}
+
+//nolint:gochecknoglobals
+var (
+	defaultSchemaLoaderV2 = gojsonschema.NewStringLoader(jsonSchemaV2())
+
+	basePresentationSchemaLoaderV2 = gojsonschema.NewStringLoader(
+		strings.ReplaceAll(basePresentationSchema, `"const": "`+ContextV1+`"`, `"const": "`+ContextV2+`"`))
+)
@@ -0,0 +1,355 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const v2TestCredential = `
+{
+  "@context": ["https://www.w3.org/ns/credentials/v2"],
+  "id": "http://university.example/credentials/3732",
+  "type": ["VerifiableCredential", "ExampleDegreeCredential"],
+  "issuer": {
+    "id": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+    "name": "Example University"
+  },
+  "validFrom": "2010-01-01T19:23:24Z",
+  "validUntil": "2030-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "ExampleBachelorDegree",
+      "name": "Bachelor of Science and Arts"
+    }
+  }
+}
+`
+
+func TestParseCredential_V2(t *testing.T) {
+	t.Run("parse and marshal VC Data Model 2.0 credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(v2TestCredential), WithJSONLDValidation())
+		require.NoError(t, err)
+
+		require.Equal(t, DataModelV2, vc.DataModelVersion())
+		require.Equal(t, time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC), vc.Issued.Time)
+		require.Equal(t, time.Date(2030, 1, 1, 19, 23, 24, 0, time.UTC), vc.Expired.Time)
+		require.Empty(t, vc.CustomFields)
+
+		vcMap := vcToMap(t, vc)
+		require.Equal(t, "2010-01-01T19:23:24Z", vcMap["validFrom"])
+		require.Equal(t, "2030-01-01T19:23:24Z", vcMap["validUntil"])
+		require.NotContains(t, vcMap, "issuanceDate")
+		require.NotContains(t, vcMap, "expirationDate")
+
+		vc2, err := parseTestCredential(t, vc.byteJSON(t), WithJSONLDValidation())
+		require.NoError(t, err)
+		require.Equal(t, vc, vc2)
+	})
+
+	t.Run("issue VC Data Model 2.0 credential", func(t *testing.T) {
+		vc := &Credential{
+			Context: []string{ContextV2},
+			Types:   []string{"VerifiableCredential"},
+			Issuer:  Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		}
+
+		vcMap := vcToMap(t, vc)
+		require.NotContains(t, vcMap, "validFrom")
+		require.NotContains(t, vcMap, "issuanceDate")
+
+		// validFrom is optional in VC Data Model 2.0
+		_, err := parseTestCredential(t, vc.byteJSON(t))
+		require.NoError(t, err)
+
+		vc.Issued = util.NewTime(time.Now())
+
+		vcMap = vcToMap(t, vc)
+		require.Contains(t, vcMap, "validFrom")
+		require.NotContains(t, vcMap, "issuanceDate")
+	})
+
+	t.Run("validity period fields of other data model are kept as custom fields", func(t *testing.T) {
+		vcMap := credentialMap(t, v2TestCredential)
+		vcMap["issuanceDate"] = "2010-01-01T19:23:24Z"
+
+		vc, err := parseTestCredential(t, mapToBytes(t, vcMap), WithDisabledProofCheck(), WithJSONLDValidation())
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC), vc.Issued.Time)
+		require.Contains(t, vc.CustomFields, "issuanceDate")
+		require.Equal(t, "2010-01-01T19:23:24Z", vcToMap(t, vc)["issuanceDate"])
+
+		v1Map := credentialMap(t, jwtTestCredential)
+		v1Map["validFrom"] = "2011-01-01T19:23:24Z"
+
+		vc, err = parseTestCredential(t, mapToBytes(t, v1Map), WithNoCustomSchemaCheck(), WithJSONLDValidation())
+		require.NoError(t, err)
+		require.Equal(t, DataModelV1, vc.DataModelVersion())
+		require.Equal(t, time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC), vc.Issued.Time)
+		require.Equal(t, "2011-01-01T19:23:24Z", vcToMap(t, vc)["validFrom"])
+	})
+
+	t.Run("restrict data model version", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(v2TestCredential), WithDataModelVersion(DataModelV2))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(v2TestCredential), WithDataModelVersion(DataModelV1))
+		require.EqualError(t, err, "violated @context constraint: VC Data Model 1.1 is expected")
+
+		_, err = parseTestCredential(t, []byte(jwtTestCredential), WithDataModelVersion(DataModelV2))
+		require.EqualError(t, err, "violated @context constraint: VC Data Model 2.0 is expected")
+	})
+
+	t.Run("invalid base contexts", func(t *testing.T) {
+		vcMap := credentialMap(t, v2TestCredential)
+		vcMap["@context"] = []interface{}{ContextV2, ContextV1}
+
+		_, err := parseTestCredential(t, mapToBytes(t, vcMap))
+		require.EqualError(t, err, "violated @context constraint: base contexts of VC Data Model 1.1 and 2.0 are mixed")
+
+		vcMap["@context"] = []interface{}{"https://www.w3.org/ns/credentials/examples/v2", ContextV2}
+
+		_, err = parseTestCredential(t, mapToBytes(t, vcMap))
+		require.EqualError(t, err, "violated @context constraint: "+
+			"https://www.w3.org/ns/credentials/v2 must be the first context")
+	})
+
+	t.Run("JSON schema validation of VC Data Model 2.0 credential", func(t *testing.T) {
+		vcMap := credentialMap(t, v2TestCredential)
+		delete(vcMap, "issuer")
+
+		_, err := parseTestCredential(t, mapToBytes(t, vcMap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "issuer is required")
+
+		vcMap = credentialMap(t, v2TestCredential)
+		vcMap["validFrom"] = "not a date"
+
+		_, err = parseTestCredential(t, mapToBytes(t, vcMap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal new credential")
+	})
+
+	t.Run("base context validation", func(t *testing.T) {
+		vcMap := credentialMap(t, v2TestCredential)
+		vcMap["@context"] = ContextV2
+		vcMap["type"] = "VerifiableCredential"
+
+		_, err := parseTestCredential(t, mapToBytes(t, vcMap), WithBaseContextValidation())
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, mapToBytes(t, vcMap), WithBaseContextExtendedValidation(nil, nil))
+		require.NoError(t, err)
+	})
+}
+
+func TestParseCredential_V2CredentialSchema(t *testing.T) {
+	const jsonSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "required": ["degree"]
+    }
+  },
+  "required": ["credentialSubject"]
+}`
+
+	schemaCredential := fmt.Sprintf(`{
+  "@context": ["https://www.w3.org/ns/credentials/v2"],
+  "type": ["VerifiableCredential", "JsonSchemaCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "credentialSubject": {
+    "id": "https://example.com/schemas/degree.json",
+    "type": "JsonSchema",
+    "jsonSchema": %s
+  }
+}`, jsonSchema)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schema.json":
+			_, _ = w.Write([]byte(jsonSchema)) //nolint:errcheck
+		case "/schema-credential.json":
+			_, _ = w.Write([]byte(schemaCredential)) //nolint:errcheck
+		case "/not-schema-credential.json":
+			_, _ = w.Write([]byte(v2TestCredential)) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	for _, schema := range []TypedID{
+		{ID: server.URL + "/schema.json", Type: "JsonSchema"},
+		{ID: server.URL + "/schema-credential.json", Type: "JsonSchemaCredential"},
+	} {
+		t.Run(schema.Type, func(t *testing.T) {
+			vcMap := credentialMap(t, v2TestCredential)
+			vcMap["credentialSchema"] = schema
+
+			_, err := parseTestCredential(t, mapToBytes(t, vcMap))
+			require.NoError(t, err)
+
+			delete(vcMap["credentialSubject"].(map[string]interface{}), "degree")
+
+			_, err = parseTestCredential(t, mapToBytes(t, vcMap))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "degree is required")
+		})
+	}
+
+	t.Run("not a JSON schema credential", func(t *testing.T) {
+		vcMap := credentialMap(t, v2TestCredential)
+		vcMap["credentialSchema"] = TypedID{
+			ID:   server.URL + "/not-schema-credential.json",
+			Type: "JsonSchemaCredential",
+		}
+
+		_, err := parseTestCredential(t, mapToBytes(t, vcMap))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential is not of JsonSchemaCredential type")
+	})
+}
+
+func TestCredential_Envelope(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	keyFetcher := createDIDKeyFetcher(t, signer.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")
+
+	vc, err := parseTestCredential(t, []byte(v2TestCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#keys-"+keyID)
+	require.NoError(t, err)
+
+	vcFromJWT, err := parseTestCredential(t, []byte(vcJWS), WithPublicKeyFetcher(keyFetcher))
+	require.NoError(t, err)
+	require.Equal(t, vc.Issued, vcFromJWT.Issued)
+	require.Equal(t, vc.Expired, vcFromJWT.Expired)
+	require.Equal(t, DataModelV2, vcFromJWT.DataModelVersion())
+
+	t.Run("enveloped credential", func(t *testing.T) {
+		enveloped, err := vcFromJWT.Envelope()
+		require.NoError(t, err)
+		require.Equal(t, "EnvelopedVerifiableCredential", enveloped["type"])
+		require.Equal(t, "data:application/vc+jwt,"+vcJWS, enveloped["id"])
+
+		parsed, err := parseTestCredential(t, mapToBytes(t, enveloped), WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+		require.Equal(t, vcJWS, parsed.JWT)
+		require.Equal(t, vc.Subject, parsed.Subject)
+
+		_, err = (&Credential{}).Envelope()
+		require.EqualError(t, err, "credential is not secured by an enveloping proof")
+	})
+
+	t.Run("invalid enveloped credential", func(t *testing.T) {
+		_, err := parseTestCredential(t, mapToBytes(t, map[string]interface{}{
+			"@context": ContextV2,
+			"id":       "urn:uuid:5b9d1d1a",
+			"type":     "EnvelopedVerifiableCredential",
+		}))
+		require.EqualError(t, err, "decode new credential: decode enveloped credential: id is not a data URL")
+
+		_, err = parseTestCredential(t, mapToBytes(t, map[string]interface{}{
+			"@context": ContextV2,
+			"id":       "data:application/vc,%7B%7D",
+			"type":     "EnvelopedVerifiableCredential",
+		}))
+		require.EqualError(t, err, "decode new credential: decode enveloped credential: "+
+			"neither JWT nor SD-JWT is enveloped")
+	})
+
+	t.Run("presentation with enveloped credential", func(t *testing.T) {
+		vp, err := NewPresentation(WithPresentationDataModel(DataModelV2), WithEnvelopedCredentials(vcFromJWT))
+		require.NoError(t, err)
+		require.Equal(t, DataModelV2, vp.DataModelVersion())
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+		require.Contains(t, string(vpBytes), `"@context":["https://www.w3.org/ns/credentials/v2"]`)
+
+		parsed, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(keyFetcher),
+			WithPresDataModelVersion(DataModelV2))
+		require.NoError(t, err)
+		require.Len(t, parsed.Credentials(), 1)
+
+		parsedVC, ok := parsed.Credentials()[0].(*Credential)
+		require.True(t, ok)
+		require.Equal(t, vcJWS, parsedVC.JWT)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(keyFetcher),
+			WithPresDataModelVersion(DataModelV1))
+		require.EqualError(t, err, "violated @context constraint: VC Data Model 1.1 is expected")
+
+		_, err = NewPresentation(WithEnvelopedCredentials(vc))
+		require.EqualError(t, err, "credential is not secured by an enveloping proof")
+	})
+}
+
+func TestDecodeDataURL(t *testing.T) {
+	data, err := decodeDataURL("data:application/vc+jwt;base64,ZXlK")
+	require.NoError(t, err)
+	require.Equal(t, "eyJ", data)
+
+	_, err = decodeDataURL("data:application/vc+jwt;base64,!")
+	require.Error(t, err)
+
+	_, err = decodeDataURL("data:application/vc+jwt")
+	require.EqualError(t, err, "invalid data URL")
+
+	_, err = decodeDataURL("data:,%zz")
+	require.Error(t, err)
+}
+
+func TestDataModelVersion_String(t *testing.T) {
+	require.Equal(t, "VC Data Model 1.1", DataModelV1.String())
+	require.Equal(t, "VC Data Model 2.0", DataModelV2.String())
+	require.True(t, strings.HasPrefix(DataModelVersion(0).String(), "unknown"))
+}
+
+func credentialMap(t *testing.T, vc string) map[string]interface{} {
+	t.Helper()
+
+	var vcMap map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(vc), &vcMap))
+
+	return vcMap
+}
+
+func mapToBytes(t *testing.T, m map[string]interface{}) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	return b
+}
+
+func vcToMap(t *testing.T, vc *Credential) map[string]interface{} {
+	t.Helper()
+
+	return credentialMap(t, vc.stringJSON(t))
+}
@@ -15,9 +15,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasd2023"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
@@ -29,6 +32,7 @@ const (
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
 	bbsBlsSignature2020         = "BbsBlsSignature2020"
 	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
+	dataIntegrityProof          = "DataIntegrityProof"
 )
 
 func getProofType(proofMap map[string]interface{}) (string, error) {
@@ -40,7 +44,7 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 	proofTypeStr := safeStringValue(proofType)
 	switch proofTypeStr {
 	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
-		bbsBlsSignature2020, bbsBlsSignatureProof2020, ed25519Signature2020:
+		bbsBlsSignature2020, bbsBlsSignatureProof2020, ed25519Signature2020, dataIntegrityProof:
 		return proofTypeStr, nil
 	default:
 		return "", fmt.Errorf("unsupported proof type: %s", proofType)
@@ -140,6 +144,13 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 
 				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
 					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+			case dataIntegrityProof:
+				diSuites, err := getDataIntegritySuites(proofs[i])
+				if err != nil {
+					return nil, err
+				}
+
+				ldpSuites = append(ldpSuites, diSuites...)
 			}
 		}
 	}
@@ -147,6 +158,28 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	return ldpSuites, nil
 }
 
+func getDataIntegritySuites(proof map[string]interface{}) ([]verifier.SignatureSuite, error) {
+	cryptosuite := safeStringValue(proof["cryptosuite"])
+
+	switch cryptosuite {
+	case eddsardfc2022.Cryptosuite:
+		return []verifier.SignatureSuite{
+			eddsardfc2022.New(suite.WithVerifier(eddsardfc2022.NewPublicKeyVerifier())),
+		}, nil
+	case ecdsardfc2019.Cryptosuite:
+		return []verifier.SignatureSuite{
+			ecdsardfc2019.New(suite.WithVerifier(ecdsardfc2019.NewPublicKeyVerifier())),
+			ecdsardfc2019.NewP384(suite.WithVerifier(ecdsardfc2019.NewP384PublicKeyVerifier())),
+		}, nil
+	case ecdsasd2023.Cryptosuite:
+		return []verifier.SignatureSuite{
+			ecdsasd2023.New(suite.WithVerifier(ecdsasd2023.NewPublicKeyVerifier())),
+		}, nil
+	default:
+		return nil, fmt.Errorf("check embedded proof: unsupported cryptosuite: %s", cryptosuite)
+	}
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
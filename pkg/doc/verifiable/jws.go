@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -67,16 +68,9 @@ func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer,
 	return token.Serialize(false)
 }
 
-func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, claims interface{}) error {
-	var verifier jose.SignatureVerifier
-
-	if checkProof {
-		verifier = jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
-	} else {
-		verifier = &noVerifier{}
-	}
-
-	jsonWebToken, err := jwt.Parse(rawJwt, jwt.WithSignatureVerifier(verifier))
+func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, x5cVerifier *jwt.X5CVerifier,
+	claims interface{}) error {
+	jsonWebToken, err := jwt.Parse(rawJwt, jwt.WithSignatureVerifier(getJWSVerifier(checkProof, fetcher, x5cVerifier)))
 	if err != nil {
 		return fmt.Errorf("parse JWT: %w", err)
 	}
@@ -88,3 +82,35 @@ func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, clai
 
 	return nil
 }
+
+func getJWSVerifier(checkProof bool, fetcher PublicKeyFetcher, x5cVerifier *jwt.X5CVerifier) jose.SignatureVerifier {
+	if !checkProof {
+		return &noVerifier{}
+	}
+
+	if x5cVerifier != nil {
+		return &x5cOrKeyIDVerifier{x5cVerifier: x5cVerifier, fetcher: fetcher}
+	}
+
+	return jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
+}
+
+// x5cOrKeyIDVerifier verifies JWS by the X.509 certificate chain of the 'x5c' JOSE header if it's present,
+// the leaf certificate of which must be bound to the 'iss' claim, otherwise the public key is fetched by
+// the 'kid' JOSE header.
+type x5cOrKeyIDVerifier struct {
+	x5cVerifier *jwt.X5CVerifier
+	fetcher     PublicKeyFetcher
+}
+
+func (v *x5cOrKeyIDVerifier) Verify(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
+	if _, ok := joseHeaders[jose.HeaderX509CertificateChain]; ok {
+		return v.x5cVerifier.Verify(joseHeaders, payload, signingInput, signature)
+	}
+
+	if v.fetcher == nil {
+		return errors.New("public key fetcher is not defined")
+	}
+
+	return jwt.NewVerifier(jwt.KeyResolverFunc(v.fetcher)).Verify(joseHeaders, payload, signingInput, signature)
+}
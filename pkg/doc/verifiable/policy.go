@@ -0,0 +1,381 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the checks of the verification policy.
+const (
+	// CheckCredential checks that the credential of the presentation can be decoded.
+	CheckCredential = "credential"
+	// CheckValidityPeriod checks that the credential is valid at the current time
+	// (issuanceDate/expirationDate of VC Data Model 1.1, validFrom/validUntil of VC Data Model 2.0).
+	CheckValidityPeriod = "validityPeriod"
+	// CheckTrustedIssuer checks that the issuer of the credential is trusted. The issuer of a credential without
+	// a proof, or with a proof which is not checked, is not trusted.
+	CheckTrustedIssuer = "trustedIssuer"
+	// CheckCredentialStatus checks the credentialStatus of the credential, e.g. that it is not revoked.
+	CheckCredentialStatus = "credentialStatus"
+	// CheckTermsOfUse checks the termsOfUse of the credential.
+	CheckTermsOfUse = "termsOfUse"
+	// CheckProofPurpose checks the proof purposes of the embedded proofs of the credential or presentation.
+	CheckProofPurpose = "proofPurpose"
+)
+
+// CheckResult is a result of the check of the verification policy.
+type CheckResult string
+
+const (
+	// CheckPassed is a result of the passed check.
+	CheckPassed CheckResult = "passed"
+	// CheckFailed is a result of the failed check.
+	CheckFailed CheckResult = "failed"
+	// CheckSkipped is a result of the check which is not applicable, e.g. when the credential
+	// has no credentialStatus or the policy does not require the check.
+	CheckSkipped CheckResult = "skipped"
+)
+
+// VerificationPolicy defines the checks of Verifiable Credentials and Presentations which are made on top of
+// the proof and data model validation. The zero value checks the validity period of the credentials only.
+type VerificationPolicy struct {
+	// Clock returns the current time, time.Now is used if not defined.
+	Clock func() time.Time
+	// ClockSkew is the allowed clock skew used in the validity period check.
+	ClockSkew time.Duration
+	// TrustedIssuers is a list of IDs of the trusted issuers. The issuer is not checked if the list is empty.
+	TrustedIssuers []string
+	// StatusChecker checks the credentialStatus of the credentials. The status is not checked if not defined.
+	StatusChecker CredentialStatusChecker
+	// TermsOfUseChecker checks the termsOfUse of the credentials. The terms are not checked if not defined.
+	TermsOfUseChecker func(vc *Credential) error
+	// CredentialProofPurposes is a list of the allowed proof purposes of the embedded proofs of the credentials,
+	// e.g. "assertionMethod". The proof purposes are not checked if the list is empty.
+	CredentialProofPurposes []string
+	// PresentationProofPurposes is a list of the allowed proof purposes of the embedded proofs of the presentations,
+	// e.g. "authentication". The proof purposes are not checked if the list is empty.
+	PresentationProofPurposes []string
+}
+
+// CheckReport is a report of a single check of the verification policy.
+type CheckReport struct {
+	// Check is the name of the check, e.g. CheckValidityPeriod.
+	Check string `json:"check"`
+	// Target is an ID of the checked credential or presentation, or its position in the presentation
+	// if ID is not defined.
+	Target string      `json:"target,omitempty"`
+	Result CheckResult `json:"result"`
+	Error  string      `json:"error,omitempty"`
+
+	err error
+}
+
+// VerificationReport is a report of the checks of the verification policy.
+type VerificationReport struct {
+	Checks []*CheckReport `json:"checks"`
+}
+
+// Passed returns true if none of the checks has failed.
+func (r *VerificationReport) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the failed checks.
+func (r *VerificationReport) Failed() []*CheckReport {
+	var failed []*CheckReport
+
+	for _, c := range r.Checks {
+		if c.Result == CheckFailed {
+			failed = append(failed, c)
+		}
+	}
+
+	return failed
+}
+
+func (r *VerificationReport) add(check, target string, err error) {
+	report := &CheckReport{Check: check, Target: target, Result: CheckPassed}
+
+	switch {
+	case errors.Is(err, errCheckSkipped):
+		report.Result = CheckSkipped
+	case err != nil:
+		report.Result = CheckFailed
+		report.Error = err.Error()
+		report.err = err
+	}
+
+	r.Checks = append(r.Checks, report)
+}
+
+// VerificationError is returned when the checks of the verification policy fail.
+type VerificationError struct {
+	Report *VerificationReport
+}
+
+func (e *VerificationError) Error() string {
+	failed := e.Report.Failed()
+	msgs := make([]string, len(failed))
+
+	for i, c := range failed {
+		msgs[i] = fmt.Sprintf("%s check of %s failed: %s", c.Check, c.Target, c.Error)
+	}
+
+	return "verification policy: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the error of the first failed check.
+func (e *VerificationError) Unwrap() error {
+	failed := e.Report.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return failed[0].err
+}
+
+// WithVerificationPolicy option to check the parsed credential with the given verification policy.
+// ParseCredential returns *VerificationError if any of the checks fails.
+func WithVerificationPolicy(policy *VerificationPolicy) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.verificationPolicy = policy
+	}
+}
+
+// WithPresVerificationPolicy option to check the parsed presentation and its credentials with the given
+// verification policy. The credentials of the presentation are parsed with the proof check, unless it's disabled
+// by WithPresDisabledProofCheck. ParsePresentation returns *VerificationError if any of the checks fails.
+func WithPresVerificationPolicy(policy *VerificationPolicy) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.verificationPolicy = policy
+	}
+}
+
+var (
+	errCheckSkipped      = errors.New("check is skipped")
+	errProofNotChecked   = errors.New("proof of the credential is not checked")
+	errCredentialNoProof = errors.New("credential has no proof")
+)
+
+// credentialDecoder returns the credential of the presentation and whether its proof was checked.
+type credentialDecoder func(cred interface{}) (*Credential, bool, error)
+
+// VerifyCredential checks the credential and returns the report of the checks.
+// The proof of the credential is expected to be checked when the credential was parsed.
+func (p *VerificationPolicy) VerifyCredential(vc *Credential) *VerificationReport {
+	return p.verifyCredentialReport(vc, true)
+}
+
+func (p *VerificationPolicy) verifyCredentialReport(vc *Credential, proofChecked bool) *VerificationReport {
+	report := &VerificationReport{}
+
+	target := vc.ID
+	if target == "" {
+		target = "credential"
+	}
+
+	p.verifyCredential(vc, target, proofChecked, report)
+
+	return report
+}
+
+// VerifyPresentation checks the presentation and its credentials and returns the report of the checks.
+// The decoded credentials of the presentation are expected to be parsed with the proof check. The credentials
+// which are not decoded are decoded without the proof check, so their issuers are not trusted.
+func (p *VerificationPolicy) VerifyPresentation(vp *Presentation) *VerificationReport {
+	return p.verifyPresentation(vp, decodeCredentialOfPresentation)
+}
+
+func (p *VerificationPolicy) verifyPresentation(vp *Presentation, decode credentialDecoder) *VerificationReport {
+	report := &VerificationReport{}
+
+	target := vp.ID
+	if target == "" {
+		target = "presentation"
+	}
+
+	report.add(CheckProofPurpose, target, checkProofPurposes(vp.Proofs, p.PresentationProofPurposes))
+
+	for i, cred := range vp.Credentials() {
+		target := "verifiableCredential[" + strconv.Itoa(i) + "]"
+
+		vc, proofChecked, err := decode(cred)
+		if err != nil {
+			report.add(CheckCredential, target, err)
+
+			continue
+		}
+
+		if vc.ID != "" {
+			target = vc.ID
+		}
+
+		p.verifyCredential(vc, target, proofChecked, report)
+	}
+
+	return report
+}
+
+func (p *VerificationPolicy) verifyCredential(vc *Credential, target string, proofChecked bool,
+	report *VerificationReport) {
+	report.add(CheckValidityPeriod, target, p.checkValidityPeriod(vc))
+	report.add(CheckTrustedIssuer, target, p.checkIssuer(vc, proofChecked))
+	report.add(CheckCredentialStatus, target, p.checkStatus(vc))
+	report.add(CheckTermsOfUse, target, p.checkTermsOfUse(vc))
+	report.add(CheckProofPurpose, target, checkProofPurposes(vc.Proofs, p.CredentialProofPurposes))
+}
+
+func (p *VerificationPolicy) now() time.Time {
+	if p.Clock != nil {
+		return p.Clock()
+	}
+
+	return time.Now()
+}
+
+func (p *VerificationPolicy) checkValidityPeriod(vc *Credential) error {
+	if vc.Issued == nil && vc.Expired == nil {
+		return errCheckSkipped
+	}
+
+	now := p.now()
+
+	if vc.Issued != nil && now.Add(p.ClockSkew).Before(vc.Issued.Time) {
+		return fmt.Errorf("credential is not valid before %s", vc.Issued.FormatToString())
+	}
+
+	if vc.Expired != nil && now.Add(-p.ClockSkew).After(vc.Expired.Time) {
+		return fmt.Errorf("credential has expired at %s", vc.Expired.FormatToString())
+	}
+
+	return nil
+}
+
+func (p *VerificationPolicy) checkIssuer(vc *Credential, proofChecked bool) error {
+	if len(p.TrustedIssuers) == 0 {
+		return errCheckSkipped
+	}
+
+	if !proofChecked {
+		return errProofNotChecked
+	}
+
+	if vc.JWT == "" && len(vc.Proofs) == 0 {
+		return errCredentialNoProof
+	}
+
+	if !contains(p.TrustedIssuers, vc.Issuer.ID) {
+		return fmt.Errorf("issuer %s is not trusted", vc.Issuer.ID)
+	}
+
+	return nil
+}
+
+func (p *VerificationPolicy) checkStatus(vc *Credential) error {
+	if p.StatusChecker == nil || vc.Status == nil {
+		return errCheckSkipped
+	}
+
+	return p.StatusChecker.CheckStatus(vc)
+}
+
+func (p *VerificationPolicy) checkTermsOfUse(vc *Credential) error {
+	if p.TermsOfUseChecker == nil || len(vc.TermsOfUse) == 0 {
+		return errCheckSkipped
+	}
+
+	return p.TermsOfUseChecker(vc)
+}
+
+func checkProofPurposes(proofs []Proof, allowed []string) error {
+	if len(allowed) == 0 || len(proofs) == 0 {
+		return errCheckSkipped
+	}
+
+	for _, proof := range proofs {
+		purpose, ok := proof["proofPurpose"].(string)
+		if !ok {
+			return errors.New("proof purpose is not defined")
+		}
+
+		if !contains(allowed, purpose) {
+			return fmt.Errorf("proof purpose %s is not allowed", purpose)
+		}
+	}
+
+	return nil
+}
+
+// decodeCredentialOfPresentation returns the credential of the presentation, decoding it without the proof check
+// if it's not decoded.
+func decodeCredentialOfPresentation(cred interface{}) (*Credential, bool, error) {
+	if vc, ok := cred.(*Credential); ok {
+		return vc, true, nil
+	}
+
+	vcBytes, err := json.Marshal(cred)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshal credential of presentation: %w", err)
+	}
+
+	vcDataDecoded, externalJWT, err := decodeRaw(vcBytes, &credentialOpts{disabledProofCheck: true})
+	if err != nil {
+		return nil, false, fmt.Errorf("decode credential of presentation: %w", err)
+	}
+
+	var raw rawCredential
+
+	err = json.Unmarshal(vcDataDecoded, &raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("unmarshal credential of presentation: %w", err)
+	}
+
+	vc, err := newCredential(&raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	vc.JWT = externalJWT
+
+	return vc, false, nil
+}
+
+// parseCredentialOfPresentationForPolicy returns the credential of the presentation, parsing it with the proof check
+// unless the check is disabled.
+func parseCredentialOfPresentationForPolicy(cred interface{}, opts *presentationOpts) (*Credential, bool, error) {
+	proofChecked := !opts.disabledProofCheck
+
+	// the credentials in string format are parsed when the presentation is decoded
+	if vc, ok := cred.(*Credential); ok {
+		return vc, proofChecked, nil
+	}
+
+	vcBytes, err := json.Marshal(cred)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshal credential of presentation: %w", err)
+	}
+
+	vc, err := parseCredentialOfPresentation(vcBytes, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return vc, proofChecked, nil
+}
+
+func reportToError(report *VerificationReport) error {
+	if !report.Passed() {
+		return &VerificationError{Report: report}
+	}
+
+	return nil
+}
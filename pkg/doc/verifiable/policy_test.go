@@ -0,0 +1,301 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+type mockPolicyStatusChecker struct {
+	err error
+}
+
+func (c *mockPolicyStatusChecker) CheckStatus(*Credential) error {
+	return c.err
+}
+
+func TestVerificationPolicy_VerifyCredential(t *testing.T) {
+	issued := time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC)
+	expired := time.Date(2020, 1, 1, 19, 23, 24, 0, time.UTC)
+
+	clock := func(t time.Time) func() time.Time {
+		return func() time.Time { return t }
+	}
+
+	vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	t.Run("default policy", func(t *testing.T) {
+		report := (&VerificationPolicy{Clock: clock(issued.AddDate(1, 0, 0))}).VerifyCredential(vc)
+		require.True(t, report.Passed())
+		require.Equal(t, []*CheckReport{
+			{Check: CheckValidityPeriod, Target: "credential", Result: CheckPassed},
+			{Check: CheckTrustedIssuer, Target: "credential", Result: CheckSkipped},
+			{Check: CheckCredentialStatus, Target: "credential", Result: CheckSkipped},
+			{Check: CheckTermsOfUse, Target: "credential", Result: CheckSkipped},
+			{Check: CheckProofPurpose, Target: "credential", Result: CheckSkipped},
+		}, report.Checks)
+	})
+
+	t.Run("validity period", func(t *testing.T) {
+		report := (&VerificationPolicy{}).VerifyCredential(vc)
+		require.False(t, report.Passed())
+		require.Len(t, report.Failed(), 1)
+		require.Equal(t, CheckValidityPeriod, report.Failed()[0].Check)
+		require.Equal(t, "credential has expired at 2020-01-01T19:23:24Z", report.Failed()[0].Error)
+
+		report = (&VerificationPolicy{Clock: clock(issued.Add(-time.Minute))}).VerifyCredential(vc)
+		require.Equal(t, "credential is not valid before 2010-01-01T19:23:24Z", report.Failed()[0].Error)
+
+		report = (&VerificationPolicy{
+			Clock:     clock(issued.Add(-time.Minute)),
+			ClockSkew: 2 * time.Minute,
+		}).VerifyCredential(vc)
+		require.True(t, report.Passed())
+
+		report = (&VerificationPolicy{
+			Clock:     clock(expired.Add(time.Minute)),
+			ClockSkew: 2 * time.Minute,
+		}).VerifyCredential(vc)
+		require.True(t, report.Passed())
+
+		report = (&VerificationPolicy{}).VerifyCredential(&Credential{})
+		require.True(t, report.Passed())
+		require.Equal(t, CheckSkipped, report.Checks[0].Result)
+	})
+
+	t.Run("trusted issuer", func(t *testing.T) {
+		vcWithProof := *vc
+		vcWithProof.Proofs = []Proof{{"type": "Ed25519Signature2018", "proofPurpose": "assertionMethod"}}
+
+		policy := &VerificationPolicy{
+			Clock:          clock(issued),
+			TrustedIssuers: []string{"did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		}
+
+		require.True(t, policy.VerifyCredential(&vcWithProof).Passed())
+
+		report := policy.VerifyCredential(vc)
+		require.False(t, report.Passed())
+		require.Equal(t, "credential has no proof", report.Failed()[0].Error)
+
+		policy.TrustedIssuers = []string{"did:example:other"}
+
+		report = policy.VerifyCredential(&vcWithProof)
+		require.False(t, report.Passed())
+		require.Equal(t, &CheckReport{
+			Check:  CheckTrustedIssuer,
+			Target: "credential",
+			Result: CheckFailed,
+			Error:  "issuer did:example:76e12ec712ebc6f1c221ebfeb1f is not trusted",
+			err:    report.Failed()[0].err,
+		}, report.Failed()[0])
+	})
+
+	t.Run("credential status and terms of use", func(t *testing.T) {
+		vcWithStatus := *vc
+		vcWithStatus.Status = &TypedID{ID: "https://example.com/status/1#1", Type: "BitstringStatusListEntry"}
+		vcWithStatus.TermsOfUse = []TypedID{{Type: "IssuerPolicy"}}
+
+		errRevoked := errors.New("revoked")
+
+		policy := &VerificationPolicy{
+			Clock:             clock(issued),
+			StatusChecker:     &mockPolicyStatusChecker{},
+			TermsOfUseChecker: func(*Credential) error { return nil },
+		}
+
+		report := policy.VerifyCredential(&vcWithStatus)
+		require.True(t, report.Passed())
+		require.Equal(t, CheckPassed, report.Checks[2].Result)
+		require.Equal(t, CheckPassed, report.Checks[3].Result)
+
+		policy.StatusChecker = &mockPolicyStatusChecker{err: errRevoked}
+		policy.TermsOfUseChecker = func(*Credential) error { return errors.New("terms are not accepted") }
+
+		report = policy.VerifyCredential(&vcWithStatus)
+		require.Len(t, report.Failed(), 2)
+
+		verr := &VerificationError{Report: report}
+		require.ErrorIs(t, verr, errRevoked)
+		require.EqualError(t, verr, "verification policy: credentialStatus check of credential failed: revoked; "+
+			"termsOfUse check of credential failed: terms are not accepted")
+	})
+
+	t.Run("proof purpose", func(t *testing.T) {
+		vcWithProof := *vc
+		vcWithProof.Proofs = []Proof{{"type": "Ed25519Signature2018", "proofPurpose": "assertionMethod"}}
+
+		policy := &VerificationPolicy{
+			Clock:                   clock(issued),
+			CredentialProofPurposes: []string{"assertionMethod"},
+		}
+
+		require.True(t, policy.VerifyCredential(&vcWithProof).Passed())
+
+		policy.CredentialProofPurposes = []string{"authentication"}
+
+		report := policy.VerifyCredential(&vcWithProof)
+		require.Equal(t, "proof purpose assertionMethod is not allowed", report.Failed()[0].Error)
+
+		vcWithProof.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+		report = policy.VerifyCredential(&vcWithProof)
+		require.Equal(t, "proof purpose is not defined", report.Failed()[0].Error)
+	})
+
+	t.Run("parse credential with verification policy", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(jwtTestCredential),
+			WithVerificationPolicy(&VerificationPolicy{Clock: clock(issued)}))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(jwtTestCredential), WithVerificationPolicy(&VerificationPolicy{}))
+		require.EqualError(t, err, "verification policy: validityPeriod check of credential failed: "+
+			"credential has expired at 2020-01-01T19:23:24Z")
+
+		var verr *VerificationError
+		require.True(t, errors.As(err, &verr))
+		require.Len(t, verr.Report.Checks, 5)
+	})
+}
+
+func TestVerificationPolicy_VerifyPresentation(t *testing.T) {
+	issued := time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC)
+
+	vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	var vcMap map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(jwtTestCredential), &vcMap))
+
+	vcMap["id"] = "http://example.edu/credentials/1872"
+
+	vp, err := NewPresentation(WithCredentials(vc))
+	require.NoError(t, err)
+
+	vp.ID = "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5"
+	vp.credentials = append(vp.credentials, vcMap, "not a credential")
+	vp.Proofs = []Proof{{"type": "Ed25519Signature2018", "proofPurpose": "authentication"}}
+
+	policy := &VerificationPolicy{
+		Clock:                     func() time.Time { return issued },
+		PresentationProofPurposes: []string{"authentication"},
+	}
+
+	report := policy.VerifyPresentation(vp)
+	require.Len(t, report.Checks, 12)
+	require.Equal(t, &CheckReport{Check: CheckProofPurpose, Target: vp.ID, Result: CheckPassed}, report.Checks[0])
+	require.Equal(t, "verifiableCredential[0]", report.Checks[1].Target)
+	require.Equal(t, "http://example.edu/credentials/1872", report.Checks[6].Target)
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	require.Equal(t, CheckCredential, failed[0].Check)
+	require.Equal(t, "verifiableCredential[2]", failed[0].Target)
+
+	vp.credentials = vp.credentials[:2]
+	vp.ID = ""
+	policy.PresentationProofPurposes = []string{"assertionMethod"}
+
+	report = policy.VerifyPresentation(vp)
+	require.Len(t, report.Failed(), 1)
+	require.Equal(t, "presentation", report.Failed()[0].Target)
+	require.Equal(t, "proof purpose authentication is not allowed", report.Failed()[0].Error)
+
+	t.Run("parse presentation with verification policy", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresDisabledProofCheck(),
+			WithPresVerificationPolicy(&VerificationPolicy{Clock: func() time.Time { return issued }}))
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresDisabledProofCheck(),
+			WithPresVerificationPolicy(&VerificationPolicy{}))
+		require.EqualError(t, err, "verification policy: validityPeriod check of verifiableCredential[0] failed: "+
+			"credential has expired at 2020-01-01T19:23:24Z")
+	})
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	keyFetcher := createDIDKeyFetcher(t, signer.PublicKeyBytes(), "76e12ec712ebc6f1c221ebfeb1f")
+	vcJWT := string(createEdDSAJWS(t, []byte(jwtTestCredential), signer, false))
+
+	trustingPolicy := &VerificationPolicy{
+		Clock:          func() time.Time { return issued },
+		TrustedIssuers: []string{"did:example:76e12ec712ebc6f1c221ebfeb1f"},
+	}
+
+	t.Run("JWT credential", func(t *testing.T) {
+		vp, err := NewPresentation()
+		require.NoError(t, err)
+
+		vp.credentials = []interface{}{vcJWT}
+
+		report := trustingPolicy.VerifyPresentation(vp)
+		require.Len(t, report.Failed(), 1)
+		require.Equal(t, CheckTrustedIssuer, report.Failed()[0].Check)
+		require.Equal(t, "proof of the credential is not checked", report.Failed()[0].Error)
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(keyFetcher),
+			WithPresVerificationPolicy(trustingPolicy))
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresDisabledProofCheck(),
+			WithPresVerificationPolicy(trustingPolicy))
+		require.EqualError(t, err, "verification policy: trustedIssuer check of verifiableCredential[0] failed: "+
+			"proof of the credential is not checked")
+	})
+
+	t.Run("forged credential", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(keyFetcher),
+			WithPresVerificationPolicy(trustingPolicy))
+		require.EqualError(t, err, "verification policy: trustedIssuer check of verifiableCredential[0] failed: "+
+			"credential has no proof")
+
+		forged := *vc
+		forged.Proofs = []Proof{{
+			"type":               "Ed25519Signature2018",
+			"created":            "2010-01-01T19:23:24Z",
+			"proofPurpose":       "assertionMethod",
+			"verificationMethod": "did:example:76e12ec712ebc6f1c221ebfeb1f#keys-1",
+			"jws":                "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..forged",
+		}}
+
+		vp, err = NewPresentation(WithCredentials(&forged))
+		require.NoError(t, err)
+
+		vpBytes, err = json.Marshal(vp)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(keyFetcher),
+			WithPresVerificationPolicy(trustingPolicy))
+
+		var verr *VerificationError
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, CheckCredential, verr.Report.Failed()[0].Check)
+	})
+}
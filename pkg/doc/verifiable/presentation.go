@@ -322,6 +322,9 @@ type rawPresentation struct {
 	JWT        string          `json:"jwt,omitempty"`
 	// All unmapped fields are put here.
 	CustomFields `json:"-"`
+
+	// claims of JWT the presentation is decoded from.
+	jwtClaims *JWTPresClaims
 }
 
 // MarshalJSON defines custom marshalling of rawPresentation to JSON.
@@ -351,11 +354,15 @@ func (rp *rawPresentation) UnmarshalJSON(data []byte) error {
 // presentationOpts holds options for the Verifiable Presentation decoding.
 type presentationOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
+	x5cVerifier        *jwt.X5CVerifier
 	disabledProofCheck bool
 	ldpSuites          []verifier.SignatureSuite
 	strictValidation   bool
 	requireVC          bool
 	requireProof       bool
+	dataModelVersion   DataModelVersion
+	verificationPolicy *VerificationPolicy
+	challengeVerifier  ChallengeVerifier
 
 	jsonldCredentialOpts
 }
@@ -371,6 +378,14 @@ func WithPresPublicKeyFetcher(fetcher PublicKeyFetcher) PresentationOpt {
 	}
 }
 
+// WithPresX5CVerifier sets the verifier of JWS signed by a key of the X.509 certificate chain from the 'x5c'
+// JOSE header. It's used for both Verifiable Presentation and its credentials decoded from JWS.
+func WithPresX5CVerifier(v *jwt.X5CVerifier) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.x5cVerifier = v
+	}
+}
+
 // WithPresEmbeddedSignatureSuites defines the suites which are used to check embedded linked data proof of VP.
 func WithPresEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) PresentationOpt {
 	return func(opts *presentationOpts) {
@@ -412,7 +427,7 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, err
 	}
 
-	err = validateVP(vpDataDecoded, vpOpts)
+	err = validateVP(vpDataDecoded, vpRaw, vpOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -428,6 +443,23 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 
 	p.JWT = vpJWT
 
+	if vpOpts.verificationPolicy != nil {
+		err = reportToError(vpOpts.verificationPolicy.verifyPresentation(p,
+			func(cred interface{}) (*Credential, bool, error) {
+				return parseCredentialOfPresentationForPolicy(cred, vpOpts)
+			}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vpOpts.challengeVerifier != nil {
+		err = checkPresentationChallenge(p, vpRaw, vpOpts.challengeVerifier)
+		if err != nil {
+			return nil, fmt.Errorf("check challenge of presentation: %w", err)
+		}
+	}
+
 	return p, nil
 }
 
@@ -490,21 +522,17 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 		// Check the case when VC is defined in string format (e.g. JWT).
 		// Decode credential and keep result of decoding.
 		if sCred, ok := cred.(string); ok {
-			bCred := []byte(sCred)
-
-			credOpts := []CredentialOpt{
-				WithPublicKeyFetcher(opts.publicKeyFetcher),
-				WithEmbeddedSignatureSuites(opts.ldpSuites...),
-				WithJSONLDDocumentLoader(opts.jsonldCredentialOpts.jsonldDocumentLoader),
-			}
+			return parseCredentialOfPresentation([]byte(sCred), opts)
+		}
 
-			if opts.disabledProofCheck {
-				credOpts = append(credOpts, WithDisabledProofCheck())
+		// Decode VC Data Model 2.0 enveloped credential.
+		if mCred, ok := cred.(map[string]interface{}); ok && isEnvelopedCredential(mCred) {
+			bCred, err := json.Marshal(mCred)
+			if err != nil {
+				return nil, err
 			}
 
-			vc, err := ParseCredential(bCred, credOpts...)
-
-			return vc, err
+			return parseCredentialOfPresentation(bCred, opts)
 		}
 
 		// return credential in a structure format as is
@@ -542,8 +570,35 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 	}
 }
 
-func validateVP(data []byte, opts *presentationOpts) error {
-	err := validateVPJSONSchema(data)
+func parseCredentialOfPresentation(vcBytes []byte, opts *presentationOpts) (*Credential, error) {
+	credOpts := []CredentialOpt{
+		WithPublicKeyFetcher(opts.publicKeyFetcher),
+		WithX5CVerifier(opts.x5cVerifier),
+		WithEmbeddedSignatureSuites(opts.ldpSuites...),
+		WithJSONLDDocumentLoader(opts.jsonldCredentialOpts.jsonldDocumentLoader),
+	}
+
+	if opts.disabledProofCheck {
+		credOpts = append(credOpts, WithDisabledProofCheck())
+	}
+
+	return ParseCredential(vcBytes, credOpts...)
+}
+
+func validateVP(data []byte, vpRaw *rawPresentation, opts *presentationOpts) error {
+	version := DataModelV1
+
+	// Missing or invalid @context is reported by JSON schema validation.
+	if contexts, _, err := decodeContext(vpRaw.Context); err == nil {
+		err = validateBaseContexts(contexts, opts.dataModelVersion)
+		if err != nil {
+			return err
+		}
+
+		version = dataModelVersion(contexts)
+	}
+
+	err := validateVPJSONSchema(data, version)
 	if err != nil {
 		return err
 	}
@@ -559,10 +614,15 @@ func validateVPJSONLD(vpBytes []byte, opts *presentationOpts) error {
 	)
 }
 
-func validateVPJSONSchema(data []byte) error {
+func validateVPJSONSchema(data []byte, version DataModelVersion) error {
 	loader := gojsonschema.NewStringLoader(string(data))
 
-	result, err := gojsonschema.Validate(basePresentationSchemaLoader, loader)
+	schemaLoader := basePresentationSchemaLoader
+	if version == DataModelV2 {
+		schemaLoader = basePresentationSchemaLoaderV2
+	}
+
+	result, err := gojsonschema.Validate(schemaLoader, loader)
 	if err != nil {
 		return fmt.Errorf("validation of verifiable credential: %w", err)
 	}
@@ -580,11 +640,12 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 	vpStr := string(unQuote(vpData))
 
 	if jwt.IsJWS(vpStr) {
-		if !vpOpts.disabledProofCheck && vpOpts.publicKeyFetcher == nil {
+		if !vpOpts.disabledProofCheck && vpOpts.publicKeyFetcher == nil && vpOpts.x5cVerifier == nil {
 			return nil, nil, "", errors.New("public key fetcher is not defined")
 		}
 
-		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, vpOpts.publicKeyFetcher)
+		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, vpOpts.publicKeyFetcher,
+			vpOpts.x5cVerifier)
 		if err != nil {
 			return nil, nil, "", fmt.Errorf("decoding of Verifiable Presentation from JWS: %w", err)
 		}
@@ -5,15 +5,20 @@ SPDX-License-Identifier: Apache-2.0
 
 package verifiable
 
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
 func (jpc *JWTPresClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
 	return marshalJWS(jpc, signatureAlg, signer, keyID)
 }
 
-func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) (*JWTPresClaims, error) {
+func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetcher,
+	x5cVerifier *jwt.X5CVerifier) (*JWTPresClaims, error) {
 	var claims JWTPresClaims
 
-	err := unmarshalJWS(vpJWT, checkProof, fetcher, &claims)
+	err := unmarshalJWS(vpJWT, checkProof, fetcher, x5cVerifier, &claims)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +26,9 @@ func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetc
 	return &claims, err
 }
 
-func decodeVPFromJWS(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) ([]byte, *rawPresentation, error) {
+func decodeVPFromJWS(vpJWT string, checkProof bool, fetcher PublicKeyFetcher,
+	x5cVerifier *jwt.X5CVerifier) ([]byte, *rawPresentation, error) {
 	return decodePresJWT(vpJWT, func(vpJWT string) (*JWTPresClaims, error) {
-		return unmarshalPresJWSClaims(vpJWT, checkProof, fetcher)
+		return unmarshalPresJWSClaims(vpJWT, checkProof, fetcher, x5cVerifier)
 	})
 }
@@ -27,7 +27,7 @@ func TestJWTPresClaims_MarshalJWS(t *testing.T) {
 
 	jws := createCredJWS(t, vp, signer)
 
-	_, rawVC, err := decodeVPFromJWS(jws, true, holderPublicKeyFetcher(signer.PublicKeyBytes()))
+	_, rawVC, err := decodeVPFromJWS(jws, true, holderPublicKeyFetcher(signer.PublicKeyBytes()), nil)
 
 	require.NoError(t, err)
 	require.Equal(t, vp.stringJSON(t), rawVC.stringJSON(t))
@@ -51,13 +51,13 @@ func TestUnmarshalPresJWSClaims(t *testing.T) {
 
 		jws := createCredJWS(t, vp, holderSigner)
 
-		claims, err := unmarshalPresJWSClaims(jws, true, testFetcher)
+		claims, err := unmarshalPresJWSClaims(jws, true, testFetcher, nil)
 		require.NoError(t, err)
 		require.Equal(t, vp.stringJSON(t), claims.Presentation.stringJSON(t))
 	})
 
 	t.Run("Invalid serialized JWS", func(t *testing.T) {
-		claims, err := unmarshalPresJWSClaims("invalid JWS", true, testFetcher)
+		claims, err := unmarshalPresJWSClaims("invalid JWS", true, testFetcher, nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "parse JWT")
 		require.Nil(t, claims)
@@ -80,7 +80,7 @@ func TestUnmarshalPresJWSClaims(t *testing.T) {
 		token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
 		require.NoError(t, err)
 
-		uc, err := unmarshalPresJWSClaims(token, true, testFetcher)
+		uc, err := unmarshalPresJWSClaims(token, true, testFetcher, nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "parse JWT")
 		require.Nil(t, uc)
@@ -101,7 +101,7 @@ func TestUnmarshalPresJWSClaims(t *testing.T) {
 				Type:  kms.RSARS256,
 				Value: issuerSigner.PublicKeyBytes(),
 			}, nil
-		})
+		}, nil)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "parse JWT")
 		require.Nil(t, uc)
@@ -17,6 +17,9 @@ type JWTPresClaims struct {
 	*jwt.Claims
 
 	Presentation *rawPresentation `json:"vp,omitempty"`
+
+	// Nonce is the challenge of the verifier the presentation is made for.
+	Nonce string `json:"nonce,omitempty"`
 }
 
 func (jpc *JWTPresClaims) refineFromJWTClaims() {
@@ -29,6 +32,8 @@ func (jpc *JWTPresClaims) refineFromJWTClaims() {
 	if jpc.ID != "" {
 		raw.ID = jpc.ID
 	}
+
+	raw.jwtClaims = jpc
 }
 
 // newJWTPresClaims creates JWT Claims of VP with an option to minimize certain fields put into "vp" claim.
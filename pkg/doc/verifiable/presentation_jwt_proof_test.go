@@ -8,6 +8,7 @@ package verifiable
 import (
 	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"testing"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -99,6 +101,45 @@ func TestParsePresentationFromJWS(t *testing.T) {
 	})
 }
 
+func TestParsePresentationFromJWS_X5C(t *testing.T) {
+	issuerSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	roots, x5c := createTestCertChain(t, ed25519.PublicKey(issuerSigner.PublicKeyBytes()),
+		"did:example:76e12ec712ebc6f1c221ebfeb1f")
+	vcJWS := createX5CJWS(t, []byte(jwtTestCredential), issuerSigner, x5c)
+
+	vp, err := NewPresentation(WithJWTCredentials(string(vcJWS)))
+	require.NoError(t, err)
+
+	vp.Holder = "did:example:fbfeb1f712ebc6f1c276e12ec21"
+
+	holderSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	jwtClaims, err := vp.JWTClaims([]string{}, true)
+	require.NoError(t, err)
+
+	vpJWS, err := jwtClaims.MarshalJWS(EdDSA, holderSigner, "did:123#holder-key")
+	require.NoError(t, err)
+
+	t.Run("VC is verified by x5c chain and VP by public key fetcher", func(t *testing.T) {
+		vpDecoded, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresPublicKeyFetcher(SingleKey(holderSigner.PublicKeyBytes(), kms.ED25519)),
+			WithPresX5CVerifier(jwt.NewX5CVerifier(roots)))
+		require.NoError(t, err)
+		require.Len(t, vpDecoded.Credentials(), 1)
+	})
+
+	t.Run("untrusted VC x5c chain", func(t *testing.T) {
+		_, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresPublicKeyFetcher(SingleKey(holderSigner.PublicKeyBytes(), kms.ED25519)),
+			WithPresX5CVerifier(jwt.NewX5CVerifier(x509.NewCertPool())))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify x5c certificate chain")
+	})
+}
+
 func TestParsePresentationFromJWS_EdDSA(t *testing.T) {
 	vpBytes := []byte(validPresentation)
 
@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"context"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// ContextRegistry is a vdr registry whose operations accept a context for cancellation, deadlines and
+// request scoped values.
+type ContextRegistry interface {
+	Registry
+	ResolveContext(ctx context.Context, did string, opts ...DIDMethodOption) (*did.DocResolution, error)
+	CreateContext(ctx context.Context, method string, did *did.Doc, opts ...DIDMethodOption) (*did.DocResolution, error)
+	UpdateContext(ctx context.Context, did *did.Doc, opts ...DIDMethodOption) error
+	DeactivateContext(ctx context.Context, did string, opts ...DIDMethodOption) error
+}
+
+// ContextVDR is a verifiable data registry whose operations accept a context for cancellation, deadlines and
+// request scoped values.
+type ContextVDR interface {
+	VDR
+	ReadContext(ctx context.Context, did string, opts ...DIDMethodOption) (*did.DocResolution, error)
+	CreateContext(ctx context.Context, did *did.Doc, opts ...DIDMethodOption) (*did.DocResolution, error)
+	UpdateContext(ctx context.Context, did *did.Doc, opts ...DIDMethodOption) error
+	DeactivateContext(ctx context.Context, did string, opts ...DIDMethodOption) error
+}
+
+// NewContextVDR returns v if it supports contexts, otherwise it wraps v in an adapter checking the context
+// before each operation. The adapter can't interrupt an operation in progress.
+func NewContextVDR(v VDR) ContextVDR {
+	if cv, ok := v.(ContextVDR); ok {
+		return cv
+	}
+
+	return &contextVDR{VDR: v}
+}
+
+type contextVDR struct {
+	VDR
+}
+
+func (v *contextVDR) ReadContext(ctx context.Context, didID string,
+	opts ...DIDMethodOption) (*did.DocResolution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return v.Read(didID, opts...)
+}
+
+func (v *contextVDR) CreateContext(ctx context.Context, doc *did.Doc,
+	opts ...DIDMethodOption) (*did.DocResolution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return v.Create(doc, opts...)
+}
+
+func (v *contextVDR) UpdateContext(ctx context.Context, doc *did.Doc, opts ...DIDMethodOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return v.Update(doc, opts...)
+}
+
+func (v *contextVDR) DeactivateContext(ctx context.Context, didID string, opts ...DIDMethodOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return v.Deactivate(didID, opts...)
+}
+
+// NewContextRegistry returns r if it supports contexts, otherwise it wraps r in an adapter checking the context
+// before each operation. The adapter can't interrupt an operation in progress.
+func NewContextRegistry(r Registry) ContextRegistry {
+	if cr, ok := r.(ContextRegistry); ok {
+		return cr
+	}
+
+	return &contextRegistry{Registry: r}
+}
+
+type contextRegistry struct {
+	Registry
+}
+
+func (r *contextRegistry) ResolveContext(ctx context.Context, didID string,
+	opts ...DIDMethodOption) (*did.DocResolution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.Resolve(didID, opts...)
+}
+
+func (r *contextRegistry) CreateContext(ctx context.Context, method string, doc *did.Doc,
+	opts ...DIDMethodOption) (*did.DocResolution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.Create(method, doc, opts...)
+}
+
+func (r *contextRegistry) UpdateContext(ctx context.Context, doc *did.Doc, opts ...DIDMethodOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.Update(doc, opts...)
+}
+
+func (r *contextRegistry) DeactivateContext(ctx context.Context, didID string, opts ...DIDMethodOption) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return r.Deactivate(didID, opts...)
+}
@@ -8,6 +8,7 @@ package aries
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/google/uuid"
@@ -82,6 +83,8 @@ type Aries struct {
 	mediaTypeProfiles          []string
 	inboundEnvelopeHandler     inbound.MessageHandler
 	didRotator                 middleware.DIDCommMessageMiddleware
+	outboxOpts                 []outbound.OutboxOption
+	outboxEnabled              bool
 }
 
 // Option configures the framework.
@@ -210,6 +213,17 @@ func WithTransportReturnRoute(transportReturnRoute string) Option {
 	}
 }
 
+// WithOutbox enables the persistent outbox of the outbound dispatcher. Messages that can't be delivered are stored
+// in the framework storage provider and retried with an exponential backoff, including after a restart.
+func WithOutbox(opts ...outbound.OutboxOption) Option {
+	return func(frameworkOpts *Aries) error {
+		frameworkOpts.outboxEnabled = true
+		frameworkOpts.outboxOpts = opts
+
+		return nil
+	}
+}
+
 // WithStoreProvider injects a storage provider to the Aries framework.
 func WithStoreProvider(prov storage.Provider) Option {
 	return func(opts *Aries) error {
@@ -412,6 +426,12 @@ func (a *Aries) Messenger() service.Messenger {
 
 // Close frees resources being maintained by the framework.
 func (a *Aries) Close() error {
+	if closer, ok := a.outboundDispatcher.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("failed to close the outbound dispatcher: %w", err)
+		}
+	}
+
 	if a.storeProvider != nil {
 		err := a.storeProvider.Close()
 		if err != nil {
@@ -580,7 +600,13 @@ func createOutboundDispatcher(frameworkOpts *Aries) error {
 		return fmt.Errorf("context creation failed: %w", err)
 	}
 
-	frameworkOpts.outboundDispatcher, err = outbound.NewOutbound(ctx)
+	var opts []outbound.Option
+
+	if frameworkOpts.outboxEnabled {
+		opts = append(opts, outbound.WithOutbox(frameworkOpts.outboxOpts...))
+	}
+
+	frameworkOpts.outboundDispatcher, err = outbound.NewOutbound(ctx, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to init outbound dispatcher: %w", err)
 	}
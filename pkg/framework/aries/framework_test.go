@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher/outbound"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
@@ -54,6 +55,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 //nolint:lll
@@ -635,6 +637,20 @@ func TestFramework(t *testing.T) {
 		require.Contains(t, err.Error(), "invalid transport return route option : "+transportReturnRoute)
 	})
 
+	t.Run("test new with outbox", func(t *testing.T) {
+		aries, err := New(WithOutbox(outbound.WithOutboxMaxAttempts(5)))
+		require.NoError(t, err)
+		require.True(t, aries.outboxEnabled)
+
+		dispatcher, ok := aries.outboundDispatcher.(*outbound.Dispatcher)
+		require.True(t, ok)
+
+		_, err = dispatcher.MessageStatus("unknown")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test message service provider option", func(t *testing.T) {
 		// custom message service provider
 		handler := msghandler.NewMockMsgServiceProvider()
@@ -0,0 +1,198 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package invitationurl encodes invitations into invitation URLs and decodes them back.
+//
+// Invitations are base64url encoded JSON in a query parameter of the URL, e.g. `oob` for out-of-band invitations
+// (RFC 0434), `_oob` for out-of-band/2.0 invitations and `c_i` for connection invitations (RFC 0160).
+// Shortened URLs, without the query parameter, are resolved by fetching them: the response either redirects to the
+// full invitation URL or holds the invitation JSON.
+package invitationurl
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// OOBParam is the query parameter of out-of-band invitations.
+	OOBParam = "oob"
+	// OOBV2Param is the query parameter of out-of-band/2.0 invitations.
+	OOBV2Param = "_oob"
+	// ConnectionParam is the query parameter of connection invitations.
+	ConnectionParam = "c_i"
+
+	maxRedirects    = 5
+	maxResponseSize = 64 * 1024
+	fetchTimeout    = 10 * time.Second
+)
+
+// HTTPClient fetches shortened invitation URLs.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// URLOption configures the decoding of invitation URLs.
+type URLOption func(opts *urlOpts)
+
+type urlOpts struct {
+	httpClient HTTPClient
+}
+
+// WithHTTPClient sets the HTTP client fetching shortened invitation URLs. By default, redirects to the full
+// invitation URL are followed up to 5 times.
+func WithHTTPClient(client HTTPClient) URLOption {
+	return func(opts *urlOpts) {
+		opts.httpClient = client
+	}
+}
+
+// Encode returns the URL with the invitation encoded in the query parameter.
+func Encode(baseURL, param string, invitation interface{}) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse base URL: %w", err)
+	}
+
+	invitationBytes, err := json.Marshal(invitation)
+	if err != nil {
+		return "", fmt.Errorf("marshal invitation: %w", err)
+	}
+
+	query := u.Query()
+	query.Set(param, base64.URLEncoding.EncodeToString(invitationBytes))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// Decode decodes the invitation of the invitation URL into target. A URL without the query parameter is a
+// shortened URL, it is fetched with the HTTP client, the default client does not follow redirects by itself and
+// times out.
+func Decode(invitationURL, param string, target interface{}, opts ...URLOption) error {
+	options := &urlOpts{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	httpClient := options.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: fetchTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+
+	for i := 0; i < maxRedirects; i++ {
+		u, err := url.Parse(invitationURL)
+		if err != nil {
+			return fmt.Errorf("parse invitation URL: %w", err)
+		}
+
+		if encoded := u.Query().Get(param); encoded != "" {
+			return decodeParam(encoded, target)
+		}
+
+		invitationURL, err = resolve(u, param, target, httpClient)
+		if err != nil || invitationURL == "" {
+			return err
+		}
+	}
+
+	return fmt.Errorf("invitation URL exceeds %d redirects", maxRedirects)
+}
+
+// resolve fetches the shortened URL, it returns the URL to follow or decodes the invitation into target.
+func resolve(u *url.URL, param string, target interface{}, httpClient HTTPClient) (string, error) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invitation URL has no '%s' query parameter", param)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil) // nolint: noctx
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch shortened invitation URL: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close() // nolint: errcheck
+	}()
+
+	if resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode < http.StatusBadRequest {
+		location, e := resp.Location()
+		if e != nil {
+			return "", fmt.Errorf("shortened invitation URL redirect: %w", e)
+		}
+
+		return location.String(), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch shortened invitation URL: unexpected status %d", resp.StatusCode)
+	}
+
+	// the HTTP client followed the redirects to the full invitation URL
+	if resp.Request != nil && resp.Request.URL.Query().Get(param) != "" {
+		return resp.Request.URL.String(), nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return "", errors.New("shortened invitation URL response is not an invitation")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return "", fmt.Errorf("read shortened invitation URL response: %w", err)
+	}
+
+	if len(body) > maxResponseSize {
+		return "", fmt.Errorf("shortened invitation URL response exceeds %d bytes", maxResponseSize)
+	}
+
+	err = json.Unmarshal(body, target)
+	if err != nil {
+		return "", fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	return "", nil
+}
+
+func decodeParam(encoded string, target interface{}) error {
+	// base64url is specified, padded or not, but some agents encode with the standard alphabet and do not escape
+	// its '+' which is then decoded as a space.
+	encoded = strings.TrimRight(encoded, "=")
+	encoded = strings.NewReplacer("+", "-", " ", "-", "/", "_").Replace(encoded)
+
+	invitationBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("decode invitation: %w", err)
+	}
+
+	err = json.Unmarshal(invitationBytes, target)
+	if err != nil {
+		return fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invitationurl
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type invitation struct {
+	ID    string `json:"@id"`
+	Label string `json:"label"`
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		invitationURL, err := Encode("https://example.com/path?lang=en", OOBParam, &invitation{ID: "id", Label: "label"})
+		require.NoError(t, err)
+		require.Contains(t, invitationURL, "https://example.com/path?lang=en&oob=")
+
+		decoded := &invitation{}
+		require.NoError(t, Decode(invitationURL, OOBParam, decoded))
+		require.Equal(t, &invitation{ID: "id", Label: "label"}, decoded)
+	})
+
+	t.Run("standard base64 encoding", func(t *testing.T) {
+		// `>>>` encodes to `Pj4+` in the standard alphabet
+		encoded := base64.StdEncoding.EncodeToString([]byte(`{"@id":"id","label":">>>"}`))
+		require.Contains(t, encoded, "+")
+
+		decoded := &invitation{}
+		require.NoError(t, Decode("https://example.com?c_i="+encoded, ConnectionParam, decoded))
+		require.Equal(t, ">>>", decoded.Label)
+	})
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		_, err := Encode(":", OOBParam, &invitation{})
+		require.ErrorContains(t, err, "parse base URL")
+	})
+
+	t.Run("invalid invitation", func(t *testing.T) {
+		require.ErrorContains(t, Decode("https://[::1", OOBParam, &invitation{}),
+			"parse invitation URL")
+		require.ErrorContains(t, Decode("https://example.com?oob=!", OOBParam, &invitation{}),
+			"decode invitation")
+		require.ErrorContains(t, Decode("https://example.com?oob=e30x", OOBParam, &invitation{}),
+			"unmarshal invitation")
+		require.EqualError(t, Decode("didcomm://invite", OOBParam, &invitation{}),
+			"invitation URL has no 'oob' query parameter")
+	})
+}
+
+func TestDecode_ShortenedURL(t *testing.T) {
+	fullURL, err := Encode("https://example.com", OOBV2Param, &invitation{ID: "id"})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redirect", func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, "/redirect-again", http.StatusFound)
+	})
+	mux.HandleFunc("/redirect-again", func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, fullURL, http.StatusFound)
+	})
+	mux.HandleFunc("/json", func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "application/json", req.Header.Get("Accept"))
+		rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = rw.Write([]byte(`{"@id":"id"}`)) // nolint: errcheck
+	})
+	mux.HandleFunc("/html", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "text/html")
+	})
+	mux.HandleFunc("/large", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(`{"label":"` + strings.Repeat("a", maxResponseSize) + `"}`)) // nolint: errcheck
+	})
+	mux.HandleFunc("/loop", func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, "/loop", http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("redirect", func(t *testing.T) {
+		decoded := &invitation{}
+		require.NoError(t, Decode(server.URL+"/redirect", OOBV2Param, decoded))
+		require.Equal(t, "id", decoded.ID)
+	})
+
+	t.Run("redirect followed by the HTTP client", func(t *testing.T) {
+		// the full invitation URL is not served, only the redirect to it is.
+		client := &http.Client{Transport: &redirectTransport{location: fullURL}}
+
+		decoded := &invitation{}
+		require.NoError(t, Decode("https://short.example.com/x", OOBV2Param, decoded, WithHTTPClient(client)))
+		require.Equal(t, "id", decoded.ID)
+	})
+
+	t.Run("invitation JSON", func(t *testing.T) {
+		decoded := &invitation{}
+		require.NoError(t, Decode(server.URL+"/json", OOBV2Param, decoded))
+		require.Equal(t, "id", decoded.ID)
+	})
+
+	t.Run("not an invitation", func(t *testing.T) {
+		require.EqualError(t, Decode(server.URL+"/html", OOBV2Param, &invitation{}),
+			"shortened invitation URL response is not an invitation")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		require.EqualError(t, Decode(server.URL+"/unknown", OOBV2Param, &invitation{}),
+			"fetch shortened invitation URL: unexpected status 404")
+	})
+
+	t.Run("response too large", func(t *testing.T) {
+		require.EqualError(t, Decode(server.URL+"/large", OOBV2Param, &invitation{}),
+			"shortened invitation URL response exceeds 65536 bytes")
+	})
+
+	t.Run("too many redirects", func(t *testing.T) {
+		require.EqualError(t, Decode(server.URL+"/loop", OOBV2Param, &invitation{}),
+			"invitation URL exceeds 5 redirects")
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		client := &http.Client{Transport: &redirectTransport{err: errors.New("network error")}}
+
+		err := Decode("https://short.example.com/x", OOBV2Param, &invitation{}, WithHTTPClient(client))
+		require.ErrorContains(t, err, "network error")
+	})
+}
+
+// redirectTransport serves a redirect to its location, and an empty page at the location.
+type redirectTransport struct {
+	location string
+	err      error
+}
+
+func (r *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+
+	if req.URL.String() != r.location {
+		resp.StatusCode = http.StatusFound
+		resp.Header.Set("Location", r.location)
+	}
+
+	return resp, nil
+}
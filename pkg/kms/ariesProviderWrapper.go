@@ -13,15 +13,23 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
-// AriesWrapperStoreName is the store name used when creating a KMS store using kms.NewAriesProviderWrapper.
-const AriesWrapperStoreName = "kmsdb"
+const (
+	// AriesWrapperStoreName is the store name used when creating a KMS store using kms.NewAriesProviderWrapper.
+	AriesWrapperStoreName = "kmsdb"
+	// AriesWrapperMetadataStoreName is the name of the store of key metadata records of a KMS store created using
+	// kms.NewAriesProviderWrapper.
+	AriesWrapperMetadataStoreName = "kmsdb_metadata"
+
+	keysetTag = "keyset"
+)
 
 type ariesProviderKMSStoreWrapper struct {
-	store storage.Store
+	provider storage.Provider
+	store    storage.Store
 }
 
 func (a *ariesProviderKMSStoreWrapper) Put(keysetID string, key []byte) error {
-	return a.store.Put(keysetID, key)
+	return a.store.Put(keysetID, key, storage.Tag{Name: keysetTag})
 }
 
 func (a *ariesProviderKMSStoreWrapper) Get(keysetID string) ([]byte, error) {
@@ -42,15 +50,66 @@ func (a *ariesProviderKMSStoreWrapper) Delete(keysetID string) error {
 	return a.store.Delete(keysetID)
 }
 
+// KeyIDs returns the IDs of the stored keysets. Keysets stored before they were tagged are not found.
+func (a *ariesProviderKMSStoreWrapper) KeyIDs() ([]string, error) {
+	iter, err := a.store.Query(keysetTag)
+	if err != nil {
+		return nil, err
+	}
+
+	defer storage.Close(iter, nil)
+
+	var kids []string
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return kids, nil
+		}
+
+		kid, err := iter.Key()
+		if err != nil {
+			return nil, err
+		}
+
+		kids = append(kids, kid)
+	}
+}
+
+// MetadataStore opens the store of key metadata records.
+func (a *ariesProviderKMSStoreWrapper) MetadataStore(tagNames ...string) (storage.Store, error) {
+	store, err := a.provider.OpenStore(AriesWrapperMetadataStoreName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.provider.SetStoreConfig(AriesWrapperMetadataStoreName, storage.StoreConfiguration{TagNames: tagNames})
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
 // NewAriesProviderWrapper returns an implementation of the kms.Store interface that wraps an
-// Aries provider implementation, allowing it to be used with a KMS.
+// Aries provider implementation, allowing it to be used with a KMS. The returned Store also implements KeyIDLister and
+// MetadataStoreProvider.
 func NewAriesProviderWrapper(provider storage.Provider) (Store, error) {
 	store, err := provider.OpenStore(AriesWrapperStoreName)
 	if err != nil {
 		return nil, err
 	}
 
-	storeWrapper := ariesProviderKMSStoreWrapper{store: store}
+	err = provider.SetStoreConfig(AriesWrapperStoreName, storage.StoreConfiguration{TagNames: []string{keysetTag}})
+	if err != nil {
+		return nil, err
+	}
+
+	storeWrapper := ariesProviderKMSStoreWrapper{provider: provider, store: store}
 
 	return &storeWrapper, nil
 }
@@ -0,0 +1,100 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"context"
+)
+
+// ContextKeyManager is a KeyManager whose operations accept a context for cancellation, deadlines and request scoped
+// values. Remote KeyManagers (eg webkms) use it to abort in-flight calls.
+type ContextKeyManager interface {
+	KeyManager
+	CreateContext(ctx context.Context, kt KeyType, opts ...KeyOpts) (string, interface{}, error)
+	GetContext(ctx context.Context, keyID string) (interface{}, error)
+	RotateContext(ctx context.Context, kt KeyType, keyID string, opts ...KeyOpts) (string, interface{}, error)
+	ExportPubKeyBytesContext(ctx context.Context, keyID string) ([]byte, KeyType, error)
+	CreateAndExportPubKeyBytesContext(ctx context.Context, kt KeyType, opts ...KeyOpts) (string, []byte, error)
+	PubKeyBytesToHandleContext(ctx context.Context, pubKey []byte, kt KeyType, opts ...KeyOpts) (interface{}, error)
+	ImportPrivateKeyContext(ctx context.Context, privKey interface{}, kt KeyType,
+		opts ...PrivateKeyOpts) (string, interface{}, error)
+}
+
+// NewContextKeyManager returns km if it supports contexts, otherwise it wraps km in an adapter checking the context
+// before each operation. The adapter can't interrupt an operation in progress.
+func NewContextKeyManager(km KeyManager) ContextKeyManager {
+	if ckm, ok := km.(ContextKeyManager); ok {
+		return ckm
+	}
+
+	return &contextKeyManager{KeyManager: km}
+}
+
+type contextKeyManager struct {
+	KeyManager
+}
+
+func (k *contextKeyManager) CreateContext(ctx context.Context, kt KeyType,
+	opts ...KeyOpts) (string, interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+
+	return k.Create(kt, opts...)
+}
+
+func (k *contextKeyManager) GetContext(ctx context.Context, keyID string) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return k.Get(keyID)
+}
+
+func (k *contextKeyManager) RotateContext(ctx context.Context, kt KeyType, keyID string,
+	opts ...KeyOpts) (string, interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+
+	return k.Rotate(kt, keyID, opts...)
+}
+
+func (k *contextKeyManager) ExportPubKeyBytesContext(ctx context.Context, keyID string) ([]byte, KeyType, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return k.ExportPubKeyBytes(keyID)
+}
+
+func (k *contextKeyManager) CreateAndExportPubKeyBytesContext(ctx context.Context, kt KeyType,
+	opts ...KeyOpts) (string, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+
+	return k.CreateAndExportPubKeyBytes(kt, opts...)
+}
+
+func (k *contextKeyManager) PubKeyBytesToHandleContext(ctx context.Context, pubKey []byte, kt KeyType,
+	opts ...KeyOpts) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return k.PubKeyBytesToHandle(pubKey, kt, opts...)
+}
+
+func (k *contextKeyManager) ImportPrivateKeyContext(ctx context.Context, privKey interface{}, kt KeyType,
+	opts ...PrivateKeyOpts) (string, interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+
+	return k.ImportPrivateKey(privKey, kt, opts...)
+}
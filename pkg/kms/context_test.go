@@ -0,0 +1,50 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+)
+
+func TestNewContextKeyManager(t *testing.T) {
+	t.Run("delegates to the key manager", func(t *testing.T) {
+		km := kms.NewContextKeyManager(&mockkms.KeyManager{CreateKeyID: "kid"})
+
+		kid, _, err := km.CreateContext(context.Background(), kms.ED25519Type)
+		require.NoError(t, err)
+		require.Equal(t, "kid", kid)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		km := kms.NewContextKeyManager(&mockkms.KeyManager{CreateKeyID: "kid"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := km.CreateContext(ctx, kms.ED25519Type)
+		require.True(t, errors.Is(err, context.Canceled))
+
+		_, err = km.GetContext(ctx, "kid")
+		require.True(t, errors.Is(err, context.Canceled))
+
+		_, _, err = km.ExportPubKeyBytesContext(ctx, "kid")
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("context key manager returned as is", func(t *testing.T) {
+		km := kms.NewContextKeyManager(&mockkms.KeyManager{})
+
+		require.Equal(t, km, kms.NewContextKeyManager(km))
+	})
+}
@@ -6,9 +6,14 @@ SPDX-License-Identifier: Apache-2.0
 
 package kms
 
+import "time"
+
 // keyOpts holds options for Create, Rotate and CreateAndExportPubKeyBytes.
 type keyOpts struct {
-	attrs []string
+	attrs     []string
+	purpose   string
+	labels    map[string]string
+	expiresAt *time.Time
 }
 
 // NewKeyOpt creates a new empty key option.
@@ -34,3 +39,48 @@ func WithAttrs(attrs []string) KeyOpts {
 		opts.attrs = attrs
 	}
 }
+
+// Purpose gets the purpose to be recorded in the metadata of the key.
+// Not to be used directly. It's intended for implementations of KeyManager interface
+// Use WithPurpose() option function below instead.
+func (pk *keyOpts) Purpose() string {
+	return pk.purpose
+}
+
+// Labels gets the labels to be recorded in the metadata of the key.
+// Not to be used directly. It's intended for implementations of KeyManager interface
+// Use WithLabels() option function below instead.
+func (pk *keyOpts) Labels() map[string]string {
+	return pk.labels
+}
+
+// ExpiresAt gets the expiry time to be recorded in the metadata of the key.
+// Not to be used directly. It's intended for implementations of KeyManager interface
+// Use WithExpiry() option function below instead.
+func (pk *keyOpts) ExpiresAt() *time.Time {
+	return pk.expiresAt
+}
+
+// WithPurpose option is for recording the purpose of the key (eg: "authentication", "didexchange") in its metadata.
+// It is ignored by KeyManagers not implementing KeyLifecycleManager.
+func WithPurpose(purpose string) KeyOpts {
+	return func(opts *keyOpts) {
+		opts.purpose = purpose
+	}
+}
+
+// WithLabels option is for recording free form labels of the key in its metadata.
+// It is ignored by KeyManagers not implementing KeyLifecycleManager.
+func WithLabels(labels map[string]string) KeyOpts {
+	return func(opts *keyOpts) {
+		opts.labels = labels
+	}
+}
+
+// WithExpiry option is for setting the time after which the key can no longer be used.
+// It is ignored by KeyManagers not implementing KeyLifecycleManager.
+func WithExpiry(expiresAt time.Time) KeyOpts {
+	return func(opts *keyOpts) {
+		opts.expiresAt = &expiresAt
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var (
+	// ErrKeyDisabled is returned by a KeyLifecycleManager when a disabled key is requested for use.
+	ErrKeyDisabled = errors.New("key is disabled")
+	// ErrKeyExpired is returned by a KeyLifecycleManager when an expired key is requested for use.
+	ErrKeyExpired = errors.New("key is expired")
+)
+
+// KeyMetadata holds the lifecycle metadata of a key managed by a KeyLifecycleManager.
+type KeyMetadata struct {
+	// KeyID of the key.
+	KeyID string `json:"keyID"`
+	// KeyType of the key.
+	KeyType KeyType `json:"keyType,omitempty"`
+	// Purpose of the key, eg: "authentication" or "didexchange".
+	Purpose string `json:"purpose,omitempty"`
+	// Labels are free form labels of the key.
+	Labels map[string]string `json:"labels,omitempty"`
+	// CreatedAt is the time the key was created or imported.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// ExpiresAt is the time after which the key can no longer be used.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Disabled is set when the key can no longer be used, eg: after it was rotated.
+	Disabled bool `json:"disabled,omitempty"`
+	// RotatedTo is the ID of the key which replaced this key by Rotate.
+	RotatedTo string `json:"rotatedTo,omitempty"`
+}
+
+// Expired checks if the key is expired at the given time.
+func (m *KeyMetadata) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && now.After(*m.ExpiresAt)
+}
+
+// KeyLifecycleManager is a KeyManager which also allows to enumerate and delete its keys and to manage their
+// metadata. KeyManagers implementing it refuse to return handles of disabled or expired keys with ErrKeyDisabled or
+// ErrKeyExpired, but their public keys can still be exported.
+type KeyLifecycleManager interface {
+	KeyManager
+	// List returns the metadata of keys matching the given filter options.
+	List(opts ...ListOpts) ([]*KeyMetadata, error)
+	// Delete deletes the key referenced by keyID along with its metadata.
+	Delete(keyID string) error
+	// GetMetadata returns the metadata of the key referenced by keyID.
+	GetMetadata(keyID string) (*KeyMetadata, error)
+	// UpdateMetadata replaces the purpose, labels, expiry and disabled state of the key referenced by keyID with the
+	// values of metadata. Other fields are managed by the KeyManager and are not changed.
+	UpdateMetadata(keyID string, metadata *KeyMetadata) error
+}
+
+// KeyIDLister is implemented by Stores which can enumerate their keysets. KeyLifecycleManagers use it to list all of
+// their keys, including the ones without metadata.
+type KeyIDLister interface {
+	// KeyIDs returns the IDs of the stored keysets.
+	KeyIDs() ([]string, error)
+}
+
+// MetadataStoreProvider is implemented by Stores which provide a store, apart from the keysets, for the per-key
+// metadata records of KeyLifecycleManagers.
+type MetadataStoreProvider interface {
+	// MetadataStore returns the store of key metadata records configured with the given tag names.
+	MetadataStore(tagNames ...string) (storage.Store, error)
+}
+
+// listOpts holds options for List.
+type listOpts struct {
+	purpose string
+	labels  map[string]string
+}
+
+// NewListOpt creates a new empty list option.
+// Not to be used directly. It's intended for implementations of KeyLifecycleManager interface
+// Use WithPurposeFilter() and WithLabelFilter() option functions below instead.
+func NewListOpt() *listOpts { // nolint
+	return &listOpts{}
+}
+
+// Purpose gets the purpose of keys to be listed.
+// Not to be used directly. It's intended for implementations of KeyLifecycleManager interface.
+func (lo *listOpts) Purpose() string {
+	return lo.purpose
+}
+
+// Labels gets the labels of keys to be listed.
+// Not to be used directly. It's intended for implementations of KeyLifecycleManager interface.
+func (lo *listOpts) Labels() map[string]string {
+	return lo.labels
+}
+
+// Match checks if the key metadata matches the list options.
+// Not to be used directly. It's intended for implementations of KeyLifecycleManager interface.
+func (lo *listOpts) Match(metadata *KeyMetadata) bool {
+	if lo.purpose != "" && lo.purpose != metadata.Purpose {
+		return false
+	}
+
+	for name, value := range lo.labels {
+		if v, ok := metadata.Labels[name]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListOpts are the list keys option.
+type ListOpts func(opts *listOpts)
+
+// WithPurposeFilter option is for listing only keys with the given purpose.
+func WithPurposeFilter(purpose string) ListOpts {
+	return func(opts *listOpts) {
+		opts.purpose = purpose
+	}
+}
+
+// WithLabelFilter option is for listing only keys having the given label value.
+// The option can be repeated to filter by several labels.
+func WithLabelFilter(name, value string) ListOpts {
+	return func(opts *listOpts) {
+		if opts.labels == nil {
+			opts.labels = make(map[string]string)
+		}
+
+		opts.labels[name] = value
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/google/tink/go/aead"
 	"github.com/google/tink/go/keyset"
@@ -22,6 +23,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -48,10 +50,15 @@ type LocalKMS struct {
 	secretLock        secretlock.Service
 	primaryKeyURI     string
 	store             kms.Store
+	metadataStore     storage.Store
 	primaryKeyEnvAEAD *aead.KMSEnvelopeAEAD
+	metadataLock      sync.Mutex
 }
 
-// New will create a new (local) KMS service.
+// New will create a new (local) KMS service. The metadata of its keys is kept in the metadata store of the provider's
+// kms.Store if it implements kms.MetadataStoreProvider. Otherwise a warning is logged and the metadata is kept in
+// memory, so the purpose, labels, expiry and state set by UpdateMetadata and Rotate are lost with the LocalKMS
+// (the keys themselves stay in the kms.Store).
 func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
 	secretLock := p.SecretLock()
 
@@ -63,8 +70,14 @@ func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
 	// create a KMSEnvelopeAEAD instance to wrap/unwrap keys managed by LocalKMS
 	keyEnvelopeAEAD := aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), kw)
 
+	metadataStore, err := openMetadataStore(p.StorageProvider())
+	if err != nil {
+		return nil, fmt.Errorf("new: failed to open key metadata store: %w", err)
+	}
+
 	return &LocalKMS{
 			store:             p.StorageProvider(),
+			metadataStore:     metadataStore,
 			secretLock:        secretLock,
 			primaryKeyURI:     primaryKeyURI,
 			primaryKeyEnvAEAD: keyEnvelopeAEAD,
@@ -106,19 +119,35 @@ func (l *LocalKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interfac
 		return "", nil, fmt.Errorf("create: failed to store keyset: %w", err)
 	}
 
+	err = l.saveNewKeyMetadata(keyID, kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to save key metadata: %w", err)
+	}
+
 	return keyID, kh, nil
 }
 
 // Get key handle for the given keyID
 // Returns:
 //   - handle instance (to private key)
-//   - error if failure
+//   - error if failure, wrapping kms.ErrKeyDisabled or kms.ErrKeyExpired if the key can no longer be used
 func (l *LocalKMS) Get(keyID string) (interface{}, error) {
-	return l.getKeySet(keyID)
+	kh, err := l.getKeySet(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.checkUsable(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return kh, nil
 }
 
 // Rotate a key referenced by keyID and return a new handle of a keyset including old key and
-// new key with type kt. It also returns the updated keyID as the first return value
+// new key with type kt. It also returns the updated keyID as the first return value.
+// The metadata of keyID is kept with the disabled state and the ID of the new key.
 // Returns:
 //   - new KeyID
 //   - handle instance (to private key)
@@ -156,6 +185,11 @@ func (l *LocalKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (st
 		return "", nil, fmt.Errorf("rotate: failed to store keySet: %w", err)
 	}
 
+	err = l.saveRotatedKeyMetadata(keyID, newID, kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: failed to save key metadata: %w", err)
+	}
+
 	return newID, updatedKH, nil
 }
 
@@ -321,16 +355,33 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...km
 //   - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
 func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	var (
+		keyID string
+		kh    interface{}
+		err   error
+	)
+
 	switch pk := privKey.(type) {
 	case *ecdsa.PrivateKey:
-		return l.importECDSAKey(pk, kt, opts...)
+		keyID, kh, err = l.importECDSAKey(pk, kt, opts...)
 	case ed25519.PrivateKey:
-		return l.importEd25519Key(pk, kt, opts...)
+		keyID, kh, err = l.importEd25519Key(pk, kt, opts...)
 	case *bbs12381g2pub.PrivateKey:
-		return l.importBBSKey(pk, kt, opts...)
+		keyID, kh, err = l.importBBSKey(pk, kt, opts...)
 	default:
 		return "", nil, fmt.Errorf("import private key does not support this key type or key is public")
 	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = l.saveNewKeyMetadata(keyID, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: failed to save key metadata: %w", err)
+	}
+
+	return keyID, kh, nil
 }
 
 func (l *LocalKMS) generateKID(kh *keyset.Handle, kt kms.KeyType) (string, error) {
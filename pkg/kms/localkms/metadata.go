@@ -0,0 +1,379 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// metadataIDPrefix prefixes the IDs of key metadata records, which are tagged with metadataTag.
+	metadataIDPrefix = "kms_metadata_"
+	metadataTag      = "kms_metadata"
+)
+
+var logger = log.New("aries-framework/kms/localkms")
+
+// LocalKMS keeps the lifecycle metadata of its keys.
+var _ kms.KeyLifecycleManager = (*LocalKMS)(nil)
+
+// openMetadataStore opens the key metadata store provided by the kms.Store. The metadata of keys of a kms.Store which
+// doesn't provide one is kept in memory.
+func openMetadataStore(store kms.Store) (storage.Store, error) {
+	if p, ok := store.(kms.MetadataStoreProvider); ok {
+		return p.MetadataStore(metadataTag)
+	}
+
+	logger.Warnf("kms store %T doesn't implement kms.MetadataStoreProvider, the key metadata is kept in memory"+
+		" and lost with the KMS", store)
+
+	return mem.NewProvider().OpenStore(kms.AriesWrapperMetadataStoreName)
+}
+
+// List returns the metadata of keys matching the given filter options. All keys of a kms.Store implementing
+// kms.KeyIDLister are listed, keys of other stores are only listed if they have metadata.
+func (l *LocalKMS) List(opts ...kms.ListOpts) ([]*kms.KeyMetadata, error) {
+	listOpts := kms.NewListOpt()
+
+	for _, opt := range opts {
+		opt(listOpts)
+	}
+
+	l.metadataLock.Lock()
+	defer l.metadataLock.Unlock()
+
+	recorded, err := l.queryMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	kids, err := l.keyIDs(recorded)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	var keys []*kms.KeyMetadata
+
+	for _, kid := range kids {
+		metadata, ok := recorded[kid]
+		if !ok {
+			metadata, err = l.keysetMetadata(kid)
+			if err != nil {
+				return nil, fmt.Errorf("list: %w", err)
+			}
+		}
+
+		if listOpts.Match(metadata) {
+			keys = append(keys, metadata)
+		}
+	}
+
+	return keys, nil
+}
+
+// Delete deletes the key referenced by keyID along with its metadata. The metadata is deleted first, so that it is
+// not left behind for a deleted key.
+func (l *LocalKMS) Delete(keyID string) error {
+	if keyID == "" {
+		return errors.New("delete: key ID is required")
+	}
+
+	l.metadataLock.Lock()
+	defer l.metadataLock.Unlock()
+
+	err := l.metadataStore.Delete(metadataID(keyID))
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("delete: failed to delete metadata of key '%s': %w", keyID, err)
+	}
+
+	err = l.store.Delete(keyID)
+	if err != nil {
+		return fmt.Errorf("delete: failed to delete key '%s': %w", keyID, err)
+	}
+
+	return nil
+}
+
+// GetMetadata returns the metadata of the key referenced by keyID. Keys created before key metadata was introduced
+// only have their ID and type set.
+func (l *LocalKMS) GetMetadata(keyID string) (*kms.KeyMetadata, error) {
+	l.metadataLock.Lock()
+	defer l.metadataLock.Unlock()
+
+	metadata, err := l.getMetadata(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("getMetadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// UpdateMetadata replaces the purpose, labels, expiry and disabled state of the key referenced by keyID.
+func (l *LocalKMS) UpdateMetadata(keyID string, metadata *kms.KeyMetadata) error {
+	if metadata == nil {
+		return errors.New("updateMetadata: metadata is required")
+	}
+
+	l.metadataLock.Lock()
+	defer l.metadataLock.Unlock()
+
+	current, err := l.getMetadata(keyID)
+	if err != nil {
+		return fmt.Errorf("updateMetadata: %w", err)
+	}
+
+	current.Purpose = metadata.Purpose
+	current.Labels = metadata.Labels
+	current.ExpiresAt = metadata.ExpiresAt
+	current.Disabled = metadata.Disabled
+
+	err = l.writeMetadata(current)
+	if err != nil {
+		return fmt.Errorf("updateMetadata: %w", err)
+	}
+
+	return nil
+}
+
+// checkUsable returns an error if the key referenced by keyID is disabled or expired.
+func (l *LocalKMS) checkUsable(keyID string) error {
+	metadata, err := l.readMetadata(keyID)
+	if err != nil {
+		return err
+	}
+
+	if metadata == nil {
+		return nil
+	}
+
+	if metadata.Disabled {
+		return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDisabled)
+	}
+
+	if metadata.Expired(time.Now()) {
+		return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyExpired)
+	}
+
+	return nil
+}
+
+// saveNewKeyMetadata records the metadata of a key which was just created or imported.
+func (l *LocalKMS) saveNewKeyMetadata(keyID string, kt kms.KeyType, opts ...kms.KeyOpts) error {
+	keyOpts := kms.NewKeyOpt()
+
+	for _, opt := range opts {
+		opt(keyOpts)
+	}
+
+	now := time.Now().UTC()
+
+	l.metadataLock.Lock()
+	defer l.metadataLock.Unlock()
+
+	return l.writeMetadata(&kms.KeyMetadata{
+		KeyID:     keyID,
+		KeyType:   kt,
+		Purpose:   keyOpts.Purpose(),
+		Labels:    keyOpts.Labels(),
+		CreatedAt: &now,
+		ExpiresAt: keyOpts.ExpiresAt(),
+	})
+}
+
+// saveRotatedKeyMetadata records the metadata of the key newID which replaced the key oldID by Rotate and disables
+// oldID. Purpose and labels of oldID are kept by newID unless set in opts.
+func (l *LocalKMS) saveRotatedKeyMetadata(oldID, newID string, kt kms.KeyType, opts ...kms.KeyOpts) error {
+	keyOpts := kms.NewKeyOpt()
+
+	for _, opt := range opts {
+		opt(keyOpts)
+	}
+
+	now := time.Now().UTC()
+
+	l.metadataLock.Lock()
+	defer l.metadataLock.Unlock()
+
+	old, err := l.readMetadata(oldID)
+	if err != nil {
+		return err
+	}
+
+	if old == nil {
+		old = &kms.KeyMetadata{KeyID: oldID}
+	}
+
+	metadata := &kms.KeyMetadata{
+		KeyID:     newID,
+		KeyType:   kt,
+		Purpose:   old.Purpose,
+		Labels:    old.Labels,
+		CreatedAt: &now,
+		ExpiresAt: keyOpts.ExpiresAt(),
+	}
+
+	if keyOpts.Purpose() != "" {
+		metadata.Purpose = keyOpts.Purpose()
+	}
+
+	if keyOpts.Labels() != nil {
+		metadata.Labels = keyOpts.Labels()
+	}
+
+	err = l.writeMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	if oldID == newID {
+		return nil
+	}
+
+	old.Disabled = true
+	old.RotatedTo = newID
+
+	return l.writeMetadata(old)
+}
+
+// getMetadata returns the recorded metadata of the key or builds it for keys without recorded metadata.
+func (l *LocalKMS) getMetadata(keyID string) (*kms.KeyMetadata, error) {
+	metadata, err := l.readMetadata(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata != nil {
+		return metadata, nil
+	}
+
+	return l.keysetMetadata(keyID)
+}
+
+// keysetMetadata builds the metadata of a key without metadata record, the key type is read from its keyset.
+func (l *LocalKMS) keysetMetadata(keyID string) (*kms.KeyMetadata, error) {
+	kh, err := l.getKeySet(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key '%s': %w", keyID, err)
+	}
+
+	metadata := &kms.KeyMetadata{KeyID: keyID}
+
+	// symmetric keys have no public key to read the type from.
+	_, kt, err := l.exportPubKeyBytes(kh)
+	if err == nil {
+		metadata.KeyType = kt
+	}
+
+	return metadata, nil
+}
+
+// keyIDs returns the IDs of the keysets of the kms.Store, or the IDs of the keys with recorded metadata if it can't
+// enumerate its keysets.
+func (l *LocalKMS) keyIDs(recorded map[string]*kms.KeyMetadata) ([]string, error) {
+	var kids []string
+
+	if lister, ok := l.store.(kms.KeyIDLister); ok {
+		var err error
+
+		kids, err = lister.KeyIDs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list keys: %w", err)
+		}
+	} else {
+		for kid := range recorded {
+			kids = append(kids, kid)
+		}
+	}
+
+	sort.Strings(kids)
+
+	return kids, nil
+}
+
+func (l *LocalKMS) readMetadata(keyID string) (*kms.KeyMetadata, error) {
+	metadataBytes, err := l.metadataStore.Get(metadataID(keyID))
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read metadata of key '%s': %w", keyID, err)
+	}
+
+	metadata := &kms.KeyMetadata{}
+
+	err = json.Unmarshal(metadataBytes, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata of key '%s': %w", keyID, err)
+	}
+
+	return metadata, nil
+}
+
+func (l *LocalKMS) writeMetadata(metadata *kms.KeyMetadata) error {
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata of key '%s': %w", metadata.KeyID, err)
+	}
+
+	err = l.metadataStore.Put(metadataID(metadata.KeyID), metadataBytes, storage.Tag{Name: metadataTag})
+	if err != nil {
+		return fmt.Errorf("failed to write metadata of key '%s': %w", metadata.KeyID, err)
+	}
+
+	return nil
+}
+
+// queryMetadata returns the recorded metadata of keys by key ID.
+func (l *LocalKMS) queryMetadata() (map[string]*kms.KeyMetadata, error) {
+	iter, err := l.metadataStore.Query(metadataTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query key metadata: %w", err)
+	}
+
+	defer storage.Close(iter, nil)
+
+	recorded := make(map[string]*kms.KeyMetadata)
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key metadata: %w", err)
+		}
+
+		if !ok {
+			return recorded, nil
+		}
+
+		metadataBytes, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key metadata: %w", err)
+		}
+
+		metadata := &kms.KeyMetadata{}
+
+		err = json.Unmarshal(metadataBytes, metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal key metadata: %w", err)
+		}
+
+		recorded[metadata.KeyID] = metadata
+	}
+}
+
+func metadataID(keyID string) string {
+	return metadataIDPrefix + keyID
+}
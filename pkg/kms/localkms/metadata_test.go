@@ -0,0 +1,289 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestLocalKMS_KeyMetadata(t *testing.T) {
+	newKMS := func(t *testing.T, store kms.Store) *LocalKMS {
+		t.Helper()
+
+		kmsService, err := New(testMasterKeyURI, &mockProvider{
+			storage:    store,
+			secretLock: &noop.NoLock{},
+		})
+		require.NoError(t, err)
+
+		return kmsService
+	}
+
+	newStore := func(t *testing.T) kms.Store {
+		t.Helper()
+
+		store, err := kms.NewAriesProviderWrapper(mem.NewProvider())
+		require.NoError(t, err)
+
+		return store
+	}
+
+	t.Run("create, list, get and update metadata", func(t *testing.T) {
+		kmsService := newKMS(t, newStore(t))
+
+		kid1, _, err := kmsService.Create(kms.ED25519Type, kms.WithPurpose("signing"),
+			kms.WithLabels(map[string]string{"env": "test"}))
+		require.NoError(t, err)
+
+		kid2, _, err := kmsService.Create(kms.AES256GCMType, kms.WithPurpose("encryption"))
+		require.NoError(t, err)
+
+		keys, err := kmsService.List()
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		keys, err = kmsService.List(kms.WithPurposeFilter("signing"))
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, kid1, keys[0].KeyID)
+		require.Equal(t, kms.ED25519Type, keys[0].KeyType)
+		require.NotNil(t, keys[0].CreatedAt)
+
+		keys, err = kmsService.List(kms.WithLabelFilter("env", "prod"))
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		metadata, err := kmsService.GetMetadata(kid2)
+		require.NoError(t, err)
+		require.Equal(t, "encryption", metadata.Purpose)
+		require.Empty(t, metadata.Labels)
+
+		metadata.Labels = map[string]string{"env": "prod"}
+
+		require.NoError(t, kmsService.UpdateMetadata(kid2, metadata))
+
+		keys, err = kmsService.List(kms.WithLabelFilter("env", "prod"))
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, kid2, keys[0].KeyID)
+
+		err = kmsService.UpdateMetadata(kid2, nil)
+		require.EqualError(t, err, "updateMetadata: metadata is required")
+	})
+
+	t.Run("disabled and expired keys can't be used", func(t *testing.T) {
+		kmsService := newKMS(t, newStore(t))
+
+		expired := time.Now().Add(-time.Minute)
+
+		kid, _, err := kmsService.Create(kms.ED25519Type, kms.WithExpiry(expired))
+		require.NoError(t, err)
+
+		_, err = kmsService.Get(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyExpired))
+
+		// the public key stays available to verify existing signatures.
+		_, _, err = kmsService.ExportPubKeyBytes(kid)
+		require.NoError(t, err)
+
+		metadata, err := kmsService.GetMetadata(kid)
+		require.NoError(t, err)
+
+		metadata.ExpiresAt = nil
+		metadata.Disabled = true
+
+		require.NoError(t, kmsService.UpdateMetadata(kid, metadata))
+
+		_, err = kmsService.Get(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyDisabled))
+
+		metadata.Disabled = false
+
+		require.NoError(t, kmsService.UpdateMetadata(kid, metadata))
+
+		_, err = kmsService.Get(kid)
+		require.NoError(t, err)
+	})
+
+	t.Run("rotate disables the old key", func(t *testing.T) {
+		kmsService := newKMS(t, newStore(t))
+
+		kid, _, err := kmsService.Create(kms.ED25519Type, kms.WithPurpose("signing"))
+		require.NoError(t, err)
+
+		newKID, _, err := kmsService.Rotate(kms.ED25519Type, kid)
+		require.NoError(t, err)
+
+		oldMetadata, err := kmsService.GetMetadata(kid)
+		require.NoError(t, err)
+		require.True(t, oldMetadata.Disabled)
+		require.Equal(t, newKID, oldMetadata.RotatedTo)
+
+		newMetadata, err := kmsService.GetMetadata(newKID)
+		require.NoError(t, err)
+		require.False(t, newMetadata.Disabled)
+		require.Equal(t, "signing", newMetadata.Purpose)
+
+		// the old key is replaced by the new key in the store.
+		_, err = kmsService.Get(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+	})
+
+	t.Run("delete key", func(t *testing.T) {
+		kmsService := newKMS(t, newStore(t))
+
+		kid, _, err := kmsService.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		require.NoError(t, kmsService.Delete(kid))
+
+		_, err = kmsService.Get(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		keys, err := kmsService.List()
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		_, err = kmsService.GetMetadata(kid)
+		require.Error(t, err)
+
+		require.EqualError(t, kmsService.Delete(""), "delete: key ID is required")
+	})
+
+	t.Run("keys stored without metadata", func(t *testing.T) {
+		kmsService := newKMS(t, newStore(t))
+
+		kid, _, err := kmsService.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		require.NoError(t, kmsService.metadataStore.Delete(metadataID(kid)))
+
+		metadata, err := kmsService.GetMetadata(kid)
+		require.NoError(t, err)
+		require.Equal(t, &kms.KeyMetadata{KeyID: kid, KeyType: kms.ED25519Type}, metadata)
+
+		keys, err := kmsService.List()
+		require.NoError(t, err)
+		require.Equal(t, []*kms.KeyMetadata{{KeyID: kid, KeyType: kms.ED25519Type}}, keys)
+
+		keys, err = kmsService.List(kms.WithPurposeFilter("signing"))
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("store which can't list its keys", func(t *testing.T) {
+		kmsService := newKMS(t, newInMemoryKMSStore())
+
+		kid, _, err := kmsService.Create(kms.ED25519Type, kms.WithPurpose("signing"))
+		require.NoError(t, err)
+
+		_, _, err = kmsService.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		keys, err := kmsService.List(kms.WithPurposeFilter("signing"))
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, kid, keys[0].KeyID)
+
+		keys, err = kmsService.List()
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		require.NoError(t, kmsService.Delete(kid))
+
+		keys, err = kmsService.List()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+	})
+
+	t.Run("metadata is deleted before the key", func(t *testing.T) {
+		metadataStore := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+		store := &listingKMSStore{inMemoryKMSStore: newInMemoryKMSStore(), metadata: metadataStore}
+		kmsService := newKMS(t, store)
+
+		kid, _, err := kmsService.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		metadataStore.ErrDelete = errors.New("delete error")
+
+		err = kmsService.Delete(kid)
+		require.EqualError(t, err, "delete: failed to delete metadata of key '"+kid+"': delete error")
+
+		_, err = kmsService.Get(kid)
+		require.NoError(t, err)
+	})
+
+	t.Run("store failures", func(t *testing.T) {
+		metadataStore := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+		store := &listingKMSStore{inMemoryKMSStore: newInMemoryKMSStore(), metadata: metadataStore}
+		kmsService := newKMS(t, store)
+
+		metadataStore.ErrQuery = errors.New("query error")
+
+		_, err := kmsService.List()
+		require.EqualError(t, err, "list: failed to query key metadata: query error")
+
+		metadataStore.ErrQuery = nil
+		store.errKeyIDs = errors.New("key IDs error")
+
+		_, err = kmsService.List()
+		require.EqualError(t, err, "list: failed to list keys: key IDs error")
+
+		metadataStore.ErrGet = errors.New("get error")
+
+		_, err = kmsService.GetMetadata("kid")
+		require.EqualError(t, err, "getMetadata: failed to read metadata of key 'kid': get error")
+
+		metadataStore.ErrPut = errors.New("put error")
+
+		_, _, err = kmsService.Create(kms.ED25519Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create: failed to save key metadata")
+
+		_, err = New(testMasterKeyURI, &mockProvider{
+			storage:    &listingKMSStore{errMetadataStore: errors.New("open error")},
+			secretLock: &noop.NoLock{},
+		})
+		require.EqualError(t, err, "new: failed to open key metadata store: open error")
+	})
+}
+
+// listingKMSStore is a kms.Store which lists its keysets and provides a key metadata store.
+type listingKMSStore struct {
+	*inMemoryKMSStore
+	metadata         storage.Store
+	errKeyIDs        error
+	errMetadataStore error
+}
+
+func (s *listingKMSStore) KeyIDs() ([]string, error) {
+	if s.errKeyIDs != nil {
+		return nil, s.errKeyIDs
+	}
+
+	var kids []string
+
+	for kid := range s.keys {
+		kids = append(kids, kid)
+	}
+
+	return kids, nil
+}
+
+func (s *listingKMSStore) MetadataStore(...string) (storage.Store, error) {
+	return s.metadata, s.errMetadataStore
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// ErrPublicKeyHandle is returned when a private key operation is requested with a handle to a public key.
+var ErrPublicKeyHandle = errors.New("key handle is a public key handle")
+
+// KeyHandle is a handle to a key pair of a KMS, or to a public key when returned by PubKeyBytesToHandle.
+// Private key operations are executed by the PKCS#11 token.
+type KeyHandle struct {
+	kms     *KMS
+	keyID   string
+	keyType kms.KeyType
+	object  pkcs11.ObjectHandle
+	pubKey  *ecdsa.PublicKey
+}
+
+// KeyID returns the ID of the key pair, it is empty for public key handles.
+func (h *KeyHandle) KeyID() string {
+	return h.keyID
+}
+
+// KeyType returns the KMS key type of the key.
+func (h *KeyHandle) KeyType() kms.KeyType {
+	return h.keyType
+}
+
+// PublicKey returns the public key.
+func (h *KeyHandle) PublicKey() *ecdsa.PublicKey {
+	return h.pubKey
+}
+
+// Sign signs the digest with the private key using CKM_ECDSA.
+// Returns:
+//   - the signature as the concatenation of r and s (IEEE P1363)
+//   - error if the handle is a public key handle or if signing fails
+func (h *KeyHandle) Sign(digest []byte) ([]byte, error) {
+	if h.kms == nil {
+		return nil, ErrPublicKeyHandle
+	}
+
+	h.kms.mu.Lock()
+	defer h.kms.mu.Unlock()
+
+	err := h.kms.ctx.SignInit(h.kms.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)},
+		h.object)
+	if err != nil {
+		return nil, fmt.Errorf("sign init: %w", err)
+	}
+
+	signature, err := h.kms.ctx.Sign(h.kms.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	return signature, nil
+}
+
+// DeriveSharedSecret executes ECDH between the private key and pubKey using CKM_ECDH1_DERIVE.
+// Returns:
+//   - the shared secret (the X coordinate of the shared point)
+//   - error if the handle is a public key handle or if key derivation fails
+func (h *KeyHandle) DeriveSharedSecret(pubKey *ecdsa.PublicKey) ([]byte, error) {
+	if h.kms == nil {
+		return nil, ErrPublicKeyHandle
+	}
+
+	if pubKey.Curve != h.pubKey.Curve || !pubKey.Curve.IsOnCurve(pubKey.X, pubKey.Y) {
+		return nil, errors.New("public key is not on the curve of the private key")
+	}
+
+	size := (pubKey.Curve.Params().BitSize + 7) / 8 // nolint:gomnd
+
+	h.kms.mu.Lock()
+	defer h.kms.mu.Unlock()
+
+	secret, err := h.kms.ctx.DeriveKey(h.kms.session, []*pkcs11.Mechanism{
+		pkcs11.NewMechanism(pkcs11.CKM_ECDH1_DERIVE, pkcs11.NewECDH1DeriveParams(pkcs11.CKD_NULL, nil,
+			elliptic.Marshal(pubKey.Curve, pubKey.X, pubKey.Y))),
+	}, h.object, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_GENERIC_SECRET),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, size),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	defer func() {
+		if errDestroy := h.kms.ctx.DestroyObject(h.kms.session, secret); errDestroy != nil {
+			logger.Warnf("failed to destroy derived key: %s", errDestroy)
+		}
+	}()
+
+	attrs, err := h.kms.ctx.GetAttributeValue(h.kms.session, secret, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read derived key: %w", err)
+	}
+
+	return attrs[0].Value, nil
+}
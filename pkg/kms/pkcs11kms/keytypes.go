@@ -0,0 +1,206 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/miekg/pkcs11"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const ecKeyType = "EC"
+
+// keyParams are the parameters of the key pairs of a KMS key type.
+type keyParams struct {
+	curve     elliptic.Curve
+	curveName string
+	ecParams  []byte
+	size      int
+	// derive is set for ECDH key wrapping keys, otherwise keys are signing keys.
+	derive bool
+}
+
+// nolint:gochecknoglobals
+var (
+	p256Params = keyParams{
+		curve:     elliptic.P256(),
+		curveName: "NIST_P256",
+		ecParams:  mustMarshalOID(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}),
+		size:      32, // nolint:gomnd
+	}
+	p384Params = keyParams{
+		curve:     elliptic.P384(),
+		curveName: "NIST_P384",
+		ecParams:  mustMarshalOID(asn1.ObjectIdentifier{1, 3, 132, 0, 34}),
+		size:      48, // nolint:gomnd
+	}
+)
+
+func mustMarshalOID(oid asn1.ObjectIdentifier) []byte {
+	der, err := asn1.Marshal(oid)
+	if err != nil {
+		panic(err)
+	}
+
+	return der
+}
+
+func keyParamsOf(kt kms.KeyType) (*keyParams, error) {
+	var params keyParams
+
+	switch kt {
+	case kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363:
+		params = p256Params
+	case kms.ECDSAP384TypeDER, kms.ECDSAP384TypeIEEEP1363:
+		params = p384Params
+	case kms.NISTP256ECDHKWType:
+		params = p256Params
+		params.derive = true
+	case kms.NISTP384ECDHKWType:
+		params = p384Params
+		params.derive = true
+	default:
+		return nil, fmt.Errorf("key type '%s' is not supported", kt)
+	}
+
+	return &params, nil
+}
+
+func (p *keyParams) publicKeyTemplate(keyID string, kt kms.KeyType) []*pkcs11.Attribute {
+	return []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, keyID),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, string(kt)),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, p.ecParams),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, !p.derive),
+		pkcs11.NewAttribute(pkcs11.CKA_DERIVE, p.derive),
+	}
+}
+
+func (p *keyParams) privateKeyTemplate(keyID string, kt kms.KeyType) []*pkcs11.Attribute {
+	return []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, keyID),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, string(kt)),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, !p.derive),
+		pkcs11.NewAttribute(pkcs11.CKA_DERIVE, p.derive),
+	}
+}
+
+// marshalECPoint marshals the public key as a CKA_EC_POINT value, a DER encoded uncompressed point.
+func marshalECPoint(pubKey *ecdsa.PublicKey) ([]byte, error) {
+	ecPoint, err := asn1.Marshal(elliptic.Marshal(pubKey.Curve, pubKey.X, pubKey.Y))
+	if err != nil {
+		return nil, fmt.Errorf("marshal EC point: %w", err)
+	}
+
+	return ecPoint, nil
+}
+
+// unmarshalECPoint unmarshals a CKA_EC_POINT value. Some tokens return the raw uncompressed point instead of the DER
+// encoding required by PKCS#11, both are accepted.
+func unmarshalECPoint(curve elliptic.Curve, ecPoint []byte) (*ecdsa.PublicKey, error) {
+	var point []byte
+
+	if rest, err := asn1.Unmarshal(ecPoint, &point); err != nil || len(rest) > 0 {
+		point = ecPoint
+	}
+
+	x, y := elliptic.Unmarshal(curve, point)
+	if x == nil {
+		return nil, errors.New("invalid EC point")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func marshalPublicKey(pubKey *ecdsa.PublicKey, kt kms.KeyType) ([]byte, error) {
+	params, err := keyParamsOf(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kt {
+	case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER:
+		return x509.MarshalPKIXPublicKey(pubKey)
+	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363:
+		return elliptic.Marshal(pubKey.Curve, pubKey.X, pubKey.Y), nil
+	default:
+		return json.Marshal(&cryptoapi.PublicKey{
+			X:     pubKey.X.Bytes(),
+			Y:     pubKey.Y.Bytes(),
+			Curve: params.curveName,
+			Type:  ecKeyType,
+		})
+	}
+}
+
+func unmarshalPublicKey(pubKey []byte, kt kms.KeyType) (*ecdsa.PublicKey, error) {
+	params, err := keyParamsOf(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	var key *ecdsa.PublicKey
+
+	switch kt {
+	case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER:
+		pkixKey, err := x509.ParsePKIXPublicKey(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("parse PKIX public key: %w", err)
+		}
+
+		ecKey, ok := pkixKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, errors.New("public key is not an EC key")
+		}
+
+		key = ecKey
+	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363:
+		x, y := elliptic.Unmarshal(params.curve, pubKey)
+		if x == nil {
+			return nil, errors.New("invalid EC point")
+		}
+
+		key = &ecdsa.PublicKey{Curve: params.curve, X: x, Y: y}
+	default:
+		ecKey := &cryptoapi.PublicKey{}
+
+		if err := json.Unmarshal(pubKey, ecKey); err != nil {
+			return nil, fmt.Errorf("unmarshal public key: %w", err)
+		}
+
+		if ecKey.Type != ecKeyType || ecKey.Curve != params.curveName {
+			return nil, fmt.Errorf("public key is not a %s EC key", params.curveName)
+		}
+
+		key = &ecdsa.PublicKey{
+			Curve: params.curve,
+			X:     new(big.Int).SetBytes(ecKey.X),
+			Y:     new(big.Int).SetBytes(ecKey.Y),
+		}
+	}
+
+	if key.Curve != params.curve || !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, fmt.Errorf("public key is not on the curve of key type %s", kt)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,465 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pkcs11kms provides a kms.KeyManager keeping private keys in a PKCS#11 token (eg: an HSM or SoftHSM).
+// Private keys are generated in the token and never leave it, signing and ECDH key agreement are executed by the
+// token through the key handles returned by the KMS, which are meant to be used with pkcs11crypto.Crypto.
+//
+// Supported key types are ECDSA P-256 and P-384 signing keys (DER and IEEE P1363 signatures) and NIST P-256 and
+// P-384 ECDH key wrapping keys.
+//
+// Usage:
+//
+//	km, err := pkcs11kms.New("/usr/lib/softhsm/libsofthsm2.so", "aries", "1234")
+//	if err != nil {
+//	    panic(err)
+//	}
+//	defer km.Close()
+//
+//	cr, err := pkcs11crypto.New()
+//	if err != nil {
+//	    panic(err)
+//	}
+//
+//	framework, err := aries.New(
+//	    aries.WithKMS(func(kms.Provider) (kms.KeyManager, error) { return km, nil }),
+//	    aries.WithCrypto(cr),
+//	)
+package pkcs11kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const keyIDLength = 32
+
+var logger = log.New("aries-framework/kms/pkcs11kms")
+
+var _ kms.KeyManager = (*KMS)(nil)
+
+// Module is the PKCS#11 API used by the KMS, it is implemented by *pkcs11.Ctx.
+type Module interface {
+	Initialize() error
+	Finalize() error
+	Destroy()
+	GetSlotList(tokenPresent bool) ([]uint, error)
+	GetTokenInfo(slotID uint) (pkcs11.TokenInfo, error)
+	OpenSession(slotID uint, flags uint) (pkcs11.SessionHandle, error)
+	CloseSession(sh pkcs11.SessionHandle) error
+	Login(sh pkcs11.SessionHandle, userType uint, pin string) error
+	Logout(sh pkcs11.SessionHandle) error
+	GenerateKeyPair(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, public, private []*pkcs11.Attribute) (
+		pkcs11.ObjectHandle, pkcs11.ObjectHandle, error)
+	CreateObject(sh pkcs11.SessionHandle, temp []*pkcs11.Attribute) (pkcs11.ObjectHandle, error)
+	DestroyObject(sh pkcs11.SessionHandle, oh pkcs11.ObjectHandle) error
+	GetAttributeValue(sh pkcs11.SessionHandle, o pkcs11.ObjectHandle, a []*pkcs11.Attribute) ([]*pkcs11.Attribute,
+		error)
+	FindObjectsInit(sh pkcs11.SessionHandle, temp []*pkcs11.Attribute) error
+	FindObjects(sh pkcs11.SessionHandle, max int) ([]pkcs11.ObjectHandle, bool, error)
+	FindObjectsFinal(sh pkcs11.SessionHandle) error
+	SignInit(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error
+	Sign(sh pkcs11.SessionHandle, message []byte) ([]byte, error)
+	DeriveKey(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, basekey pkcs11.ObjectHandle, a []*pkcs11.Attribute) (
+		pkcs11.ObjectHandle, error)
+}
+
+// KMS is a kms.KeyManager keeping its keys in a PKCS#11 token.
+// Keys are identified in the token by their CKA_ID set to the key ID and their CKA_LABEL set to the KMS key type.
+type KMS struct {
+	ctx     Module
+	session pkcs11.SessionHandle
+	// PKCS#11 sessions must not be used concurrently.
+	mu sync.Mutex
+}
+
+// New loads the PKCS#11 library found at libPath, opens a session to the token labeled tokenLabel and logs in
+// the token user with pin.
+func New(libPath, tokenLabel, pin string) (*KMS, error) {
+	ctx := pkcs11.New(libPath)
+	if ctx == nil {
+		return nil, fmt.Errorf("new pkcs11kms: failed to load PKCS#11 library '%s'", libPath)
+	}
+
+	return NewWithModule(ctx, tokenLabel, pin)
+}
+
+// NewWithModule creates a KMS using the given PKCS#11 module, it opens a session to the token labeled tokenLabel
+// and logs in the token user with pin.
+func NewWithModule(ctx Module, tokenLabel, pin string) (*KMS, error) {
+	if err := ctx.Initialize(); err != nil {
+		return nil, fmt.Errorf("new pkcs11kms: initialize: %w", err)
+	}
+
+	k := &KMS{ctx: ctx}
+
+	err := k.openSession(tokenLabel, pin)
+	if err != nil {
+		k.finalize()
+
+		return nil, fmt.Errorf("new pkcs11kms: %w", err)
+	}
+
+	return k, nil
+}
+
+func (k *KMS) openSession(tokenLabel, pin string) error {
+	slots, err := k.ctx.GetSlotList(true)
+	if err != nil {
+		return fmt.Errorf("get slot list: %w", err)
+	}
+
+	for _, slot := range slots {
+		info, err := k.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return fmt.Errorf("get token info of slot %d: %w", slot, err)
+		}
+
+		if info.Label != tokenLabel {
+			continue
+		}
+
+		k.session, err = k.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err != nil {
+			return fmt.Errorf("open session: %w", err)
+		}
+
+		err = k.ctx.Login(k.session, pkcs11.CKU_USER, pin)
+		if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+			if errClose := k.ctx.CloseSession(k.session); errClose != nil {
+				logger.Warnf("failed to close session: %s", errClose)
+			}
+
+			return fmt.Errorf("login: %w", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("token '%s' not found", tokenLabel)
+}
+
+// Close logs out of the token, closes the session and unloads the PKCS#11 library.
+func (k *KMS) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.ctx.Logout(k.session); err != nil {
+		logger.Warnf("failed to logout: %s", err)
+	}
+
+	if err := k.ctx.CloseSession(k.session); err != nil {
+		return fmt.Errorf("pkcs11kms: close session: %w", err)
+	}
+
+	k.finalize()
+
+	return nil
+}
+
+func (k *KMS) finalize() {
+	if err := k.ctx.Finalize(); err != nil {
+		logger.Warnf("failed to finalize: %s", err)
+	}
+
+	k.ctx.Destroy()
+}
+
+// Create a new key pair of type kt in the token.
+// Returns:
+//   - keyID of the key pair
+//   - handle instance (to private key) as *KeyHandle
+//   - error if failure
+func (k *KMS) Create(kt kms.KeyType, _ ...kms.KeyOpts) (string, interface{}, error) {
+	params, err := keyParamsOf(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	keyID, err := newKeyID()
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	_, priv, err := k.ctx.GenerateKeyPair(k.session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		params.publicKeyTemplate(keyID, kt), params.privateKeyTemplate(keyID, kt))
+	if err != nil {
+		return "", nil, fmt.Errorf("create: generate key pair: %w", err)
+	}
+
+	kh, err := k.keyHandle(keyID, kt, priv)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	return keyID, kh, nil
+}
+
+// Get key handle for the given keyID.
+// Returns:
+//   - handle instance (to private key) as *KeyHandle
+//   - error if failure
+func (k *KMS) Get(keyID string) (interface{}, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	priv, err := k.findObject(keyID, pkcs11.CKO_PRIVATE_KEY)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	attrs, err := k.ctx.GetAttributeValue(k.session, priv, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get: read key type: %w", err)
+	}
+
+	kh, err := k.keyHandle(keyID, kms.KeyType(attrs[0].Value), priv)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return kh, nil
+}
+
+// Rotate creates a new key pair of type kt in the token, the key referenced by keyID is kept in the token so that
+// data protected by it can still be processed.
+// Returns:
+//   - new KeyID
+//   - handle instance (to private key) as *KeyHandle
+//   - error if failure
+func (k *KMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (string, interface{}, error) {
+	if _, err := k.Get(keyID); err != nil {
+		return "", nil, fmt.Errorf("rotate: %w", err)
+	}
+
+	newKeyID, kh, err := k.Create(kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: %w", err)
+	}
+
+	return newKeyID, kh, nil
+}
+
+// ExportPubKeyBytes returns the public key of the key pair referenced by keyID in raw bytes along with its type.
+// ECDSA DER keys are exported in PKIX format, ECDSA IEEE P1363 keys as uncompressed points and ECDH key wrapping keys
+// as a marshalled crypto.PublicKey, just as localkms does.
+// Returns:
+//   - marshalled public key []byte
+//   - error if it fails to export the public key bytes
+func (k *KMS) ExportPubKeyBytes(keyID string) ([]byte, kms.KeyType, error) {
+	kh, err := k.Get(keyID)
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPubKeyBytes: %w", err)
+	}
+
+	pubKeyHandle, ok := kh.(*KeyHandle)
+	if !ok {
+		return nil, "", errors.New("exportPubKeyBytes: invalid key handle")
+	}
+
+	pubKey, err := marshalPublicKey(pubKeyHandle.pubKey, pubKeyHandle.keyType)
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPubKeyBytes: %w", err)
+	}
+
+	return pubKey, pubKeyHandle.keyType, nil
+}
+
+// CreateAndExportPubKeyBytes creates a new key pair of type kt in the token and exports its public key in raw bytes.
+// Returns:
+//   - keyID of the new key pair
+//   - marshalled public key []byte
+//   - error if it fails to create the key pair or to export the public key bytes
+func (k *KMS) CreateAndExportPubKeyBytes(kt kms.KeyType, opts ...kms.KeyOpts) (string, []byte, error) {
+	keyID, _, err := k.Create(kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: %w", err)
+	}
+
+	pubKey, _, err := k.ExportPubKeyBytes(keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: %w", err)
+	}
+
+	return keyID, pubKey, nil
+}
+
+// PubKeyBytesToHandle transforms pubKey raw bytes into a public key handle of type kt, to be used with
+// pkcs11crypto.Crypto for signature verification or key wrapping. The key is not stored in the token.
+// Returns:
+//   - handle instance to the public key of type kt as *KeyHandle
+//   - error if kt is not supported, the key does not match kt or unmarshal fails
+func (k *KMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, _ ...kms.KeyOpts) (interface{}, error) {
+	key, err := unmarshalPublicKey(pubKey, kt)
+	if err != nil {
+		return nil, fmt.Errorf("pubKeyBytesToHandle: %w", err)
+	}
+
+	return &KeyHandle{keyType: kt, pubKey: key}, nil
+}
+
+// ImportPrivateKey imports privKey as a non extractable key pair of type kt in the token.
+// 'privKey' must be an *ecdsa.PrivateKey on the curve of 'kt'.
+// 'opts' allows setting the keyID of the imported key using WithKeyID() option. If the ID is already used,
+// then an error is returned.
+// Returns:
+//   - keyID of the handle
+//   - handle instance (to private key) as *KeyHandle
+//   - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
+func (k *KMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	ecPrivKey, ok := privKey.(*ecdsa.PrivateKey)
+	if !ok || ecPrivKey == nil {
+		return "", nil, fmt.Errorf("importPrivateKey: unsupported private key type %T", privKey)
+	}
+
+	params, err := keyParamsOf(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: %w", err)
+	}
+
+	if ecPrivKey.Curve != params.curve {
+		return "", nil, fmt.Errorf("importPrivateKey: private key is not on the curve of key type %s", kt)
+	}
+
+	pOpts := kms.NewOpt()
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	keyID := pOpts.KsID()
+	if keyID == "" {
+		keyID, err = newKeyID()
+		if err != nil {
+			return "", nil, fmt.Errorf("importPrivateKey: %w", err)
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, err = k.findObject(keyID, pkcs11.CKO_PRIVATE_KEY); err == nil {
+		return "", nil, fmt.Errorf("importPrivateKey: key with ID '%s' already exists", keyID)
+	}
+
+	priv, err := k.createKeyPair(keyID, kt, params, ecPrivKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: %w", err)
+	}
+
+	return keyID, &KeyHandle{kms: k, keyID: keyID, keyType: kt, object: priv, pubKey: &ecPrivKey.PublicKey}, nil
+}
+
+func (k *KMS) createKeyPair(keyID string, kt kms.KeyType, params *keyParams,
+	privKey *ecdsa.PrivateKey) (pkcs11.ObjectHandle, error) {
+	ecPoint, err := marshalECPoint(&privKey.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	pub, err := k.ctx.CreateObject(k.session, append(params.publicKeyTemplate(keyID, kt),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, ecPoint)))
+	if err != nil {
+		return 0, fmt.Errorf("create public key: %w", err)
+	}
+
+	priv, err := k.ctx.CreateObject(k.session, append(params.privateKeyTemplate(keyID, kt),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, params.ecParams),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, privKey.D.FillBytes(make([]byte, params.size)))))
+	if err != nil {
+		if errDestroy := k.ctx.DestroyObject(k.session, pub); errDestroy != nil {
+			logger.Warnf("failed to destroy public key: %s", errDestroy)
+		}
+
+		return 0, fmt.Errorf("create private key: %w", err)
+	}
+
+	return priv, nil
+}
+
+// keyHandle reads the public key of the key pair referenced by keyID and returns the handle of the key pair.
+func (k *KMS) keyHandle(keyID string, kt kms.KeyType, priv pkcs11.ObjectHandle) (*KeyHandle, error) {
+	params, err := keyParamsOf(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := k.findObject(keyID, pkcs11.CKO_PUBLIC_KEY)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, err := k.ctx.GetAttributeValue(k.session, pub, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read public key: %w", err)
+	}
+
+	pubKey, err := unmarshalECPoint(params.curve, attrs[0].Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyHandle{kms: k, keyID: keyID, keyType: kt, object: priv, pubKey: pubKey}, nil
+}
+
+// findObject returns the object of class class with the CKA_ID keyID. It must be called with k.mu locked.
+func (k *KMS) findObject(keyID string, class uint) (pkcs11.ObjectHandle, error) {
+	err := k.ctx.FindObjectsInit(k.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, keyID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("find objects: %w", err)
+	}
+
+	objects, _, err := k.ctx.FindObjects(k.session, 1)
+
+	if errFinal := k.ctx.FindObjectsFinal(k.session); errFinal != nil && err == nil {
+		err = errFinal
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("find objects: %w", err)
+	}
+
+	if len(objects) == 0 {
+		return 0, fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyNotFound)
+	}
+
+	return objects[0], nil
+}
+
+func newKeyID() (string, error) {
+	id := make([]byte, keyIDLength)
+
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("generate key ID: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(id), nil
+}
@@ -0,0 +1,399 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockpkcs11 "github.com/hyperledger/aries-framework-go/pkg/mock/pkcs11"
+)
+
+const (
+	tokenLabel = "aries"
+	pin        = "1234"
+)
+
+func newKMS(t *testing.T) (*KMS, *mockpkcs11.Module) {
+	t.Helper()
+
+	module := &mockpkcs11.Module{TokenLabel: tokenLabel, PIN: pin}
+
+	k, err := NewWithModule(module, tokenLabel, pin)
+	require.NoError(t, err)
+
+	return k, module
+}
+
+func TestNew(t *testing.T) {
+	t.Run("opens a session to the token", func(t *testing.T) {
+		k, module := newKMS(t)
+		require.True(t, module.LoggedIn)
+
+		require.NoError(t, k.Close())
+		require.False(t, module.LoggedIn)
+		require.True(t, module.Finalized)
+		require.True(t, module.Destroyed)
+
+		module.CloseSessionErr = errors.New("close error")
+		require.EqualError(t, k.Close(), "pkcs11kms: close session: close error")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := New("/does/not/exist.so", tokenLabel, pin)
+		require.EqualError(t, err, "new pkcs11kms: failed to load PKCS#11 library '/does/not/exist.so'")
+
+		module := &mockpkcs11.Module{TokenLabel: tokenLabel, PIN: pin, InitializeErr: errors.New("init error")}
+		_, err = NewWithModule(module, tokenLabel, pin)
+		require.EqualError(t, err, "new pkcs11kms: initialize: init error")
+
+		module = &mockpkcs11.Module{TokenLabel: tokenLabel, PIN: pin}
+		_, err = NewWithModule(module, "other", pin)
+		require.EqualError(t, err, "new pkcs11kms: token 'other' not found")
+		require.True(t, module.Finalized)
+
+		_, err = NewWithModule(module, tokenLabel, "0000")
+		require.EqualError(t, err, "new pkcs11kms: login: pkcs11: 0xA0: CKR_PIN_INCORRECT")
+
+		module = &mockpkcs11.Module{TokenLabel: tokenLabel, PIN: pin, GetSlotListErr: errors.New("slot error")}
+		_, err = NewWithModule(module, tokenLabel, pin)
+		require.EqualError(t, err, "new pkcs11kms: get slot list: slot error")
+
+		module = &mockpkcs11.Module{TokenLabel: tokenLabel, PIN: pin, OpenSessionErr: errors.New("session error")}
+		_, err = NewWithModule(module, tokenLabel, pin)
+		require.EqualError(t, err, "new pkcs11kms: open session: session error")
+	})
+}
+
+func TestKMS_Create(t *testing.T) {
+	k, _ := newKMS(t)
+
+	for _, kt := range []kms.KeyType{
+		kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363,
+		kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType,
+	} {
+		t.Run(string(kt), func(t *testing.T) {
+			keyID, kh, err := k.Create(kt)
+			require.NoError(t, err)
+			require.NotEmpty(t, keyID)
+
+			keyHandle, ok := kh.(*KeyHandle)
+			require.True(t, ok)
+			require.Equal(t, keyID, keyHandle.KeyID())
+			require.Equal(t, kt, keyHandle.KeyType())
+
+			kh, err = k.Get(keyID)
+			require.NoError(t, err)
+			require.Equal(t, keyHandle.PublicKey(), kh.(*KeyHandle).PublicKey())
+
+			pubKey, exportedType, err := k.ExportPubKeyBytes(keyID)
+			require.NoError(t, err)
+			require.Equal(t, kt, exportedType)
+
+			pubKH, err := k.PubKeyBytesToHandle(pubKey, kt)
+			require.NoError(t, err)
+			require.Equal(t, keyHandle.PublicKey(), pubKH.(*KeyHandle).PublicKey())
+			require.Empty(t, pubKH.(*KeyHandle).KeyID())
+
+			_, err = pubKH.(*KeyHandle).Sign([]byte("digest"))
+			require.ErrorIs(t, err, ErrPublicKeyHandle)
+
+			_, err = pubKH.(*KeyHandle).DeriveSharedSecret(keyHandle.PublicKey())
+			require.ErrorIs(t, err, ErrPublicKeyHandle)
+
+			newKeyID, _, err := k.Rotate(kt, keyID)
+			require.NoError(t, err)
+			require.NotEqual(t, keyID, newKeyID)
+
+			_, err = k.Get(keyID)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("exported public key formats", func(t *testing.T) {
+		_, pubKey, err := k.CreateAndExportPubKeyBytes(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		pkixKey, err := x509.ParsePKIXPublicKey(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, elliptic.P256(), pkixKey.(*ecdsa.PublicKey).Curve)
+
+		_, pubKey, err = k.CreateAndExportPubKeyBytes(kms.ECDSAP384TypeIEEEP1363)
+		require.NoError(t, err)
+
+		x, _ := elliptic.Unmarshal(elliptic.P384(), pubKey)
+		require.NotNil(t, x)
+
+		_, pubKey, err = k.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		ecdhKey := &cryptoapi.PublicKey{}
+		require.NoError(t, json.Unmarshal(pubKey, ecdhKey))
+		require.Equal(t, "NIST_P256", ecdhKey.Curve)
+		require.Equal(t, "EC", ecdhKey.Type)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := k.Create(kms.ED25519Type)
+		require.EqualError(t, err, "create: key type 'ED25519' is not supported")
+
+		_, _, err = k.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.EqualError(t, err, "createAndExportPubKeyBytes: create: key type 'ED25519' is not supported")
+
+		_, err = k.Get("unknown")
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+
+		_, _, err = k.ExportPubKeyBytes("unknown")
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+
+		_, _, err = k.Rotate(kms.ECDSAP256TypeDER, "unknown")
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+
+		keyID, _, err := k.Create(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		_, _, err = k.Rotate(kms.ED25519Type, keyID)
+		require.EqualError(t, err, "rotate: create: key type 'ED25519' is not supported")
+
+		module := &mockpkcs11.Module{TokenLabel: tokenLabel, PIN: pin}
+
+		k2, err := NewWithModule(module, tokenLabel, pin)
+		require.NoError(t, err)
+
+		module.GenerateKeyPairErr = errors.New("generate error")
+		_, _, err = k2.Create(kms.ECDSAP256TypeDER)
+		require.EqualError(t, err, "create: generate key pair: generate error")
+
+		module.FindObjectsErr = errors.New("find error")
+		_, err = k2.Get(keyID)
+		require.EqualError(t, err, "get: find objects: find error")
+
+		module.FindObjectsErr = nil
+		module.GenerateKeyPairErr = nil
+
+		keyID, _, err = k2.Create(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		module.GetAttributeErr = errors.New("attribute error")
+		_, err = k2.Get(keyID)
+		require.EqualError(t, err, "get: read key type: attribute error")
+	})
+}
+
+func TestKMS_PubKeyBytesToHandle(t *testing.T) {
+	k, _ := newKMS(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = k.PubKeyBytesToHandle([]byte("invalid"), kms.ECDSAP256TypeDER)
+	require.Contains(t, err.Error(), "pubKeyBytesToHandle: parse PKIX public key")
+
+	_, err = k.PubKeyBytesToHandle([]byte("invalid"), kms.ECDSAP256TypeIEEEP1363)
+	require.EqualError(t, err, "pubKeyBytesToHandle: invalid EC point")
+
+	_, err = k.PubKeyBytesToHandle(elliptic.Marshal(key.Curve, key.X, key.Y), kms.ECDSAP384TypeIEEEP1363)
+	require.EqualError(t, err, "pubKeyBytesToHandle: invalid EC point")
+
+	pkixKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	_, err = k.PubKeyBytesToHandle(pkixKey, kms.ECDSAP384TypeDER)
+	require.EqualError(t, err, "pubKeyBytesToHandle: public key is not on the curve of key type ECDSAP384DER")
+
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pkixKey, err = x509.MarshalPKIXPublicKey(edKey)
+	require.NoError(t, err)
+
+	_, err = k.PubKeyBytesToHandle(pkixKey, kms.ECDSAP256TypeDER)
+	require.EqualError(t, err, "pubKeyBytesToHandle: public key is not an EC key")
+
+	_, err = k.PubKeyBytesToHandle([]byte("invalid"), kms.NISTP256ECDHKWType)
+	require.Contains(t, err.Error(), "pubKeyBytesToHandle: unmarshal public key")
+
+	ecdhKey, err := json.Marshal(&cryptoapi.PublicKey{X: key.X.Bytes(), Y: key.Y.Bytes(), Curve: "P-256", Type: "EC"})
+	require.NoError(t, err)
+
+	_, err = k.PubKeyBytesToHandle(ecdhKey, kms.NISTP256ECDHKWType)
+	require.EqualError(t, err, "pubKeyBytesToHandle: public key is not a NIST_P256 EC key")
+
+	ecdhKey, err = json.Marshal(&cryptoapi.PublicKey{X: key.X.Bytes(), Y: big.NewInt(1).Bytes(), Curve: "NIST_P256",
+		Type: "EC"})
+	require.NoError(t, err)
+
+	_, err = k.PubKeyBytesToHandle(ecdhKey, kms.NISTP256ECDHKWType)
+	require.EqualError(t, err, "pubKeyBytesToHandle: public key is not on the curve of key type NISTP256ECDHKW")
+
+	_, err = k.PubKeyBytesToHandle(ecdhKey, kms.ED25519Type)
+	require.EqualError(t, err, "pubKeyBytesToHandle: key type 'ED25519' is not supported")
+}
+
+func TestKMS_ImportPrivateKey(t *testing.T) {
+	k, module := newKMS(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	keyID, kh, err := k.ImportPrivateKey(key, kms.ECDSAP384TypeIEEEP1363, kms.WithKeyID("imported"))
+	require.NoError(t, err)
+	require.Equal(t, "imported", keyID)
+	require.Equal(t, &key.PublicKey, kh.(*KeyHandle).PublicKey())
+
+	kh, err = k.Get(keyID)
+	require.NoError(t, err)
+	require.True(t, key.PublicKey.Equal(kh.(*KeyHandle).PublicKey()))
+
+	digest := sha256.Sum256([]byte("message"))
+
+	signature, err := kh.(*KeyHandle).Sign(digest[:])
+	require.NoError(t, err)
+	require.True(t, ecdsa.Verify(&key.PublicKey, digest[:], new(big.Int).SetBytes(signature[:48]),
+		new(big.Int).SetBytes(signature[48:])))
+
+	keyID, _, err = k.ImportPrivateKey(key, kms.ECDSAP384TypeDER)
+	require.NoError(t, err)
+	require.NotEmpty(t, keyID)
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err = k.ImportPrivateKey(key, kms.ECDSAP384TypeIEEEP1363, kms.WithKeyID("imported"))
+		require.EqualError(t, err, "importPrivateKey: key with ID 'imported' already exists")
+
+		_, _, err = k.ImportPrivateKey(key, kms.ECDSAP256TypeDER)
+		require.EqualError(t, err, "importPrivateKey: private key is not on the curve of key type ECDSAP256DER")
+
+		_, _, err = k.ImportPrivateKey(key, kms.ED25519Type)
+		require.EqualError(t, err, "importPrivateKey: key type 'ED25519' is not supported")
+
+		_, _, err = k.ImportPrivateKey(ed25519.PrivateKey{}, kms.ED25519Type)
+		require.EqualError(t, err, "importPrivateKey: unsupported private key type ed25519.PrivateKey")
+
+		module.CreateObjectErr = errors.New("create error")
+		_, _, err = k.ImportPrivateKey(key, kms.ECDSAP384TypeDER)
+		require.EqualError(t, err, "importPrivateKey: create public key: create error")
+	})
+}
+
+func TestKeyHandle(t *testing.T) {
+	k, module := newKMS(t)
+
+	t.Run("sign", func(t *testing.T) {
+		_, kh, err := k.Create(kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		keyHandle := kh.(*KeyHandle)
+		digest := sha256.Sum256([]byte("message"))
+
+		signature, err := keyHandle.Sign(digest[:])
+		require.NoError(t, err)
+		require.Len(t, signature, 64)
+		require.True(t, ecdsa.Verify(keyHandle.PublicKey(), digest[:], new(big.Int).SetBytes(signature[:32]),
+			new(big.Int).SetBytes(signature[32:])))
+
+		module.SignErr = errors.New("sign error")
+		defer func() { module.SignErr = nil }()
+
+		_, err = keyHandle.Sign(digest[:])
+		require.EqualError(t, err, "sign: sign error")
+
+		_, err = keyHandle.DeriveSharedSecret(keyHandle.PublicKey())
+		require.Contains(t, err.Error(), "CKR_KEY_FUNCTION_NOT_PERMITTED")
+	})
+
+	t.Run("derive shared secret", func(t *testing.T) {
+		_, kh, err := k.Create(kms.NISTP384ECDHKWType)
+		require.NoError(t, err)
+
+		keyHandle := kh.(*KeyHandle)
+
+		other, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		z, err := keyHandle.DeriveSharedSecret(&other.PublicKey)
+		require.NoError(t, err)
+
+		expected, _ := elliptic.P384().ScalarMult(keyHandle.PublicKey().X, keyHandle.PublicKey().Y, other.D.Bytes())
+		require.Equal(t, expected.FillBytes(make([]byte, 48)), z)
+
+		_, err = keyHandle.DeriveSharedSecret(&ecdsa.PublicKey{Curve: elliptic.P256(), X: other.X, Y: other.Y})
+		require.EqualError(t, err, "public key is not on the curve of the private key")
+
+		_, err = keyHandle.Sign([]byte("digest"))
+		require.Contains(t, err.Error(), "CKR_KEY_FUNCTION_NOT_PERMITTED")
+
+		module.DeriveKeyErr = errors.New("derive error")
+		defer func() { module.DeriveKeyErr = nil }()
+
+		_, err = keyHandle.DeriveSharedSecret(&other.PublicKey)
+		require.EqualError(t, err, "derive key: derive error")
+	})
+}
+
+// TestKMS_SoftHSM runs against a real PKCS#11 library, eg: SoftHSM with a token initialized with:
+//
+//	softhsm2-util --init-token --free --label aries --pin 1234 --so-pin 1234
+//	PKCS11_LIB=/usr/lib/softhsm/libsofthsm2.so go test ./pkg/kms/pkcs11kms/...
+func TestKMS_SoftHSM(t *testing.T) {
+	lib := os.Getenv("PKCS11_LIB")
+	if lib == "" {
+		t.Skip("PKCS11_LIB is not set")
+	}
+
+	k, err := New(lib, tokenLabel, pin)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, k.Close())
+	}()
+
+	_, kh, err := k.Create(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("message"))
+
+	signature, err := kh.(*KeyHandle).Sign(digest[:])
+	require.NoError(t, err)
+	require.True(t, ecdsa.Verify(kh.(*KeyHandle).PublicKey(), digest[:], new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:])))
+
+	keyID, _, err := k.Create(kms.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	kh, err = k.Get(keyID)
+	require.NoError(t, err)
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	z, err := kh.(*KeyHandle).DeriveSharedSecret(&other.PublicKey)
+	require.NoError(t, err)
+
+	expected, _ := elliptic.P256().ScalarMult(kh.(*KeyHandle).PublicKey().X, kh.(*KeyHandle).PublicKey().Y,
+		other.D.Bytes())
+	require.Equal(t, expected.FillBytes(make([]byte, 32)), z)
+
+	// private keys can't be read.
+	_, err = k.ctx.GetAttributeValue(k.session, kh.(*KeyHandle).object, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil),
+	})
+	require.Error(t, err)
+}
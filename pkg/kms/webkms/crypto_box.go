@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package webkms
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -100,7 +101,7 @@ func (b *CryptoBox) Easy(payload, nonce, theirPub []byte, myKID string) ([]byte,
 		return nil, fmt.Errorf("failed to marshal Easy request [%s, %w]", destination, err)
 	}
 
-	resp, err := b.km.postHTTPRequest(destination, marshaledReq)
+	resp, err := b.km.postHTTPRequest(context.Background(), destination, marshaledReq)
 	if err != nil {
 		return nil, fmt.Errorf("posting Easy request failed [%s, %w]", destination, err)
 	}
@@ -152,7 +153,7 @@ func (b *CryptoBox) EasyOpen(cipherText, nonce, theirPub, myPub []byte) ([]byte,
 		return nil, fmt.Errorf("failed to marshal EasyOpen request [%s, %w]", destination, err)
 	}
 
-	resp, err := b.km.postHTTPRequest(destination, marshaledReq)
+	resp, err := b.km.postHTTPRequest(context.Background(), destination, marshaledReq)
 	if err != nil {
 		return nil, fmt.Errorf("posting EasyOpen failed [%s, %w]", destination, err)
 	}
@@ -234,7 +235,7 @@ func (b *CryptoBox) SealOpen(cipherText, myPub []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to marshal SealOpen request [%s, %w]", destination, err)
 	}
 
-	resp, err := b.km.postHTTPRequest(destination, marshaledReq)
+	resp, err := b.km.postHTTPRequest(context.Background(), destination, marshaledReq)
 	if err != nil {
 		return nil, fmt.Errorf("posting SealOpen failed [%s, %w]", destination, err)
 	}
@@ -8,6 +8,7 @@ package webkms
 
 import (
 	"bytes"
+	"context"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
@@ -34,6 +35,9 @@ const (
 
 var logger = log.New("aries-framework/kms/webkms")
 
+// RemoteKMS manages the lifecycle metadata of its keys through the key server.
+var _ kms.KeyLifecycleManager = (*RemoteKMS)(nil)
+
 // HTTPClient interface for the http client.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -59,8 +63,11 @@ type createKeyStoreResp struct {
 }
 
 type createKeyReq struct {
-	KeyType kms.KeyType `json:"key_type"`
-	Attrs   []string    `json:"attrs,omitempty"`
+	KeyType   kms.KeyType       `json:"key_type"`
+	Attrs     []string          `json:"attrs,omitempty"`
+	Purpose   string            `json:"purpose,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
 }
 
 type createKeyResp struct {
@@ -83,6 +90,10 @@ type importKeyResp struct {
 	KeyURL string `json:"key_url"`
 }
 
+type listKeysResp struct {
+	Keys []*kms.KeyMetadata `json:"keys"`
+}
+
 type marshalFunc func(interface{}) ([]byte, error)
 
 type unmarshalFunc func([]byte, interface{}) error
@@ -203,19 +214,24 @@ func New(keystoreURL string, client HTTPClient, opts ...Opt) *RemoteKMS {
 	}
 }
 
-func (r *RemoteKMS) postHTTPRequest(destination string, mReq []byte) (*http.Response, error) {
-	return r.doHTTPRequest(http.MethodPost, destination, mReq)
+func (r *RemoteKMS) postHTTPRequest(ctx context.Context, destination string, mReq []byte) (*http.Response, error) {
+	return r.doHTTPRequest(ctx, http.MethodPost, destination, mReq)
+}
+
+func (r *RemoteKMS) putHTTPRequest(ctx context.Context, destination string, mReq []byte) (*http.Response, error) {
+	return r.doHTTPRequest(ctx, http.MethodPut, destination, mReq)
 }
 
-func (r *RemoteKMS) putHTTPRequest(destination string, mReq []byte) (*http.Response, error) {
-	return r.doHTTPRequest(http.MethodPut, destination, mReq)
+func (r *RemoteKMS) getHTTPRequest(ctx context.Context, destination string) (*http.Response, error) {
+	return r.doHTTPRequest(ctx, http.MethodGet, destination, nil)
 }
 
-func (r *RemoteKMS) getHTTPRequest(destination string) (*http.Response, error) {
-	return r.doHTTPRequest(http.MethodGet, destination, nil)
+func (r *RemoteKMS) deleteHTTPRequest(ctx context.Context, destination string) (*http.Response, error) {
+	return r.doHTTPRequest(ctx, http.MethodDelete, destination, nil)
 }
 
-func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*http.Response, error) {
+func (r *RemoteKMS) doHTTPRequest(ctx context.Context, method, destination string,
+	mReq []byte) (*http.Response, error) {
 	start := time.Now()
 
 	var (
@@ -224,12 +240,12 @@ func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*htt
 	)
 
 	if mReq != nil {
-		httpReq, err = http.NewRequest(method, destination, bytes.NewBuffer(mReq))
+		httpReq, err = http.NewRequestWithContext(ctx, method, destination, bytes.NewBuffer(mReq))
 		if err != nil {
 			return nil, fmt.Errorf("build post request error: %w", err)
 		}
 	} else {
-		httpReq, err = http.NewRequest(method, destination, nil)
+		httpReq, err = http.NewRequestWithContext(ctx, method, destination, nil)
 		if err != nil {
 			return nil, fmt.Errorf("build get request error: %w", err)
 		}
@@ -263,9 +279,15 @@ func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*htt
 //  - handle instance representing a remote keystore URL including KeyID
 //  - error if failure
 func (r *RemoteKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interface{}, error) {
+	return r.CreateContext(context.Background(), kt, opts...)
+}
+
+// CreateContext is Create honouring ctx cancellation and deadline.
+func (r *RemoteKMS) CreateContext(ctx context.Context, kt kms.KeyType,
+	opts ...kms.KeyOpts) (string, interface{}, error) {
 	startCreate := time.Now()
 
-	keyURL, _, err := r.createKey(kt, opts...)
+	keyURL, _, err := r.createKey(ctx, kt, opts...)
 	if err != nil {
 		return "", nil, err
 	}
@@ -277,7 +299,7 @@ func (r *RemoteKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interfa
 	return kid, keyURL, nil
 }
 
-func (r *RemoteKMS) createKey(kt kms.KeyType, opts ...kms.KeyOpts) (string, []byte, error) {
+func (r *RemoteKMS) createKey(ctx context.Context, kt kms.KeyType, opts ...kms.KeyOpts) (string, []byte, error) {
 	destination := r.keystoreURL + "/keys"
 
 	keyOpts := kms.NewKeyOpt()
@@ -287,8 +309,11 @@ func (r *RemoteKMS) createKey(kt kms.KeyType, opts ...kms.KeyOpts) (string, []by
 	}
 
 	httpReqJSON := &createKeyReq{
-		KeyType: kt,
-		Attrs:   keyOpts.Attrs(),
+		KeyType:   kt,
+		Attrs:     keyOpts.Attrs(),
+		Purpose:   keyOpts.Purpose(),
+		Labels:    keyOpts.Labels(),
+		ExpiresAt: keyOpts.ExpiresAt(),
 	}
 
 	marshaledReq, err := r.marshalFunc(httpReqJSON)
@@ -296,7 +321,7 @@ func (r *RemoteKMS) createKey(kt kms.KeyType, opts ...kms.KeyOpts) (string, []by
 		return "", nil, fmt.Errorf("failed to marshal Create key request [%s, %w]", destination, err)
 	}
 
-	resp, err := r.postHTTPRequest(destination, marshaledReq)
+	resp, err := r.postHTTPRequest(ctx, destination, marshaledReq)
 	if err != nil {
 		return "", nil, fmt.Errorf("posting Create key failed [%s, %w]", destination, err)
 	}
@@ -321,7 +346,7 @@ func (r *RemoteKMS) HealthCheck() error {
 		return err
 	}
 
-	resp, err := r.getHTTPRequest(parseURL.Scheme + "://" + parseURL.Host + "/healthcheck")
+	resp, err := r.getHTTPRequest(context.Background(), parseURL.Scheme + "://" + parseURL.Host + "/healthcheck")
 	if err != nil {
 		return err
 	}
@@ -344,6 +369,15 @@ func (r *RemoteKMS) Get(keyID string) (interface{}, error) {
 	return r.buildKIDURL(keyID), nil
 }
 
+// GetContext is Get honouring ctx cancellation. The handle is built locally, no remote call is made.
+func (r *RemoteKMS) GetContext(ctx context.Context, keyID string) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.Get(keyID)
+}
+
 func (r *RemoteKMS) buildKIDURL(keyID string) string {
 	return r.keystoreURL + "/keys/" + keyID
 }
@@ -358,18 +392,29 @@ func (r *RemoteKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (s
 	return "", nil, errors.New("function Rotate is not implemented in remoteKMS")
 }
 
+// RotateContext is not implemented in remoteKMS.
+func (r *RemoteKMS) RotateContext(_ context.Context, kt kms.KeyType, keyID string,
+	opts ...kms.KeyOpts) (string, interface{}, error) {
+	return r.Rotate(kt, keyID, opts...)
+}
+
 // ExportPubKeyBytes will remotely fetch a key referenced by id then gets its public key in raw bytes and returns it.
 // The key must be an asymmetric key.
 // Returns:
 //  - marshalled public key []byte
 //  - error if it fails to export the public key bytes
 func (r *RemoteKMS) ExportPubKeyBytes(keyID string) ([]byte, kms.KeyType, error) {
+	return r.ExportPubKeyBytesContext(context.Background(), keyID)
+}
+
+// ExportPubKeyBytesContext is ExportPubKeyBytes honouring ctx cancellation and deadline.
+func (r *RemoteKMS) ExportPubKeyBytesContext(ctx context.Context, keyID string) ([]byte, kms.KeyType, error) {
 	startExport := time.Now()
 	keyURL := r.buildKIDURL(keyID)
 
 	destination := keyURL + "/export"
 
-	resp, err := r.getHTTPRequest(destination)
+	resp, err := r.getHTTPRequest(ctx, destination)
 	if err != nil {
 		return nil, "", fmt.Errorf("posting GET ExportPubKeyBytes key failed [%s, %w]", destination, err)
 	}
@@ -396,9 +441,15 @@ func (r *RemoteKMS) ExportPubKeyBytes(keyID string) ([]byte, kms.KeyType, error)
 //  - marshalled public key []byte
 //  - error if it fails to export the public key bytes
 func (r *RemoteKMS) CreateAndExportPubKeyBytes(kt kms.KeyType, opts ...kms.KeyOpts) (string, []byte, error) {
+	return r.CreateAndExportPubKeyBytesContext(context.Background(), kt, opts...)
+}
+
+// CreateAndExportPubKeyBytesContext is CreateAndExportPubKeyBytes honouring ctx cancellation and deadline.
+func (r *RemoteKMS) CreateAndExportPubKeyBytesContext(ctx context.Context, kt kms.KeyType,
+	opts ...kms.KeyOpts) (string, []byte, error) {
 	start := time.Now()
 
-	keyURL, keyBytes, err := r.createKey(kt, opts...)
+	keyURL, keyBytes, err := r.createKey(ctx, kt, opts...)
 	if err != nil {
 		return "", nil, err
 	}
@@ -415,6 +466,12 @@ func (r *RemoteKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...k
 	return nil, errors.New("function PubKeyBytesToHandle is not implemented in remoteKMS")
 }
 
+// PubKeyBytesToHandleContext is not implemented in remoteKMS.
+func (r *RemoteKMS) PubKeyBytesToHandleContext(_ context.Context, pubKey []byte, kt kms.KeyType,
+	opts ...kms.KeyOpts) (interface{}, error) {
+	return r.PubKeyBytesToHandle(pubKey, kt, opts...)
+}
+
 // ImportPrivateKey will import privKey into the KMS storage for the given KeyType then returns the new key id and
 // the newly persisted Handle.
 // 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey
@@ -426,6 +483,12 @@ func (r *RemoteKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...k
 //  - handle instance (to private key)
 //  - error if import failure (key empty, invalid, doesn't match KeyType, unsupported KeyType or storing key failed)
 func (r *RemoteKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	return r.ImportPrivateKeyContext(context.Background(), privKey, kt, opts...)
+}
+
+// ImportPrivateKeyContext is ImportPrivateKey honouring ctx cancellation and deadline.
+func (r *RemoteKMS) ImportPrivateKeyContext(ctx context.Context, privKey interface{}, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
 	pOpts := kms.NewOpt()
 
@@ -451,7 +514,7 @@ func (r *RemoteKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 		return "", nil, fmt.Errorf("failed to marshal ImportKey request [%s, %w]", destination, err)
 	}
 
-	resp, err := r.putHTTPRequest(destination, marshaledReq)
+	resp, err := r.putHTTPRequest(ctx, destination, marshaledReq)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to post ImportKey request [%s, %w]", destination, err)
 	}
@@ -473,6 +536,115 @@ func (r *RemoteKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 	return kid, keyURL, nil
 }
 
+// List remotely fetches the metadata of keys in the keystore matching the given filter options.
+func (r *RemoteKMS) List(opts ...kms.ListOpts) ([]*kms.KeyMetadata, error) {
+	listOpts := kms.NewListOpt()
+
+	for _, opt := range opts {
+		opt(listOpts)
+	}
+
+	query := url.Values{}
+
+	if listOpts.Purpose() != "" {
+		query.Set("purpose", listOpts.Purpose())
+	}
+
+	for name, value := range listOpts.Labels() {
+		query.Add("label", name+":"+value)
+	}
+
+	destination := r.keystoreURL + "/keys"
+
+	if len(query) > 0 {
+		destination += "?" + query.Encode()
+	}
+
+	resp, err := r.getHTTPRequest(context.Background(), destination)
+	if err != nil {
+		return nil, fmt.Errorf("get List keys failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "List")
+
+	var httpResp listKeysResp
+
+	err = readResponse(resp, &httpResp, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("list keys failed [%s, %w]", destination, err)
+	}
+
+	return httpResp.Keys, nil
+}
+
+// Delete remotely deletes the key referenced by keyID.
+func (r *RemoteKMS) Delete(keyID string) error {
+	destination := r.buildKIDURL(keyID)
+
+	resp, err := r.deleteHTTPRequest(context.Background(), destination)
+	if err != nil {
+		return fmt.Errorf("delete Delete key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "Delete")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("delete key failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
+// GetMetadata remotely fetches the metadata of the key referenced by keyID.
+func (r *RemoteKMS) GetMetadata(keyID string) (*kms.KeyMetadata, error) {
+	destination := r.buildKIDURL(keyID) + "/metadata"
+
+	resp, err := r.getHTTPRequest(context.Background(), destination)
+	if err != nil {
+		return nil, fmt.Errorf("get GetMetadata key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "GetMetadata")
+
+	metadata := &kms.KeyMetadata{}
+
+	err = readResponse(resp, metadata, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("get key metadata failed [%s, %w]", destination, err)
+	}
+
+	return metadata, nil
+}
+
+// UpdateMetadata remotely replaces the purpose, labels, expiry and disabled state of the key referenced by keyID.
+func (r *RemoteKMS) UpdateMetadata(keyID string, metadata *kms.KeyMetadata) error {
+	destination := r.buildKIDURL(keyID) + "/metadata"
+
+	marshaledReq, err := r.marshalFunc(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal UpdateMetadata key request [%s, %w]", destination, err)
+	}
+
+	resp, err := r.putHTTPRequest(context.Background(), destination, marshaledReq)
+	if err != nil {
+		return fmt.Errorf("put UpdateMetadata key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "UpdateMetadata")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("update key metadata failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
 // closeResponseBody closes the response body.
 func closeResponseBody(respBody io.Closer, logger spi.Logger, action string) {
 	err := respBody.Close()
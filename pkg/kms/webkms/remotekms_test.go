@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package webkms
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
@@ -21,6 +22,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -299,6 +301,34 @@ func TestHealthCheck(t *testing.T) {
 	require.Contains(t, err.Error(), "kms health check return 503 status code")
 }
 
+func TestRemoteKeyStoreWithCancelledContext(t *testing.T) {
+	var _ kms.ContextKeyManager = (*RemoteKMS)(nil)
+
+	hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Fail(t, "request must not be sent with a cancelled context")
+	})
+
+	server := httptest.NewServer(hf)
+	defer server.Close()
+
+	defaultKeystoreURL := fmt.Sprintf("%s/%s", strings.ReplaceAll(KeystoreEndpoint,
+		"{serverEndpoint}", server.URL), defaultKeyStoreID)
+
+	remoteKMS := New(defaultKeystoreURL, server.Client())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := remoteKMS.CreateContext(ctx, kms.ED25519Type)
+	require.True(t, errors.Is(err, context.Canceled))
+
+	_, _, err = remoteKMS.ExportPubKeyBytesContext(ctx, defaultKID)
+	require.True(t, errors.Is(err, context.Canceled))
+
+	_, err = remoteKMS.GetContext(ctx, defaultKID)
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
 func TestRemoteKeyStoreWithHeadersFunc(t *testing.T) {
 	xRootCapabilityHeaderValue := []byte("DUMMY")
 
@@ -424,6 +454,89 @@ func TestImportPrivateKey(t *testing.T) {
 	})
 }
 
+func TestKeyLifecycle(t *testing.T) {
+	metadata := &kms.KeyMetadata{KeyID: defaultKID, KeyType: kms.ED25519Type, Purpose: "signing"}
+
+	hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case !strings.HasPrefix(r.URL.Path, "/v1/keystores/"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errMessage": "not found"}`)) // nolint:errcheck
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/keys"):
+			if r.URL.Query().Get("purpose") != "" && r.URL.Query().Get("purpose") != metadata.Purpose {
+				_, _ = w.Write([]byte(`{"keys": []}`)) // nolint:errcheck
+
+				return
+			}
+
+			require.NoError(t, json.NewEncoder(w).Encode(&listKeysResp{Keys: []*kms.KeyMetadata{metadata}}))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/metadata"):
+			require.NoError(t, json.NewEncoder(w).Encode(metadata))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/metadata"):
+			update := &kms.KeyMetadata{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(update))
+
+			metadata.Disabled = update.Disabled
+
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	server := httptest.NewServer(hf)
+	defer server.Close()
+
+	remoteKMS := New(server.URL+"/v1/keystores/"+defaultKeyStoreID, server.Client())
+
+	t.Run("list keys", func(t *testing.T) {
+		keys, err := remoteKMS.List()
+		require.NoError(t, err)
+		require.Equal(t, []*kms.KeyMetadata{metadata}, keys)
+
+		keys, err = remoteKMS.List(kms.WithPurposeFilter("encryption"), kms.WithLabelFilter("env", "test"))
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("get and update metadata", func(t *testing.T) {
+		m, err := remoteKMS.GetMetadata(defaultKID)
+		require.NoError(t, err)
+		require.Equal(t, metadata, m)
+
+		m.Disabled = true
+
+		require.NoError(t, remoteKMS.UpdateMetadata(defaultKID, m))
+		require.True(t, metadata.Disabled)
+
+		remoteKMS.marshalFunc = failingMarshal
+		defer func() { remoteKMS.marshalFunc = json.Marshal }()
+
+		err = remoteKMS.UpdateMetadata(defaultKID, m)
+		require.Contains(t, err.Error(), "failed to marshal UpdateMetadata key request")
+	})
+
+	t.Run("delete key", func(t *testing.T) {
+		require.NoError(t, remoteKMS.Delete(defaultKID))
+	})
+
+	t.Run("API errors", func(t *testing.T) {
+		tmpKMS := New(server.URL+"/unknown", server.Client())
+
+		_, err := tmpKMS.List()
+		require.Contains(t, err.Error(), "not found")
+
+		_, err = tmpKMS.GetMetadata("")
+		require.Contains(t, err.Error(), "not found")
+
+		err = tmpKMS.UpdateMetadata("", metadata)
+		require.Contains(t, err.Error(), "not found")
+
+		err = tmpKMS.Delete("")
+		require.Contains(t, err.Error(), "not found")
+	})
+}
+
 func TestCloseResponseBody(t *testing.T) {
 	closeResponseBody(&errFailingCloser{}, logger, "testing close fail should log: errFailingCloser always fails")
 }
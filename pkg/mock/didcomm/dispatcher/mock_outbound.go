@@ -7,6 +7,7 @@ package dispatcher
 
 import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
 // MockOutbound mock outbound dispatcher.
@@ -14,7 +15,9 @@ type MockOutbound struct {
 	ValidateSend      func(msg interface{}, senderVerKey string, des *service.Destination) error
 	ValidateSendToDID func(msg interface{}, myDID, theirDID string) error
 	ValidateForward   func(msg interface{}, des *service.Destination) error
-	SendErr           error
+	// ValidateSendToSession defaults to ValidateSendToDID if not set.
+	ValidateSendToSession func(msg interface{}, myDID, theirDID string, session transport.Session) error
+	SendErr               error
 }
 
 // Send msg.
@@ -35,6 +38,15 @@ func (m *MockOutbound) SendToDID(msg interface{}, myDID, theirDID string) error
 	return m.SendErr
 }
 
+// SendToSession msg.
+func (m *MockOutbound) SendToSession(msg interface{}, myDID, theirDID string, session transport.Session) error {
+	if m.ValidateSendToSession != nil {
+		return m.ValidateSendToSession(msg, myDID, theirDID, session)
+	}
+
+	return m.SendToDID(msg, myDID, theirDID)
+}
+
 // Forward msg.
 func (m *MockOutbound) Forward(msg interface{}, des *service.Destination) error {
 	if m.ValidateForward != nil {
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// Disclosure is a disclosed feature.
+type Disclosure = discoverfeatures.Disclosure
+
+// MockDiscoverFeaturesSvc mock discover features service.
+type MockDiscoverFeaturesSvc struct {
+	service.Handler
+	ProtocolName    string
+	QueryErr        error
+	QueryFunc       func(connectionID string, options ...discoverfeatures.QueryOption) ([]Disclosure, error)
+	DisclosuresErr  error
+	DisclosuresFunc func(connectionID string) ([]discoverfeatures.Disclosure, error)
+	Policy          discoverfeatures.DisclosurePolicy
+	GoalCodes       []string
+}
+
+// Initialize service.
+func (m *MockDiscoverFeaturesSvc) Initialize(interface{}) error {
+	return nil
+}
+
+// Name return service name.
+func (m *MockDiscoverFeaturesSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return discoverfeatures.DiscoverFeatures
+}
+
+// Accept msg checks the msg type.
+func (m *MockDiscoverFeaturesSvc) Accept(msgType string) bool {
+	return false
+}
+
+// Query perform Query.
+func (m *MockDiscoverFeaturesSvc) Query(connectionID string,
+	options ...discoverfeatures.QueryOption) ([]discoverfeatures.Disclosure, error) {
+	if m.QueryErr != nil {
+		return nil, m.QueryErr
+	}
+
+	if m.QueryFunc != nil {
+		return m.QueryFunc(connectionID, options...)
+	}
+
+	return []discoverfeatures.Disclosure{{
+		FeatureType: discoverfeatures.FeatureTypeProtocol,
+		ID:          "https://didcomm.org/trust-ping/2.0",
+	}}, nil
+}
+
+// Disclosures returns the saved disclosures.
+func (m *MockDiscoverFeaturesSvc) Disclosures(connectionID string) ([]discoverfeatures.Disclosure, error) {
+	if m.DisclosuresErr != nil {
+		return nil, m.DisclosuresErr
+	}
+
+	if m.DisclosuresFunc != nil {
+		return m.DisclosuresFunc(connectionID)
+	}
+
+	return nil, discoverfeatures.ErrNoDisclosures
+}
+
+// SetDisclosurePolicy sets the disclosure policy.
+func (m *MockDiscoverFeaturesSvc) SetDisclosurePolicy(policy discoverfeatures.DisclosurePolicy) {
+	m.Policy = policy
+}
+
+// AddGoalCodes adds goal codes.
+func (m *MockDiscoverFeaturesSvc) AddGoalCodes(goalCodes ...string) {
+	m.GoalCodes = append(m.GoalCodes, goalCodes...)
+}
@@ -30,6 +30,7 @@ type MockMediatorSvc struct {
 	Connections        []string
 	GetConnectionsErr  error
 	AddKeyFunc         func(string) error
+	KeylistQueryFunc   func(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error)
 }
 
 // Initialize service.
@@ -100,6 +101,15 @@ func (m *MockMediatorSvc) AddKey(connID, recKey string) error {
 	return nil
 }
 
+// KeylistQuery queries the keys routed by the router.
+func (m *MockMediatorSvc) KeylistQuery(connID string, paginate *mediator.Paginate) (*mediator.Keylist, error) {
+	if m.KeylistQueryFunc != nil {
+		return m.KeylistQueryFunc(connID, paginate)
+	}
+
+	return &mediator.Keylist{}, nil
+}
+
 // Config gives back the router configuration.
 func (m *MockMediatorSvc) Config(connID string) (*mediator.Config, error) {
 	if m.ConfigErr != nil {
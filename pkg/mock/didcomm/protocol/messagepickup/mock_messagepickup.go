@@ -14,18 +14,24 @@ import (
 // MockMessagePickupSvc mock messagepickup service.
 type MockMessagePickupSvc struct {
 	service.DIDComm
-	ProtocolName       string
-	StatusRequestErr   error
-	StatusRequestFunc  func(connectionID string) (*messagepickup.Status, error)
-	BatchPickupErr     error
-	BatchPickupFunc    func(connectionID string, size int) (int, error)
-	HandleInboundFunc  func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
-	HandleOutboundFunc func(_ service.DIDCommMsg, _, _ string) (string, error)
-	AddMessageFunc     func(message []byte, theirDID string) error
-	AddMessageErr      error
-	AcceptFunc         func(msgType string) bool
-	NoopErr            error
-	NoopFunc           func(connectionID string) error
+	ProtocolName               string
+	StatusRequestErr           error
+	StatusRequestFunc          func(connectionID string) (*messagepickup.Status, error)
+	BatchPickupErr             error
+	BatchPickupFunc            func(connectionID string, size int) (int, error)
+	HandleInboundFunc          func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
+	HandleOutboundFunc         func(_ service.DIDCommMsg, _, _ string) (string, error)
+	AddMessageFunc             func(message []byte, theirDID string) error
+	AddMessageForRecipientFunc func(message []byte, theirDID, recipientDID string) error
+	AddMessageErr              error
+	AcceptFunc                 func(msgType string) bool
+	NoopErr                    error
+	NoopFunc                   func(connectionID string) error
+	StatusRequestV3Err         error
+	StatusRequestV3Func        func(connectionID string) (*messagepickup.StatusV3, error)
+	DeliveryRequestErr         error
+	DeliveryRequestFunc        func(connectionID string, limit int) (int, error)
+	LiveDeliveryChangeErr      error
 }
 
 // Initialize service.
@@ -99,6 +105,19 @@ func (m *MockMessagePickupSvc) AddMessage(message []byte, theirDID string) error
 	return nil
 }
 
+// AddMessageForRecipient perform AddMessageForRecipient, defaults to AddMessage.
+func (m *MockMessagePickupSvc) AddMessageForRecipient(message []byte, theirDID, recipientDID string) error {
+	if m.AddMessageErr != nil {
+		return m.AddMessageErr
+	}
+
+	if m.AddMessageForRecipientFunc != nil {
+		return m.AddMessageForRecipientFunc(message, theirDID, recipientDID)
+	}
+
+	return m.AddMessage(message, theirDID)
+}
+
 // Noop perform Noop.
 func (m *MockMessagePickupSvc) Noop(connectionID string) error {
 	if m.NoopErr != nil {
@@ -111,3 +130,34 @@ func (m *MockMessagePickupSvc) Noop(connectionID string) error {
 
 	return nil
 }
+
+// StatusRequestV3 perform StatusRequestV3.
+func (m *MockMessagePickupSvc) StatusRequestV3(connectionID string) (*messagepickup.StatusV3, error) {
+	if m.StatusRequestV3Err != nil {
+		return nil, m.StatusRequestV3Err
+	}
+
+	if m.StatusRequestV3Func != nil {
+		return m.StatusRequestV3Func(connectionID)
+	}
+
+	return nil, nil
+}
+
+// DeliveryRequest perform DeliveryRequest.
+func (m *MockMessagePickupSvc) DeliveryRequest(connectionID string, limit int) (int, error) {
+	if m.DeliveryRequestErr != nil {
+		return 0, m.DeliveryRequestErr
+	}
+
+	if m.DeliveryRequestFunc != nil {
+		return m.DeliveryRequestFunc(connectionID, limit)
+	}
+
+	return 0, nil
+}
+
+// LiveDeliveryChange perform LiveDeliveryChange.
+func (m *MockMessagePickupSvc) LiveDeliveryChange(connectionID string, enabled bool) error {
+	return m.LiveDeliveryChangeErr
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// MockTrustPingSvc mock trust ping service.
+type MockTrustPingSvc struct {
+	service.Handler
+	ProtocolName string
+	PingErr      error
+	PingFunc     func(connectionID string, options ...trustping.PingOption) (*trustping.PingResult, error)
+}
+
+// Initialize service.
+func (m *MockTrustPingSvc) Initialize(interface{}) error {
+	return nil
+}
+
+// Name return service name.
+func (m *MockTrustPingSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return trustping.TrustPing
+}
+
+// Accept msg checks the msg type.
+func (m *MockTrustPingSvc) Accept(msgType string) bool {
+	return false
+}
+
+// Ping perform Ping.
+func (m *MockTrustPingSvc) Ping(connectionID string, options ...trustping.PingOption) (*trustping.PingResult, error) {
+	if m.PingErr != nil {
+		return nil, m.PingErr
+	}
+
+	if m.PingFunc != nil {
+		return m.PingFunc(connectionID, options...)
+	}
+
+	return &trustping.PingResult{PingID: "ping-id"}, nil
+}
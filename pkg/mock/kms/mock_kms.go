@@ -42,6 +42,12 @@ type KeyManager struct {
 	ImportPrivateKeyErr      error
 	ImportPrivateKeyID       string
 	ImportPrivateKeyValue    *keyset.Handle
+	ListValue                []*kmsservice.KeyMetadata
+	ListErr                  error
+	DeleteErr                error
+	GetMetadataValue         *kmsservice.KeyMetadata
+	GetMetadataErr           error
+	UpdateMetadataErr        error
 }
 
 // Create a new mock ey/keyset/key handle for the type kt.
@@ -115,6 +121,34 @@ func (k *KeyManager) ImportPrivateKey(privKey interface{}, keyType kmsservice.Ke
 	return k.ImportPrivateKeyID, k.ImportPrivateKeyValue, nil
 }
 
+// List returns mocked key metadata.
+func (k *KeyManager) List(opts ...kmsservice.ListOpts) ([]*kmsservice.KeyMetadata, error) {
+	if k.ListErr != nil {
+		return nil, k.ListErr
+	}
+
+	return k.ListValue, nil
+}
+
+// Delete emulates deleting a key.
+func (k *KeyManager) Delete(keyID string) error {
+	return k.DeleteErr
+}
+
+// GetMetadata returns mocked key metadata.
+func (k *KeyManager) GetMetadata(keyID string) (*kmsservice.KeyMetadata, error) {
+	if k.GetMetadataErr != nil {
+		return nil, k.GetMetadataErr
+	}
+
+	return k.GetMetadataValue, nil
+}
+
+// UpdateMetadata emulates updating key metadata.
+func (k *KeyManager) UpdateMetadata(keyID string, metadata *kmsservice.KeyMetadata) error {
+	return k.UpdateMetadataErr
+}
+
 func createMockKeyHandle(ks *tinkpb.Keyset) (*keyset.Handle, error) {
 	primaryKey := ks.Key[0]
 
@@ -0,0 +1,393 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// Module mocks a PKCS#11 module with a single software token supporting the EC mechanisms used by pkcs11kms.
+type Module struct {
+	TokenLabel         string
+	PIN                string
+	InitializeErr      error
+	GetSlotListErr     error
+	OpenSessionErr     error
+	CloseSessionErr    error
+	GenerateKeyPairErr error
+	CreateObjectErr    error
+	FindObjectsErr     error
+	GetAttributeErr    error
+	SignErr            error
+	DeriveKeyErr       error
+	LoggedIn           bool
+	Finalized          bool
+	Destroyed          bool
+	mu                 sync.Mutex
+	objects            map[pkcs11.ObjectHandle][]*pkcs11.Attribute
+	lastObject         pkcs11.ObjectHandle
+	found              []pkcs11.ObjectHandle
+	signKey            pkcs11.ObjectHandle
+}
+
+// Initialize initializes the module.
+func (m *Module) Initialize() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.objects == nil {
+		m.objects = map[pkcs11.ObjectHandle][]*pkcs11.Attribute{}
+	}
+
+	return m.InitializeErr
+}
+
+// Finalize finalizes the module.
+func (m *Module) Finalize() error {
+	m.Finalized = true
+
+	return nil
+}
+
+// Destroy unloads the module.
+func (m *Module) Destroy() {
+	m.Destroyed = true
+}
+
+// GetSlotList returns the slot of the token.
+func (m *Module) GetSlotList(bool) ([]uint, error) {
+	return []uint{0}, m.GetSlotListErr
+}
+
+// GetTokenInfo returns the label of the token.
+func (m *Module) GetTokenInfo(uint) (pkcs11.TokenInfo, error) {
+	return pkcs11.TokenInfo{Label: m.TokenLabel}, nil
+}
+
+// OpenSession opens a session.
+func (m *Module) OpenSession(uint, uint) (pkcs11.SessionHandle, error) {
+	return 1, m.OpenSessionErr
+}
+
+// CloseSession closes a session.
+func (m *Module) CloseSession(pkcs11.SessionHandle) error {
+	return m.CloseSessionErr
+}
+
+// Login logs in the user if pin is the PIN of the token.
+func (m *Module) Login(_ pkcs11.SessionHandle, _ uint, pin string) error {
+	if pin != m.PIN {
+		return pkcs11.Error(pkcs11.CKR_PIN_INCORRECT)
+	}
+
+	m.LoggedIn = true
+
+	return nil
+}
+
+// Logout logs out the user.
+func (m *Module) Logout(pkcs11.SessionHandle) error {
+	m.LoggedIn = false
+
+	return nil
+}
+
+// GenerateKeyPair generates an EC key pair on the curve of the CKA_EC_PARAMS of the public key template.
+func (m *Module) GenerateKeyPair(_ pkcs11.SessionHandle, mech []*pkcs11.Mechanism, public,
+	private []*pkcs11.Attribute) (pkcs11.ObjectHandle, pkcs11.ObjectHandle, error) {
+	if m.GenerateKeyPairErr != nil {
+		return 0, 0, m.GenerateKeyPairErr
+	}
+
+	if len(mech) != 1 || mech[0].Mechanism != pkcs11.CKM_EC_KEY_PAIR_GEN {
+		return 0, 0, pkcs11.Error(pkcs11.CKR_MECHANISM_INVALID)
+	}
+
+	ecParams := attribute(public, pkcs11.CKA_EC_PARAMS)
+
+	curve, err := curveOf(ecParams)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ecPoint, err := asn1.Marshal(elliptic.Marshal(curve, key.X, key.Y))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pub := m.add(append(public,
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, ecPoint)))
+	priv := m.add(append(private,
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, ecParams),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, key.D.Bytes())))
+
+	return pub, priv, nil
+}
+
+// CreateObject creates an object with the template.
+func (m *Module) CreateObject(_ pkcs11.SessionHandle, temp []*pkcs11.Attribute) (pkcs11.ObjectHandle, error) {
+	if m.CreateObjectErr != nil {
+		return 0, m.CreateObjectErr
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.add(temp), nil
+}
+
+// DestroyObject destroys an object.
+func (m *Module) DestroyObject(_ pkcs11.SessionHandle, oh pkcs11.ObjectHandle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.objects[oh]; !ok {
+		return pkcs11.Error(pkcs11.CKR_OBJECT_HANDLE_INVALID)
+	}
+
+	delete(m.objects, oh)
+
+	return nil
+}
+
+// GetAttributeValue returns the requested attributes of an object, the value of sensitive keys can't be read.
+func (m *Module) GetAttributeValue(_ pkcs11.SessionHandle, o pkcs11.ObjectHandle,
+	a []*pkcs11.Attribute) ([]*pkcs11.Attribute, error) {
+	if m.GetAttributeErr != nil {
+		return nil, m.GetAttributeErr
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attrs, ok := m.objects[o]
+	if !ok {
+		return nil, pkcs11.Error(pkcs11.CKR_OBJECT_HANDLE_INVALID)
+	}
+
+	result := make([]*pkcs11.Attribute, len(a))
+
+	for i, requested := range a {
+		if requested.Type == pkcs11.CKA_VALUE && bytes.Equal(attribute(attrs, pkcs11.CKA_SENSITIVE), []byte{1}) {
+			return nil, pkcs11.Error(pkcs11.CKR_ATTRIBUTE_SENSITIVE)
+		}
+
+		value := attribute(attrs, requested.Type)
+		if value == nil {
+			return nil, pkcs11.Error(pkcs11.CKR_ATTRIBUTE_TYPE_INVALID)
+		}
+
+		result[i] = pkcs11.NewAttribute(requested.Type, value)
+	}
+
+	return result, nil
+}
+
+// FindObjectsInit finds the objects matching the template.
+func (m *Module) FindObjectsInit(_ pkcs11.SessionHandle, temp []*pkcs11.Attribute) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.found = nil
+
+	for handle, attrs := range m.objects {
+		if matches(attrs, temp) {
+			m.found = append(m.found, handle)
+		}
+	}
+
+	return nil
+}
+
+// FindObjects returns the objects found by FindObjectsInit.
+func (m *Module) FindObjects(_ pkcs11.SessionHandle, max int) ([]pkcs11.ObjectHandle, bool, error) {
+	if m.FindObjectsErr != nil {
+		return nil, false, m.FindObjectsErr
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.found) < max {
+		max = len(m.found)
+	}
+
+	found := m.found[:max]
+	m.found = m.found[max:]
+
+	return found, false, nil
+}
+
+// FindObjectsFinal ends the search of objects.
+func (m *Module) FindObjectsFinal(pkcs11.SessionHandle) error {
+	m.found = nil
+
+	return nil
+}
+
+// SignInit initializes a CKM_ECDSA signature.
+func (m *Module) SignInit(_ pkcs11.SessionHandle, mech []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error {
+	if len(mech) != 1 || mech[0].Mechanism != pkcs11.CKM_ECDSA {
+		return pkcs11.Error(pkcs11.CKR_MECHANISM_INVALID)
+	}
+
+	m.signKey = o
+
+	return nil
+}
+
+// Sign signs the digest with the key of SignInit.
+func (m *Module) Sign(_ pkcs11.SessionHandle, digest []byte) ([]byte, error) {
+	if m.SignErr != nil {
+		return nil, m.SignErr
+	}
+
+	key, err := m.privateKey(m.signKey, pkcs11.CKA_SIGN)
+	if err != nil {
+		return nil, err
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	size := (key.Curve.Params().BitSize + 7) / 8
+
+	return append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...), nil
+}
+
+// DeriveKey derives a secret with CKM_ECDH1_DERIVE.
+func (m *Module) DeriveKey(_ pkcs11.SessionHandle, mech []*pkcs11.Mechanism, basekey pkcs11.ObjectHandle,
+	a []*pkcs11.Attribute) (pkcs11.ObjectHandle, error) {
+	if m.DeriveKeyErr != nil {
+		return 0, m.DeriveKeyErr
+	}
+
+	if len(mech) != 1 || mech[0].Mechanism != pkcs11.CKM_ECDH1_DERIVE {
+		return 0, pkcs11.Error(pkcs11.CKR_MECHANISM_INVALID)
+	}
+
+	key, err := m.privateKey(basekey, pkcs11.CKA_DERIVE)
+	if err != nil {
+		return 0, err
+	}
+
+	x, y := elliptic.Unmarshal(key.Curve, ecdh1DerivePublicKey(mech[0]))
+	if x == nil {
+		return 0, pkcs11.Error(pkcs11.CKR_MECHANISM_PARAM_INVALID)
+	}
+
+	z, _ := key.Curve.ScalarMult(x, y, key.D.Bytes())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.add(append(a, pkcs11.NewAttribute(pkcs11.CKA_VALUE,
+		z.FillBytes(make([]byte, (key.Curve.Params().BitSize+7)/8))))), nil
+}
+
+// ecdh1DerivePublicKey reads the public key data of the ECDH1 derive parameters of the mechanism, which are
+// not exported by the mechanism.
+func ecdh1DerivePublicKey(mech *pkcs11.Mechanism) []byte {
+	params := reflect.ValueOf(mech).Elem().FieldByName("generator").Elem()
+	if params.Kind() != reflect.Ptr {
+		return nil
+	}
+
+	return params.Elem().FieldByName("PublicKeyData").Bytes()
+}
+
+func (m *Module) privateKey(o pkcs11.ObjectHandle, usage uint) (*ecdsa.PrivateKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attrs, ok := m.objects[o]
+	if !ok {
+		return nil, pkcs11.Error(pkcs11.CKR_KEY_HANDLE_INVALID)
+	}
+
+	if !bytes.Equal(attribute(attrs, usage), []byte{1}) {
+		return nil, pkcs11.Error(pkcs11.CKR_KEY_FUNCTION_NOT_PERMITTED)
+	}
+
+	curve, err := curveOf(attribute(attrs, pkcs11.CKA_EC_PARAMS))
+	if err != nil {
+		return nil, err
+	}
+
+	key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(attribute(attrs, pkcs11.CKA_VALUE))}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(key.D.Bytes())
+
+	return key, nil
+}
+
+func (m *Module) add(attrs []*pkcs11.Attribute) pkcs11.ObjectHandle {
+	m.lastObject++
+	m.objects[m.lastObject] = attrs
+
+	return m.lastObject
+}
+
+func attribute(attrs []*pkcs11.Attribute, typ uint) []byte {
+	for _, attr := range attrs {
+		if attr.Type == typ {
+			return attr.Value
+		}
+	}
+
+	return nil
+}
+
+func matches(attrs, temp []*pkcs11.Attribute) bool {
+	for _, attr := range temp {
+		value := attribute(attrs, attr.Type)
+		if value == nil || !bytes.Equal(value, attr.Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func curveOf(ecParams []byte) (elliptic.Curve, error) {
+	var oid asn1.ObjectIdentifier
+
+	if _, err := asn1.Unmarshal(ecParams, &oid); err != nil {
+		return nil, fmt.Errorf("invalid EC params: %w", err)
+	}
+
+	switch {
+	case oid.Equal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}):
+		return elliptic.P256(), nil
+	case oid.Equal(asn1.ObjectIdentifier{1, 3, 132, 0, 34}):
+		return elliptic.P384(), nil
+	}
+
+	return nil, pkcs11.Error(pkcs11.CKR_CURVE_NOT_SUPPORTED)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package challenge
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for challenge store.
+	NameSpace = "challenge"
+
+	// DefaultTTL is the default time to live of the issued challenges.
+	DefaultTTL = 5 * time.Minute
+
+	challengeTag  = "challenge"
+	challengeSize = 32
+)
+
+var (
+	// ErrChallengeNotFound is returned when the challenge was not issued or was already used.
+	ErrChallengeNotFound = errors.New("challenge was not issued or was already used")
+	// ErrChallengeExpired is returned when the challenge is expired.
+	ErrChallengeExpired = errors.New("challenge is expired")
+	// ErrDomainMismatch is returned when the challenge was issued for another domain.
+	ErrDomainMismatch = errors.New("challenge was issued for another domain")
+)
+
+var logger = log.New("aries-framework/store/challenge")
+
+// Challenge is a single-use challenge issued by the verifier to be put into the presentation proof.
+type Challenge struct {
+	// Value of the challenge ("challenge" of the embedded proof or "nonce" of JWT presentation).
+	Value string `json:"value"`
+	// Domain the challenge is issued for ("domain" of the embedded proof or "aud" of JWT presentation).
+	// The domain is not checked if empty.
+	Domain string `json:"domain,omitempty"`
+	// ExpiresAt is the time when the challenge expires.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Opt represents option function.
+type Opt func(s *Store)
+
+// WithTTL sets time to live of the issued challenges, DefaultTTL is used by default.
+func WithTTL(ttl time.Duration) Opt {
+	return func(s *Store) {
+		s.ttl = ttl
+	}
+}
+
+// Store issues and verifies single-use challenges of the verifier.
+type Store struct {
+	store storage.Store
+	ttl   time.Duration
+	now   func() time.Time
+	// mu makes getting and deleting the verified challenge atomic, so that it cannot be used twice concurrently.
+	mu sync.Mutex
+}
+
+// Store is used by verifiable.WithPresChallengeVerifier option to check the challenges of the presentations.
+var _ verifiable.ChallengeVerifier = (*Store)(nil)
+
+type provider interface {
+	StorageProvider() storage.Provider
+}
+
+// New returns a new challenge store.
+func New(ctx provider, opts ...Opt) (*Store, error) {
+	store, err := ctx.StorageProvider().OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open challenge store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(NameSpace, storage.StoreConfiguration{TagNames: []string{challengeTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	s := &Store{store: store, ttl: DefaultTTL, now: time.Now}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// Issue issues a new challenge for the domain. The domain may be empty if it is not checked.
+func (s *Store) Issue(domain string) (*Challenge, error) {
+	value := make([]byte, challengeSize)
+
+	_, err := rand.Read(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	challenge := &Challenge{
+		Value:     base64.RawURLEncoding.EncodeToString(value),
+		Domain:    domain,
+		ExpiresAt: s.now().Add(s.ttl).UTC(),
+	}
+
+	challengeBytes, err := json.Marshal(challenge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal challenge: %w", err)
+	}
+
+	err = s.store.Put(challenge.Value, challengeBytes, storage.Tag{Name: challengeTag})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save challenge: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// VerifyChallenge checks that the challenge was issued for one of the domains, is not expired and was not used
+// before. The challenge is removed by the check, so it cannot be used again.
+func (s *Store) VerifyChallenge(value string, domains []string) error {
+	challengeBytes, err := s.consume(value)
+	if err != nil {
+		return err
+	}
+
+	var challenge Challenge
+
+	err = json.Unmarshal(challengeBytes, &challenge)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal challenge: %w", err)
+	}
+
+	if s.now().After(challenge.ExpiresAt) {
+		return ErrChallengeExpired
+	}
+
+	if challenge.Domain != "" && !contains(domains, challenge.Domain) {
+		return ErrDomainMismatch
+	}
+
+	return nil
+}
+
+// consume gets and deletes the challenge.
+func (s *Store) consume(value string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	challengeBytes, err := s.store.Get(value)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrChallengeNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get challenge: %w", err)
+	}
+
+	err = s.store.Delete(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete challenge: %w", err)
+	}
+
+	return challengeBytes, nil
+}
+
+// RemoveExpired removes the expired challenges from the store.
+func (s *Store) RemoveExpired() error {
+	iter, err := s.store.Query(challengeTag)
+	if err != nil {
+		return fmt.Errorf("failed to query challenges: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var expired []string
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("failed to get next challenge: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		challengeBytes, err := iter.Value()
+		if err != nil {
+			return fmt.Errorf("failed to get challenge: %w", err)
+		}
+
+		var challenge Challenge
+
+		err = json.Unmarshal(challengeBytes, &challenge)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal challenge: %w", err)
+		}
+
+		if s.now().After(challenge.ExpiresAt) {
+			expired = append(expired, challenge.Value)
+		}
+	}
+
+	for _, value := range expired {
+		err = s.store.Delete(value)
+		if err != nil {
+			return fmt.Errorf("failed to delete challenge: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,219 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package challenge
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new store", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mem.NewProvider(),
+		}, WithTTL(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, time.Minute, s.ttl)
+	})
+
+	t.Run("test error from open store", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: fmt.Errorf("failed to open store"),
+			},
+		})
+		require.EqualError(t, err, "failed to open challenge store: failed to open store")
+		require.Nil(t, s)
+	})
+
+	t.Run("test error from set store config", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				Store:             &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)},
+				ErrSetStoreConfig: fmt.Errorf("failed to set store config"),
+			},
+		})
+		require.EqualError(t, err, "failed to set store configuration: failed to set store config")
+		require.Nil(t, s)
+	})
+}
+
+func TestStore_VerifyChallenge(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return now }
+
+	t.Run("single use", func(t *testing.T) {
+		challenge, err := s.Issue("example.com")
+		require.NoError(t, err)
+		require.NotEmpty(t, challenge.Value)
+		require.Equal(t, "example.com", challenge.Domain)
+		require.Equal(t, now.Add(DefaultTTL), challenge.ExpiresAt)
+
+		other, err := s.Issue("")
+		require.NoError(t, err)
+		require.NotEqual(t, challenge.Value, other.Value)
+
+		require.NoError(t, s.VerifyChallenge(challenge.Value, []string{"other.com", "example.com"}))
+		require.ErrorIs(t, s.VerifyChallenge(challenge.Value, []string{"example.com"}), ErrChallengeNotFound)
+
+		require.NoError(t, s.VerifyChallenge(other.Value, nil))
+	})
+
+	t.Run("concurrent replay", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+
+		// widen the window between getting and deleting the challenge.
+		s.store = &slowGetStore{Store: s.store}
+
+		challenge, err := s.Issue("")
+		require.NoError(t, err)
+
+		const replays = 20
+
+		var (
+			wg       sync.WaitGroup
+			verified int32
+		)
+
+		start := make(chan struct{})
+
+		for i := 0; i < replays; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				<-start
+
+				if s.VerifyChallenge(challenge.Value, nil) == nil {
+					atomic.AddInt32(&verified, 1)
+				}
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+
+		require.Equal(t, int32(1), verified)
+	})
+
+	t.Run("unknown challenge", func(t *testing.T) {
+		require.ErrorIs(t, s.VerifyChallenge("unknown", nil), ErrChallengeNotFound)
+	})
+
+	t.Run("domain mismatch", func(t *testing.T) {
+		challenge, err := s.Issue("example.com")
+		require.NoError(t, err)
+
+		require.ErrorIs(t, s.VerifyChallenge(challenge.Value, []string{"other.com"}), ErrDomainMismatch)
+		require.ErrorIs(t, s.VerifyChallenge(challenge.Value, []string{"example.com"}), ErrChallengeNotFound)
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		challenge, err := s.Issue("")
+		require.NoError(t, err)
+
+		s.now = func() time.Time { return now.Add(DefaultTTL + time.Second) }
+		defer func() { s.now = func() time.Time { return now } }()
+
+		require.ErrorIs(t, s.VerifyChallenge(challenge.Value, nil), ErrChallengeExpired)
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)}
+
+		s, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{Store: store}})
+		require.NoError(t, err)
+
+		store.ErrPut = errors.New("put error")
+
+		_, err = s.Issue("")
+		require.EqualError(t, err, "failed to save challenge: put error")
+
+		store.ErrGet = errors.New("get error")
+
+		require.EqualError(t, s.VerifyChallenge("challenge", nil), "failed to get challenge: get error")
+
+		store.ErrGet = nil
+		store.ErrDelete = errors.New("delete error")
+		store.Store["challenge"] = mockstore.DBEntry{Value: []byte("invalid")}
+
+		require.EqualError(t, s.VerifyChallenge("challenge", nil), "failed to delete challenge: delete error")
+
+		store.ErrDelete = nil
+		store.Store["challenge"] = mockstore.DBEntry{Value: []byte("invalid")}
+
+		require.Contains(t, s.VerifyChallenge("challenge", nil).Error(), "failed to unmarshal challenge")
+	})
+}
+
+func TestStore_RemoveExpired(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	provider := mem.NewProvider()
+
+	s, err := New(&mockprovider.Provider{StorageProviderValue: provider}, WithTTL(time.Minute))
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return now }
+
+	expired, err := s.Issue("")
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return now.Add(time.Minute) }
+
+	valid, err := s.Issue("")
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return now.Add(90 * time.Second) }
+
+	require.NoError(t, s.RemoveExpired())
+
+	_, err = s.store.Get(expired.Value)
+	require.ErrorIs(t, err, storage.ErrDataNotFound)
+
+	require.NoError(t, s.VerifyChallenge(valid.Value, nil))
+
+	t.Run("query error", func(t *testing.T) {
+		store := &mockstore.MockStore{
+			Store:    make(map[string]mockstore.DBEntry),
+			ErrQuery: errors.New("query error"),
+		}
+
+		s, err := New(&mockprovider.Provider{StorageProviderValue: &mockstore.MockStoreProvider{Store: store}})
+		require.NoError(t, err)
+
+		require.EqualError(t, s.RemoveExpired(), "failed to query challenges: query error")
+	})
+}
+
+type slowGetStore struct {
+	storage.Store
+}
+
+func (s *slowGetStore) Get(key string) ([]byte, error) {
+	value, err := s.Store.Get(key)
+
+	time.Sleep(10 * time.Millisecond)
+
+	return value, err
+}
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package httpbinding
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -27,8 +28,8 @@ const (
 )
 
 // resolveDID makes DID resolution via HTTP.
-func (v *VDR) resolveDID(uri string) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, uri, nil)
+func (v *VDR) resolveDID(ctx context.Context, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP create get request failed: %w", err)
 	}
@@ -75,7 +76,13 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 }
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
-func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) { //nolint: funlen,gocyclo
+func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return v.ReadContext(context.Background(), didID, opts...)
+}
+
+// ReadContext resolves the DID, the HTTP request is cancelled when the context is done.
+func (v *VDR) ReadContext(ctx context.Context, didID string, //nolint: funlen,gocyclo
+	opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
 	didMethodOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
 
 	// Apply options
@@ -123,7 +130,7 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 		reqURL.RawQuery = fmt.Sprintf("versionTime=%s", versionTime)
 	}
 
-	data, err := v.resolveDID(reqURL.String())
+	data, err := v.resolveDID(ctx, reqURL.String())
 	if err != nil {
 		return nil, err
 	}
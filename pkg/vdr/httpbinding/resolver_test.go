@@ -5,6 +5,7 @@ SPDX-License-Identifier: Apache-2.0
 package httpbinding
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -92,6 +93,24 @@ func TestNew(t *testing.T) {
 }
 
 func TestRead_DIDDoc(t *testing.T) {
+	t.Run("test cancelled context", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Fail(t, "request must not be sent with a cancelled context")
+		}))
+
+		defer func() { testServer.Close() }()
+
+		resolver, err := New(testServer.URL)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		gotDocument, err := resolver.ReadContext(ctx, "did:example:334455")
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, gotDocument)
+	})
+
 	t.Run("test success return did doc", func(t *testing.T) {
 		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 			require.Equal(t, "/did:example:334455", req.URL.String())
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package httpbinding
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -80,6 +81,22 @@ func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
 	return fmt.Errorf("not supported")
 }
 
+// CreateContext did doc.
+func (v *VDR) CreateContext(_ context.Context, didDoc *did.Doc,
+	opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return v.Create(didDoc, opts...)
+}
+
+// UpdateContext did doc.
+func (v *VDR) UpdateContext(_ context.Context, didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return v.Update(didDoc, opts...)
+}
+
+// DeactivateContext did doc.
+func (v *VDR) DeactivateContext(_ context.Context, didID string, opts ...vdrapi.DIDMethodOption) error {
+	return v.Deactivate(didID, opts...)
+}
+
 // Option configures the peer vdr.
 type Option func(opts *VDR)
 
@@ -55,16 +55,67 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 			return nil, fmt.Errorf("create peer DID : %w", err)
 		}
 
-		didDoc = docResolution.DIDDocument
+		didDoc, err = v.applyNumalgo(docResolution.DIDDocument, docOpts)
+		if err != nil {
+			return nil, fmt.Errorf("create peer DID : %w", err)
+		}
 	}
 
-	if err := v.storeDID(didDoc, nil); err != nil {
+	if err := v.storeDIDDoc(didDoc); err != nil {
 		return nil, err
 	}
 
 	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
 }
 
+// storeDIDDoc saves the DID Document, and its short form doc in case of a long form numalgo 4 peer DID,
+// so the short form can be resolved later.
+func (v *VDR) storeDIDDoc(didDoc *did.Doc) error {
+	if isDidMethod4LongForm(didDoc.ID) {
+		shortFormDoc, err := resolveDidMethod4(didDoc.ID, true)
+		if err != nil {
+			return err
+		}
+
+		if err = v.storeDID(shortFormDoc, nil); err != nil {
+			return err
+		}
+	}
+
+	return v.storeDID(didDoc, nil)
+}
+
+// applyNumalgo replaces the numalgo 1 DID of the built didDoc with the numalgo requested in docOpts.
+func (v *VDR) applyNumalgo(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.Doc, error) {
+	numalgo := numAlgo
+
+	if opt := docOpts.Values[NumalgoOpt]; opt != nil {
+		// accept the numalgo as a number or a string, eg from JSON options
+		numalgo = fmt.Sprint(opt)
+	}
+
+	switch numalgo {
+	case numAlgo:
+		return didDoc, nil
+	case numAlgo2:
+		didID, err := computeDidMethod2(didDoc)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolveDidMethod2(didID)
+	case numAlgo4:
+		didID, err := computeDidMethod4(didDoc)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolveDidMethod4(didID, false)
+	default:
+		return nil, fmt.Errorf("numalgo %s not supported", numalgo)
+	}
+}
+
 //nolint: funlen,gocyclo,gocognit
 func build(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution, error) {
 	if len(didDoc.VerificationMethod) == 0 && len(didDoc.KeyAgreement) == 0 {
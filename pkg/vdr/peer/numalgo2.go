@@ -0,0 +1,366 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+// Reference: https://identity.foundation/peer-did-method-spec/#method-2-multiple-inception-key-without-doc
+const (
+	numAlgo2 = "2"
+
+	purposeAssertion            = 'A'
+	purposeEncryption           = 'E'
+	purposeVerification         = 'V'
+	purposeCapabilityInvocation = 'I'
+	purposeCapabilityDelegation = 'D'
+	purposeService              = 'S'
+
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	x25519KeyAgreementKey2020  = "X25519KeyAgreementKey2020"
+	bls12381G2Key2020          = "Bls12381G2Key2020"
+
+	didCommMessagingAbbreviation = "dm"
+)
+
+var didMethod2Regex = regexp.MustCompile(`^did:peer:2(\.[AEVIDS][a-zA-Z0-9_\-=]+)+$`)
+
+// serviceAbbreviations maps the service properties to their abbreviation in a numalgo 2 DID.
+// nolint:gochecknoglobals
+var serviceAbbreviations = map[string]string{
+	"type":            "t",
+	"serviceEndpoint": "s",
+	"routingKeys":     "r",
+	"accept":          "a",
+}
+
+// computeDidMethod2 creates the numalgo 2 peer DID of doc, inlining its keys and abbreviated services.
+// For example: did:peer:2.Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V.Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc.
+func computeDidMethod2(doc *did.Doc) (string, error) {
+	elements := []string{peerPrefix + numAlgo2}
+
+	relationships := []struct {
+		purpose       byte
+		verifications []did.Verification
+	}{
+		{purposeVerification, doc.Authentication},
+		{purposeEncryption, doc.KeyAgreement},
+		{purposeAssertion, doc.AssertionMethod},
+		{purposeCapabilityInvocation, doc.CapabilityInvocation},
+		{purposeCapabilityDelegation, doc.CapabilityDelegation},
+	}
+
+	for _, rel := range relationships {
+		for i := range rel.verifications {
+			key, err := multibaseKey(&rel.verifications[i].VerificationMethod)
+			if err != nil {
+				return "", err
+			}
+
+			elements = append(elements, string(rel.purpose)+key)
+		}
+	}
+
+	if len(elements) == 1 {
+		return "", errors.New("the numalgo 2 peer DID must include at least one key")
+	}
+
+	for i := range doc.Service {
+		svc, err := abbreviateService(&doc.Service[i])
+		if err != nil {
+			return "", err
+		}
+
+		elements = append(elements, string(purposeService)+svc)
+	}
+
+	return strings.Join(elements, "."), nil
+}
+
+func multibaseKey(vm *did.VerificationMethod) (string, error) {
+	switch vm.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		return fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, vm.Value), nil
+	case x25519KeyAgreementKey2019, x25519KeyAgreementKey2020:
+		return fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, vm.Value), nil
+	case bls12381G2Key2020:
+		return fingerprint.KeyFingerprint(fingerprint.BLS12381g2PubKeyMultiCodec, vm.Value), nil
+	case jsonWebKey2020:
+		_, keyID, err := fingerprint.CreateDIDKeyByJwk(vm.JSONWebKey())
+		if err != nil {
+			return "", fmt.Errorf("encode %s key: %w", vm.ID, err)
+		}
+
+		return keyID[strings.Index(keyID, "#")+1:], nil
+	default:
+		return "", fmt.Errorf("not supported verification method type for numalgo 2: %s", vm.Type)
+	}
+}
+
+func abbreviateService(svc *did.Service) (string, error) {
+	abbreviated := map[string]interface{}{}
+
+	svcType, ok := svc.Type.(string)
+	if !ok {
+		return "", fmt.Errorf("service %s type is not a string", svc.ID)
+	}
+
+	if svcType == vdrapi.DIDCommV2ServiceType {
+		svcType = didCommMessagingAbbreviation
+	}
+
+	abbreviated["t"] = svcType
+
+	uri, err := svc.ServiceEndpoint.URI()
+	if err != nil {
+		return "", fmt.Errorf("service %s endpoint: %w", svc.ID, err)
+	}
+
+	if svc.ServiceEndpoint.Type() == model.DIDCommV2 {
+		endpoint := map[string]interface{}{"uri": uri}
+
+		if accept, e := svc.ServiceEndpoint.Accept(); e == nil && len(accept) > 0 {
+			endpoint["a"] = accept
+		}
+
+		if routingKeys, e := svc.ServiceEndpoint.RoutingKeys(); e == nil && len(routingKeys) > 0 {
+			endpoint["r"] = routingKeys
+		}
+
+		abbreviated["s"] = endpoint
+	} else {
+		abbreviated["s"] = uri
+
+		if len(svc.RoutingKeys) > 0 {
+			abbreviated["r"] = svc.RoutingKeys
+		}
+
+		if len(svc.Accept) > 0 {
+			abbreviated["a"] = svc.Accept
+		}
+
+		// recipientKeys has no abbreviation, it is kept as is
+		if len(svc.RecipientKeys) > 0 {
+			abbreviated["recipientKeys"] = svc.RecipientKeys
+		}
+	}
+
+	svcBytes, err := json.Marshal(abbreviated)
+	if err != nil {
+		return "", fmt.Errorf("marshal service %s: %w", svc.ID, err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(svcBytes), nil
+}
+
+// resolveDidMethod2 statically resolves a numalgo 2 peer DID into its DID document.
+func resolveDidMethod2(didID string) (*did.Doc, error) { //nolint:funlen,gocyclo
+	if !didMethod2Regex.MatchString(didID) {
+		return nil, errors.New("did doesnt follow matching regex")
+	}
+
+	raw := map[string]interface{}{
+		"@context": []string{did.ContextV1},
+		"id":       didID,
+	}
+
+	var (
+		verificationMethods []interface{}
+		services            []interface{}
+		keyAgreements       []interface{}
+	)
+
+	relationships := map[byte]string{
+		purposeAssertion:            "assertionMethod",
+		purposeEncryption:           "keyAgreement",
+		purposeVerification:         "authentication",
+		purposeCapabilityInvocation: "capabilityInvocation",
+		purposeCapabilityDelegation: "capabilityDelegation",
+	}
+
+	for _, element := range strings.Split(didID, ".")[1:] {
+		purpose, value := element[0], element[1:]
+
+		if purpose == purposeService {
+			svc, err := expandService(value)
+			if err != nil {
+				return nil, err
+			}
+
+			services = append(services, svc)
+
+			continue
+		}
+
+		vmID := fmt.Sprintf("#key-%d", len(verificationMethods)+1)
+
+		vm, err := verificationMethodFromMultibase(vmID, didID, value)
+		if err != nil {
+			return nil, err
+		}
+
+		verificationMethods = append(verificationMethods, vm)
+
+		refs, _ := raw[relationships[purpose]].([]interface{}) //nolint:errcheck
+		raw[relationships[purpose]] = append(refs, vmID)
+
+		if purpose == purposeEncryption {
+			keyAgreements = append(keyAgreements, vmID)
+		}
+	}
+
+	raw["verificationMethod"] = verificationMethods
+
+	for i, svc := range services {
+		svcMap, _ := svc.(map[string]interface{}) //nolint:errcheck
+
+		if _, ok := svcMap["id"]; !ok {
+			svcMap["id"] = "#service"
+
+			if i > 0 {
+				svcMap["id"] = fmt.Sprintf("#service-%d", i)
+			}
+		}
+
+		if svcMap["type"] == vdrapi.DIDCommV2ServiceType {
+			svcMap["recipientKeys"] = keyAgreements
+		}
+	}
+
+	if len(services) > 0 {
+		raw["service"] = services
+	}
+
+	docBytes, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("marshal did doc: %w", err)
+	}
+
+	return did.ParseDocument(docBytes)
+}
+
+func verificationMethodFromMultibase(vmID, controller, value string) (map[string]interface{}, error) {
+	pubKey, code, err := fingerprint.PubKeyFromFingerprint(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode key %s: %w", vmID, err)
+	}
+
+	vm := map[string]interface{}{
+		"id":         vmID,
+		"controller": controller,
+	}
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		vm["type"] = ed25519VerificationKey2018
+		vm["publicKeyBase58"] = base58.Encode(pubKey)
+	case fingerprint.X25519PubKeyMultiCodec:
+		vm["type"] = x25519KeyAgreementKey2019
+		vm["publicKeyBase58"] = base58.Encode(pubKey)
+	case fingerprint.BLS12381g2PubKeyMultiCodec, fingerprint.BLS12381g1g2PubKeyMultiCodec:
+		vm["type"] = bls12381G2Key2020
+		vm["publicKeyBase58"] = base58.Encode(pubKey)
+	case fingerprint.P256PubKeyMultiCodec, fingerprint.P384PubKeyMultiCodec, fingerprint.P521PubKeyMultiCodec:
+		curve := map[uint64]elliptic.Curve{
+			fingerprint.P256PubKeyMultiCodec: elliptic.P256(),
+			fingerprint.P384PubKeyMultiCodec: elliptic.P384(),
+			fingerprint.P521PubKeyMultiCodec: elliptic.P521(),
+		}[code]
+
+		x, y := elliptic.UnmarshalCompressed(curve, pubKey)
+		if x == nil {
+			return nil, fmt.Errorf("decode key %s: error unmarshalling key bytes", vmID)
+		}
+
+		j, err := jwksupport.JWKFromKey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+		if err != nil {
+			return nil, fmt.Errorf("decode key %s: %w", vmID, err)
+		}
+
+		vm["type"] = jsonWebKey2020
+		vm["publicKeyJwk"] = j
+	default:
+		return nil, fmt.Errorf("decode key %s: unsupported key multicodec code [0x%x]", vmID, code)
+	}
+
+	return vm, nil
+}
+
+func expandService(value string) (map[string]interface{}, error) {
+	svcBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode service: %w", err)
+	}
+
+	abbreviated := map[string]interface{}{}
+
+	err = json.Unmarshal(svcBytes, &abbreviated)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal service: %w", err)
+	}
+
+	svc := expandAbbreviations(abbreviated)
+
+	if svc["type"] == didCommMessagingAbbreviation {
+		svc["type"] = vdrapi.DIDCommV2ServiceType
+	}
+
+	if svc["type"] != vdrapi.DIDCommV2ServiceType {
+		return svc, nil
+	}
+
+	// DIDComm V2 endpoints are an array of {uri, accept, routingKeys} objects in this framework, either
+	// abbreviated inside "s" or (legacy encoding) as the service's own properties.
+	endpoint, ok := svc["serviceEndpoint"].(map[string]interface{})
+	if !ok {
+		endpoint = map[string]interface{}{"uri": svc["serviceEndpoint"]}
+
+		for _, k := range []string{"accept", "routingKeys"} {
+			if v, exists := svc[k]; exists {
+				endpoint[k] = v
+				delete(svc, k)
+			}
+		}
+	}
+
+	svc["serviceEndpoint"] = []interface{}{expandAbbreviations(endpoint)}
+
+	return svc, nil
+}
+
+func expandAbbreviations(abbreviated map[string]interface{}) map[string]interface{} {
+	expanded := make(map[string]interface{}, len(abbreviated))
+
+	for k, v := range abbreviated {
+		expanded[k] = v
+
+		for name, abbreviation := range serviceAbbreviations {
+			if k == abbreviation {
+				delete(expanded, k)
+				expanded[name] = v
+			}
+		}
+	}
+
+	return expanded
+}
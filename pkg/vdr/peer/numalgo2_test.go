@@ -0,0 +1,153 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+// nolint:lll
+const (
+	numalgo2DID = "did:peer:2.Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc.Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V.SeyJ0IjoiZG0iLCJzIjp7InVyaSI6Imh0dHBzOi8vZXhhbXBsZS5jb20vZW5kcG9pbnQiLCJyIjpbImRpZDpleGFtcGxlOnNvbWVtZWRpYXRvciNzb21la2V5Il0sImEiOlsiZGlkY29tbS92MiJdfX0"
+)
+
+func TestNumalgo2(t *testing.T) {
+	t.Run("test resolve", func(t *testing.T) {
+		doc, err := resolveDidMethod2(numalgo2DID)
+		require.NoError(t, err)
+		require.Equal(t, numalgo2DID, doc.ID)
+		require.Len(t, doc.VerificationMethod, 2)
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, numalgo2DID+"#key-1", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, x25519KeyAgreementKey2019, doc.KeyAgreement[0].VerificationMethod.Type)
+
+		require.Len(t, doc.Authentication, 1)
+		require.Equal(t, numalgo2DID+"#key-2", doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, ed25519VerificationKey2018, doc.Authentication[0].VerificationMethod.Type)
+
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, vdrapi.DIDCommV2ServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{numalgo2DID + "#key-1"}, doc.Service[0].RecipientKeys)
+
+		uri, err := doc.Service[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/endpoint", uri)
+
+		routingKeys, err := doc.Service[0].ServiceEndpoint.RoutingKeys()
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:somemediator#somekey"}, routingKeys)
+
+		accept, err := doc.Service[0].ServiceEndpoint.Accept()
+		require.NoError(t, err)
+		require.Equal(t, []string{"didcomm/v2"}, accept)
+	})
+
+	t.Run("test compute and resolve round trip", func(t *testing.T) {
+		doc, err := resolveDidMethod2(numalgo2DID)
+		require.NoError(t, err)
+
+		didID, err := computeDidMethod2(doc)
+		require.NoError(t, err)
+
+		resolved, err := resolveDidMethod2(didID)
+		require.NoError(t, err)
+		require.Equal(t, doc.Authentication[0].VerificationMethod.Value,
+			resolved.Authentication[0].VerificationMethod.Value)
+		require.Equal(t, doc.KeyAgreement[0].VerificationMethod.Value,
+			resolved.KeyAgreement[0].VerificationMethod.Value)
+
+		uri, err := resolved.Service[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/endpoint", uri)
+	})
+
+	t.Run("test compute with DIDComm V1 service", func(t *testing.T) {
+		signingKey := getSigningKey()
+		didID, err := computeDidMethod2(&did.Doc{
+			Authentication: []did.Verification{{VerificationMethod: signingKey}},
+			Service: []did.Service{{
+				Type:            vdrapi.DIDCommServiceType,
+				ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com/endpoint"),
+				RoutingKeys:     []string{"did:example:somemediator#somekey"},
+				RecipientKeys:   []string{"did:example:123#key-1"},
+			}},
+		})
+		require.NoError(t, err)
+
+		doc, err := resolveDidMethod2(didID)
+		require.NoError(t, err)
+		require.Equal(t, signingKey.Value, doc.Authentication[0].VerificationMethod.Value)
+		require.Equal(t, vdrapi.DIDCommServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{"did:example:somemediator#somekey"}, doc.Service[0].RoutingKeys)
+		require.Equal(t, []string{"did:example:123#key-1"}, doc.Service[0].RecipientKeys)
+	})
+
+	t.Run("test compute without keys", func(t *testing.T) {
+		_, err := computeDidMethod2(&did.Doc{})
+		require.EqualError(t, err, "the numalgo 2 peer DID must include at least one key")
+	})
+
+	t.Run("test compute with unsupported key type", func(t *testing.T) {
+		vm := getSigningKey()
+		vm.Type = "undefined"
+
+		_, err := computeDidMethod2(&did.Doc{Authentication: []did.Verification{{VerificationMethod: vm}}})
+		require.EqualError(t, err, "not supported verification method type for numalgo 2: undefined")
+	})
+
+	t.Run("test resolve invalid DID", func(t *testing.T) {
+		_, err := resolveDidMethod2("did:peer:2")
+		require.EqualError(t, err, "did doesnt follow matching regex")
+
+		_, err = resolveDidMethod2("did:peer:2.Vz6Mkinvalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode key #key-1")
+
+		_, err = resolveDidMethod2(strings.Split(numalgo2DID, ".S")[0] + ".Sinvalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service")
+	})
+
+	t.Run("test create and read", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := v.Create(&did.Doc{
+			VerificationMethod: []did.VerificationMethod{getSigningKey()},
+			Service: []did.Service{{
+				Type:            vdrapi.DIDCommV2ServiceType,
+				ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{URI: "https://example.com"}}),
+			}},
+		}, vdrapi.WithOption(NumalgoOpt, 2))
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(docResolution.DIDDocument.ID, "did:peer:2."))
+
+		resolved, err := v.Read(docResolution.DIDDocument.ID)
+		require.NoError(t, err)
+		require.Equal(t, docResolution.DIDDocument.ID, resolved.DIDDocument.ID)
+		require.Len(t, resolved.DIDDocument.Service, 1)
+	})
+
+	t.Run("test create with unsupported numalgo", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdrapi.WithOption(NumalgoOpt, 3))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "numalgo 3 not supported")
+	})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// Reference: https://identity.foundation/peer-did-method-spec/#method-4-short-form-and-long-form
+const (
+	numAlgo4 = "4"
+
+	// jsonMultiCodec is the multicodec code of the JSON encoded input document.
+	jsonMultiCodec = 0x0200
+)
+
+var didMethod4Regex = regexp.MustCompile(`^did:peer:4(z[1-9a-km-zA-HJ-NP-Z]{46})(:z[1-9a-km-zA-HJ-NP-Z]+)?$`)
+
+// computeDidMethod4 creates the long form numalgo 4 peer DID of doc. The doc ID is ignored, the other ids of the doc
+// should be relative to be resolved against the peer DID.
+// For example: did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd:z2M1k7h4psgp4CmJcnQn2Ljp7Pz7ktsd7oBhMU3dWY5s4f.
+func computeDidMethod4(doc *did.Doc) (string, error) {
+	inputDoc := *doc
+	inputDoc.ID = ""
+
+	docBytes, err := inputDoc.JSONBytes()
+	if err != nil {
+		return "", fmt.Errorf("marshal input doc: %w", err)
+	}
+
+	codec := make([]byte, binary.MaxVarintLen64)
+	codec = codec[:binary.PutUvarint(codec, jsonMultiCodec)]
+
+	encodedDoc, err := multibase.Encode(transform, append(codec, docBytes...))
+	if err != nil {
+		return "", fmt.Errorf("encode input doc: %w", err)
+	}
+
+	hash, err := hashDidMethod4(encodedDoc)
+	if err != nil {
+		return "", err
+	}
+
+	return peerPrefix + numAlgo4 + hash + ":" + encodedDoc, nil
+}
+
+func hashDidMethod4(encodedDoc string) (string, error) {
+	hash, err := multihash.Sum([]byte(encodedDoc), multihash.SHA2_256, -1)
+	if err != nil {
+		return "", fmt.Errorf("hash input doc: %w", err)
+	}
+
+	return string(transform) + hash.B58String(), nil
+}
+
+// isDidMethod4LongForm reports whether didID is a long form numalgo 4 peer DID, which embeds its input doc.
+func isDidMethod4LongForm(didID string) bool {
+	m := didMethod4Regex.FindStringSubmatch(didID)
+
+	return m != nil && m[2] != ""
+}
+
+// shortFormDidMethod4 returns the short form of the long form numalgo 4 peer DID.
+func shortFormDidMethod4(longForm string) string {
+	return longForm[:strings.LastIndex(longForm, ":")]
+}
+
+// resolveDidMethod4 statically resolves a long form numalgo 4 peer DID. The resolved doc's ID is the long form DID,
+// also known as the short form, unless asShortForm is set in which case the forms are swapped.
+func resolveDidMethod4(longForm string, asShortForm bool) (*did.Doc, error) {
+	m := didMethod4Regex.FindStringSubmatch(longForm)
+	if m == nil || m[2] == "" {
+		return nil, errors.New("did doesnt follow matching regex")
+	}
+
+	hash, encodedDoc := m[1], m[2][1:]
+
+	computedHash, err := hashDidMethod4(encodedDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	if computedHash != hash {
+		return nil, errors.New("multiHash of the input doc doesnt match the DID multiHash")
+	}
+
+	_, docBytes, err := multibase.Decode(encodedDoc)
+	if err != nil {
+		return nil, fmt.Errorf("decode input doc: %w", err)
+	}
+
+	code, n := binary.Uvarint(docBytes)
+	if n <= 0 || code != jsonMultiCodec {
+		return nil, fmt.Errorf("input doc is not JSON multicodec encoded")
+	}
+
+	raw := map[string]interface{}{}
+
+	err = json.Unmarshal(docBytes[n:], &raw)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal input doc: %w", err)
+	}
+
+	id, aka := longForm, shortFormDidMethod4(longForm)
+	if asShortForm {
+		id, aka = aka, id
+	}
+
+	raw["id"] = id
+	raw["alsoKnownAs"] = append(stringArray(raw["alsoKnownAs"]), aka)
+
+	if _, ok := raw["@context"]; !ok {
+		raw["@context"] = []string{did.ContextV1}
+	}
+
+	resolvedBytes, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("marshal did doc: %w", err)
+	}
+
+	return did.ParseDocument(resolvedBytes)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNumalgo4(t *testing.T) {
+	t.Run("test compute and resolve", func(t *testing.T) {
+		signingKey := getSigningKey()
+		signingKey.ID = "#key-1"
+
+		longForm, err := computeDidMethod4(&did.Doc{
+			Context:            []string{did.ContextV1},
+			VerificationMethod: []did.VerificationMethod{signingKey},
+		})
+		require.NoError(t, err)
+		require.True(t, isDidMethod4LongForm(longForm))
+
+		shortForm := shortFormDidMethod4(longForm)
+		require.False(t, isDidMethod4LongForm(shortForm))
+		require.True(t, didMethod4Regex.MatchString(shortForm))
+
+		doc, err := resolveDidMethod4(longForm, false)
+		require.NoError(t, err)
+		require.Equal(t, longForm, doc.ID)
+		require.Equal(t, []string{shortForm}, doc.AlsoKnownAs)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, longForm+"#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, signingKey.Value, doc.VerificationMethod[0].Value)
+
+		doc, err = resolveDidMethod4(longForm, true)
+		require.NoError(t, err)
+		require.Equal(t, shortForm, doc.ID)
+		require.Equal(t, []string{longForm}, doc.AlsoKnownAs)
+	})
+
+	t.Run("test resolve invalid DID", func(t *testing.T) {
+		_, err := resolveDidMethod4("did:peer:4invalid", false)
+		require.EqualError(t, err, "did doesnt follow matching regex")
+
+		longForm, err := computeDidMethod4(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}})
+		require.NoError(t, err)
+
+		_, err = resolveDidMethod4(shortFormDidMethod4(longForm), false)
+		require.EqualError(t, err, "did doesnt follow matching regex")
+
+		_, err = resolveDidMethod4(longForm+"a", false)
+		require.EqualError(t, err, "multiHash of the input doc doesnt match the DID multiHash")
+	})
+
+	t.Run("test create and read short form", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdrapi.WithOption(NumalgoOpt, 4))
+		require.NoError(t, err)
+
+		longForm := docResolution.DIDDocument.ID
+		require.True(t, isDidMethod4LongForm(longForm))
+
+		resolved, err := v.Read(shortFormDidMethod4(longForm))
+		require.NoError(t, err)
+		require.Equal(t, shortFormDidMethod4(longForm), resolved.DIDDocument.ID)
+		require.Equal(t, []string{longForm}, resolved.DIDDocument.AlsoKnownAs)
+	})
+
+	t.Run("test read long form doesn't store the short form", func(t *testing.T) {
+		longForm, err := computeDidMethod4(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}})
+		require.NoError(t, err)
+
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		resolved, err := v.Read(longForm)
+		require.NoError(t, err)
+		require.Equal(t, longForm, resolved.DIDDocument.ID)
+
+		_, err = v.Read(shortFormDidMethod4(longForm))
+		require.Error(t, err)
+
+		_, err = v.Create(resolved.DIDDocument, vdrapi.WithOption("store", true))
+		require.NoError(t, err)
+
+		resolved, err = v.Read(shortFormDidMethod4(longForm))
+		require.NoError(t, err)
+		require.Equal(t, shortFormDidMethod4(longForm), resolved.DIDDocument.ID)
+		require.Equal(t, []string{longForm}, resolved.DIDDocument.AlsoKnownAs)
+	})
+}
@@ -8,6 +8,7 @@ package peer
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -15,6 +16,23 @@ import (
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
 func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	switch {
+	case strings.HasPrefix(didID, peerPrefix+numAlgo2):
+		doc, err := resolveDidMethod2(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve numalgo 2 peer DID: %w", err)
+		}
+
+		return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+	case isDidMethod4LongForm(didID):
+		doc, err := resolveDidMethod4(didID, false)
+		if err != nil {
+			return nil, fmt.Errorf("resolve numalgo 4 peer DID: %w", err)
+		}
+
+		return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+	}
+
 	// get the document from the store
 	doc, err := v.Get(didID)
 	if err != nil {
@@ -21,6 +21,8 @@ const (
 	DefaultServiceType = "defaultServiceType"
 	// DefaultServiceEndpoint default service endpoint.
 	DefaultServiceEndpoint = "defaultServiceEndpoint"
+	// NumalgoOpt selects the numeric algorithm of the peer DID created: 1 (default), 2 or 4.
+	NumalgoOpt = "numalgo"
 )
 
 // VDR implements building new peer dids.
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package vdr
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -42,6 +43,12 @@ func New(opts ...Option) *Registry {
 
 // Resolve did document.
 func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	return r.ResolveContext(context.Background(), did, opts...)
+}
+
+// ResolveContext resolves did document, the context is passed to the did method.
+func (r *Registry) ResolveContext(ctx context.Context, did string,
+	opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
 	didMethod, err := GetDidMethod(did)
 	if err != nil {
 		return nil, err
@@ -58,7 +65,7 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 	}
 
 	// Obtain the DID Document
-	didDocResolution, err := method.Read(did, opts...)
+	didDocResolution, err := vdrapi.NewContextVDR(method).ReadContext(ctx, did, opts...)
 	if err != nil {
 		if errors.Is(err, vdrapi.ErrNotFound) {
 			return nil, err
@@ -72,6 +79,11 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 
 // Update did document.
 func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return r.UpdateContext(context.Background(), didDoc, opts...)
+}
+
+// UpdateContext updates did document, the context is passed to the did method.
+func (r *Registry) UpdateContext(ctx context.Context, didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 	didMethod, err := GetDidMethod(didDoc.ID)
 	if err != nil {
 		return err
@@ -87,11 +99,16 @@ func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) er
 		return err
 	}
 
-	return method.Update(didDoc, opts...)
+	return vdrapi.NewContextVDR(method).UpdateContext(ctx, didDoc, opts...)
 }
 
 // Deactivate did document.
 func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
+	return r.DeactivateContext(context.Background(), did, opts...)
+}
+
+// DeactivateContext deactivates did document, the context is passed to the did method.
+func (r *Registry) DeactivateContext(ctx context.Context, did string, opts ...vdrapi.DIDMethodOption) error {
 	didMethod, err := GetDidMethod(did)
 	if err != nil {
 		return err
@@ -107,11 +124,17 @@ func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error
 		return err
 	}
 
-	return method.Deactivate(did, opts...)
+	return vdrapi.NewContextVDR(method).DeactivateContext(ctx, did, opts...)
 }
 
 // Create a new DID Document and store it in this registry.
 func (r *Registry) Create(didMethod string, did *diddoc.Doc,
+	opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	return r.CreateContext(context.Background(), didMethod, did, opts...)
+}
+
+// CreateContext creates a new DID Document and stores it in this registry, the context is passed to the did method.
+func (r *Registry) CreateContext(ctx context.Context, didMethod string, did *diddoc.Doc,
 	opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
 	docOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
 
@@ -124,7 +147,8 @@ func (r *Registry) Create(didMethod string, did *diddoc.Doc,
 		return nil, err
 	}
 
-	didDocResolution, err := method.Create(did, r.applyDefaultDocOpts(docOpts, opts...)...)
+	didDocResolution, err := vdrapi.NewContextVDR(method).CreateContext(ctx, did,
+		r.applyDefaultDocOpts(docOpts, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package vdr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -126,6 +128,22 @@ func TestRegistry_Resolve(t *testing.T) {
 		_, err := registry.Resolve("1:id:123")
 		require.NoError(t, err)
 	})
+
+	t.Run("test context cancelled", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				require.Fail(t, "read must not be called with a cancelled context")
+				return nil, nil
+			},
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		d, err := registry.ResolveContext(ctx, "1:id:123")
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, d)
+	})
 }
 
 func TestRegistry_Update(t *testing.T) {
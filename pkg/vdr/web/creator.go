@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -17,3 +18,9 @@ import (
 func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
 	return nil, fmt.Errorf("error building did:web did doc --> build not supported in http binding vdr")
 }
+
+// CreateContext creates a did:web diddoc (unsupported at the moment).
+func (v *VDR) CreateContext(_ context.Context, didDoc *did.Doc,
+	opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return v.Create(didDoc, opts...)
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -29,6 +30,12 @@ var logger = log.New("aries-framework/pkg/vdr/web")
 
 // Read resolves a did:web did.
 func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return v.ReadContext(context.Background(), didID, opts...)
+}
+
+// ReadContext resolves a did:web did, the http request is cancelled when the context is done.
+func (v *VDR) ReadContext(ctx context.Context, didID string,
+	opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
 	httpClient := &http.Client{}
 
 	didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
@@ -58,7 +65,12 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 		return nil, fmt.Errorf("error resolving did:web did --> could not parse did:web did --> %w", err)
 	}
 
-	resp, err := httpClient.Get(address)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:web did --> failed to create http request --> %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving did:web did --> http request unsuccessful --> %w", err)
 	}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -142,6 +144,21 @@ func TestResolveDID(t *testing.T) {
 		_, err := v.Read(did, vdrapi.WithOption(HTTPClientOpt, s.Client()))
 		require.Error(t, err)
 	})
+	t.Run("test resolve did with cancelled context", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Fail(t, "request must not be sent with a cancelled context")
+		}))
+		defer s.Close()
+		did := fmt.Sprintf("did:web:%s", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		v := New()
+		doc, err := v.ReadContext(ctx, did, vdrapi.WithOption(HTTPClientOpt, s.Client()))
+		require.Nil(t, doc)
+		require.True(t, errors.Is(err, context.Canceled))
+	})
 }
 
 func TestResolveDomain(t *testing.T) {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"context"
 	"fmt"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -40,6 +41,16 @@ func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
 	return fmt.Errorf("not supported")
 }
 
+// UpdateContext did doc.
+func (v *VDR) UpdateContext(_ context.Context, didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return v.Update(didDoc, opts...)
+}
+
+// DeactivateContext did doc.
+func (v *VDR) DeactivateContext(_ context.Context, did string, opts ...vdrapi.DIDMethodOption) error {
+	return v.Deactivate(did, opts...)
+}
+
 // Close method of the VDR interface.
 func (v *VDR) Close() error {
 	return nil
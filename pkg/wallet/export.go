@@ -0,0 +1,566 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	encryptedWalletType = "EncryptedWallet"
+	jsonWebKey2020      = "JsonWebKey2020"
+
+	ecdsaPrivateKeyTypeURL       = "type.googleapis.com/google.crypto.tink.EcdsaPrivateKey"
+	ed25519PrivateKeyTypeURL     = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
+	bbsPrivateKeyTypeURL         = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+	nistpECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey"
+
+	// maxPBES2Count limits the PBES2 iterations ("p2c" header) of imported wallets, so that crafted wallet
+	// contents can not make the key derivation run for ages. Exported wallets use 100000 iterations.
+	maxPBES2Count = 1000000
+)
+
+// errUnsupportedKey is returned by exportKey for private keys which can not be exported.
+var errUnsupportedKey = errors.New("unsupported key")
+
+// ErrImportConflict is returned by import when exported wallet contains contents which already exist in this wallet
+// and ImportConflictFail conflict resolution is used.
+var ErrImportConflict = errors.New("wallet content to be imported already exists in this wallet")
+
+// order in which wallet contents are exported and imported, collections go first to be available for mapping.
+var exportContentTypes = []ContentType{ //nolint:gochecknoglobals
+	Collection, Metadata, Connection, Credential, DIDResolutionResponse,
+}
+
+// curves of ECDSA and NIST P ECDH key types.
+var ecKeyTypeCurves = map[kms.KeyType]elliptic.Curve{ //nolint:gochecknoglobals
+	kms.ECDSAP256TypeDER:       elliptic.P256(),
+	kms.ECDSAP256TypeIEEEP1363: elliptic.P256(),
+	kms.NISTP256ECDHKWType:     elliptic.P256(),
+	kms.ECDSAP384TypeDER:       elliptic.P384(),
+	kms.ECDSAP384TypeIEEEP1363: elliptic.P384(),
+	kms.NISTP384ECDHKWType:     elliptic.P384(),
+	kms.ECDSAP521TypeDER:       elliptic.P521(),
+	kms.ECDSAP521TypeIEEEP1363: elliptic.P521(),
+	kms.NISTP521ECDHKWType:     elliptic.P521(),
+}
+
+// exportedContent is a single wallet content inside encrypted wallet contents.
+type exportedContent struct {
+	ContentType  ContentType     `json:"contentType"`
+	CollectionID string          `json:"collectionID,omitempty"`
+	KeyType      kms.KeyType     `json:"keyType,omitempty"`
+	Content      json.RawMessage `json:"content"`
+}
+
+// exportContents collects all wallet contents and keys of wallet key manager.
+func (c *Wallet) exportContents(auth string) ([]*exportedContent, error) {
+	collections, err := c.contents.GetAll(auth, Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	// content type -> content ID -> collection ID
+	mappings := make(map[ContentType]map[string]string)
+
+	for _, ct := range exportContentTypes[1:] {
+		mappings[ct] = make(map[string]string)
+
+		for collectionID := range collections {
+			mapped, e := c.contents.GetAllByCollection(auth, collectionID, ct)
+			if e != nil {
+				return nil, fmt.Errorf("failed to get contents of collection '%s': %w", collectionID, e)
+			}
+
+			for id := range mapped {
+				mappings[ct][id] = collectionID
+			}
+		}
+	}
+
+	var exported []*exportedContent
+
+	for _, ct := range exportContentTypes {
+		contents, e := c.contents.GetAll(auth, ct)
+		if e != nil {
+			return nil, fmt.Errorf("failed to get wallet contents of type '%s': %w", ct, e)
+		}
+
+		for _, id := range sortedKeys(contents) {
+			exported = append(exported, &exportedContent{
+				ContentType:  ct,
+				CollectionID: mappings[ct][id],
+				Content:      contents[id],
+			})
+		}
+	}
+
+	keys, err := c.exportKeys(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(exported, keys...), nil
+}
+
+// exportKeys exports private keys of local wallet key manager in JWK format.
+// Keys of remote key manager can not be exported.
+func (c *Wallet) exportKeys(auth string) ([]*exportedContent, error) {
+	if c.profile.KeyServerURL != "" {
+		return nil, nil
+	}
+
+	session, err := sessionManager().getSession(auth)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAuthToken) {
+			return nil, ErrWalletLocked
+		}
+
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	kids, err := getKeyIDs(c.storeProvider, c.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		exported    []*exportedContent
+		unsupported []string
+	)
+
+	for _, kid := range kids {
+		key, err := exportKey(session.KeyManager, kid)
+		if errors.Is(err, errUnsupportedKey) {
+			unsupported = append(unsupported, fmt.Sprintf("'%s' (%s)", kid, err))
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to export key '%s': %w", kid, err)
+		}
+
+		if key != nil {
+			exported = append(exported, key)
+		}
+	}
+
+	// keys are not left out silently, the wallet could not be restored from the export.
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("failed to export keys: %s", strings.Join(unsupported, ", "))
+	}
+
+	return exported, nil
+}
+
+// exportKey exports private key from key manager, returns nil for keys without public key (symmetric keys) and
+// errUnsupportedKey for private keys which can not be exported.
+func exportKey(keyManager kms.KeyManager, kid string) (*exportedContent, error) {
+	// keys without public key (symmetric keys) are not exported.
+	_, kt, err := keyManager.ExportPubKeyBytes(kid)
+	if err != nil {
+		logger.Debugf("key '%s' is not exported: %s", kid, err)
+
+		return nil, nil
+	}
+
+	kh, err := keyManager.Get(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, fmt.Errorf("%w handle", errUnsupportedKey)
+	}
+
+	privKey, err := privateKeyFromKeyset(handle, kt)
+	if err != nil {
+		return nil, err
+	}
+
+	if privKey == nil {
+		return nil, fmt.Errorf("%w type '%s'", errUnsupportedKey, kt)
+	}
+
+	j := jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: privKey, KeyID: kid}}
+
+	privKeyJWK, err := j.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal jwk: %w", err)
+	}
+
+	content, err := json.Marshal(&keyContent{ID: kid, KeyType: jsonWebKey2020, PrivateKeyJwk: privKeyJWK})
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportedContent{ContentType: Key, KeyType: kt, Content: content}, nil
+}
+
+// privateKeyFromKeyset reads primary private key of key set, returns nil for unsupported keys.
+func privateKeyFromKeyset(kh *keyset.Handle, kt kms.KeyType) (interface{}, error) {
+	ks := insecurecleartextkeyset.KeysetMaterial(kh)
+
+	var keyData *tinkpb.KeyData
+
+	for _, key := range ks.Key {
+		if key.KeyId == ks.PrimaryKeyId {
+			keyData = key.KeyData
+		}
+	}
+
+	if keyData == nil {
+		return nil, errors.New("primary key not found in key set")
+	}
+
+	switch keyData.TypeUrl {
+	case ed25519PrivateKeyTypeURL:
+		key := new(ed25519pb.Ed25519PrivateKey)
+		if err := proto.Unmarshal(keyData.Value, key); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ed25519 key: %w", err)
+		}
+
+		return ed25519.NewKeyFromSeed(key.KeyValue), nil
+	case ecdsaPrivateKeyTypeURL:
+		key := new(ecdsapb.EcdsaPrivateKey)
+		if err := proto.Unmarshal(keyData.Value, key); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ecdsa key: %w", err)
+		}
+
+		return ecPrivateKey(kt, key.KeyValue, key.PublicKey.X, key.PublicKey.Y), nil
+	case nistpECDHKWPrivateKeyTypeURL:
+		key := new(ecdhpb.EcdhAeadPrivateKey)
+		if err := proto.Unmarshal(keyData.Value, key); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ecdh key: %w", err)
+		}
+
+		return ecPrivateKey(kt, key.KeyValue, key.PublicKey.X, key.PublicKey.Y), nil
+	case bbsPrivateKeyTypeURL:
+		key := new(bbspb.BBSPrivateKey)
+		if err := proto.Unmarshal(keyData.Value, key); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bbs key: %w", err)
+		}
+
+		return bbs12381g2pub.UnmarshalPrivateKey(key.KeyValue)
+	default:
+		return nil, nil
+	}
+}
+
+func ecPrivateKey(kt kms.KeyType, d, x, y []byte) interface{} {
+	curve, ok := ecKeyTypeCurves[kt]
+	if !ok {
+		return nil
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)},
+		D:         new(big.Int).SetBytes(d),
+	}
+}
+
+// encryptWallet encrypts exported wallet contents by given passphrase and wraps them into EncryptedWallet.
+func encryptWallet(contents []*exportedContent, passphrase string) (json.RawMessage, error) {
+	plaintext, err := json.Marshal(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet contents: %w", err)
+	}
+
+	encrypter, err := jose.NewEncrypter(jose.A256GCM,
+		jose.Recipient{Algorithm: jose.PBES2_HS512_A256KW, Key: []byte(passphrase)},
+		(&jose.EncrypterOptions{}).WithContentType("application/json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encrypter: %w", err)
+	}
+
+	jwe, err := encrypter.Encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt wallet contents: %w", err)
+	}
+
+	return json.Marshal(&EncryptedWallet{
+		Context:      []string{"https://www.w3.org/2018/credentials/v1", "https://w3id.org/wallet/v1"},
+		ID:           "urn:uuid:" + uuid.New().String(),
+		Type:         []string{"VerifiableCredential", encryptedWalletType},
+		IssuanceDate: time.Now().UTC(),
+		CredentialSubject: EncryptedWalletSubject{
+			EncryptedWalletContents: json.RawMessage(jwe.FullSerialize()),
+		},
+	})
+}
+
+// decryptWallet decrypts contents of EncryptedWallet by given passphrase.
+func decryptWallet(encrypted json.RawMessage, passphrase string) ([]*exportedContent, error) {
+	var ew EncryptedWallet
+
+	err := json.Unmarshal(encrypted, &ew)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted wallet: %w", err)
+	}
+
+	if len(ew.CredentialSubject.EncryptedWalletContents) == 0 {
+		return nil, errors.New("encrypted wallet contents not found")
+	}
+
+	jwe, err := jose.ParseEncrypted(string(ew.CredentialSubject.EncryptedWalletContents))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted wallet contents: %w", err)
+	}
+
+	err = checkPBES2Count(jwe, ew.CredentialSubject.EncryptedWalletContents)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := jwe.Decrypt([]byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt wallet contents: %w", err)
+	}
+
+	var contents []*exportedContent
+
+	err = json.Unmarshal(plaintext, &contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet contents: %w", err)
+	}
+
+	return contents, nil
+}
+
+// checkPBES2Count checks the "p2c" headers of encrypted wallet contents before the key is derived by decryption.
+func checkPBES2Count(jwe *jose.JSONWebEncryption, raw json.RawMessage) error {
+	const p2cHeader = "p2c"
+
+	counts := []interface{}{jwe.Header.ExtraHeaders[p2cHeader]}
+
+	// the header of the recipient, which is not merged into jwe.Header, may hold "p2c" too.
+	var recipients struct {
+		Header     map[string]interface{} `json:"header"`
+		Recipients []struct {
+			Header map[string]interface{} `json:"header"`
+		} `json:"recipients"`
+	}
+
+	if json.Unmarshal(raw, &recipients) == nil {
+		counts = append(counts, recipients.Header[p2cHeader])
+
+		for _, r := range recipients.Recipients {
+			counts = append(counts, r.Header[p2cHeader])
+		}
+	}
+
+	for _, c := range counts {
+		if count, ok := c.(float64); ok && count > maxPBES2Count {
+			return fmt.Errorf("encrypted wallet contents PBES2 count %.0f exceeds %d", count, maxPBES2Count)
+		}
+	}
+
+	return nil
+}
+
+// importContents imports exported wallet contents into wallet resolving conflicts as per given resolution.
+func (c *Wallet) importContents(auth string, contents []*exportedContent, resolution ImportConflict) error {
+	conflicts := make([]bool, len(contents))
+
+	for i, content := range contents {
+		exists, err := c.contentExists(auth, content)
+		if err != nil {
+			return err
+		}
+
+		if exists && resolution == ImportConflictFail {
+			return ErrImportConflict
+		}
+
+		conflicts[i] = exists
+	}
+
+	for i, content := range contents {
+		if conflicts[i] {
+			// keys are never replaced.
+			if resolution == ImportConflictSkip || content.ContentType == Key {
+				continue
+			}
+
+			id, err := contentKey(content)
+			if err != nil {
+				return err
+			}
+
+			err = c.contents.Remove(auth, id, content.ContentType)
+			if err != nil {
+				return fmt.Errorf("failed to remove existing wallet content '%s': %w", id, err)
+			}
+		}
+
+		err := c.importContent(auth, content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Wallet) importContent(auth string, content *exportedContent) error {
+	if content.ContentType == Key {
+		return importExportedKey(auth, content)
+	}
+
+	var options []AddContentOptions
+	if content.CollectionID != "" {
+		options = append(options, AddByCollection(content.CollectionID))
+	}
+
+	err := c.contents.Save(auth, content.ContentType, content.Content, options...)
+	if err != nil {
+		return fmt.Errorf("failed to import wallet content of type '%s': %w", content.ContentType, err)
+	}
+
+	return nil
+}
+
+// contentExists checks if given content already exists in this wallet.
+func (c *Wallet) contentExists(auth string, content *exportedContent) (bool, error) {
+	id, err := contentKey(content)
+	if err != nil {
+		return false, err
+	}
+
+	if content.ContentType == Key {
+		session, e := sessionManager().getSession(auth)
+		if e != nil {
+			if errors.Is(e, ErrInvalidAuthToken) {
+				return false, ErrWalletLocked
+			}
+
+			return false, fmt.Errorf("failed to get session: %w", e)
+		}
+
+		_, e = session.KeyManager.Get(id)
+
+		return e == nil, nil
+	}
+
+	_, err = c.contents.Get(auth, id, content.ContentType)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// contentKey returns ID of exported content, which is used as a key in the wallet.
+func contentKey(content *exportedContent) (string, error) {
+	switch content.ContentType {
+	case DIDResolutionResponse:
+		docRes, err := did.ParseDocumentResolution(content.Content)
+		if err != nil {
+			return "", fmt.Errorf("invalid DID resolution response model: %w", err)
+		}
+
+		return docRes.DIDDocument.ID, nil
+	case Key:
+		var key keyContent
+
+		err := json.Unmarshal(content.Content, &key)
+		if err != nil {
+			return "", fmt.Errorf("failed to read key contents: %w", err)
+		}
+
+		var j jwk.JWK
+		if len(key.PrivateKeyJwk) > 0 {
+			if e := j.UnmarshalJSON(key.PrivateKeyJwk); e != nil {
+				return "", fmt.Errorf("failed to unmarshal jwk : %w", e)
+			}
+		}
+
+		return getKIDFromJWK(key.ID, &j), nil
+	case Collection, Metadata, Connection, Credential:
+		return getContentID(content.Content)
+	default:
+		return "", fmt.Errorf("invalid content type '%s'", content.ContentType)
+	}
+}
+
+// importExportedKey imports exported key into key manager keeping its key type.
+func importExportedKey(auth string, content *exportedContent) error {
+	var key keyContent
+
+	err := json.Unmarshal(content.Content, &key)
+	if err != nil {
+		return fmt.Errorf("failed to read key contents: %w", err)
+	}
+
+	if content.KeyType == "" {
+		return saveKey(auth, &key)
+	}
+
+	session, err := sessionManager().getSession(auth)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAuthToken) {
+			return ErrWalletLocked
+		}
+
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	var j jwk.JWK
+	if e := j.UnmarshalJSON(key.PrivateKeyJwk); e != nil {
+		return fmt.Errorf("failed to unmarshal jwk : %w", e)
+	}
+
+	_, _, err = session.KeyManager.ImportPrivateKey(j.Key, content.KeyType,
+		kms.WithKeyID(getKIDFromJWK(key.ID, &j)))
+	if err != nil {
+		return fmt.Errorf("failed to import key : %w", err)
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -38,6 +39,10 @@ const (
 
 	// number of sections in verification method.
 	vmSectionCount = 2
+
+	// store of IDs of keys of wallet key manager.
+	keyIndexStoreName = "vcwallet_keys_%s"
+	keyIndexTag       = "kid"
 )
 
 // supported key types for import key base58 (all constants defined in lower case).
@@ -114,6 +119,120 @@ func (k *walletKeyManager) createKeyManager(profileInfo *profile,
 	return keyManager, nil
 }
 
+// walletKMSStore is kms.Store for wallet key manager, which keeps index of IDs of keys of the wallet profile
+// to allow exporting them.
+type walletKMSStore struct {
+	kms.Store
+	index storage.Store
+}
+
+func newWalletKMSStore(p storage.Provider, profileInfo *profile) (*walletKMSStore, error) {
+	kmsStore, err := kms.NewAriesProviderWrapper(p)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := openKeyIndex(p, profileInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &walletKMSStore{Store: kmsStore, index: index}, nil
+}
+
+// Put stores the key and adds its ID to the index.
+func (s *walletKMSStore) Put(keysetID string, key []byte) error {
+	err := s.Store.Put(keysetID, key)
+	if err != nil {
+		return err
+	}
+
+	return s.index.Put(keyIndexTag+"_"+keysetID, []byte(keysetID), storage.Tag{Name: keyIndexTag})
+}
+
+// Delete deletes the key and removes its ID from the index.
+func (s *walletKMSStore) Delete(keysetID string) error {
+	err := s.Store.Delete(keysetID)
+	if err != nil {
+		return err
+	}
+
+	return s.index.Delete(keyIndexTag + "_" + keysetID)
+}
+
+// openKeyIndex opens store of IDs of keys of wallet key manager.
+func openKeyIndex(p storage.Provider, profileInfo *profile) (storage.Store, error) {
+	name := fmt.Sprintf(keyIndexStoreName, profileInfo.ID)
+
+	index, err := p.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key index store: %w", err)
+	}
+
+	err = p.SetStoreConfig(name, storage.StoreConfiguration{TagNames: []string{keyIndexTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set key index store config: %w", err)
+	}
+
+	return index, nil
+}
+
+// KeyIDs returns the IDs of the keys of the wallet profile, so that its key manager lists only them.
+func (s *walletKMSStore) KeyIDs() ([]string, error) {
+	return queryKeyIDs(s.index)
+}
+
+// MetadataStore returns the key metadata store of the underlying kms.Store.
+func (s *walletKMSStore) MetadataStore(tagNames ...string) (storage.Store, error) {
+	p, ok := s.Store.(kms.MetadataStoreProvider)
+	if !ok {
+		return nil, errors.New("kms store does not provide a key metadata store")
+	}
+
+	return p.MetadataStore(tagNames...)
+}
+
+// getKeyIDs returns IDs of keys of wallet key manager.
+func getKeyIDs(p storage.Provider, profileInfo *profile) ([]string, error) {
+	index, err := openKeyIndex(p, profileInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryKeyIDs(index)
+}
+
+func queryKeyIDs(index storage.Store) ([]string, error) {
+	iter, err := index.Query(keyIndexTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query key index: %w", err)
+	}
+
+	defer storage.Close(iter, logger)
+
+	var kids []string
+
+	for {
+		ok, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key index: %w", err)
+		}
+
+		if !ok {
+			break
+		}
+
+		kid, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key index: %w", err)
+		}
+
+		kids = append(kids, string(kid))
+	}
+
+	return kids, nil
+}
+
 // createMasterLock creates master lock from secret lock service provided.
 func createMasterLock(secretLockSvc secretlock.Service) (string, error) {
 	masterKeyContent := random.GetRandomBytes(uint32(32)) //nolint: gomnd
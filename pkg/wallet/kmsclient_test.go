@@ -15,6 +15,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
@@ -170,6 +171,61 @@ func TestKeyManager(t *testing.T) {
 	})
 }
 
+func TestWalletKMSStore(t *testing.T) {
+	t.Run("test key index", func(t *testing.T) {
+		sp := mem.NewProvider()
+		profileInfo := &profile{ID: uuid.New().String()}
+
+		store, err := newWalletKMSStore(sp, profileInfo)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("kid-1", []byte("key-1")))
+		require.NoError(t, store.Put("kid-2", []byte("key-2")))
+
+		key, err := store.Get("kid-1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("key-1"), key)
+
+		kids, err := getKeyIDs(sp, profileInfo)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"kid-1", "kid-2"}, kids)
+
+		require.NoError(t, store.Delete("kid-1"))
+
+		kids, err = getKeyIDs(sp, profileInfo)
+		require.NoError(t, err)
+		require.Equal(t, []string{"kid-2"}, kids)
+
+		// keys of other profiles are not listed.
+		kids, err = getKeyIDs(sp, &profile{ID: uuid.New().String()})
+		require.NoError(t, err)
+		require.Empty(t, kids)
+	})
+
+	t.Run("test store errors", func(t *testing.T) {
+		_, err := newWalletKMSStore(&mockstorage.MockStoreProvider{
+			Store:             &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)},
+			ErrSetStoreConfig: errors.New(sampleWalletErr),
+		}, &profile{})
+		require.EqualError(t, err, sampleWalletErr)
+
+		mockStore := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		store, err := newWalletKMSStore(&mockstorage.MockStoreProvider{Store: mockStore}, &profile{})
+		require.NoError(t, err)
+
+		mockStore.ErrPut = errors.New(sampleWalletErr)
+		require.EqualError(t, store.Put("kid", []byte("key")), sampleWalletErr)
+
+		mockStore.ErrDelete = errors.New(sampleWalletErr)
+		require.EqualError(t, store.Delete("kid"), sampleWalletErr)
+
+		mockStore.ErrQuery = errors.New(sampleWalletErr)
+		_, err = getKeyIDs(&mockstorage.MockStoreProvider{Store: mockStore}, &profile{})
+		require.EqualError(t, err, "failed to query key index: "+sampleWalletErr)
+	})
+}
+
 func TestImportKeyJWK(t *testing.T) {
 	sampleUser := uuid.New().String()
 	masterLock, err := getDefaultSecretLock(samplePassPhrase)
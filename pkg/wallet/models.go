@@ -126,3 +126,32 @@ type CredentialInteractionStatus struct {
 	// Optional web redirect URL info sent by verifier.
 	RedirectURL string `json:"url,omitempty"`
 }
+
+// EncryptedWallet is exported wallet representation containing all wallet contents encrypted by passphrase.
+// Refer https://w3c-ccg.github.io/universal-wallet-interop-spec/#EncryptedWallet.
+type EncryptedWallet struct {
+	Context           []string               `json:"@context"`
+	ID                string                 `json:"id"`
+	Type              []string               `json:"type"`
+	IssuanceDate      time.Time              `json:"issuanceDate"`
+	CredentialSubject EncryptedWalletSubject `json:"credentialSubject"`
+}
+
+// EncryptedWalletSubject is credential subject of EncryptedWallet.
+type EncryptedWalletSubject struct {
+	// EncryptedWalletContents is compact JWE of exported wallet contents.
+	EncryptedWalletContents json.RawMessage `json:"encryptedWalletContents"`
+}
+
+// ImportConflict determines how wallet contents which already exist in the wallet are handled during import.
+type ImportConflict string
+
+const (
+	// ImportConflictFail fails import before importing any content if any of the contents already exists.
+	ImportConflictFail ImportConflict = "fail"
+	// ImportConflictSkip keeps existing wallet contents and skips conflicting contents being imported.
+	ImportConflictSkip ImportConflict = "skip"
+	// ImportConflictReplace replaces existing wallet contents by contents being imported.
+	// Existing keys are never replaced.
+	ImportConflictReplace ImportConflict = "replace"
+)
@@ -205,6 +205,12 @@ type verifyOpts struct {
 	rawCredential json.RawMessage
 	// raw presentation to be verified from wallet.
 	rawPresentation json.RawMessage
+	// checker of the credential status, e.g. for revocation.
+	statusChecker verifiable.CredentialStatusChecker
+	// policy to check the verified credential or presentation with.
+	policy *verifiable.VerificationPolicy
+	// verifier of the challenge of the verified presentation.
+	challengeVerifier verifiable.ChallengeVerifier
 }
 
 // VerificationOption options for verifying credential from wallet.
@@ -231,6 +237,30 @@ func WithRawPresentationToVerify(raw json.RawMessage) VerificationOption {
 	}
 }
 
+// WithStatusCheckToVerify option for checking the status of the credentials verified, e.g. rejecting revoked
+// credentials with a statuslist.Verifier.
+func WithStatusCheckToVerify(checker verifiable.CredentialStatusChecker) VerificationOption {
+	return func(opts *verifyOpts) {
+		opts.statusChecker = checker
+	}
+}
+
+// WithVerificationPolicyToVerify option for checking the credential or presentation verified with
+// the verification policy, e.g. to reject expired credentials or credentials of untrusted issuers.
+func WithVerificationPolicyToVerify(policy *verifiable.VerificationPolicy) VerificationOption {
+	return func(opts *verifyOpts) {
+		opts.policy = policy
+	}
+}
+
+// WithChallengeVerifierToVerify option for checking that the challenge and domain of the presentation verified
+// were issued by the verifier and were not used before, e.g. with a challenge.Store.
+func WithChallengeVerifierToVerify(verifier verifiable.ChallengeVerifier) VerificationOption {
+	return func(opts *verifyOpts) {
+		opts.challengeVerifier = verifier
+	}
+}
+
 // verifyOpts contains options for deriving credentials.
 type deriveOpts struct {
 	// for deriving credential from stored credential.
@@ -307,6 +337,23 @@ func FilterByCollection(collectionID string) GetAllContentsOptions {
 	}
 }
 
+// ImportOptions is option for importing exported wallet.
+type ImportOptions func(opts *importOpts)
+
+// importOpts contains options for importing exported wallet.
+type importOpts struct {
+	// resolution of conflicts with existing wallet contents.
+	conflict ImportConflict
+}
+
+// WithImportConflict sets how contents which already exist in the wallet are handled,
+// ImportConflictFail is used by default.
+func WithImportConflict(conflict ImportConflict) ImportOptions {
+	return func(opts *importOpts) {
+		opts.conflict = conflict
+	}
+}
+
 // connectOpts contains options for wallet's DIDComm connect features.
 type connectOpts struct {
 	outofband.EventOptions
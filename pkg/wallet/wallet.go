@@ -169,7 +169,7 @@ func CreateDataVaultKeyPairs(userID string, ctx provider, options ...UnlockOptio
 		opt(opts)
 	}
 
-	kmsStore, err := kms.NewAriesProviderWrapper(ctx.StorageProvider())
+	kmsStore, err := newWalletKMSStore(ctx.StorageProvider(), profile)
 	if err != nil {
 		return err
 	}
@@ -268,7 +268,7 @@ func (c *Wallet) Open(options ...UnlockOptions) (string, error) {
 		opt(opts)
 	}
 
-	kmsStore, err := kms.NewAriesProviderWrapper(c.storeProvider)
+	kmsStore, err := newWalletKMSStore(c.storeProvider, c.profile)
 	if err != nil {
 		return "", err
 	}
@@ -303,10 +303,12 @@ func (c *Wallet) Close() bool {
 }
 
 // Export produces a serialized exported wallet representation.
-// Only ciphertext wallet contents can be exported.
+// All wallet contents and private keys of local wallet key manager are exported into
+// Universal Wallet EncryptedWallet locked by given passphrase.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- authToken: authorization for performing operation.
+//		- passphrase: passphrase to be used to lock the wallet before exporting.
 //
 //	Returns exported locked wallet.
 //
@@ -316,17 +318,28 @@ func (c *Wallet) Close() bool {
 //   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
-func (c *Wallet) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+//   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
+func (c *Wallet) Export(authToken, passphrase string) (json.RawMessage, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required to export wallet")
+	}
+
+	contents, err := c.exportContents(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet: %w", err)
+	}
+
+	return encryptWallet(contents, passphrase)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- authToken: authorization for performing operation.
+//		- passphrase: passphrase used while exporting the wallet.
+//		- contents: exported wallet to be imported.
+//		- options: options for importing, like resolution of conflicts with existing contents.
 //
 // Supported data models:
 //   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -335,9 +348,35 @@ func (c *Wallet) Export(auth string) (json.RawMessage, error) {
 //   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //   - https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
-func (c *Wallet) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Wallet) Import(authToken, passphrase string, contents json.RawMessage, options ...ImportOptions) error {
+	opts := &importOpts{conflict: ImportConflictFail}
+
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	switch opts.conflict {
+	case ImportConflictFail, ImportConflictSkip, ImportConflictReplace:
+	default:
+		return fmt.Errorf("invalid import conflict resolution '%s'", opts.conflict)
+	}
+
+	_, err := sessionManager().getSession(authToken)
+	if err != nil {
+		return err
+	}
+
+	exported, err := decryptWallet(contents, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to import wallet: %w", err)
+	}
+
+	err = c.importContents(authToken, exported, opts.conflict)
+	if err != nil {
+		return fmt.Errorf("failed to import wallet: %w", err)
+	}
+
+	return nil
 }
 
 // Add adds given data model to wallet contents store.
@@ -491,13 +530,19 @@ func (c *Wallet) Prove(authToken string, proofOptions *ProofOptions, credentials
 
 	switch proofOptions.ProofFormat {
 	case ExternalJWTProofFormat:
-		// TODO: look into passing audience identifier
-		//  https://github.com/hyperledger/aries-framework-go/issues/3354
-		claims, e := presentation.JWTClaims(nil, false)
+		var audience []string
+		if proofOptions.Domain != "" {
+			audience = []string{proofOptions.Domain}
+		}
+
+		claims, e := presentation.JWTClaims(audience, false)
 		if e != nil {
 			return nil, fmt.Errorf("failed to generate JWT claims for VP: %w", e)
 		}
 
+		// challenge and domain of JWT presentation are put into "nonce" and "aud" claims.
+		claims.Nonce = proofOptions.Challenge
+
 		jws, e := c.verifiableClaimsToJWT(authToken, claims, proofOptions)
 		if e != nil {
 			return nil, fmt.Errorf("failed to generate JWT VP: %w", e)
@@ -518,27 +563,49 @@ func (c *Wallet) Prove(authToken string, proofOptions *ProofOptions, credentials
 //
 //	Args:
 //		- verification option for sending different models (stored credential ID, raw credential, raw presentation).
+//		- optional status check option to reject revoked or suspended credentials.
+//		- optional verification policy option.
 //
 // Returns: a boolean verified, and an error if verified is false.
-func (c *Wallet) Verify(authToken string, options VerificationOption) (bool, error) {
+func (c *Wallet) Verify(authToken string, options ...VerificationOption) (bool, error) {
+	report, err := c.VerifyWithReport(authToken, options...)
+	if err != nil {
+		return false, err
+	}
+
+	if !report.Passed() {
+		return false, &verifiable.VerificationError{Report: report}
+	}
+
+	return true, nil
+}
+
+// VerifyWithReport takes a Verifiable Credential or Verifiable Presentation as input and verifies it like Verify.
+//
+// Returns: the report of the checks of the verification policy (empty if no policy option is given), and an error
+// if the proof or data model verification fails.
+func (c *Wallet) VerifyWithReport(authToken string,
+	options ...VerificationOption) (*verifiable.VerificationReport, error) {
 	requestOpts := &verifyOpts{}
 
-	options(requestOpts)
+	for _, opt := range options {
+		opt(requestOpts)
+	}
 
 	switch {
 	case requestOpts.credentialID != "":
 		raw, err := c.contents.Get(authToken, requestOpts.credentialID, Credential)
 		if err != nil {
-			return false, fmt.Errorf("failed to get credential: %w", err)
+			return nil, fmt.Errorf("failed to get credential: %w", err)
 		}
 
-		return c.verifyCredential(authToken, raw)
+		return c.verifyCredential(authToken, raw, requestOpts)
 	case len(requestOpts.rawCredential) > 0:
-		return c.verifyCredential(authToken, requestOpts.rawCredential)
+		return c.verifyCredential(authToken, requestOpts.rawCredential, requestOpts)
 	case len(requestOpts.rawPresentation) > 0:
-		return c.verifyPresentation(authToken, requestOpts.rawPresentation)
+		return c.verifyPresentation(authToken, requestOpts.rawPresentation, requestOpts)
 	default:
-		return false, fmt.Errorf("invalid verify request")
+		return nil, fmt.Errorf("invalid verify request")
 	}
 }
 
@@ -569,13 +636,14 @@ func (c *Wallet) Derive(authToken string, credential CredentialToDerive, options
 //	Args:
 //		- authToken: authorization for performing create key pair operation.
 //		- keyType: type of the key to be created.
-func (c *Wallet) CreateKeyPair(authToken string, keyType kms.KeyType) (*KeyPair, error) {
+//		- opts: options for the key to be created (ex: purpose, labels or expiry).
+func (c *Wallet) CreateKeyPair(authToken string, keyType kms.KeyType, opts ...kms.KeyOpts) (*KeyPair, error) {
 	session, err := sessionManager().getSession(authToken)
 	if err != nil {
 		return nil, err
 	}
 
-	kid, pubBytes, err := session.KeyManager.CreateAndExportPubKeyBytes(keyType)
+	kid, pubBytes, err := session.KeyManager.CreateAndExportPubKeyBytes(keyType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -760,39 +828,86 @@ func (c *Wallet) resolveCredentialToDerive(auth string, credential CredentialToD
 	return nil, errors.New("invalid request to derive credential")
 }
 
-func (c *Wallet) verifyCredential(authToken string, credential json.RawMessage) (bool, error) {
-	_, err := verifiable.ParseCredential(credential, verifiable.WithPublicKeyFetcher(
-		verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
-	), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+func (c *Wallet) verifyCredential(authToken string, credential json.RawMessage,
+	requestOpts *verifyOpts) (*verifiable.VerificationReport, error) {
+	vc, err := c.parseCredentialToVerify(authToken, credential, requestOpts.statusChecker)
 	if err != nil {
-		return false, fmt.Errorf("credential verification failed: %w", err)
+		return nil, err
 	}
 
-	return true, nil
+	if requestOpts.policy == nil {
+		return &verifiable.VerificationReport{}, nil
+	}
+
+	return requestOpts.policy.VerifyCredential(vc), nil
+}
+
+func (c *Wallet) parseCredentialToVerify(authToken string, credential json.RawMessage,
+	statusChecker verifiable.CredentialStatusChecker) (*verifiable.Credential, error) {
+	opts := []verifiable.CredentialOpt{
+		verifiable.WithPublicKeyFetcher(
+			verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
+		), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader),
+	}
+
+	if statusChecker != nil {
+		opts = append(opts, verifiable.WithStatusCheck(statusChecker))
+	}
+
+	vc, err := verifiable.ParseCredential(credential, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("credential verification failed: %w", err)
+	}
+
+	return vc, nil
 }
 
-func (c *Wallet) verifyPresentation(authToken string, presentation json.RawMessage) (bool, error) {
-	vp, err := verifiable.ParsePresentation(presentation, verifiable.WithPresPublicKeyFetcher(
-		verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
-	), verifiable.WithPresJSONLDDocumentLoader(c.jsonldDocumentLoader))
+func (c *Wallet) verifyPresentation(authToken string, presentation json.RawMessage,
+	requestOpts *verifyOpts) (*verifiable.VerificationReport, error) {
+	opts := []verifiable.PresentationOpt{
+		verifiable.WithPresPublicKeyFetcher(
+			verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
+		),
+		verifiable.WithPresJSONLDDocumentLoader(c.jsonldDocumentLoader),
+	}
+
+	if requestOpts.challengeVerifier != nil {
+		opts = append(opts, verifiable.WithPresChallengeVerifier(requestOpts.challengeVerifier))
+	}
+
+	vp, err := verifiable.ParsePresentation(presentation, opts...)
 	if err != nil {
-		return false, fmt.Errorf("presentation verification failed: %w", err)
+		return nil, fmt.Errorf("presentation verification failed: %w", err)
 	}
 
+	credentials := make([]*verifiable.Credential, len(vp.Credentials()))
+
 	// verify proof of each credential
-	for _, cred := range vp.Credentials() {
+	for i, cred := range vp.Credentials() {
 		vc, err := json.Marshal(cred)
 		if err != nil {
-			return false, fmt.Errorf("failed to read credentials from presentation: %w", err)
+			return nil, fmt.Errorf("failed to read credentials from presentation: %w", err)
 		}
 
-		_, err = c.verifyCredential(authToken, vc)
+		credentials[i], err = c.parseCredentialToVerify(authToken, vc, requestOpts.statusChecker)
 		if err != nil {
-			return false, fmt.Errorf("presentation verification failed: %w", err)
+			return nil, fmt.Errorf("presentation verification failed: %w", err)
 		}
 	}
 
-	return true, nil
+	if requestOpts.policy == nil {
+		return &verifiable.VerificationReport{}, nil
+	}
+
+	// check the verified credentials instead of the credentials of the presentation which may be not decoded.
+	verifiedPresentation, err := verifiable.NewPresentation(verifiable.WithCredentials(credentials...))
+	if err != nil {
+		return nil, err
+	}
+
+	verifiedPresentation.ID, verifiedPresentation.Proofs = vp.ID, vp.Proofs
+
+	return requestOpts.policy.VerifyPresentation(verifiedPresentation), nil
 }
 
 func (c *Wallet) verifiableClaimsToJWT(authToken string, claims jwtClaims, options *ProofOptions) (string, error) {
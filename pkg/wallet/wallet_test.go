@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storage/edv"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/internal/testdata"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
@@ -46,6 +47,7 @@ import (
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/pbkdf2"
+	"github.com/hyperledger/aries-framework-go/pkg/store/challenge"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -55,7 +57,6 @@ import (
 const (
 	sampleUserID            = "sample-user01"
 	sampleFakeTkn           = "fake-auth-tkn"
-	toBeImplementedErr      = "to be implemented"
 	sampleWalletErr         = "sample wallet err"
 	sampleCreatedDate       = "2020-12-25"
 	sampleChallenge         = "sample-challenge"
@@ -687,33 +688,205 @@ func TestWallet_OpenClose(t *testing.T) {
 	})
 }
 
-func TestWallet_Export(t *testing.T) {
+func TestWallet_ExportImport(t *testing.T) {
+	const orgCollection = `{
+                    "@context": ["https://w3id.org/wallet/v1"],
+                    "id": "did:example:acme123456789abcdefghi",
+                    "type": "Organization",
+                    "name": "Acme Corp.",
+                    "description" : "A software company."
+                }`
+
+	const (
+		collectionID     = "did:example:acme123456789abcdefghi"
+		exportPassphrase = "export-passphrase"
+	)
+
+	// wallet to be exported.
+	user := uuid.New().String()
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
+	require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+	walletInstance, err := New(user, mockctx)
 	require.NoError(t, err)
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
+	tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	result, err := walletInstance.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+	defer walletInstance.Close()
 
-func TestWallet_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
+	require.NoError(t, walletInstance.Add(tkn, Collection, []byte(orgCollection)))
+	require.NoError(t, walletInstance.Add(tkn, Credential, testdata.SampleUDCVC, AddByCollection(collectionID)))
+	require.NoError(t, walletInstance.Add(tkn, DIDResolutionResponse, testdata.SampleDocResolutionResponse))
+
+	var keyPairs []*KeyPair
+
+	for _, kt := range []kms.KeyType{kms.ED25519, kms.ECDSAP256TypeIEEEP1363, kms.BLS12381G2, kms.NISTP384ECDHKW} {
+		keyPair, e := walletInstance.CreateKeyPair(tkn, kt)
+		require.NoError(t, e)
+
+		keyPairs = append(keyPairs, keyPair)
+	}
+
+	t.Run("export errors", func(t *testing.T) {
+		result, err := walletInstance.Export(tkn, "")
+		require.EqualError(t, err, "passphrase is required to export wallet")
+		require.Empty(t, result)
+
+		result, err = walletInstance.Export(sampleFakeTkn, exportPassphrase)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, result)
+	})
+
+	t.Run("export error - unsupported key", func(t *testing.T) {
+		otherUser := uuid.New().String()
+		otherCtx := newMockProvider(t)
+		require.NoError(t, CreateProfile(otherUser, otherCtx, WithPassphrase(samplePassPhrase)))
+
+		otherWallet, err := New(otherUser, otherCtx)
+		require.NoError(t, err)
+
+		otherTkn, err := otherWallet.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer otherWallet.Close()
+
+		_, err = otherWallet.CreateKeyPair(otherTkn, kms.ED25519)
+		require.NoError(t, err)
+
+		keyPair, err := otherWallet.CreateKeyPair(otherTkn, kms.X25519ECDHKW)
+		require.NoError(t, err)
+
+		result, err := otherWallet.Export(otherTkn, exportPassphrase)
+		require.EqualError(t, err, fmt.Sprintf("failed to export wallet: failed to export keys: "+
+			"'%s' (unsupported key type '%s')", keyPair.KeyID, kms.X25519ECDHKWType))
+		require.Empty(t, result)
+	})
+
+	exported, err := walletInstance.Export(tkn, exportPassphrase)
 	require.NoError(t, err)
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
+	var encryptedWallet EncryptedWallet
+	require.NoError(t, json.Unmarshal(exported, &encryptedWallet))
+	require.Contains(t, encryptedWallet.Type, "EncryptedWallet")
+	require.NotEmpty(t, encryptedWallet.CredentialSubject.EncryptedWalletContents)
+	require.NotContains(t, string(exported), collectionID)
+
+	// fresh wallet to import into.
+	importUser := uuid.New().String()
+	importCtx := newMockProvider(t)
+	require.NoError(t, CreateProfile(importUser, importCtx, WithPassphrase(samplePassPhrase)))
+
+	importWallet, err := New(importUser, importCtx)
 	require.NoError(t, err)
 
-	err = walletInstance.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+	importTkn, err := importWallet.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	defer importWallet.Close()
+
+	t.Run("import errors", func(t *testing.T) {
+		err := importWallet.Import(importTkn, exportPassphrase+"wrong", exported)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt wallet contents")
+
+		err = importWallet.Import(importTkn, exportPassphrase, []byte("{}"))
+		require.EqualError(t, err, "failed to import wallet: encrypted wallet contents not found")
+
+		err = importWallet.Import(importTkn, exportPassphrase, exported, WithImportConflict("invalid"))
+		require.EqualError(t, err, "invalid import conflict resolution 'invalid'")
+
+		err = importWallet.Import(sampleFakeTkn, exportPassphrase, exported)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	})
+
+	t.Run("import error - PBES2 count exceeds limit", func(t *testing.T) {
+		withHeader := func(t *testing.T, update func(jwe map[string]interface{})) []byte {
+			t.Helper()
+
+			var ew map[string]interface{}
+			require.NoError(t, json.Unmarshal(exported, &ew))
+
+			subject, ok := ew["credentialSubject"].(map[string]interface{})
+			require.True(t, ok)
+
+			jwe, ok := subject["encryptedWalletContents"].(map[string]interface{})
+			require.True(t, ok)
+
+			update(jwe)
+
+			ewBytes, err := json.Marshal(ew)
+			require.NoError(t, err)
+
+			return ewBytes
+		}
+
+		protected := withHeader(t, func(jwe map[string]interface{}) {
+			headerBytes, err := base64.RawURLEncoding.DecodeString(jwe["protected"].(string))
+			require.NoError(t, err)
+
+			var header map[string]interface{}
+			require.NoError(t, json.Unmarshal(headerBytes, &header))
+			require.Equal(t, float64(100000), header["p2c"])
+
+			header["p2c"] = 1000000000
+
+			headerBytes, err = json.Marshal(header)
+			require.NoError(t, err)
+
+			jwe["protected"] = base64.RawURLEncoding.EncodeToString(headerBytes)
+		})
+
+		err := importWallet.Import(importTkn, exportPassphrase, protected)
+		require.EqualError(t, err,
+			"failed to import wallet: encrypted wallet contents PBES2 count 1000000000 exceeds 1000000")
+
+		unprotected := withHeader(t, func(jwe map[string]interface{}) {
+			jwe["header"] = map[string]interface{}{"p2c": 2000000}
+		})
+
+		err = importWallet.Import(importTkn, exportPassphrase, unprotected)
+		require.EqualError(t, err,
+			"failed to import wallet: encrypted wallet contents PBES2 count 2000000 exceeds 1000000")
+	})
+
+	require.NoError(t, importWallet.Import(importTkn, exportPassphrase, exported))
+
+	checkImported := func(t *testing.T) {
+		t.Helper()
+
+		credentials, err := importWallet.GetAll(importTkn, Credential, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		didResolutions, err := importWallet.GetAll(importTkn, DIDResolutionResponse)
+		require.NoError(t, err)
+		require.Len(t, didResolutions, 1)
+
+		session, err := sessionManager().getSession(importTkn)
+		require.NoError(t, err)
+
+		for _, keyPair := range keyPairs {
+			pubKey, _, err := session.KeyManager.ExportPubKeyBytes(keyPair.KeyID)
+			require.NoError(t, err)
+			require.Equal(t, keyPair.PublicKey, base64.RawURLEncoding.EncodeToString(pubKey))
+		}
+	}
+
+	checkImported(t)
+
+	t.Run("import conflicts", func(t *testing.T) {
+		err := importWallet.Import(importTkn, exportPassphrase, exported)
+		require.True(t, errors.Is(err, ErrImportConflict))
+
+		require.NoError(t, importWallet.Import(importTkn, exportPassphrase, exported,
+			WithImportConflict(ImportConflictSkip)))
+		checkImported(t)
+
+		require.NoError(t, importWallet.Import(importTkn, exportPassphrase, exported,
+			WithImportConflict(ImportConflictReplace)))
+		checkImported(t)
+	})
 }
 
 func TestWallet_Add(t *testing.T) {
@@ -2384,6 +2557,112 @@ func TestWallet_Verify(t *testing.T) {
 		require.True(t, walletInstance.Close())
 	})
 
+	t.Run("Test VC wallet verifying a credential - status check", func(t *testing.T) {
+		walletInstance, err := New(user, mockctx)
+		require.NotEmpty(t, walletInstance)
+		require.NoError(t, err)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+		require.NotEmpty(t, tkn)
+
+		statusCred := *templateCred
+		statusCred.Context = append(append([]string{}, templateCred.Context...),
+			"https://w3id.org/vc/status-list/2021/v1")
+		statusCred.Status = &verifiable.TypedID{
+			ID:   "https://example.com/status/1#94567",
+			Type: "StatusList2021Entry",
+			CustomFields: verifiable.CustomFields{
+				"statusPurpose":        "revocation",
+				"statusListIndex":      "94567",
+				"statusListCredential": "https://example.com/status/1",
+			},
+		}
+
+		statusData, err := statusCred.MarshalJSON()
+		require.NoError(t, err)
+
+		statusVC, err := walletInstance.Issue(tkn, statusData, &ProofOptions{Controller: didKey})
+		require.NoError(t, err)
+
+		rawBytes, err := statusVC.MarshalJSON()
+		require.NoError(t, err)
+
+		ok, err := walletInstance.Verify(tkn, WithRawCredentialToVerify(rawBytes),
+			WithStatusCheckToVerify(&mockStatusChecker{}))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = walletInstance.Verify(tkn, WithRawCredentialToVerify(rawBytes),
+			WithStatusCheckToVerify(&mockStatusChecker{err: errors.New("credential is revoked")}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential is revoked")
+		require.False(t, ok)
+
+		require.True(t, walletInstance.Close())
+	})
+
+	t.Run("Test VC wallet verifying a credential - verification policy", func(t *testing.T) {
+		walletInstance, err := New(user, mockctx)
+		require.NotEmpty(t, walletInstance)
+		require.NoError(t, err)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+		require.NotEmpty(t, tkn)
+
+		rawBytes, err := sampleVC.MarshalJSON()
+		require.NoError(t, err)
+
+		// sample credential expires in 2020.
+		clock := func() time.Time { return time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+		policy := &verifiable.VerificationPolicy{
+			Clock:                   clock,
+			TrustedIssuers:          []string{sampleVC.Issuer.ID},
+			CredentialProofPurposes: []string{"assertionMethod"},
+		}
+
+		report, err := walletInstance.VerifyWithReport(tkn, WithRawCredentialToVerify(rawBytes),
+			WithVerificationPolicyToVerify(policy))
+		require.NoError(t, err)
+		require.True(t, report.Passed())
+		require.Len(t, report.Checks, 5)
+
+		policy.TrustedIssuers = []string{"did:example:untrusted"}
+
+		report, err = walletInstance.VerifyWithReport(tkn, WithRawCredentialToVerify(rawBytes),
+			WithVerificationPolicyToVerify(policy))
+		require.NoError(t, err)
+		require.Len(t, report.Failed(), 1)
+		require.Equal(t, verifiable.CheckTrustedIssuer, report.Failed()[0].Check)
+
+		ok, err := walletInstance.Verify(tkn, WithRawCredentialToVerify(rawBytes),
+			WithVerificationPolicyToVerify(policy))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not trusted")
+		require.False(t, ok)
+
+		// verify presentation with policy
+		rawBytes, err = sampleVP.MarshalJSON()
+		require.NoError(t, err)
+
+		report, err = walletInstance.VerifyWithReport(tkn, WithRawPresentationToVerify(rawBytes),
+			WithVerificationPolicyToVerify(&verifiable.VerificationPolicy{
+				Clock:                     clock,
+				PresentationProofPurposes: []string{"authentication"},
+			}))
+		require.NoError(t, err)
+		require.True(t, report.Passed())
+		require.Equal(t, verifiable.CheckPassed, report.Checks[0].Result)
+
+		report, err = walletInstance.VerifyWithReport(tkn)
+		require.EqualError(t, err, "invalid verify request")
+		require.Nil(t, report)
+
+		require.True(t, walletInstance.Close())
+	})
+
 	t.Run("Test VC wallet verifying a presentation - success", func(t *testing.T) {
 		walletInstance, err := New(user, mockctx)
 		require.NotEmpty(t, walletInstance)
@@ -2403,6 +2682,77 @@ func TestWallet_Verify(t *testing.T) {
 		require.True(t, walletInstance.Close())
 	})
 
+	t.Run("Test VC wallet verifying a presentation - challenge", func(t *testing.T) {
+		walletInstance, err := New(user, mockctx)
+		require.NotEmpty(t, walletInstance)
+		require.NoError(t, err)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+		require.NotEmpty(t, tkn)
+
+		defer walletInstance.Close()
+
+		challenges, err := challenge.New(&mockprovider.Provider{StorageProviderValue: mem.NewProvider()})
+		require.NoError(t, err)
+
+		// embedded proof
+		c, err := challenges.Issue(sampleDomain)
+		require.NoError(t, err)
+
+		vp, err := walletInstance.Prove(tkn, &ProofOptions{Controller: didKey, Challenge: c.Value, Domain: c.Domain},
+			WithCredentialsToProve(sampleVC))
+		require.NoError(t, err)
+
+		rawBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		ok, err := walletInstance.Verify(tkn, WithRawPresentationToVerify(rawBytes),
+			WithChallengeVerifierToVerify(challenges))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// replayed presentation
+		ok, err = walletInstance.Verify(tkn, WithRawPresentationToVerify(rawBytes),
+			WithChallengeVerifierToVerify(challenges))
+		require.ErrorIs(t, err, challenge.ErrChallengeNotFound)
+		require.False(t, ok)
+
+		// JWT presentation
+		c, err = challenges.Issue(sampleDomain)
+		require.NoError(t, err)
+
+		vp, err = walletInstance.Prove(tkn, &ProofOptions{
+			Controller:         didKey,
+			VerificationMethod: sampleVerificationMethod,
+			ProofFormat:        ExternalJWTProofFormat,
+			Challenge:          c.Value,
+			Domain:             c.Domain,
+		}, WithCredentialsToProve(sampleJWTVC))
+		require.NoError(t, err)
+
+		ok, err = walletInstance.Verify(tkn, WithRawPresentationToVerify([]byte(vp.JWT)),
+			WithChallengeVerifierToVerify(challenges))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// replayed JWT presentation
+		ok, err = walletInstance.Verify(tkn, WithRawPresentationToVerify([]byte(vp.JWT)),
+			WithChallengeVerifierToVerify(challenges))
+		require.ErrorIs(t, err, challenge.ErrChallengeNotFound)
+		require.False(t, ok)
+
+		// presentation without challenge
+		rawBytes, err = sampleVP.MarshalJSON()
+		require.NoError(t, err)
+
+		ok, err = walletInstance.Verify(tkn, WithRawPresentationToVerify(rawBytes),
+			WithChallengeVerifierToVerify(challenges))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "challenge of proof is not defined")
+		require.False(t, ok)
+	})
+
 	t.Run("Test VC wallet verifying a credential - invalid signature", func(t *testing.T) {
 		walletInstance, err := New(user, mockctx)
 		require.NotEmpty(t, walletInstance)
@@ -2734,6 +3084,24 @@ func TestWallet_CreateKeyPair(t *testing.T) {
 		require.NotEmpty(t, keyPair.PublicKey)
 	})
 
+	t.Run("test creating key pair with metadata", func(t *testing.T) {
+		keyPair, err := wallet.CreateKeyPair(token, kms.ED25519, kms.WithPurpose("signing"),
+			kms.WithLabels(map[string]string{"connection": "alice"}))
+		require.NoError(t, err)
+		require.NotEmpty(t, keyPair)
+
+		session, err := sessionManager().getSession(token)
+		require.NoError(t, err)
+
+		km, ok := session.KeyManager.(kms.KeyLifecycleManager)
+		require.True(t, ok)
+
+		metadata, err := km.GetMetadata(keyPair.KeyID)
+		require.NoError(t, err)
+		require.Equal(t, "signing", metadata.Purpose)
+		require.Equal(t, map[string]string{"connection": "alice"}, metadata.Labels)
+	})
+
 	t.Run("test creating key pair with invalid auth", func(t *testing.T) {
 		keyPair, err := wallet.CreateKeyPair(sampleFakeTkn, kms.ED25519)
 		require.True(t, errors.Is(err, ErrInvalidAuthToken))
@@ -3135,3 +3503,11 @@ func addCredentialsToWallet(t *testing.T, walletInstance *Wallet, auth string, v
 		}
 	}
 }
+
+type mockStatusChecker struct {
+	err error
+}
+
+func (c *mockStatusChecker) CheckStatus(*verifiable.Credential) error {
+	return c.err
+}
@@ -43,6 +43,9 @@ echo "done linting component/storage/edv"
 echo "linting component/storage/leveldb.."
 ${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/leveldb ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
 echo "done linting component/storage/leveldb"
+echo "linting component/storage/sqlite.."
+${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/sqlite ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
+echo "done linting component/storage/sqlite"
 echo "linting component/storage/indexeddb.."
 ${DOCKER_CMD} run --rm -e GOPROXY=${GOPROXY} -e GOOS=js -e GOARCH=wasm -v $(pwd):/opt/workspace -w /opt/workspace/component/storage/indexeddb ${GOLANGCI_LINT_IMAGE} golangci-lint run -c ../../../.golangci.yml
 echo "done linting component/storage/indexeddb"
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"context"
+)
+
+// ContextStore is a Store whose operations accept a context for cancellation, deadlines and request scoped values.
+// Remote stores (eg EDV) use it to abort in-flight calls. An Iterator returned by QueryContext keeps using the
+// context it was created with.
+type ContextStore interface {
+	Store
+	PutContext(ctx context.Context, key string, value []byte, tags ...Tag) error
+	GetContext(ctx context.Context, key string) ([]byte, error)
+	GetTagsContext(ctx context.Context, key string) ([]Tag, error)
+	GetBulkContext(ctx context.Context, keys ...string) ([][]byte, error)
+	QueryContext(ctx context.Context, expression string, options ...QueryOption) (Iterator, error)
+	DeleteContext(ctx context.Context, key string) error
+	BatchContext(ctx context.Context, operations []Operation) error
+}
+
+// NewContextStore returns s if it supports contexts, otherwise it wraps s in an adapter checking the context before
+// each operation. The adapter can't interrupt an operation in progress.
+func NewContextStore(s Store) ContextStore {
+	if cs, ok := s.(ContextStore); ok {
+		return cs
+	}
+
+	return &contextStore{Store: s}
+}
+
+type contextStore struct {
+	Store
+}
+
+func (s *contextStore) PutContext(ctx context.Context, key string, value []byte, tags ...Tag) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.Put(key, value, tags...)
+}
+
+func (s *contextStore) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.Get(key)
+}
+
+func (s *contextStore) GetTagsContext(ctx context.Context, key string) ([]Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.GetTags(key)
+}
+
+func (s *contextStore) GetBulkContext(ctx context.Context, keys ...string) ([][]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.GetBulk(keys...)
+}
+
+func (s *contextStore) QueryContext(ctx context.Context, expression string,
+	options ...QueryOption) (Iterator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.Query(expression, options...)
+}
+
+func (s *contextStore) DeleteContext(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.Delete(key)
+}
+
+func (s *contextStore) BatchContext(ctx context.Context, operations []Operation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.Batch(operations)
+}
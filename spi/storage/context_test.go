@@ -0,0 +1,203 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestNewContextStore(t *testing.T) {
+	t.Run("wraps a store without contexts", func(t *testing.T) {
+		s := &mapStore{data: map[string][]byte{}, tags: map[string][]storage.Tag{}}
+
+		cs := storage.NewContextStore(s)
+		require.NotEqual(t, s, cs)
+
+		ctx := context.Background()
+		tags := []storage.Tag{{Name: "tagName", Value: "tagValue"}}
+
+		require.NoError(t, cs.PutContext(ctx, "key", []byte("value"), tags...))
+
+		value, err := cs.GetContext(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		storedTags, err := cs.GetTagsContext(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, tags, storedTags)
+
+		values, err := cs.GetBulkContext(ctx, "key", "other")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("value"), nil}, values)
+
+		_, err = cs.QueryContext(ctx, "tagName:tagValue")
+		require.EqualError(t, err, "query not supported")
+
+		require.NoError(t, cs.BatchContext(ctx, []storage.Operation{{Key: "other", Value: []byte("other value")}}))
+
+		value, err = cs.Get("other")
+		require.NoError(t, err)
+		require.Equal(t, []byte("other value"), value)
+
+		require.NoError(t, cs.DeleteContext(ctx, "key"))
+
+		_, err = cs.GetContext(ctx, "key")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("checks the context before each operation", func(t *testing.T) {
+		s := &mapStore{data: map[string][]byte{"key": []byte("value")}, tags: map[string][]storage.Tag{}}
+		cs := storage.NewContextStore(s)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.True(t, errors.Is(cs.PutContext(ctx, "other", []byte("value")), context.Canceled))
+
+		value, err := cs.GetContext(ctx, "key")
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, value)
+
+		tags, err := cs.GetTagsContext(ctx, "key")
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, tags)
+
+		values, err := cs.GetBulkContext(ctx, "key")
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, values)
+
+		iterator, err := cs.QueryContext(ctx, "tagName:tagValue")
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, iterator)
+
+		require.True(t, errors.Is(cs.DeleteContext(ctx, "key"), context.Canceled))
+		require.True(t, errors.Is(cs.BatchContext(ctx, []storage.Operation{{Key: "key"}}), context.Canceled))
+
+		require.Len(t, s.data, 1)
+		require.Equal(t, []byte("value"), s.data["key"])
+	})
+
+	t.Run("returns a store supporting contexts as is", func(t *testing.T) {
+		s := &contextMapStore{mapStore: &mapStore{}}
+
+		require.Equal(t, s, storage.NewContextStore(s))
+	})
+}
+
+// mapStore is a minimal storage.Store without contexts.
+type mapStore struct {
+	data map[string][]byte
+	tags map[string][]storage.Tag
+}
+
+func (s *mapStore) Put(key string, value []byte, tags ...storage.Tag) error {
+	s.data[key] = value
+	s.tags[key] = tags
+
+	return nil
+}
+
+func (s *mapStore) Get(key string) ([]byte, error) {
+	value, ok := s.data[key]
+	if !ok {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return value, nil
+}
+
+func (s *mapStore) GetTags(key string) ([]storage.Tag, error) {
+	tags, ok := s.tags[key]
+	if !ok {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return tags, nil
+}
+
+func (s *mapStore) GetBulk(keys ...string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		values[i] = s.data[key]
+	}
+
+	return values, nil
+}
+
+func (s *mapStore) Query(string, ...storage.QueryOption) (storage.Iterator, error) {
+	return nil, errors.New("query not supported")
+}
+
+func (s *mapStore) Delete(key string) error {
+	delete(s.data, key)
+	delete(s.tags, key)
+
+	return nil
+}
+
+func (s *mapStore) Batch(operations []storage.Operation) error {
+	for _, op := range operations {
+		if op.Value == nil {
+			delete(s.data, op.Key)
+
+			continue
+		}
+
+		s.data[op.Key] = op.Value
+	}
+
+	return nil
+}
+
+func (s *mapStore) Flush() error {
+	return nil
+}
+
+func (s *mapStore) Close() error {
+	return nil
+}
+
+// contextMapStore is a storage.ContextStore.
+type contextMapStore struct {
+	*mapStore
+}
+
+func (s *contextMapStore) PutContext(_ context.Context, key string, value []byte, tags ...storage.Tag) error {
+	return s.Put(key, value, tags...)
+}
+
+func (s *contextMapStore) GetContext(_ context.Context, key string) ([]byte, error) {
+	return s.Get(key)
+}
+
+func (s *contextMapStore) GetTagsContext(_ context.Context, key string) ([]storage.Tag, error) {
+	return s.GetTags(key)
+}
+
+func (s *contextMapStore) GetBulkContext(_ context.Context, keys ...string) ([][]byte, error) {
+	return s.GetBulk(keys...)
+}
+
+func (s *contextMapStore) QueryContext(_ context.Context, expression string,
+	options ...storage.QueryOption) (storage.Iterator, error) {
+	return s.Query(expression, options...)
+}
+
+func (s *contextMapStore) DeleteContext(_ context.Context, key string) error {
+	return s.Delete(key)
+}
+
+func (s *contextMapStore) BatchContext(_ context.Context, operations []storage.Operation) error {
+	return s.Batch(operations)
+}